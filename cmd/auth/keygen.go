@@ -0,0 +1,64 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/jwt"
+	"github.com/skygeario/skygear-server/pkg/core/uuid"
+)
+
+const generateOIDCKeyCommand = "generate-oidc-key"
+
+// generateOIDCKey generates a new OIDC signing key pair, and prints it as a
+// published key to be appended to oidc.keys of the tenant config.
+//
+// To rotate keys, stage the new key as published first, so that clients
+// caching JWKS can learn it. Then make it active and the old key published.
+// Finally retire the old key after tokens signed by it are expired.
+func generateOIDCKey(args []string) error {
+	flags := flag.NewFlagSet(generateOIDCKeyCommand, flag.ContinueOnError)
+	alg := flags.String("alg", jwt.AlgorithmRS256, "signing algorithm, one of "+strings.Join(jwt.SupportedSigningAlgorithms, ", "))
+	kid := flags.String("kid", "", "key ID, random if not specified")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *kid == "" {
+		*kid = uuid.New()
+	}
+
+	privateKey, publicKey, err := jwt.GenerateKeyPair(*alg)
+	if err != nil {
+		return err
+	}
+
+	out, err := yaml.Marshal([]config.OIDCSigningKeyConfiguration{{
+		KID:        *kid,
+		State:      config.OIDCSigningKeyStatePublished,
+		PublicKey:  publicKey,
+		PrivateKey: privateKey,
+	}})
+	if err != nil {
+		return err
+	}
+
+	_, err = os.Stdout.Write(out)
+	return err
+}
+
+func runCommand(args []string) (handled bool) {
+	if len(args) == 0 || args[0] != generateOIDCKeyCommand {
+		return false
+	}
+
+	if err := generateOIDCKey(args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	return true
+}
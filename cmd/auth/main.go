@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"net/http"
 	"os"
 
@@ -11,8 +12,11 @@ import (
 	"github.com/skygeario/skygear-server/pkg/auth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/loginid"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/webapp"
+	adminhandler "github.com/skygeario/skygear-server/pkg/auth/handler/admin"
 	oauthhandler "github.com/skygeario/skygear-server/pkg/auth/handler/oauth"
+	samlhandler "github.com/skygeario/skygear-server/pkg/auth/handler/saml"
 	"github.com/skygeario/skygear-server/pkg/auth/handler/session"
+	userhandler "github.com/skygeario/skygear-server/pkg/auth/handler/user"
 	webapphandler "github.com/skygeario/skygear-server/pkg/auth/handler/webapp"
 	"github.com/skygeario/skygear-server/pkg/auth/task"
 	"github.com/skygeario/skygear-server/pkg/core/async"
@@ -80,6 +84,10 @@ type TemplateConfiguration struct {
 		Single sign-on
 */
 func main() {
+	if runCommand(os.Args[1:]) {
+		return
+	}
+
 	// logging initialization
 	logging.SetModule("auth")
 	loggerFactory := logging.NewFactory(
@@ -102,6 +110,7 @@ func main() {
 	validator := validation.NewValidator("http://v2.skgyear.io")
 	validator.AddSchemaFragments(
 		oauthhandler.ChallengeRequestSchema,
+		adminhandler.SetUserDisabledRequestSchema,
 	)
 
 	dbPool := db.NewPool()
@@ -138,6 +147,14 @@ func main() {
 	task.AttachVerifyCodeSendTask(asyncTaskExecutor, authDependency)
 	task.AttachPwHousekeeperTask(asyncTaskExecutor, authDependency)
 	task.AttachSendMessagesTask(asyncTaskExecutor, authDependency)
+	task.AttachSendLogoutNotificationsTask(asyncTaskExecutor, authDependency)
+	task.AttachDeliverEventsTask(asyncTaskExecutor, authDependency)
+
+	deliverEventsPoller := &task.DeliverEventsPoller{
+		Executor: asyncTaskExecutor,
+		Interval: task.DeliverEventsPollInterval,
+	}
+	go deliverEventsPoller.Run(context.Background())
 
 	var router *mux.Router
 	var rootRouter *mux.Router
@@ -154,6 +171,9 @@ func main() {
 			logger.WithError(err).Fatal("Cannot parse standalone config")
 		}
 
+		// Deliver events persisted before the server started.
+		deliverEventsPoller.Track(tenantConfig)
+
 		router = server.NewRouter()
 		router.HandleFunc("/healthz", server.HealthCheckHandler)
 
@@ -171,6 +191,7 @@ func main() {
 		rootRouter.Use(middleware.ReadTenantConfigMiddleware{}.Handle)
 	}
 
+	rootRouter.Use(deliverEventsPoller.Handle)
 	rootRouter.Use(middleware.DBMiddleware{Pool: dbPool}.Handle)
 	rootRouter.Use(middleware.RedisMiddleware{Pool: redisPool}.Handle)
 	rootRouter.Use(auth.MakeMiddleware(authDependency, auth.NewSessionMiddleware))
@@ -228,11 +249,16 @@ func main() {
 	webappAuthenticatedRouter.Use(webapp.RequireAuthenticatedMiddleware{}.Handle)
 	webapphandler.AttachSettingsHandler(webappAuthenticatedRouter, authDependency)
 	webapphandler.AttachSettingsIdentityHandler(webappAuthenticatedRouter, authDependency)
+	webapphandler.AttachSettingsAuthorizationsHandler(webappAuthenticatedRouter, authDependency)
 	webapphandler.AttachLogoutHandler(webappAuthenticatedRouter, authDependency)
+	webapphandler.AttachDeviceHandler(webappAuthenticatedRouter, authDependency)
+	webapphandler.AttachConsentHandler(webappAuthenticatedRouter, authDependency)
 
 	webappSSOCallbackRouter := rootRouter.NewRoute().Subrouter()
 	webappSSOCallbackRouter.Use(webapp.PostNoCacheMiddleware)
 	webapphandler.AttachSSOCallbackHandler(webappSSOCallbackRouter, authDependency)
+	webapphandler.AttachSAMLACSHandler(webappSSOCallbackRouter, authDependency)
+	webapphandler.AttachSAMLMetadataHandler(webappSSOCallbackRouter, authDependency)
 
 	if configuration.StaticAssetDir != "" {
 		rootRouter.PathPrefix("/static/").Handler(http.StripPrefix("/static/", http.FileServer(http.Dir(configuration.StaticAssetDir))))
@@ -243,10 +269,33 @@ func main() {
 	oauthhandler.AttachAuthorizeHandler(oauthRouter, authDependency)
 	oauthhandler.AttachTokenHandler(oauthRouter, authDependency)
 	oauthhandler.AttachRevokeHandler(oauthRouter, authDependency)
+	oauthhandler.AttachIntrospectHandler(oauthRouter, authDependency)
+	oauthhandler.AttachDeviceAuthorizationHandler(oauthRouter, authDependency)
+	oauthhandler.AttachPushedAuthorizationRequestHandler(oauthRouter, authDependency)
+	oauthhandler.AttachRegisterHandler(oauthRouter, authDependency)
 	oauthhandler.AttachUserInfoHandler(oauthRouter, authDependency)
 	oauthhandler.AttachEndSessionHandler(oauthRouter, authDependency)
 	oauthhandler.AttachChallengeHandler(oauthRouter, authDependency)
 
+	samlhandler.AttachMetadataHandler(oauthRouter, authDependency)
+	samlhandler.AttachSSOHandler(oauthRouter, authDependency)
+	samlhandler.AttachSLOHandler(oauthRouter, authDependency)
+
+	adminhandler.AttachListUsersHandler(rootRouter, authDependency)
+	adminhandler.AttachGetUserHandler(rootRouter, authDependency)
+	adminhandler.AttachSetUserDisabledHandler(rootRouter, authDependency)
+	adminhandler.AttachDeleteUserHandler(rootRouter, authDependency)
+	adminhandler.AttachListHookDeliveryAttemptsHandler(rootRouter, authDependency)
+	adminhandler.AttachRedeliverEventHandler(rootRouter, authDependency)
+	adminhandler.AttachListUserSessionsHandler(rootRouter, authDependency)
+	adminhandler.AttachRevokeUserSessionHandler(rootRouter, authDependency)
+	adminhandler.AttachRevokeUserSessionsHandler(rootRouter, authDependency)
+
+	userhandler.AttachDeleteUserHandler(oauthRouter, authDependency)
+	session.AttachListSessionsHandler(oauthRouter, authDependency)
+	session.AttachRevokeSessionHandler(oauthRouter, authDependency)
+	session.AttachRevokeOtherSessionsHandler(oauthRouter, authDependency)
+
 	srv := &http.Server{
 		Addr:    configuration.Host,
 		Handler: router,
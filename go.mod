@@ -9,10 +9,12 @@ require (
 	github.com/FZambia/sentinel v1.1.0
 	github.com/Masterminds/squirrel v1.1.0
 	github.com/aws/aws-sdk-go v1.25.6
+	github.com/beevik/etree v1.1.0
 	github.com/davidbyttow/govips v0.0.0-20190304175058-d272f04c0fea
 	github.com/dgrijalva/jwt-go v3.2.0+incompatible
 	github.com/getsentry/sentry-go v0.3.0
 	github.com/go-gomail/gomail v0.0.0-20150902115704-41f357289737
+	github.com/go-ldap/ldap/v3 v3.4.1
 	github.com/go-sql-driver/mysql v1.4.1 // indirect
 	github.com/golang/mock v1.4.0
 	github.com/gomodule/redigo v2.0.0+incompatible
@@ -37,6 +39,7 @@ require (
 	github.com/nyaruka/phonenumbers v1.0.45
 	github.com/philhofer/fwd v1.0.0 // indirect
 	github.com/pquerna/otp v1.2.0
+	github.com/russellhaering/goxmldsig v1.4.0
 	github.com/sfreiberg/gotwilio v0.0.0-20181012193634-a13e5b0d458a
 	github.com/sirupsen/logrus v1.4.2
 	github.com/skygeario/go-confusable-homoglyphs v0.0.0-20191212061114-e2b2a60df110
@@ -47,7 +50,7 @@ require (
 	github.com/ttacon/chalk v0.0.0-20160626202418-22c06c80ed31 // indirect
 	github.com/ua-parser/uap-go v0.0.0-20190826212731-daf92ba38329
 	github.com/xeipuuv/gojsonschema v1.2.0
-	golang.org/x/crypto v0.0.0-20191011191535-87dc89f01550
+	golang.org/x/crypto v0.0.0-20200604202706-70a84ac30bf9
 	golang.org/x/net v0.0.0-20200222125558-5a598a2470a0
 	golang.org/x/text v0.3.2
 	golang.org/x/tools v0.0.0-20200224181240-023911ca70b2
@@ -0,0 +1,13 @@
+CREATE TABLE _auth_event (
+  seq BIGINT PRIMARY KEY,
+  app_id TEXT NOT NULL,
+  type TEXT NOT NULL,
+  data JSONB NOT NULL,
+  status TEXT NOT NULL,
+  attempts INTEGER NOT NULL,
+  created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+  next_attempt_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+  last_attempt_at TIMESTAMP WITHOUT TIME ZONE,
+  last_error TEXT NOT NULL
+);
+CREATE INDEX _auth_event_delivery_idx ON _auth_event(app_id, status, seq);
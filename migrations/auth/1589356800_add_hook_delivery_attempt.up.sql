@@ -0,0 +1,14 @@
+CREATE TABLE _auth_hook_delivery_attempt (
+  id TEXT PRIMARY KEY,
+  app_id TEXT NOT NULL,
+  event_id TEXT NOT NULL,
+  event_type TEXT NOT NULL,
+  hook_url TEXT NOT NULL,
+  status_code INTEGER NOT NULL,
+  latency_ms BIGINT NOT NULL,
+  response_body TEXT NOT NULL,
+  error TEXT NOT NULL,
+  created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL
+);
+CREATE INDEX _auth_hook_delivery_attempt_event_idx ON _auth_hook_delivery_attempt(app_id, event_id);
+CREATE INDEX _auth_hook_delivery_attempt_hook_idx ON _auth_hook_delivery_attempt(app_id, hook_url, created_at);
@@ -0,0 +1,8 @@
+CREATE TABLE _auth_oauth_client (
+  id TEXT PRIMARY KEY,
+  app_id TEXT NOT NULL,
+  created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+  updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+  registration_access_token_hash TEXT NOT NULL,
+  metadata JSONB NOT NULL
+);
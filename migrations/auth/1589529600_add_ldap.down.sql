@@ -0,0 +1,2 @@
+DROP TABLE _auth_authenticator_ldap;
+DROP TABLE _auth_identity_ldap;
@@ -0,0 +1,18 @@
+CREATE TABLE _auth_identity_ldap (
+  identity_id TEXT NOT NULL REFERENCES _auth_identity(id) PRIMARY KEY,
+  app_id TEXT NOT NULL,
+  subject_id TEXT NOT NULL,
+  username TEXT NOT NULL,
+  dn TEXT NOT NULL,
+  claims JSONB NOT NULL,
+  attributes JSONB NOT NULL,
+  created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+  updated_at TIMESTAMP WITHOUT TIME ZONE NOT NULL,
+  UNIQUE (app_id, subject_id)
+);
+
+CREATE TABLE _auth_authenticator_ldap (
+  id TEXT PRIMARY KEY REFERENCES _auth_authenticator(id),
+  app_id TEXT NOT NULL,
+  created_at TIMESTAMP WITHOUT TIME ZONE NOT NULL
+);
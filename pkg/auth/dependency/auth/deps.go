@@ -2,10 +2,32 @@ package auth
 
 import (
 	"github.com/google/wire"
+
+	"github.com/skygeario/skygear-server/pkg/core/logging"
 )
 
+func ProvideSessionManager(
+	users UserProvider,
+	hooks HookProvider,
+	idpSessions IDPSessionManager,
+	accessTokenSessions AccessTokenSessionManager,
+	accessEvents AccessEventStore,
+	logoutNotifier LogoutNotifier,
+	lf logging.Factory,
+) *SessionManager {
+	return &SessionManager{
+		Users:               users,
+		Hooks:               hooks,
+		IDPSessions:         idpSessions,
+		AccessTokenSessions: accessTokenSessions,
+		AccessEvents:        accessEvents,
+		LogoutNotifier:      logoutNotifier,
+		Logger:              lf.NewLogger("session-manager"),
+	}
+}
+
 var DependencySet = wire.NewSet(
 	wire.Struct(new(Middleware), "*"),
 	wire.Struct(new(AccessEventProvider), "*"),
-	wire.Struct(new(SessionManager), "*"),
+	ProvideSessionManager,
 )
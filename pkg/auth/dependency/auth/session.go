@@ -10,6 +10,7 @@ import (
 const (
 	SessionTypeIdentityProvider authn.SessionType = "idp"
 	SessionTypeOfflineGrant     authn.SessionType = "offline_grant"
+	SessionTypeClient           authn.SessionType = "client"
 )
 
 // nolint: golint
@@ -5,21 +5,31 @@ import (
 	"net/http"
 	"sort"
 
+	"github.com/sirupsen/logrus"
+
 	"github.com/skygeario/skygear-server/pkg/auth/event"
 	"github.com/skygeario/skygear-server/pkg/auth/model"
 	corehttp "github.com/skygeario/skygear-server/pkg/core/http"
+	"github.com/skygeario/skygear-server/pkg/core/skyerr"
 )
 
 type HookProvider interface {
 	DispatchEvent(payload event.Payload, user *model.User) error
 }
 
-var ErrSessionNotFound = errors.New("session not found")
+var SessionNotFound = skyerr.NotFound.WithReason("SessionNotFound")
+
+var ErrSessionNotFound = SessionNotFound.New("session not found")
 
 type UserProvider interface {
 	Get(id string) (*model.User, error)
 }
 
+// LogoutNotifier notifies clients tied to the session when it is ended.
+type LogoutNotifier interface {
+	NotifyLogout(session AuthSession) error
+}
+
 type SessionManagementProvider interface {
 	CookieConfig() *corehttp.CookieConfiguration
 	Get(id string) (AuthSession, error)
@@ -36,6 +46,9 @@ type SessionManager struct {
 	Hooks               HookProvider
 	IDPSessions         IDPSessionManager
 	AccessTokenSessions AccessTokenSessionManager
+	AccessEvents        AccessEventStore
+	LogoutNotifier      LogoutNotifier
+	Logger              *logrus.Entry
 }
 
 func (m *SessionManager) resolveManagementProvider(session AuthSession) SessionManagementProvider {
@@ -74,9 +87,25 @@ func (m *SessionManager) invalidate(session AuthSession, reason SessionDeleteRea
 		return nil, err
 	}
 
+	err = m.AccessEvents.ResetEventStream(session)
+	if err != nil {
+		return nil, err
+	}
+
+	m.notifyLogout(session)
+
 	return provider, nil
 }
 
+// notifyLogout notifies clients of the deleted session. The session is
+// already deleted; failing to notify clients should not fail the logout.
+func (m *SessionManager) notifyLogout(session AuthSession) {
+	err := m.LogoutNotifier.NotifyLogout(session)
+	if err != nil {
+		m.Logger.WithError(err).Error("failed to notify logout")
+	}
+}
+
 func (m *SessionManager) Logout(session AuthSession, rw http.ResponseWriter) error {
 	provider, err := m.invalidate(session, SessionDeleteReasonLogout)
 	if err != nil {
@@ -99,6 +128,54 @@ func (m *SessionManager) Revoke(session AuthSession) error {
 	return nil
 }
 
+// RevokeAll revokes all sessions of the user, except the session with
+// exceptID if it is not empty.
+func (m *SessionManager) RevokeAll(userID string, exceptID string) error {
+	sessions, err := m.List(userID)
+	if err != nil {
+		return err
+	}
+
+	for _, session := range sessions {
+		if session.SessionID() == exceptID {
+			continue
+		}
+		err = m.Revoke(session)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteAll deletes all sessions of the user. Unlike Revoke, no
+// session_delete event is dispatched; it is intended for user deletion.
+// Clients are still notified of the logout.
+func (m *SessionManager) DeleteAll(userID string) error {
+	sessions, err := m.List(userID)
+	if err != nil {
+		return err
+	}
+
+	for _, session := range sessions {
+		provider := m.resolveManagementProvider(session)
+		err = provider.Delete(session)
+		if err != nil {
+			return err
+		}
+
+		err = m.AccessEvents.ResetEventStream(session)
+		if err != nil {
+			return err
+		}
+
+		m.notifyLogout(session)
+	}
+
+	return nil
+}
+
 func (m *SessionManager) Get(id string) (AuthSession, error) {
 	session, err := m.IDPSessions.Get(id)
 	if err != nil && !errors.Is(err, ErrSessionNotFound) {
@@ -117,6 +194,20 @@ func (m *SessionManager) Get(id string) (AuthSession, error) {
 	return nil, ErrSessionNotFound
 }
 
+// GetByUser returns the session with id, which must belong to the user.
+func (m *SessionManager) GetByUser(userID string, id string) (AuthSession, error) {
+	session, err := m.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.AuthnAttrs().UserID != userID {
+		return nil, ErrSessionNotFound
+	}
+
+	return session, nil
+}
+
 func (m *SessionManager) Update(session AuthSession) error {
 	provider := m.resolveManagementProvider(session)
 	err := provider.Update(session)
@@ -0,0 +1,230 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/auth/event"
+	"github.com/skygeario/skygear-server/pkg/auth/model"
+	"github.com/skygeario/skygear-server/pkg/core/authn"
+	corehttp "github.com/skygeario/skygear-server/pkg/core/http"
+)
+
+type mockSession struct {
+	ID        string
+	Type      authn.SessionType
+	Attrs     authn.Attrs
+	CreatedAt time.Time
+}
+
+func (s *mockSession) SessionID() string              { return s.ID }
+func (s *mockSession) SessionType() authn.SessionType { return s.Type }
+func (s *mockSession) AuthnAttrs() *authn.Attrs       { return &s.Attrs }
+func (s *mockSession) GetClientID() string            { return "" }
+func (s *mockSession) GetCreatedAt() time.Time        { return s.CreatedAt }
+func (s *mockSession) GetAccessInfo() *AccessInfo     { return &AccessInfo{} }
+func (s *mockSession) ToAPIModel() *model.Session {
+	return &model.Session{ID: s.ID, Type: s.Type}
+}
+
+type mockSessionProvider struct {
+	Sessions []AuthSession
+	Deleted  []string
+}
+
+func (p *mockSessionProvider) CookieConfig() *corehttp.CookieConfiguration { return nil }
+
+func (p *mockSessionProvider) Get(id string) (AuthSession, error) {
+	for _, s := range p.Sessions {
+		if s.SessionID() == id {
+			return s, nil
+		}
+	}
+	return nil, ErrSessionNotFound
+}
+
+func (p *mockSessionProvider) Update(AuthSession) error { return nil }
+
+func (p *mockSessionProvider) Delete(s AuthSession) error {
+	p.Deleted = append(p.Deleted, s.SessionID())
+	return nil
+}
+
+func (p *mockSessionProvider) List(userID string) ([]AuthSession, error) {
+	var sessions []AuthSession
+	for _, s := range p.Sessions {
+		if s.AuthnAttrs().UserID == userID {
+			sessions = append(sessions, s)
+		}
+	}
+	return sessions, nil
+}
+
+type mockUserProvider struct{}
+
+func (mockUserProvider) Get(id string) (*model.User, error) {
+	return &model.User{ID: id}, nil
+}
+
+type mockHookProvider struct {
+	DispatchedEvents []event.Payload
+}
+
+func (p *mockHookProvider) DispatchEvent(payload event.Payload, user *model.User) error {
+	p.DispatchedEvents = append(p.DispatchedEvents, payload)
+	return nil
+}
+
+type mockAccessEventStore struct {
+	Reset []string
+}
+
+func (s *mockAccessEventStore) AppendAccessEvent(AuthSession, *AccessEvent) error { return nil }
+
+func (s *mockAccessEventStore) ResetEventStream(session AuthSession) error {
+	s.Reset = append(s.Reset, session.SessionID())
+	return nil
+}
+
+type mockLogoutNotifier struct {
+	Notified []string
+	Err      error
+}
+
+func (n *mockLogoutNotifier) NotifyLogout(session AuthSession) error {
+	n.Notified = append(n.Notified, session.SessionID())
+	return n.Err
+}
+
+func TestSessionManager(t *testing.T) {
+	Convey("SessionManager", t, func() {
+		now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		idpSessions := &mockSessionProvider{
+			Sessions: []AuthSession{
+				&mockSession{
+					ID:        "idp-1",
+					Type:      SessionTypeIdentityProvider,
+					Attrs:     authn.Attrs{UserID: "user-id"},
+					CreatedAt: now,
+				},
+				&mockSession{
+					ID:        "idp-2",
+					Type:      SessionTypeIdentityProvider,
+					Attrs:     authn.Attrs{UserID: "user-id"},
+					CreatedAt: now.Add(time.Minute),
+				},
+				&mockSession{
+					ID:        "idp-other",
+					Type:      SessionTypeIdentityProvider,
+					Attrs:     authn.Attrs{UserID: "other-user-id"},
+					CreatedAt: now,
+				},
+			},
+		}
+		offlineGrants := &mockSessionProvider{
+			Sessions: []AuthSession{
+				&mockSession{
+					ID:        "grant-1",
+					Type:      SessionTypeOfflineGrant,
+					Attrs:     authn.Attrs{UserID: "user-id"},
+					CreatedAt: now.Add(2 * time.Minute),
+				},
+			},
+		}
+		hooks := &mockHookProvider{}
+		accessEvents := &mockAccessEventStore{}
+		logoutNotifier := &mockLogoutNotifier{}
+
+		m := &SessionManager{
+			Users:               mockUserProvider{},
+			Hooks:               hooks,
+			IDPSessions:         idpSessions,
+			AccessTokenSessions: offlineGrants,
+			AccessEvents:        accessEvents,
+			LogoutNotifier:      logoutNotifier,
+			Logger:              logrus.NewEntry(logrus.New()),
+		}
+
+		Convey("should list sessions of user in descending creation order", func() {
+			sessions, err := m.List("user-id")
+			So(err, ShouldBeNil)
+
+			var ids []string
+			for _, s := range sessions {
+				ids = append(ids, s.SessionID())
+			}
+			So(ids, ShouldResemble, []string{"grant-1", "idp-2", "idp-1"})
+		})
+
+		Convey("should get session of user only", func() {
+			s, err := m.GetByUser("user-id", "grant-1")
+			So(err, ShouldBeNil)
+			So(s.SessionID(), ShouldEqual, "grant-1")
+
+			_, err = m.GetByUser("user-id", "idp-other")
+			So(err, ShouldBeError, ErrSessionNotFound)
+
+			_, err = m.GetByUser("user-id", "non-existent")
+			So(err, ShouldBeError, ErrSessionNotFound)
+		})
+
+		Convey("should revoke session", func() {
+			s, err := m.GetByUser("user-id", "idp-1")
+			So(err, ShouldBeNil)
+
+			err = m.Revoke(s)
+			So(err, ShouldBeNil)
+			So(idpSessions.Deleted, ShouldResemble, []string{"idp-1"})
+			So(accessEvents.Reset, ShouldResemble, []string{"idp-1"})
+			So(logoutNotifier.Notified, ShouldResemble, []string{"idp-1"})
+			So(hooks.DispatchedEvents, ShouldResemble, []event.Payload{
+				event.SessionDeleteEvent{
+					Reason:  string(SessionDeleteReasonRevoke),
+					User:    model.User{ID: "user-id"},
+					Session: model.Session{ID: "idp-1", Type: SessionTypeIdentityProvider},
+				},
+			})
+		})
+
+		Convey("should revoke session if clients cannot be notified", func() {
+			logoutNotifier.Err = errors.New("cannot notify")
+			s, err := m.GetByUser("user-id", "idp-1")
+			So(err, ShouldBeNil)
+
+			err = m.Revoke(s)
+			So(err, ShouldBeNil)
+			So(idpSessions.Deleted, ShouldResemble, []string{"idp-1"})
+			So(logoutNotifier.Notified, ShouldResemble, []string{"idp-1"})
+		})
+
+		Convey("should revoke all sessions except specified one", func() {
+			err := m.RevokeAll("user-id", "idp-2")
+			So(err, ShouldBeNil)
+			So(idpSessions.Deleted, ShouldResemble, []string{"idp-1"})
+			So(offlineGrants.Deleted, ShouldResemble, []string{"grant-1"})
+			So(accessEvents.Reset, ShouldResemble, []string{"grant-1", "idp-1"})
+			So(hooks.DispatchedEvents, ShouldHaveLength, 2)
+		})
+
+		Convey("should revoke all sessions", func() {
+			err := m.RevokeAll("user-id", "")
+			So(err, ShouldBeNil)
+			So(idpSessions.Deleted, ShouldResemble, []string{"idp-2", "idp-1"})
+			So(offlineGrants.Deleted, ShouldResemble, []string{"grant-1"})
+			So(hooks.DispatchedEvents, ShouldHaveLength, 3)
+		})
+
+		Convey("should delete all sessions and notify clients", func() {
+			err := m.DeleteAll("user-id")
+			So(err, ShouldBeNil)
+			So(idpSessions.Deleted, ShouldResemble, []string{"idp-2", "idp-1"})
+			So(offlineGrants.Deleted, ShouldResemble, []string{"grant-1"})
+			So(logoutNotifier.Notified, ShouldResemble, []string{"grant-1", "idp-2", "idp-1"})
+			So(hooks.DispatchedEvents, ShouldBeEmpty)
+		})
+	})
+}
@@ -36,6 +36,9 @@ func (m *Middleware) Handle(next http.Handler) http.Handler {
 			r = r.WithContext(authn.WithInvalidAuthn(r.Context()))
 		} else if err != nil {
 			panic(err)
+		} else if s != nil && u == nil {
+			// Client session is not associated with any user.
+			r = r.WithContext(authn.WithAuthn(r.Context(), s, nil))
 		} else if s != nil {
 			r = r.WithContext(authn.WithAuthn(r.Context(), s, u.ToUserInfo(m.Time.NowUTC())))
 		}
@@ -55,6 +58,10 @@ func (m *Middleware) resolve(rw http.ResponseWriter, r *http.Request) (session A
 		if session == nil {
 			return
 		}
+		// Client session has no user and access event stream.
+		if session.SessionType() == SessionTypeClient {
+			return
+		}
 		user = &authinfo.AuthInfo{}
 		if err = m.AuthInfoStore.GetAuth(session.AuthnAttrs().UserID, user); err != nil {
 			return
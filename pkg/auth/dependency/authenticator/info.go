@@ -1,6 +1,9 @@
 package authenticator
 
-import "github.com/skygeario/skygear-server/pkg/core/authn"
+import (
+	"github.com/skygeario/skygear-server/pkg/auth/model"
+	"github.com/skygeario/skygear-server/pkg/core/authn"
+)
 
 type Info struct {
 	ID            string                  `json:"id"`
@@ -17,3 +20,11 @@ func (i *Info) ToSpec() Spec {
 func (i *Info) ToRef() Ref {
 	return Ref{ID: i.ID, Type: i.Type}
 }
+
+func (i *Info) ToModel() model.Authenticator {
+	return model.Authenticator{
+		ID:    i.ID,
+		Type:  i.Type,
+		Props: i.Props,
+	}
+}
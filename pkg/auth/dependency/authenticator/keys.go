@@ -28,4 +28,7 @@ const (
 	AuthenticatorStateOOBOTPGenerateTime string = "https://auth.skygear.io/claims/oob_otp/generate_time"
 	// AuthenticatorStateOOBOTPTriggerTime is a claim with string value for OOB last trigger time of current interaction.
 	AuthenticatorStateOOBOTPTriggerTime string = "https://auth.skygear.io/claims/oob_otp/trigger_time"
+
+	// AuthenticatorStateLDAPDN is a claim with string value for the DN to bind of current interaction.
+	AuthenticatorStateLDAPDN string = "https://auth.skygear.io/claims/ldap/dn"
 )
@@ -0,0 +1,13 @@
+package ldap
+
+import (
+	"time"
+)
+
+// Authenticator records that the user authenticates by binding to the
+// directory. The password is never stored.
+type Authenticator struct {
+	ID        string
+	UserID    string
+	CreatedAt time.Time
+}
@@ -0,0 +1,78 @@
+package ldap
+
+import (
+	"errors"
+	"sort"
+
+	goldap "github.com/go-ldap/ldap/v3"
+
+	"github.com/skygeario/skygear-server/pkg/core/time"
+	"github.com/skygeario/skygear-server/pkg/core/uuid"
+)
+
+var ErrInvalidCredentials = errors.New("invalid LDAP credentials")
+
+type Directory interface {
+	Bind(dn string, password string) error
+}
+
+type Provider struct {
+	Store     *Store
+	Directory Directory
+	Time      time.Provider
+}
+
+func (p *Provider) Get(userID string, id string) (*Authenticator, error) {
+	return p.Store.Get(userID, id)
+}
+
+func (p *Provider) Delete(a *Authenticator) error {
+	return p.Store.Delete(a.ID)
+}
+
+func (p *Provider) List(userID string) ([]*Authenticator, error) {
+	authenticators, err := p.Store.List(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sortAuthenticators(authenticators)
+	return authenticators, nil
+}
+
+func (p *Provider) New(userID string) *Authenticator {
+	a := &Authenticator{
+		ID:     uuid.New(),
+		UserID: userID,
+	}
+	return a
+}
+
+func (p *Provider) Create(a *Authenticator) error {
+	now := p.Time.NowUTC()
+	a.CreatedAt = now
+
+	return p.Store.Create(a)
+}
+
+// Authenticate verifies password by binding to the directory as dn.
+// Errors other than ErrInvalidCredentials indicate the directory is
+// unavailable.
+func (p *Provider) Authenticate(dn string, password string) error {
+	err := p.Directory.Bind(dn, password)
+	// Empty password is rejected by the client, since the server would
+	// treat it as unauthenticated bind.
+	if goldap.IsErrorWithCode(err, goldap.LDAPResultInvalidCredentials) ||
+		goldap.IsErrorWithCode(err, goldap.ErrorEmptyPassword) {
+		return ErrInvalidCredentials
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+func sortAuthenticators(as []*Authenticator) {
+	sort.Slice(as, func(i, j int) bool {
+		return as[i].CreatedAt.Before(as[j].CreatedAt)
+	})
+}
@@ -28,6 +28,9 @@ type HistoryStore interface {
 	// RemovePasswordHistory removes old password history.
 	// It uses GetPasswordHistory to query active history and then purge old history.
 	RemovePasswordHistory(userID string, historySize, historyDays int) error
+
+	// ResetPasswordHistory removes all password history of the given user.
+	ResetPasswordHistory(userID string) error
 }
 
 type HistoryStoreImpl struct {
@@ -113,6 +116,15 @@ func (p *HistoryStoreImpl) RemovePasswordHistory(userID string, historySize, his
 	return err
 }
 
+func (p *HistoryStoreImpl) ResetPasswordHistory(userID string) error {
+	builder := p.sqlBuilder.Tenant().
+		Delete(p.sqlBuilder.FullTableName("password_history")).
+		Where("user_id = ?", userID)
+
+	_, err := p.sqlExecutor.ExecWith(builder)
+	return err
+}
+
 func (p *HistoryStoreImpl) basePasswordHistoryBuilder(userID string) db.SelectBuilder {
 	return p.sqlBuilder.Tenant().
 		Select("id", "user_id", "password", "logged_at").
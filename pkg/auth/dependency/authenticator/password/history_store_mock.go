@@ -58,3 +58,8 @@ func (m *mockPasswordHistoryStoreImpl) RemovePasswordHistory(userID string, hist
 	m.Data[userID] = uph
 	return nil
 }
+
+func (m *mockPasswordHistoryStoreImpl) ResetPasswordHistory(userID string) error {
+	delete(m.Data, userID)
+	return nil
+}
@@ -28,6 +28,10 @@ func (p *Provider) Delete(a *Authenticator) error {
 	return p.Store.Delete(a.ID)
 }
 
+func (p *Provider) DeleteHistory(userID string) error {
+	return p.PasswordHistory.ResetPasswordHistory(userID)
+}
+
 func (p *Provider) List(userID string) ([]*Authenticator, error) {
 	authenticators, err := p.Store.List(userID)
 	if err != nil {
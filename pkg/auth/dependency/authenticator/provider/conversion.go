@@ -3,6 +3,7 @@ package provider
 import (
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/bearertoken"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/ldap"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/oob"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/password"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/recoverycode"
@@ -112,3 +113,20 @@ func recoveryCodeFromAuthenticatorInfo(userID string, a *authenticator.Info) *re
 		Code:   a.Secret,
 	}
 }
+
+func ldapToAuthenticatorInfo(l *ldap.Authenticator) *authenticator.Info {
+	return &authenticator.Info{
+		Type:          authn.AuthenticatorTypeLDAP,
+		ID:            l.ID,
+		Secret:        "",
+		Props:         map[string]interface{}{},
+		Authenticator: l,
+	}
+}
+
+func ldapFromAuthenticatorInfo(userID string, a *authenticator.Info) *ldap.Authenticator {
+	return &ldap.Authenticator{
+		ID:     a.ID,
+		UserID: userID,
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/bearertoken"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/ldap"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/oob"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/password"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/recoverycode"
@@ -24,6 +25,7 @@ type PasswordAuthenticatorProvider interface {
 	Create(*password.Authenticator) error
 	UpdatePassword(*password.Authenticator) error
 	Delete(*password.Authenticator) error
+	DeleteHistory(userID string) error
 	Authenticate(a *password.Authenticator, password string) error
 }
 
@@ -51,6 +53,7 @@ type BearerTokenAuthenticatorProvider interface {
 	List(userID string) ([]*bearertoken.Authenticator, error)
 	New(userID string, parentID string) *bearertoken.Authenticator
 	Create(*bearertoken.Authenticator) error
+	RevokeAll(userID string) error
 	Authenticate(authenticator *bearertoken.Authenticator, token string) error
 }
 
@@ -59,15 +62,26 @@ type RecoveryCodeAuthenticatorProvider interface {
 	List(userID string) ([]*recoverycode.Authenticator, error)
 	Generate(userID string) []*recoverycode.Authenticator
 	ReplaceAll(userID string, as []*recoverycode.Authenticator) error
+	DeleteAll(userID string) error
 	Authenticate(candidates []*recoverycode.Authenticator, code string) *recoverycode.Authenticator
 }
 
+type LDAPAuthenticatorProvider interface {
+	Get(userID, id string) (*ldap.Authenticator, error)
+	List(userID string) ([]*ldap.Authenticator, error)
+	New(userID string) *ldap.Authenticator
+	Create(*ldap.Authenticator) error
+	Delete(*ldap.Authenticator) error
+	Authenticate(dn string, password string) error
+}
+
 type Provider struct {
 	Password     PasswordAuthenticatorProvider
 	TOTP         TOTPAuthenticatorProvider
 	OOBOTP       OOBOTPAuthenticatorProvider
 	BearerToken  BearerTokenAuthenticatorProvider
 	RecoveryCode RecoveryCodeAuthenticatorProvider
+	LDAP         LDAPAuthenticatorProvider
 }
 
 func (a *Provider) Get(userID string, typ authn.AuthenticatorType, id string) (*authenticator.Info, error) {
@@ -106,6 +120,13 @@ func (a *Provider) Get(userID string, typ authn.AuthenticatorType, id string) (*
 			return nil, err
 		}
 		return recoveryCodeToAuthenticatorInfo(r), nil
+
+	case authn.AuthenticatorTypeLDAP:
+		l, err := a.LDAP.Get(userID, id)
+		if err != nil {
+			return nil, err
+		}
+		return ldapToAuthenticatorInfo(l), nil
 	}
 
 	panic("interaction_adaptors: unknown authenticator type " + typ)
@@ -159,6 +180,15 @@ func (a *Provider) List(userID string, typ authn.AuthenticatorType) ([]*authenti
 			ais = append(ais, recoveryCodeToAuthenticatorInfo(a))
 		}
 
+	case authn.AuthenticatorTypeLDAP:
+		as, err := a.LDAP.List(userID)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range as {
+			ais = append(ais, ldapToAuthenticatorInfo(a))
+		}
+
 	default:
 		panic("interaction_adaptors: unknown authenticator type " + typ)
 	}
@@ -207,6 +237,16 @@ func (a *Provider) ListByIdentity(userID string, ii *identity.Info) (ais []*auth
 	case authn.IdentityTypeAnonymous:
 		// Anonymous Identity does not have associated authenticators.
 		return
+	case authn.IdentityTypeLDAP:
+		// LDAP Identity has LDAP authenticator only.
+		var las []*ldap.Authenticator
+		las, err = a.LDAP.List(userID)
+		if err != nil {
+			return
+		}
+		for _, la := range las {
+			ais = append(ais, ldapToAuthenticatorInfo(la))
+		}
 	default:
 		panic("interaction_adaptors: unknown identity type " + ii.Type)
 	}
@@ -252,6 +292,10 @@ func (a *Provider) New(userID string, spec authenticator.Spec, secret string) ([
 			ais = append(ais, recoveryCodeToAuthenticatorInfo(r))
 		}
 		return ais, nil
+
+	case authn.AuthenticatorTypeLDAP:
+		l := a.LDAP.New(userID)
+		return []*authenticator.Info{ldapToAuthenticatorInfo(l)}, nil
 	}
 
 	panic("interaction_adaptors: unknown authenticator type " + spec.Type)
@@ -305,6 +349,12 @@ func (a *Provider) CreateAll(userID string, ais []*authenticator.Info) error {
 			authenticator := recoveryCodeFromAuthenticatorInfo(userID, ai)
 			recoveryCodes = append(recoveryCodes, authenticator)
 
+		case authn.AuthenticatorTypeLDAP:
+			authenticator := ldapFromAuthenticatorInfo(userID, ai)
+			if err := a.LDAP.Create(authenticator); err != nil {
+				return err
+			}
+
 		default:
 			panic("interaction_adaptors: unknown authenticator type " + ai.Type)
 		}
@@ -364,6 +414,12 @@ func (a *Provider) DeleteAll(userID string, ais []*authenticator.Info) error {
 			if err := a.OOBOTP.Delete(authenticator); err != nil {
 				return err
 			}
+
+		case authn.AuthenticatorTypeLDAP:
+			authenticator := ldapFromAuthenticatorInfo(userID, ai)
+			if err := a.LDAP.Delete(authenticator); err != nil {
+				return err
+			}
 		default:
 			panic("interaction_adaptors: delete authenticator is not supported yet for type " + ai.Type)
 		}
@@ -372,6 +428,40 @@ func (a *Provider) DeleteAll(userID string, ais []*authenticator.Info) error {
 	return nil
 }
 
+// DeleteAllByUser deletes every authenticator of the user, including
+// bearer tokens, recovery codes and password history.
+func (a *Provider) DeleteAllByUser(userID string) error {
+	var ais []*authenticator.Info
+	for _, typ := range []authn.AuthenticatorType{
+		authn.AuthenticatorTypePassword,
+		authn.AuthenticatorTypeTOTP,
+		authn.AuthenticatorTypeOOB,
+		authn.AuthenticatorTypeLDAP,
+	} {
+		as, err := a.List(userID, typ)
+		if err != nil {
+			return err
+		}
+		ais = append(ais, as...)
+	}
+
+	// Bearer tokens reference their parent authenticators,
+	// so they must be deleted first.
+	if err := a.BearerToken.RevokeAll(userID); err != nil {
+		return err
+	}
+
+	if err := a.RecoveryCode.DeleteAll(userID); err != nil {
+		return err
+	}
+
+	if err := a.DeleteAll(userID, ais); err != nil {
+		return err
+	}
+
+	return a.Password.DeleteHistory(userID)
+}
+
 func (a *Provider) Authenticate(userID string, spec authenticator.Spec, state *map[string]string, secret string) (*authenticator.Info, error) {
 	switch spec.Type {
 	case authn.AuthenticatorTypePassword:
@@ -452,6 +542,33 @@ func (a *Provider) Authenticate(userID string, spec authenticator.Spec, state *m
 			return nil, interaction.ErrInvalidCredentials
 		}
 		return recoveryCodeToAuthenticatorInfo(r), nil
+
+	case authn.AuthenticatorTypeLDAP:
+		if state == nil {
+			return nil, interaction.ErrInvalidCredentials
+		}
+		dn := (*state)[authenticator.AuthenticatorStateLDAPDN]
+		if dn == "" {
+			return nil, interaction.ErrInvalidCredentials
+		}
+
+		err := a.LDAP.Authenticate(dn, secret)
+		if errors.Is(err, ldap.ErrInvalidCredentials) {
+			return nil, interaction.ErrInvalidCredentials
+		} else if err != nil {
+			return nil, err
+		}
+
+		// This function can be called by login or signup.
+		// In case of signup, the user does not have the authenticator yet.
+		ls, err := a.LDAP.List(userID)
+		if err != nil {
+			return nil, err
+		}
+		if len(ls) == 0 {
+			return nil, nil
+		}
+		return ldapToAuthenticatorInfo(ls[0]), nil
 	}
 
 	panic("interaction_adaptors: unknown authenticator type " + spec.Type)
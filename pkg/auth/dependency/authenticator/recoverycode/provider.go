@@ -63,6 +63,10 @@ func (p *Provider) ReplaceAll(userID string, as []*Authenticator) error {
 	return nil
 }
 
+func (p *Provider) DeleteAll(userID string) error {
+	return p.Store.DeleteAll(userID)
+}
+
 func (p *Provider) Authenticate(candidates []*Authenticator, code string) *Authenticator {
 	for _, a := range candidates {
 		if VerifyCode(a.Code, code) {
@@ -264,6 +264,16 @@ func (p *Provider) ResetPassword(codeStr string, newPassword string) (err error)
 		return
 	}
 
+	err = p.HookProvider.DispatchEvent(
+		event.PasswordResetEvent{
+			User: *user,
+		},
+		user,
+	)
+	if err != nil {
+		return
+	}
+
 	// We have to mark the code as consumed at the end
 	// because if we mark it at the beginning,
 	// the code will be consumed if the new password violates
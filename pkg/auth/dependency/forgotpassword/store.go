@@ -4,4 +4,5 @@ type Store interface {
 	Create(code *Code) error
 	Get(codeStr string) (*Code, error)
 	Update(code *Code) error
+	DeleteByUser(userID string) error
 }
@@ -30,8 +30,18 @@ func (s *StoreImpl) Create(code *Code) (err error) {
 	if errors.Is(err, goredis.ErrNil) {
 		err = errors.Newf("duplicated forgot password code: %w", err)
 		return
+	} else if err != nil {
+		return
 	}
 
+	// Index the code by user, so that codes can be removed with the user.
+	// Codes share the same lifetime, so the latest code expires last.
+	userKey := userCodeListKey(code.UserID)
+	_, err = conn.Do("SADD", userKey, code.CodeHash)
+	if err != nil {
+		return
+	}
+	_, err = conn.Do("PEXPIRE", userKey, codeExpire(code))
 	return
 }
 
@@ -68,10 +78,34 @@ func (s *StoreImpl) Get(codeHash string) (code *Code, err error) {
 	return
 }
 
+func (s *StoreImpl) DeleteByUser(userID string) (err error) {
+	conn := redis.GetConn(s.Context)
+	userKey := userCodeListKey(userID)
+
+	codeHashes, err := goredis.Strings(conn.Do("SMEMBERS", userKey))
+	if err != nil {
+		return
+	}
+
+	for _, codeHash := range codeHashes {
+		_, err = conn.Do("DEL", codeKey(codeHash))
+		if err != nil {
+			return
+		}
+	}
+
+	_, err = conn.Do("DEL", userKey)
+	return
+}
+
 func codeKey(codeHash string) string {
 	return fmt.Sprintf("forgotpassword-code:%s", codeHash)
 }
 
+func userCodeListKey(userID string) string {
+	return fmt.Sprintf("forgotpassword-user:%s", userID)
+}
+
 func codeExpire(code *Code) int64 {
 	d := code.ExpireAt.Sub(code.CreatedAt)
 	return int64(d / time.Millisecond)
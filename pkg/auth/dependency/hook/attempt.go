@@ -0,0 +1,37 @@
+package hook
+
+import (
+	"time"
+
+	"github.com/skygeario/skygear-server/pkg/auth/event"
+)
+
+// maxAttemptResponseBodySize is the maximum size of hook response body kept
+// in delivery attempt records.
+const maxAttemptResponseBodySize = 1024
+
+// DeliveryAttempt records an attempt to deliver an event to a hook.
+type DeliveryAttempt struct {
+	ID           string
+	EventID      string
+	EventType    event.Type
+	HookURL      string
+	StatusCode   int
+	Latency      time.Duration
+	ResponseBody string
+	Error        string
+	CreatedAt    time.Time
+}
+
+type AttemptFilter struct {
+	EventID string
+	HookURL string
+}
+
+// AttemptStore stores delivery attempts. Attempts are stored outside of
+// the ongoing transaction, so failed attempts are kept even if the
+// operation is rolled back.
+type AttemptStore interface {
+	AddAttempt(attempt *DeliveryAttempt) error
+	ListAttempts(filter AttemptFilter, offset uint64, limit uint64) ([]*DeliveryAttempt, error)
+}
@@ -0,0 +1,118 @@
+package hook
+
+import (
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/skygeario/skygear-server/pkg/core/db"
+)
+
+type attemptStoreImpl struct {
+	sqlBuilder  db.SQLBuilder
+	sqlExecutor db.SQLExecutor
+}
+
+// NewAttemptStore creates an attempt store. The executor should execute
+// statements outside of transaction.
+func NewAttemptStore(builder db.SQLBuilder, executor db.SQLExecutor) AttemptStore {
+	return &attemptStoreImpl{
+		sqlBuilder:  builder,
+		sqlExecutor: executor,
+	}
+}
+
+func (store *attemptStoreImpl) AddAttempt(attempt *DeliveryAttempt) error {
+	builder := store.sqlBuilder.Tenant().
+		Insert(store.sqlBuilder.FullTableName("hook_delivery_attempt")).
+		Columns(
+			"id",
+			"event_id",
+			"event_type",
+			"hook_url",
+			"status_code",
+			"latency_ms",
+			"response_body",
+			"error",
+			"created_at",
+		).
+		Values(
+			attempt.ID,
+			attempt.EventID,
+			attempt.EventType,
+			attempt.HookURL,
+			attempt.StatusCode,
+			int64(attempt.Latency/time.Millisecond),
+			attempt.ResponseBody,
+			attempt.Error,
+			attempt.CreatedAt,
+		)
+
+	_, err := store.sqlExecutor.ExecWith(builder)
+	return err
+}
+
+func (store *attemptStoreImpl) ListAttempts(filter AttemptFilter, offset uint64, limit uint64) ([]*DeliveryAttempt, error) {
+	builder := store.sqlBuilder.Tenant().
+		Select(
+			"id",
+			"event_id",
+			"event_type",
+			"hook_url",
+			"status_code",
+			"latency_ms",
+			"response_body",
+			"error",
+			"created_at",
+		).
+		From(store.sqlBuilder.FullTableName("hook_delivery_attempt"))
+	if filter.EventID != "" {
+		builder = builder.Where("event_id = ?", filter.EventID)
+	}
+	if filter.HookURL != "" {
+		builder = builder.Where("hook_url = ?", filter.HookURL)
+	}
+	builder = builder.
+		OrderBy("created_at DESC", "id").
+		Offset(offset).
+		Limit(limit)
+
+	rows, err := store.sqlExecutor.QueryWith(builder)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attempts := []*DeliveryAttempt{}
+	for rows.Next() {
+		attempt, err := store.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, attempt)
+	}
+
+	return attempts, nil
+}
+
+func (store *attemptStoreImpl) scan(scn sqlx.ColScanner) (*DeliveryAttempt, error) {
+	attempt := &DeliveryAttempt{}
+	var latencyMS int64
+	err := scn.Scan(
+		&attempt.ID,
+		&attempt.EventID,
+		&attempt.EventType,
+		&attempt.HookURL,
+		&attempt.StatusCode,
+		&latencyMS,
+		&attempt.ResponseBody,
+		&attempt.Error,
+		&attempt.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	attempt.Latency = time.Duration(latencyMS) * time.Millisecond
+
+	return attempt, nil
+}
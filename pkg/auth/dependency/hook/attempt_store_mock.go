@@ -0,0 +1,37 @@
+package hook
+
+type mockAttemptStore struct {
+	attempts []*DeliveryAttempt
+}
+
+func newMockAttemptStore() *mockAttemptStore {
+	return &mockAttemptStore{}
+}
+
+func (store *mockAttemptStore) AddAttempt(attempt *DeliveryAttempt) error {
+	store.attempts = append(store.attempts, attempt)
+	return nil
+}
+
+func (store *mockAttemptStore) ListAttempts(filter AttemptFilter, offset uint64, limit uint64) ([]*DeliveryAttempt, error) {
+	attempts := []*DeliveryAttempt{}
+	for _, a := range store.attempts {
+		if filter.EventID != "" && a.EventID != filter.EventID {
+			continue
+		}
+		if filter.HookURL != "" && a.HookURL != filter.HookURL {
+			continue
+		}
+		attempts = append(attempts, a)
+	}
+	if offset >= uint64(len(attempts)) {
+		return []*DeliveryAttempt{}, nil
+	}
+	attempts = attempts[offset:]
+	if limit < uint64(len(attempts)) {
+		attempts = attempts[:limit]
+	}
+	return attempts, nil
+}
+
+var _ AttemptStore = &mockAttemptStore{}
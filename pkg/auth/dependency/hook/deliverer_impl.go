@@ -3,15 +3,23 @@ package hook
 import (
 	"bytes"
 	"encoding/json"
+	"io"
+	"io/ioutil"
 	"net"
 	gohttp "net/http"
 	"net/url"
+	"strings"
 	gotime "time"
 
+	"github.com/sirupsen/logrus"
+
+	"github.com/skygeario/skygear-server/pkg/core/logging"
 	"github.com/skygeario/skygear-server/pkg/core/time"
+	"github.com/skygeario/skygear-server/pkg/core/uuid"
 
 	"github.com/skygeario/skygear-server/pkg/core/crypto"
 	"github.com/skygeario/skygear-server/pkg/core/http"
+	"github.com/skygeario/skygear-server/pkg/core/http/httpsigning"
 
 	"github.com/skygeario/skygear-server/pkg/auth/event"
 	"github.com/skygeario/skygear-server/pkg/auth/model"
@@ -24,17 +32,27 @@ type delivererImpl struct {
 	HookTenantConfig *config.HookTenantConfiguration
 	TimeProvider     time.Provider
 	Mutator          Mutator
+	Attempts         AttemptStore
 	HTTPClient       gohttp.Client
+	Logger           *logrus.Entry
 }
 
-func NewDeliverer(config *config.TenantConfiguration, timeProvider time.Provider, mutator Mutator) Deliverer {
+func NewDeliverer(
+	config *config.TenantConfiguration,
+	timeProvider time.Provider,
+	mutator Mutator,
+	attempts AttemptStore,
+	loggerFactory logging.Factory,
+) Deliverer {
 	return &delivererImpl{
 		Hooks:            &config.Hooks,
 		HookAppConfig:    config.AppConfig.Hook,
 		HookTenantConfig: config.Hook,
 		TimeProvider:     timeProvider,
 		Mutator:          mutator,
+		Attempts:         attempts,
 		HTTPClient:       gohttp.Client{},
+		Logger:           loggerFactory.NewLogger("hook-deliverer"),
 	}
 }
 
@@ -66,12 +84,7 @@ func (deliverer *delivererImpl) DeliverBeforeEvent(e *event.Event, user *model.U
 			return errDeliveryTimeout
 		}
 
-		request, err := deliverer.prepareRequest(hook, e)
-		if err != nil {
-			return err
-		}
-
-		resp, err := performRequest(client, request, true)
+		resp, err := deliverer.send(client, hook, e, true)
 		if err != nil {
 			return err
 		}
@@ -113,12 +126,7 @@ func (deliverer *delivererImpl) DeliverNonBeforeEvent(e *event.Event, timeout go
 			continue
 		}
 
-		request, err := deliverer.prepareRequest(hook, e)
-		if err != nil {
-			return err
-		}
-
-		_, err = performRequest(client, request, false)
+		_, err := deliverer.send(client, hook, e, false)
 		if err != nil {
 			return err
 		}
@@ -127,6 +135,36 @@ func (deliverer *delivererImpl) DeliverNonBeforeEvent(e *event.Event, timeout go
 	return nil
 }
 
+// send delivers the event to the hook, and records the attempt.
+func (deliverer *delivererImpl) send(client gohttp.Client, hook config.Hook, e *event.Event, withResponse bool) (*event.HookResponse, error) {
+	startTime := deliverer.TimeProvider.NowUTC()
+	attempt := &DeliveryAttempt{
+		ID:        uuid.New(),
+		EventID:   e.ID,
+		EventType: e.Type,
+		HookURL:   hook.URL,
+		CreatedAt: startTime,
+	}
+
+	var result requestResult
+	request, err := deliverer.prepareRequest(hook, e)
+	if err == nil {
+		result, err = performRequest(client, request, withResponse)
+	}
+
+	attempt.Latency = deliverer.TimeProvider.NowUTC().Sub(startTime)
+	attempt.StatusCode = result.StatusCode
+	attempt.ResponseBody = truncateResponseBody(result.Body)
+	if err != nil {
+		attempt.Error = err.Error()
+	}
+	if recordErr := deliverer.Attempts.AddAttempt(attempt); recordErr != nil {
+		deliverer.Logger.WithError(recordErr).Error("failed to record delivery attempt")
+	}
+
+	return result.HookResponse, err
+}
+
 func (deliverer *delivererImpl) prepareRequest(hook config.Hook, event *event.Event) (*gohttp.Request, error) {
 	hookURL, err := url.Parse(hook.URL)
 	if err != nil {
@@ -138,14 +176,24 @@ func (deliverer *delivererImpl) prepareRequest(hook config.Hook, event *event.Ev
 		return nil, newErrorDeliveryFailed(err)
 	}
 
-	signature := crypto.HMACSHA256String([]byte(deliverer.HookAppConfig.Secret), body)
+	// The body-only signature is kept for receivers not yet verifying
+	// the timestamped signature.
+	bodySignature := crypto.HMACSHA256String([]byte(deliverer.HookAppConfig.Secret), body)
+
+	keys := [][]byte{[]byte(deliverer.HookAppConfig.Secret)}
+	for _, secret := range deliverer.HookAppConfig.AdditionalSecrets {
+		keys = append(keys, []byte(secret))
+	}
+	signature := httpsigning.SignWebhook(keys, body, deliverer.TimeProvider.NowUTC())
 
 	request, err := gohttp.NewRequest("POST", hookURL.String(), bytes.NewReader(body))
 	if err != nil {
 		return nil, newErrorDeliveryFailed(err)
 	}
 	request.Header.Add("Content-Type", "application/json")
-	request.Header.Add(http.HeaderRequestBodySignature, signature)
+	request.Header.Add(http.HeaderWebhookEventID, event.ID)
+	request.Header.Add(http.HeaderWebhookSignature, signature)
+	request.Header.Add(http.HeaderRequestBodySignature, bodySignature)
 
 	return request, nil
 }
@@ -154,7 +202,16 @@ func noFollowRedirectPolicy(*gohttp.Request, []*gohttp.Request) error {
 	return gohttp.ErrUseLastResponse
 }
 
-func performRequest(client gohttp.Client, request *gohttp.Request, withResponse bool) (hookResp *event.HookResponse, err error) {
+// maxResponseBodySize is the maximum size of hook response body.
+const maxResponseBodySize = 1024 * 1024
+
+type requestResult struct {
+	StatusCode   int
+	Body         []byte
+	HookResponse *event.HookResponse
+}
+
+func performRequest(client gohttp.Client, request *gohttp.Request, withResponse bool) (result requestResult, err error) {
 	var resp *gohttp.Response
 	resp, err = client.Do(request)
 	if reqError, ok := err.(net.Error); ok && reqError.Timeout() {
@@ -172,6 +229,18 @@ func performRequest(client gohttp.Client, request *gohttp.Request, withResponse
 		}
 	}()
 
+	result.StatusCode = resp.StatusCode
+	result.Body, err = ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize+1))
+	if err != nil {
+		err = newErrorDeliveryFailed(err)
+		return
+	}
+	if len(result.Body) > maxResponseBodySize {
+		result.Body = result.Body[:maxResponseBodySize]
+		err = newErrorDeliveryFailed(errDeliveryResponseTooLarge)
+		return
+	}
+
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		err = errDeliveryInvalidStatusCode
 		return
@@ -181,7 +250,7 @@ func performRequest(client gohttp.Client, request *gohttp.Request, withResponse
 		return
 	}
 
-	hookResp, err = event.ParseHookResponse(resp.Body)
+	result.HookResponse, err = event.ParseHookResponse(bytes.NewReader(result.Body))
 	if err != nil {
 		err = newErrorDeliveryFailed(err)
 		return
@@ -189,3 +258,13 @@ func performRequest(client gohttp.Client, request *gohttp.Request, withResponse
 
 	return
 }
+
+func truncateResponseBody(body []byte) string {
+	if len(body) > maxAttemptResponseBodySize {
+		body = body[:maxAttemptResponseBodySize]
+	}
+	// Response body may be cut in the middle of a character, or may not be
+	// text at all; make sure it can be stored as text.
+	s := strings.ToValidUTF8(string(body), "\uFFFD")
+	return strings.ReplaceAll(s, "\x00", "")
+}
@@ -3,6 +3,7 @@ package hook
 import (
 	"fmt"
 	gohttp "net/http"
+	"strings"
 	"testing"
 	gotime "time"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/skygeario/skygear-server/pkg/auth/model"
 	"github.com/skygeario/skygear-server/pkg/core/config"
 	"github.com/skygeario/skygear-server/pkg/core/http"
+	"github.com/skygeario/skygear-server/pkg/core/logging"
 	"github.com/skygeario/skygear-server/pkg/core/time"
 
 	. "github.com/smartystreets/goconvey/convey"
@@ -21,7 +23,8 @@ import (
 func TestDeliverer(t *testing.T) {
 	Convey("Event Deliverer", t, func() {
 		hookAppConfig := &config.HookAppConfiguration{
-			Secret: "hook-secret",
+			Secret:            "hook-secret",
+			AdditionalSecrets: []string{"new-hook-secret"},
 		}
 		hookTenantConfig := &config.HookTenantConfiguration{
 			SyncHookTimeout:      5,
@@ -33,6 +36,7 @@ func TestDeliverer(t *testing.T) {
 		timeProvider.TimeNow = initialTime
 		timeProvider.TimeNowUTC = initialTime
 		mutator := newMockMutator()
+		attempts := newMockAttemptStore()
 
 		httpClient := gohttp.Client{}
 		gock.InterceptClient(&httpClient)
@@ -41,7 +45,9 @@ func TestDeliverer(t *testing.T) {
 			HookTenantConfig: hookTenantConfig,
 			TimeProvider:     &timeProvider,
 			Mutator:          mutator,
+			Attempts:         attempts,
 			HTTPClient:       httpClient,
+			Logger:           logging.NewNullFactory().NewLogger("hook-deliverer"),
 		}
 
 		defer gock.Off()
@@ -91,6 +97,8 @@ func TestDeliverer(t *testing.T) {
 					Post("/a").
 					JSON(e).
 					HeaderPresent(http.HeaderRequestBodySignature).
+					MatchHeader(http.HeaderWebhookEventID, "^event-id$").
+					MatchHeader(http.HeaderWebhookSignature, "^t=1136214245,v1=[0-9a-f]{64},v1=[0-9a-f]{64}$").
 					Reply(200).
 					JSON(map[string]interface{}{
 						"is_allowed": true,
@@ -268,6 +276,31 @@ func TestDeliverer(t *testing.T) {
 				So(gock.IsDone(), ShouldBeTrue)
 			})
 
+			Convey("should reject large response body", func() {
+				deliverer.Hooks = &[]config.Hook{
+					config.Hook{
+						Event: string(event.BeforeSessionCreate),
+						URL:   "https://example.com/a",
+					},
+				}
+
+				user := model.User{
+					ID: "user-id",
+				}
+
+				gock.New("https://example.com").
+					Post("/a").
+					JSON(e).
+					Reply(200).
+					BodyString(strings.Repeat(" ", 1024*1024+1))
+				defer func() { gock.Flush() }()
+
+				err := deliverer.DeliverBeforeEvent(&e, &user)
+
+				So(err, ShouldBeError, "web-hook event delivery failed: response body too large")
+				So(gock.IsDone(), ShouldBeTrue)
+			})
+
 			Convey("should time out long requests", func() {
 				deliverer.Hooks = &[]config.Hook{
 					config.Hook{
@@ -342,6 +375,13 @@ func TestDeliverer(t *testing.T) {
 
 				So(err, ShouldBeNil)
 				So(gock.IsDone(), ShouldBeTrue)
+				So(attempts.attempts, ShouldHaveLength, 1)
+				So(attempts.attempts[0].EventID, ShouldEqual, e.ID)
+				So(attempts.attempts[0].EventType, ShouldEqual, event.UserSync)
+				So(attempts.attempts[0].HookURL, ShouldEqual, "https://example.com/a")
+				So(attempts.attempts[0].StatusCode, ShouldEqual, 200)
+				So(attempts.attempts[0].ResponseBody, ShouldEqual, "test")
+				So(attempts.attempts[0].Error, ShouldEqual, "")
 			})
 
 			Convey("should reject invalid status code", func() {
@@ -362,7 +402,20 @@ func TestDeliverer(t *testing.T) {
 
 				So(err, ShouldBeError, "invalid status code")
 				So(gock.IsDone(), ShouldBeTrue)
+				So(attempts.attempts, ShouldHaveLength, 1)
+				So(attempts.attempts[0].EventID, ShouldEqual, e.ID)
+				So(attempts.attempts[0].HookURL, ShouldEqual, "https://example.com/a")
+				So(attempts.attempts[0].StatusCode, ShouldEqual, 500)
+				So(attempts.attempts[0].Error, ShouldEqual, "invalid status code")
 			})
 		})
 	})
 }
+
+func TestTruncateResponseBody(t *testing.T) {
+	Convey("truncateResponseBody", t, func() {
+		So(truncateResponseBody([]byte("ok")), ShouldEqual, "ok")
+		So(truncateResponseBody([]byte(strings.Repeat("a", 2000))), ShouldHaveLength, maxAttemptResponseBodySize)
+		So(truncateResponseBody([]byte("\xff\x00ok")), ShouldEqual, "\uFFFDok")
+	})
+}
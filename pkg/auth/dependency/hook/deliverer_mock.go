@@ -11,6 +11,7 @@ type mockDeliverer struct {
 	WillDeliverFunc       func(eventType event.Type) bool
 	DeliveryError         error
 	OnDeliverBeforeEvents func(event *event.Event, user *model.User)
+	OnDeliver             func()
 	BeforeEvents          []mockDelivererBeforeEvent
 	NonBeforeEvents       []mockDelivererNonBeforeEvent
 }
@@ -55,6 +56,12 @@ func (deliverer *mockDeliverer) DeliverNonBeforeEvent(event *event.Event, timeou
 		Event:   &_event,
 		Timeout: timeout,
 	})
+	if deliverer.OnDeliver != nil {
+		// Reset before invocation to avoid recursion.
+		onDeliver := deliverer.OnDeliver
+		deliverer.OnDeliver = nil
+		onDeliver()
+	}
 	return deliverer.DeliveryError
 }
 
@@ -6,6 +6,8 @@ import (
 	"github.com/google/wire"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/userprofile"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/userverify"
+	"github.com/skygeario/skygear-server/pkg/core/async"
 	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo"
 	"github.com/skygeario/skygear-server/pkg/core/config"
 	"github.com/skygeario/skygear-server/pkg/core/db"
@@ -13,40 +15,103 @@ import (
 	"github.com/skygeario/skygear-server/pkg/core/time"
 )
 
-func ProvideHookProvider(
-	ctx context.Context,
+func ProvideStore(
+	tConfig *config.TenantConfiguration,
 	sqlb db.SQLBuilder,
 	sqle db.SQLExecutor,
+) Store {
+	return NewStore(tConfig.AppID, sqlb, sqle)
+}
+
+func ProvideAttemptStore(
+	ctx context.Context,
+	tConfig *config.TenantConfiguration,
+	sqlb db.SQLBuilder,
+) AttemptStore {
+	return NewAttemptStore(
+		sqlb,
+		db.NewSQLExecutor(ctx, db.NewNonTxContextWithContext(ctx, *tConfig)),
+	)
+}
+
+func ProvideDeliverer(
 	tConfig *config.TenantConfiguration,
-	txContext db.TxContext,
 	timeProvider time.Provider,
-	users UserProvider,
 	authInfoStore authinfo.Store,
 	userProfileStore userprofile.Store,
 	loginIDProvider LoginIDProvider,
+	attemptStore AttemptStore,
+	loggerFactory logging.Factory,
+) Deliverer {
+	return NewDeliverer(
+		tConfig,
+		timeProvider,
+		NewMutator(
+			tConfig.AppConfig.UserVerification,
+			loginIDProvider,
+			authInfoStore,
+			userProfileStore,
+		),
+		attemptStore,
+		loggerFactory,
+	)
+}
+
+func ProvideHookProvider(
+	ctx context.Context,
+	tConfig *config.TenantConfiguration,
+	sqlb db.SQLBuilder,
+	store Store,
+	txContext db.TxContext,
+	timeProvider time.Provider,
+	users UserProvider,
+	deliverer Deliverer,
+	taskQueue async.Queue,
+	taskExecutor *async.Executor,
 	loggerFactory logging.Factory,
 ) Provider {
 	return NewProvider(
 		ctx,
-		NewStore(sqlb, sqle),
+		store,
+		NewStore(
+			tConfig.AppID,
+			sqlb,
+			db.NewSQLExecutor(ctx, db.NewNonTxContextWithContext(ctx, *tConfig)),
+		),
 		txContext,
 		timeProvider,
 		users,
-		NewDeliverer(
-			tConfig,
-			timeProvider,
-			NewMutator(
-				tConfig.AppConfig.UserVerification,
-				loginIDProvider,
-				authInfoStore,
-				userProfileStore,
-			),
-		),
+		deliverer,
+		taskQueue,
+		async.NewQueue(ctx, nil, tConfig, taskExecutor),
+		loggerFactory,
+	)
+}
+
+func ProvideDeliveryWorker(
+	tConfig *config.TenantConfiguration,
+	txContext db.TxContext,
+	store Store,
+	deliverer Deliverer,
+	timeProvider time.Provider,
+	loggerFactory logging.Factory,
+) *DeliveryWorker {
+	return NewDeliveryWorker(
+		txContext,
+		store,
+		deliverer,
+		timeProvider,
+		tConfig.Hook,
 		loggerFactory,
 	)
 }
 
 var DependencySet = wire.NewSet(
+	ProvideStore,
+	ProvideAttemptStore,
+	ProvideDeliverer,
 	ProvideHookProvider,
+	ProvideDeliveryWorker,
 	wire.Bind(new(auth.HookProvider), new(Provider)),
+	wire.Bind(new(userverify.HookProvider), new(Provider)),
 )
@@ -6,9 +6,13 @@ import (
 )
 
 var WebHookDisallowed = skyerr.Forbidden.WithReason("WebHookDisallowed")
+var EventNotFound = skyerr.NotFound.WithReason("EventNotFound")
+
+var ErrEventNotFound = EventNotFound.New("event not found")
 
 var errDeliveryTimeout = errors.New("web-hook event delivery timed out")
 var errDeliveryInvalidStatusCode = errors.New("invalid status code")
+var errDeliveryResponseTooLarge = errors.New("response body too large")
 
 func newErrorDeliveryFailed(inner error) error {
 	return errors.Newf("web-hook event delivery failed: %w", inner)
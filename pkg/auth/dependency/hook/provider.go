@@ -9,4 +9,8 @@ import (
 type Provider interface {
 	db.TransactionHook
 	DispatchEvent(payload event.Payload, user *model.User) error
+	// DispatchEventImmediately persists the event outside of the ongoing
+	// transaction, so that it is delivered even if the transaction is rolled
+	// back, e.g. for events about failed operations.
+	DispatchEventImmediately(payload event.NotificationPayload) error
 }
@@ -3,13 +3,14 @@ package hook
 import (
 	"context"
 	"fmt"
-	gotime "time"
 
 	"github.com/sirupsen/logrus"
 
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
 	"github.com/skygeario/skygear-server/pkg/auth/event"
 	"github.com/skygeario/skygear-server/pkg/auth/model"
+	taskspec "github.com/skygeario/skygear-server/pkg/auth/task/spec"
+	"github.com/skygeario/skygear-server/pkg/core/async"
 	"github.com/skygeario/skygear-server/pkg/core/authn"
 	"github.com/skygeario/skygear-server/pkg/core/db"
 	"github.com/skygeario/skygear-server/pkg/core/errors"
@@ -26,34 +27,44 @@ type UserProvider interface {
 
 type providerImpl struct {
 	Store                   Store
+	ImmediateStore          Store
 	Context                 context.Context
 	TxContext               db.TxContext
 	TimeProvider            time.Provider
 	Users                   UserProvider
 	Deliverer               Deliverer
+	TaskQueue               async.Queue
+	ImmediateTaskQueue      async.Queue
 	PersistentEventPayloads []event.Payload
 	Logger                  *logrus.Entry
 
-	txHooked bool
+	txHooked        bool
+	eventsPersisted bool
 }
 
 func NewProvider(
 	ctx context.Context,
 	store Store,
+	immediateStore Store,
 	txContext db.TxContext,
 	timeProvider time.Provider,
 	users UserProvider,
 	deliverer Deliverer,
+	taskQueue async.Queue,
+	immediateTaskQueue async.Queue,
 	loggerFactory logging.Factory,
 ) Provider {
 	return &providerImpl{
-		Context:      ctx,
-		Store:        store,
-		TxContext:    txContext,
-		TimeProvider: timeProvider,
-		Users:        users,
-		Deliverer:    deliverer,
-		Logger:       loggerFactory.NewLogger("hook"),
+		Context:            ctx,
+		Store:              store,
+		ImmediateStore:     immediateStore,
+		TxContext:          txContext,
+		TimeProvider:       timeProvider,
+		Users:              users,
+		Deliverer:          deliverer,
+		TaskQueue:          taskQueue,
+		ImmediateTaskQueue: immediateTaskQueue,
+		Logger:             loggerFactory.NewLogger("hook"),
 	}
 }
 
@@ -97,13 +108,43 @@ func (provider *providerImpl) DispatchEvent(payload event.Payload, user *model.U
 	return
 }
 
+func (provider *providerImpl) DispatchEventImmediately(payload event.NotificationPayload) error {
+	if !provider.Deliverer.WillDeliver(payload.EventType()) {
+		return nil
+	}
+
+	seq, err := provider.ImmediateStore.NextSequenceNumber()
+	if err != nil {
+		return errors.HandledWithMessage(err, "failed to dispatch event")
+	}
+
+	now := provider.TimeProvider.NowUTC()
+	err = provider.ImmediateStore.AddDeliveries([]*Delivery{
+		&Delivery{
+			Event:         event.NewEvent(seq, payload, provider.makeContext()),
+			Status:        DeliveryStatusPending,
+			CreatedAt:     now,
+			NextAttemptAt: now,
+		},
+	})
+	if err != nil {
+		return errors.HandledWithMessage(err, "failed to dispatch event")
+	}
+
+	provider.ImmediateTaskQueue.Enqueue(async.TaskSpec{
+		Name: taskspec.DeliverEventsTaskName,
+	})
+	return nil
+}
+
 func (provider *providerImpl) WillCommitTx() error {
 	err := provider.dispatchSyncUserEventIfNeeded()
 	if err != nil {
 		return err
 	}
 
-	events := []*event.Event{}
+	now := provider.TimeProvider.NowUTC()
+	deliveries := []*Delivery{}
 	for _, payload := range provider.PersistentEventPayloads {
 		var ev *event.Event
 
@@ -135,28 +176,38 @@ func (provider *providerImpl) WillCommitTx() error {
 		if ev == nil {
 			continue
 		}
-		events = append(events, ev)
+		deliveries = append(deliveries, &Delivery{
+			Event:         ev,
+			Status:        DeliveryStatusPending,
+			CreatedAt:     now,
+			NextAttemptAt: now,
+		})
 	}
 
-	err = provider.Store.AddEvents(events)
+	err = provider.Store.AddDeliveries(deliveries)
 	if err != nil {
 		err = errors.HandledWithMessage(err, "failed to persist event")
 		return err
 	}
 	provider.PersistentEventPayloads = nil
+	if len(deliveries) > 0 {
+		provider.eventsPersisted = true
+	}
 
 	return nil
 }
 
 func (provider *providerImpl) DidCommitTx() {
-	// TODO(webhook): deliver persisted events
-	events, _ := provider.Store.GetEventsForDelivery()
-	for _, event := range events {
-		err := provider.Deliverer.DeliverNonBeforeEvent(event, 60*gotime.Second)
-		if err != nil {
-			provider.Logger.WithError(err).Debug("Failed to dispatch event")
-		}
+	if !provider.eventsPersisted {
+		return
 	}
+	provider.eventsPersisted = false
+
+	// Persisted events are delivered in background,
+	// so that failed deliveries can be retried later.
+	provider.TaskQueue.Enqueue(async.TaskSpec{
+		Name: taskspec.DeliverEventsTaskName,
+	})
 }
 
 func (provider *providerImpl) dispatchSyncUserEventIfNeeded() error {
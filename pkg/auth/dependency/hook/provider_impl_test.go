@@ -7,9 +7,12 @@ import (
 	gotime "time"
 
 	gomock "github.com/golang/mock/gomock"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/session"
 	"github.com/skygeario/skygear-server/pkg/auth/event"
 	"github.com/skygeario/skygear-server/pkg/auth/model"
+	taskspec "github.com/skygeario/skygear-server/pkg/auth/task/spec"
+	"github.com/skygeario/skygear-server/pkg/core/async"
 	"github.com/skygeario/skygear-server/pkg/core/authn"
 	"github.com/skygeario/skygear-server/pkg/core/db"
 	"github.com/skygeario/skygear-server/pkg/core/logging"
@@ -27,15 +30,21 @@ func TestDispatchEvent(t *testing.T) {
 		store := newMockStore()
 		deliverer := newMockDeliverer()
 		users := NewMockUserProvider(ctrl)
+		taskQueue := async.NewMockQueue()
+		immediateStore := newMockStore()
+		immediateTaskQueue := async.NewMockQueue()
 		ctx := context.Background()
 
 		provider := NewProvider(
 			ctx,
 			store,
+			immediateStore,
 			db.NewMockTxContext(),
 			&timeProvider,
 			users,
 			deliverer,
+			taskQueue,
+			immediateTaskQueue,
 			logging.NewNullFactory(),
 		).(*providerImpl)
 
@@ -162,7 +171,8 @@ func TestDispatchEvent(t *testing.T) {
 					Timestamp: 1136214245,
 					UserID:    &userID,
 					Session: &model.Session{
-						ID: "user-id-principal-id",
+						ID:   "user-id-principal-id",
+						Type: auth.SessionTypeIdentityProvider,
 					},
 				})
 			})
@@ -202,6 +212,53 @@ func TestDispatchEvent(t *testing.T) {
 			})
 		})
 
+		Convey("dispatching events immediately", func() {
+			payload := event.LoginFailedEvent{
+				User: &model.User{
+					ID: "user-id",
+				},
+				AuthenticatorType: authn.AuthenticatorTypePassword,
+			}
+
+			Convey("should persist event outside of transaction", func() {
+				err := provider.DispatchEventImmediately(payload)
+
+				So(err, ShouldBeNil)
+				So(provider.PersistentEventPayloads, ShouldBeEmpty)
+				So(store.persistedEvents, ShouldBeEmpty)
+				So(immediateStore.persistedEvents, ShouldResemble, []*Delivery{
+					&Delivery{
+						Event: &event.Event{
+							ID:      "0000000000000001",
+							Type:    event.AfterLoginFailed,
+							Seq:     1,
+							Payload: payload,
+							Context: event.Context{
+								Timestamp: 1136214245,
+								UserID:    nil,
+							},
+						},
+						Status:        DeliveryStatusPending,
+						CreatedAt:     timeProvider.TimeNowUTC,
+						NextAttemptAt: timeProvider.TimeNowUTC,
+					},
+				})
+				So(taskQueue.TasksName, ShouldBeEmpty)
+				So(immediateTaskQueue.TasksName, ShouldResemble, []string{taskspec.DeliverEventsTaskName})
+			})
+
+			Convey("should not persist events that would not be delivered", func() {
+				deliverer.WillDeliverFunc = func(eventType event.Type) bool {
+					return false
+				}
+				err := provider.DispatchEventImmediately(payload)
+
+				So(err, ShouldBeNil)
+				So(immediateStore.persistedEvents, ShouldBeEmpty)
+				So(immediateTaskQueue.TasksName, ShouldBeEmpty)
+			})
+		})
+
 		Convey("when transaction is about to commit", func() {
 			Convey("should generate & persist events", func() {
 				provider.PersistentEventPayloads = []event.Payload{
@@ -223,38 +280,74 @@ func TestDispatchEvent(t *testing.T) {
 
 				So(err, ShouldBeNil)
 				So(provider.PersistentEventPayloads, ShouldBeNil)
-				So(store.persistedEvents, ShouldResemble, []*event.Event{
-					&event.Event{
-						ID:   "0000000000000001",
-						Type: event.AfterSessionCreate,
-						Seq:  1,
-						Payload: event.SessionCreateEvent{
-							User: model.User{
-								ID: "user-id",
+				So(store.persistedEvents, ShouldResemble, []*Delivery{
+					&Delivery{
+						Event: &event.Event{
+							ID:   "0000000000000001",
+							Type: event.AfterSessionCreate,
+							Seq:  1,
+							Payload: event.SessionCreateEvent{
+								User: model.User{
+									ID: "user-id",
+								},
+							},
+							Context: event.Context{
+								Timestamp: 1136214245,
+								UserID:    nil,
 							},
 						},
-						Context: event.Context{
-							Timestamp: 1136214245,
-							UserID:    nil,
-						},
+						Status:        DeliveryStatusPending,
+						CreatedAt:     timeProvider.TimeNowUTC,
+						NextAttemptAt: timeProvider.TimeNowUTC,
 					},
-					&event.Event{
-						ID:   "0000000000000002",
-						Type: event.UserSync,
-						Seq:  2,
-						Payload: event.UserSyncEvent{
-							User: model.User{
-								ID:         "user-id",
-								VerifyInfo: map[string]bool{"user@example.com": true},
-								Metadata:   map[string]interface{}{"user": true},
+					&Delivery{
+						Event: &event.Event{
+							ID:   "0000000000000002",
+							Type: event.UserSync,
+							Seq:  2,
+							Payload: event.UserSyncEvent{
+								User: model.User{
+									ID:         "user-id",
+									VerifyInfo: map[string]bool{"user@example.com": true},
+									Metadata:   map[string]interface{}{"user": true},
+								},
+							},
+							Context: event.Context{
+								Timestamp: 1136214245,
+								UserID:    nil,
 							},
 						},
-						Context: event.Context{
-							Timestamp: 1136214245,
-							UserID:    nil,
-						},
+						Status:        DeliveryStatusPending,
+						CreatedAt:     timeProvider.TimeNowUTC,
+						NextAttemptAt: timeProvider.TimeNowUTC,
 					},
 				})
+
+				provider.DidCommitTx()
+				So(taskQueue.TasksName, ShouldResemble, []string{taskspec.DeliverEventsTaskName})
+			})
+
+			Convey("should not schedule delivery if no events are persisted", func() {
+				deliverer.WillDeliverFunc = func(eventType event.Type) bool {
+					return false
+				}
+				provider.PersistentEventPayloads = []event.Payload{
+					event.SessionCreateEvent{
+						User: model.User{
+							ID: "user-id",
+						},
+					},
+				}
+				users.EXPECT().Get("user-id").Return(&model.User{
+					ID: "user-id",
+				}, nil)
+
+				err := provider.WillCommitTx()
+				So(err, ShouldBeNil)
+				So(store.persistedEvents, ShouldBeEmpty)
+
+				provider.DidCommitTx()
+				So(taskQueue.TasksName, ShouldBeEmpty)
 			})
 
 			Convey("should not generate events that would not be delivered", func() {
@@ -281,22 +374,27 @@ func TestDispatchEvent(t *testing.T) {
 				So(err, ShouldBeNil)
 				So(provider.PersistentEventPayloads, ShouldBeNil)
 				So(store.nextSequenceNumber, ShouldEqual, 2)
-				So(store.persistedEvents, ShouldResemble, []*event.Event{
-					&event.Event{
-						ID:   "0000000000000001",
-						Type: event.UserSync,
-						Seq:  1,
-						Payload: event.UserSyncEvent{
-							User: model.User{
-								ID:         "user-id",
-								VerifyInfo: map[string]bool{"user@example.com": true},
-								Metadata:   map[string]interface{}{"user": true},
+				So(store.persistedEvents, ShouldResemble, []*Delivery{
+					&Delivery{
+						Event: &event.Event{
+							ID:   "0000000000000001",
+							Type: event.UserSync,
+							Seq:  1,
+							Payload: event.UserSyncEvent{
+								User: model.User{
+									ID:         "user-id",
+									VerifyInfo: map[string]bool{"user@example.com": true},
+									Metadata:   map[string]interface{}{"user": true},
+								},
+							},
+							Context: event.Context{
+								Timestamp: 1136214245,
+								UserID:    nil,
 							},
 						},
-						Context: event.Context{
-							Timestamp: 1136214245,
-							UserID:    nil,
-						},
+						Status:        DeliveryStatusPending,
+						CreatedAt:     timeProvider.TimeNowUTC,
+						NextAttemptAt: timeProvider.TimeNowUTC,
 					},
 				})
 			})
@@ -18,6 +18,11 @@ func (provider *MockProvider) DispatchEvent(payload event.Payload, user *model.U
 	return nil
 }
 
+func (provider *MockProvider) DispatchEventImmediately(payload event.NotificationPayload) error {
+	provider.DispatchedEvents = append(provider.DispatchedEvents, payload)
+	return nil
+}
+
 func (MockProvider) WillCommitTx() error {
 	return nil
 }
@@ -1,9 +1,39 @@
 package hook
 
-import "github.com/skygeario/skygear-server/pkg/auth/event"
+import (
+	"time"
+
+	"github.com/skygeario/skygear-server/pkg/auth/event"
+)
+
+type DeliveryStatus string
+
+const (
+	DeliveryStatusPending   DeliveryStatus = "pending"
+	DeliveryStatusDelivered DeliveryStatus = "delivered"
+	// DeliveryStatusFailed indicates the event exhausted all delivery attempts
+	// and is dead-lettered.
+	DeliveryStatusFailed DeliveryStatus = "failed"
+)
+
+// Delivery is an event persisted in the outbox, along with its delivery state.
+type Delivery struct {
+	Event         *event.Event
+	Status        DeliveryStatus
+	Attempts      int
+	CreatedAt     time.Time
+	NextAttemptAt time.Time
+	LastAttemptAt *time.Time
+	LastError     string
+}
 
 type Store interface {
 	NextSequenceNumber() (int64, error)
-	AddEvents(events []*event.Event) error
-	GetEventsForDelivery() ([]*event.Event, error)
+	AddDeliveries(deliveries []*Delivery) error
+	// AcquireDeliveryLock tries to acquire the delivery lock of the app until
+	// the transaction ends, so that events are delivered by one worker at a time.
+	AcquireDeliveryLock() (bool, error)
+	GetDelivery(seq int64) (*Delivery, error)
+	GetPendingDeliveries(limit int) ([]*Delivery, error)
+	UpdateDelivery(delivery *Delivery) error
 }
@@ -1,20 +1,26 @@
 package hook
 
 import (
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 
+	"github.com/jmoiron/sqlx"
+
 	"github.com/skygeario/skygear-server/pkg/auth/event"
 	"github.com/skygeario/skygear-server/pkg/core/db"
 )
 
 type storeImpl struct {
+	appID       string
 	sqlBuilder  db.SQLBuilder
 	sqlExecutor db.SQLExecutor
-	events      []*event.Event
 }
 
-func NewStore(builder db.SQLBuilder, executor db.SQLExecutor) Store {
+func NewStore(appID string, builder db.SQLBuilder, executor db.SQLExecutor) Store {
 	return &storeImpl{
+		appID:       appID,
 		sqlBuilder:  builder,
 		sqlExecutor: executor,
 	}
@@ -31,13 +37,152 @@ func (store *storeImpl) NextSequenceNumber() (seq int64, err error) {
 	return
 }
 
-func (store *storeImpl) AddEvents(events []*event.Event) error {
-	// TODO(webhook): persist events
-	store.events = append(store.events, events...)
-	return nil
+func (store *storeImpl) AddDeliveries(deliveries []*Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	builder := store.sqlBuilder.Tenant().
+		Insert(store.sqlBuilder.FullTableName("event")).
+		Columns(
+			"seq",
+			"type",
+			"data",
+			"status",
+			"attempts",
+			"created_at",
+			"next_attempt_at",
+			"last_attempt_at",
+			"last_error",
+		)
+	for _, d := range deliveries {
+		data, err := json.Marshal(d.Event)
+		if err != nil {
+			return err
+		}
+		builder = builder.Values(
+			d.Event.Seq,
+			d.Event.Type,
+			data,
+			d.Status,
+			d.Attempts,
+			d.CreatedAt,
+			d.NextAttemptAt,
+			d.LastAttemptAt,
+			d.LastError,
+		)
+	}
+
+	_, err := store.sqlExecutor.ExecWith(builder)
+	return err
 }
 
-func (store *storeImpl) GetEventsForDelivery() ([]*event.Event, error) {
-	// TODO(webhook): get events
-	return store.events, nil
+func (store *storeImpl) AcquireDeliveryLock() (locked bool, err error) {
+	builder := store.sqlBuilder.Global().
+		Select().
+		Column("pg_try_advisory_xact_lock(hashtext(?))", "auth_event_delivery:"+store.appID)
+	row, err := store.sqlExecutor.QueryRowWith(builder)
+	if err != nil {
+		return
+	}
+	err = row.Scan(&locked)
+	return
+}
+
+func (store *storeImpl) GetDelivery(seq int64) (*Delivery, error) {
+	builder := store.selectQuery().Where("seq = ?", seq)
+
+	row, err := store.sqlExecutor.QueryRowWith(builder)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := store.scan(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrEventNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func (store *storeImpl) GetPendingDeliveries(limit int) ([]*Delivery, error) {
+	builder := store.selectQuery().
+		Where("status = ?", DeliveryStatusPending).
+		OrderBy("seq").
+		Limit(uint64(limit))
+
+	rows, err := store.sqlExecutor.QueryWith(builder)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []*Delivery{}
+	for rows.Next() {
+		d, err := store.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, nil
+}
+
+func (store *storeImpl) UpdateDelivery(delivery *Delivery) error {
+	builder := store.sqlBuilder.Tenant().
+		Update(store.sqlBuilder.FullTableName("event")).
+		Set("status", delivery.Status).
+		Set("attempts", delivery.Attempts).
+		Set("next_attempt_at", delivery.NextAttemptAt).
+		Set("last_attempt_at", delivery.LastAttemptAt).
+		Set("last_error", delivery.LastError).
+		Where("seq = ?", delivery.Event.Seq)
+
+	_, err := store.sqlExecutor.ExecWith(builder)
+	return err
+}
+
+func (store *storeImpl) selectQuery() db.SelectBuilder {
+	return store.sqlBuilder.Tenant().
+		Select(
+			"data",
+			"status",
+			"attempts",
+			"created_at",
+			"next_attempt_at",
+			"last_attempt_at",
+			"last_error",
+		).
+		From(store.sqlBuilder.FullTableName("event"))
+}
+
+func (store *storeImpl) scan(scn sqlx.ColScanner) (*Delivery, error) {
+	d := &Delivery{}
+	var data []byte
+	err := scn.Scan(
+		&data,
+		&d.Status,
+		&d.Attempts,
+		&d.CreatedAt,
+		&d.NextAttemptAt,
+		&d.LastAttemptAt,
+		&d.LastError,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the payload as is, so the event is delivered as it was persisted.
+	payload := json.RawMessage{}
+	d.Event = &event.Event{Payload: &payload}
+	err = json.Unmarshal(data, d.Event)
+	if err != nil {
+		return nil, err
+	}
+	d.Event.Payload = payload
+
+	return d, nil
 }
@@ -1,10 +1,9 @@
 package hook
 
-import "github.com/skygeario/skygear-server/pkg/auth/event"
-
 type mockStore struct {
 	nextSequenceNumber int64
-	persistedEvents    []*event.Event
+	locked             bool
+	persistedEvents    []*Delivery
 }
 
 func newMockStore() *mockStore {
@@ -20,13 +19,47 @@ func (store *mockStore) NextSequenceNumber() (seq int64, err error) {
 	return
 }
 
-func (store *mockStore) AddEvents(events []*event.Event) error {
-	store.persistedEvents = append(store.persistedEvents, events...)
+func (store *mockStore) AddDeliveries(deliveries []*Delivery) error {
+	store.persistedEvents = append(store.persistedEvents, deliveries...)
 	return nil
 }
 
-func (store *mockStore) GetEventsForDelivery() ([]*event.Event, error) {
-	return store.persistedEvents, nil
+func (store *mockStore) AcquireDeliveryLock() (bool, error) {
+	return !store.locked, nil
+}
+
+func (store *mockStore) GetDelivery(seq int64) (*Delivery, error) {
+	for _, d := range store.persistedEvents {
+		if d.Event.Seq == seq {
+			_d := *d
+			return &_d, nil
+		}
+	}
+	return nil, ErrEventNotFound
+}
+
+func (store *mockStore) GetPendingDeliveries(limit int) ([]*Delivery, error) {
+	deliveries := []*Delivery{}
+	for _, d := range store.persistedEvents {
+		if len(deliveries) >= limit {
+			break
+		}
+		if d.Status == DeliveryStatusPending {
+			_d := *d
+			deliveries = append(deliveries, &_d)
+		}
+	}
+	return deliveries, nil
+}
+
+func (store *mockStore) UpdateDelivery(delivery *Delivery) error {
+	for i, d := range store.persistedEvents {
+		if d.Event.Seq == delivery.Event.Seq {
+			_d := *delivery
+			store.persistedEvents[i] = &_d
+		}
+	}
+	return nil
 }
 
 var _ Store = &mockStore{}
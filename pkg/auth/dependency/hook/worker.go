@@ -0,0 +1,203 @@
+package hook
+
+import (
+	"strconv"
+	gotime "time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/logging"
+	"github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+const (
+	// deliveryLeaseMargin is added to the delivery timeout to compute the
+	// time a claimed delivery is not attempted by other workers.
+	deliveryLeaseMargin      = 30 * gotime.Second
+	deliveryRetryBaseBackoff = 10 * gotime.Second
+	deliveryRetryMaxBackoff  = 1 * gotime.Hour
+)
+
+// DeliveryWorker delivers persisted events in sequence order.
+type DeliveryWorker struct {
+	TxContext        db.TxContext
+	Store            Store
+	Deliverer        Deliverer
+	TimeProvider     time.Provider
+	HookTenantConfig *config.HookTenantConfiguration
+	Logger           *logrus.Entry
+}
+
+func NewDeliveryWorker(
+	txContext db.TxContext,
+	store Store,
+	deliverer Deliverer,
+	timeProvider time.Provider,
+	hookTenantConfig *config.HookTenantConfiguration,
+	loggerFactory logging.Factory,
+) *DeliveryWorker {
+	return &DeliveryWorker{
+		TxContext:        txContext,
+		Store:            store,
+		Deliverer:        deliverer,
+		TimeProvider:     timeProvider,
+		HookTenantConfig: hookTenantConfig,
+		Logger:           loggerFactory.NewLogger("hook-delivery"),
+	}
+}
+
+// DeliverPendingEvents delivers pending events that are due, until an event
+// fails to be delivered, so that later events are never delivered before
+// earlier ones. Events not yet due are delivered by a later call, at their
+// next attempt time persisted in the store.
+//
+// Each delivery is claimed and recorded in its own transaction, so that no
+// transaction or lock is held while the event is being delivered. A claimed
+// delivery is not attempted by other workers until its lease expires.
+func (w *DeliveryWorker) DeliverPendingEvents() error {
+	for {
+		var d *Delivery
+		err := db.WithTx(w.TxContext, func() (err error) {
+			d, err = w.claimNextDelivery()
+			return
+		})
+		if err != nil {
+			return err
+		}
+		if d == nil {
+			return nil
+		}
+
+		now := w.TimeProvider.NowUTC()
+		err = w.Deliverer.DeliverNonBeforeEvent(d.Event, w.timeout())
+		d.Attempts++
+		d.LastAttemptAt = &now
+		if err == nil {
+			d.Status = DeliveryStatusDelivered
+			d.LastError = ""
+		} else {
+			d.LastError = err.Error()
+			if d.Attempts >= w.HookTenantConfig.AsyncHookMaxAttempts {
+				d.Status = DeliveryStatusFailed
+				w.Logger.WithError(err).WithFields(logrus.Fields{
+					"event_seq":  d.Event.Seq,
+					"event_type": d.Event.Type,
+					"attempts":   d.Attempts,
+				}).Error("event delivery failed permanently")
+			} else {
+				d.NextAttemptAt = now.Add(deliveryBackoff(d.Attempts))
+				w.Logger.WithError(err).WithFields(logrus.Fields{
+					"event_seq":  d.Event.Seq,
+					"event_type": d.Event.Type,
+					"attempts":   d.Attempts,
+				}).Warn("event delivery failed, will retry")
+			}
+		}
+
+		err = db.WithTx(w.TxContext, func() error {
+			return w.Store.UpdateDelivery(d)
+		})
+		if err != nil {
+			return err
+		}
+
+		if d.Status == DeliveryStatusPending {
+			return nil
+		}
+	}
+}
+
+// claimNextDelivery returns the earliest pending delivery if it is due, or
+// nil if there is none. The delivery is leased by postponing its next
+// attempt time, so that other workers would not deliver it, nor any later
+// event, in the meantime. It must be called within a transaction.
+func (w *DeliveryWorker) claimNextDelivery() (*Delivery, error) {
+	locked, err := w.Store.AcquireDeliveryLock()
+	if err != nil || !locked {
+		// Another worker is claiming a delivery, which it delivers instead.
+		return nil, err
+	}
+
+	deliveries, err := w.Store.GetPendingDeliveries(1)
+	if err != nil || len(deliveries) == 0 {
+		return nil, err
+	}
+	d := deliveries[0]
+
+	now := w.TimeProvider.NowUTC()
+	if d.NextAttemptAt.After(now) {
+		return nil, nil
+	}
+
+	leased := *d
+	leased.NextAttemptAt = now.Add(w.timeout() + deliveryLeaseMargin)
+	if err := w.Store.UpdateDelivery(&leased); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// Redeliver delivers the persisted event again, regardless of its delivery
+// status. The delivery is recorded in a separate transaction after the event
+// is delivered.
+func (w *DeliveryWorker) Redeliver(eventID string) (*Delivery, error) {
+	seq, err := strconv.ParseInt(eventID, 16, 64)
+	if err != nil {
+		return nil, ErrEventNotFound
+	}
+
+	var d *Delivery
+	err = db.WithTx(w.TxContext, func() (err error) {
+		d, err = w.Store.GetDelivery(seq)
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Manual deliveries do not count towards the automatic retry attempts.
+	now := w.TimeProvider.NowUTC()
+	deliverErr := w.Deliverer.DeliverNonBeforeEvent(d.Event, w.timeout())
+
+	err = db.WithTx(w.TxContext, func() error {
+		// The delivery may be updated by the automatic delivery meanwhile.
+		current, getErr := w.Store.GetDelivery(seq)
+		if getErr != nil {
+			return getErr
+		}
+		current.LastAttemptAt = &now
+		if deliverErr == nil {
+			current.Status = DeliveryStatusDelivered
+			current.LastError = ""
+		} else {
+			current.LastError = deliverErr.Error()
+		}
+		d = current
+		return w.Store.UpdateDelivery(current)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func (w *DeliveryWorker) timeout() gotime.Duration {
+	return gotime.Duration(w.HookTenantConfig.AsyncHookTimeout) * gotime.Second
+}
+
+// deliveryBackoff returns the delay before retrying a delivery that has been
+// attempted for the given number of times.
+func deliveryBackoff(attempts int) gotime.Duration {
+	backoff := deliveryRetryBaseBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= deliveryRetryMaxBackoff {
+			return deliveryRetryMaxBackoff
+		}
+	}
+	return backoff
+}
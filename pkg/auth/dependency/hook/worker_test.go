@@ -0,0 +1,163 @@
+package hook
+
+import (
+	"errors"
+	"testing"
+	gotime "time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/auth/event"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/logging"
+	"github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+func TestDeliveryWorker(t *testing.T) {
+	Convey("DeliveryWorker", t, func() {
+		now := gotime.Date(2006, 1, 2, 15, 4, 5, 0, gotime.UTC)
+		timeProvider := time.MockProvider{TimeNowUTC: now}
+		store := newMockStore()
+		deliverer := newMockDeliverer()
+
+		worker := NewDeliveryWorker(
+			db.NewMockTxContext(),
+			store,
+			deliverer,
+			&timeProvider,
+			&config.HookTenantConfiguration{
+				AsyncHookTimeout:     60,
+				AsyncHookMaxAttempts: 3,
+			},
+			logging.NewNullFactory(),
+		)
+
+		newDelivery := func(seq int64) *Delivery {
+			return &Delivery{
+				Event: &event.Event{
+					Seq:  seq,
+					Type: event.UserSync,
+				},
+				Status:        DeliveryStatusPending,
+				CreatedAt:     now,
+				NextAttemptAt: now,
+			}
+		}
+		store.persistedEvents = []*Delivery{newDelivery(1), newDelivery(2)}
+
+		Convey("should deliver pending events in order", func() {
+			err := worker.DeliverPendingEvents()
+			So(err, ShouldBeNil)
+
+			So(deliverer.NonBeforeEvents, ShouldHaveLength, 2)
+			So(deliverer.NonBeforeEvents[0].Event.Seq, ShouldEqual, 1)
+			So(deliverer.NonBeforeEvents[0].Timeout, ShouldEqual, 60*gotime.Second)
+			So(deliverer.NonBeforeEvents[1].Event.Seq, ShouldEqual, 2)
+			for _, d := range store.persistedEvents {
+				So(d.Status, ShouldEqual, DeliveryStatusDelivered)
+				So(d.Attempts, ShouldEqual, 1)
+				So(*d.LastAttemptAt, ShouldEqual, now)
+			}
+		})
+
+		Convey("should retry failed event with backoff before delivering later events", func() {
+			deliverer.DeliveryError = errors.New("connection refused")
+
+			err := worker.DeliverPendingEvents()
+			So(err, ShouldBeNil)
+
+			So(deliverer.NonBeforeEvents, ShouldHaveLength, 1)
+			So(store.persistedEvents[0].Status, ShouldEqual, DeliveryStatusPending)
+			So(store.persistedEvents[0].NextAttemptAt, ShouldEqual, now.Add(10*gotime.Second))
+			So(store.persistedEvents[0].Attempts, ShouldEqual, 1)
+			So(store.persistedEvents[0].LastError, ShouldEqual, "connection refused")
+			So(store.persistedEvents[1].Attempts, ShouldEqual, 0)
+
+			Convey("should not deliver before next attempt time", func() {
+				err := worker.DeliverPendingEvents()
+				So(err, ShouldBeNil)
+				So(deliverer.NonBeforeEvents, ShouldHaveLength, 1)
+			})
+
+			Convey("should deliver when next attempt time is reached", func() {
+				timeProvider.TimeNowUTC = now.Add(10 * gotime.Second)
+				deliverer.DeliveryError = nil
+
+				err := worker.DeliverPendingEvents()
+				So(err, ShouldBeNil)
+				So(deliverer.NonBeforeEvents, ShouldHaveLength, 3)
+				So(store.persistedEvents[0].Status, ShouldEqual, DeliveryStatusDelivered)
+				So(store.persistedEvents[0].Attempts, ShouldEqual, 2)
+				So(store.persistedEvents[0].LastError, ShouldEqual, "")
+				So(store.persistedEvents[1].Status, ShouldEqual, DeliveryStatusDelivered)
+			})
+		})
+
+		Convey("should dead-letter event after max attempts and continue", func() {
+			store.persistedEvents[0].Attempts = 2
+			deliverer.DeliveryError = errors.New("connection refused")
+
+			err := worker.DeliverPendingEvents()
+			So(err, ShouldBeNil)
+
+			So(store.persistedEvents[0].Status, ShouldEqual, DeliveryStatusFailed)
+			So(store.persistedEvents[0].Attempts, ShouldEqual, 3)
+			So(store.persistedEvents[1].Status, ShouldEqual, DeliveryStatusPending)
+			So(store.persistedEvents[1].Attempts, ShouldEqual, 1)
+		})
+
+		Convey("should redeliver dead-lettered event", func() {
+			store.persistedEvents[0].Status = DeliveryStatusFailed
+			store.persistedEvents[0].Attempts = 3
+			store.persistedEvents[0].LastError = "connection refused"
+
+			d, err := worker.Redeliver("0000000000000001")
+			So(err, ShouldBeNil)
+			So(d.Status, ShouldEqual, DeliveryStatusDelivered)
+			So(d.Attempts, ShouldEqual, 3)
+			So(d.LastError, ShouldEqual, "")
+			So(deliverer.NonBeforeEvents, ShouldHaveLength, 1)
+			So(store.persistedEvents[0].Status, ShouldEqual, DeliveryStatusDelivered)
+		})
+
+		Convey("should not redeliver unknown event", func() {
+			_, err := worker.Redeliver("not-an-event")
+			So(err, ShouldBeError, ErrEventNotFound)
+			_, err = worker.Redeliver("00000000000000ff")
+			So(err, ShouldBeError, ErrEventNotFound)
+		})
+
+		Convey("should not deliver if another worker is claiming delivery", func() {
+			store.locked = true
+
+			err := worker.DeliverPendingEvents()
+			So(err, ShouldBeNil)
+			So(deliverer.NonBeforeEvents, ShouldBeEmpty)
+		})
+
+		Convey("should not deliver events claimed by another worker", func() {
+			deliverer.OnDeliver = func() {
+				// Another worker starts while the event is being delivered.
+				err := worker.DeliverPendingEvents()
+				So(err, ShouldBeNil)
+			}
+
+			err := worker.DeliverPendingEvents()
+			So(err, ShouldBeNil)
+			So(deliverer.NonBeforeEvents, ShouldHaveLength, 2)
+			So(store.persistedEvents[0].Status, ShouldEqual, DeliveryStatusDelivered)
+			So(store.persistedEvents[1].Status, ShouldEqual, DeliveryStatusDelivered)
+		})
+	})
+}
+
+func TestDeliveryBackoff(t *testing.T) {
+	Convey("deliveryBackoff", t, func() {
+		So(deliveryBackoff(1), ShouldEqual, 10*gotime.Second)
+		So(deliveryBackoff(2), ShouldEqual, 20*gotime.Second)
+		So(deliveryBackoff(3), ShouldEqual, 40*gotime.Second)
+		So(deliveryBackoff(10), ShouldEqual, 1*gotime.Hour)
+		So(deliveryBackoff(100), ShouldEqual, 1*gotime.Hour)
+	})
+}
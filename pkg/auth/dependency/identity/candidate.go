@@ -12,22 +12,27 @@ const (
 
 	CandidateKeyEmail = "email"
 
-	CandidateKeyProviderType      = "provider_type"
-	CandidateKeyProviderAlias     = "provider_alias"
-	CandidateKeyProviderSubjectID = "provider_subject_id"
+	CandidateKeyProviderType        = "provider_type"
+	CandidateKeyProviderAlias       = "provider_alias"
+	CandidateKeyProviderSubjectID   = "provider_subject_id"
+	CandidateKeyProviderDisplayName = "provider_display_name"
 
 	CandidateKeyLoginIDType  = "login_id_type"
 	CandidateKeyLoginIDKey   = "login_id_key"
 	CandidateKeyLoginIDValue = "login_id_value"
+
+	CandidateKeyLDAPUsername    = "ldap_username"
+	CandidateKeyLDAPDisplayName = "ldap_display_name"
 )
 
 func NewOAuthCandidate(c *config.OAuthProviderConfiguration) Candidate {
 	return Candidate{
-		CandidateKeyType:              string(authn.IdentityTypeOAuth),
-		CandidateKeyEmail:             "",
-		CandidateKeyProviderType:      string(c.Type),
-		CandidateKeyProviderAlias:     string(c.ID),
-		CandidateKeyProviderSubjectID: "",
+		CandidateKeyType:                string(authn.IdentityTypeOAuth),
+		CandidateKeyEmail:               "",
+		CandidateKeyProviderType:        string(c.Type),
+		CandidateKeyProviderAlias:       string(c.ID),
+		CandidateKeyProviderSubjectID:   "",
+		CandidateKeyProviderDisplayName: c.DisplayName,
 	}
 }
 
@@ -40,3 +45,12 @@ func NewLoginIDCandidate(c *config.LoginIDKeyConfiguration) Candidate {
 		CandidateKeyLoginIDValue: "",
 	}
 }
+
+func NewLDAPCandidate(c *config.LDAPConfiguration) Candidate {
+	return Candidate{
+		CandidateKeyType:            string(authn.IdentityTypeLDAP),
+		CandidateKeyEmail:           "",
+		CandidateKeyLDAPUsername:    "",
+		CandidateKeyLDAPDisplayName: c.DisplayName,
+	}
+}
@@ -30,4 +30,15 @@ const (
 	IdentityClaimAnonymousKeyID string = "https://auth.skygear.io/claims/anonymous/key_id"
 	// IdentityClaimAnonymousKey is a claim with a string value containing anonymous public key JWK.
 	IdentityClaimAnonymousKey string = "https://auth.skygear.io/claims/anonymous/key"
+
+	// IdentityClaimLDAPSubjectID is a claim with a string value containing the unique ID of the directory entry.
+	IdentityClaimLDAPSubjectID string = "https://auth.skygear.io/claims/ldap/subject_id"
+	// IdentityClaimLDAPUsername is a claim with a string value containing the username used to sign in.
+	IdentityClaimLDAPUsername string = "https://auth.skygear.io/claims/ldap/username"
+	// IdentityClaimLDAPDN is a claim with a string value containing the DN of the directory entry.
+	IdentityClaimLDAPDN string = "https://auth.skygear.io/claims/ldap/dn"
+	// IdentityClaimLDAPClaims is a claim with a map value containing mapped OIDC claims.
+	IdentityClaimLDAPClaims string = "https://auth.skygear.io/claims/ldap/claims"
+	// IdentityClaimLDAPAttributes is a claim with a map value containing attributes mapped to user metadata.
+	IdentityClaimLDAPAttributes string = "https://auth.skygear.io/claims/ldap/attributes"
 )
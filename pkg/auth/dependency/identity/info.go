@@ -44,6 +44,11 @@ func (i *Info) ToModel() model.Identity {
 		case IdentityClaimAnonymousKey:
 			continue
 
+		// They are already exposed as top-level claims and user
+		// metadata respectively.
+		case IdentityClaimLDAPClaims, IdentityClaimLDAPAttributes:
+			continue
+
 		}
 		claims[key] = value
 	}
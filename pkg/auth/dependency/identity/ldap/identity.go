@@ -0,0 +1,19 @@
+package ldap
+
+import (
+	"time"
+)
+
+type Identity struct {
+	ID     string
+	UserID string
+	// SubjectID is the value of the configured ID attribute, which is
+	// stable across renames unlike the DN.
+	SubjectID  string
+	Username   string
+	DN         string
+	Claims     map[string]interface{}
+	Attributes map[string]interface{}
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
@@ -0,0 +1,236 @@
+package ldap
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity"
+	"github.com/skygeario/skygear-server/pkg/core/authn"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+)
+
+type Store struct {
+	SQLBuilder  db.SQLBuilder
+	SQLExecutor db.SQLExecutor
+}
+
+func (s *Store) selectQuery() db.SelectBuilder {
+	return s.SQLBuilder.Tenant().
+		Select(
+			"p.id",
+			"p.user_id",
+			"l.subject_id",
+			"l.username",
+			"l.dn",
+			"l.claims",
+			"l.attributes",
+			"l.created_at",
+			"l.updated_at",
+		).
+		From(s.SQLBuilder.FullTableName("identity"), "p").
+		Join(s.SQLBuilder.FullTableName("identity_ldap"), "l", "p.id = l.identity_id")
+}
+
+func (s *Store) scan(scn db.Scanner) (*Identity, error) {
+	i := &Identity{}
+	var claims []byte
+	var attributes []byte
+
+	err := scn.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.SubjectID,
+		&i.Username,
+		&i.DN,
+		&claims,
+		&attributes,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, identity.ErrIdentityNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(claims, &i.Claims); err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(attributes, &i.Attributes); err != nil {
+		return nil, err
+	}
+
+	return i, nil
+}
+
+func (s *Store) scanAll(q db.SelectBuilder) ([]*Identity, error) {
+	rows, err := s.SQLExecutor.QueryWith(q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var is []*Identity
+	for rows.Next() {
+		i, err := s.scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		is = append(is, i)
+	}
+
+	return is, nil
+}
+
+func (s *Store) List(userID string) ([]*Identity, error) {
+	q := s.selectQuery().Where("p.user_id = ?", userID)
+	return s.scanAll(q)
+}
+
+func (s *Store) ListByClaim(name string, value string) ([]*Identity, error) {
+	q := s.selectQuery().
+		Where("(l.claims #>> ?) = ?", pq.Array([]string{name}), value)
+	return s.scanAll(q)
+}
+
+func (s *Store) Get(userID string, id string) (*Identity, error) {
+	q := s.selectQuery().Where("p.user_id = ? AND p.id = ?", userID, id)
+	rows, err := s.SQLExecutor.QueryRowWith(q)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.scan(rows)
+}
+
+func (s *Store) GetBySubjectID(subjectID string) (*Identity, error) {
+	q := s.selectQuery().Where("l.subject_id = ?", subjectID)
+	rows, err := s.SQLExecutor.QueryRowWith(q)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.scan(rows)
+}
+
+func (s *Store) Create(i *Identity) error {
+	builder := s.SQLBuilder.Tenant().
+		Insert(s.SQLBuilder.FullTableName("identity")).
+		Columns(
+			"id",
+			"type",
+			"user_id",
+		).
+		Values(
+			i.ID,
+			authn.IdentityTypeLDAP,
+			i.UserID,
+		)
+
+	_, err := s.SQLExecutor.ExecWith(builder)
+	if err != nil {
+		return err
+	}
+
+	claims, err := json.Marshal(i.Claims)
+	if err != nil {
+		return err
+	}
+	attributes, err := json.Marshal(i.Attributes)
+	if err != nil {
+		return err
+	}
+
+	q := s.SQLBuilder.Tenant().
+		Insert(s.SQLBuilder.FullTableName("identity_ldap")).
+		Columns(
+			"identity_id",
+			"subject_id",
+			"username",
+			"dn",
+			"claims",
+			"attributes",
+			"created_at",
+			"updated_at",
+		).
+		Values(
+			i.ID,
+			i.SubjectID,
+			i.Username,
+			i.DN,
+			claims,
+			attributes,
+			i.CreatedAt,
+			i.UpdatedAt,
+		)
+
+	_, err = s.SQLExecutor.ExecWith(q)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Store) Update(i *Identity) error {
+	claims, err := json.Marshal(i.Claims)
+	if err != nil {
+		return err
+	}
+	attributes, err := json.Marshal(i.Attributes)
+	if err != nil {
+		return err
+	}
+
+	q := s.SQLBuilder.Tenant().
+		Update(s.SQLBuilder.FullTableName("identity_ldap")).
+		Set("username", i.Username).
+		Set("dn", i.DN).
+		Set("claims", claims).
+		Set("attributes", attributes).
+		Set("updated_at", i.UpdatedAt).
+		Where("identity_id = ?", i.ID)
+
+	result, err := s.SQLExecutor.ExecWith(q)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return identity.ErrIdentityNotFound
+	} else if rowsAffected > 1 {
+		panic(fmt.Sprintf("identity_ldap: want 1 row updated, got %v", rowsAffected))
+	}
+
+	return nil
+}
+
+func (s *Store) Delete(i *Identity) error {
+	q := s.SQLBuilder.Tenant().
+		Delete(s.SQLBuilder.FullTableName("identity_ldap")).
+		Where("identity_id = ?", i.ID)
+
+	_, err := s.SQLExecutor.ExecWith(q)
+	if err != nil {
+		return err
+	}
+
+	q = s.SQLBuilder.Tenant().
+		Delete(s.SQLBuilder.FullTableName("identity")).
+		Where("id = ?", i.ID)
+
+	_, err = s.SQLExecutor.ExecWith(q)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -66,6 +66,41 @@ func NewProviderID(c config.OAuthProviderConfiguration) ProviderID {
 		// Since Apple has private relay to hide the real email,
 		// the user may not be associate their account.
 		keys["team_id"] = c.TeamID
+	case config.OAuthProviderTypeOIDC:
+		// Generic OIDC providers are identified by their discovery document.
+		// sub is unique within the issuer, but it may be pairwise and
+		// scoped to client_id.
+		// Therefore, ProviderID is Type + discovery_url + client_id.
+		//
+		// Rotating the OAuth application is problematic.
+		// But if email remains unchanged, the user can associate their account.
+		keys["discovery_url"] = c.DiscoveryURL
+		keys["client_id"] = c.ClientID
+	case config.OAuthProviderTypeGitHub:
+		// GitHub does NOT support OIDC.
+		// GitHub user ID is public, not scoped to anything.
+		// Therefore, ProviderID is simply Type.
+		//
+		// Rotating the OAuth application is OK.
+		break
+	case config.OAuthProviderTypeGitLab:
+		// GitLab user ID is public, but is scoped to the instance.
+		// Therefore, ProviderID is Type + base_url.
+		//
+		// Rotating the OAuth application is OK.
+		// But moving the instance to another URL is problematic.
+		// But if email remains unchanged, the user can associate their account.
+		keys["base_url"] = c.BaseURL
+	case config.OAuthProviderTypeSAML:
+		// SAML name ID is unique within the IdP, but it may be transient
+		// or pairwise and scoped to SP, depending on IdP configuration.
+		// Therefore, ProviderID is Type + idp_entity_id.
+		// The SP entity ID is derived from provider ID so it is stable.
+		//
+		// Rotating the IdP certificate is OK.
+		// But migrating to another IdP is problematic.
+		// But if email remains unchanged, the user can associate their account.
+		keys["idp_entity_id"] = c.IdPEntityID
 	}
 
 	return ProviderID{
@@ -3,6 +3,7 @@ package provider
 import (
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/anonymous"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/ldap"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/loginid"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/oauth"
 	"github.com/skygeario/skygear-server/pkg/core/authn"
@@ -107,3 +108,50 @@ func anonymousFromIdentityInfo(userID string, i *identity.Info) *anonymous.Ident
 	}
 	return a
 }
+
+func ldapToIdentityInfo(l *ldap.Identity) *identity.Info {
+	claims := map[string]interface{}{
+		identity.IdentityClaimLDAPSubjectID:  l.SubjectID,
+		identity.IdentityClaimLDAPUsername:   l.Username,
+		identity.IdentityClaimLDAPDN:         l.DN,
+		identity.IdentityClaimLDAPClaims:     l.Claims,
+		identity.IdentityClaimLDAPAttributes: l.Attributes,
+	}
+	for k, v := range l.Claims {
+		claims[k] = v
+	}
+
+	return &identity.Info{
+		Type:     authn.IdentityTypeLDAP,
+		ID:       l.ID,
+		Claims:   claims,
+		Identity: l,
+	}
+}
+
+func ldapFromIdentityInfo(userID string, i *identity.Info) *ldap.Identity {
+	l := &ldap.Identity{
+		ID:         i.ID,
+		UserID:     userID,
+		Claims:     map[string]interface{}{},
+		Attributes: map[string]interface{}{},
+	}
+	for k, v := range i.Claims {
+		switch k {
+		case identity.IdentityClaimLDAPSubjectID:
+			l.SubjectID = v.(string)
+		case identity.IdentityClaimLDAPUsername:
+			l.Username = v.(string)
+		case identity.IdentityClaimLDAPDN:
+			l.DN = v.(string)
+		case identity.IdentityClaimLDAPClaims:
+			// Standard claims are also top-level claims.
+			continue
+		case identity.IdentityClaimLDAPAttributes:
+			l.Attributes = v.(map[string]interface{})
+		default:
+			l.Claims[k] = v
+		}
+	}
+	return l
+}
@@ -11,6 +11,7 @@ func ProvideProvider(
 	loginID LoginIDIdentityProvider,
 	oauth OAuthIdentityProvider,
 	anonymous AnonymousIdentityProvider,
+	ldap LDAPIdentityProvider,
 ) *Provider {
 	return &Provider{
 		Authentication: c.AppConfig.Authentication,
@@ -18,6 +19,7 @@ func ProvideProvider(
 		LoginID:        loginID,
 		OAuth:          oauth,
 		Anonymous:      anonymous,
+		LDAP:           ldap,
 	}
 }
 
@@ -7,6 +7,7 @@ import (
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/anonymous"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/ldap"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/loginid"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/oauth"
 	"github.com/skygeario/skygear-server/pkg/core/auth/metadata"
@@ -60,12 +61,32 @@ type AnonymousIdentityProvider interface {
 	Delete(i *anonymous.Identity) error
 }
 
+type LDAPIdentityProvider interface {
+	Get(userID, id string) (*ldap.Identity, error)
+	List(userID string) ([]*ldap.Identity, error)
+	GetBySubjectID(subjectID string) (*ldap.Identity, error)
+	ListByClaim(name string, value string) ([]*ldap.Identity, error)
+	New(
+		userID string,
+		subjectID string,
+		username string,
+		dn string,
+		claims map[string]interface{},
+		attributes map[string]interface{},
+	) *ldap.Identity
+	Create(i *ldap.Identity) error
+	Update(i *ldap.Identity) error
+	Delete(i *ldap.Identity) error
+	CheckDuplicated(standardClaims map[string]string, userID string) error
+}
+
 type Provider struct {
 	Authentication *config.AuthenticationConfiguration
 	Identity       *config.IdentityConfiguration
 	LoginID        LoginIDIdentityProvider
 	OAuth          OAuthIdentityProvider
 	Anonymous      AnonymousIdentityProvider
+	LDAP           LDAPIdentityProvider
 }
 
 func (a *Provider) Get(userID string, typ authn.IdentityType, id string) (*identity.Info, error) {
@@ -90,6 +111,13 @@ func (a *Provider) Get(userID string, typ authn.IdentityType, id string) (*ident
 			return nil, err
 		}
 		return anonymousToIdentityInfo(a), nil
+
+	case authn.IdentityTypeLDAP:
+		l, err := a.LDAP.Get(userID, id)
+		if err != nil {
+			return nil, err
+		}
+		return ldapToIdentityInfo(l), nil
 	}
 
 	panic("interaction_adaptors: unknown identity type " + typ)
@@ -123,6 +151,14 @@ func (a *Provider) GetByClaims(typ authn.IdentityType, claims map[string]interfa
 			return "", nil, err
 		}
 		return a.UserID, anonymousToIdentityInfo(a), nil
+
+	case authn.IdentityTypeLDAP:
+		subjectID := extractLDAPSubjectID(claims)
+		l, err := a.LDAP.GetBySubjectID(subjectID)
+		if err != nil {
+			return "", nil, err
+		}
+		return l.UserID, ldapToIdentityInfo(l), nil
 	}
 
 	panic("interaction_adaptors: unknown identity type " + typ)
@@ -183,6 +219,14 @@ func (a *Provider) ListByClaims(claims map[string]string) ([]*identity.Info, err
 			all = append(all, a.toIdentityInfo(i))
 		}
 
+		lds, err := a.LDAP.ListByClaim(name, value)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range lds {
+			all = append(all, ldapToIdentityInfo(i))
+		}
+
 		// Skip anonymous: no standard claims for anonymous identity
 	}
 
@@ -219,6 +263,15 @@ func (a *Provider) ListByUser(userID string) ([]*identity.Info, error) {
 		iis = append(iis, anonymousToIdentityInfo(i))
 	}
 
+	// ldap
+	lds, err := a.LDAP.List(userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range lds {
+		iis = append(iis, ldapToIdentityInfo(i))
+	}
+
 	return iis, nil
 }
 
@@ -248,6 +301,11 @@ func (a *Provider) New(userID string, typ authn.IdentityType, claims map[string]
 		keyID, key := extractAnonymousClaims(claims)
 		a := a.Anonymous.New(userID, keyID, []byte(key))
 		return anonymousToIdentityInfo(a), nil
+	case authn.IdentityTypeLDAP:
+		subjectID, username, dn := extractLDAPClaims(claims)
+		ldapClaims, attributes := extractLDAPAttributeClaims(claims)
+		l := a.LDAP.New(userID, subjectID, username, dn, ldapClaims, attributes)
+		return ldapToIdentityInfo(l), nil
 	}
 
 	panic("interaction_adaptors: unknown identity type " + typ)
@@ -274,6 +332,17 @@ func (a *Provider) WithClaims(userID string, ii *identity.Info, claims map[strin
 		return a.toIdentityInfo(i), nil
 	case authn.IdentityTypeAnonymous:
 		panic("interaction_adaptors: update no support for identity type " + ii.Type)
+	case authn.IdentityTypeLDAP:
+		// Directory entry may be renamed or its attributes may be
+		// changed, so they are refreshed on each login.
+		_, username, dn := extractLDAPClaims(claims)
+		ldapClaims, attributes := extractLDAPAttributeClaims(claims)
+		i := ldapFromIdentityInfo(userID, ii)
+		i.Username = username
+		i.DN = dn
+		i.Claims = ldapClaims
+		i.Attributes = attributes
+		return ldapToIdentityInfo(i), nil
 	}
 	panic("interaction_adaptors: unknown identity type " + ii.Type)
 }
@@ -299,6 +368,12 @@ func (a *Provider) CreateAll(userID string, is []*identity.Info) error {
 				return err
 			}
 
+		case authn.IdentityTypeLDAP:
+			identity := ldapFromIdentityInfo(userID, i)
+			if err := a.LDAP.Create(identity); err != nil {
+				return err
+			}
+
 		default:
 			panic("interaction_adaptors: unknown identity type " + i.Type)
 		}
@@ -321,6 +396,11 @@ func (a *Provider) UpdateAll(userID string, is []*identity.Info) error {
 			}
 		case authn.IdentityTypeAnonymous:
 			panic("interaction_adaptors: update no support for identity type " + i.Type)
+		case authn.IdentityTypeLDAP:
+			identity := ldapFromIdentityInfo(userID, i)
+			if err := a.LDAP.Update(identity); err != nil {
+				return err
+			}
 		default:
 			panic("interaction_adaptors: unknown identity type " + i.Type)
 		}
@@ -346,6 +426,11 @@ func (a *Provider) DeleteAll(userID string, is []*identity.Info) error {
 			if err := a.Anonymous.Delete(identity); err != nil {
 				return err
 			}
+		case authn.IdentityTypeLDAP:
+			identity := ldapFromIdentityInfo(userID, i)
+			if err := a.LDAP.Delete(identity); err != nil {
+				return err
+			}
 		default:
 			panic("interaction_adaptors: unknown identity type " + i.Type)
 		}
@@ -356,6 +441,7 @@ func (a *Provider) DeleteAll(userID string, is []*identity.Info) error {
 func (a *Provider) Validate(is []*identity.Info) error {
 	var loginIDs []loginid.LoginID
 	var oauthProviderIDs []oauth.ProviderID
+	ldapCount := 0
 	for _, i := range is {
 		if i.Type == authn.IdentityTypeLoginID {
 			loginID := extractLoginIDClaims(i.Claims)
@@ -363,9 +449,16 @@ func (a *Provider) Validate(is []*identity.Info) error {
 		} else if i.Type == authn.IdentityTypeOAuth {
 			providerID, _ := extractOAuthClaims(i.Claims)
 			oauthProviderIDs = append(oauthProviderIDs, providerID)
+		} else if i.Type == authn.IdentityTypeLDAP {
+			ldapCount++
 		}
 	}
 
+	// user can have at most one ldap identity
+	if ldapCount > 1 {
+		return identity.ErrIdentityAlreadyExists
+	}
+
 	// if there is IdentityInfo with type is loginid
 	if len(loginIDs) > 0 {
 		if err := a.LoginID.Validate(loginIDs); err != nil {
@@ -416,6 +509,8 @@ func (a *Provider) RelateIdentityToAuthenticator(is identity.Spec, as *authentic
 		return nil
 	case authn.IdentityTypeAnonymous:
 		return nil
+	case authn.IdentityTypeLDAP:
+		return as
 	}
 
 	panic("interaction_adaptors: unknown identity type " + is.Type)
@@ -442,6 +537,11 @@ func (a *Provider) CheckIdentityDuplicated(is *identity.Info, userID string) (er
 		return err
 	}
 
+	err = a.LDAP.CheckDuplicated(claims, userID)
+	if err != nil {
+		return err
+	}
+
 	// No need to consider anonymous identity
 
 	return
@@ -450,6 +550,7 @@ func (a *Provider) CheckIdentityDuplicated(is *identity.Info, userID string) (er
 func (a *Provider) ListCandidates(userID string) (out []identity.Candidate, err error) {
 	var loginIDs []*loginid.Identity
 	var oauths []*oauth.Identity
+	var ldaps []*ldap.Identity
 
 	if userID != "" {
 		loginIDs, err = a.LoginID.List(userID)
@@ -460,6 +561,10 @@ func (a *Provider) ListCandidates(userID string) (out []identity.Candidate, err
 		if err != nil {
 			return
 		}
+		ldaps, err = a.LDAP.List(userID)
+		if err != nil {
+			return
+		}
 		// No need to consider anonymous identity
 	}
 
@@ -492,6 +597,15 @@ func (a *Provider) ListCandidates(userID string) (out []identity.Candidate, err
 				}
 				out = append(out, candidate)
 			}
+		case string(authn.IdentityTypeLDAP):
+			candidate := identity.NewLDAPCandidate(a.Identity.LDAP)
+			for _, iden := range ldaps {
+				candidate[identity.CandidateKeyLDAPUsername] = iden.Username
+				if email, ok := iden.Claims["email"].(string); ok {
+					candidate[identity.CandidateKeyEmail] = email
+				}
+			}
+			out = append(out, candidate)
 		}
 	}
 
@@ -597,6 +711,38 @@ func extractAnonymousClaims(claims map[string]interface{}) (keyID string, key st
 	return
 }
 
+func extractLDAPSubjectID(claims map[string]interface{}) string {
+	subjectID, ok := claims[identity.IdentityClaimLDAPSubjectID].(string)
+	if !ok {
+		panic(fmt.Sprintf("interaction_adaptors: expect string subject ID claim, got %T", claims[identity.IdentityClaimLDAPSubjectID]))
+	}
+	return subjectID
+}
+
+func extractLDAPClaims(claims map[string]interface{}) (subjectID string, username string, dn string) {
+	subjectID = extractLDAPSubjectID(claims)
+	username, ok := claims[identity.IdentityClaimLDAPUsername].(string)
+	if !ok {
+		panic(fmt.Sprintf("interaction_adaptors: expect string username claim, got %T", claims[identity.IdentityClaimLDAPUsername]))
+	}
+	dn, ok = claims[identity.IdentityClaimLDAPDN].(string)
+	if !ok {
+		panic(fmt.Sprintf("interaction_adaptors: expect string DN claim, got %T", claims[identity.IdentityClaimLDAPDN]))
+	}
+	return
+}
+
+func extractLDAPAttributeClaims(claims map[string]interface{}) (ldapClaims map[string]interface{}, attributes map[string]interface{}) {
+	var ok bool
+	if ldapClaims, ok = claims[identity.IdentityClaimLDAPClaims].(map[string]interface{}); !ok {
+		ldapClaims = map[string]interface{}{}
+	}
+	if attributes, ok = claims[identity.IdentityClaimLDAPAttributes].(map[string]interface{}); !ok {
+		attributes = map[string]interface{}{}
+	}
+	return
+}
+
 func extractStandardClaims(claims map[string]interface{}) map[string]string {
 	standardClaims := map[string]string{}
 	email, hasEmail := claims[string(metadata.Email)].(string)
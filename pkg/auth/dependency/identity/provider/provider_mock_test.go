@@ -7,6 +7,7 @@ package provider
 import (
 	gomock "github.com/golang/mock/gomock"
 	anonymous "github.com/skygeario/skygear-server/pkg/auth/dependency/identity/anonymous"
+	ldap "github.com/skygeario/skygear-server/pkg/auth/dependency/identity/ldap"
 	loginid "github.com/skygeario/skygear-server/pkg/auth/dependency/identity/loginid"
 	oauth "github.com/skygeario/skygear-server/pkg/auth/dependency/identity/oauth"
 	config "github.com/skygeario/skygear-server/pkg/core/config"
@@ -505,3 +506,156 @@ func (mr *MockAnonymousIdentityProviderMockRecorder) Delete(i interface{}) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAnonymousIdentityProvider)(nil).Delete), i)
 }
+
+// MockLDAPIdentityProvider is a mock of LDAPIdentityProvider interface
+type MockLDAPIdentityProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockLDAPIdentityProviderMockRecorder
+}
+
+// MockLDAPIdentityProviderMockRecorder is the mock recorder for MockLDAPIdentityProvider
+type MockLDAPIdentityProviderMockRecorder struct {
+	mock *MockLDAPIdentityProvider
+}
+
+// NewMockLDAPIdentityProvider creates a new mock instance
+func NewMockLDAPIdentityProvider(ctrl *gomock.Controller) *MockLDAPIdentityProvider {
+	mock := &MockLDAPIdentityProvider{ctrl: ctrl}
+	mock.recorder = &MockLDAPIdentityProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockLDAPIdentityProvider) EXPECT() *MockLDAPIdentityProviderMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method
+func (m *MockLDAPIdentityProvider) Get(userID, id string) (*ldap.Identity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", userID, id)
+	ret0, _ := ret[0].(*ldap.Identity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockLDAPIdentityProviderMockRecorder) Get(userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockLDAPIdentityProvider)(nil).Get), userID, id)
+}
+
+// List mocks base method
+func (m *MockLDAPIdentityProvider) List(userID string) ([]*ldap.Identity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", userID)
+	ret0, _ := ret[0].([]*ldap.Identity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List
+func (mr *MockLDAPIdentityProviderMockRecorder) List(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockLDAPIdentityProvider)(nil).List), userID)
+}
+
+// GetBySubjectID mocks base method
+func (m *MockLDAPIdentityProvider) GetBySubjectID(subjectID string) (*ldap.Identity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySubjectID", subjectID)
+	ret0, _ := ret[0].(*ldap.Identity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySubjectID indicates an expected call of GetBySubjectID
+func (mr *MockLDAPIdentityProviderMockRecorder) GetBySubjectID(subjectID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySubjectID", reflect.TypeOf((*MockLDAPIdentityProvider)(nil).GetBySubjectID), subjectID)
+}
+
+// ListByClaim mocks base method
+func (m *MockLDAPIdentityProvider) ListByClaim(name, value string) ([]*ldap.Identity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByClaim", name, value)
+	ret0, _ := ret[0].([]*ldap.Identity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByClaim indicates an expected call of ListByClaim
+func (mr *MockLDAPIdentityProviderMockRecorder) ListByClaim(name, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByClaim", reflect.TypeOf((*MockLDAPIdentityProvider)(nil).ListByClaim), name, value)
+}
+
+// New mocks base method
+func (m *MockLDAPIdentityProvider) New(userID, subjectID, username, dn string, claims, attributes map[string]interface{}) *ldap.Identity {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "New", userID, subjectID, username, dn, claims, attributes)
+	ret0, _ := ret[0].(*ldap.Identity)
+	return ret0
+}
+
+// New indicates an expected call of New
+func (mr *MockLDAPIdentityProviderMockRecorder) New(userID, subjectID, username, dn, claims, attributes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "New", reflect.TypeOf((*MockLDAPIdentityProvider)(nil).New), userID, subjectID, username, dn, claims, attributes)
+}
+
+// Create mocks base method
+func (m *MockLDAPIdentityProvider) Create(i *ldap.Identity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", i)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create
+func (mr *MockLDAPIdentityProviderMockRecorder) Create(i interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLDAPIdentityProvider)(nil).Create), i)
+}
+
+// Update mocks base method
+func (m *MockLDAPIdentityProvider) Update(i *ldap.Identity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", i)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update
+func (mr *MockLDAPIdentityProviderMockRecorder) Update(i interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockLDAPIdentityProvider)(nil).Update), i)
+}
+
+// Delete mocks base method
+func (m *MockLDAPIdentityProvider) Delete(i *ldap.Identity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", i)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete
+func (mr *MockLDAPIdentityProviderMockRecorder) Delete(i interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockLDAPIdentityProvider)(nil).Delete), i)
+}
+
+// CheckDuplicated mocks base method
+func (m *MockLDAPIdentityProvider) CheckDuplicated(standardClaims map[string]string, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckDuplicated", standardClaims, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckDuplicated indicates an expected call of CheckDuplicated
+func (mr *MockLDAPIdentityProviderMockRecorder) CheckDuplicated(standardClaims, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckDuplicated", reflect.TypeOf((*MockLDAPIdentityProvider)(nil).CheckDuplicated), standardClaims, userID)
+}
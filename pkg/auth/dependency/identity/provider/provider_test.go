@@ -7,6 +7,7 @@ import (
 	. "github.com/smartystreets/goconvey/convey"
 
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/ldap"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/loginid"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/oauth"
 	"github.com/skygeario/skygear-server/pkg/core/config"
@@ -19,15 +20,18 @@ func TestProviderListCandidates(t *testing.T) {
 
 		loginIDProvider := NewMockLoginIDIdentityProvider(ctrl)
 		oauthProvider := NewMockOAuthIdentityProvider(ctrl)
+		ldapProvider := NewMockLDAPIdentityProvider(ctrl)
 
 		p := &Provider{
 			Authentication: &config.AuthenticationConfiguration{},
 			Identity: &config.IdentityConfiguration{
 				OAuth:   &config.OAuthConfiguration{},
 				LoginID: &config.LoginIDConfiguration{},
+				LDAP:    &config.LDAPConfiguration{},
 			},
 			LoginID: loginIDProvider,
 			OAuth:   oauthProvider,
+			LDAP:    ldapProvider,
 		}
 
 		Convey("no candidates", func() {
@@ -49,11 +53,12 @@ func TestProviderListCandidates(t *testing.T) {
 			So(err, ShouldBeNil)
 			So(actual, ShouldResemble, []identity.Candidate{
 				{
-					"type":                "oauth",
-					"email":               "",
-					"provider_type":       "google",
-					"provider_alias":      "google",
-					"provider_subject_id": "",
+					"type":                  "oauth",
+					"email":                 "",
+					"provider_type":         "google",
+					"provider_alias":        "google",
+					"provider_subject_id":   "",
+					"provider_display_name": "",
 				},
 			})
 		})
@@ -120,6 +125,7 @@ func TestProviderListCandidates(t *testing.T) {
 				},
 			}, nil)
 			oauthProvider.EXPECT().List(userID).Return(nil, nil)
+			ldapProvider.EXPECT().List(userID).Return(nil, nil)
 
 			actual, err := p.ListCandidates(userID)
 			So(err, ShouldBeNil)
@@ -158,16 +164,48 @@ func TestProviderListCandidates(t *testing.T) {
 					},
 				},
 			}, nil)
+			ldapProvider.EXPECT().List(userID).Return(nil, nil)
 
 			actual, err := p.ListCandidates(userID)
 			So(err, ShouldBeNil)
 			So(actual, ShouldResemble, []identity.Candidate{
 				{
-					"type":                "oauth",
-					"email":               "john.doe@gmail.com",
-					"provider_type":       "google",
-					"provider_alias":      "google",
-					"provider_subject_id": "john.doe@gmail.com",
+					"type":                  "oauth",
+					"email":                 "john.doe@gmail.com",
+					"provider_type":         "google",
+					"provider_alias":        "google",
+					"provider_subject_id":   "john.doe@gmail.com",
+					"provider_display_name": "",
+				},
+			})
+		})
+
+		Convey("associate ldap identity", func() {
+			userID := "a"
+
+			p.Authentication.Identities = []string{"ldap"}
+			p.Identity.LDAP.DisplayName = "Directory"
+
+			loginIDProvider.EXPECT().List(userID).Return(nil, nil)
+			oauthProvider.EXPECT().List(userID).Return(nil, nil)
+			ldapProvider.EXPECT().List(userID).Return([]*ldap.Identity{
+				{
+					SubjectID: "0f8d1ad6-6c6e-4a6b-9a6e-1d3b2f6b4c2d",
+					Username:  "john.doe",
+					Claims: map[string]interface{}{
+						"email": "john.doe@example.com",
+					},
+				},
+			}, nil)
+
+			actual, err := p.ListCandidates(userID)
+			So(err, ShouldBeNil)
+			So(actual, ShouldResemble, []identity.Candidate{
+				{
+					"type":              "ldap",
+					"email":             "john.doe@example.com",
+					"ldap_username":     "john.doe",
+					"ldap_display_name": "Directory",
 				},
 			})
 		})
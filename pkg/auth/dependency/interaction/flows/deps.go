@@ -43,6 +43,15 @@ func ProvideIsAnonymousIdentityEnabled(c *config.TenantConfiguration) IsAnonymou
 	return false
 }
 
+func isLDAPIdentityEnabled(c *config.TenantConfiguration) bool {
+	for _, i := range c.AppConfig.Authentication.Identities {
+		if i == string(authn.IdentityTypeLDAP) {
+			return true
+		}
+	}
+	return false
+}
+
 func ProvideWebAppFlow(
 	c *config.TenantConfiguration,
 	idp IdentityProvider,
@@ -50,6 +59,7 @@ func ProvideWebAppFlow(
 	hp hook.Provider,
 	ip InteractionProvider,
 	uc *UserController,
+	ld LDAPDirectory,
 ) *WebAppFlow {
 	return &WebAppFlow{
 		ConflictConfig: c.AppConfig.Identity.OnConflict,
@@ -58,6 +68,8 @@ func ProvideWebAppFlow(
 		Hooks:          hp,
 		Interactions:   ip,
 		UserController: uc,
+		LDAPEnabled:    isLDAPIdentityEnabled(c),
+		LDAPDirectory:  ld,
 	}
 }
 
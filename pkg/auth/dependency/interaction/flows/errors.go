@@ -11,3 +11,7 @@ var ErrUnsupportedConfiguration = UnsupportedConfiguration.New(
 var ErrAnonymousDisabled = UnsupportedConfiguration.New(
 	"anonymous user is disabled by configuration",
 )
+
+var ErrLDAPDisabled = UnsupportedConfiguration.New(
+	"LDAP login is disabled by configuration",
+)
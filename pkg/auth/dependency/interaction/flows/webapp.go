@@ -16,6 +16,8 @@ type WebAppFlow struct {
 	Hooks          hook.Provider
 	Interactions   InteractionProvider
 	UserController *UserController
+	LDAPEnabled    bool
+	LDAPDirectory  LDAPDirectory
 }
 
 func (f *WebAppFlow) LoginWithLoginID(loginID string) (*WebAppResult, error) {
@@ -0,0 +1,184 @@
+package flows
+
+import (
+	"errors"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/interaction"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/ldap"
+	"github.com/skygeario/skygear-server/pkg/core/auth/metadata"
+	"github.com/skygeario/skygear-server/pkg/core/authn"
+)
+
+type LDAPDirectory interface {
+	Search(username string) (*ldap.Entry, error)
+}
+
+// LoginWithLDAP authenticates the user by binding to the directory. On
+// first login, the identity is linked to the user having the same verified
+// email login ID, or a new user is created.
+func (f *WebAppFlow) LoginWithLDAP(username string, password string) (*WebAppResult, error) {
+	if !f.LDAPEnabled {
+		return nil, ErrLDAPDisabled
+	}
+
+	entry, err := f.LDAPDirectory.Search(username)
+	if errors.Is(err, ldap.ErrUserNotFound) {
+		return nil, interaction.ErrInvalidCredentials
+	} else if err != nil {
+		return nil, err
+	}
+
+	claims := map[string]interface{}{
+		identity.IdentityClaimLDAPSubjectID:  entry.SubjectID,
+		identity.IdentityClaimLDAPUsername:   entry.Username,
+		identity.IdentityClaimLDAPDN:         entry.DN,
+		identity.IdentityClaimLDAPClaims:     entry.Claims,
+		identity.IdentityClaimLDAPAttributes: entry.Metadata,
+	}
+	i, err := f.Interactions.NewInteractionLogin(&interaction.IntentLogin{
+		Identity: identity.Spec{
+			Type:   authn.IdentityTypeLDAP,
+			Claims: claims,
+		},
+	}, "")
+	if err == nil {
+		return f.authenticateLDAP(i, password)
+	}
+	if !errors.Is(err, interaction.ErrInvalidCredentials) {
+		return nil, err
+	}
+
+	spec := identity.Spec{
+		Type:   authn.IdentityTypeLDAP,
+		Claims: claims,
+	}
+
+	// Link to the existing user having the same email login ID.
+	userID, err := f.findUserByEmail(entry.Claims)
+	if err != nil {
+		return nil, err
+	}
+	if userID != "" {
+		i, err = f.Interactions.NewInteractionAddIdentity(&interaction.IntentAddIdentity{
+			Identity: spec,
+		}, "", userID)
+	} else {
+		i, err = f.Interactions.NewInteractionSignup(&interaction.IntentSignup{
+			UserMetadata: entry.Metadata,
+			Identity:     spec,
+		}, "")
+	}
+	if err != nil {
+		return nil, err
+	}
+	s, err := f.Interactions.GetInteractionState(i)
+	if err != nil {
+		return nil, err
+	}
+	if s.CurrentStep().Step != interaction.StepSetupPrimaryAuthenticator || len(s.CurrentStep().AvailableAuthenticators) <= 0 {
+		panic("interaction_flow_webapp: unexpected interaction state")
+	}
+
+	// Setting up LDAP authenticator binds to the directory.
+	err = f.Interactions.PerformAction(i, interaction.StepSetupPrimaryAuthenticator, &interaction.ActionSetupAuthenticator{
+		Authenticator: s.CurrentStep().AvailableAuthenticators[0],
+		Secret:        password,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if i.Error != nil {
+		return nil, i.Error
+	}
+
+	s, err = f.Interactions.GetInteractionState(i)
+	if err != nil {
+		return nil, err
+	} else if s.CurrentStep().Step != interaction.StepCommit {
+		panic("interaction_flow_webapp: unexpected interaction state")
+	}
+	result, err := f.Interactions.Commit(i)
+	if err != nil {
+		return nil, err
+	}
+
+	// create new interaction after signup or linking
+	i, err = f.Interactions.NewInteractionLoginAs(
+		&interaction.IntentLogin{
+			Identity: identity.Spec{
+				Type:   result.Identity.Type,
+				Claims: result.Identity.Claims,
+			},
+			OriginalIntentType: i.Intent.Type(),
+		},
+		result.Attrs.UserID,
+		i.Identity,
+		i.PrimaryAuthenticator,
+		i.ClientID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return f.afterPrimaryAuthentication(i)
+}
+
+// findUserByEmail returns the ID of the user having a verified email login
+// ID matching the email claim of the directory entry, or empty string if
+// none. Unverified email is not trusted, otherwise an account pre-registered
+// with the email of someone else would be taken over on their first login;
+// the signup then fails with duplicated identity, and the user must log in
+// and link the identity explicitly.
+func (f *WebAppFlow) findUserByEmail(claims map[string]interface{}) (string, error) {
+	email, ok := claims[string(metadata.Email)].(string)
+	if !ok || email == "" {
+		return "", nil
+	}
+
+	userID, iden, err := f.Identities.GetByClaims(authn.IdentityTypeLoginID, map[string]interface{}{
+		identity.IdentityClaimLoginIDValue: email,
+	})
+	if errors.Is(err, identity.ErrIdentityNotFound) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	// Only login ID of email type has the email claim.
+	if _, ok := iden.Claims[string(metadata.Email)]; !ok {
+		return "", nil
+	}
+
+	user, err := f.Users.Get(userID)
+	if err != nil {
+		return "", err
+	}
+	loginID, _ := iden.Claims[identity.IdentityClaimLoginIDValue].(string)
+	if !user.VerifyInfo[loginID] {
+		return "", nil
+	}
+	return userID, nil
+}
+
+func (f *WebAppFlow) authenticateLDAP(i *interaction.Interaction, password string) (*WebAppResult, error) {
+	s, err := f.Interactions.GetInteractionState(i)
+	if err != nil {
+		return nil, err
+	}
+	if s.CurrentStep().Step != interaction.StepAuthenticatePrimary || len(s.CurrentStep().AvailableAuthenticators) <= 0 {
+		panic("interaction_flow_webapp: unexpected interaction state")
+	}
+
+	err = f.Interactions.PerformAction(i, interaction.StepAuthenticatePrimary, &interaction.ActionAuthenticate{
+		Authenticator: s.CurrentStep().AvailableAuthenticators[0],
+		Secret:        password,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if i.Error != nil {
+		return nil, i.Error
+	}
+
+	return f.afterPrimaryAuthentication(i)
+}
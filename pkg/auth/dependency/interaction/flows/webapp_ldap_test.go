@@ -0,0 +1,82 @@
+package flows
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity"
+	"github.com/skygeario/skygear-server/pkg/auth/model"
+	"github.com/skygeario/skygear-server/pkg/core/authn"
+)
+
+type mockIdentityProvider struct {
+	userID string
+	info   *identity.Info
+}
+
+func (p *mockIdentityProvider) GetByClaims(typ authn.IdentityType, claims map[string]interface{}) (string, *identity.Info, error) {
+	if p.info == nil || p.info.Type != typ ||
+		p.info.Claims[identity.IdentityClaimLoginIDValue] != claims[identity.IdentityClaimLoginIDValue] {
+		return "", nil, identity.ErrIdentityNotFound
+	}
+	return p.userID, p.info, nil
+}
+
+type mockUserProvider struct {
+	users map[string]*model.User
+}
+
+func (p *mockUserProvider) Get(id string) (*model.User, error) {
+	return p.users[id], nil
+}
+
+func TestWebAppFlowFindUserByEmail(t *testing.T) {
+	Convey("WebAppFlow.findUserByEmail", t, func() {
+		user := &model.User{ID: "user-id", VerifyInfo: map[string]bool{}}
+		f := &WebAppFlow{
+			Identities: &mockIdentityProvider{
+				userID: "user-id",
+				info: &identity.Info{
+					Type: authn.IdentityTypeLoginID,
+					Claims: map[string]interface{}{
+						identity.IdentityClaimLoginIDKey:   "email",
+						identity.IdentityClaimLoginIDValue: "user@example.com",
+						"email":                            "user@example.com",
+					},
+				},
+			},
+			Users: &mockUserProvider{
+				users: map[string]*model.User{"user-id": user},
+			},
+		}
+		claims := map[string]interface{}{"email": "user@example.com"}
+
+		Convey("should find user with verified email", func() {
+			user.VerifyInfo["user@example.com"] = true
+
+			userID, err := f.findUserByEmail(claims)
+			So(err, ShouldBeNil)
+			So(userID, ShouldEqual, "user-id")
+		})
+
+		Convey("should not find user with unverified email", func() {
+			userID, err := f.findUserByEmail(claims)
+			So(err, ShouldBeNil)
+			So(userID, ShouldBeEmpty)
+
+			user.VerifyInfo["user@example.com"] = false
+			userID, err = f.findUserByEmail(claims)
+			So(err, ShouldBeNil)
+			So(userID, ShouldBeEmpty)
+		})
+
+		Convey("should not find user with other email", func() {
+			user.VerifyInfo["user@example.com"] = true
+
+			userID, err := f.findUserByEmail(map[string]interface{}{"email": "other@example.com"})
+			So(err, ShouldBeNil)
+			So(userID, ShouldBeEmpty)
+		})
+	})
+}
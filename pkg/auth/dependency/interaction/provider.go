@@ -78,6 +78,7 @@ type AuthenticatorProvider interface {
 type UserProvider interface {
 	Create(userID string, metadata map[string]interface{}, identities []*identity.Info) error
 	Get(userID string) (*model.User, error)
+	UpdateMetadata(userID string, metadata map[string]interface{}) error
 }
 
 type OOBProvider interface {
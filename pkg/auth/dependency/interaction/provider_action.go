@@ -8,6 +8,8 @@ import (
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/oob"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity"
+	"github.com/skygeario/skygear-server/pkg/auth/event"
+	"github.com/skygeario/skygear-server/pkg/auth/model"
 	"github.com/skygeario/skygear-server/pkg/core/authn"
 	"github.com/skygeario/skygear-server/pkg/core/skyerr"
 )
@@ -174,13 +176,22 @@ func (p *Provider) setupPrimaryAuthenticator(i *Interaction, step *StepState, s
 func (p *Provider) doAuthenticate(i *Interaction, step *StepState, astate *map[string]string, is identity.Spec, as authenticator.Spec, secret string) (*authenticator.Info, error) {
 	userID, iden, err := p.Identity.GetByClaims(is.Type, is.Claims)
 	if errors.Is(err, identity.ErrIdentityNotFound) {
+		ii := model.Identity{Type: string(is.Type), Claims: is.Claims}
+		if dispatchErr := p.dispatchLoginFailedEvent("", ii, as.Type); dispatchErr != nil {
+			return nil, dispatchErr
+		}
 		return nil, ErrInvalidCredentials
 	} else if err != nil {
 		return nil, err
 	}
 
 	authen, err := p.Authenticator.Authenticate(userID, as, astate, secret)
-	if err != nil {
+	if skyerr.IsKind(err, InvalidCredentials) {
+		if dispatchErr := p.dispatchLoginFailedEvent(userID, iden.ToModel(), as.Type); dispatchErr != nil {
+			return nil, dispatchErr
+		}
+		return nil, err
+	} else if err != nil {
 		return nil, err
 	}
 
@@ -191,6 +202,25 @@ func (p *Provider) doAuthenticate(i *Interaction, step *StepState, astate *map[s
 	return authen, nil
 }
 
+// dispatchLoginFailedEvent dispatches the event immediately because
+// the ongoing transaction is rolled back when login fails.
+func (p *Provider) dispatchLoginFailedEvent(userID string, identity model.Identity, typ authn.AuthenticatorType) error {
+	var user *model.User
+	if userID != "" {
+		var err error
+		user, err = p.User.Get(userID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return p.Hooks.DispatchEventImmediately(event.LoginFailedEvent{
+		User:              user,
+		Identity:          identity,
+		AuthenticatorType: typ,
+	})
+}
+
 func (p *Provider) setupAuthenticator(i *Interaction, step *StepState, astate *map[string]string, as authenticator.Spec, secret string) (*authenticator.Info, error) {
 	ok := false
 	for _, aa := range step.AvailableAuthenticators {
@@ -208,7 +238,7 @@ func (p *Provider) setupAuthenticator(i *Interaction, step *StepState, astate *m
 	case authn.AuthenticatorTypePassword:
 		// Nothing special needs to be done
 		break
-	case authn.AuthenticatorTypeOOB:
+	case authn.AuthenticatorTypeOOB, authn.AuthenticatorTypeLDAP:
 		// Ignoring the first return value because it is always nil.
 		_, err := p.Authenticator.Authenticate(i.UserID, as, astate, secret)
 		if err != nil {
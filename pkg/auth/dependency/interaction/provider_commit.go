@@ -73,6 +73,10 @@ func (p *Provider) Commit(i *Interaction) (*Result, error) {
 		return nil, err
 	}
 
+	if err := p.dispatchAuthenticatorEvents(i); err != nil {
+		return nil, err
+	}
+
 	err = p.Store.Delete(i)
 	if err != nil {
 		p.Logger.WithError(err).Warn("failed to cleanup interaction")
@@ -92,22 +96,36 @@ func (p *Provider) Commit(i *Interaction) (*Result, error) {
 }
 
 func (p *Provider) onCommitLogin(i *Interaction, intent *IntentLogin) error {
-	if intent.Identity.Type == authn.IdentityTypeOAuth {
-		// skip update if login is triggered by signup
-		if intent.OriginalIntentType == IntentTypeSignup {
-			return nil
-		}
+	if intent.Identity.Type != authn.IdentityTypeOAuth && intent.Identity.Type != authn.IdentityTypeLDAP {
+		return nil
+	}
 
-		ii, err := p.Identity.Get(i.UserID, intent.Identity.Type, i.Identity.ID)
-		if err != nil {
-			p.Logger.WithError(err).Warn("failed to new identity for update")
-			return err
-		}
-		ui, err := p.Identity.WithClaims(i.UserID, ii, intent.Identity.Claims)
-		if err != nil {
-			return err
+	// skip update if login is triggered by signup
+	if intent.OriginalIntentType == IntentTypeSignup {
+		return nil
+	}
+
+	ii, err := p.Identity.Get(i.UserID, intent.Identity.Type, i.Identity.ID)
+	if err != nil {
+		p.Logger.WithError(err).Warn("failed to new identity for update")
+		return err
+	}
+	ui, err := p.Identity.WithClaims(i.UserID, ii, intent.Identity.Claims)
+	if err != nil {
+		return err
+	}
+	i.UpdateIdentities = append(i.UpdateIdentities, ui)
+
+	if intent.Identity.Type == authn.IdentityTypeLDAP {
+		// The directory is the source of truth of mapped attributes,
+		// so they are synced into user metadata on each login.
+		metadata, _ := intent.Identity.Claims[identity.IdentityClaimLDAPAttributes].(map[string]interface{})
+		if len(metadata) > 0 {
+			err = p.User.UpdateMetadata(i.UserID, metadata)
+			if err != nil {
+				return err
+			}
 		}
-		i.UpdateIdentities = append(i.UpdateIdentities, ui)
 	}
 
 	return nil
@@ -296,6 +314,87 @@ func (p *Provider) onCommitUpdateIdentity(i *Interaction, intent *IntentUpdateId
 	return nil
 }
 
+// eventAuthenticatorTypes excludes recovery codes and bearer tokens,
+// which are implementation details of MFA.
+var eventAuthenticatorTypes = map[authn.AuthenticatorType]bool{
+	authn.AuthenticatorTypePassword: true,
+	authn.AuthenticatorTypeTOTP:     true,
+	authn.AuthenticatorTypeOOB:      true,
+	authn.AuthenticatorTypeLDAP:     true,
+}
+
+func (p *Provider) dispatchAuthenticatorEvents(i *Interaction) error {
+	// Authenticators of new user are covered by user create event.
+	if _, isSignup := i.Intent.(*IntentSignup); isSignup {
+		return nil
+	}
+
+	var newAuthenticators, removeAuthenticators []*authenticator.Info
+	for _, a := range i.NewAuthenticators {
+		if eventAuthenticatorTypes[a.Type] {
+			newAuthenticators = append(newAuthenticators, a)
+		}
+	}
+	for _, a := range i.RemoveAuthenticators {
+		if eventAuthenticatorTypes[a.Type] {
+			removeAuthenticators = append(removeAuthenticators, a)
+		}
+	}
+	_, isLogin := i.Intent.(*IntentLogin)
+	recoveryCodeUsed := isLogin &&
+		i.SecondaryAuthenticator != nil &&
+		i.SecondaryAuthenticator.Type == authn.AuthenticatorTypeRecoveryCode
+
+	if len(newAuthenticators) == 0 && len(removeAuthenticators) == 0 && !recoveryCodeUsed {
+		return nil
+	}
+
+	user, err := p.User.Get(i.UserID)
+	if err != nil {
+		return err
+	}
+
+	for _, a := range newAuthenticators {
+		err = p.Hooks.DispatchEvent(
+			event.AuthenticatorCreateEvent{
+				User:          *user,
+				Authenticator: a.ToModel(),
+			},
+			user,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, a := range removeAuthenticators {
+		err = p.Hooks.DispatchEvent(
+			event.AuthenticatorDeleteEvent{
+				User:          *user,
+				Authenticator: a.ToModel(),
+			},
+			user,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if recoveryCodeUsed {
+		err = p.Hooks.DispatchEvent(
+			event.RecoveryCodeUsedEvent{
+				User: *user,
+			},
+			user,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (p *Provider) checkIdentitiesDuplicated(iis []*identity.Info, userID string) error {
 	for _, i := range iis {
 		err := p.Identity.CheckIdentityDuplicated(i, userID)
@@ -106,15 +106,22 @@ func TestProviderCommit(t *testing.T) {
 			sort.Sort(authenticatorInfoSlice(actual))
 			So(expected, ShouldResemble, actual)
 
-			So(hooks.DispatchedEvents, ShouldResemble, []event.Payload{
-				event.IdentityDeleteEvent{
-					User: model.User{ID: userID},
-					Identity: model.Identity{
-						Type:   string(loginID1.Type),
-						Claims: loginID1.Claims,
-					},
+			So(hooks.DispatchedEvents, ShouldHaveLength, 3)
+			So(hooks.DispatchedEvents[0], ShouldResemble, event.IdentityDeleteEvent{
+				User: model.User{ID: userID},
+				Identity: model.Identity{
+					Type:   string(loginID1.Type),
+					Claims: loginID1.Claims,
 				},
 			})
+			So(hooks.DispatchedEvents[1:], ShouldContain, event.AuthenticatorDeleteEvent{
+				User:          model.User{ID: userID},
+				Authenticator: pwAuthenticator.ToModel(),
+			})
+			So(hooks.DispatchedEvents[1:], ShouldContain, event.AuthenticatorDeleteEvent{
+				User:          model.User{ID: userID},
+				Authenticator: totpAuthenticator.ToModel(),
+			})
 		})
 
 		Convey("should not remove authenticators when removing identity has no related authenticator", func() {
@@ -176,6 +183,96 @@ func TestProviderCommit(t *testing.T) {
 						Claims: loginID2.Claims,
 					},
 				},
+				event.AuthenticatorDeleteEvent{
+					User:          model.User{ID: userID},
+					Authenticator: oobAuthenticator.ToModel(),
+				},
+			})
+		})
+	})
+}
+
+func TestProviderCommitAuthenticatorEvents(t *testing.T) {
+	Convey("InteractionProviderCommitAuthenticatorEvents", t, func() {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		identityProvider := NewMockIdentityProvider(ctrl)
+		authenticatorProvider := NewMockAuthenticatorProvider(ctrl)
+		store := NewMockStore(ctrl)
+		userProvider := NewMockUserProvider(ctrl)
+		hooks := hook.NewMockProvider()
+
+		p := &interaction.Provider{
+			Time:          &coretime.MockProvider{},
+			Identity:      identityProvider,
+			Authenticator: authenticatorProvider,
+			User:          userProvider,
+			Store:         store,
+			Hooks:         hooks,
+		}
+		userID := "userid1"
+
+		store.EXPECT().Delete(gomock.Any()).Return(nil).AnyTimes()
+		identityProvider.EXPECT().CreateAll(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		identityProvider.EXPECT().UpdateAll(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		identityProvider.EXPECT().DeleteAll(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		identityProvider.EXPECT().Get(gomock.Any(), gomock.Any(), gomock.Any()).Return(&identity.Info{}, nil).AnyTimes()
+		authenticatorProvider.EXPECT().CreateAll(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		authenticatorProvider.EXPECT().UpdateAll(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		authenticatorProvider.EXPECT().DeleteAll(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+		userProvider.EXPECT().Get(userID).Return(&model.User{ID: userID}, nil).AnyTimes()
+
+		Convey("should dispatch event for new authenticators except recovery codes", func() {
+			totpAuthenticator := &authenticator.Info{
+				ID:    "aid1",
+				Type:  authn.AuthenticatorTypeTOTP,
+				Props: map[string]interface{}{"display_name": "My Phone"},
+			}
+			i := &interaction.Interaction{
+				Intent: &interaction.IntentUpdateAuthenticator{},
+				UserID: userID,
+				NewAuthenticators: []*authenticator.Info{
+					totpAuthenticator,
+					{ID: "aid2", Type: authn.AuthenticatorTypeRecoveryCode},
+				},
+			}
+
+			_, err := p.Commit(i)
+			So(err, ShouldBeNil)
+			So(hooks.DispatchedEvents, ShouldResemble, []event.Payload{
+				event.AuthenticatorCreateEvent{
+					User: model.User{ID: userID},
+					Authenticator: model.Authenticator{
+						ID:    "aid1",
+						Type:  authn.AuthenticatorTypeTOTP,
+						Props: map[string]interface{}{"display_name": "My Phone"},
+					},
+				},
+			})
+		})
+
+		Convey("should dispatch event when recovery code is used for login", func() {
+			i := &interaction.Interaction{
+				Intent:   &interaction.IntentLogin{},
+				Identity: &identity.Ref{},
+				UserID:   userID,
+				PrimaryAuthenticator: &authenticator.Ref{
+					ID:   "aid1",
+					Type: authn.AuthenticatorTypePassword,
+				},
+				SecondaryAuthenticator: &authenticator.Ref{
+					ID:   "aid2",
+					Type: authn.AuthenticatorTypeRecoveryCode,
+				},
+			}
+
+			_, err := p.Commit(i)
+			So(err, ShouldBeNil)
+			So(hooks.DispatchedEvents, ShouldResemble, []event.Payload{
+				event.RecoveryCodeUsedEvent{
+					User: model.User{ID: userID},
+				},
 			})
 		})
 	})
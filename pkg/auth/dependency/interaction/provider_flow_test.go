@@ -10,6 +10,7 @@ import (
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/interaction"
+	"github.com/skygeario/skygear-server/pkg/auth/event"
 	"github.com/skygeario/skygear-server/pkg/auth/model"
 	"github.com/skygeario/skygear-server/pkg/core/authn"
 	"github.com/skygeario/skygear-server/pkg/core/config"
@@ -251,6 +252,57 @@ func TestProviderFlow(t *testing.T) {
 				So(err, ShouldBeNil)
 
 			})
+
+			Convey("Login with invalid credentials", func() {
+				userID := "user_id_1"
+				loginIDClaims := map[string]interface{}{"email": "user@example.com"}
+				ii := &identity.Info{
+					ID:     "identity_id_1",
+					Type:   authn.IdentityTypeLoginID,
+					Claims: loginIDClaims,
+				}
+				ai := &authenticator.Info{
+					ID:     "authenticator_id_1",
+					Type:   authn.AuthenticatorTypePassword,
+					Props:  map[string]interface{}{},
+					Secret: "password",
+				}
+
+				identityProvider.EXPECT().GetByClaims(
+					gomock.Eq(authn.IdentityTypeLoginID), gomock.Eq(loginIDClaims),
+				).Return(userID, ii, nil).AnyTimes()
+				authenticatorProvider.EXPECT().ListByIdentity(
+					gomock.Eq(userID), gomock.Eq(ii),
+				).Return([]*authenticator.Info{ai}, nil).AnyTimes()
+				authenticatorProvider.EXPECT().Authenticate(
+					gomock.Eq(userID), gomock.Eq(ai.ToSpec()), gomock.Any(), gomock.Any(),
+				).Return(nil, interaction.ErrInvalidCredentials)
+				userProvider.EXPECT().Get(gomock.Eq(userID)).Return(&model.User{ID: userID}, nil)
+
+				i, err := p.NewInteractionLogin(
+					&interaction.IntentLogin{Identity: identity.Spec{
+						Type:   authn.IdentityTypeLoginID,
+						Claims: loginIDClaims,
+					}},
+					"",
+				)
+				So(err, ShouldBeNil)
+
+				err = p.PerformAction(i, interaction.StepAuthenticatePrimary, &interaction.ActionAuthenticate{
+					Authenticator: ai.ToSpec(),
+					Secret:        "wrong-password",
+				})
+				So(err, ShouldBeNil)
+				So(i.Error, ShouldNotBeNil)
+
+				So(hooks.DispatchedEvents, ShouldResemble, []event.Payload{
+					event.LoginFailedEvent{
+						User:              &model.User{ID: userID},
+						Identity:          ii.ToModel(),
+						AuthenticatorType: authn.AuthenticatorTypePassword,
+					},
+				})
+			})
 		})
 
 		Convey("SSO flow with MFA", func() {
@@ -579,8 +631,8 @@ func TestProviderFlow(t *testing.T) {
 				authenticatorProvider.EXPECT().UpdateAll(gomock.Any(), gomock.Eq(emptyAuthenticatorInfoList)).Return(nil)
 				authenticatorProvider.EXPECT().DeleteAll(gomock.Any(), gomock.Eq(emptyAuthenticatorInfoList)).Return(nil)
 
-				// get user for hook
-				userProvider.EXPECT().Get(gomock.Eq(userID)).Return(&model.User{}, nil)
+				// get user for identity and authenticator hooks
+				userProvider.EXPECT().Get(gomock.Eq(userID)).Return(&model.User{}, nil).Times(2)
 
 				store.EXPECT().Delete(gomock.Any()).Return(nil)
 
@@ -619,6 +671,12 @@ func TestProviderFlow(t *testing.T) {
 
 				_, err = p.Commit(i)
 				So(err, ShouldBeNil)
+
+				So(hooks.DispatchedEvents, ShouldHaveLength, 2)
+				So(hooks.DispatchedEvents[1], ShouldResemble, event.AuthenticatorCreateEvent{
+					User:          model.User{},
+					Authenticator: ai.ToModel(),
+				})
 			})
 
 		})
@@ -705,8 +763,8 @@ func TestProviderFlow(t *testing.T) {
 			// remove oob authenticator
 			authenticatorProvider.EXPECT().DeleteAll(gomock.Any(), gomock.Eq([]*authenticator.Info{oobai})).Return(nil)
 
-			// get user for hook
-			userProvider.EXPECT().Get(gomock.Eq(userID)).Return(&model.User{}, nil)
+			// get user for identity and authenticator hooks
+			userProvider.EXPECT().Get(gomock.Eq(userID)).Return(&model.User{}, nil).Times(2)
 
 			store.EXPECT().Delete(gomock.Any()).Return(nil)
 
@@ -731,6 +789,12 @@ func TestProviderFlow(t *testing.T) {
 
 			_, err = p.Commit(i)
 			So(err, ShouldBeNil)
+
+			So(hooks.DispatchedEvents, ShouldHaveLength, 2)
+			So(hooks.DispatchedEvents[1], ShouldResemble, event.AuthenticatorDeleteEvent{
+				User:          model.User{},
+				Authenticator: oobai.ToModel(),
+			})
 		})
 
 		Convey("Remove identity", func() {
@@ -815,8 +879,8 @@ func TestProviderFlow(t *testing.T) {
 				authenticatorProvider.EXPECT().UpdateAll(gomock.Any(), gomock.Eq(emptyAuthenticatorInfoList)).Return(nil)
 				authenticatorProvider.EXPECT().DeleteAll(gomock.Any(), gomock.Eq([]*authenticator.Info{ai2})).Return(nil)
 
-				// get user for hook
-				userProvider.EXPECT().Get(gomock.Eq(userID)).Return(&model.User{}, nil)
+				// get user for identity and authenticator hooks
+				userProvider.EXPECT().Get(gomock.Eq(userID)).Return(&model.User{}, nil).Times(2)
 
 				store.EXPECT().Delete(gomock.Any()).Return(nil)
 
@@ -836,6 +900,12 @@ func TestProviderFlow(t *testing.T) {
 
 				_, err = p.Commit(i)
 				So(err, ShouldBeNil)
+
+				So(hooks.DispatchedEvents, ShouldHaveLength, 2)
+				So(hooks.DispatchedEvents[1], ShouldResemble, event.AuthenticatorDeleteEvent{
+					User:          model.User{},
+					Authenticator: ai2.ToModel(),
+				})
 			})
 
 		})
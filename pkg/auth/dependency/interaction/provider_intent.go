@@ -51,6 +51,13 @@ func (p *Provider) NewInteractionLogin(intent *IntentLogin, clientID string) (*I
 		return p.newInteractionLogin(intent, clientID)
 	case authn.IdentityTypeOAuth, authn.IdentityTypeAnonymous:
 		return p.newInteractionLoginRequireIdentity(intent, clientID)
+	case authn.IdentityTypeLDAP:
+		i, err := p.newInteractionLoginRequireIdentity(intent, clientID)
+		if err != nil {
+			return nil, err
+		}
+		i.State = newLDAPAuthenticatorState(intent.Identity)
+		return i, nil
 	default:
 		panic("interaction_provider: unknown identity type " + intent.Identity.Type)
 	}
@@ -91,9 +98,22 @@ func (p *Provider) NewInteractionSignup(intent *IntentSignup, clientID string) (
 	if err := p.Identity.Validate(i.NewIdentities); err != nil {
 		return nil, err
 	}
+
+	if intent.Identity.Type == authn.IdentityTypeLDAP {
+		i.State = newLDAPAuthenticatorState(intent.Identity)
+	}
 	return i, nil
 }
 
+// newLDAPAuthenticatorState remembers the DN of the directory entry,
+// which LDAP authenticator binds as.
+func newLDAPAuthenticatorState(is identity.Spec) map[string]string {
+	dn, _ := is.Claims[identity.IdentityClaimLDAPDN].(string)
+	return map[string]string{
+		authenticator.AuthenticatorStateLDAPDN: dn,
+	}
+}
+
 func (p *Provider) NewInteractionAddIdentity(intent *IntentAddIdentity, clientID string, userID string) (*Interaction, error) {
 	i := newInteraction(clientID, intent)
 	i.UserID = userID
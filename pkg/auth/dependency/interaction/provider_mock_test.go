@@ -530,6 +530,20 @@ func (mr *MockUserProviderMockRecorder) Get(userID interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockUserProvider)(nil).Get), userID)
 }
 
+// UpdateMetadata mocks base method
+func (m *MockUserProvider) UpdateMetadata(userID string, metadata map[string]interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMetadata", userID, metadata)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMetadata indicates an expected call of UpdateMetadata
+func (mr *MockUserProviderMockRecorder) UpdateMetadata(userID, metadata interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetadata", reflect.TypeOf((*MockUserProvider)(nil).UpdateMetadata), userID, metadata)
+}
+
 // MockOOBProvider is a mock of OOBProvider interface
 type MockOOBProvider struct {
 	ctrl     *gomock.Controller
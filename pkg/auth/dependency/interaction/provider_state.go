@@ -205,6 +205,9 @@ var identityPrimaryAuthenticators = map[authn.IdentityType]map[authn.Authenticat
 		authn.AuthenticatorTypeTOTP:     true,
 		authn.AuthenticatorTypeOOB:      true,
 	},
+	authn.IdentityTypeLDAP: {
+		authn.AuthenticatorTypeLDAP: true,
+	},
 }
 
 func (p *Provider) getAvailablePrimaryAuthenticators(is identity.Spec) []authenticator.Spec {
@@ -0,0 +1,14 @@
+package ldap
+
+import (
+	"github.com/google/wire"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+)
+
+func ProvideDirectory(c *config.TenantConfiguration) *Directory {
+	return &Directory{
+		Config: c.AppConfig.Identity.LDAP,
+	}
+}
+
+var DependencySet = wire.NewSet(ProvideDirectory)
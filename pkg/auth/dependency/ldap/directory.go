@@ -0,0 +1,164 @@
+package ldap
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"net"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	goldap "github.com/go-ldap/ldap/v3"
+
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+)
+
+// timeout is the timeout of connecting to the server and of each request.
+const timeout = 10 * time.Second
+
+var ErrUserNotFound = errors.New("LDAP user not found")
+
+// Entry is a user entry found in the directory, with attributes mapped
+// according to configuration.
+type Entry struct {
+	DN        string
+	SubjectID string
+	Username  string
+	// Claims are the mapped OIDC standard claims.
+	Claims map[string]interface{}
+	// Metadata are the attributes mapped to user metadata.
+	Metadata map[string]interface{}
+}
+
+// Directory looks up and authenticates users against the configured LDAP
+// server. A new connection is made for each operation.
+type Directory struct {
+	Config *config.LDAPConfiguration
+}
+
+func (d *Directory) dial() (*goldap.Conn, error) {
+	tlsConfig := &tls.Config{}
+	if d.Config.CACertificate != "" {
+		certs, err := d.Config.CACertificates()
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		for _, cert := range certs {
+			pool.AddCert(cert)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	conn, err := goldap.DialURL(
+		d.Config.URL,
+		goldap.DialWithDialer(&net.Dialer{Timeout: timeout}),
+		goldap.DialWithTLSConfig(tlsConfig),
+	)
+	if err != nil {
+		return nil, err
+	}
+	conn.SetTimeout(timeout)
+
+	if d.Config.StartTLS {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+// Search finds the entry of username. The service account is used if it
+// is configured.
+func (d *Directory) Search(username string) (*Entry, error) {
+	if username == "" {
+		return nil, ErrUserNotFound
+	}
+
+	conn, err := d.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if d.Config.BindDN != "" {
+		if err := conn.Bind(d.Config.BindDN, d.Config.BindPassword); err != nil {
+			return nil, errors.Newf("failed to bind LDAP service account: %w", err)
+		}
+	}
+
+	attrs := d.Config.Attributes
+	attributes := []string{attrs.ID, attrs.Email}
+	for _, attr := range attrs.Metadata {
+		attributes = append(attributes, attr)
+	}
+
+	result, err := conn.Search(goldap.NewSearchRequest(
+		d.Config.SearchBase,
+		goldap.ScopeWholeSubtree,
+		goldap.NeverDerefAliases,
+		// Only need to know whether the username is ambiguous.
+		2,
+		int(timeout.Seconds()),
+		false,
+		strings.Replace(d.Config.Filter, "{username}", goldap.EscapeFilter(username), -1),
+		attributes,
+		nil,
+	))
+	if goldap.IsErrorWithCode(err, goldap.LDAPResultSizeLimitExceeded) {
+		return nil, errors.New("LDAP search filter matches multiple entries")
+	} else if err != nil {
+		return nil, err
+	}
+	if len(result.Entries) == 0 {
+		return nil, ErrUserNotFound
+	} else if len(result.Entries) > 1 {
+		return nil, errors.New("LDAP search filter matches multiple entries")
+	}
+
+	entry := result.Entries[0]
+	subjectID := entry.GetAttributeValue(attrs.ID)
+	if subjectID == "" {
+		return nil, errors.Newf("LDAP entry has no %s attribute", attrs.ID)
+	}
+	// Binary IDs, such as objectGUID of Active Directory, are not valid
+	// UTF-8 strings.
+	if !utf8.ValidString(subjectID) {
+		subjectID = base64.StdEncoding.EncodeToString([]byte(subjectID))
+	}
+
+	claims := map[string]interface{}{}
+	if email := entry.GetAttributeValue(attrs.Email); email != "" {
+		claims["email"] = email
+	}
+
+	// Only the first value of multi-valued attributes is used.
+	metadata := map[string]interface{}{}
+	for key, attr := range attrs.Metadata {
+		if value := entry.GetAttributeValue(attr); value != "" {
+			metadata[key] = value
+		}
+	}
+
+	return &Entry{
+		DN:        entry.DN,
+		SubjectID: subjectID,
+		Username:  username,
+		Claims:    claims,
+		Metadata:  metadata,
+	}, nil
+}
+
+// Bind verifies password of the entry dn.
+func (d *Directory) Bind(dn string, password string) error {
+	conn, err := d.dial()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.Bind(dn, password)
+}
@@ -0,0 +1,106 @@
+package ldap
+
+import (
+	"os"
+	"testing"
+
+	goldap "github.com/go-ldap/ldap/v3"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/core/config"
+)
+
+// The tests run against the openldap service of docker-compose.yml, e.g.
+// LDAP_TEST_URL=ldap://localhost:389 go test ./pkg/auth/dependency/ldap
+const (
+	testBaseDN        = "dc=example,dc=org"
+	testAdminDN       = "cn=admin,dc=example,dc=org"
+	testAdminPassword = "admin"
+)
+
+func TestDirectory(t *testing.T) {
+	url := os.Getenv("LDAP_TEST_URL")
+	if url == "" {
+		t.Skip("LDAP_TEST_URL is not set")
+	}
+
+	Convey("Directory", t, func() {
+		conn, err := goldap.DialURL(url)
+		So(err, ShouldBeNil)
+		defer conn.Close()
+		So(conn.Bind(testAdminDN, testAdminPassword), ShouldBeNil)
+
+		addUser := func(uid string, mail string, password string) string {
+			dn := "uid=" + uid + "," + testBaseDN
+			req := goldap.NewAddRequest(dn, nil)
+			req.Attribute("objectClass", []string{"inetOrgPerson"})
+			req.Attribute("uid", []string{uid})
+			req.Attribute("cn", []string{uid})
+			req.Attribute("sn", []string{uid})
+			req.Attribute("mail", []string{mail})
+			req.Attribute("userPassword", []string{password})
+			So(conn.Add(req), ShouldBeNil)
+			return dn
+		}
+		deleteUser := func(dn string) {
+			_ = conn.Del(goldap.NewDelRequest(dn, nil))
+		}
+
+		d := &Directory{
+			Config: &config.LDAPConfiguration{
+				URL:          url,
+				BindDN:       testAdminDN,
+				BindPassword: testAdminPassword,
+				SearchBase:   testBaseDN,
+				Filter:       "(&(objectClass=inetOrgPerson)(|(uid={username})(mail={username})))",
+				Attributes: &config.LDAPAttributesConfiguration{
+					ID:    "entryUUID",
+					Email: "mail",
+					Metadata: map[string]string{
+						"name": "cn",
+					},
+				},
+			},
+		}
+
+		dn := addUser("john", "john@example.org", "secret")
+		defer deleteUser(dn)
+
+		Convey("should search user", func() {
+			entry, err := d.Search("john")
+			So(err, ShouldBeNil)
+			So(entry.DN, ShouldEqual, dn)
+			So(entry.SubjectID, ShouldNotBeEmpty)
+			So(entry.Username, ShouldEqual, "john")
+			So(entry.Claims, ShouldResemble, map[string]interface{}{
+				"email": "john@example.org",
+			})
+			So(entry.Metadata, ShouldResemble, map[string]interface{}{
+				"name": "john",
+			})
+		})
+
+		Convey("should escape username in filter", func() {
+			_, err := d.Search("*")
+			So(err, ShouldBeError, ErrUserNotFound)
+
+			_, err = d.Search("nobody")
+			So(err, ShouldBeError, ErrUserNotFound)
+		})
+
+		Convey("should reject ambiguous username", func() {
+			other := addUser("jane", "john", "secret")
+			defer deleteUser(other)
+
+			_, err := d.Search("john")
+			So(err, ShouldBeError, "LDAP search filter matches multiple entries")
+		})
+
+		Convey("should bind user", func() {
+			So(d.Bind(dn, "secret"), ShouldBeNil)
+
+			err := d.Bind(dn, "wrong")
+			So(goldap.IsErrorWithCode(err, goldap.LDAPResultInvalidCredentials), ShouldBeTrue)
+		})
+	})
+}
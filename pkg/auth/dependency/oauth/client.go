@@ -0,0 +1,23 @@
+package oauth
+
+import (
+	"time"
+
+	"github.com/skygeario/skygear-server/pkg/core/config"
+)
+
+// Client is a client registered dynamically.
+type Client struct {
+	ID        string
+	AppID     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// RegistrationAccessTokenHash is the hash of token used to manage the
+	// client through client configuration endpoint.
+	RegistrationAccessTokenHash string
+
+	// Metadata is the client configuration, in same format as clients
+	// configured in tenant config.
+	Metadata config.OAuthClientConfiguration
+}
@@ -0,0 +1,36 @@
+package oauth
+
+import (
+	"errors"
+
+	"github.com/skygeario/skygear-server/pkg/core/config"
+)
+
+// ClientResolver resolves clients configured in tenant config, and
+// clients registered dynamically.
+type ClientResolver struct {
+	Clients []config.OAuthClientConfiguration
+	Store   ClientStore
+}
+
+// ResolveClient returns nil client if the client is not found.
+func (r *ClientResolver) ResolveClient(clientID string) (config.OAuthClientConfiguration, error) {
+	for _, c := range r.Clients {
+		if c.ClientID() == clientID {
+			return c, nil
+		}
+	}
+
+	client, err := r.Store.Get(clientID)
+	if errors.Is(err, ErrClientNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	// Clients registered without first-party status are third-party.
+	if _, ok := client.Metadata["is_first_party"]; !ok {
+		client.Metadata["is_first_party"] = false
+	}
+	return client.Metadata, nil
+}
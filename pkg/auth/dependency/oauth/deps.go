@@ -4,14 +4,60 @@ import (
 	"github.com/google/wire"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/session"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/urlprefix"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/time"
 )
 
 func ProvideResolverProvider(p session.Provider) ResolverSessionProvider { return p }
 
+func ProvideResolver(
+	cr *ClientResolver,
+	as AuthorizationStore,
+	ags AccessGrantStore,
+	ogs OfflineGrantStore,
+	sp ResolverSessionProvider,
+	jc *JWTAccessTokenCodec,
+	dl AccessTokenDenylist,
+	tp time.Provider,
+) *Resolver {
+	return &Resolver{
+		Clients:         cr,
+		Authorizations:  as,
+		AccessGrants:    ags,
+		OfflineGrants:   ogs,
+		Sessions:        sp,
+		JWTAccessTokens: jc,
+		Denylist:        dl,
+		Time:            tp,
+	}
+}
+
+func ProvideClientResolver(cfg *config.TenantConfiguration, cs ClientStore) *ClientResolver {
+	return &ClientResolver{
+		Clients: cfg.AppConfig.Clients,
+		Store:   cs,
+	}
+}
+
+func ProvideJWTAccessTokenCodec(
+	cfg *config.TenantConfiguration,
+	up urlprefix.Provider,
+	tp time.Provider,
+) *JWTAccessTokenCodec {
+	return &JWTAccessTokenCodec{
+		OIDCConfig: *cfg.AppConfig.OIDC,
+		URLPrefix:  up,
+		Time:       tp,
+	}
+}
+
 var DependencySet = wire.NewSet(
 	wire.Struct(new(MetadataProvider), "*"),
-	wire.Struct(new(Resolver), "*"),
+	ProvideResolver,
 	ProvideResolverProvider,
+	ProvideClientResolver,
+	ProvideJWTAccessTokenCodec,
 	wire.Bind(new(auth.AccessTokenSessionResolver), new(*Resolver)),
 	wire.Struct(new(SessionManager), "*"),
 	wire.Bind(new(auth.AccessTokenSessionManager), new(*SessionManager)),
@@ -13,3 +13,23 @@ type TokenEndpointProvider interface {
 type RevokeEndpointProvider interface {
 	RevokeEndpointURI() *url.URL
 }
+
+type IntrospectEndpointProvider interface {
+	IntrospectEndpointURI() *url.URL
+}
+
+type DeviceAuthorizationEndpointProvider interface {
+	DeviceAuthorizationEndpointURI() *url.URL
+}
+
+type DeviceVerificationEndpointProvider interface {
+	DeviceVerificationEndpointURI() *url.URL
+}
+
+type RegistrationEndpointProvider interface {
+	RegistrationEndpointURI() *url.URL
+}
+
+type PushedAuthorizationRequestEndpointProvider interface {
+	PushedAuthorizationRequestEndpointURI() *url.URL
+}
@@ -4,3 +4,5 @@ import "errors"
 
 var ErrAuthorizationNotFound = errors.New("oauth authorization not found")
 var ErrGrantNotFound = errors.New("oauth grant not found")
+var ErrGrantConflict = errors.New("oauth grant is modified concurrently")
+var ErrClientNotFound = errors.New("oauth client not found")
@@ -5,6 +5,7 @@ type GrantSessionKind string
 const (
 	GrantSessionKindOffline GrantSessionKind = "offline_grant"
 	GrantSessionKindSession GrantSessionKind = "idp_session"
+	GrantSessionKindClient  GrantSessionKind = "client"
 )
 
 type Grant interface {
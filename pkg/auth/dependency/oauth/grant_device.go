@@ -0,0 +1,36 @@
+package oauth
+
+import "time"
+
+type DeviceGrantStatus string
+
+const (
+	DeviceGrantStatusPending  DeviceGrantStatus = "pending"
+	DeviceGrantStatusApproved DeviceGrantStatus = "approved"
+	DeviceGrantStatusDenied   DeviceGrantStatus = "denied"
+)
+
+type DeviceGrant struct {
+	AppID    string `json:"app_id"`
+	ClientID string `json:"client_id"`
+
+	CreatedAt      time.Time `json:"created_at"`
+	ExpireAt       time.Time `json:"expire_at"`
+	Scopes         []string  `json:"scopes"`
+	DeviceCodeHash string    `json:"device_code_hash"`
+	UserCode       string    `json:"user_code"`
+
+	// Interval is the minimum polling interval in seconds.
+	Interval     int       `json:"interval"`
+	LastPolledAt time.Time `json:"last_polled_at"`
+
+	Status          DeviceGrantStatus `json:"status"`
+	AuthorizationID string            `json:"authz_id,omitempty"`
+	SessionID       string            `json:"session_id,omitempty"`
+}
+
+var _ Grant = &DeviceGrant{}
+
+func (g *DeviceGrant) Session() (kind GrantSessionKind, id string) {
+	return GrantSessionKindSession, g.SessionID
+}
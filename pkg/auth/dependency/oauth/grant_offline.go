@@ -1,6 +1,7 @@
 package oauth
 
 import (
+	"crypto/subtle"
 	"time"
 
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
@@ -8,17 +9,28 @@ import (
 	"github.com/skygeario/skygear-server/pkg/core/authn"
 )
 
+// MaxRotatedTokenHashes is the number of rotated refresh tokens remembered
+// for reuse detection. Older tokens are rejected without revoking the grant.
+const MaxRotatedTokenHashes = 20
+
 type OfflineGrant struct {
 	AppID           string `json:"app_id"`
 	ID              string `json:"id"`
 	ClientID        string `json:"client_id"`
 	AuthorizationID string `json:"authz_id"`
+	// IDPSessionID is the ID of the IDP session the grant is issued through,
+	// if any. The client is notified when the IDP session is ended.
+	IDPSessionID string `json:"idp_session_id,omitempty"`
 
 	CreatedAt time.Time `json:"created_at"`
 	ExpireAt  time.Time `json:"expire_at"`
 	Scopes    []string  `json:"scopes"`
 	TokenHash string    `json:"token_hash"`
 
+	// RotatedTokenHashes are hashes of refresh tokens replaced by rotation.
+	// Presenting any of them again indicates the grant is compromised.
+	RotatedTokenHashes []string `json:"rotated_token_hashes,omitempty"`
+
 	Attrs      authn.Attrs     `json:"attrs"`
 	AccessInfo auth.AccessInfo `json:"access_info"`
 }
@@ -45,7 +57,9 @@ func (g *OfflineGrant) ToAPIModel() *model.Session {
 	ua := model.ParseUserAgent(g.AccessInfo.LastAccess.UserAgent)
 	ua.DeviceName = g.AccessInfo.LastAccess.Extra.DeviceName()
 	return &model.Session{
-		ID: g.ID,
+		ID:       g.ID,
+		Type:     auth.SessionTypeOfflineGrant,
+		ClientID: g.ClientID,
 
 		AMR: g.Attrs.AMR,
 		ACR: g.Attrs.ACR,
@@ -57,3 +71,33 @@ func (g *OfflineGrant) ToAPIModel() *model.Session {
 		UserAgent:        ua,
 	}
 }
+
+// IDPSessionID returns the ID of the IDP session that the session is tied to,
+// or empty string if there is none.
+func IDPSessionID(s auth.AuthSession) string {
+	if g, ok := s.(*OfflineGrant); ok {
+		return g.IDPSessionID
+	}
+	if s.SessionType() == auth.SessionTypeIdentityProvider {
+		return s.SessionID()
+	}
+	return ""
+}
+
+func (g *OfflineGrant) IsRotatedTokenHash(tokenHash string) bool {
+	for _, h := range g.RotatedTokenHashes {
+		if subtle.ConstantTimeCompare([]byte(tokenHash), []byte(h)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// RotateTokenHash replaces the token hash, remembering the old one.
+func (g *OfflineGrant) RotateTokenHash(tokenHash string) {
+	g.RotatedTokenHashes = append(g.RotatedTokenHashes, g.TokenHash)
+	if n := len(g.RotatedTokenHashes); n > MaxRotatedTokenHashes {
+		g.RotatedTokenHashes = g.RotatedTokenHashes[n-MaxRotatedTokenHashes:]
+	}
+	g.TokenHash = tokenHash
+}
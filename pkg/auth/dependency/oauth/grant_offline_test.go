@@ -0,0 +1,28 @@
+package oauth_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestOfflineGrant(t *testing.T) {
+	Convey("OfflineGrant", t, func() {
+		Convey("RotateTokenHash", func() {
+			g := &oauth.OfflineGrant{TokenHash: "hash-0"}
+			g.RotateTokenHash("hash-1")
+			So(g.TokenHash, ShouldEqual, "hash-1")
+			So(g.IsRotatedTokenHash("hash-0"), ShouldBeTrue)
+			So(g.IsRotatedTokenHash("hash-1"), ShouldBeFalse)
+
+			for i := 2; i <= oauth.MaxRotatedTokenHashes+1; i++ {
+				g.RotateTokenHash(fmt.Sprintf("hash-%d", i))
+			}
+			So(g.RotatedTokenHashes, ShouldHaveLength, oauth.MaxRotatedTokenHashes)
+			So(g.IsRotatedTokenHash("hash-0"), ShouldBeFalse)
+			So(g.IsRotatedTokenHash("hash-1"), ShouldBeTrue)
+		})
+	})
+}
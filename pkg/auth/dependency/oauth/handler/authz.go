@@ -23,8 +23,8 @@ func checkAuthorization(
 		return nil, err
 	}
 
-	// Authorization of requested scopes not granted, requesting consent.
-	// TODO(oauth): request consent, for now just always implicitly grant scopes.
+	// Authorization of requested scopes not granted yet, grant them now.
+	// Callers are responsible for obtaining consent of user if needed.
 	if authz == nil {
 		authz = &oauth.Authorization{
 			ID:        uuid.New(),
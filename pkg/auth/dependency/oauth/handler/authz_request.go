@@ -0,0 +1,243 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	gotime "time"
+
+	"github.com/dgrijalva/jwt-go"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/protocol"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/urlprefix"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+// PushedRequestValidDuration is the lifetime of request URIs returned by
+// pushed authorization request endpoint.
+const PushedRequestValidDuration = 10 * gotime.Minute
+
+// RetryRequestValidDuration is the lifetime of request URIs used internally
+// to retry authorization requests after authentication and consent.
+const RetryRequestValidDuration = 1 * gotime.Hour
+
+// RequestObjectMaxLifetime is the maximum lifetime of request objects.
+// https://openid.net/specs/openid-financial-api-part-2-1_0.html#authorization-server
+const RequestObjectMaxLifetime = 60 * gotime.Minute
+
+// requestObjectIgnoredClaims are JWT claims of request objects that are not
+// authorization request parameters.
+var requestObjectIgnoredClaims = []string{
+	"iss", "aud", "exp", "nbf", "iat", "jti", "request", "request_uri",
+}
+
+// AuthorizationRequestResolver resolves authorization requests passed by
+// reference (request_uri) or by value (request) into plain authorization
+// requests.
+type AuthorizationRequestResolver struct {
+	AppID string
+
+	URLPrefix      urlprefix.Provider
+	PushedRequests oauth.PushedRequestStore
+	GenerateToken  TokenGenerator
+	Time           time.Provider
+}
+
+// Resolve returns the authorization request to be processed. The returned
+// request is r itself if it is neither pushed nor signed. Pushed requests
+// are consumed, so that each request URI is processed once only.
+func (p *AuthorizationRequestResolver) Resolve(
+	client config.OAuthClientConfiguration,
+	r protocol.AuthorizationRequest,
+) (protocol.AuthorizationRequest, error) {
+	return p.resolve(client, r, true)
+}
+
+// Peek is like Resolve, but pushed requests are not consumed. It is used to
+// display the request before it is processed.
+func (p *AuthorizationRequestResolver) Peek(
+	client config.OAuthClientConfiguration,
+	r protocol.AuthorizationRequest,
+) (protocol.AuthorizationRequest, error) {
+	return p.resolve(client, r, false)
+}
+
+func (p *AuthorizationRequestResolver) resolve(
+	client config.OAuthClientConfiguration,
+	r protocol.AuthorizationRequest,
+	consume bool,
+) (protocol.AuthorizationRequest, error) {
+	if r.RequestURI() != "" {
+		if r.Request() != "" {
+			return nil, protocol.NewError("invalid_request", "request and request_uri cannot be used together")
+		}
+		return p.resolvePushedRequest(client, r.RequestURI(), consume)
+	}
+
+	if client.RequirePushedAuthorizationRequests() {
+		return nil, protocol.NewError("invalid_request", "pushed authorization request is required")
+	}
+
+	return p.ResolveRequestObject(client, r)
+}
+
+// ResolveRequestObject verifies the request object of r with the JWKS of the
+// client, and returns the request carried by it. Parameters outside the
+// request object are ignored.
+func (p *AuthorizationRequestResolver) ResolveRequestObject(
+	client config.OAuthClientConfiguration,
+	r protocol.AuthorizationRequest,
+) (protocol.AuthorizationRequest, error) {
+	if r.Request() == "" {
+		return r, nil
+	}
+
+	claims, err := p.verifyRequestObject(client, r.Request())
+	if err != nil {
+		return nil, protocol.NewError("invalid_request_object", err.Error())
+	}
+
+	req := protocol.AuthorizationRequest{}
+	for k, v := range claims {
+		if s, ok := v.(string); ok {
+			req[k] = s
+			continue
+		}
+		// Structured parameters (e.g. claims) are passed as JSON.
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, protocol.NewError("invalid_request_object", "invalid parameter: "+k)
+		}
+		req[k] = string(b)
+	}
+	for _, k := range requestObjectIgnoredClaims {
+		delete(req, k)
+	}
+
+	if clientID, ok := req["client_id"]; ok && clientID != client.ClientID() {
+		return nil, protocol.NewError("invalid_request_object", "client ID mismatch")
+	}
+	req["client_id"] = client.ClientID()
+
+	return req, nil
+}
+
+// Push stores the authorization request, and returns the request URI
+// referencing it.
+func (p *AuthorizationRequestResolver) Push(
+	client config.OAuthClientConfiguration,
+	r protocol.AuthorizationRequest,
+	validDuration gotime.Duration,
+) (string, error) {
+	requestURI := oauth.RequestURIPrefix + p.GenerateToken()
+
+	req := map[string]string{}
+	for k, v := range r {
+		req[k] = v
+	}
+
+	now := p.Time.NowUTC()
+	err := p.PushedRequests.CreatePushedRequest(&oauth.PushedRequest{
+		AppID:    p.AppID,
+		ClientID: client.ClientID(),
+
+		CreatedAt:      now,
+		ExpireAt:       now.Add(validDuration),
+		RequestURIHash: oauth.HashToken(requestURI),
+
+		Request: req,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return requestURI, nil
+}
+
+func (p *AuthorizationRequestResolver) resolvePushedRequest(
+	client config.OAuthClientConfiguration,
+	requestURI string,
+	consume bool,
+) (protocol.AuthorizationRequest, error) {
+	if !oauth.IsRequestURI(requestURI) {
+		return nil, protocol.NewError("invalid_request_uri", "only pushed authorization requests are supported")
+	}
+
+	pushed, err := p.PushedRequests.GetPushedRequest(oauth.HashToken(requestURI))
+	if errors.Is(err, oauth.ErrGrantNotFound) {
+		return nil, protocol.NewError("invalid_request_uri", "invalid or expired request URI")
+	} else if err != nil {
+		return nil, err
+	}
+
+	if !p.Time.NowUTC().Before(pushed.ExpireAt) {
+		return nil, protocol.NewError("invalid_request_uri", "invalid or expired request URI")
+	}
+	if pushed.ClientID != client.ClientID() {
+		return nil, protocol.NewError("invalid_request_uri", "invalid or expired request URI")
+	}
+
+	// Consumed after validation, so that other clients cannot invalidate
+	// the request URI.
+	if consume {
+		err = p.PushedRequests.ConsumePushedRequest(pushed.RequestURIHash)
+		if errors.Is(err, oauth.ErrGrantNotFound) {
+			return nil, protocol.NewError("invalid_request_uri", "invalid or expired request URI")
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	req := protocol.AuthorizationRequest{}
+	for k, v := range pushed.Request {
+		req[k] = v
+	}
+	return req, nil
+}
+
+func (p *AuthorizationRequestResolver) verifyRequestObject(
+	client config.OAuthClientConfiguration,
+	requestObject string,
+) (jwt.MapClaims, error) {
+	keyFunc, err := clientKeyFunc(client)
+	if err != nil {
+		return nil, errors.New("invalid client JWKS")
+	}
+
+	claims := jwt.MapClaims{}
+	parser := &jwt.Parser{SkipClaimsValidation: true}
+	if _, err = parser.ParseWithClaims(requestObject, claims, keyFunc); err != nil {
+		return nil, errors.New("invalid request object signature")
+	}
+
+	now := p.Time.NowUTC().Unix()
+	if iss, ok := claims["iss"]; ok && iss != client.ClientID() {
+		return nil, errors.New("invalid issuer")
+	}
+	if !assertionAudienceMatches(claims["aud"], []string{p.URLPrefix.Value().String()}) {
+		return nil, errors.New("invalid audience")
+	}
+	if !claims.VerifyExpiresAt(now, true) {
+		return nil, errors.New("request object expired")
+	}
+	if !claims.VerifyNotBefore(now, false) {
+		return nil, errors.New("request object not yet valid")
+	}
+
+	// Request objects must be short-lived, so that captured ones cannot be
+	// replayed indefinitely.
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return nil, errors.New("invalid expiration time")
+	}
+	maxLifetime := RequestObjectMaxLifetime.Seconds()
+	if exp-float64(now) > maxLifetime {
+		return nil, errors.New("request object lifetime is too long")
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && exp-nbf > maxLifetime {
+		return nil, errors.New("request object lifetime is too long")
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,284 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	gotime "time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/lestrrat-go/jwx/jwk"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/protocol"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/urlprefix"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+const ClientAssertionTypeJWTBearer = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+// ClientAssertionMaxLifetime is the maximum lifetime of client assertions,
+// which bounds the time their IDs are recorded to prevent replay.
+const ClientAssertionMaxLifetime = 5 * gotime.Minute
+
+// ClientAuthMethodsSupported are the client authentication methods supported
+// at token endpoint and revocation endpoint.
+var ClientAuthMethodsSupported = []string{
+	config.ClientAuthMethodNone,
+	config.ClientAuthMethodClientSecretBasic,
+	config.ClientAuthMethodClientSecretPost,
+	config.ClientAuthMethodPrivateKeyJWT,
+}
+
+// ClientAssertionSigningAlgsSupported are the JWS algorithms supported for
+// private_key_jwt client assertions.
+var ClientAssertionSigningAlgsSupported = []string{
+	"RS256", "RS384", "RS512",
+	"ES256", "ES384", "ES512",
+}
+
+var errInvalidClient = protocol.NewError("invalid_client", "invalid client ID")
+var errClientAuthFailed = protocol.NewError("invalid_client", "client authentication failed")
+
+type clientAuthRequest interface {
+	ClientID() string
+	ClientSecret() string
+	ClientAssertionType() string
+	ClientAssertion() string
+}
+
+type ClientAuthenticator struct {
+	Request       *http.Request
+	Clients       ClientResolver
+	URLPrefix     urlprefix.Provider
+	TokenEndpoint oauth.TokenEndpointProvider
+	Assertions    oauth.ClientAssertionStore
+	Time          time.Provider
+}
+
+// Authenticate resolves the client making the request, and authenticates it
+// with the method configured for the client. It returns nil client if the
+// request does not identify a client.
+func (a *ClientAuthenticator) Authenticate(r clientAuthRequest) (config.OAuthClientConfiguration, error) {
+	basicID, basicSecret, hasBasic := a.basicAuth()
+
+	clientID := r.ClientID()
+	if hasBasic {
+		if clientID != "" && clientID != basicID {
+			return nil, protocol.NewError("invalid_request", "client ID mismatch")
+		}
+		clientID = basicID
+	}
+	if clientID == "" && r.ClientAssertion() != "" {
+		clientID = peekAssertionSubject(r.ClientAssertion())
+	}
+	if clientID == "" {
+		return nil, nil
+	}
+
+	client, err := a.Clients.ResolveClient(clientID)
+	if err != nil {
+		return nil, err
+	} else if client == nil {
+		return nil, errInvalidClient
+	}
+
+	var ok bool
+	switch client.TokenEndpointAuthMethod() {
+	case config.ClientAuthMethodNone:
+		ok = true
+	case config.ClientAuthMethodClientSecretBasic:
+		ok = hasBasic && verifyClientSecret(client, basicSecret)
+	case config.ClientAuthMethodClientSecretPost:
+		ok = !hasBasic && verifyClientSecret(client, r.ClientSecret())
+	case config.ClientAuthMethodPrivateKeyJWT:
+		if r.ClientAssertionType() == ClientAssertionTypeJWTBearer {
+			ok, err = a.useAssertion(client, r.ClientAssertion())
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	if !ok {
+		return nil, errClientAuthFailed
+	}
+
+	return client, nil
+}
+
+func (a *ClientAuthenticator) basicAuth() (id string, secret string, ok bool) {
+	if a.Request == nil {
+		return "", "", false
+	}
+	id, secret, ok = a.Request.BasicAuth()
+	if !ok {
+		return "", "", false
+	}
+
+	// Client credentials are form-urlencoded before using in basic auth.
+	// https://tools.ietf.org/html/rfc6749#section-2.3.1
+	var err error
+	if id, err = url.QueryUnescape(id); err != nil {
+		return "", "", false
+	}
+	if secret, err = url.QueryUnescape(secret); err != nil {
+		return "", "", false
+	}
+	return id, secret, true
+}
+
+// useAssertion verifies the client assertion and records its ID, so that
+// it cannot be replayed.
+func (a *ClientAuthenticator) useAssertion(client config.OAuthClientConfiguration, assertion string) (bool, error) {
+	claims, err := a.verifyAssertion(client, assertion)
+	if err != nil {
+		return false, nil
+	}
+
+	jti := claims["jti"].(string)
+	exp := int64(claims["exp"].(float64))
+	return a.Assertions.UseClientAssertion(client.ClientID(), jti, gotime.Unix(exp, 0).UTC())
+}
+
+func (a *ClientAuthenticator) verifyAssertion(client config.OAuthClientConfiguration, assertion string) (jwt.MapClaims, error) {
+	keyFunc, err := clientKeyFunc(client)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := jwt.MapClaims{}
+	parser := &jwt.Parser{SkipClaimsValidation: true}
+	if _, err = parser.ParseWithClaims(assertion, claims, keyFunc); err != nil {
+		return nil, err
+	}
+
+	now := a.Time.NowUTC().Unix()
+	iss, _ := claims["iss"].(string)
+	sub, _ := claims["sub"].(string)
+	if iss != client.ClientID() || sub != client.ClientID() {
+		return nil, errors.New("invalid issuer or subject")
+	}
+	if !claims.VerifyExpiresAt(now, true) {
+		return nil, errors.New("assertion expired")
+	}
+	if !claims.VerifyNotBefore(now, false) {
+		return nil, errors.New("assertion not yet valid")
+	}
+
+	// Audience may be the token endpoint or the issuer.
+	audiences := []string{
+		a.TokenEndpoint.TokenEndpointURI().String(),
+		a.URLPrefix.Value().String(),
+	}
+	if !assertionAudienceMatches(claims["aud"], audiences) {
+		return nil, errors.New("invalid audience")
+	}
+
+	// Assertions must be short-lived, and identified so that they can be
+	// used only once.
+	// https://tools.ietf.org/html/rfc7523#section-3
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return nil, errors.New("invalid expiration time")
+	}
+	iat, ok := claims["iat"].(float64)
+	if !ok {
+		return nil, errors.New("missing issued at")
+	}
+	maxLifetime := ClientAssertionMaxLifetime.Seconds()
+	if exp-iat > maxLifetime || exp-float64(now) > maxLifetime {
+		return nil, errors.New("assertion lifetime is too long")
+	}
+	if jti, _ := claims["jti"].(string); jti == "" {
+		return nil, errors.New("missing JWT ID")
+	}
+
+	return claims, nil
+}
+
+// clientKeyFunc returns a jwt.Keyfunc resolving verification keys from the
+// JWKS of the client.
+func clientKeyFunc(client config.OAuthClientConfiguration) (jwt.Keyfunc, error) {
+	// Set.ExtractMap consumes the map, so parse a copy of the configured JWKS.
+	jwksJSON, err := json.Marshal(client.JWKS())
+	if err != nil {
+		return nil, err
+	}
+	keySet, err := jwk.ParseBytes(jwksJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(token *jwt.Token) (interface{}, error) {
+		switch token.Method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+			break
+		default:
+			return nil, errors.New("unexpected signing method")
+		}
+
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" && len(keySet.Keys) == 1 {
+			return keySet.Keys[0].Materialize()
+		}
+		for _, key := range keySet.Keys {
+			if kid != "" && key.KeyID() == kid {
+				return key.Materialize()
+			}
+		}
+		return nil, errors.New("no matching key")
+	}, nil
+}
+
+func assertionAudienceMatches(aud interface{}, expected []string) bool {
+	var values []string
+	switch aud := aud.(type) {
+	case string:
+		values = []string{aud}
+	case []interface{}:
+		for _, v := range aud {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+	}
+
+	for _, v := range values {
+		for _, e := range expected {
+			if v == e {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// peekAssertionSubject returns the subject of the client assertion without
+// verifying it, so that the client can be resolved.
+func peekAssertionSubject(assertion string) string {
+	claims := jwt.MapClaims{}
+	if _, _, err := new(jwt.Parser).ParseUnverified(assertion, claims); err != nil {
+		return ""
+	}
+	sub, _ := claims["sub"].(string)
+	return sub
+}
+
+// verifyClientSecret verifies the client secret, which is stored as hash for
+// clients registered dynamically.
+func verifyClientSecret(client config.OAuthClientConfiguration, provided string) bool {
+	if hash := client.ClientSecretHash(); hash != "" {
+		return provided != "" && secretEqual(oauth.HashToken(provided), hash)
+	}
+	return secretEqual(provided, client.ClientSecret())
+}
+
+func secretEqual(provided string, expected string) bool {
+	if provided == "" || expected == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(provided), []byte(expected)) == 1
+}
@@ -0,0 +1,265 @@
+package handler_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/lestrrat-go/jwx/jwk"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/handler"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/protocol"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/urlprefix"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	coretime "github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+type mockTokenEndpointProvider struct{}
+
+func (mockTokenEndpointProvider) TokenEndpointURI() *url.URL {
+	u, _ := url.Parse("https://auth/oauth2/token")
+	return u
+}
+
+func TestClientAuthenticator(t *testing.T) {
+	Convey("ClientAuthenticator", t, func() {
+		mockTime := &coretime.MockProvider{}
+		mockTime.TimeNowUTC = time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		So(err, ShouldBeNil)
+		publicKey, err := jwk.New(&privateKey.PublicKey)
+		So(err, ShouldBeNil)
+		_ = publicKey.Set(jwk.KeyIDKey, "key-1")
+		jwkJSON, err := json.Marshal(publicKey)
+		So(err, ShouldBeNil)
+		var jwkMap map[string]interface{}
+		So(json.Unmarshal(jwkJSON, &jwkMap), ShouldBeNil)
+
+		a := &handler.ClientAuthenticator{
+			Clients: newMockClientResolver([]config.OAuthClientConfiguration{
+				{"client_id": "public"},
+				{
+					"client_id":                  "basic",
+					"client_secret":              "basic-secret",
+					"token_endpoint_auth_method": "client_secret_basic",
+				},
+				{
+					"client_id":                  "post",
+					"client_secret":              "post-secret",
+					"token_endpoint_auth_method": "client_secret_post",
+				},
+				{
+					"client_id":                  "hashed",
+					"client_secret_hash":         oauth.HashToken("hashed-secret"),
+					"token_endpoint_auth_method": "client_secret_post",
+				},
+				{
+					"client_id":                  "jwt",
+					"token_endpoint_auth_method": "private_key_jwt",
+					"jwks": map[string]interface{}{
+						"keys": []interface{}{jwkMap},
+					},
+				},
+			}),
+			URLPrefix:     urlprefix.Provider{Prefix: url.URL{Scheme: "https", Host: "auth"}},
+			TokenEndpoint: mockTokenEndpointProvider{},
+			Assertions:    &mockClientAssertionStore{},
+			Time:          mockTime,
+		}
+		withBasicAuth := func(id, secret string) {
+			a.Request, _ = http.NewRequest("POST", "/oauth2/token", nil)
+			a.Request.SetBasicAuth(id, secret)
+		}
+		makeAssertion := func(claims jwt.MapClaims, kid string) string {
+			token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+			if kid != "" {
+				token.Header["kid"] = kid
+			}
+			s, err := token.SignedString(privateKey)
+			So(err, ShouldBeNil)
+			return s
+		}
+		clientID := func(c config.OAuthClientConfiguration) string {
+			if c == nil {
+				return ""
+			}
+			return c.ClientID()
+		}
+
+		Convey("should not resolve client if not identified", func() {
+			c, err := a.Authenticate(protocol.TokenRequest{})
+			So(err, ShouldBeNil)
+			So(c, ShouldBeNil)
+		})
+
+		Convey("should reject unknown client", func() {
+			_, err := a.Authenticate(protocol.TokenRequest{"client_id": "unknown"})
+			So(err, ShouldBeError, "invalid client ID")
+		})
+
+		Convey("should accept public client", func() {
+			c, err := a.Authenticate(protocol.TokenRequest{"client_id": "public"})
+			So(err, ShouldBeNil)
+			So(clientID(c), ShouldEqual, "public")
+		})
+
+		Convey("should authenticate with client_secret_basic", func() {
+			withBasicAuth("basic", "basic-secret")
+			c, err := a.Authenticate(protocol.TokenRequest{})
+			So(err, ShouldBeNil)
+			So(clientID(c), ShouldEqual, "basic")
+
+			withBasicAuth("basic", "wrong-secret")
+			_, err = a.Authenticate(protocol.TokenRequest{})
+			So(err, ShouldBeError, "client authentication failed")
+
+			a.Request = nil
+			_, err = a.Authenticate(protocol.TokenRequest{
+				"client_id":     "basic",
+				"client_secret": "basic-secret",
+			})
+			So(err, ShouldBeError, "client authentication failed")
+		})
+
+		Convey("should authenticate with client_secret_post", func() {
+			c, err := a.Authenticate(protocol.TokenRequest{
+				"client_id":     "post",
+				"client_secret": "post-secret",
+			})
+			So(err, ShouldBeNil)
+			So(clientID(c), ShouldEqual, "post")
+
+			_, err = a.Authenticate(protocol.TokenRequest{"client_id": "post"})
+			So(err, ShouldBeError, "client authentication failed")
+		})
+
+		Convey("should authenticate with hashed client secret", func() {
+			c, err := a.Authenticate(protocol.TokenRequest{
+				"client_id":     "hashed",
+				"client_secret": "hashed-secret",
+			})
+			So(err, ShouldBeNil)
+			So(clientID(c), ShouldEqual, "hashed")
+
+			_, err = a.Authenticate(protocol.TokenRequest{
+				"client_id":     "hashed",
+				"client_secret": oauth.HashToken("hashed-secret"),
+			})
+			So(err, ShouldBeError, "client authentication failed")
+
+			_, err = a.Authenticate(protocol.TokenRequest{"client_id": "hashed"})
+			So(err, ShouldBeError, "client authentication failed")
+		})
+
+		Convey("should reject mismatched client ID", func() {
+			withBasicAuth("basic", "basic-secret")
+			_, err := a.Authenticate(protocol.TokenRequest{"client_id": "post"})
+			So(err, ShouldBeError, "client ID mismatch")
+		})
+
+		Convey("should authenticate with private_key_jwt", func() {
+			jtiCounter := 0
+			validClaims := func() jwt.MapClaims {
+				jtiCounter++
+				return jwt.MapClaims{
+					"iss": "jwt",
+					"sub": "jwt",
+					"aud": []interface{}{"https://auth/oauth2/token"},
+					"iat": mockTime.TimeNowUTC.Unix(),
+					"exp": mockTime.TimeNowUTC.Add(time.Minute).Unix(),
+					"jti": fmt.Sprintf("jti-%d", jtiCounter),
+				}
+			}
+			authenticate := func(assertion string) (config.OAuthClientConfiguration, error) {
+				return a.Authenticate(protocol.TokenRequest{
+					"client_assertion_type": handler.ClientAssertionTypeJWTBearer,
+					"client_assertion":      assertion,
+				})
+			}
+
+			c, err := authenticate(makeAssertion(validClaims(), "key-1"))
+			So(err, ShouldBeNil)
+			So(clientID(c), ShouldEqual, "jwt")
+
+			claims := validClaims()
+			claims["aud"] = "https://auth"
+			c, err = authenticate(makeAssertion(claims, ""))
+			So(err, ShouldBeNil)
+			So(clientID(c), ShouldEqual, "jwt")
+
+			claims = validClaims()
+			claims["aud"] = "https://other"
+			_, err = authenticate(makeAssertion(claims, "key-1"))
+			So(err, ShouldBeError, "client authentication failed")
+
+			claims = validClaims()
+			claims["exp"] = mockTime.TimeNowUTC.Add(-time.Minute).Unix()
+			_, err = authenticate(makeAssertion(claims, "key-1"))
+			So(err, ShouldBeError, "client authentication failed")
+
+			claims = validClaims()
+			claims["iss"] = "other"
+			_, err = authenticate(makeAssertion(claims, "key-1"))
+			So(err, ShouldBeError, "client authentication failed")
+
+			_, err = authenticate(makeAssertion(validClaims(), "key-2"))
+			So(err, ShouldBeError, "client authentication failed")
+
+			otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+			So(err, ShouldBeNil)
+			token := jwt.NewWithClaims(jwt.SigningMethodRS256, validClaims())
+			forged, err := token.SignedString(otherKey)
+			So(err, ShouldBeNil)
+			_, err = authenticate(forged)
+			So(err, ShouldBeError, "client authentication failed")
+		})
+
+		Convey("should reject replayed or long-lived private_key_jwt", func() {
+			claims := jwt.MapClaims{
+				"iss": "jwt",
+				"sub": "jwt",
+				"aud": "https://auth/oauth2/token",
+				"iat": mockTime.TimeNowUTC.Unix(),
+				"exp": mockTime.TimeNowUTC.Add(time.Minute).Unix(),
+				"jti": "jti",
+			}
+			authenticate := func(claims jwt.MapClaims) error {
+				_, err := a.Authenticate(protocol.TokenRequest{
+					"client_assertion_type": handler.ClientAssertionTypeJWTBearer,
+					"client_assertion":      makeAssertion(claims, "key-1"),
+				})
+				return err
+			}
+
+			So(authenticate(claims), ShouldBeNil)
+			So(authenticate(claims), ShouldBeError, "client authentication failed")
+
+			claims["jti"] = "other-jti"
+			claims["exp"] = mockTime.TimeNowUTC.Add(time.Hour).Unix()
+			So(authenticate(claims), ShouldBeError, "client authentication failed")
+
+			claims["exp"] = mockTime.TimeNowUTC.Add(time.Minute).Unix()
+			claims["iat"] = mockTime.TimeNowUTC.Add(-time.Hour).Unix()
+			So(authenticate(claims), ShouldBeError, "client authentication failed")
+
+			delete(claims, "iat")
+			So(authenticate(claims), ShouldBeError, "client authentication failed")
+
+			claims["iat"] = mockTime.TimeNowUTC.Unix()
+			delete(claims, "jti")
+			So(authenticate(claims), ShouldBeError, "client authentication failed")
+
+			claims["jti"] = "other-jti"
+			So(authenticate(claims), ShouldBeNil)
+		})
+	})
+}
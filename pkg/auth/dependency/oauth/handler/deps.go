@@ -6,9 +6,11 @@ import (
 
 	"github.com/google/wire"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
 	interactionflows "github.com/skygeario/skygear-server/pkg/auth/dependency/interaction/flows"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/session"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/urlprefix"
 	"github.com/skygeario/skygear-server/pkg/core/config"
 	"github.com/skygeario/skygear-server/pkg/core/logging"
 	"github.com/skygeario/skygear-server/pkg/core/time"
@@ -18,10 +20,13 @@ func ProvideAuthorizationHandler(
 	ctx context.Context,
 	cfg *config.TenantConfiguration,
 	lf logging.Factory,
+	cr ClientResolver,
+	arr *AuthorizationRequestResolver,
 	as oauth.AuthorizationStore,
 	cs oauth.CodeGrantStore,
 	authze AuthorizeURLProvider,
 	authne AuthenticateURLProvider,
+	cu ConsentURLProvider,
 	vs ScopesValidator,
 	cg TokenGenerator,
 	tp time.Provider,
@@ -29,13 +34,15 @@ func ProvideAuthorizationHandler(
 	return &AuthorizationHandler{
 		Context: ctx,
 		AppID:   cfg.AppID,
-		Clients: cfg.AppConfig.Clients,
+		Clients: cr,
 		Logger:  lf.NewLogger("oauth-authz"),
 
+		Requests:        arr,
 		Authorizations:  as,
 		CodeGrants:      cs,
 		AuthorizeURL:    authze,
 		AuthenticateURL: authne,
+		ConsentURL:      cu,
 		ValidateScopes:  vs,
 		CodeGenerator:   cg,
 		Time:            tp,
@@ -46,12 +53,18 @@ func ProvideTokenHandler(
 	r *http.Request,
 	cfg *config.TenantConfiguration,
 	lf logging.Factory,
+	ca *ClientAuthenticator,
 	as oauth.AuthorizationStore,
 	cs oauth.CodeGrantStore,
+	dgs oauth.DeviceGrantStore,
 	os oauth.OfflineGrantStore,
 	ags oauth.AccessGrantStore,
+	jc *oauth.JWTAccessTokenCodec,
 	aep auth.AccessEventProvider,
 	sp session.Provider,
+	up UserProvider,
+	hp hook.Provider,
+	sr SessionRevoker,
 	aif AnonymousInteractionFlow,
 	ti IDTokenIssuer,
 	cg TokenGenerator,
@@ -60,26 +73,178 @@ func ProvideTokenHandler(
 	return &TokenHandler{
 		Request: r,
 		AppID:   cfg.AppID,
-		Clients: cfg.AppConfig.Clients,
 		Logger:  lf.NewLogger("oauth-token"),
 
+		ClientAuth:      ca,
+		Authorizations:  as,
+		CodeGrants:      cs,
+		DeviceGrants:    dgs,
+		OfflineGrants:   os,
+		AccessGrants:    ags,
+		JWTAccessTokens: jc,
+		AccessEvents:    aep,
+		Sessions:        sp,
+		Users:           up,
+		Hooks:           hp,
+		SessionRevoker:  sr,
+		Anonymous:       aif,
+		IDTokenIssuer:   ti,
+		GenerateToken:   cg,
+		Time:            tp,
+	}
+}
+
+func ProvideClientAuthenticator(
+	r *http.Request,
+	cr ClientResolver,
+	up urlprefix.Provider,
+	te oauth.TokenEndpointProvider,
+	cas oauth.ClientAssertionStore,
+	tp time.Provider,
+) *ClientAuthenticator {
+	return &ClientAuthenticator{
+		Request:       r,
+		Clients:       cr,
+		URLPrefix:     up,
+		TokenEndpoint: te,
+		Assertions:    cas,
+		Time:          tp,
+	}
+}
+
+func ProvideRevokeHandler(
+	cr ClientResolver,
+	ca *ClientAuthenticator,
+	as oauth.AuthorizationStore,
+	os oauth.OfflineGrantStore,
+	ags oauth.AccessGrantStore,
+	agr AccessGrantResolver,
+	dl oauth.AccessTokenDenylist,
+) *RevokeHandler {
+	return &RevokeHandler{
+		Clients: cr,
+
+		ClientAuth:     ca,
 		Authorizations: as,
-		CodeGrants:     cs,
 		OfflineGrants:  os,
 		AccessGrants:   ags,
-		AccessEvents:   aep,
-		Sessions:       sp,
-		Anonymous:      aif,
-		IDTokenIssuer:  ti,
-		GenerateToken:  cg,
+		Resolver:       agr,
+		Denylist:       dl,
+	}
+}
+
+func ProvideDeviceAuthorizationHandler(
+	cfg *config.TenantConfiguration,
+	r *http.Request,
+	cr ClientResolver,
+	ca *ClientAuthenticator,
+	as oauth.AuthorizationStore,
+	dgs oauth.DeviceGrantStore,
+	uca oauth.UserCodeAttemptCounter,
+	vu oauth.DeviceVerificationEndpointProvider,
+	vs ScopesValidator,
+	tg TokenGenerator,
+	tp time.Provider,
+) *DeviceAuthorizationHandler {
+	return &DeviceAuthorizationHandler{
+		AppID:   cfg.AppID,
+		Request: r,
+
+		Clients:         cr,
+		ClientAuth:      ca,
+		Authorizations:  as,
+		DeviceGrants:    dgs,
+		Attempts:        uca,
+		VerificationURL: vu,
+		ValidateScopes:  vs,
+		GenerateToken:   tg,
+		Time:            tp,
+	}
+}
+
+func ProvideConsentHandler(
+	cfg *config.TenantConfiguration,
+	cr ClientResolver,
+	arr *AuthorizationRequestResolver,
+	as oauth.AuthorizationStore,
+	os oauth.OfflineGrantStore,
+	sr SessionRevoker,
+	vs ScopesValidator,
+	tp time.Provider,
+) *ConsentHandler {
+	return &ConsentHandler{
+		AppID:   cfg.AppID,
+		Clients: cr,
+		Scopes:  cfg.AppConfig.OIDC.Scopes,
+
+		Requests:       arr,
+		Authorizations: as,
+		OfflineGrants:  os,
+		SessionRevoker: sr,
+		ValidateScopes: vs,
 		Time:           tp,
 	}
 }
 
+func ProvideAuthorizationRequestResolver(
+	cfg *config.TenantConfiguration,
+	up urlprefix.Provider,
+	ps oauth.PushedRequestStore,
+	tg TokenGenerator,
+	tp time.Provider,
+) *AuthorizationRequestResolver {
+	return &AuthorizationRequestResolver{
+		AppID: cfg.AppID,
+
+		URLPrefix:      up,
+		PushedRequests: ps,
+		GenerateToken:  tg,
+		Time:           tp,
+	}
+}
+
+func ProvidePushedAuthorizationRequestHandler(
+	ca *ClientAuthenticator,
+	arr *AuthorizationRequestResolver,
+	vs ScopesValidator,
+) *PushedAuthorizationRequestHandler {
+	return &PushedAuthorizationRequestHandler{
+		ClientAuth:     ca,
+		Requests:       arr,
+		ValidateScopes: vs,
+	}
+}
+
+func ProvideClientRegistrationHandler(
+	cfg *config.TenantConfiguration,
+	cs oauth.ClientStore,
+	as oauth.AuthorizationStore,
+	re oauth.RegistrationEndpointProvider,
+	tg TokenGenerator,
+	tp time.Provider,
+) *ClientRegistrationHandler {
+	return &ClientRegistrationHandler{
+		AppConfig: cfg.AppConfig,
+
+		Clients:          cs,
+		Authorizations:   as,
+		RegisterEndpoint: re,
+		GenerateToken:    tg,
+		Time:             tp,
+	}
+}
+
 var DependencySet = wire.NewSet(
 	ProvideAuthorizationHandler,
 	ProvideTokenHandler,
-	wire.Struct(new(RevokeHandler), "*"),
+	ProvideClientAuthenticator,
+	ProvideRevokeHandler,
+	ProvideDeviceAuthorizationHandler,
+	ProvideConsentHandler,
+	ProvideClientRegistrationHandler,
+	ProvideAuthorizationRequestResolver,
+	ProvidePushedAuthorizationRequestHandler,
+	wire.Struct(new(IntrospectionHandler), "*"),
 	wire.Value(TokenGenerator(oauth.GenerateToken)),
 	wire.Bind(new(interactionflows.TokenIssuer), new(*TokenHandler)),
 	wire.Struct(new(URLProvider), "*"),
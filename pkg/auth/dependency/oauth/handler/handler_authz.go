@@ -28,29 +28,61 @@ type AuthenticateURLProvider interface {
 	AuthenticateURI(options webapp.AuthenticateURLOptions) (*url.URL, error)
 }
 
+type ConsentURLProvider interface {
+	ConsentURI(redirectURI string) *url.URL
+}
+
 type AuthorizationHandler struct {
 	Context context.Context
 	AppID   string
-	Clients []config.OAuthClientConfiguration
+	Clients ClientResolver
 	Logger  *logrus.Entry
 
+	Requests        *AuthorizationRequestResolver
 	Authorizations  oauth.AuthorizationStore
 	CodeGrants      oauth.CodeGrantStore
 	AuthorizeURL    AuthorizeURLProvider
 	AuthenticateURL AuthenticateURLProvider
+	ConsentURL      ConsentURLProvider
 	ValidateScopes  ScopesValidator
 	CodeGenerator   TokenGenerator
 	Time            time.Provider
 }
 
 func (h *AuthorizationHandler) Handle(r protocol.AuthorizationRequest) AuthorizationResult {
-	client := resolveClient(h.Clients, r)
-	if client == nil {
+	client, err := h.Clients.ResolveClient(r.ClientID())
+	if err != nil {
+		h.Logger.WithError(err).Error("authz handler failed")
+		return authorizationResultError{
+			ResponseMode:  r.ResponseMode(),
+			Response:      protocol.NewErrorResponse("server_error", "internal server error"),
+			InternalError: true,
+		}
+	} else if client == nil {
 		return authorizationResultError{
 			ResponseMode: r.ResponseMode(),
 			Response:     protocol.NewErrorResponse("unauthorized_client", "invalid client ID"),
 		}
 	}
+
+	// Requests passed by reference or by value are resolved before
+	// validation, so they are processed as plain requests.
+	pushed := r.RequestURI() != "" || r.Request() != ""
+	r, err = h.Requests.Resolve(client, r)
+	if err != nil {
+		var oauthError *protocol.OAuthProtocolError
+		if errors.As(err, &oauthError) {
+			return authorizationResultError{
+				Response: oauthError.Response,
+			}
+		}
+		h.Logger.WithError(err).Error("authz handler failed")
+		return authorizationResultError{
+			Response:      protocol.NewErrorResponse("server_error", "internal server error"),
+			InternalError: true,
+		}
+	}
+
 	redirectURI, errResp := parseRedirectURI(client, r)
 	if errResp != nil {
 		return authorizationResultError{
@@ -59,7 +91,7 @@ func (h *AuthorizationHandler) Handle(r protocol.AuthorizationRequest) Authoriza
 		}
 	}
 
-	result, err := h.doHandle(redirectURI, client, r)
+	result, err := h.doHandle(redirectURI, client, r, pushed)
 	if err != nil {
 		var oauthError *protocol.OAuthProtocolError
 		resultErr := authorizationResultError{
@@ -87,8 +119,9 @@ func (h *AuthorizationHandler) doHandle(
 	redirectURI *url.URL,
 	client config.OAuthClientConfiguration,
 	r protocol.AuthorizationRequest,
+	pushed bool,
 ) (AuthorizationResult, error) {
-	if err := h.validateRequest(client, r); err != nil {
+	if err := validateAuthorizationRequest(client, r); err != nil {
 		return nil, err
 	}
 
@@ -120,7 +153,10 @@ func (h *AuthorizationHandler) doHandle(
 		authnOptions.UILocales = strings.Join(r.UILocales(), " ")
 		authnOptions.LoginHint = r.LoginHint()
 		r.SetLoginHint("")
-		authorizeURI := h.AuthorizeURL.AuthorizeURI(r)
+		authorizeURI, err := h.retryURI(client, r, pushed)
+		if err != nil {
+			return nil, err
+		}
 		authnOptions.RedirectURI = authorizeURI.String()
 
 		authenticateURI, err := h.AuthenticateURL.AuthenticateURI(authnOptions)
@@ -135,16 +171,45 @@ func (h *AuthorizationHandler) doHandle(
 		}, nil
 	}
 
-	authz, err := checkAuthorization(
-		h.Authorizations,
-		h.Time.NowUTC(),
-		h.AppID,
-		r.ClientID(),
-		session.AuthnAttrs().UserID,
-		scopes,
-	)
-	if err != nil {
-		return nil, err
+	var authz *oauth.Authorization
+	if client.IsFirstParty() && !utils.StringSliceContains(r.Prompt(), "consent") {
+		authz, err = checkAuthorization(
+			h.Authorizations,
+			h.Time.NowUTC(),
+			h.AppID,
+			r.ClientID(),
+			session.AuthnAttrs().UserID,
+			scopes,
+		)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		authz, err = h.Authorizations.Get(session.AuthnAttrs().UserID, r.ClientID())
+		if err != nil && !errors.Is(err, oauth.ErrAuthorizationNotFound) {
+			return nil, err
+		}
+
+		if authz == nil || !authz.IsAuthorized(scopes) || utils.StringSliceContains(r.Prompt(), "consent") {
+			if utils.StringSliceContains(r.Prompt(), "none") {
+				return nil, protocol.NewError("consent_required", "consent is required")
+			}
+
+			// Requested scopes not consented by user => request consent and retry
+			r2 := protocol.AuthorizationRequest{}
+			for k, v := range r {
+				r2[k] = v
+			}
+			r2.SetPrompt(utils.StringSliceExcept(r.Prompt(), []string{"consent"}))
+			authorizeURI, err := h.retryURI(client, r2, pushed)
+			if err != nil {
+				return nil, err
+			}
+
+			return authorizationResultRequireConsent{
+				ConsentURI: h.ConsentURL.ConsentURI(authorizeURI.String()),
+			}, nil
+		}
 	}
 
 	resp := protocol.AuthorizationResponse{}
@@ -174,7 +239,29 @@ func (h *AuthorizationHandler) doHandle(
 	}, nil
 }
 
-func (h *AuthorizationHandler) validateRequest(
+// retryURI returns the authorize URI to retry the request with. Pushed or
+// signed requests are pushed again, so that the parameters are kept out of
+// the URI.
+func (h *AuthorizationHandler) retryURI(
+	client config.OAuthClientConfiguration,
+	r protocol.AuthorizationRequest,
+	pushed bool,
+) (*url.URL, error) {
+	if !pushed {
+		return h.AuthorizeURL.AuthorizeURI(r), nil
+	}
+
+	requestURI, err := h.Requests.Push(client, r, RetryRequestValidDuration)
+	if err != nil {
+		return nil, err
+	}
+	return h.AuthorizeURL.AuthorizeURI(protocol.AuthorizationRequest{
+		"client_id":   client.ClientID(),
+		"request_uri": requestURI,
+	}), nil
+}
+
+func validateAuthorizationRequest(
 	client config.OAuthClientConfiguration,
 	r protocol.AuthorizationRequest,
 ) error {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/handler"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/protocol"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/urlprefix"
 	"github.com/skygeario/skygear-server/pkg/core/config"
 	coretime "github.com/skygeario/skygear-server/pkg/core/time"
 	. "github.com/smartystreets/goconvey/convey"
@@ -24,15 +26,26 @@ func TestAuthorizationHandler(t *testing.T) {
 		authzStore := &mockAuthzStore{}
 		codeGrantStore := &mockCodeGrantStore{}
 
+		clientResolver := &oauth.ClientResolver{Store: &mockClientStore{}}
+		pushedRequests := &mockPushedRequestStore{}
+
 		h := &handler.AuthorizationHandler{
 			Context: context.Background(),
 			AppID:   "app-id",
-			Clients: []config.OAuthClientConfiguration{},
+			Clients: clientResolver,
 
+			Requests: &handler.AuthorizationRequestResolver{
+				AppID:          "app-id",
+				URLPrefix:      urlprefix.Provider{Prefix: url.URL{Scheme: "https", Host: "auth"}},
+				PushedRequests: pushedRequests,
+				GenerateToken:  func() string { return "request-token" },
+				Time:           mockTime,
+			},
 			Authorizations:  authzStore,
 			CodeGrants:      codeGrantStore,
 			AuthorizeURL:    mockEndpointsProvider{},
 			AuthenticateURL: mockEndpointsProvider{},
+			ConsentURL:      mockEndpointsProvider{},
 			ValidateScopes:  func(config.OAuthClientConfiguration, []string) error { return nil },
 			CodeGenerator:   func() string { return "authz-code" },
 			Time:            mockTime,
@@ -46,7 +59,7 @@ func TestAuthorizationHandler(t *testing.T) {
 		}
 
 		Convey("general request validation", func() {
-			h.Clients = []config.OAuthClientConfiguration{{
+			clientResolver.Clients = []config.OAuthClientConfiguration{{
 				"client_id": "client-id",
 				"redirect_uris": []interface{}{
 					"https://example.com/",
@@ -75,7 +88,7 @@ func TestAuthorizationHandler(t *testing.T) {
 		})
 
 		Convey("should preserve query parameters in redirect URI", func() {
-			h.Clients = []config.OAuthClientConfiguration{{
+			clientResolver.Clients = []config.OAuthClientConfiguration{{
 				"client_id":     "client-id",
 				"redirect_uris": []interface{}{"https://example.com/cb?from=sso"},
 			}}
@@ -87,7 +100,7 @@ func TestAuthorizationHandler(t *testing.T) {
 		})
 
 		Convey("authorization code flow", func() {
-			h.Clients = []config.OAuthClientConfiguration{{
+			clientResolver.Clients = []config.OAuthClientConfiguration{{
 				"client_id":     "client-id",
 				"redirect_uris": []interface{}{"https://example.com/"},
 			}}
@@ -154,6 +167,7 @@ func TestAuthorizationHandler(t *testing.T) {
 					UserID("user-id").
 					SessionID("session-id").
 					ToContext(context.Background())
+				clientResolver.Clients[0]["is_first_party"] = true
 
 				Convey("create new authorization implicitly", func() {
 					resp := handle(protocol.AuthorizationRequest{
@@ -238,15 +252,93 @@ func TestAuthorizationHandler(t *testing.T) {
 				})
 			})
 		})
+		Convey("consent", func() {
+			clientResolver.Clients = []config.OAuthClientConfiguration{{
+				"client_id":      "client-id",
+				"redirect_uris":  []interface{}{"https://example.com/"},
+				"is_first_party": false,
+			}}
+			h.Context = authtesting.WithAuthn().
+				UserID("user-id").
+				SessionID("session-id").
+				ToContext(context.Background())
+			request := protocol.AuthorizationRequest{
+				"client_id":             "client-id",
+				"response_type":         "code",
+				"scope":                 "openid",
+				"code_challenge_method": "S256",
+				"code_challenge":        "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM",
+			}
+
+			Convey("request consent for third-party client", func() {
+				resp := handle(request)
+				So(resp.Result().StatusCode, ShouldEqual, 302)
+				So(resp.Header().Get("Location"), ShouldEqual,
+					"https://auth/consent?redirect_uri=https%3A%2F%2Fauth%2Fauthorize",
+				)
+				So(authzStore.authzs, ShouldBeEmpty)
+				So(codeGrantStore.grants, ShouldBeEmpty)
+			})
+
+			Convey("request consent for additional scopes", func() {
+				authzStore.authzs = []oauth.Authorization{{
+					ID:       "authz-id",
+					ClientID: "client-id",
+					UserID:   "user-id",
+					Scopes:   []string{"openid"},
+				}}
+				request["scope"] = "openid offline_access"
+				resp := handle(request)
+				So(resp.Result().StatusCode, ShouldEqual, 302)
+				So(resp.Header().Get("Location"), ShouldStartWith, "https://auth/consent?")
+				So(authzStore.authzs[0].Scopes, ShouldResemble, []string{"openid"})
+			})
+
+			Convey("reuse consented authorization", func() {
+				authzStore.authzs = []oauth.Authorization{{
+					ID:       "authz-id",
+					ClientID: "client-id",
+					UserID:   "user-id",
+					Scopes:   []string{"openid"},
+				}}
+				resp := handle(request)
+				So(resp.Result().StatusCode, ShouldEqual, 200)
+				So(codeGrantStore.grants, ShouldHaveLength, 1)
+				So(codeGrantStore.grants[0].AuthorizationID, ShouldEqual, "authz-id")
+
+				Convey("unless consent prompt is requested", func() {
+					request["prompt"] = "consent"
+					resp := handle(request)
+					So(resp.Result().StatusCode, ShouldEqual, 302)
+					So(resp.Header().Get("Location"), ShouldStartWith, "https://auth/consent?")
+				})
+			})
+
+			Convey("request consent for first-party client if prompted", func() {
+				clientResolver.Clients[0]["is_first_party"] = true
+				request["prompt"] = "consent"
+				resp := handle(request)
+				So(resp.Result().StatusCode, ShouldEqual, 302)
+				So(resp.Header().Get("Location"), ShouldStartWith, "https://auth/consent?")
+			})
+
+			Convey("return error if consent is required without prompt", func() {
+				request["prompt"] = "none"
+				resp := handle(request)
+				So(resp.Result().StatusCode, ShouldEqual, 200)
+				So(string(resp.Body.Bytes()), ShouldContainSubstring, "consent_required")
+				So(authzStore.authzs, ShouldBeEmpty)
+			})
+		})
 		Convey("none response type", func() {
-			h.Clients = []config.OAuthClientConfiguration{{
+			clientResolver.Clients = []config.OAuthClientConfiguration{{
 				"client_id":      "client-id",
 				"redirect_uris":  []interface{}{"https://example.com/"},
 				"response_types": []interface{}{"none"},
 			}}
 			Convey("request validation", func() {
 				Convey("not allowed response types", func() {
-					h.Clients[0]["response_types"] = nil
+					clientResolver.Clients[0]["response_types"] = nil
 					resp := handle(protocol.AuthorizationRequest{
 						"client_id":     "client-id",
 						"response_type": "none",
@@ -285,6 +377,7 @@ func TestAuthorizationHandler(t *testing.T) {
 					UserID("user-id").
 					SessionID("session-id").
 					ToContext(context.Background())
+				clientResolver.Clients[0]["is_first_party"] = true
 
 				Convey("create new authorization implicitly", func() {
 					resp := handle(protocol.AuthorizationRequest{
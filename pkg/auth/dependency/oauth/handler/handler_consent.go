@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/protocol"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/skyerr"
+	"github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+var InvalidConsentRequest = skyerr.Invalid.WithReason("InvalidConsentRequest")
+var ErrInvalidConsentRequest = InvalidConsentRequest.New("invalid consent request")
+
+var AuthorizationNotFound = skyerr.NotFound.WithReason("AuthorizationNotFound")
+var ErrAuthorizationNotFound = AuthorizationNotFound.New("authorization not found")
+
+// ConsentRequest is an authorization request pending for consent of user.
+type ConsentRequest struct {
+	Request     protocol.AuthorizationRequest
+	Client      config.OAuthClientConfiguration
+	RedirectURI *url.URL
+}
+
+// GrantedAuthorization is an authorization granted by user to a client.
+// Client is nil if the client is no longer configured.
+type GrantedAuthorization struct {
+	Authorization *oauth.Authorization
+	Client        config.OAuthClientConfiguration
+}
+
+type ConsentHandler struct {
+	AppID   string
+	Clients ClientResolver
+	Scopes  []config.OIDCScopeConfiguration
+
+	Requests       *AuthorizationRequestResolver
+	Authorizations oauth.AuthorizationStore
+	OfflineGrants  oauth.OfflineGrantStore
+	SessionRevoker SessionRevoker
+	ValidateScopes ScopesValidator
+	Time           time.Provider
+}
+
+// ParseRequest parses the authorization request from the authorize URI
+// the user would be redirected to after consent.
+func (h *ConsentHandler) ParseRequest(authorizeURI string) (*ConsentRequest, error) {
+	u, err := url.Parse(authorizeURI)
+	if err != nil {
+		return nil, ErrInvalidConsentRequest
+	}
+
+	r := protocol.AuthorizationRequest{}
+	for name, values := range u.Query() {
+		r[name] = values[0]
+	}
+
+	client, err := h.Clients.ResolveClient(r.ClientID())
+	if err != nil {
+		return nil, err
+	} else if client == nil {
+		return nil, ErrInvalidConsentRequest
+	}
+	// The request is consumed when it is retried at authorization endpoint.
+	r, err = h.Requests.Peek(client, r)
+	var oauthError *protocol.OAuthProtocolError
+	if errors.As(err, &oauthError) {
+		return nil, ErrInvalidConsentRequest
+	} else if err != nil {
+		return nil, err
+	}
+	redirectURI, errResp := parseRedirectURI(client, r)
+	if errResp != nil {
+		return nil, ErrInvalidConsentRequest
+	}
+	if err := h.ValidateScopes(client, r.Scope()); err != nil {
+		return nil, ErrInvalidConsentRequest
+	}
+
+	return &ConsentRequest{
+		Request:     r,
+		Client:      client,
+		RedirectURI: redirectURI,
+	}, nil
+}
+
+// Grant records the consent of the user on the requested scopes.
+func (h *ConsentHandler) Grant(req *ConsentRequest, session auth.AuthSession) error {
+	_, err := checkAuthorization(
+		h.Authorizations,
+		h.Time.NowUTC(),
+		h.AppID,
+		req.Client.ClientID(),
+		session.AuthnAttrs().UserID,
+		req.Request.Scope(),
+	)
+	return err
+}
+
+// Deny returns the error result to be sent to the client.
+func (h *ConsentHandler) Deny(req *ConsentRequest) AuthorizationResult {
+	resp := protocol.NewErrorResponse("access_denied", "authorization denied by user")
+	if state := req.Request.State(); state != "" {
+		resp.State(state)
+	}
+	return authorizationResultError{
+		RedirectURI:  req.RedirectURI,
+		ResponseMode: req.Request.ResponseMode(),
+		Response:     resp,
+	}
+}
+
+// List lists the authorizations granted by the user.
+func (h *ConsentHandler) List(userID string) ([]GrantedAuthorization, error) {
+	authzs, err := h.Authorizations.ListByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var granted []GrantedAuthorization
+	for _, authz := range authzs {
+		client, err := h.Clients.ResolveClient(authz.ClientID)
+		if err != nil {
+			return nil, err
+		}
+		granted = append(granted, GrantedAuthorization{
+			Authorization: authz,
+			Client:        client,
+		})
+	}
+	return granted, nil
+}
+
+// DescribeScope returns the description of a custom scope declared in
+// tenant config, or empty string for other scopes.
+func (h *ConsentHandler) DescribeScope(scope string) string {
+	for _, s := range h.Scopes {
+		if s.Name == scope {
+			return s.Description
+		}
+	}
+	return ""
+}
+
+// Revoke revokes the authorization granted by the user, with all offline
+// grants issued with it.
+func (h *ConsentHandler) Revoke(userID string, authzID string) error {
+	authz, err := h.Authorizations.GetByID(authzID)
+	if errors.Is(err, oauth.ErrAuthorizationNotFound) {
+		return ErrAuthorizationNotFound
+	} else if err != nil {
+		return err
+	}
+	if authz.UserID != userID {
+		return ErrAuthorizationNotFound
+	}
+
+	offlineGrants, err := h.OfflineGrants.ListOfflineGrants(userID)
+	if err != nil {
+		return err
+	}
+	for _, offlineGrant := range offlineGrants {
+		if offlineGrant.AuthorizationID != authz.ID {
+			continue
+		}
+		err = h.SessionRevoker.Revoke(offlineGrant)
+		if err != nil {
+			return err
+		}
+	}
+
+	return h.Authorizations.Delete(authz)
+}
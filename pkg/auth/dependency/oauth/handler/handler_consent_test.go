@@ -0,0 +1,111 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/handler"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/session"
+	"github.com/skygeario/skygear-server/pkg/core/authn"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	coretime "github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+func TestConsentHandler(t *testing.T) {
+	Convey("Consent handler", t, func() {
+		mockTime := &coretime.MockProvider{}
+		mockTime.TimeNowUTC = time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+		authzStore := &mockAuthzStore{}
+		offlineGrants := &mockOfflineGrantStore{}
+		revoker := &mockSessionRevoker{offlineGrants: offlineGrants}
+
+		h := &handler.ConsentHandler{
+			AppID: "app-id",
+			Clients: newMockClientResolver([]config.OAuthClientConfiguration{{
+				"client_id":     "client-id",
+				"client_name":   "My App",
+				"redirect_uris": []interface{}{"https://example.com/"},
+			}}),
+
+			Requests: &handler.AuthorizationRequestResolver{
+				PushedRequests: &mockPushedRequestStore{},
+				Time:           mockTime,
+			},
+			Authorizations: authzStore,
+			OfflineGrants:  offlineGrants,
+			SessionRevoker: revoker,
+			ValidateScopes: func(config.OAuthClientConfiguration, []string) error { return nil },
+			Time:           mockTime,
+		}
+
+		Convey("should parse authorization request", func() {
+			req, err := h.ParseRequest("https://auth/oauth2/authorize?client_id=client-id&scope=openid+offline_access&state=my-state")
+			So(err, ShouldBeNil)
+			So(req.Client.ClientName(), ShouldEqual, "My App")
+			So(req.Request.Scope(), ShouldResemble, []string{"openid", "offline_access"})
+			So(req.RedirectURI.String(), ShouldEqual, "https://example.com/")
+
+			_, err = h.ParseRequest("https://auth/oauth2/authorize?client_id=unknown&scope=openid")
+			So(err, ShouldBeError, handler.ErrInvalidConsentRequest)
+
+			_, err = h.ParseRequest("https://auth/oauth2/authorize?client_id=client-id&scope=openid&redirect_uri=https%3A%2F%2Fevil.com")
+			So(err, ShouldBeError, handler.ErrInvalidConsentRequest)
+		})
+
+		Convey("should record consent of user", func() {
+			req, err := h.ParseRequest("https://auth/oauth2/authorize?client_id=client-id&scope=openid")
+			So(err, ShouldBeNil)
+
+			sess := &session.IDPSession{ID: "session-id", Attrs: authn.Attrs{UserID: "user-id"}}
+			err = h.Grant(req, sess)
+			So(err, ShouldBeNil)
+			So(authzStore.authzs, ShouldHaveLength, 1)
+			So(authzStore.authzs[0].UserID, ShouldEqual, "user-id")
+			So(authzStore.authzs[0].Scopes, ShouldResemble, []string{"openid"})
+
+			granted, err := h.List("user-id")
+			So(err, ShouldBeNil)
+			So(granted, ShouldHaveLength, 1)
+			So(granted[0].Client.ClientID(), ShouldEqual, "client-id")
+		})
+
+		Convey("should return error to client if denied", func() {
+			req, err := h.ParseRequest("https://auth/oauth2/authorize?client_id=client-id&scope=openid&state=my-state")
+			So(err, ShouldBeNil)
+
+			result := h.Deny(req)
+			r, _ := http.NewRequest("GET", "/consent", nil)
+			resp := httptest.NewRecorder()
+			result.WriteResponse(resp, r)
+			So(resp.Result().StatusCode, ShouldEqual, 200)
+			So(resp.Body.String(), ShouldContainSubstring, "error=access_denied")
+			So(resp.Body.String(), ShouldContainSubstring, "state=my-state")
+		})
+
+		Convey("should revoke authorization with offline grants", func() {
+			authzStore.authzs = []oauth.Authorization{
+				{ID: "authz-id", ClientID: "client-id", UserID: "user-id"},
+				{ID: "other-authz-id", ClientID: "other-client-id", UserID: "user-id"},
+			}
+			offlineGrants.grants = []oauth.OfflineGrant{
+				{ID: "grant-1", AuthorizationID: "authz-id", Attrs: authn.Attrs{UserID: "user-id"}},
+				{ID: "grant-2", AuthorizationID: "other-authz-id", Attrs: authn.Attrs{UserID: "user-id"}},
+			}
+
+			err := h.Revoke("other-user-id", "authz-id")
+			So(err, ShouldBeError, handler.ErrAuthorizationNotFound)
+
+			err = h.Revoke("user-id", "authz-id")
+			So(err, ShouldBeNil)
+			So(revoker.revoked, ShouldResemble, []string{"grant-1"})
+			So(authzStore.authzs, ShouldHaveLength, 1)
+			So(authzStore.authzs[0].ID, ShouldEqual, "other-authz-id")
+			So(offlineGrants.grants, ShouldHaveLength, 1)
+		})
+	})
+}
@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	gotime "time"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/protocol"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/skyerr"
+	"github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+const GrantTypeDeviceCode = "urn:ietf:params:oauth:grant-type:device_code"
+
+const DeviceGrantValidDuration = 10 * gotime.Minute
+
+// DeviceGrantPollingInterval is the initial minimum polling interval in
+// seconds. It is increased by the same amount on every slow_down error.
+const DeviceGrantPollingInterval = 5
+
+// Attempts of entering user codes are limited per session and per IP
+// address within the window, so that user codes cannot be guessed.
+const (
+	UserCodeAttemptWindow           = 10 * gotime.Minute
+	UserCodeMaxAttemptsPerSession   = 10
+	UserCodeMaxAttemptsPerIPAddress = 50
+)
+
+var ErrInvalidUserCode = skyerr.Invalid.WithReason("InvalidUserCode").New("invalid or expired user code")
+var ErrUserCodeAttemptsExceeded = skyerr.TooManyRequest.WithReason("UserCodeAttemptsExceeded").New("too many user code attempts")
+
+// DeviceConsentRequest is a device authorization pending for consent of
+// user, identified by the user code entered.
+type DeviceConsentRequest struct {
+	Grant  *oauth.DeviceGrant
+	Client config.OAuthClientConfiguration
+}
+
+type DeviceAuthorizationHandler struct {
+	AppID   string
+	Request *http.Request
+
+	Clients         ClientResolver
+	ClientAuth      *ClientAuthenticator
+	Authorizations  oauth.AuthorizationStore
+	DeviceGrants    oauth.DeviceGrantStore
+	Attempts        oauth.UserCodeAttemptCounter
+	VerificationURL oauth.DeviceVerificationEndpointProvider
+	ValidateScopes  ScopesValidator
+	GenerateToken   TokenGenerator
+	Time            time.Provider
+}
+
+func (h *DeviceAuthorizationHandler) Handle(r protocol.DeviceAuthorizationRequest) (protocol.DeviceAuthorizationResponse, error) {
+	client, err := h.ClientAuth.Authenticate(r)
+	if err != nil {
+		return nil, err
+	} else if client == nil {
+		return nil, errInvalidClient
+	}
+
+	allowed := false
+	for _, grantType := range client.GrantTypes() {
+		if grantType == GrantTypeDeviceCode {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return nil, protocol.NewError("unauthorized_client", "grant type is not allowed for this client")
+	}
+
+	scopes := r.Scope()
+	if len(scopes) == 0 {
+		return nil, protocol.NewError("invalid_request", "scope is required")
+	}
+	if err := h.ValidateScopes(client, scopes); err != nil {
+		return nil, err
+	}
+
+	deviceCode := h.GenerateToken()
+	now := h.Time.NowUTC()
+	grant := &oauth.DeviceGrant{
+		AppID:    h.AppID,
+		ClientID: client.ClientID(),
+
+		CreatedAt:      now,
+		ExpireAt:       now.Add(DeviceGrantValidDuration),
+		Scopes:         scopes,
+		DeviceCodeHash: oauth.HashToken(deviceCode),
+		UserCode:       oauth.GenerateUserCode(),
+
+		Interval: DeviceGrantPollingInterval,
+		Status:   oauth.DeviceGrantStatusPending,
+	}
+	err = h.DeviceGrants.CreateDeviceGrant(grant)
+	if err != nil {
+		return nil, err
+	}
+
+	verificationURI := h.VerificationURL.DeviceVerificationEndpointURI()
+	verificationURIComplete := *verificationURI
+	verificationURIComplete.RawQuery = url.Values{"user_code": []string{grant.UserCode}}.Encode()
+
+	resp := protocol.DeviceAuthorizationResponse{}
+	resp.DeviceCode(deviceCode)
+	resp.UserCode(grant.UserCode)
+	resp.VerificationURI(verificationURI.String())
+	resp.VerificationURIComplete(verificationURIComplete.String())
+	resp.ExpiresIn(int(DeviceGrantValidDuration.Seconds()))
+	resp.Interval(grant.Interval)
+	return resp, nil
+}
+
+// ParseUserCode resolves the device authorization identified by the user
+// code, so that the client and requested scopes can be shown to the user
+// for consent.
+func (h *DeviceAuthorizationHandler) ParseUserCode(userCode string, session auth.AuthSession) (*DeviceConsentRequest, error) {
+	err := h.countAttempt("session:"+session.SessionID(), UserCodeMaxAttemptsPerSession)
+	if err != nil {
+		return nil, err
+	}
+	ip := auth.NewAccessEvent(h.Time.NowUTC(), h.Request).Remote.IP()
+	err = h.countAttempt("ip:"+ip, UserCodeMaxAttemptsPerIPAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	grant, err := h.DeviceGrants.GetDeviceGrantByUserCode(oauth.NormalizeUserCode(userCode))
+	if errors.Is(err, oauth.ErrGrantNotFound) {
+		return nil, ErrInvalidUserCode
+	} else if err != nil {
+		return nil, err
+	}
+	if h.Time.NowUTC().After(grant.ExpireAt) || grant.Status != oauth.DeviceGrantStatusPending {
+		return nil, ErrInvalidUserCode
+	}
+
+	client, err := h.Clients.ResolveClient(grant.ClientID)
+	if err != nil {
+		return nil, err
+	} else if client == nil {
+		return nil, ErrInvalidUserCode
+	}
+
+	return &DeviceConsentRequest{Grant: grant, Client: client}, nil
+}
+
+func (h *DeviceAuthorizationHandler) countAttempt(key string, limit int) error {
+	count, err := h.Attempts.CountUserCodeAttempt(key, UserCodeAttemptWindow)
+	if err != nil {
+		return err
+	}
+	if count > limit {
+		return ErrUserCodeAttemptsExceeded
+	}
+	return nil
+}
+
+// Verify records the decision of the user on the device authorization,
+// after the client and requested scopes are shown to the user.
+func (h *DeviceAuthorizationHandler) Verify(req *DeviceConsentRequest, session auth.AuthSession, approved bool) error {
+	grant := req.Grant
+	now := h.Time.NowUTC()
+	if !approved {
+		grant.Status = oauth.DeviceGrantStatusDenied
+		return h.DeviceGrants.UpdateDeviceGrant(grant)
+	}
+
+	authz, err := checkAuthorization(
+		h.Authorizations,
+		now,
+		h.AppID,
+		grant.ClientID,
+		session.AuthnAttrs().UserID,
+		grant.Scopes,
+	)
+	if err != nil {
+		return err
+	}
+
+	grant.Status = oauth.DeviceGrantStatusApproved
+	grant.AuthorizationID = authz.ID
+	grant.SessionID = session.SessionID()
+	return h.DeviceGrants.UpdateDeviceGrant(grant)
+}
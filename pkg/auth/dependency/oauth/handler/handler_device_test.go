@@ -0,0 +1,171 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/handler"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/protocol"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/session"
+	"github.com/skygeario/skygear-server/pkg/core/authn"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	coretime "github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+func TestDeviceAuthorizationGrant(t *testing.T) {
+	Convey("Device authorization grant", t, func() {
+		mockTime := &coretime.MockProvider{}
+		mockTime.TimeNowUTC = time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+		authzStore := &mockAuthzStore{}
+		deviceGrants := &mockDeviceGrantStore{}
+
+		clients := []config.OAuthClientConfiguration{{
+			"client_id":   "client-id",
+			"grant_types": []interface{}{handler.GrantTypeDeviceCode},
+		}}
+		clientAuth := &handler.ClientAuthenticator{Clients: newMockClientResolver(clients), Time: mockTime}
+
+		attempts := &mockUserCodeAttemptCounter{}
+		verifyReq, _ := http.NewRequest("POST", "/device", nil)
+		verifyReq.RemoteAddr = "127.0.0.1:12345"
+
+		dh := &handler.DeviceAuthorizationHandler{
+			AppID:           "app-id",
+			Request:         verifyReq,
+			Clients:         newMockClientResolver(clients),
+			ClientAuth:      clientAuth,
+			Authorizations:  authzStore,
+			DeviceGrants:    deviceGrants,
+			Attempts:        attempts,
+			VerificationURL: mockEndpointsProvider{},
+			ValidateScopes:  func(config.OAuthClientConfiguration, []string) error { return nil },
+			GenerateToken:   func() string { return "device-code" },
+			Time:            mockTime,
+		}
+		th := &handler.TokenHandler{
+			AppID:  "app-id",
+			Logger: logrus.NewEntry(logrus.New()),
+
+			ClientAuth:     clientAuth,
+			Authorizations: authzStore,
+			DeviceGrants:   deviceGrants,
+			Time:           mockTime,
+		}
+		poll := func() (int, map[string]interface{}) {
+			result := th.Handle(protocol.TokenRequest{
+				"grant_type":  handler.GrantTypeDeviceCode,
+				"client_id":   "client-id",
+				"device_code": "device-code",
+			})
+			req, _ := http.NewRequest("POST", "/token", nil)
+			resp := httptest.NewRecorder()
+			result.WriteResponse(resp, req)
+
+			var body map[string]interface{}
+			_ = json.Unmarshal(resp.Body.Bytes(), &body)
+			return resp.Result().StatusCode, body
+		}
+
+		resp, err := dh.Handle(protocol.DeviceAuthorizationRequest{
+			"client_id": "client-id",
+			"scope":     "openid",
+		})
+		So(err, ShouldBeNil)
+		So(resp["device_code"], ShouldEqual, "device-code")
+		So(resp["verification_uri"], ShouldEqual, "https://auth/device")
+		So(resp["interval"], ShouldEqual, handler.DeviceGrantPollingInterval)
+		So(deviceGrants.grants, ShouldHaveLength, 1)
+		userCode := resp["user_code"].(string)
+		So(resp["verification_uri_complete"], ShouldEqual, "https://auth/device?user_code="+userCode)
+
+		Convey("should reject clients not allowed to use device grant", func() {
+			clients[0]["grant_types"] = []interface{}{"authorization_code"}
+			_, err := dh.Handle(protocol.DeviceAuthorizationRequest{
+				"client_id": "client-id",
+				"scope":     "openid",
+			})
+			So(err, ShouldBeError, "grant type is not allowed for this client")
+		})
+
+		Convey("should ask client to wait until authorized", func() {
+			status, body := poll()
+			So(status, ShouldEqual, 400)
+			So(body["error"], ShouldEqual, "authorization_pending")
+
+			status, body = poll()
+			So(status, ShouldEqual, 400)
+			So(body["error"], ShouldEqual, "slow_down")
+			So(deviceGrants.grants[0].Interval, ShouldEqual, 2*handler.DeviceGrantPollingInterval)
+
+			mockTime.TimeNowUTC = mockTime.TimeNowUTC.Add(10 * time.Second)
+			status, body = poll()
+			So(status, ShouldEqual, 400)
+			So(body["error"], ShouldEqual, "authorization_pending")
+		})
+
+		Convey("should record authorization of user", func() {
+			sess := &session.IDPSession{ID: "session-id", Attrs: authn.Attrs{UserID: "user-id"}}
+
+			_, err := dh.ParseUserCode("invalid-code", sess)
+			So(err, ShouldBeError, handler.ErrInvalidUserCode)
+
+			req, err := dh.ParseUserCode(userCode, sess)
+			So(err, ShouldBeNil)
+			So(req.Client.ClientID(), ShouldEqual, "client-id")
+			So(req.Grant.Scopes, ShouldResemble, []string{"openid"})
+
+			err = dh.Verify(req, sess, true)
+			So(err, ShouldBeNil)
+			So(deviceGrants.grants[0].Status, ShouldEqual, oauth.DeviceGrantStatusApproved)
+			So(deviceGrants.grants[0].SessionID, ShouldEqual, "session-id")
+			So(authzStore.authzs, ShouldHaveLength, 1)
+			So(deviceGrants.grants[0].AuthorizationID, ShouldEqual, authzStore.authzs[0].ID)
+
+			_, err = dh.ParseUserCode(userCode, sess)
+			So(err, ShouldBeError, handler.ErrInvalidUserCode)
+		})
+
+		Convey("should limit attempts of entering user code", func() {
+			sess := &session.IDPSession{ID: "session-id", Attrs: authn.Attrs{UserID: "user-id"}}
+			for i := 0; i < handler.UserCodeMaxAttemptsPerSession; i++ {
+				_, err := dh.ParseUserCode("invalid-code", sess)
+				So(err, ShouldBeError, handler.ErrInvalidUserCode)
+			}
+			_, err := dh.ParseUserCode(userCode, sess)
+			So(err, ShouldBeError, handler.ErrUserCodeAttemptsExceeded)
+
+			attempts.counts["session:other-session-id"] = 0
+			attempts.counts["ip:127.0.0.1"] = handler.UserCodeMaxAttemptsPerIPAddress
+			otherSess := &session.IDPSession{ID: "other-session-id", Attrs: authn.Attrs{UserID: "user-id"}}
+			_, err = dh.ParseUserCode(userCode, otherSess)
+			So(err, ShouldBeError, handler.ErrUserCodeAttemptsExceeded)
+		})
+
+		Convey("should reject denied authorization", func() {
+			sess := &session.IDPSession{ID: "session-id", Attrs: authn.Attrs{UserID: "user-id"}}
+			req, err := dh.ParseUserCode(userCode, sess)
+			So(err, ShouldBeNil)
+			err = dh.Verify(req, sess, false)
+			So(err, ShouldBeNil)
+
+			status, body := poll()
+			So(status, ShouldEqual, 400)
+			So(body["error"], ShouldEqual, "access_denied")
+			So(deviceGrants.grants, ShouldBeEmpty)
+		})
+
+		Convey("should reject expired device code", func() {
+			mockTime.TimeNowUTC = mockTime.TimeNowUTC.Add(handler.DeviceGrantValidDuration + time.Second)
+			status, body := poll()
+			So(status, ShouldEqual, 400)
+			So(body["error"], ShouldEqual, "expired_token")
+		})
+	})
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/protocol"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+// IntrospectionAuthMethodsSupported are the client authentication methods
+// supported at introspection endpoint. Public clients cannot introspect tokens.
+var IntrospectionAuthMethodsSupported = []string{
+	config.ClientAuthMethodClientSecretBasic,
+	config.ClientAuthMethodClientSecretPost,
+	config.ClientAuthMethodPrivateKeyJWT,
+}
+
+type AccessGrantResolver interface {
+	GetAccessGrant(encodedToken string) (*oauth.AccessGrant, error)
+	ResolveAccessGrant(grant *oauth.AccessGrant) (auth.AuthSession, error)
+}
+
+type IntrospectionHandler struct {
+	ClientAuth     *ClientAuthenticator
+	Authorizations oauth.AuthorizationStore
+	Resolver       AccessGrantResolver
+	Users          UserProvider
+	Time           time.Provider
+}
+
+// Authenticate authenticates the resource server calling the introspection
+// endpoint with its client credentials.
+func (h *IntrospectionHandler) Authenticate(r protocol.IntrospectRequest) error {
+	client, err := h.ClientAuth.Authenticate(r)
+	if err != nil {
+		return err
+	}
+	if client == nil || !client.IsConfidential() {
+		return protocol.NewError("invalid_client", "client authentication is required")
+	}
+	return nil
+}
+
+func (h *IntrospectionHandler) Handle(r protocol.IntrospectRequest) (protocol.IntrospectResponse, error) {
+	if r.Token() == "" {
+		return nil, protocol.NewError("invalid_request", "token is required")
+	}
+
+	inactive := protocol.IntrospectResponse{}
+	inactive.Active(false)
+
+	accessGrant, err := h.Resolver.GetAccessGrant(r.Token())
+	if errors.Is(err, oauth.ErrGrantNotFound) {
+		return inactive, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if !h.Time.NowUTC().Before(accessGrant.ExpireAt) {
+		return inactive, nil
+	}
+
+	session, err := h.Resolver.ResolveAccessGrant(accessGrant)
+	if errors.Is(err, auth.ErrInvalidSession) {
+		return inactive, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	clientID := session.GetClientID()
+	if accessGrant.SessionKind != oauth.GrantSessionKindClient {
+		authz, err := h.Authorizations.GetByID(accessGrant.AuthorizationID)
+		if errors.Is(err, oauth.ErrAuthorizationNotFound) {
+			return inactive, nil
+		} else if err != nil {
+			return nil, err
+		}
+		clientID = authz.ClientID
+	}
+
+	// Tokens of disabled users are not revoked, but must not be accepted.
+	attrs := session.AuthnAttrs()
+	if attrs.UserID != "" {
+		user, err := h.Users.Get(attrs.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if user.Disabled {
+			return inactive, nil
+		}
+	}
+
+	resp := protocol.IntrospectResponse{}
+	resp.Active(true)
+	resp.ClientID(clientID)
+	resp.Scope(strings.Join(accessGrant.Scopes, " "))
+	resp.TokenType("Bearer")
+	resp.ExpiresAt(accessGrant.ExpireAt.Unix())
+	resp.IssuedAt(accessGrant.CreatedAt.Unix())
+	resp.SessionID(session.SessionID())
+
+	if attrs.UserID != "" {
+		resp.Subject(attrs.UserID)
+	}
+	if attrs.ACR != "" {
+		resp.ACR(attrs.ACR)
+	}
+	if len(attrs.AMR) > 0 {
+		resp.AMR(attrs.AMR)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,148 @@
+package handler_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/handler"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/protocol"
+	"github.com/skygeario/skygear-server/pkg/core/authn"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	coretime "github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+func TestIntrospectionHandler(t *testing.T) {
+	Convey("Introspection handler", t, func() {
+		mockTime := &coretime.MockProvider{}
+		mockTime.TimeNowUTC = time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+
+		clients := []config.OAuthClientConfiguration{
+			{
+				"client_id":                  "resource-server",
+				"client_secret":              "secret",
+				"token_endpoint_auth_method": "client_secret_post",
+			},
+			{"client_id": "client-id"},
+		}
+		authzStore := &mockAuthzStore{
+			authzs: []oauth.Authorization{
+				{ID: "authz-id", UserID: "user-id", ClientID: "client-id"},
+			},
+		}
+		offlineGrants := &mockOfflineGrantStore{
+			grants: []oauth.OfflineGrant{
+				{
+					ID:              "grant-id",
+					ClientID:        "client-id",
+					AuthorizationID: "authz-id",
+					Attrs: authn.Attrs{
+						UserID: "user-id",
+						ACR:    "http://schemas.openid.net/pape/policies/2007/06/multi-factor",
+						AMR:    []string{"pwd", "otp"},
+					},
+				},
+			},
+		}
+		accessGrants := &mockAccessGrantStore{
+			grants: []oauth.AccessGrant{
+				{
+					AuthorizationID: "authz-id",
+					SessionID:       "grant-id",
+					SessionKind:     oauth.GrantSessionKindOffline,
+					CreatedAt:       mockTime.TimeNowUTC.Add(-time.Minute),
+					ExpireAt:        mockTime.TimeNowUTC.Add(time.Hour),
+					Scopes:          []string{"openid", "offline_access"},
+					TokenHash:       oauth.HashToken("access-token"),
+				},
+				{
+					AuthorizationID: "authz-id",
+					SessionID:       "grant-id",
+					SessionKind:     oauth.GrantSessionKindOffline,
+					CreatedAt:       mockTime.TimeNowUTC.Add(-time.Hour),
+					ExpireAt:        mockTime.TimeNowUTC.Add(-time.Minute),
+					TokenHash:       oauth.HashToken("expired-token"),
+				},
+			},
+		}
+
+		users := mockUserProvider{disabled: map[string]bool{}}
+		h := &handler.IntrospectionHandler{
+			ClientAuth:     &handler.ClientAuthenticator{Clients: newMockClientResolver(clients), Time: mockTime},
+			Authorizations: authzStore,
+			Resolver: &oauth.Resolver{
+				Clients:        newMockClientResolver(clients),
+				Authorizations: authzStore,
+				AccessGrants:   accessGrants,
+				OfflineGrants:  offlineGrants,
+				Time:           mockTime,
+			},
+			Users: users,
+			Time:  mockTime,
+		}
+
+		Convey("should authenticate confidential client only", func() {
+			err := h.Authenticate(protocol.IntrospectRequest{
+				"client_id":     "resource-server",
+				"client_secret": "secret",
+			})
+			So(err, ShouldBeNil)
+
+			err = h.Authenticate(protocol.IntrospectRequest{
+				"client_id":     "resource-server",
+				"client_secret": "wrong",
+			})
+			So(err, ShouldBeError, "client authentication failed")
+
+			err = h.Authenticate(protocol.IntrospectRequest{"client_id": "client-id"})
+			So(err, ShouldBeError, "client authentication is required")
+
+			err = h.Authenticate(protocol.IntrospectRequest{})
+			So(err, ShouldBeError, "client authentication is required")
+		})
+
+		Convey("should introspect active token", func() {
+			resp, err := h.Handle(protocol.IntrospectRequest{"token": "access-token"})
+			So(err, ShouldBeNil)
+			So(resp, ShouldResemble, protocol.IntrospectResponse{
+				"active":             true,
+				"sub":                "user-id",
+				"client_id":          "client-id",
+				"scope":              "openid offline_access",
+				"token_type":         "Bearer",
+				"exp":                mockTime.TimeNowUTC.Add(time.Hour).Unix(),
+				"iat":                mockTime.TimeNowUTC.Add(-time.Minute).Unix(),
+				"skygear_session_id": "grant-id",
+				"acr":                "http://schemas.openid.net/pape/policies/2007/06/multi-factor",
+				"amr":                []string{"pwd", "otp"},
+			})
+		})
+
+		Convey("should introspect inactive token", func() {
+			inactive := protocol.IntrospectResponse{"active": false}
+
+			resp, err := h.Handle(protocol.IntrospectRequest{"token": "expired-token"})
+			So(err, ShouldBeNil)
+			So(resp, ShouldResemble, inactive)
+
+			resp, err = h.Handle(protocol.IntrospectRequest{"token": "unknown-token"})
+			So(err, ShouldBeNil)
+			So(resp, ShouldResemble, inactive)
+
+			authzStore.authzs = nil
+			resp, err = h.Handle(protocol.IntrospectRequest{"token": "access-token"})
+			So(err, ShouldBeNil)
+			So(resp, ShouldResemble, inactive)
+		})
+
+		Convey("should introspect token of disabled user as inactive", func() {
+			users.disabled["user-id"] = true
+
+			resp, err := h.Handle(protocol.IntrospectRequest{"token": "access-token"})
+			So(err, ShouldBeNil)
+			So(resp, ShouldResemble, protocol.IntrospectResponse{"active": false})
+		})
+	})
+}
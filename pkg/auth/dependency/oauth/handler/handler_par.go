@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/protocol"
+)
+
+// clientAuthParameters are parameters of pushed authorization requests used
+// for client authentication only.
+var clientAuthParameters = []string{
+	"client_secret",
+	"client_assertion_type",
+	"client_assertion",
+}
+
+// PushedAuthorizationRequestHandler implements OAuth 2.0 Pushed
+// Authorization Requests (RFC 9126).
+type PushedAuthorizationRequestHandler struct {
+	ClientAuth     *ClientAuthenticator
+	Requests       *AuthorizationRequestResolver
+	ValidateScopes ScopesValidator
+}
+
+func (h *PushedAuthorizationRequestHandler) Handle(r protocol.PushedAuthorizationRequest) (protocol.PushedAuthorizationResponse, error) {
+	client, err := h.ClientAuth.Authenticate(r)
+	if err != nil {
+		return nil, err
+	} else if client == nil {
+		return nil, errInvalidClient
+	}
+
+	req := protocol.AuthorizationRequest{}
+	for k, v := range r {
+		req[k] = v
+	}
+	for _, k := range clientAuthParameters {
+		delete(req, k)
+	}
+	req["client_id"] = client.ClientID()
+
+	if req.RequestURI() != "" {
+		return nil, protocol.NewError("invalid_request", "request_uri cannot be pushed")
+	}
+	req, err = h.Requests.ResolveRequestObject(client, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the request now, so that errors are reported to the client
+	// instead of the user.
+	if _, errResp := parseRedirectURI(client, req); errResp != nil {
+		return nil, &protocol.OAuthProtocolError{Response: errResp}
+	}
+	if err := validateAuthorizationRequest(client, req); err != nil {
+		return nil, err
+	}
+	if err := h.ValidateScopes(client, req.Scope()); err != nil {
+		return nil, err
+	}
+
+	requestURI, err := h.Requests.Push(client, req, PushedRequestValidDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := protocol.PushedAuthorizationResponse{}
+	resp.RequestURI(requestURI)
+	resp.ExpiresIn(int(PushedRequestValidDuration.Seconds()))
+	return resp, nil
+}
@@ -0,0 +1,305 @@
+package handler_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/lestrrat-go/jwx/jwk"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/handler"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/protocol"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/urlprefix"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	coretime "github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+func TestPushedAuthorizationRequest(t *testing.T) {
+	Convey("Pushed authorization requests", t, func() {
+		mockTime := &coretime.MockProvider{}
+		mockTime.TimeNowUTC = time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		So(err, ShouldBeNil)
+		publicKey, err := jwk.New(&privateKey.PublicKey)
+		So(err, ShouldBeNil)
+		_ = publicKey.Set(jwk.KeyIDKey, "key-1")
+		jwkJSON, err := json.Marshal(publicKey)
+		So(err, ShouldBeNil)
+		var jwkMap map[string]interface{}
+		So(json.Unmarshal(jwkJSON, &jwkMap), ShouldBeNil)
+
+		clientResolver := newMockClientResolver([]config.OAuthClientConfiguration{{
+			"client_id":     "client-id",
+			"redirect_uris": []interface{}{"https://example.com/"},
+			"jwks": map[string]interface{}{
+				"keys": []interface{}{jwkMap},
+			},
+		}})
+		pushedRequests := &mockPushedRequestStore{}
+		tokens := 0
+		resolver := &handler.AuthorizationRequestResolver{
+			AppID:          "app-id",
+			URLPrefix:      urlprefix.Provider{Prefix: url.URL{Scheme: "https", Host: "auth"}},
+			PushedRequests: pushedRequests,
+			GenerateToken: func() string {
+				tokens++
+				return fmt.Sprintf("request-token-%d", tokens)
+			},
+			Time: mockTime,
+		}
+		validated := false
+		validateScopes := func(config.OAuthClientConfiguration, []string) error {
+			validated = true
+			return nil
+		}
+
+		par := &handler.PushedAuthorizationRequestHandler{
+			ClientAuth: &handler.ClientAuthenticator{
+				Clients:       clientResolver,
+				URLPrefix:     resolver.URLPrefix,
+				TokenEndpoint: mockTokenEndpointProvider{},
+				Time:          mockTime,
+			},
+			Requests:       resolver,
+			ValidateScopes: validateScopes,
+		}
+		authz := &handler.AuthorizationHandler{
+			Context: context.Background(),
+			AppID:   "app-id",
+			Clients: clientResolver,
+
+			Requests:        resolver,
+			Authorizations:  &mockAuthzStore{},
+			CodeGrants:      &mockCodeGrantStore{},
+			AuthorizeURL:    &handler.URLProvider{Endpoints: mockAuthorizeEndpointProvider{}},
+			AuthenticateURL: mockEndpointsProvider{},
+			ConsentURL:      mockEndpointsProvider{},
+			ValidateScopes:  validateScopes,
+			CodeGenerator:   func() string { return "authz-code" },
+			Time:            mockTime,
+		}
+		handle := func(r protocol.AuthorizationRequest) *httptest.ResponseRecorder {
+			result := authz.Handle(r)
+			req, _ := http.NewRequest("GET", "/authorize", nil)
+			resp := httptest.NewRecorder()
+			result.WriteResponse(resp, req)
+			return resp
+		}
+		makeRequestObject := func(claims jwt.MapClaims) string {
+			token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+			token.Header["kid"] = "key-1"
+			s, err := token.SignedString(privateKey)
+			So(err, ShouldBeNil)
+			return s
+		}
+		request := protocol.AuthorizationRequest{
+			"client_id":             "client-id",
+			"response_type":         "code",
+			"scope":                 "openid",
+			"code_challenge_method": "S256",
+			"code_challenge":        "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM",
+			"state":                 "my-state",
+		}
+
+		Convey("should push valid request", func() {
+			r := protocol.PushedAuthorizationRequest{}
+			for k, v := range request {
+				r[k] = v
+			}
+			resp, err := par.Handle(r)
+			So(err, ShouldBeNil)
+			So(validated, ShouldBeTrue)
+			So(resp, ShouldResemble, protocol.PushedAuthorizationResponse{
+				"request_uri": "urn:ietf:params:oauth:request_uri:request-token-1",
+				"expires_in":  600,
+			})
+			So(pushedRequests.requests, ShouldHaveLength, 1)
+			So(pushedRequests.requests[0].ClientID, ShouldEqual, "client-id")
+			So(pushedRequests.requests[0].ExpireAt, ShouldEqual, time.Date(2020, 2, 1, 0, 10, 0, 0, time.UTC))
+			So(pushedRequests.requests[0].Request, ShouldResemble, map[string]string(request))
+
+			Convey("and resolve it by request URI", func() {
+				resp := handle(protocol.AuthorizationRequest{
+					"client_id":   "client-id",
+					"request_uri": "urn:ietf:params:oauth:request_uri:request-token-1",
+				})
+				// Authentication is requested, and the retry request is
+				// pushed again instead of put in the URI.
+				So(resp.Result().StatusCode, ShouldEqual, 302)
+				So(pushedRequests.requests, ShouldHaveLength, 1)
+				So(pushedRequests.requests[0].RequestURIHash, ShouldNotEqual, oauth.HashToken("urn:ietf:params:oauth:request_uri:request-token-1"))
+				So(pushedRequests.requests[0].Request["state"], ShouldEqual, "my-state")
+			})
+
+			Convey("and reject request URI used twice", func() {
+				resp := handle(protocol.AuthorizationRequest{
+					"client_id":   "client-id",
+					"request_uri": "urn:ietf:params:oauth:request_uri:request-token-1",
+				})
+				So(resp.Result().StatusCode, ShouldEqual, 302)
+
+				resp = handle(protocol.AuthorizationRequest{
+					"client_id":   "client-id",
+					"request_uri": "urn:ietf:params:oauth:request_uri:request-token-1",
+				})
+				So(resp.Result().StatusCode, ShouldEqual, 400)
+				So(resp.Body.String(), ShouldContainSubstring, "error: invalid_request_uri\n")
+			})
+
+			Convey("and reject request URI of other clients", func() {
+				clientResolver.Clients = append(clientResolver.Clients, config.OAuthClientConfiguration{
+					"client_id":     "other-client",
+					"redirect_uris": []interface{}{"https://example.com/"},
+				})
+				resp := handle(protocol.AuthorizationRequest{
+					"client_id":   "other-client",
+					"request_uri": "urn:ietf:params:oauth:request_uri:request-token-1",
+				})
+				So(resp.Result().StatusCode, ShouldEqual, 400)
+				So(resp.Body.String(), ShouldContainSubstring, "error: invalid_request_uri\n")
+			})
+
+			Convey("and reject expired request URI", func() {
+				mockTime.TimeNowUTC = mockTime.TimeNowUTC.Add(handler.PushedRequestValidDuration)
+				resp := handle(protocol.AuthorizationRequest{
+					"client_id":   "client-id",
+					"request_uri": "urn:ietf:params:oauth:request_uri:request-token-1",
+				})
+				So(resp.Result().StatusCode, ShouldEqual, 400)
+				So(resp.Body.String(), ShouldContainSubstring, "error: invalid_request_uri\n")
+			})
+		})
+
+		Convey("should reject invalid pushed request", func() {
+			_, err := par.Handle(protocol.PushedAuthorizationRequest{
+				"client_id":     "client-id",
+				"response_type": "code",
+				"scope":         "openid",
+			})
+			So(err, ShouldBeError, "PKCE code challenge is required")
+			So(pushedRequests.requests, ShouldBeEmpty)
+
+			_, err = par.Handle(protocol.PushedAuthorizationRequest{
+				"client_id":   "client-id",
+				"request_uri": "urn:ietf:params:oauth:request_uri:request-token-1",
+			})
+			So(err, ShouldBeError, "request_uri cannot be pushed")
+		})
+
+		Convey("should require pushed request if configured", func() {
+			clientResolver.Clients[0]["require_pushed_authorization_requests"] = true
+			resp := handle(request)
+			So(resp.Result().StatusCode, ShouldEqual, 400)
+			So(resp.Body.String(), ShouldContainSubstring,
+				"error_description: pushed authorization request is required\n")
+		})
+
+		Convey("should resolve signed request object", func() {
+			claims := jwt.MapClaims{
+				"iss": "client-id",
+				"aud": "https://auth",
+				"exp": mockTime.TimeNowUTC.Add(time.Minute).Unix(),
+			}
+			for k, v := range request {
+				claims[k] = v
+			}
+			claims["max_age"] = 300
+
+			r, err := resolver.ResolveRequestObject(clientResolver.Clients[0], protocol.AuthorizationRequest{
+				"client_id": "client-id",
+				"scope":     "email",
+				"request":   makeRequestObject(claims),
+			})
+			So(err, ShouldBeNil)
+			expected := protocol.AuthorizationRequest{"max_age": "300"}
+			for k, v := range request {
+				expected[k] = v
+			}
+			So(r, ShouldResemble, expected)
+
+			Convey("and reject request object with wrong signature", func() {
+				otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+				So(err, ShouldBeNil)
+				token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+				token.Header["kid"] = "key-1"
+				s, err := token.SignedString(otherKey)
+				So(err, ShouldBeNil)
+
+				_, err = resolver.ResolveRequestObject(clientResolver.Clients[0], protocol.AuthorizationRequest{
+					"client_id": "client-id",
+					"request":   s,
+				})
+				So(err, ShouldBeError, "invalid request object signature")
+			})
+
+			Convey("and reject request object of other clients", func() {
+				claims["client_id"] = "other-client"
+				claims["iss"] = "other-client"
+				_, err := resolver.ResolveRequestObject(clientResolver.Clients[0], protocol.AuthorizationRequest{
+					"client_id": "client-id",
+					"request":   makeRequestObject(claims),
+				})
+				So(err, ShouldBeError, "invalid issuer")
+			})
+
+			Convey("and reject expired request object", func() {
+				claims["exp"] = mockTime.TimeNowUTC.Add(-time.Minute).Unix()
+				_, err := resolver.ResolveRequestObject(clientResolver.Clients[0], protocol.AuthorizationRequest{
+					"client_id": "client-id",
+					"request":   makeRequestObject(claims),
+				})
+				So(err, ShouldBeError, "request object expired")
+
+				delete(claims, "exp")
+				_, err = resolver.ResolveRequestObject(clientResolver.Clients[0], protocol.AuthorizationRequest{
+					"client_id": "client-id",
+					"request":   makeRequestObject(claims),
+				})
+				So(err, ShouldBeError, "request object expired")
+			})
+
+			Convey("and reject request object with long lifetime", func() {
+				claims["exp"] = mockTime.TimeNowUTC.Add(2 * time.Hour).Unix()
+				_, err := resolver.ResolveRequestObject(clientResolver.Clients[0], protocol.AuthorizationRequest{
+					"client_id": "client-id",
+					"request":   makeRequestObject(claims),
+				})
+				So(err, ShouldBeError, "request object lifetime is too long")
+			})
+
+			Convey("and reject request object without issuer as audience", func() {
+				delete(claims, "aud")
+				_, err := resolver.ResolveRequestObject(clientResolver.Clients[0], protocol.AuthorizationRequest{
+					"client_id": "client-id",
+					"request":   makeRequestObject(claims),
+				})
+				So(err, ShouldBeError, "invalid audience")
+
+				claims["aud"] = "https://other-auth"
+				_, err = resolver.ResolveRequestObject(clientResolver.Clients[0], protocol.AuthorizationRequest{
+					"client_id": "client-id",
+					"request":   makeRequestObject(claims),
+				})
+				So(err, ShouldBeError, "invalid audience")
+			})
+		})
+	})
+}
+
+type mockAuthorizeEndpointProvider struct{}
+
+func (mockAuthorizeEndpointProvider) AuthorizeEndpointURI() *url.URL {
+	u, _ := url.Parse("https://auth/authorize")
+	return u
+}
@@ -0,0 +1,284 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/url"
+	"reflect"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/protocol"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/time"
+	"github.com/skygeario/skygear-server/pkg/core/uuid"
+)
+
+// serverManagedClientMetadata are metadata that cannot be set by clients.
+var serverManagedClientMetadata = []string{
+	"client_id",
+	"client_secret",
+	"client_secret_hash",
+	"client_id_issued_at",
+	"client_secret_expires_at",
+	"registration_access_token",
+	"registration_client_uri",
+}
+
+// privilegedClientMetadata are metadata that can be set only with master
+// key, since they grant privileges not consented by users, or bypass limits
+// of the server.
+var privilegedClientMetadata = []string{
+	"is_first_party",
+	"client_credentials_scopes",
+	"access_token_lifetime",
+	"refresh_token_lifetime",
+	"jwt_access_token",
+	"backchannel_logout_uri",
+}
+
+var errInvalidRegistrationToken = protocol.NewError("invalid_token", "invalid registration access token")
+
+// ClientRegistrationHandler implements OAuth 2.0 Dynamic Client Registration
+// Protocol (RFC 7591) and Management Protocol (RFC 7592).
+type ClientRegistrationHandler struct {
+	AppConfig *config.AppConfiguration
+
+	Clients          oauth.ClientStore
+	Authorizations   oauth.AuthorizationStore
+	RegisterEndpoint oauth.RegistrationEndpointProvider
+	GenerateToken    TokenGenerator
+	Time             time.Provider
+}
+
+// AuthenticateInitialAccessToken checks whether the token can be used to
+// register clients.
+func (h *ClientRegistrationHandler) AuthenticateInitialAccessToken(token string) bool {
+	if token == "" {
+		return false
+	}
+	for _, t := range h.AppConfig.OIDC.InitialAccessTokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(t)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// Register registers a new client. Privileged metadata, such as first-party
+// status, can be set only with master key.
+func (h *ClientRegistrationHandler) Register(
+	r protocol.ClientMetadata,
+	isMasterKey bool,
+) (protocol.ClientInformationResponse, error) {
+	now := h.Time.NowUTC()
+	client := &oauth.Client{
+		ID:        uuid.New(),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	metadata, clientSecret, err := h.prepareMetadata(client.ID, nil, r, isMasterKey)
+	if err != nil {
+		return nil, err
+	}
+	client.Metadata = metadata
+
+	token := h.GenerateToken()
+	client.RegistrationAccessTokenHash = oauth.HashToken(token)
+
+	err = h.Clients.Create(client)
+	if err != nil {
+		return nil, err
+	}
+
+	// Client secret is returned only once, since only its hash is stored.
+	resp := h.makeResponse(client, token)
+	if clientSecret != "" {
+		resp.ClientSecret(clientSecret)
+	}
+	return resp, nil
+}
+
+// Get returns the registered client.
+func (h *ClientRegistrationHandler) Get(clientID string, token string) (protocol.ClientInformationResponse, error) {
+	client, err := h.authenticate(clientID, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.makeResponse(client, ""), nil
+}
+
+// Update replaces the metadata of the registered client.
+func (h *ClientRegistrationHandler) Update(
+	clientID string,
+	token string,
+	r protocol.ClientMetadata,
+) (protocol.ClientInformationResponse, error) {
+	client, err := h.authenticate(clientID, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.ClientID() != client.ID {
+		return nil, protocol.NewError("invalid_client_metadata", "client ID mismatch")
+	}
+	if secret := r.ClientSecret(); secret != "" && !verifyClientSecret(client.Metadata, secret) {
+		return nil, protocol.NewError("invalid_client_metadata", "client secret mismatch")
+	}
+
+	// Privileged metadata are kept, since they cannot be changed by the
+	// client.
+	metadata, clientSecret, err := h.prepareMetadata(client.ID, client.Metadata, r, false)
+	if err != nil {
+		return nil, err
+	}
+	client.Metadata = metadata
+	client.UpdatedAt = h.Time.NowUTC()
+
+	err = h.Clients.Update(client)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := h.makeResponse(client, "")
+	if clientSecret != "" {
+		resp.ClientSecret(clientSecret)
+	}
+	return resp, nil
+}
+
+// Delete deletes the registered client, and revokes its authorizations.
+// Access tokens and refresh tokens are invalidated with the authorizations.
+func (h *ClientRegistrationHandler) Delete(clientID string, token string) error {
+	client, err := h.authenticate(clientID, token)
+	if err != nil {
+		return err
+	}
+
+	err = h.Authorizations.DeleteByClient(client.ID)
+	if err != nil {
+		return err
+	}
+
+	return h.Clients.Delete(client)
+}
+
+func (h *ClientRegistrationHandler) authenticate(clientID string, token string) (*oauth.Client, error) {
+	client, err := h.Clients.Get(clientID)
+	if errors.Is(err, oauth.ErrClientNotFound) {
+		return nil, errInvalidRegistrationToken
+	} else if err != nil {
+		return nil, err
+	}
+
+	tokenHash := oauth.HashToken(token)
+	if subtle.ConstantTimeCompare([]byte(tokenHash), []byte(client.RegistrationAccessTokenHash)) != 1 {
+		return nil, errInvalidRegistrationToken
+	}
+
+	return client, nil
+}
+
+// prepareMetadata prepares the metadata of the client from the requested
+// metadata. Existing metadata is nil for new clients. The client secret is
+// returned if a new one is generated.
+func (h *ClientRegistrationHandler) prepareMetadata(
+	clientID string,
+	existing config.OAuthClientConfiguration,
+	r protocol.ClientMetadata,
+	isMasterKey bool,
+) (config.OAuthClientConfiguration, string, error) {
+	metadata := config.OAuthClientConfiguration{}
+	for k, v := range r {
+		metadata[k] = v
+	}
+	for _, k := range serverManagedClientMetadata {
+		delete(metadata, k)
+	}
+
+	if !isMasterKey {
+		for _, k := range privilegedClientMetadata {
+			value, ok := metadata[k]
+			existingValue, hasExisting := existing[k]
+			if ok && (!hasExisting || !reflect.DeepEqual(value, existingValue)) {
+				return nil, "", protocol.NewError("invalid_client_metadata", k+" cannot be set without master key")
+			}
+			if hasExisting {
+				metadata[k] = existingValue
+			}
+		}
+		if hasGrantType(metadata, "client_credentials") && !hasGrantType(existing, "client_credentials") {
+			return nil, "", protocol.NewError("invalid_client_metadata", "client_credentials grant cannot be used without master key")
+		}
+	}
+
+	// Registered clients are third-party unless registered otherwise.
+	if _, ok := metadata["is_first_party"]; !ok {
+		metadata["is_first_party"] = false
+	}
+
+	for _, u := range metadata.RedirectURIs() {
+		if err := config.ValidateRedirectURI(u); err != nil {
+			return nil, "", protocol.NewError("invalid_redirect_uri", err.Error())
+		}
+	}
+
+	// Clients authenticate with client secret by default.
+	if _, ok := metadata["token_endpoint_auth_method"]; !ok {
+		metadata["token_endpoint_auth_method"] = config.ClientAuthMethodClientSecretBasic
+	}
+
+	metadata["client_id"] = clientID
+	clientSecret := ""
+	switch metadata.TokenEndpointAuthMethod() {
+	case config.ClientAuthMethodClientSecretBasic, config.ClientAuthMethodClientSecretPost:
+		secretHash := existing.ClientSecretHash()
+		if secretHash == "" {
+			clientSecret = h.GenerateToken()
+			secretHash = oauth.HashToken(clientSecret)
+		}
+		metadata["client_secret_hash"] = secretHash
+	}
+
+	metadata.SetDefaults()
+	if err := h.AppConfig.ValidateOAuthClient(metadata); err != nil {
+		return nil, "", protocol.NewError("invalid_client_metadata", err.Error())
+	}
+
+	return metadata, clientSecret, nil
+}
+
+func hasGrantType(c config.OAuthClientConfiguration, grantType string) bool {
+	for _, t := range c.GrantTypes() {
+		if t == grantType {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *ClientRegistrationHandler) makeResponse(client *oauth.Client, token string) protocol.ClientInformationResponse {
+	resp := protocol.ClientInformationResponse{}
+	for k, v := range client.Metadata {
+		resp[k] = v
+	}
+	delete(resp, "client_secret_hash")
+	resp.ClientIDIssuedAt(client.CreatedAt.Unix())
+	if client.Metadata.ClientSecretHash() != "" {
+		resp.ClientSecretExpiresAt(0)
+	}
+	if token != "" {
+		resp.RegistrationAccessToken(token)
+	}
+	resp.RegistrationClientURI(h.RegistrationClientURI(client.ID).String())
+	return resp
+}
+
+// RegistrationClientURI returns the client configuration endpoint of the
+// client.
+func (h *ClientRegistrationHandler) RegistrationClientURI(clientID string) *url.URL {
+	u := *h.RegisterEndpoint.RegistrationEndpointURI()
+	u.Path += "/" + url.PathEscape(clientID)
+	return &u
+}
@@ -0,0 +1,218 @@
+package handler_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/handler"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/protocol"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	coretime "github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+func TestClientRegistrationHandler(t *testing.T) {
+	Convey("Client registration handler", t, func() {
+		mockTime := &coretime.MockProvider{}
+		mockTime.TimeNowUTC = time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+		clientStore := &mockClientStore{}
+		authzStore := &mockAuthzStore{}
+		tokens := []string{"client-secret", "registration-token"}
+
+		h := &handler.ClientRegistrationHandler{
+			AppConfig: &config.AppConfiguration{
+				MasterKey: "master-key",
+				OIDC: &config.OIDCConfiguration{
+					InitialAccessTokens: []string{"initial-token"},
+				},
+			},
+			Clients:          clientStore,
+			Authorizations:   authzStore,
+			RegisterEndpoint: mockEndpointsProvider{},
+			GenerateToken: func() string {
+				token := tokens[0]
+				tokens = tokens[1:]
+				return token
+			},
+			Time: mockTime,
+		}
+
+		Convey("authenticate initial access token", func() {
+			So(h.AuthenticateInitialAccessToken("initial-token"), ShouldBeTrue)
+			So(h.AuthenticateInitialAccessToken("other-token"), ShouldBeFalse)
+			So(h.AuthenticateInitialAccessToken(""), ShouldBeFalse)
+		})
+
+		Convey("register client", func() {
+			resp, err := h.Register(protocol.ClientMetadata{
+				"client_id":     "my-client",
+				"client_name":   "Partner App",
+				"redirect_uris": []interface{}{"https://partner.example.com/cb"},
+			}, false)
+			So(err, ShouldBeNil)
+
+			clientID := resp["client_id"].(string)
+			So(clientID, ShouldNotEqual, "my-client")
+			So(resp["client_secret"], ShouldEqual, "client-secret")
+			So(resp["token_endpoint_auth_method"], ShouldEqual, "client_secret_basic")
+			So(resp["registration_access_token"], ShouldEqual, "registration-token")
+			So(resp["registration_client_uri"], ShouldEqual, "https://auth/oauth2/register/"+clientID)
+			So(resp["client_id_issued_at"], ShouldEqual, 1580515200)
+
+			So(clientStore.clients, ShouldHaveLength, 1)
+			client := clientStore.clients[0]
+			So(client.RegistrationAccessTokenHash, ShouldEqual, oauth.HashToken("registration-token"))
+			So(client.Metadata.ClientSecret(), ShouldBeEmpty)
+			So(client.Metadata.ClientSecretHash(), ShouldEqual, oauth.HashToken("client-secret"))
+			So(client.Metadata.AccessTokenLifetime(), ShouldEqual, 1800)
+			So(client.Metadata.IsFirstParty(), ShouldBeFalse)
+
+			resolver := &oauth.ClientResolver{Store: clientStore}
+			resolved, err := resolver.ResolveClient(clientID)
+			So(err, ShouldBeNil)
+			So(resolved.ClientName(), ShouldEqual, "Partner App")
+
+			delete(client.Metadata, "is_first_party")
+			resolved, err = resolver.ResolveClient(clientID)
+			So(err, ShouldBeNil)
+			So(resolved.IsFirstParty(), ShouldBeFalse)
+
+			Convey("manage with registration access token", func() {
+				_, err := h.Get(clientID, "wrong-token")
+				So(err, ShouldBeError, "invalid registration access token")
+
+				resp, err := h.Get(clientID, "registration-token")
+				So(err, ShouldBeNil)
+				So(resp["client_name"], ShouldEqual, "Partner App")
+				So(resp["registration_access_token"], ShouldBeNil)
+				So(resp["client_secret"], ShouldBeNil)
+				So(resp["client_secret_hash"], ShouldBeNil)
+
+				resp, err = h.Update(clientID, "registration-token", protocol.ClientMetadata{
+					"client_id":     clientID,
+					"client_name":   "Renamed App",
+					"redirect_uris": []interface{}{"https://partner.example.com/cb"},
+				})
+				So(err, ShouldBeNil)
+				So(resp["client_name"], ShouldEqual, "Renamed App")
+				So(resp["client_secret"], ShouldBeNil)
+				So(clientStore.clients[0].Metadata.ClientSecretHash(), ShouldEqual, oauth.HashToken("client-secret"))
+
+				_, err = h.Update(clientID, "registration-token", protocol.ClientMetadata{
+					"client_id":     clientID,
+					"client_secret": "wrong-secret",
+				})
+				So(err, ShouldBeError, "client secret mismatch")
+
+				_, err = h.Update(clientID, "registration-token", protocol.ClientMetadata{
+					"client_id":             clientID,
+					"access_token_lifetime": 86400,
+				})
+				So(err, ShouldBeError, "access_token_lifetime cannot be set without master key")
+
+				_, err = h.Update(clientID, "registration-token", protocol.ClientMetadata{
+					"client_id": "other-client",
+				})
+				So(err, ShouldBeError, "client ID mismatch")
+
+				authzStore.authzs = []oauth.Authorization{
+					{ID: "authz-id", UserID: "user-id", ClientID: clientID},
+					{ID: "other-authz-id", UserID: "user-id", ClientID: "other-client"},
+				}
+				err = h.Delete(clientID, "registration-token")
+				So(err, ShouldBeNil)
+				So(clientStore.clients, ShouldBeEmpty)
+				So(authzStore.authzs, ShouldHaveLength, 1)
+				So(authzStore.authzs[0].ClientID, ShouldEqual, "other-client")
+			})
+		})
+
+		Convey("reject invalid metadata", func() {
+			_, err := h.Register(protocol.ClientMetadata{
+				"redirect_uris": []interface{}{"/relative"},
+			}, false)
+			So(err, ShouldBeError, "redirect URI must be an absolute URI without fragment")
+
+			for _, uri := range []string{
+				"javascript:alert(document.domain)//",
+				"JavaScript://example.com/%0aalert(1)",
+				"data:text/html,<script>alert(1)</script>",
+				"vbscript:msgbox(1)",
+				"http://partner.example.com/cb",
+				"myapp:/cb",
+			} {
+				_, err = h.Register(protocol.ClientMetadata{
+					"redirect_uris": []interface{}{uri},
+				}, false)
+				So(err, ShouldNotBeNil)
+				So(err.(*protocol.OAuthProtocolError).Response["error"], ShouldEqual, "invalid_redirect_uri")
+			}
+
+			for _, uri := range []string{
+				"https://partner.example.com/cb",
+				"http://localhost:8080/cb",
+				"http://127.0.0.1/cb",
+				"http://[::1]/cb",
+				"com.example.app:/cb",
+			} {
+				tokens = []string{"client-secret", "registration-token"}
+				_, err = h.Register(protocol.ClientMetadata{
+					"redirect_uris": []interface{}{uri},
+				}, false)
+				So(err, ShouldBeNil)
+			}
+
+			_, err = h.Register(protocol.ClientMetadata{
+				"token_endpoint_auth_method": "unknown",
+			}, false)
+			So(err, ShouldNotBeNil)
+			So(err.(*protocol.OAuthProtocolError).Response["error"], ShouldEqual, "invalid_client_metadata")
+
+			for _, uri := range []string{"http://partner.example.com/logout", "/logout"} {
+				tokens = []string{"client-secret", "registration-token"}
+				_, err = h.Register(protocol.ClientMetadata{
+					"backchannel_logout_uri": uri,
+				}, true)
+				So(err, ShouldNotBeNil)
+				So(err.(*protocol.OAuthProtocolError).Response["error"], ShouldEqual, "invalid_client_metadata")
+			}
+		})
+
+		Convey("register first-party client with master key only", func() {
+			metadata := protocol.ClientMetadata{
+				"is_first_party":             true,
+				"token_endpoint_auth_method": "none",
+			}
+			_, err := h.Register(metadata, false)
+			So(err, ShouldBeError, "is_first_party cannot be set without master key")
+
+			resp, err := h.Register(metadata, true)
+			So(err, ShouldBeNil)
+			So(resp["is_first_party"], ShouldEqual, true)
+			So(resp["client_secret"], ShouldBeNil)
+		})
+
+		Convey("set privileged metadata with master key only", func() {
+			for _, metadata := range []protocol.ClientMetadata{
+				{"client_credentials_scopes": []interface{}{"https://example.com/admin"}},
+				{"grant_types": []interface{}{"client_credentials"}},
+				{"access_token_lifetime": 31536000},
+				{"jwt_access_token": true},
+				{"backchannel_logout_uri": "https://partner.example.com/logout"},
+			} {
+				_, err := h.Register(metadata, false)
+				So(err, ShouldNotBeNil)
+				So(err.(*protocol.OAuthProtocolError).Response["error"], ShouldEqual, "invalid_client_metadata")
+			}
+
+			resp, err := h.Register(protocol.ClientMetadata{
+				"grant_types":      []interface{}{"client_credentials"},
+				"jwt_access_token": true,
+			}, true)
+			So(err, ShouldBeNil)
+			So(resp["jwt_access_token"], ShouldEqual, true)
+		})
+	})
+}
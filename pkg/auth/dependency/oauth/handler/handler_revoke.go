@@ -6,24 +6,56 @@ import (
 
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/protocol"
+	"github.com/skygeario/skygear-server/pkg/core/config"
 )
 
 // TODO(oauth): write tests
 
 type RevokeHandler struct {
-	OfflineGrants oauth.OfflineGrantStore
-	AccessGrants  oauth.AccessGrantStore
+	Clients ClientResolver
+
+	ClientAuth     *ClientAuthenticator
+	Authorizations oauth.AuthorizationStore
+	OfflineGrants  oauth.OfflineGrantStore
+	AccessGrants   oauth.AccessGrantStore
+	Resolver       AccessGrantResolver
+	Denylist       oauth.AccessTokenDenylist
 }
 
 func (h *RevokeHandler) Handle(r protocol.RevokeRequest) error {
+	client, err := h.ClientAuth.Authenticate(r)
+	if err != nil {
+		return err
+	}
+
+	// JWT access tokens contain dots, so they must be checked before
+	// refresh tokens.
+	if oauth.IsJWTAccessToken(r.Token()) {
+		return h.revokeAccessGrant(client, r.Token())
+	}
+
 	token, grantID, err := oauth.DecodeRefreshToken(r.Token())
 	if err == nil {
-		return h.revokeOfflineGrant(token, grantID)
+		return h.revokeOfflineGrant(client, token, grantID)
+	}
+	return h.revokeAccessGrant(client, r.Token())
+}
+
+// canRevoke checks whether the requesting client can revoke tokens issued to
+// clientID. Tokens of confidential clients can only be revoked by the
+// authenticated client itself.
+func (h *RevokeHandler) canRevoke(client config.OAuthClientConfiguration, clientID string) (bool, error) {
+	c, err := h.Clients.ResolveClient(clientID)
+	if err != nil {
+		return false, err
+	}
+	if c == nil || !c.IsConfidential() {
+		return true, nil
 	}
-	return h.revokeAccessGrant(r.Token())
+	return client != nil && client.ClientID() == clientID, nil
 }
 
-func (h *RevokeHandler) revokeOfflineGrant(token, grantID string) error {
+func (h *RevokeHandler) revokeOfflineGrant(client config.OAuthClientConfiguration, token, grantID string) error {
 	offlineGrant, err := h.OfflineGrants.GetOfflineGrant(grantID)
 	if errors.Is(err, oauth.ErrGrantNotFound) {
 		return nil
@@ -36,6 +68,12 @@ func (h *RevokeHandler) revokeOfflineGrant(token, grantID string) error {
 		return nil
 	}
 
+	if ok, err := h.canRevoke(client, offlineGrant.ClientID); err != nil {
+		return err
+	} else if !ok {
+		return nil
+	}
+
 	err = h.OfflineGrants.DeleteOfflineGrant(offlineGrant)
 	if err != nil {
 		return err
@@ -44,16 +82,38 @@ func (h *RevokeHandler) revokeOfflineGrant(token, grantID string) error {
 	return nil
 }
 
-func (h *RevokeHandler) revokeAccessGrant(token string) error {
-	tokenHash := oauth.HashToken(token)
-	accessGrant, err := h.AccessGrants.GetAccessGrant(tokenHash)
+func (h *RevokeHandler) revokeAccessGrant(client config.OAuthClientConfiguration, token string) error {
+	accessGrant, err := h.Resolver.GetAccessGrant(token)
 	if errors.Is(err, oauth.ErrGrantNotFound) {
 		return nil
 	} else if err != nil {
 		return err
 	}
 
-	err = h.AccessGrants.DeleteAccessGrant(accessGrant)
+	// Access grants of client sessions are issued to the client directly.
+	clientID := accessGrant.SessionID
+	if accessGrant.SessionKind != oauth.GrantSessionKindClient {
+		authz, err := h.Authorizations.GetByID(accessGrant.AuthorizationID)
+		if errors.Is(err, oauth.ErrAuthorizationNotFound) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		clientID = authz.ClientID
+	}
+
+	if ok, err := h.canRevoke(client, clientID); err != nil {
+		return err
+	} else if !ok {
+		return nil
+	}
+
+	// JWT access tokens cannot be deleted, so deny them until they expire.
+	if oauth.IsJWTAccessToken(token) {
+		err = h.Denylist.DenyAccessToken(accessGrant.TokenHash, accessGrant.ExpireAt)
+	} else {
+		err = h.AccessGrants.DeleteAccessGrant(accessGrant)
+	}
 	if err != nil {
 		return err
 	}
@@ -6,15 +6,19 @@ import (
 	"encoding/base64"
 	"errors"
 	"net/http"
+	"strings"
 	gotime "time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/interaction"
 	interactionflows "github.com/skygeario/skygear-server/pkg/auth/dependency/interaction/flows"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/protocol"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/session"
+	"github.com/skygeario/skygear-server/pkg/auth/event"
+	"github.com/skygeario/skygear-server/pkg/auth/model"
 	"github.com/skygeario/skygear-server/pkg/core/authn"
 	"github.com/skygeario/skygear-server/pkg/core/config"
 	"github.com/skygeario/skygear-server/pkg/core/time"
@@ -33,33 +37,47 @@ type IDTokenIssuer interface {
 	IssueIDToken(client config.OAuthClientConfiguration, session auth.AuthSession, nonce string) (token string, err error)
 }
 
+type UserProvider interface {
+	Get(id string) (*model.User, error)
+}
+
+type SessionRevoker interface {
+	Revoke(session auth.AuthSession) error
+}
+
 type TokenHandler struct {
 	Request *http.Request
 	AppID   string
-	Clients []config.OAuthClientConfiguration
 	Logger  *logrus.Entry
 
-	Authorizations oauth.AuthorizationStore
-	CodeGrants     oauth.CodeGrantStore
-	OfflineGrants  oauth.OfflineGrantStore
-	AccessGrants   oauth.AccessGrantStore
-	AccessEvents   auth.AccessEventProvider
-	Sessions       session.Provider
-	Anonymous      AnonymousInteractionFlow
-	IDTokenIssuer  IDTokenIssuer
-	GenerateToken  TokenGenerator
-	Time           time.Provider
+	ClientAuth      *ClientAuthenticator
+	Authorizations  oauth.AuthorizationStore
+	CodeGrants      oauth.CodeGrantStore
+	DeviceGrants    oauth.DeviceGrantStore
+	OfflineGrants   oauth.OfflineGrantStore
+	AccessGrants    oauth.AccessGrantStore
+	JWTAccessTokens *oauth.JWTAccessTokenCodec
+	AccessEvents    auth.AccessEventProvider
+	Sessions        session.Provider
+	Users           UserProvider
+	Hooks           hook.Provider
+	SessionRevoker  SessionRevoker
+	Anonymous       AnonymousInteractionFlow
+	IDTokenIssuer   IDTokenIssuer
+	GenerateToken   TokenGenerator
+	Time            time.Provider
 }
 
 func (h *TokenHandler) Handle(r protocol.TokenRequest) TokenResult {
-	client := resolveClient(h.Clients, r)
-	if client == nil {
-		return tokenResultError{
-			Response: protocol.NewErrorResponse("invalid_client", "invalid client ID"),
-		}
+	client, err := h.ClientAuth.Authenticate(r)
+	if err == nil && client == nil {
+		err = errInvalidClient
 	}
 
-	result, err := h.doHandle(client, r)
+	var result TokenResult
+	if err == nil {
+		result, err = h.doHandle(client, r)
+	}
 	if err != nil {
 		var oauthError *protocol.OAuthProtocolError
 		resultErr := tokenResultError{}
@@ -112,6 +130,10 @@ func (h *TokenHandler) doHandle(
 		return tokenResultOK{Response: resp}, nil
 	case "urn:skygear-auth:params:oauth:grant-type:anonymous-request":
 		return h.handleAnonymousRequest(client, r)
+	case "client_credentials":
+		return h.handleClientCredentials(client, r)
+	case GrantTypeDeviceCode:
+		return h.handleDeviceCode(client, r)
 	default:
 		panic("oauth: unexpected grant type")
 	}
@@ -134,6 +156,12 @@ func (h *TokenHandler) validateRequest(r protocol.TokenRequest) error {
 		if r.JWT() == "" {
 			return protocol.NewError("invalid_request", "jwt is required")
 		}
+	case "client_credentials":
+		break
+	case GrantTypeDeviceCode:
+		if r.DeviceCode() == "" {
+			return protocol.NewError("invalid_request", "device code is required")
+		}
 	default:
 		return protocol.NewError("unsupported_grant_type", "grant type is not supported")
 	}
@@ -175,6 +203,10 @@ func (h *TokenHandler) handleAuthorizationCode(
 		return nil, err
 	}
 
+	if authz.ClientID != client.ClientID() {
+		return nil, errInvalidAuthzCode
+	}
+
 	sess, err := h.Sessions.Get(codeGrant.SessionID)
 	if errors.Is(err, session.ErrSessionNotFound) {
 		return nil, errInvalidAuthzCode
@@ -182,7 +214,7 @@ func (h *TokenHandler) handleAuthorizationCode(
 		return nil, err
 	}
 
-	resp, err := h.issueTokensForAuthorizationCode(client, codeGrant, authz, sess)
+	resp, err := h.issueTokensForAuthorizationCode(client, codeGrant.Scopes, codeGrant.OIDCNonce, authz, sess)
 	if err != nil {
 		return nil, err
 	}
@@ -219,6 +251,16 @@ func (h *TokenHandler) handleRefreshToken(
 
 	tokenHash := oauth.HashToken(token)
 	if subtle.ConstantTimeCompare([]byte(tokenHash), []byte(offlineGrant.TokenHash)) != 1 {
+		if offlineGrant.IsRotatedTokenHash(tokenHash) {
+			err = h.revokeReusedOfflineGrant(offlineGrant)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return nil, errInvalidRefreshToken
+	}
+
+	if offlineGrant.ClientID != client.ClientID() {
 		return nil, errInvalidRefreshToken
 	}
 
@@ -270,29 +312,153 @@ func (h *TokenHandler) handleAnonymousRequest(
 
 	resp := protocol.TokenResponse{}
 
-	offlineGrant, err := h.issueOfflineGrant(client, scopes, authz.ID, attrs, resp)
+	offlineGrant, err := h.issueOfflineGrant(client, scopes, authz.ID, "", attrs, resp)
 	if err != nil {
 		return nil, err
 	}
 
 	err = h.issueAccessGrant(client, scopes, authz.ID,
-		offlineGrant.ID, oauth.GrantSessionKindOffline, resp)
+		offlineGrant.ID, oauth.GrantSessionKindOffline, attrs, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return tokenResultOK{Response: resp}, nil
+}
+
+var errInvalidDeviceCode = protocol.NewError("invalid_grant", "invalid device code")
+
+func (h *TokenHandler) handleDeviceCode(
+	client config.OAuthClientConfiguration,
+	r protocol.TokenRequest,
+) (TokenResult, error) {
+	deviceGrant, err := h.DeviceGrants.GetDeviceGrant(oauth.HashToken(r.DeviceCode()))
+	if errors.Is(err, oauth.ErrGrantNotFound) {
+		return nil, errInvalidDeviceCode
+	} else if err != nil {
+		return nil, err
+	}
+
+	if deviceGrant.ClientID != client.ClientID() {
+		return nil, errInvalidDeviceCode
+	}
+
+	now := h.Time.NowUTC()
+	if now.After(deviceGrant.ExpireAt) {
+		return nil, protocol.NewError("expired_token", "device code is expired")
+	}
+
+	// Clients polling too frequently are asked to slow down.
+	interval := gotime.Duration(deviceGrant.Interval) * gotime.Second
+	if now.Before(deviceGrant.LastPolledAt.Add(interval)) {
+		deviceGrant.Interval += DeviceGrantPollingInterval
+		deviceGrant.LastPolledAt = now
+		if err = h.DeviceGrants.UpdateDeviceGrant(deviceGrant); err != nil {
+			return nil, err
+		}
+		return nil, protocol.NewError("slow_down", "polling too frequently")
+	}
+
+	switch deviceGrant.Status {
+	case oauth.DeviceGrantStatusPending:
+		deviceGrant.LastPolledAt = now
+		if err = h.DeviceGrants.UpdateDeviceGrant(deviceGrant); err != nil {
+			return nil, err
+		}
+		return nil, protocol.NewError("authorization_pending", "authorization is pending")
+	case oauth.DeviceGrantStatusDenied:
+		if err = h.DeviceGrants.DeleteDeviceGrant(deviceGrant); err != nil {
+			return nil, err
+		}
+		return nil, protocol.NewError("access_denied", "authorization is denied")
+	case oauth.DeviceGrantStatusApproved:
+		break
+	default:
+		panic("oauth: unexpected device grant status")
+	}
+
+	// The device code can be used once only.
+	if err = h.DeviceGrants.DeleteDeviceGrant(deviceGrant); err != nil {
+		return nil, err
+	}
+
+	authz, err := h.Authorizations.GetByID(deviceGrant.AuthorizationID)
+	if errors.Is(err, oauth.ErrAuthorizationNotFound) {
+		return nil, errInvalidDeviceCode
+	} else if err != nil {
+		return nil, err
+	}
+
+	sess, err := h.Sessions.Get(deviceGrant.SessionID)
+	if errors.Is(err, session.ErrSessionNotFound) {
+		return nil, errInvalidDeviceCode
+	} else if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.issueTokensForAuthorizationCode(client, deviceGrant.Scopes, "", authz, sess)
+	if err != nil {
+		return nil, err
+	}
+
+	return tokenResultOK{Response: resp}, nil
+}
+
+// userScopes are scopes that can only be granted by users.
+var userScopes = map[string]struct{}{
+	"openid":              {},
+	"offline_access":      {},
+	oauth.FullAccessScope: {},
+}
+
+func (h *TokenHandler) handleClientCredentials(
+	client config.OAuthClientConfiguration,
+	r protocol.TokenRequest,
+) (TokenResult, error) {
+	// Public clients cannot keep their credentials confidential.
+	if !client.IsConfidential() {
+		return nil, protocol.NewError("unauthorized_client", "client credentials grant requires client authentication")
+	}
+
+	allowedScopes := client.ClientCredentialsScopes()
+	scopes := r.Scope()
+	if len(scopes) == 0 {
+		scopes = allowedScopes
+	}
+	for _, scope := range scopes {
+		allowed := false
+		for _, s := range allowedScopes {
+			if s == scope {
+				allowed = true
+				break
+			}
+		}
+		if _, isUserScope := userScopes[scope]; isUserScope || !allowed {
+			return nil, protocol.NewError("invalid_scope", "specified scope is not allowed")
+		}
+	}
+
+	resp := protocol.TokenResponse{}
+	err := h.issueAccessGrant(client, scopes, "",
+		client.ClientID(), oauth.GrantSessionKindClient, &authn.Attrs{}, resp)
 	if err != nil {
 		return nil, err
 	}
+	resp.Scope(strings.Join(scopes, " "))
 
 	return tokenResultOK{Response: resp}, nil
 }
 
 func (h *TokenHandler) issueTokensForAuthorizationCode(
 	client config.OAuthClientConfiguration,
-	code *oauth.CodeGrant,
+	scopes []string,
+	nonce string,
 	authz *oauth.Authorization,
 	session *session.IDPSession,
 ) (protocol.TokenResponse, error) {
 	issueRefreshToken := false
 	issueIDToken := false
-	for _, scope := range code.Scopes {
+	for _, scope := range scopes {
 		switch scope {
 		case "offline_access":
 			issueRefreshToken = true
@@ -321,7 +487,7 @@ func (h *TokenHandler) issueTokensForAuthorizationCode(
 	var sessionKind oauth.GrantSessionKind
 	var atSession auth.AuthSession
 	if issueRefreshToken {
-		offlineGrant, err := h.issueOfflineGrant(client, code.Scopes, authz.ID, session.AuthnAttrs(), resp)
+		offlineGrant, err := h.issueOfflineGrant(client, scopes, authz.ID, session.ID, session.AuthnAttrs(), resp)
 		if err != nil {
 			return nil, err
 		}
@@ -334,8 +500,8 @@ func (h *TokenHandler) issueTokensForAuthorizationCode(
 		sessionKind = oauth.GrantSessionKindSession
 	}
 
-	err := h.issueAccessGrant(client, code.Scopes,
-		authz.ID, sessionID, sessionKind, resp)
+	err := h.issueAccessGrant(client, scopes,
+		authz.ID, sessionID, sessionKind, atSession.AuthnAttrs(), resp)
 	if err != nil {
 		return nil, err
 	}
@@ -344,13 +510,21 @@ func (h *TokenHandler) issueTokensForAuthorizationCode(
 		if h.IDTokenIssuer == nil {
 			return nil, errors.New("id token issuer is not provided")
 		}
-		idToken, err := h.IDTokenIssuer.IssueIDToken(client, atSession, code.OIDCNonce)
+		idToken, err := h.IDTokenIssuer.IssueIDToken(client, atSession, nonce)
 		if err != nil {
 			return nil, err
 		}
 		resp.IDToken(idToken)
 	}
 
+	// Record the client, so that it is notified when the session is ended.
+	if session.AddClientID(client.ClientID()) {
+		err = h.Sessions.Update(session)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return resp, nil
 }
 
@@ -369,6 +543,22 @@ func (h *TokenHandler) issueTokensForRefreshToken(
 
 	resp := protocol.TokenResponse{}
 
+	// Rotation is a property of the grant, so it follows the client the
+	// grant is issued to rather than the client presenting the token.
+	grantClient, err := h.ClientAuth.Clients.ResolveClient(offlineGrant.ClientID)
+	if err != nil {
+		return nil, err
+	} else if grantClient == nil {
+		return nil, errInvalidRefreshToken
+	}
+
+	if grantClient.RefreshTokenRotationEnabled() {
+		err := h.rotateRefreshToken(offlineGrant, resp)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if issueIDToken {
 		if h.IDTokenIssuer == nil {
 			return nil, errors.New("id token issuer is not provided")
@@ -380,8 +570,8 @@ func (h *TokenHandler) issueTokensForRefreshToken(
 		resp.IDToken(idToken)
 	}
 
-	err := h.issueAccessGrant(client, offlineGrant.Scopes,
-		authz.ID, offlineGrant.ID, oauth.GrantSessionKindOffline, resp)
+	err = h.issueAccessGrant(client, offlineGrant.Scopes,
+		authz.ID, offlineGrant.ID, oauth.GrantSessionKindOffline, offlineGrant.AuthnAttrs(), resp)
 	if err != nil {
 		return nil, err
 	}
@@ -393,6 +583,7 @@ func (h *TokenHandler) issueOfflineGrant(
 	client config.OAuthClientConfiguration,
 	scopes []string,
 	authzID string,
+	idpSessionID string,
 	attrs *authn.Attrs,
 	resp protocol.TokenResponse,
 ) (*oauth.OfflineGrant, error) {
@@ -404,6 +595,7 @@ func (h *TokenHandler) issueOfflineGrant(
 		ID:              uuid.New(),
 		AuthorizationID: authzID,
 		ClientID:        client.ClientID(),
+		IDPSessionID:    idpSessionID,
 
 		CreatedAt: now,
 		ExpireAt:  now.Add(gotime.Duration(client.RefreshTokenLifetime()) * gotime.Second),
@@ -430,12 +622,69 @@ func (h *TokenHandler) issueOfflineGrant(
 	return offlineGrant, nil
 }
 
+func (h *TokenHandler) rotateRefreshToken(
+	offlineGrant *oauth.OfflineGrant,
+	resp protocol.TokenResponse,
+) error {
+	token := h.GenerateToken()
+	tokenHash := offlineGrant.TokenHash
+	offlineGrant.RotateTokenHash(oauth.HashToken(token))
+
+	// The refresh token may be used concurrently; only one of the requests
+	// can rotate it, and others are treated as reuse.
+	err := h.OfflineGrants.CompareAndUpdateOfflineGrant(offlineGrant, tokenHash)
+	if errors.Is(err, oauth.ErrGrantConflict) {
+		err = h.revokeReusedOfflineGrant(offlineGrant)
+		if err != nil {
+			return err
+		}
+		return errInvalidRefreshToken
+	} else if errors.Is(err, oauth.ErrGrantNotFound) {
+		return errInvalidRefreshToken
+	} else if err != nil {
+		return err
+	}
+
+	resp.RefreshToken(oauth.EncodeRefreshToken(token, offlineGrant.ID))
+	return nil
+}
+
+// revokeReusedOfflineGrant revokes the offline grant when a rotated refresh
+// token is presented again, since either the client or an attacker holds a
+// leaked refresh token.
+func (h *TokenHandler) revokeReusedOfflineGrant(offlineGrant *oauth.OfflineGrant) error {
+	user, err := h.Users.Get(offlineGrant.Attrs.UserID)
+	if err != nil {
+		return err
+	}
+
+	err = h.Hooks.DispatchEvent(
+		event.RefreshTokenReusedEvent{
+			User:    *user,
+			Session: *offlineGrant.ToAPIModel(),
+		},
+		user,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = h.SessionRevoker.Revoke(offlineGrant)
+	if err != nil {
+		return err
+	}
+
+	h.Logger.WithField("grant_id", offlineGrant.ID).Warn("refresh token reuse detected, offline grant revoked")
+	return nil
+}
+
 func (h *TokenHandler) issueAccessGrant(
 	client config.OAuthClientConfiguration,
 	scopes []string,
 	authzID string,
 	sessionID string,
 	sessionKind oauth.GrantSessionKind,
+	attrs *authn.Attrs,
 	resp protocol.TokenResponse,
 ) error {
 	token := h.GenerateToken()
@@ -451,13 +700,25 @@ func (h *TokenHandler) issueAccessGrant(
 		Scopes:          scopes,
 		TokenHash:       oauth.HashToken(token),
 	}
-	err := h.AccessGrants.CreateAccessGrant(accessGrant)
-	if err != nil {
-		return err
+
+	// JWT access tokens are self-contained, so the grant is not stored.
+	var accessToken string
+	if client.JWTAccessTokenEnabled() {
+		var err error
+		accessToken, err = h.JWTAccessTokens.Encode(accessGrant, client.ClientID(), token, attrs)
+		if err != nil {
+			return err
+		}
+	} else {
+		err := h.AccessGrants.CreateAccessGrant(accessGrant)
+		if err != nil {
+			return err
+		}
+		accessToken = oauth.EncodeAccessToken(token)
 	}
 
 	resp.TokenType("Bearer")
-	resp.AccessToken(oauth.EncodeAccessToken(token))
+	resp.AccessToken(accessToken)
 	resp.ExpiresIn(client.AccessTokenLifetime())
 	return nil
 }
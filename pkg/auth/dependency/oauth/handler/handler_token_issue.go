@@ -28,30 +28,16 @@ func (h *TokenHandler) IssueTokens(
 
 	resp := protocol.TokenResponse{}
 
-	offlineGrant, err := h.issueOfflineGrant(client, scopes, authz.ID, attrs, resp)
+	offlineGrant, err := h.issueOfflineGrant(client, scopes, authz.ID, "", attrs, resp)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	err = h.issueAccessGrant(client, scopes, authz.ID,
-		offlineGrant.ID, oauth.GrantSessionKindOffline, resp)
+		offlineGrant.ID, oauth.GrantSessionKindOffline, attrs, resp)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	return offlineGrant, resp, nil
 }
-
-func (h *TokenHandler) RefreshAPIToken(
-	client config.OAuthClientConfiguration,
-	refreshToken string,
-) (accessToken string, err error) {
-	resp, err := h.handleRefreshToken(client, protocol.TokenRequest{
-		"client_id":     client.ClientID(),
-		"refresh_token": refreshToken,
-	})
-	if err != nil {
-		return "", err
-	}
-	return resp.GetAccessToken(), nil
-}
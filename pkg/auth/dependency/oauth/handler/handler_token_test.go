@@ -0,0 +1,337 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/handler"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/protocol"
+	"github.com/skygeario/skygear-server/pkg/auth/event"
+	"github.com/skygeario/skygear-server/pkg/core/authn"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	coretime "github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+func TestTokenHandlerRefreshToken(t *testing.T) {
+	Convey("Token handler refresh token grant", t, func() {
+		mockTime := &coretime.MockProvider{}
+		mockTime.TimeNowUTC = time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+		authzStore := &mockAuthzStore{
+			authzs: []oauth.Authorization{
+				{ID: "authz-id", UserID: "user-id", ClientID: "client-id"},
+			},
+		}
+		offlineGrants := &mockOfflineGrantStore{
+			grants: []oauth.OfflineGrant{
+				{
+					ID:              "grant-id",
+					ClientID:        "client-id",
+					AuthorizationID: "authz-id",
+					CreatedAt:       mockTime.TimeNowUTC,
+					ExpireAt:        mockTime.TimeNowUTC.Add(time.Hour),
+					Scopes:          []string{"offline_access"},
+					TokenHash:       oauth.HashToken("refresh-token-0"),
+					Attrs:           authn.Attrs{UserID: "user-id"},
+				},
+			},
+		}
+		hooks := hook.NewMockProvider()
+		revoker := &mockSessionRevoker{offlineGrants: offlineGrants}
+		tokenCount := 0
+
+		clients := []config.OAuthClientConfiguration{{
+			"client_id":             "client-id",
+			"grant_types":           []interface{}{"authorization_code", "refresh_token"},
+			"access_token_lifetime": 1800.0,
+		}, {
+			"client_id":             "other-client-id",
+			"grant_types":           []interface{}{"authorization_code", "refresh_token"},
+			"access_token_lifetime": 1800.0,
+		}}
+
+		h := &handler.TokenHandler{
+			AppID:  "app-id",
+			Logger: logrus.NewEntry(logrus.New()),
+
+			ClientAuth:     &handler.ClientAuthenticator{Clients: newMockClientResolver(clients), Time: mockTime},
+			Authorizations: authzStore,
+			OfflineGrants:  offlineGrants,
+			AccessGrants:   &mockAccessGrantStore{},
+			Users:          mockUserProvider{},
+			Hooks:          hooks,
+			SessionRevoker: revoker,
+			GenerateToken: func() string {
+				tokenCount++
+				return fmt.Sprintf("refresh-token-%d", tokenCount)
+			},
+			Time: mockTime,
+		}
+		refreshAs := func(clientID string, token string) (int, map[string]interface{}) {
+			result := h.Handle(protocol.TokenRequest{
+				"grant_type":    "refresh_token",
+				"client_id":     clientID,
+				"refresh_token": token,
+			})
+			req, _ := http.NewRequest("POST", "/token", nil)
+			resp := httptest.NewRecorder()
+			result.WriteResponse(resp, req)
+
+			var body map[string]interface{}
+			_ = json.Unmarshal(resp.Body.Bytes(), &body)
+			return resp.Result().StatusCode, body
+		}
+		refresh := func(token string) (int, map[string]interface{}) {
+			return refreshAs("client-id", token)
+		}
+
+		Convey("should reject refresh token of other client", func() {
+			status, body := refreshAs("other-client-id", "grant-id.refresh-token-0")
+			So(status, ShouldEqual, 400)
+			So(body["error"], ShouldEqual, "invalid_grant")
+
+			status, _ = refresh("grant-id.refresh-token-0")
+			So(status, ShouldEqual, 200)
+		})
+
+		Convey("should keep refresh token without rotation", func() {
+			status, body := refresh("grant-id.refresh-token-0")
+			So(status, ShouldEqual, 200)
+			So(body, ShouldContainKey, "access_token")
+			So(body, ShouldNotContainKey, "refresh_token")
+
+			status, _ = refresh("grant-id.refresh-token-0")
+			So(status, ShouldEqual, 200)
+		})
+
+		Convey("with refresh token rotation", func() {
+			clients[0]["refresh_token_rotation"] = true
+
+			Convey("should issue new refresh token", func() {
+				status, body := refresh("grant-id.refresh-token-0")
+				So(status, ShouldEqual, 200)
+				So(body["refresh_token"], ShouldNotEqual, "grant-id.refresh-token-0")
+				So(body["refresh_token"], ShouldStartWith, "grant-id.")
+
+				status, body = refresh(body["refresh_token"].(string))
+				So(status, ShouldEqual, 200)
+				So(body, ShouldContainKey, "refresh_token")
+				So(revoker.revoked, ShouldBeEmpty)
+			})
+
+			Convey("should revoke offline grant on reuse", func() {
+				status, body := refresh("grant-id.refresh-token-0")
+				So(status, ShouldEqual, 200)
+				newToken := body["refresh_token"].(string)
+
+				status, body = refresh("grant-id.refresh-token-0")
+				So(status, ShouldEqual, 400)
+				So(body["error"], ShouldEqual, "invalid_grant")
+				So(revoker.revoked, ShouldResemble, []string{"grant-id"})
+				So(hooks.DispatchedEvents, ShouldHaveLength, 1)
+				payload := hooks.DispatchedEvents[0].(event.RefreshTokenReusedEvent)
+				So(payload.User.ID, ShouldEqual, "user-id")
+				So(payload.Session.ID, ShouldEqual, "grant-id")
+
+				status, _ = refresh(newToken)
+				So(status, ShouldEqual, 400)
+			})
+
+			Convey("should revoke offline grant on reuse by other client", func() {
+				status, _ := refresh("grant-id.refresh-token-0")
+				So(status, ShouldEqual, 200)
+
+				status, body := refreshAs("other-client-id", "grant-id.refresh-token-0")
+				So(status, ShouldEqual, 400)
+				So(body["error"], ShouldEqual, "invalid_grant")
+				So(revoker.revoked, ShouldResemble, []string{"grant-id"})
+			})
+
+			Convey("should rotate refresh token only once on concurrent use", func() {
+				offlineGrants.OnCompareAndUpdate = func() {
+					offlineGrants.grants[0].RotateTokenHash(oauth.HashToken("concurrent"))
+				}
+
+				status, body := refresh("grant-id.refresh-token-0")
+				So(status, ShouldEqual, 400)
+				So(body["error"], ShouldEqual, "invalid_grant")
+				So(revoker.revoked, ShouldResemble, []string{"grant-id"})
+			})
+
+			Convey("should not revoke offline grant on unknown token", func() {
+				status, body := refresh("grant-id.unknown")
+				So(status, ShouldEqual, 400)
+				So(body["error"], ShouldEqual, "invalid_grant")
+				So(revoker.revoked, ShouldBeEmpty)
+				So(hooks.DispatchedEvents, ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func TestTokenHandlerAuthorizationCode(t *testing.T) {
+	Convey("Token handler authorization code grant", t, func() {
+		mockTime := &coretime.MockProvider{}
+		mockTime.TimeNowUTC = time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+		authzStore := &mockAuthzStore{
+			authzs: []oauth.Authorization{
+				{ID: "authz-id", UserID: "user-id", ClientID: "client-id"},
+			},
+		}
+		codeGrants := &mockCodeGrantStore{
+			grants: []oauth.CodeGrant{
+				{
+					AppID:           "app-id",
+					AuthorizationID: "authz-id",
+					SessionID:       "session-id",
+					CreatedAt:       mockTime.TimeNowUTC,
+					ExpireAt:        mockTime.TimeNowUTC.Add(5 * time.Minute),
+					Scopes:          []string{"openid"},
+					CodeHash:        oauth.HashToken("code"),
+					RedirectURI:     "https://example.com/cb",
+				},
+			},
+		}
+
+		clients := []config.OAuthClientConfiguration{{
+			"client_id":     "client-id",
+			"redirect_uris": []interface{}{"https://example.com/cb"},
+		}, {
+			"client_id":     "other-client-id",
+			"redirect_uris": []interface{}{"https://example.com/cb"},
+		}}
+
+		h := &handler.TokenHandler{
+			AppID:  "app-id",
+			Logger: logrus.NewEntry(logrus.New()),
+
+			ClientAuth:     &handler.ClientAuthenticator{Clients: newMockClientResolver(clients), Time: mockTime},
+			Authorizations: authzStore,
+			CodeGrants:     codeGrants,
+			Time:           mockTime,
+		}
+
+		Convey("should reject authorization code of other client", func() {
+			result := h.Handle(protocol.TokenRequest{
+				"grant_type":    "authorization_code",
+				"client_id":     "other-client-id",
+				"code":          "code",
+				"code_verifier": "verifier",
+				"redirect_uri":  "https://example.com/cb",
+			})
+			req, _ := http.NewRequest("POST", "/token", nil)
+			resp := httptest.NewRecorder()
+			result.WriteResponse(resp, req)
+
+			var body map[string]interface{}
+			_ = json.Unmarshal(resp.Body.Bytes(), &body)
+			So(resp.Result().StatusCode, ShouldEqual, 400)
+			So(body["error"], ShouldEqual, "invalid_grant")
+			So(codeGrants.grants, ShouldHaveLength, 1)
+		})
+	})
+}
+
+func TestTokenHandlerClientCredentials(t *testing.T) {
+	Convey("Token handler client credentials grant", t, func() {
+		mockTime := &coretime.MockProvider{}
+		mockTime.TimeNowUTC = time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+		accessGrants := &mockAccessGrantStore{}
+
+		clients := []config.OAuthClientConfiguration{
+			{
+				"client_id":                  "service-id",
+				"client_secret":              "service-secret",
+				"token_endpoint_auth_method": "client_secret_post",
+				"grant_types":                []interface{}{"client_credentials"},
+				"client_credentials_scopes":  []interface{}{"read", "write"},
+				"access_token_lifetime":      1800.0,
+			},
+			{
+				"client_id":   "public-id",
+				"grant_types": []interface{}{"client_credentials"},
+			},
+		}
+
+		h := &handler.TokenHandler{
+			AppID:  "app-id",
+			Logger: logrus.NewEntry(logrus.New()),
+
+			ClientAuth:    &handler.ClientAuthenticator{Clients: newMockClientResolver(clients), Time: mockTime},
+			AccessGrants:  accessGrants,
+			GenerateToken: func() string { return "access-token" },
+			Time:          mockTime,
+		}
+		request := func(r protocol.TokenRequest) (int, map[string]interface{}) {
+			r["grant_type"] = "client_credentials"
+			result := h.Handle(r)
+			req, _ := http.NewRequest("POST", "/token", nil)
+			resp := httptest.NewRecorder()
+			result.WriteResponse(resp, req)
+
+			var body map[string]interface{}
+			_ = json.Unmarshal(resp.Body.Bytes(), &body)
+			return resp.Result().StatusCode, body
+		}
+
+		Convey("should issue access token with client scopes", func() {
+			status, body := request(protocol.TokenRequest{
+				"client_id":     "service-id",
+				"client_secret": "service-secret",
+			})
+			So(status, ShouldEqual, 200)
+			So(body["access_token"], ShouldNotBeEmpty)
+			So(body["scope"], ShouldEqual, "read write")
+			So(body, ShouldNotContainKey, "refresh_token")
+
+			So(accessGrants.grants, ShouldHaveLength, 1)
+			So(accessGrants.grants[0].SessionID, ShouldEqual, "service-id")
+			So(accessGrants.grants[0].SessionKind, ShouldEqual, oauth.GrantSessionKindClient)
+			So(accessGrants.grants[0].Scopes, ShouldResemble, []string{"read", "write"})
+		})
+
+		Convey("should issue access token with requested scopes", func() {
+			status, body := request(protocol.TokenRequest{
+				"client_id":     "service-id",
+				"client_secret": "service-secret",
+				"scope":         "read",
+			})
+			So(status, ShouldEqual, 200)
+			So(body["scope"], ShouldEqual, "read")
+		})
+
+		Convey("should reject scopes not allowed for client", func() {
+			status, body := request(protocol.TokenRequest{
+				"client_id":     "service-id",
+				"client_secret": "service-secret",
+				"scope":         "read openid",
+			})
+			So(status, ShouldEqual, 400)
+			So(body["error"], ShouldEqual, "invalid_scope")
+			So(accessGrants.grants, ShouldBeEmpty)
+		})
+
+		Convey("should reject unauthenticated client", func() {
+			status, body := request(protocol.TokenRequest{
+				"client_id":     "service-id",
+				"client_secret": "wrong-secret",
+			})
+			So(status, ShouldEqual, 401)
+			So(body["error"], ShouldEqual, "invalid_client")
+
+			status, body = request(protocol.TokenRequest{
+				"client_id": "public-id",
+			})
+			So(status, ShouldEqual, 400)
+			So(body["error"], ShouldEqual, "unauthorized_client")
+		})
+	})
+}
@@ -2,12 +2,58 @@ package handler_test
 
 import (
 	"net/url"
+	"time"
 
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/protocol"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/webapp"
+	"github.com/skygeario/skygear-server/pkg/auth/model"
+	"github.com/skygeario/skygear-server/pkg/core/config"
 )
 
+type mockClientStore struct {
+	clients []*oauth.Client
+}
+
+func newMockClientResolver(clients []config.OAuthClientConfiguration) *oauth.ClientResolver {
+	return &oauth.ClientResolver{Clients: clients, Store: &mockClientStore{}}
+}
+
+func (s *mockClientStore) Get(clientID string) (*oauth.Client, error) {
+	for _, c := range s.clients {
+		if c.ID == clientID {
+			return c, nil
+		}
+	}
+	return nil, oauth.ErrClientNotFound
+}
+
+func (s *mockClientStore) Create(client *oauth.Client) error {
+	s.clients = append(s.clients, client)
+	return nil
+}
+
+func (s *mockClientStore) Update(client *oauth.Client) error {
+	for i, c := range s.clients {
+		if c.ID == client.ID {
+			s.clients[i] = client
+			return nil
+		}
+	}
+	return oauth.ErrClientNotFound
+}
+
+func (s *mockClientStore) Delete(client *oauth.Client) error {
+	for i, c := range s.clients {
+		if c.ID == client.ID {
+			s.clients = append(s.clients[:i], s.clients[i+1:]...)
+			return nil
+		}
+	}
+	return oauth.ErrClientNotFound
+}
+
 type mockEndpointsProvider struct{}
 
 func (mockEndpointsProvider) AuthorizeURI(r protocol.AuthorizationRequest) *url.URL {
@@ -20,6 +66,24 @@ func (mockEndpointsProvider) AuthenticateURI(opts webapp.AuthenticateURLOptions)
 	return u, nil
 }
 
+func (mockEndpointsProvider) ConsentURI(redirectURI string) *url.URL {
+	u, _ := url.Parse("https://auth/consent")
+	q := u.Query()
+	q.Set("redirect_uri", redirectURI)
+	u.RawQuery = q.Encode()
+	return u
+}
+
+func (mockEndpointsProvider) DeviceVerificationEndpointURI() *url.URL {
+	u, _ := url.Parse("https://auth/device")
+	return u
+}
+
+func (mockEndpointsProvider) RegistrationEndpointURI() *url.URL {
+	u, _ := url.Parse("https://auth/oauth2/register")
+	return u
+}
+
 type mockAuthzStore struct {
 	authzs []oauth.Authorization
 }
@@ -42,6 +106,17 @@ func (m *mockAuthzStore) GetByID(id string) (*oauth.Authorization, error) {
 	return nil, oauth.ErrAuthorizationNotFound
 }
 
+func (m *mockAuthzStore) ListByUser(userID string) ([]*oauth.Authorization, error) {
+	var authzs []*oauth.Authorization
+	for _, a := range m.authzs {
+		if a.UserID == userID {
+			a := a
+			authzs = append(authzs, &a)
+		}
+	}
+	return authzs, nil
+}
+
 func (m *mockAuthzStore) Create(authz *oauth.Authorization) error {
 	m.authzs = append(m.authzs, *authz)
 	return nil
@@ -59,6 +134,30 @@ func (m *mockAuthzStore) Delete(authz *oauth.Authorization) error {
 	return nil
 }
 
+func (m *mockAuthzStore) DeleteByUser(userID string) error {
+	n := 0
+	for _, a := range m.authzs {
+		if a.UserID != userID {
+			m.authzs[n] = a
+			n++
+		}
+	}
+	m.authzs = m.authzs[:n]
+	return nil
+}
+
+func (m *mockAuthzStore) DeleteByClient(clientID string) error {
+	n := 0
+	for _, a := range m.authzs {
+		if a.ClientID != clientID {
+			m.authzs[n] = a
+			n++
+		}
+	}
+	m.authzs = m.authzs[:n]
+	return nil
+}
+
 func (m *mockAuthzStore) UpdateScopes(authz *oauth.Authorization) error {
 	for i, a := range m.authzs {
 		if a.ID == authz.ID {
@@ -99,3 +198,226 @@ func (m *mockCodeGrantStore) DeleteCodeGrant(grant *oauth.CodeGrant) error {
 	m.grants = m.grants[:n]
 	return nil
 }
+
+type mockPushedRequestStore struct {
+	requests []oauth.PushedRequest
+}
+
+func (m *mockPushedRequestStore) GetPushedRequest(requestURIHash string) (*oauth.PushedRequest, error) {
+	for _, r := range m.requests {
+		if r.RequestURIHash == requestURIHash {
+			return &r, nil
+		}
+	}
+	return nil, oauth.ErrGrantNotFound
+}
+
+func (m *mockPushedRequestStore) CreatePushedRequest(r *oauth.PushedRequest) error {
+	m.requests = append(m.requests, *r)
+	return nil
+}
+
+func (m *mockPushedRequestStore) ConsumePushedRequest(requestURIHash string) error {
+	for i, r := range m.requests {
+		if r.RequestURIHash == requestURIHash {
+			m.requests = append(m.requests[:i], m.requests[i+1:]...)
+			return nil
+		}
+	}
+	return oauth.ErrGrantNotFound
+}
+
+type mockUserCodeAttemptCounter struct {
+	counts map[string]int
+}
+
+func (m *mockUserCodeAttemptCounter) CountUserCodeAttempt(key string, window time.Duration) (int, error) {
+	if m.counts == nil {
+		m.counts = map[string]int{}
+	}
+	m.counts[key]++
+	return m.counts[key], nil
+}
+
+type mockClientAssertionStore struct {
+	used map[string]time.Time
+}
+
+func (m *mockClientAssertionStore) UseClientAssertion(clientID string, jti string, expireAt time.Time) (bool, error) {
+	if m.used == nil {
+		m.used = map[string]time.Time{}
+	}
+	key := clientID + ":" + jti
+	if _, ok := m.used[key]; ok {
+		return false, nil
+	}
+	m.used[key] = expireAt
+	return true, nil
+}
+
+type mockOfflineGrantStore struct {
+	grants []oauth.OfflineGrant
+	// OnCompareAndUpdate is invoked before comparing, to simulate
+	// concurrent modification.
+	OnCompareAndUpdate func()
+}
+
+func (m *mockOfflineGrantStore) GetOfflineGrant(id string) (*oauth.OfflineGrant, error) {
+	for _, g := range m.grants {
+		if g.ID == id {
+			return &g, nil
+		}
+	}
+	return nil, oauth.ErrGrantNotFound
+}
+
+func (m *mockOfflineGrantStore) CreateOfflineGrant(grant *oauth.OfflineGrant) error {
+	m.grants = append(m.grants, *grant)
+	return nil
+}
+
+func (m *mockOfflineGrantStore) UpdateOfflineGrant(grant *oauth.OfflineGrant) error {
+	for i, g := range m.grants {
+		if g.ID == grant.ID {
+			m.grants[i] = *grant
+		}
+	}
+	return nil
+}
+
+func (m *mockOfflineGrantStore) CompareAndUpdateOfflineGrant(grant *oauth.OfflineGrant, tokenHash string) error {
+	if f := m.OnCompareAndUpdate; f != nil {
+		m.OnCompareAndUpdate = nil
+		f()
+	}
+	for i, g := range m.grants {
+		if g.ID == grant.ID {
+			if g.TokenHash != tokenHash {
+				return oauth.ErrGrantConflict
+			}
+			m.grants[i] = *grant
+			return nil
+		}
+	}
+	return oauth.ErrGrantNotFound
+}
+
+func (m *mockOfflineGrantStore) DeleteOfflineGrant(grant *oauth.OfflineGrant) error {
+	n := 0
+	for _, g := range m.grants {
+		if g.ID != grant.ID {
+			m.grants[n] = g
+			n++
+		}
+	}
+	m.grants = m.grants[:n]
+	return nil
+}
+
+func (m *mockOfflineGrantStore) ListOfflineGrants(userID string) ([]*oauth.OfflineGrant, error) {
+	var grants []*oauth.OfflineGrant
+	for _, g := range m.grants {
+		if g.Attrs.UserID == userID {
+			g := g
+			grants = append(grants, &g)
+		}
+	}
+	return grants, nil
+}
+
+type mockAccessGrantStore struct {
+	grants []oauth.AccessGrant
+}
+
+func (m *mockAccessGrantStore) GetAccessGrant(tokenHash string) (*oauth.AccessGrant, error) {
+	for _, g := range m.grants {
+		if g.TokenHash == tokenHash {
+			return &g, nil
+		}
+	}
+	return nil, oauth.ErrGrantNotFound
+}
+
+func (m *mockAccessGrantStore) CreateAccessGrant(grant *oauth.AccessGrant) error {
+	m.grants = append(m.grants, *grant)
+	return nil
+}
+
+func (m *mockAccessGrantStore) DeleteAccessGrant(grant *oauth.AccessGrant) error {
+	n := 0
+	for _, g := range m.grants {
+		if g.TokenHash != grant.TokenHash {
+			m.grants[n] = g
+			n++
+		}
+	}
+	m.grants = m.grants[:n]
+	return nil
+}
+
+type mockDeviceGrantStore struct {
+	grants []oauth.DeviceGrant
+}
+
+func (m *mockDeviceGrantStore) GetDeviceGrant(deviceCodeHash string) (*oauth.DeviceGrant, error) {
+	for _, g := range m.grants {
+		if g.DeviceCodeHash == deviceCodeHash {
+			return &g, nil
+		}
+	}
+	return nil, oauth.ErrGrantNotFound
+}
+
+func (m *mockDeviceGrantStore) GetDeviceGrantByUserCode(userCode string) (*oauth.DeviceGrant, error) {
+	for _, g := range m.grants {
+		if g.UserCode == userCode {
+			return &g, nil
+		}
+	}
+	return nil, oauth.ErrGrantNotFound
+}
+
+func (m *mockDeviceGrantStore) CreateDeviceGrant(grant *oauth.DeviceGrant) error {
+	m.grants = append(m.grants, *grant)
+	return nil
+}
+
+func (m *mockDeviceGrantStore) UpdateDeviceGrant(grant *oauth.DeviceGrant) error {
+	for i, g := range m.grants {
+		if g.DeviceCodeHash == grant.DeviceCodeHash {
+			m.grants[i] = *grant
+			return nil
+		}
+	}
+	return oauth.ErrGrantNotFound
+}
+
+func (m *mockDeviceGrantStore) DeleteDeviceGrant(grant *oauth.DeviceGrant) error {
+	n := 0
+	for _, g := range m.grants {
+		if g.DeviceCodeHash != grant.DeviceCodeHash {
+			m.grants[n] = g
+			n++
+		}
+	}
+	m.grants = m.grants[:n]
+	return nil
+}
+
+type mockUserProvider struct {
+	disabled map[string]bool
+}
+
+func (m mockUserProvider) Get(id string) (*model.User, error) {
+	return &model.User{ID: id, Disabled: m.disabled[id]}, nil
+}
+
+type mockSessionRevoker struct {
+	offlineGrants *mockOfflineGrantStore
+	revoked       []string
+}
+
+func (m *mockSessionRevoker) Revoke(session auth.AuthSession) error {
+	m.revoked = append(m.revoked, session.SessionID())
+	return m.offlineGrants.DeleteOfflineGrant(session.(*oauth.OfflineGrant))
+}
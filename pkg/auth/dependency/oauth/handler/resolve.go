@@ -12,13 +12,8 @@ type oauthRequest interface {
 	RedirectURI() string
 }
 
-func resolveClient(clients []config.OAuthClientConfiguration, r oauthRequest) config.OAuthClientConfiguration {
-	for _, c := range clients {
-		if c.ClientID() == r.ClientID() {
-			return c
-		}
-	}
-	return nil
+type ClientResolver interface {
+	ResolveClient(clientID string) (config.OAuthClientConfiguration, error)
 }
 
 func parseRedirectURI(client config.OAuthClientConfiguration, r oauthRequest) (*url.URL, protocol.ErrorResponse) {
@@ -29,6 +29,9 @@ type (
 	authorizationResultRequireAuthn struct {
 		AuthenticateURI *url.URL
 	}
+	authorizationResultRequireConsent struct {
+		ConsentURI *url.URL
+	}
 )
 
 func (a authorizationResultCode) WriteResponse(rw http.ResponseWriter, r *http.Request) {
@@ -70,3 +73,11 @@ func (a authorizationResultRequireAuthn) WriteResponse(rw http.ResponseWriter, r
 func (a authorizationResultRequireAuthn) IsInternalError() bool {
 	return false
 }
+
+func (a authorizationResultRequireConsent) WriteResponse(rw http.ResponseWriter, r *http.Request) {
+	http.Redirect(rw, r, a.ConsentURI.String(), http.StatusFound)
+}
+
+func (a authorizationResultRequireConsent) IsInternalError() bool {
+	return false
+}
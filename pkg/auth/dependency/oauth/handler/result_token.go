@@ -45,6 +45,8 @@ func (t tokenResultError) WriteResponse(rw http.ResponseWriter, r *http.Request)
 	rw.Header().Set("Pragma", "no-cache")
 	if t.InternalError {
 		rw.WriteHeader(http.StatusInternalServerError)
+	} else if t.Response["error"] == "invalid_client" {
+		rw.WriteHeader(http.StatusUnauthorized)
 	} else {
 		rw.WriteHeader(http.StatusBadRequest)
 	}
@@ -1,9 +1,13 @@
 package oauth
 
 type MetadataProvider struct {
-	AuthorizeEndpoint AuthorizeEndpointProvider
-	TokenEndpoint     TokenEndpointProvider
-	RevokeEndpoint    RevokeEndpointProvider
+	AuthorizeEndpoint  AuthorizeEndpointProvider
+	TokenEndpoint      TokenEndpointProvider
+	RevokeEndpoint     RevokeEndpointProvider
+	IntrospectEndpoint IntrospectEndpointProvider
+	DeviceEndpoint     DeviceAuthorizationEndpointProvider
+	RegisterEndpoint   RegistrationEndpointProvider
+	PAREndpoint        PushedAuthorizationRequestEndpointProvider
 }
 
 func (p *MetadataProvider) PopulateMetadata(meta map[string]interface{}) {
@@ -11,8 +15,17 @@ func (p *MetadataProvider) PopulateMetadata(meta map[string]interface{}) {
 	meta["token_endpoint"] = p.TokenEndpoint.TokenEndpointURI().String()
 	meta["response_types_supported"] = []string{"code", "none"}
 	meta["response_modes_supported"] = []string{"query", "fragment", "form_post"}
-	meta["grant_types_supported"] = []string{"authorization_code", "refresh_token"}
+	meta["grant_types_supported"] = []string{
+		"authorization_code",
+		"refresh_token",
+		"client_credentials",
+		"urn:ietf:params:oauth:grant-type:device_code",
+	}
 	meta["code_challenge_methods_supported"] = []string{"S256"}
 	meta["revocation_endpoint"] = p.RevokeEndpoint.RevokeEndpointURI().String()
-
+	meta["introspection_endpoint"] = p.IntrospectEndpoint.IntrospectEndpointURI().String()
+	meta["device_authorization_endpoint"] = p.DeviceEndpoint.DeviceAuthorizationEndpointURI().String()
+	meta["registration_endpoint"] = p.RegisterEndpoint.RegistrationEndpointURI().String()
+	meta["pushed_authorization_request_endpoint"] = p.PAREndpoint.PushedAuthorizationRequestEndpointURI().String()
+	meta["require_pushed_authorization_requests"] = false
 }
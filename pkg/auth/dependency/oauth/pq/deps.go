@@ -8,4 +8,6 @@ import (
 var DependencySet = wire.NewSet(
 	wire.Struct(new(AuthorizationStore), "*"),
 	wire.Bind(new(oauth.AuthorizationStore), new(*AuthorizationStore)),
+	wire.Struct(new(ClientStore), "*"),
+	wire.Bind(new(oauth.ClientStore), new(*ClientStore)),
 )
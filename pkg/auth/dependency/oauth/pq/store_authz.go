@@ -43,6 +43,31 @@ func (s *AuthorizationStore) GetByID(id string) (*oauth.Authorization, error) {
 	return s.scanAuthz(scanner)
 }
 
+func (s *AuthorizationStore) ListByUser(userID string) ([]*oauth.Authorization, error) {
+	builder := s.SQLBuilder.Tenant().
+		Select("id", "app_id", "client_id", "user_id", "created_at", "updated_at", "scopes").
+		From(s.SQLBuilder.FullTableName("oauth_authorization")).
+		Where("user_id = ?", userID).
+		OrderBy("created_at")
+
+	rows, err := s.SQLExecutor.QueryWith(builder)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var authzs []*oauth.Authorization
+	for rows.Next() {
+		authz, err := s.scanAuthz(rows)
+		if err != nil {
+			return nil, err
+		}
+		authzs = append(authzs, authz)
+	}
+
+	return authzs, nil
+}
+
 func (s *AuthorizationStore) scanAuthz(scn sqlx.ColScanner) (*oauth.Authorization, error) {
 	authz := &oauth.Authorization{}
 	var scopeBytes []byte
@@ -108,6 +133,32 @@ func (s *AuthorizationStore) Delete(authz *oauth.Authorization) error {
 	return nil
 }
 
+func (s *AuthorizationStore) DeleteByUser(userID string) error {
+	builder := s.SQLBuilder.Tenant().
+		Delete(s.SQLBuilder.FullTableName("oauth_authorization")).
+		Where("user_id = ?", userID)
+
+	_, err := s.SQLExecutor.ExecWith(builder)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *AuthorizationStore) DeleteByClient(clientID string) error {
+	builder := s.SQLBuilder.Tenant().
+		Delete(s.SQLBuilder.FullTableName("oauth_authorization")).
+		Where("client_id = ?", clientID)
+
+	_, err := s.SQLExecutor.ExecWith(builder)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (s *AuthorizationStore) UpdateScopes(authz *oauth.Authorization) error {
 	scopeBytes, err := json.Marshal(authz.Scopes)
 	if err != nil {
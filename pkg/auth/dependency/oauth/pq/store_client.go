@@ -0,0 +1,109 @@
+package pq
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+)
+
+type ClientStore struct {
+	SQLBuilder  db.SQLBuilder
+	SQLExecutor db.SQLExecutor
+}
+
+func (s *ClientStore) Get(clientID string) (*oauth.Client, error) {
+	builder := s.SQLBuilder.Tenant().
+		Select("id", "app_id", "created_at", "updated_at", "registration_access_token_hash", "metadata").
+		From(s.SQLBuilder.FullTableName("oauth_client")).
+		Where("id = ?", clientID)
+
+	scanner, err := s.SQLExecutor.QueryRowWith(builder)
+	if err != nil {
+		return nil, err
+	}
+
+	client := &oauth.Client{}
+	var metadataBytes []byte
+	err = scanner.Scan(
+		&client.ID,
+		&client.AppID,
+		&client.CreatedAt,
+		&client.UpdatedAt,
+		&client.RegistrationAccessTokenHash,
+		&metadataBytes,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, oauth.ErrClientNotFound
+	} else if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(metadataBytes, &client.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func (s *ClientStore) Create(client *oauth.Client) error {
+	metadataBytes, err := json.Marshal(client.Metadata)
+	if err != nil {
+		return err
+	}
+
+	builder := s.SQLBuilder.Tenant().
+		Insert(s.SQLBuilder.FullTableName("oauth_client")).
+		Columns("id", "created_at", "updated_at", "registration_access_token_hash", "metadata").
+		Values(
+			client.ID,
+			client.CreatedAt,
+			client.UpdatedAt,
+			client.RegistrationAccessTokenHash,
+			metadataBytes,
+		)
+
+	_, err = s.SQLExecutor.ExecWith(builder)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *ClientStore) Update(client *oauth.Client) error {
+	metadataBytes, err := json.Marshal(client.Metadata)
+	if err != nil {
+		return err
+	}
+
+	builder := s.SQLBuilder.Tenant().
+		Update(s.SQLBuilder.FullTableName("oauth_client")).
+		Set("updated_at", client.UpdatedAt).
+		Set("registration_access_token_hash", client.RegistrationAccessTokenHash).
+		Set("metadata", metadataBytes).
+		Where("id = ?", client.ID)
+
+	_, err = s.SQLExecutor.ExecWith(builder)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *ClientStore) Delete(client *oauth.Client) error {
+	builder := s.SQLBuilder.Tenant().
+		Delete(s.SQLBuilder.FullTableName("oauth_client")).
+		Where("id = ?", client.ID)
+
+	_, err := s.SQLExecutor.ExecWith(builder)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -27,6 +27,11 @@ func (r AuthorizationResponse) State(v string) { r["state"] = v }
 func (r AuthorizationRequest) Nonce() string       { return r["nonce"] }
 func (r AuthorizationRequest) UILocales() []string { return parseSpaceDelimitedString(r["ui_locales"]) }
 
+// JWT-Secured Authorization Request & Pushed Authorization Requests
+
+func (r AuthorizationRequest) Request() string    { return r["request"] }
+func (r AuthorizationRequest) RequestURI() string { return r["request_uri"] }
+
 // PKCE extension
 
 func (r AuthorizationRequest) CodeChallenge() string       { return r["code_challenge"] }
@@ -0,0 +1,30 @@
+package protocol
+
+type IntrospectRequest map[string]string
+type IntrospectResponse map[string]interface{}
+
+// OAuth 2.0 Token Introspection
+
+func (r IntrospectRequest) Token() string         { return r["token"] }
+func (r IntrospectRequest) TokenTypeHint() string { return r["token_type_hint"] }
+
+func (r IntrospectResponse) Active(v bool)      { r["active"] = v }
+func (r IntrospectResponse) Subject(v string)   { r["sub"] = v }
+func (r IntrospectResponse) ClientID(v string)  { r["client_id"] = v }
+func (r IntrospectResponse) Scope(v string)     { r["scope"] = v }
+func (r IntrospectResponse) TokenType(v string) { r["token_type"] = v }
+func (r IntrospectResponse) ExpiresAt(v int64)  { r["exp"] = v }
+func (r IntrospectResponse) IssuedAt(v int64)   { r["iat"] = v }
+
+// Skygear extension
+
+func (r IntrospectResponse) SessionID(v string) { r["skygear_session_id"] = v }
+func (r IntrospectResponse) ACR(v string)       { r["acr"] = v }
+func (r IntrospectResponse) AMR(v []string)     { r["amr"] = v }
+
+// Client authentication
+
+func (r IntrospectRequest) ClientID() string            { return r["client_id"] }
+func (r IntrospectRequest) ClientSecret() string        { return r["client_secret"] }
+func (r IntrospectRequest) ClientAssertionType() string { return r["client_assertion_type"] }
+func (r IntrospectRequest) ClientAssertion() string     { return r["client_assertion"] }
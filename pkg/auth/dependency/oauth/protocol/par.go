@@ -0,0 +1,17 @@
+package protocol
+
+type PushedAuthorizationRequest map[string]string
+type PushedAuthorizationResponse map[string]interface{}
+
+// OAuth 2.0 Pushed Authorization Requests
+
+func (r PushedAuthorizationRequest) ClientID() string { return r["client_id"] }
+
+func (r PushedAuthorizationResponse) RequestURI(v string) { r["request_uri"] = v }
+func (r PushedAuthorizationResponse) ExpiresIn(v int)     { r["expires_in"] = v }
+
+// Client authentication
+
+func (r PushedAuthorizationRequest) ClientSecret() string        { return r["client_secret"] }
+func (r PushedAuthorizationRequest) ClientAssertionType() string { return r["client_assertion_type"] }
+func (r PushedAuthorizationRequest) ClientAssertion() string     { return r["client_assertion"] }
@@ -0,0 +1,27 @@
+package protocol
+
+// ClientMetadata is the client metadata of OAuth 2.0 Dynamic Client
+// Registration, in same format as OAuthClientConfiguration.
+type ClientMetadata map[string]interface{}
+type ClientInformationResponse map[string]interface{}
+
+// OAuth 2.0 Dynamic Client Registration Protocol
+
+func (r ClientMetadata) ClientID() string {
+	s, _ := r["client_id"].(string)
+	return s
+}
+
+func (r ClientMetadata) ClientSecret() string {
+	s, _ := r["client_secret"].(string)
+	return s
+}
+
+func (r ClientInformationResponse) ClientID(v string)             { r["client_id"] = v }
+func (r ClientInformationResponse) ClientSecret(v string)         { r["client_secret"] = v }
+func (r ClientInformationResponse) ClientIDIssuedAt(v int64)      { r["client_id_issued_at"] = v }
+func (r ClientInformationResponse) ClientSecretExpiresAt(v int64) { r["client_secret_expires_at"] = v }
+func (r ClientInformationResponse) RegistrationAccessToken(v string) {
+	r["registration_access_token"] = v
+}
+func (r ClientInformationResponse) RegistrationClientURI(v string) { r["registration_client_uri"] = v }
@@ -0,0 +1,38 @@
+package oauth
+
+import (
+	"strings"
+	"time"
+)
+
+// RequestURIPrefix is the prefix of request URIs referencing pushed
+// authorization requests.
+const RequestURIPrefix = "urn:ietf:params:oauth:request_uri:"
+
+// PushedRequest is an authorization request pushed to the server, which can
+// be referenced by its request URI in authorization requests.
+type PushedRequest struct {
+	AppID    string `json:"app_id"`
+	ClientID string `json:"client_id"`
+
+	CreatedAt      time.Time `json:"created_at"`
+	ExpireAt       time.Time `json:"expire_at"`
+	RequestURIHash string    `json:"request_uri_hash"`
+
+	Request map[string]string `json:"request"`
+}
+
+type PushedRequestStore interface {
+	GetPushedRequest(requestURIHash string) (*PushedRequest, error)
+	CreatePushedRequest(*PushedRequest) error
+	// ConsumePushedRequest deletes the pushed request, so that its request
+	// URI cannot be used again. ErrGrantNotFound is returned if it is
+	// already consumed.
+	ConsumePushedRequest(requestURIHash string) error
+}
+
+// IsRequestURI checks whether the request URI references a pushed
+// authorization request.
+func IsRequestURI(requestURI string) bool {
+	return strings.HasPrefix(requestURI, RequestURIPrefix)
+}
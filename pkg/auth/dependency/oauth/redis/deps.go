@@ -32,6 +32,11 @@ func ProvideGrantStore(
 var DependencySet = wire.NewSet(
 	ProvideGrantStore,
 	wire.Bind(new(oauth.CodeGrantStore), new(*GrantStore)),
+	wire.Bind(new(oauth.DeviceGrantStore), new(*GrantStore)),
+	wire.Bind(new(oauth.UserCodeAttemptCounter), new(*GrantStore)),
 	wire.Bind(new(oauth.AccessGrantStore), new(*GrantStore)),
 	wire.Bind(new(oauth.OfflineGrantStore), new(*GrantStore)),
+	wire.Bind(new(oauth.AccessTokenDenylist), new(*GrantStore)),
+	wire.Bind(new(oauth.PushedRequestStore), new(*GrantStore)),
+	wire.Bind(new(oauth.ClientAssertionStore), new(*GrantStore)),
 )
@@ -6,10 +6,26 @@ func codeGrantKey(appID string, codeHash string) string {
 	return fmt.Sprintf("%s:code-grant:%s", appID, codeHash)
 }
 
+func deviceGrantKey(appID string, deviceCodeHash string) string {
+	return fmt.Sprintf("%s:device-grant:%s", appID, deviceCodeHash)
+}
+
+func deviceUserCodeKey(appID string, userCode string) string {
+	return fmt.Sprintf("%s:device-user-code:%s", appID, userCode)
+}
+
+func deviceUserCodeAttemptsKey(appID string, key string) string {
+	return fmt.Sprintf("%s:device-user-code-attempts:%s", appID, key)
+}
+
 func accessGrantKey(appID string, tokenHash string) string {
 	return fmt.Sprintf("%s:access-grant:%s", appID, tokenHash)
 }
 
+func accessTokenDenylistKey(appID string, tokenHash string) string {
+	return fmt.Sprintf("%s:access-token-denylist:%s", appID, tokenHash)
+}
+
 func offlineGrantKey(appID string, id string) string {
 	return fmt.Sprintf("%s:offline-grant:%s", appID, id)
 }
@@ -17,3 +33,11 @@ func offlineGrantKey(appID string, id string) string {
 func offlineGrantListKey(appID, userID string) string {
 	return fmt.Sprintf("%s:offline-grant-list:%s", appID, userID)
 }
+
+func pushedRequestKey(appID string, requestURIHash string) string {
+	return fmt.Sprintf("%s:pushed-request:%s", appID, requestURIHash)
+}
+
+func clientAssertionKey(appID string, clientID string, jti string) string {
+	return fmt.Sprintf("%s:client-assertion:%s:%s", appID, clientID, jti)
+}
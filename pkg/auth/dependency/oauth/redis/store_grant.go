@@ -87,6 +87,93 @@ func (s *GrantStore) DeleteCodeGrant(grant *oauth.CodeGrant) error {
 	return s.del(redis.GetConn(s.Context), codeGrantKey(grant.AppID, grant.CodeHash))
 }
 
+func (s *GrantStore) GetDeviceGrant(deviceCodeHash string) (*oauth.DeviceGrant, error) {
+	g := &oauth.DeviceGrant{}
+	err := s.load(redis.GetConn(s.Context), deviceGrantKey(s.AppID, deviceCodeHash), g)
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func (s *GrantStore) GetDeviceGrantByUserCode(userCode string) (*oauth.DeviceGrant, error) {
+	conn := redis.GetConn(s.Context)
+	deviceCodeHash, err := redigo.String(conn.Do("GET", deviceUserCodeKey(s.AppID, userCode)))
+	if errors.Is(err, redigo.ErrNil) {
+		return nil, oauth.ErrGrantNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return s.GetDeviceGrant(deviceCodeHash)
+}
+
+func (s *GrantStore) CreateDeviceGrant(grant *oauth.DeviceGrant) error {
+	conn := redis.GetConn(s.Context)
+	ttl := grant.ExpireAt.Sub(s.Time.NowUTC())
+	_, err := redigo.String(conn.Do("SET", deviceUserCodeKey(grant.AppID, grant.UserCode), grant.DeviceCodeHash, "PX", toMilliseconds(ttl), "NX"))
+	if errors.Is(err, redigo.ErrNil) {
+		return errors.New("user code already exist")
+	} else if err != nil {
+		return err
+	}
+	return s.save(conn, deviceGrantKey(grant.AppID, grant.DeviceCodeHash), grant, grant.ExpireAt, true)
+}
+
+func (s *GrantStore) UpdateDeviceGrant(grant *oauth.DeviceGrant) error {
+	return s.save(redis.GetConn(s.Context), deviceGrantKey(grant.AppID, grant.DeviceCodeHash), grant, grant.ExpireAt, false)
+}
+
+func (s *GrantStore) DeleteDeviceGrant(grant *oauth.DeviceGrant) error {
+	conn := redis.GetConn(s.Context)
+	err := s.del(conn, deviceUserCodeKey(grant.AppID, grant.UserCode))
+	if err != nil {
+		return err
+	}
+	return s.del(conn, deviceGrantKey(grant.AppID, grant.DeviceCodeHash))
+}
+
+func (s *GrantStore) CountUserCodeAttempt(key string, window time.Duration) (int, error) {
+	conn := redis.GetConn(s.Context)
+	redisKey := deviceUserCodeAttemptsKey(s.AppID, key)
+	count, err := redigo.Int(conn.Do("INCR", redisKey))
+	if err != nil {
+		return 0, err
+	}
+	if count == 1 {
+		// The window starts at the first attempt.
+		_, err = conn.Do("PEXPIRE", redisKey, toMilliseconds(window))
+		if err != nil {
+			return 0, err
+		}
+	}
+	return count, nil
+}
+
+func (s *GrantStore) GetPushedRequest(requestURIHash string) (*oauth.PushedRequest, error) {
+	r := &oauth.PushedRequest{}
+	err := s.load(redis.GetConn(s.Context), pushedRequestKey(s.AppID, requestURIHash), r)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (s *GrantStore) CreatePushedRequest(r *oauth.PushedRequest) error {
+	return s.save(redis.GetConn(s.Context), pushedRequestKey(r.AppID, r.RequestURIHash), r, r.ExpireAt, true)
+}
+
+func (s *GrantStore) ConsumePushedRequest(requestURIHash string) error {
+	conn := redis.GetConn(s.Context)
+	n, err := redigo.Int(conn.Do("DEL", pushedRequestKey(s.AppID, requestURIHash)))
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return oauth.ErrGrantNotFound
+	}
+	return nil
+}
+
 func (s *GrantStore) GetAccessGrant(tokenHash string) (*oauth.AccessGrant, error) {
 	g := &oauth.AccessGrant{}
 	err := s.load(redis.GetConn(s.Context), accessGrantKey(s.AppID, tokenHash), g)
@@ -104,6 +191,40 @@ func (s *GrantStore) DeleteAccessGrant(grant *oauth.AccessGrant) error {
 	return s.del(redis.GetConn(s.Context), accessGrantKey(grant.AppID, grant.TokenHash))
 }
 
+func (s *GrantStore) DenyAccessToken(tokenHash string, expireAt time.Time) error {
+	ttl := expireAt.Sub(s.Time.NowUTC())
+	if ttl <= 0 {
+		// The token is already expired.
+		return nil
+	}
+
+	conn := redis.GetConn(s.Context)
+	_, err := conn.Do("SET", accessTokenDenylistKey(s.AppID, tokenHash), "1", "PX", toMilliseconds(ttl))
+	return err
+}
+
+func (s *GrantStore) IsAccessTokenDenied(tokenHash string) (bool, error) {
+	conn := redis.GetConn(s.Context)
+	return redigo.Bool(conn.Do("EXISTS", accessTokenDenylistKey(s.AppID, tokenHash)))
+}
+
+func (s *GrantStore) UseClientAssertion(clientID string, jti string, expireAt time.Time) (bool, error) {
+	ttl := expireAt.Sub(s.Time.NowUTC())
+	if ttl <= 0 {
+		// Expired assertions are rejected anyway.
+		return false, nil
+	}
+
+	conn := redis.GetConn(s.Context)
+	_, err := redigo.String(conn.Do("SET", clientAssertionKey(s.AppID, clientID, jti), "1", "PX", toMilliseconds(ttl), "NX"))
+	if errors.Is(err, redigo.ErrNil) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 func (s *GrantStore) GetOfflineGrant(id string) (*oauth.OfflineGrant, error) {
 	g := &oauth.OfflineGrant{}
 	err := s.load(redis.GetConn(s.Context), offlineGrantKey(s.AppID, id), g)
@@ -155,6 +276,63 @@ func (s *GrantStore) UpdateOfflineGrant(grant *oauth.OfflineGrant) error {
 	return nil
 }
 
+func (s *GrantStore) CompareAndUpdateOfflineGrant(grant *oauth.OfflineGrant, tokenHash string) error {
+	expiry, err := grant.ExpireAt.MarshalText()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(grant)
+	if err != nil {
+		return err
+	}
+
+	conn := redis.GetConn(s.Context)
+	key := offlineGrantKey(grant.AppID, grant.ID)
+
+	// The transaction is aborted if the grant is modified after WATCH.
+	_, err = conn.Do("WATCH", key)
+	if err != nil {
+		return err
+	}
+	current := &oauth.OfflineGrant{}
+	err = s.load(conn, key, current)
+	if err == nil && current.TokenHash != tokenHash {
+		err = oauth.ErrGrantConflict
+	}
+	if err != nil {
+		if _, unwatchErr := conn.Do("UNWATCH"); unwatchErr != nil {
+			s.Logger.WithError(unwatchErr).Error("failed to unwatch offline grant")
+		}
+		return err
+	}
+
+	ttl := grant.ExpireAt.Sub(s.Time.NowUTC())
+	err = conn.Send("MULTI")
+	if err != nil {
+		return err
+	}
+	err = conn.Send("SET", key, data, "PX", toMilliseconds(ttl), "XX")
+	if err != nil {
+		return err
+	}
+	result, err := redigo.Values(conn.Do("EXEC"))
+	if errors.Is(err, redigo.ErrNil) {
+		return oauth.ErrGrantConflict
+	} else if err != nil {
+		return err
+	}
+	if len(result) != 1 || result[0] == nil {
+		return oauth.ErrGrantNotFound
+	}
+
+	_, err = conn.Do("HSET", offlineGrantListKey(grant.AppID, grant.Attrs.UserID), grant.ID, expiry)
+	if err != nil {
+		return fmt.Errorf("failed to update session list: %w", err)
+	}
+
+	return nil
+}
+
 func (s *GrantStore) DeleteOfflineGrant(grant *oauth.OfflineGrant) error {
 	conn := redis.GetConn(s.Context)
 
@@ -16,11 +16,14 @@ type ResolverSessionProvider interface {
 }
 
 type Resolver struct {
-	Authorizations AuthorizationStore
-	AccessGrants   AccessGrantStore
-	OfflineGrants  OfflineGrantStore
-	Sessions       ResolverSessionProvider
-	Time           time.Provider
+	Clients         *ClientResolver
+	Authorizations  AuthorizationStore
+	AccessGrants    AccessGrantStore
+	OfflineGrants   OfflineGrantStore
+	Sessions        ResolverSessionProvider
+	JWTAccessTokens *JWTAccessTokenCodec
+	Denylist        AccessTokenDenylist
+	Time            time.Provider
 }
 
 func (re *Resolver) Resolve(rw http.ResponseWriter, r *http.Request) (auth.AuthSession, error) {
@@ -30,20 +33,75 @@ func (re *Resolver) Resolve(rw http.ResponseWriter, r *http.Request) (auth.AuthS
 		return nil, nil
 	}
 
-	token, err := DecodeAccessToken(token)
-	if err != nil {
-		return nil, auth.ErrInvalidSession
-	}
-
-	tokenHash := HashToken(token)
-	grant, err := re.AccessGrants.GetAccessGrant(tokenHash)
+	grant, err := re.GetAccessGrant(token)
 	if errors.Is(err, ErrGrantNotFound) {
 		return nil, auth.ErrInvalidSession
 	} else if err != nil {
 		return nil, err
 	}
 
-	_, err = re.Authorizations.GetByID(grant.AuthorizationID)
+	authSession, err := re.ResolveAccessGrant(grant)
+	if err != nil {
+		return nil, err
+	}
+
+	event := auth.NewAccessEvent(re.Time.NowUTC(), r)
+
+	switch s := authSession.(type) {
+	case *session.IDPSession:
+		s.AccessInfo.LastAccess = event
+		if err = re.Sessions.Update(s); err != nil {
+			return nil, err
+		}
+	case *OfflineGrant:
+		s.AccessInfo.LastAccess = event
+		if err = re.OfflineGrants.UpdateOfflineGrant(s); err != nil {
+			return nil, err
+		}
+	case *ClientSession:
+		s.AccessInfo.InitialAccess = event
+		s.AccessInfo.LastAccess = event
+	}
+
+	return authSession, nil
+}
+
+// GetAccessGrant gets the access grant of the encoded access token, which
+// may be an opaque token or a JWT access token.
+func (re *Resolver) GetAccessGrant(encodedToken string) (*AccessGrant, error) {
+	if IsJWTAccessToken(encodedToken) {
+		grant, err := re.JWTAccessTokens.Decode(encodedToken)
+		if err != nil {
+			return nil, ErrGrantNotFound
+		}
+
+		denied, err := re.Denylist.IsAccessTokenDenied(grant.TokenHash)
+		if err != nil {
+			return nil, err
+		} else if denied {
+			return nil, ErrGrantNotFound
+		}
+
+		return grant, nil
+	}
+
+	token, err := DecodeAccessToken(encodedToken)
+	if err != nil {
+		return nil, ErrGrantNotFound
+	}
+
+	return re.AccessGrants.GetAccessGrant(HashToken(token))
+}
+
+// ResolveAccessGrant resolves the session of the access grant, without
+// recording the access.
+func (re *Resolver) ResolveAccessGrant(grant *AccessGrant) (auth.AuthSession, error) {
+	// Client grants are not authorized by any user.
+	if grant.SessionKind == GrantSessionKindClient {
+		return re.resolveClientSession(grant)
+	}
+
+	authz, err := re.Authorizations.GetByID(grant.AuthorizationID)
 	if errors.Is(err, ErrAuthorizationNotFound) {
 		// Authorization does not exists (e.g. revoked)
 		return nil, auth.ErrInvalidSession
@@ -51,8 +109,13 @@ func (re *Resolver) Resolve(rw http.ResponseWriter, r *http.Request) (auth.AuthS
 		return nil, err
 	}
 
-	var authSession auth.AuthSession
-	event := auth.NewAccessEvent(re.Time.NowUTC(), r)
+	// The client may be deleted after the grant is issued.
+	client, err := re.Clients.ResolveClient(authz.ClientID)
+	if err != nil {
+		return nil, err
+	} else if client == nil {
+		return nil, auth.ErrInvalidSession
+	}
 
 	switch grant.SessionKind {
 	case GrantSessionKindSession:
@@ -62,12 +125,7 @@ func (re *Resolver) Resolve(rw http.ResponseWriter, r *http.Request) (auth.AuthS
 		} else if err != nil {
 			return nil, err
 		}
-		s.AccessInfo.LastAccess = event
-		if err = re.Sessions.Update(s); err != nil {
-			return nil, err
-		}
-
-		authSession = s
+		return s, nil
 
 	case GrantSessionKindOffline:
 		g, err := re.OfflineGrants.GetOfflineGrant(grant.SessionID)
@@ -76,18 +134,37 @@ func (re *Resolver) Resolve(rw http.ResponseWriter, r *http.Request) (auth.AuthS
 		} else if err != nil {
 			return nil, err
 		}
-		g.AccessInfo.LastAccess = event
-		if err = re.OfflineGrants.UpdateOfflineGrant(g); err != nil {
-			return nil, err
-		}
-
-		authSession = g
+		return g, nil
 
 	default:
 		panic("oauth: resolving unknown grant session kind")
 	}
+}
 
-	return authSession, nil
+func (re *Resolver) resolveClientSession(grant *AccessGrant) (auth.AuthSession, error) {
+	// The client must be still allowed to use client credentials grant.
+	c, err := re.Clients.ResolveClient(grant.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	allowed := false
+	if c != nil && c.IsConfidential() {
+		for _, grantType := range c.GrantTypes() {
+			if grantType == "client_credentials" {
+				allowed = true
+				break
+			}
+		}
+	}
+	if !allowed {
+		return nil, auth.ErrInvalidSession
+	}
+
+	return &ClientSession{
+		ClientID:  grant.SessionID,
+		Scopes:    grant.Scopes,
+		CreatedAt: grant.CreatedAt,
+	}, nil
 }
 
 func parseAuthorizationHeader(r *http.Request) (token string) {
@@ -17,6 +17,8 @@ func SessionScopes(s auth.AuthSession) []string {
 		return []string{FullAccessScope}
 	case *OfflineGrant:
 		return s.Scopes
+	case *ClientSession:
+		return s.Scopes
 	default:
 		panic("oauth: unexpected session type")
 	}
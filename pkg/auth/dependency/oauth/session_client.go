@@ -0,0 +1,46 @@
+package oauth
+
+import (
+	"time"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/model"
+	"github.com/skygeario/skygear-server/pkg/core/authn"
+)
+
+// ClientSession is the session of an access grant issued to a client itself
+// through client credentials grant. It is not associated with any user.
+type ClientSession struct {
+	ClientID   string
+	Scopes     []string
+	CreatedAt  time.Time
+	AccessInfo auth.AccessInfo
+}
+
+var _ auth.AuthSession = &ClientSession{}
+
+func (s *ClientSession) SessionID() string              { return s.ClientID }
+func (s *ClientSession) SessionType() authn.SessionType { return auth.SessionTypeClient }
+
+func (s *ClientSession) AuthnAttrs() *authn.Attrs {
+	return &authn.Attrs{}
+}
+
+func (s *ClientSession) GetCreatedAt() time.Time         { return s.CreatedAt }
+func (s *ClientSession) GetClientID() string             { return s.ClientID }
+func (s *ClientSession) GetAccessInfo() *auth.AccessInfo { return &s.AccessInfo }
+
+func (s *ClientSession) ToAPIModel() *model.Session {
+	ua := model.ParseUserAgent(s.AccessInfo.LastAccess.UserAgent)
+	return &model.Session{
+		ID:       s.ClientID,
+		Type:     auth.SessionTypeClient,
+		ClientID: s.ClientID,
+
+		CreatedAt:        s.CreatedAt,
+		LastAccessedAt:   s.AccessInfo.LastAccess.Timestamp,
+		CreatedByIP:      s.AccessInfo.InitialAccess.Remote.IP(),
+		LastAccessedByIP: s.AccessInfo.LastAccess.Remote.IP(),
+		UserAgent:        ua,
+	}
+}
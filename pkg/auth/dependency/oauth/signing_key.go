@@ -0,0 +1,79 @@
+package oauth
+
+import (
+	"errors"
+
+	"github.com/dgrijalva/jwt-go"
+
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	corejwt "github.com/skygeario/skygear-server/pkg/core/jwt"
+)
+
+var ErrNoActiveSigningKey = errors.New("no active OIDC signing key")
+var ErrSigningKeyNotFound = errors.New("OIDC signing key not found")
+
+// SignToken signs the token with the active signing key. The signing method
+// of the token is replaced by the one of the key.
+func SignToken(c *config.OIDCConfiguration, token *jwt.Token) (string, error) {
+	key := c.ActiveKey()
+	if key == nil {
+		return "", ErrNoActiveSigningKey
+	}
+
+	privKey, err := corejwt.ParsePrivateKeyFromPEM([]byte(key.PrivateKey))
+	if err != nil {
+		return "", err
+	}
+	method, err := corejwt.SigningMethodOf(privKey)
+	if err != nil {
+		return "", err
+	}
+
+	token.Method = method
+	token.Header["alg"] = method.Alg()
+	token.Header["kid"] = key.KID
+	return token.SignedString(privKey)
+}
+
+// VerificationKey returns the public key of the published signing key
+// identified by the kid of the token, which can be used with jwt.Parser.
+func VerificationKey(c *config.OIDCConfiguration, token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	for _, key := range c.PublishedKeys() {
+		if key.KID != kid {
+			continue
+		}
+
+		pubKey, err := corejwt.ParsePublicKeyFromPEM([]byte(key.PublicKey))
+		if err != nil {
+			return nil, err
+		}
+		// Reject tokens claiming an algorithm other than the one of the key.
+		if alg, err := corejwt.SigningAlgorithmOf(pubKey); err != nil || alg != token.Method.Alg() {
+			return nil, ErrSigningKeyNotFound
+		}
+		return pubKey, nil
+	}
+	return nil, ErrSigningKeyNotFound
+}
+
+// SigningAlgorithms returns the algorithms of the published signing keys.
+func SigningAlgorithms(c *config.OIDCConfiguration) []string {
+	seen := map[string]struct{}{}
+	var algs []string
+	for _, key := range c.PublishedKeys() {
+		pubKey, err := corejwt.ParsePublicKeyFromPEM([]byte(key.PublicKey))
+		if err != nil {
+			continue
+		}
+		alg, err := corejwt.SigningAlgorithmOf(pubKey)
+		if err != nil {
+			continue
+		}
+		if _, ok := seen[alg]; !ok {
+			seen[alg] = struct{}{}
+			algs = append(algs, alg)
+		}
+	}
+	return algs
+}
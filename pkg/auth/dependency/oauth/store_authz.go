@@ -3,7 +3,10 @@ package oauth
 type AuthorizationStore interface {
 	Get(userID, clientID string) (*Authorization, error)
 	GetByID(id string) (*Authorization, error)
+	ListByUser(userID string) ([]*Authorization, error)
 	Create(*Authorization) error
 	Delete(*Authorization) error
 	UpdateScopes(*Authorization) error
+	DeleteByUser(userID string) error
+	DeleteByClient(clientID string) error
 }
@@ -0,0 +1,8 @@
+package oauth
+
+type ClientStore interface {
+	Get(clientID string) (*Client, error)
+	Create(*Client) error
+	Update(*Client) error
+	Delete(*Client) error
+}
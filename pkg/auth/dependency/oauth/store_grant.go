@@ -1,15 +1,36 @@
 package oauth
 
+import "time"
+
 type CodeGrantStore interface {
 	GetCodeGrant(codeHash string) (*CodeGrant, error)
 	CreateCodeGrant(*CodeGrant) error
 	DeleteCodeGrant(*CodeGrant) error
 }
 
+type DeviceGrantStore interface {
+	GetDeviceGrant(deviceCodeHash string) (*DeviceGrant, error)
+	GetDeviceGrantByUserCode(userCode string) (*DeviceGrant, error)
+	CreateDeviceGrant(*DeviceGrant) error
+	UpdateDeviceGrant(*DeviceGrant) error
+	DeleteDeviceGrant(*DeviceGrant) error
+}
+
+// UserCodeAttemptCounter counts attempts of entering user codes, so that
+// guessing of user codes can be limited.
+type UserCodeAttemptCounter interface {
+	// CountUserCodeAttempt records an attempt identified by key, and
+	// returns the number of attempts within the window.
+	CountUserCodeAttempt(key string, window time.Duration) (int, error)
+}
+
 type OfflineGrantStore interface {
 	GetOfflineGrant(id string) (*OfflineGrant, error)
 	CreateOfflineGrant(*OfflineGrant) error
 	UpdateOfflineGrant(*OfflineGrant) error
+	// CompareAndUpdateOfflineGrant updates the grant only if the stored
+	// grant has the token hash; otherwise ErrGrantConflict is returned.
+	CompareAndUpdateOfflineGrant(grant *OfflineGrant, tokenHash string) error
 	DeleteOfflineGrant(*OfflineGrant) error
 
 	ListOfflineGrants(userID string) ([]*OfflineGrant, error)
@@ -20,3 +41,17 @@ type AccessGrantStore interface {
 	CreateAccessGrant(*AccessGrant) error
 	DeleteAccessGrant(*AccessGrant) error
 }
+
+// AccessTokenDenylist records revoked JWT access tokens until they expire.
+type AccessTokenDenylist interface {
+	DenyAccessToken(tokenHash string, expireAt time.Time) error
+	IsAccessTokenDenied(tokenHash string) (bool, error)
+}
+
+// ClientAssertionStore records IDs of client assertions until they expire,
+// so that an assertion cannot be used more than once.
+type ClientAssertionStore interface {
+	// UseClientAssertion records the assertion ID, and returns false if it
+	// has already been used.
+	UseClientAssertion(clientID string, jti string, expireAt time.Time) (bool, error)
+}
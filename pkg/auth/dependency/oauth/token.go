@@ -1,12 +1,17 @@
 package oauth
 
 import (
+	"strings"
+
 	"github.com/skygeario/skygear-server/pkg/core/crypto"
 	"github.com/skygeario/skygear-server/pkg/core/rand"
 )
 
 const (
 	tokenAlphabet string = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	// userCodeAlphabet excludes vowels and ambiguous characters, so user codes
+	// are easy to type and do not form words.
+	userCodeAlphabet string = "BCDFGHJKLMNPQRSTVWXZ"
 )
 
 func GenerateToken() string {
@@ -17,3 +22,25 @@ func GenerateToken() string {
 func HashToken(token string) string {
 	return crypto.SHA256String(token)
 }
+
+// GenerateUserCode generates user code of device authorization grant,
+// in form of XXXX-XXXX.
+func GenerateUserCode() string {
+	code := rand.StringWithAlphabet(8, userCodeAlphabet, rand.SecureRand)
+	return code[:4] + "-" + code[4:]
+}
+
+// NormalizeUserCode normalizes user code entered by user.
+func NormalizeUserCode(code string) string {
+	code = strings.ToUpper(code)
+	code = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(userCodeAlphabet, r) {
+			return r
+		}
+		return -1
+	}, code)
+	if len(code) != 8 {
+		return code
+	}
+	return code[:4] + "-" + code[4:]
+}
@@ -0,0 +1,112 @@
+package oauth
+
+import (
+	"errors"
+	"strings"
+	gotime "time"
+
+	"github.com/dgrijalva/jwt-go"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/urlprefix"
+	"github.com/skygeario/skygear-server/pkg/core/authn"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+// jwtAccessTokenType is the type of JWT access tokens.
+// https://tools.ietf.org/html/draft-ietf-oauth-access-token-jwt
+const jwtAccessTokenType = "at+jwt"
+
+var ErrInvalidJWTAccessToken = errors.New("invalid JWT access token")
+
+type AccessTokenClaims struct {
+	jwt.StandardClaims
+	ClientID string   `json:"client_id"`
+	Scope    string   `json:"scope,omitempty"`
+	ACR      string   `json:"acr,omitempty"`
+	AMR      []string `json:"amr,omitempty"`
+
+	SessionID       string           `json:"skygear_session_id"`
+	SessionKind     GrantSessionKind `json:"skygear_session_kind"`
+	AuthorizationID string           `json:"skygear_authz_id,omitempty"`
+}
+
+// IsJWTAccessToken checks whether the encoded access token is a JWT,
+// instead of an opaque token.
+func IsJWTAccessToken(encodedToken string) bool {
+	return strings.Count(encodedToken, ".") == 2
+}
+
+type JWTAccessTokenCodec struct {
+	OIDCConfig config.OIDCConfiguration
+	URLPrefix  urlprefix.Provider
+	Time       time.Provider
+}
+
+// Encode encodes the access grant as a JWT access token, identified by the
+// random token. The token hash of the grant is derived from the random token.
+func (c *JWTAccessTokenCodec) Encode(grant *AccessGrant, clientID string, token string, attrs *authn.Attrs) (string, error) {
+	issuer := c.URLPrefix.Value().String()
+	subject := attrs.UserID
+	if grant.SessionKind == GrantSessionKindClient {
+		subject = clientID
+	}
+
+	claims := &AccessTokenClaims{
+		StandardClaims: jwt.StandardClaims{
+			Id:        token,
+			Issuer:    issuer,
+			Audience:  issuer,
+			Subject:   subject,
+			IssuedAt:  grant.CreatedAt.Unix(),
+			ExpiresAt: grant.ExpireAt.Unix(),
+		},
+		ClientID:        clientID,
+		Scope:           strings.Join(grant.Scopes, " "),
+		ACR:             attrs.ACR,
+		AMR:             attrs.AMR,
+		SessionID:       grant.SessionID,
+		SessionKind:     grant.SessionKind,
+		AuthorizationID: grant.AuthorizationID,
+	}
+
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	jwtToken.Header["typ"] = jwtAccessTokenType
+	return SignToken(&c.OIDCConfig, jwtToken)
+}
+
+// Decode verifies the JWT access token, and reconstructs the access grant
+// from its claims.
+func (c *JWTAccessTokenCodec) Decode(encodedToken string) (*AccessGrant, error) {
+	keyFunc := func(token *jwt.Token) (interface{}, error) {
+		if typ, _ := token.Header["typ"].(string); typ != jwtAccessTokenType {
+			return nil, ErrInvalidJWTAccessToken
+		}
+		return VerificationKey(&c.OIDCConfig, token)
+	}
+
+	claims := &AccessTokenClaims{}
+	parser := &jwt.Parser{SkipClaimsValidation: true}
+	if _, err := parser.ParseWithClaims(encodedToken, claims, keyFunc); err != nil {
+		return nil, ErrInvalidJWTAccessToken
+	}
+
+	issuer := c.URLPrefix.Value().String()
+	now := c.Time.NowUTC().Unix()
+	if claims.Issuer != issuer || claims.Audience != issuer || claims.Id == "" {
+		return nil, ErrInvalidJWTAccessToken
+	}
+	if !claims.VerifyExpiresAt(now, true) {
+		return nil, ErrInvalidJWTAccessToken
+	}
+
+	return &AccessGrant{
+		AuthorizationID: claims.AuthorizationID,
+		SessionID:       claims.SessionID,
+		SessionKind:     claims.SessionKind,
+		CreatedAt:       gotime.Unix(claims.IssuedAt, 0).UTC(),
+		ExpireAt:        gotime.Unix(claims.ExpiresAt, 0).UTC(),
+		Scopes:          strings.Fields(claims.Scope),
+		TokenHash:       HashToken(claims.Id),
+	}, nil
+}
@@ -0,0 +1,127 @@
+package oauth_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/url"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/urlprefix"
+	"github.com/skygeario/skygear-server/pkg/core/authn"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	corejwt "github.com/skygeario/skygear-server/pkg/core/jwt"
+	coretime "github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+func TestJWTAccessTokenCodec(t *testing.T) {
+	Convey("JWTAccessTokenCodec", t, func() {
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		So(err, ShouldBeNil)
+		publicKeyDER, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+		So(err, ShouldBeNil)
+
+		mockTime := &coretime.MockProvider{}
+		mockTime.TimeNowUTC = time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+
+		codec := &oauth.JWTAccessTokenCodec{
+			OIDCConfig: config.OIDCConfiguration{
+				Keys: []config.OIDCSigningKeyConfiguration{{
+					KID:   "key-id",
+					State: config.OIDCSigningKeyStateActive,
+					PrivateKey: string(pem.EncodeToMemory(&pem.Block{
+						Type:  "RSA PRIVATE KEY",
+						Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+					})),
+					PublicKey: string(pem.EncodeToMemory(&pem.Block{
+						Type:  "PUBLIC KEY",
+						Bytes: publicKeyDER,
+					})),
+				}},
+			},
+			URLPrefix: urlprefix.Provider{Prefix: url.URL{Scheme: "https", Host: "auth"}},
+			Time:      mockTime,
+		}
+
+		grant := &oauth.AccessGrant{
+			AuthorizationID: "authz-id",
+			SessionID:       "grant-id",
+			SessionKind:     oauth.GrantSessionKindOffline,
+			CreatedAt:       mockTime.TimeNowUTC,
+			ExpireAt:        mockTime.TimeNowUTC.Add(time.Hour),
+			Scopes:          []string{"openid", "offline_access"},
+			TokenHash:       oauth.HashToken("token"),
+		}
+		attrs := &authn.Attrs{UserID: "user-id", AMR: []string{"pwd"}}
+
+		Convey("should encode and decode access grant", func() {
+			token, err := codec.Encode(grant, "client-id", "token", attrs)
+			So(err, ShouldBeNil)
+			So(oauth.IsJWTAccessToken(token), ShouldBeTrue)
+
+			decoded, err := codec.Decode(token)
+			So(err, ShouldBeNil)
+			So(decoded, ShouldResemble, grant)
+		})
+
+		Convey("should reject expired token", func() {
+			token, err := codec.Encode(grant, "client-id", "token", attrs)
+			So(err, ShouldBeNil)
+
+			mockTime.TimeNowUTC = mockTime.TimeNowUTC.Add(2 * time.Hour)
+			_, err = codec.Decode(token)
+			So(err, ShouldBeError, oauth.ErrInvalidJWTAccessToken)
+		})
+
+		Convey("should reject token of other issuer", func() {
+			token, err := codec.Encode(grant, "client-id", "token", attrs)
+			So(err, ShouldBeNil)
+
+			codec.URLPrefix = urlprefix.Provider{Prefix: url.URL{Scheme: "https", Host: "other"}}
+			_, err = codec.Decode(token)
+			So(err, ShouldBeError, oauth.ErrInvalidJWTAccessToken)
+		})
+
+		Convey("should verify with published keys after rotation", func() {
+			token, err := codec.Encode(grant, "client-id", "token", attrs)
+			So(err, ShouldBeNil)
+
+			for _, alg := range []string{corejwt.AlgorithmES256, corejwt.AlgorithmEdDSA} {
+				privKey, pubKey, err := corejwt.GenerateKeyPair(alg)
+				So(err, ShouldBeNil)
+				codec.OIDCConfig.Keys[0].State = config.OIDCSigningKeyStatePublished
+				codec.OIDCConfig.Keys = append(codec.OIDCConfig.Keys, config.OIDCSigningKeyConfiguration{
+					KID:        alg,
+					State:      config.OIDCSigningKeyStateActive,
+					PrivateKey: privKey,
+					PublicKey:  pubKey,
+				})
+
+				newToken, err := codec.Encode(grant, "client-id", "token", attrs)
+				So(err, ShouldBeNil)
+				decoded, err := codec.Decode(newToken)
+				So(err, ShouldBeNil)
+				So(decoded, ShouldResemble, grant)
+
+				codec.OIDCConfig.Keys = codec.OIDCConfig.Keys[:1]
+			}
+
+			decoded, err := codec.Decode(token)
+			So(err, ShouldBeNil)
+			So(decoded, ShouldResemble, grant)
+
+			codec.OIDCConfig.Keys[0].State = config.OIDCSigningKeyStateRetired
+			_, err = codec.Decode(token)
+			So(err, ShouldBeError, oauth.ErrInvalidJWTAccessToken)
+		})
+
+		Convey("should not treat opaque token as JWT", func() {
+			So(oauth.IsJWTAccessToken(oauth.GenerateToken()), ShouldBeFalse)
+		})
+	})
+}
@@ -0,0 +1,102 @@
+package oidc
+
+import (
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity"
+	"github.com/skygeario/skygear-server/pkg/auth/model"
+	"github.com/skygeario/skygear-server/pkg/core/auth/metadata"
+	"github.com/skygeario/skygear-server/pkg/core/authn"
+)
+
+const (
+	ScopeProfile = "profile"
+	ScopeEmail   = "email"
+	ScopePhone   = "phone"
+)
+
+// StandardClaimsScopes are scopes granting standard OIDC claims.
+var StandardClaimsScopes = []string{
+	ScopeProfile,
+	ScopeEmail,
+	ScopePhone,
+}
+
+// StandardClaims are the standard OIDC claims supported.
+var StandardClaims = []string{
+	"name",
+	"preferred_username",
+	"updated_at",
+	"email",
+	"email_verified",
+	"phone_number",
+	"phone_number_verified",
+}
+
+// MetadataKeyName is the key of user metadata used as name claim.
+const MetadataKeyName = "name"
+
+func isStandardClaimsScope(scope string) bool {
+	for _, s := range StandardClaimsScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func populateStandardClaims(
+	claims *UserClaims,
+	scopes map[string]bool,
+	user *model.User,
+	identities []*identity.Info,
+) {
+	if scopes[ScopeProfile] {
+		if name, ok := user.Metadata[MetadataKeyName].(string); ok {
+			claims.Name = name
+		}
+		if username, _ := findLoginID(user, identities, metadata.Username); username != "" {
+			claims.PreferredUsername = username
+		}
+		if !user.UpdatedAt.IsZero() {
+			claims.UpdatedAt = user.UpdatedAt.Unix()
+		}
+	}
+
+	if scopes[ScopeEmail] {
+		if email, verified := findLoginID(user, identities, metadata.Email); email != "" {
+			claims.Email = email
+			claims.EmailVerified = &verified
+		}
+	}
+
+	if scopes[ScopePhone] {
+		if phone, verified := findLoginID(user, identities, metadata.Phone); phone != "" {
+			claims.PhoneNumber = phone
+			claims.PhoneNumberVerified = &verified
+		}
+	}
+}
+
+// findLoginID finds the login ID of the standard key, preferring verified one.
+func findLoginID(
+	user *model.User,
+	identities []*identity.Info,
+	key metadata.StandardKey,
+) (value string, verified bool) {
+	for _, i := range identities {
+		if i.Type != authn.IdentityTypeLoginID {
+			continue
+		}
+		v, ok := i.Claims[string(key)].(string)
+		if !ok {
+			continue
+		}
+		loginID, _ := i.Claims[identity.IdentityClaimLoginIDValue].(string)
+		if user.VerifyInfo[loginID] {
+			return v, true
+		}
+		if value == "" {
+			value = v
+		}
+	}
+	return value, false
+}
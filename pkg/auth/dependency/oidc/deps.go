@@ -2,8 +2,10 @@ package oidc
 
 import (
 	"github.com/google/wire"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/handler"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/urlprefix"
+	"github.com/skygeario/skygear-server/pkg/core/async"
 	"github.com/skygeario/skygear-server/pkg/core/config"
 	"github.com/skygeario/skygear-server/pkg/core/time"
 )
@@ -12,19 +14,63 @@ func ProvideIDTokenIssuer(
 	cfg *config.TenantConfiguration,
 	up urlprefix.Provider,
 	u UserProvider,
+	i IdentityProvider,
 	t time.Provider,
 ) *IDTokenIssuer {
 	return &IDTokenIssuer{
 		OIDCConfig: *cfg.AppConfig.OIDC,
 		URLPrefix:  up,
 		Users:      u,
+		Identities: i,
 		Time:       t,
 	}
 }
 
+func ProvideScopesValidator(cfg *config.TenantConfiguration) handler.ScopesValidator {
+	customScopes := cfg.AppConfig.OIDC.Scopes
+	return func(client config.OAuthClientConfiguration, scopes []string) error {
+		return ValidateScopes(customScopes, client, scopes)
+	}
+}
+
+func ProvideMetadataProvider(
+	cfg *config.TenantConfiguration,
+	up urlprefix.Provider,
+	jwks JWKSEndpointProvider,
+	ui UserInfoEndpointProvider,
+	es EndSessionEndpointProvider,
+) *MetadataProvider {
+	return &MetadataProvider{
+		URLPrefix:          up,
+		JWKSEndpoint:       jwks,
+		UserInfoEndpoint:   ui,
+		EndSessionEndpoint: es,
+		CustomScopes:       cfg.AppConfig.OIDC.Scopes,
+		SigningAlgorithms:  oauth.SigningAlgorithms(cfg.AppConfig.OIDC),
+	}
+}
+
+func ProvideLogoutNotifier(
+	cfg *config.TenantConfiguration,
+	up urlprefix.Provider,
+	cr ClientResolver,
+	tq async.Queue,
+	t time.Provider,
+) *LogoutNotifier {
+	return &LogoutNotifier{
+		OIDCConfig:        *cfg.AppConfig.OIDC,
+		URLPrefix:         up,
+		Clients:           cr,
+		ConfiguredClients: cfg.AppConfig.Clients,
+		TaskQueue:         tq,
+		Time:              t,
+	}
+}
+
 var DependencySet = wire.NewSet(
-	wire.Value(handler.ScopesValidator(ValidateScopes)),
-	wire.Struct(new(MetadataProvider), "*"),
+	ProvideScopesValidator,
+	ProvideMetadataProvider,
 	ProvideIDTokenIssuer,
+	ProvideLogoutNotifier,
 	wire.Bind(new(handler.IDTokenIssuer), new(*IDTokenIssuer)),
 )
@@ -8,12 +8,14 @@ import (
 
 func ProvideEndSessionHandler(
 	cfg *config.TenantConfiguration,
+	cr oidc.ClientResolver,
 	endSession oidc.EndSessionEndpointProvider,
 	logout LogoutURLProvider,
 	settings SettingsURLProvider,
 ) *EndSessionHandler {
 	return &EndSessionHandler{
 		Clients:            cfg.AppConfig.Clients,
+		ClientResolver:     cr,
 		EndSessionEndpoint: endSession,
 		LogoutURL:          logout,
 		SettingsURL:        settings,
@@ -4,6 +4,8 @@ import (
 	"net/http"
 	"net/url"
 
+	"github.com/dgrijalva/jwt-go"
+
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/oidc"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/oidc/protocol"
@@ -23,6 +25,7 @@ type SettingsURLProvider interface {
 
 type EndSessionHandler struct {
 	Clients            []config.OAuthClientConfiguration
+	ClientResolver     oidc.ClientResolver
 	EndSessionEndpoint oidc.EndSessionEndpointProvider
 	LogoutURL          LogoutURLProvider
 	SettingsURL        SettingsURLProvider
@@ -44,7 +47,10 @@ func (h *EndSessionHandler) Handle(s auth.AuthSession, req protocol.EndSessionRe
 	}
 
 	redirectURI := req.PostLogoutRedirectURI()
-	valid, client := h.validateRedirectURI(redirectURI)
+	valid, client, err := h.validateRedirectURI(req)
+	if err != nil {
+		return err
+	}
 	if !valid {
 		// Invalid/empty redirect URI, redirect to home page/settings
 		if client != nil && client.ClientURI() != "" {
@@ -68,13 +74,52 @@ func (h *EndSessionHandler) Handle(s auth.AuthSession, req protocol.EndSessionRe
 	return nil
 }
 
-func (h *EndSessionHandler) validateRedirectURI(redirectURI string) (valid bool, client config.OAuthClientConfiguration) {
+// validateRedirectURI validates the post logout redirect URI with the
+// client identified by client_id or ID token hint, since clients registered
+// dynamically cannot be enumerated. Otherwise, the URI is validated with
+// clients in tenant config.
+func (h *EndSessionHandler) validateRedirectURI(req protocol.EndSessionRequest) (valid bool, client config.OAuthClientConfiguration, err error) {
+	redirectURI := req.PostLogoutRedirectURI()
+
+	clientID := req.ClientID()
+	if clientID == "" {
+		clientID = peekIDTokenAudience(req.IDTokenHint())
+	}
+	if clientID != "" {
+		client, err := h.ClientResolver.ResolveClient(clientID)
+		if err != nil {
+			return false, nil, err
+		}
+		if client != nil {
+			for _, uri := range client.PostLogoutRedirectURIs() {
+				if uri == redirectURI {
+					return true, client, nil
+				}
+			}
+			return false, client, nil
+		}
+	}
+
 	for _, client := range h.Clients {
 		for _, uri := range client.PostLogoutRedirectURIs() {
 			if uri == redirectURI {
-				return true, client
+				return true, client, nil
 			}
 		}
 	}
-	return false, nil
+	return false, nil, nil
+}
+
+// peekIDTokenAudience returns the audience of ID token without verifying it.
+// The audience only selects the client to validate with, so it needs not be
+// trusted.
+func peekIDTokenAudience(idToken string) string {
+	if idToken == "" {
+		return ""
+	}
+	claims := jwt.StandardClaims{}
+	if _, _, err := new(jwt.Parser).ParseUnverified(idToken, &claims); err != nil {
+		return ""
+	}
+	return claims.Audience
 }
@@ -1,10 +1,12 @@
 package oidc
 
 import (
+	"encoding/json"
 	gotime "time"
 
 	"github.com/dgrijalva/jwt-go"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/urlprefix"
 	"github.com/skygeario/skygear-server/pkg/auth/model"
@@ -16,21 +18,83 @@ type UserProvider interface {
 	Get(id string) (*model.User, error)
 }
 
+type IdentityProvider interface {
+	ListByUser(userID string) ([]*identity.Info, error)
+}
+
 type UserClaims struct {
 	jwt.StandardClaims
 	User      *model.User `json:"skygear_user,omitempty"`
 	SessionID string      `json:"skygear_session_id,omitempty"`
+
+	// Standard claims of profile, email and phone scopes.
+	Name                string `json:"name,omitempty"`
+	PreferredUsername   string `json:"preferred_username,omitempty"`
+	UpdatedAt           int64  `json:"updated_at,omitempty"`
+	Email               string `json:"email,omitempty"`
+	EmailVerified       *bool  `json:"email_verified,omitempty"`
+	PhoneNumber         string `json:"phone_number,omitempty"`
+	PhoneNumberVerified *bool  `json:"phone_number_verified,omitempty"`
+
+	// CustomClaims are claims of granted custom scopes.
+	CustomClaims map[string]interface{} `json:"-"`
+}
+
+func (c UserClaims) MarshalJSON() ([]byte, error) {
+	claims, err := c.toMap()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(claims)
+}
+
+func (c UserClaims) toMap() (map[string]interface{}, error) {
+	// userClaims has no MarshalJSON method, to avoid infinite recursion.
+	type userClaims UserClaims
+	b, err := json.Marshal(userClaims(c))
+	if err != nil {
+		return nil, err
+	}
+	claims := map[string]interface{}{}
+	if err = json.Unmarshal(b, &claims); err != nil {
+		return nil, err
+	}
+
+	// Custom claims cannot override standard claims.
+	for k, v := range c.CustomClaims {
+		if _, ok := claims[k]; !ok {
+			claims[k] = v
+		}
+	}
+	return claims, nil
 }
 
 type IDTokenClaims struct {
 	UserClaims
 	Nonce string `json:"nonce,omitempty"`
+	// SID is the ID of the IDP session, used in logout notifications.
+	SID string `json:"sid,omitempty"`
+}
+
+func (c IDTokenClaims) MarshalJSON() ([]byte, error) {
+	claims, err := c.UserClaims.toMap()
+	if err != nil {
+		return nil, err
+	}
+	if c.Nonce != "" {
+		claims["nonce"] = c.Nonce
+	}
+	if c.SID != "" {
+		claims["sid"] = c.SID
+	}
+	return json.Marshal(claims)
 }
 
 type IDTokenIssuer struct {
 	OIDCConfig config.OIDCConfiguration
 	URLPrefix  urlprefix.Provider
 	Users      UserProvider
+	Identities IdentityProvider
 	Time       time.Provider
 }
 
@@ -52,24 +116,30 @@ func (ti *IDTokenIssuer) IssueIDToken(client config.OAuthClientConfiguration, se
 	claims := &IDTokenClaims{
 		UserClaims: *userClaims,
 		Nonce:      nonce,
-	}
-
-	key := ti.OIDCConfig.Keys[0]
-	privKey, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(key.PrivateKey))
-	if err != nil {
-		return "", err
+		SID:        oauth.IDPSessionID(session),
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
-	token.Header["kid"] = key.KID
-	return token.SignedString(privKey)
+	return oauth.SignToken(&ti.OIDCConfig, token)
 }
 
 func (ti *IDTokenIssuer) LoadUserClaims(session auth.AuthSession) (*UserClaims, error) {
 	allowProfile := false
+	standardScopes := map[string]bool{}
+	var customScopes []*config.OIDCScopeConfiguration
 	for _, scope := range oauth.SessionScopes(session) {
 		if scope == oauth.FullAccessScope {
 			allowProfile = true
+		} else if isStandardClaimsScope(scope) {
+			standardScopes[scope] = true
+		} else if s := FindCustomScope(ti.OIDCConfig.Scopes, scope); s != nil && len(s.Claims) > 0 {
+			customScopes = append(customScopes, s)
+		}
+	}
+	if allowProfile {
+		// Full access implies all standard claims.
+		for _, scope := range StandardClaimsScopes {
+			standardScopes[scope] = true
 		}
 	}
 
@@ -80,7 +150,7 @@ func (ti *IDTokenIssuer) LoadUserClaims(session auth.AuthSession) (*UserClaims,
 		},
 	}
 
-	if !allowProfile {
+	if !allowProfile && len(standardScopes) == 0 && len(customScopes) == 0 {
 		return claims, nil
 	}
 
@@ -89,8 +159,31 @@ func (ti *IDTokenIssuer) LoadUserClaims(session auth.AuthSession) (*UserClaims,
 		return nil, err
 	}
 
-	claims.User = user
-	claims.SessionID = session.SessionID()
+	if allowProfile {
+		claims.User = user
+		claims.SessionID = session.SessionID()
+	}
+
+	if len(standardScopes) > 0 {
+		identities, err := ti.Identities.ListByUser(user.ID)
+		if err != nil {
+			return nil, err
+		}
+		populateStandardClaims(claims, standardScopes, user, identities)
+	}
+
+	for _, scope := range customScopes {
+		for claim, metadataKey := range scope.Claims {
+			value, ok := user.Metadata[metadataKey]
+			if !ok {
+				continue
+			}
+			if claims.CustomClaims == nil {
+				claims.CustomClaims = map[string]interface{}{}
+			}
+			claims.CustomClaims[claim] = value
+		}
+	}
 
 	return claims, nil
 }
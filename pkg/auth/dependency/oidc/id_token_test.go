@@ -0,0 +1,161 @@
+package oidc
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/urlprefix"
+	"github.com/skygeario/skygear-server/pkg/auth/model"
+	"github.com/skygeario/skygear-server/pkg/core/authn"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+)
+
+type mockUserProvider struct {
+	user *model.User
+}
+
+func (p *mockUserProvider) Get(id string) (*model.User, error) {
+	return p.user, nil
+}
+
+type mockIdentityProvider struct {
+	identities []*identity.Info
+}
+
+func (p *mockIdentityProvider) ListByUser(userID string) ([]*identity.Info, error) {
+	return p.identities, nil
+}
+
+func loginIDIdentity(key string, value string) *identity.Info {
+	return &identity.Info{
+		Type: authn.IdentityTypeLoginID,
+		Claims: map[string]interface{}{
+			identity.IdentityClaimLoginIDKey:   key,
+			identity.IdentityClaimLoginIDValue: value,
+			key:                                value,
+		},
+	}
+}
+
+func TestStandardClaims(t *testing.T) {
+	Convey("LoadUserClaims with standard scopes", t, func() {
+		users := &mockUserProvider{user: &model.User{
+			ID:         "user-id",
+			UpdatedAt:  time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC),
+			VerifyInfo: map[string]bool{"user@example.com": true},
+			Metadata:   map[string]interface{}{"name": "John Doe"},
+		}}
+		identities := &mockIdentityProvider{identities: []*identity.Info{
+			loginIDIdentity("username", "johndoe"),
+			loginIDIdentity("email", "john@example.com"),
+			loginIDIdentity("email", "user@example.com"),
+			loginIDIdentity("phone", "+85299999999"),
+		}}
+		issuer := &IDTokenIssuer{
+			URLPrefix:  urlprefix.Provider{Prefix: url.URL{Scheme: "https", Host: "auth.example.com"}},
+			Users:      users,
+			Identities: identities,
+		}
+		load := func(scopes ...string) *UserClaims {
+			claims, err := issuer.LoadUserClaims(&oauth.OfflineGrant{
+				ID:     "grant-id",
+				Scopes: scopes,
+				Attrs:  authn.Attrs{UserID: "user-id"},
+			})
+			So(err, ShouldBeNil)
+			return claims
+		}
+
+		Convey("should include claims of granted scopes only", func() {
+			claims := load("openid", "profile")
+			So(claims.Name, ShouldEqual, "John Doe")
+			So(claims.PreferredUsername, ShouldEqual, "johndoe")
+			So(claims.UpdatedAt, ShouldEqual, 1580515200)
+			So(claims.Email, ShouldBeEmpty)
+			So(claims.PhoneNumber, ShouldBeEmpty)
+			So(claims.User, ShouldBeNil)
+		})
+
+		Convey("should prefer verified login ID", func() {
+			claims := load("openid", "email", "phone")
+			So(claims.Email, ShouldEqual, "user@example.com")
+			So(*claims.EmailVerified, ShouldBeTrue)
+			So(claims.PhoneNumber, ShouldEqual, "+85299999999")
+			So(*claims.PhoneNumberVerified, ShouldBeFalse)
+			So(claims.Name, ShouldBeEmpty)
+		})
+
+		Convey("should not load user without scopes with claims", func() {
+			users.user = nil
+			claims := load("openid")
+			So(claims.Subject, ShouldEqual, "user-id")
+		})
+	})
+}
+
+func TestCustomScopes(t *testing.T) {
+	customScopes := []config.OIDCScopeConfiguration{
+		{
+			Name:        "profile:read",
+			Description: "Read your profile",
+			Claims:      map[string]string{"nickname": "nickname"},
+		},
+		{Name: "orders:read"},
+	}
+
+	Convey("ValidateScopes", t, func() {
+		client := config.OAuthClientConfiguration{
+			"client_id":      "client-id",
+			"allowed_scopes": []interface{}{"profile:read"},
+		}
+
+		So(ValidateScopes(customScopes, client, []string{"openid", "profile:read"}), ShouldBeNil)
+		So(ValidateScopes(customScopes, client, []string{"openid", "orders:read"}), ShouldBeError, "specified scope is not allowed")
+		So(ValidateScopes(customScopes, client, []string{"openid", "unknown"}), ShouldBeError, "specified scope is not allowed")
+		So(ValidateScopes(nil, client, []string{"openid", "profile:read"}), ShouldBeError, "specified scope is not allowed")
+	})
+
+	Convey("LoadUserClaims", t, func() {
+		users := &mockUserProvider{user: &model.User{
+			ID:       "user-id",
+			Metadata: map[string]interface{}{"nickname": "John", "secret": "s"},
+		}}
+		issuer := &IDTokenIssuer{
+			OIDCConfig: config.OIDCConfiguration{Scopes: customScopes},
+			URLPrefix:  urlprefix.Provider{Prefix: url.URL{Scheme: "https", Host: "auth.example.com"}},
+			Users:      users,
+		}
+
+		Convey("should include claims of granted custom scopes", func() {
+			claims, err := issuer.LoadUserClaims(&oauth.OfflineGrant{
+				ID:     "grant-id",
+				Scopes: []string{"openid", "profile:read"},
+				Attrs:  authn.Attrs{UserID: "user-id"},
+			})
+			So(err, ShouldBeNil)
+			So(claims.User, ShouldBeNil)
+			So(claims.CustomClaims, ShouldResemble, map[string]interface{}{"nickname": "John"})
+
+			b, err := json.Marshal(IDTokenClaims{UserClaims: *claims, Nonce: "nonce"})
+			So(err, ShouldBeNil)
+			So(string(b), ShouldEqual, `{"iss":"https://auth.example.com","nickname":"John","nonce":"nonce","sub":"user-id"}`)
+		})
+
+		Convey("should not load user without scopes with claims", func() {
+			users.user = nil
+			claims, err := issuer.LoadUserClaims(&oauth.OfflineGrant{
+				ID:     "grant-id",
+				Scopes: []string{"openid", "orders:read"},
+				Attrs:  authn.Attrs{UserID: "user-id"},
+			})
+			So(err, ShouldBeNil)
+			So(claims.CustomClaims, ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,170 @@
+package oidc
+
+import (
+	"net/url"
+	gotime "time"
+
+	"github.com/dgrijalva/jwt-go"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/session"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/urlprefix"
+	taskspec "github.com/skygeario/skygear-server/pkg/auth/task/spec"
+	"github.com/skygeario/skygear-server/pkg/core/async"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/time"
+	coreurl "github.com/skygeario/skygear-server/pkg/core/url"
+	"github.com/skygeario/skygear-server/pkg/core/uuid"
+)
+
+// BackChannelLogoutEvent is the event of logout tokens.
+// https://openid.net/specs/openid-connect-backchannel-1_0.html
+const BackChannelLogoutEvent = "http://schemas.openid.net/event/backchannel-logout"
+
+// logoutTokenType is the type of logout tokens.
+const logoutTokenType = "logout+jwt"
+
+// LogoutTokenValidDuration is the valid period of logout token.
+const LogoutTokenValidDuration = 2 * gotime.Minute
+
+type ClientResolver interface {
+	ResolveClient(clientID string) (config.OAuthClientConfiguration, error)
+}
+
+type LogoutTokenClaims struct {
+	jwt.StandardClaims
+	SID    string                 `json:"sid,omitempty"`
+	Events map[string]interface{} `json:"events"`
+}
+
+// LogoutNotifier notifies clients of ended sessions with OIDC back-channel
+// and front-channel logout.
+type LogoutNotifier struct {
+	OIDCConfig        config.OIDCConfiguration
+	URLPrefix         urlprefix.Provider
+	Clients           ClientResolver
+	ConfiguredClients []config.OAuthClientConfiguration
+	TaskQueue         async.Queue
+	Time              time.Provider
+}
+
+// clientsOf returns the clients tied to the session.
+func (n *LogoutNotifier) clientsOf(s auth.AuthSession) ([]config.OAuthClientConfiguration, error) {
+	var clientIDs []string
+	switch s := s.(type) {
+	case *session.IDPSession:
+		clientIDs = s.ClientIDs
+	case *oauth.OfflineGrant:
+		clientIDs = []string{s.ClientID}
+	}
+
+	var clients []config.OAuthClientConfiguration
+	for _, clientID := range clientIDs {
+		client, err := n.Clients.ResolveClient(clientID)
+		if err != nil {
+			return nil, err
+		} else if client == nil {
+			continue
+		}
+		clients = append(clients, client)
+	}
+	return clients, nil
+}
+
+// NotifyLogout sends logout tokens to back-channel logout URIs of the
+// clients tied to the ended session.
+func (n *LogoutNotifier) NotifyLogout(s auth.AuthSession) error {
+	clients, err := n.clientsOf(s)
+	if err != nil {
+		return err
+	}
+
+	var requests []taskspec.BackChannelLogoutRequest
+	for _, client := range clients {
+		uri := client.BackChannelLogoutURI()
+		if uri == "" {
+			continue
+		}
+		token, err := n.issueLogoutToken(client, s)
+		if err != nil {
+			return err
+		}
+		requests = append(requests, taskspec.BackChannelLogoutRequest{
+			URI:               uri,
+			LogoutToken:       token,
+			PublicNetworkOnly: !n.isConfigured(client),
+		})
+	}
+	if len(requests) == 0 {
+		return nil
+	}
+
+	n.TaskQueue.Enqueue(async.TaskSpec{
+		Name: taskspec.SendLogoutNotificationsTaskName,
+		Param: taskspec.SendLogoutNotificationsTaskParam{
+			BackChannelLogoutRequests: requests,
+		},
+	})
+	return nil
+}
+
+func (n *LogoutNotifier) isConfigured(client config.OAuthClientConfiguration) bool {
+	for _, c := range n.ConfiguredClients {
+		if c.ClientID() == client.ClientID() {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *LogoutNotifier) issueLogoutToken(client config.OAuthClientConfiguration, s auth.AuthSession) (string, error) {
+	now := n.Time.NowUTC()
+	claims := &LogoutTokenClaims{
+		StandardClaims: jwt.StandardClaims{
+			Id:        uuid.New(),
+			Issuer:    n.URLPrefix.Value().String(),
+			Audience:  client.ClientID(),
+			Subject:   s.AuthnAttrs().UserID,
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(LogoutTokenValidDuration).Unix(),
+		},
+		SID: oauth.IDPSessionID(s),
+		Events: map[string]interface{}{
+			BackChannelLogoutEvent: map[string]interface{}{},
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["typ"] = logoutTokenType
+	return oauth.SignToken(&n.OIDCConfig, token)
+}
+
+// FrontChannelLogoutURIs returns the front-channel logout URIs of the
+// clients tied to the session, to be rendered in iframes after logout.
+func (n *LogoutNotifier) FrontChannelLogoutURIs(s auth.AuthSession) ([]string, error) {
+	clients, err := n.clientsOf(s)
+	if err != nil {
+		return nil, err
+	}
+
+	var uris []string
+	for _, client := range clients {
+		uri := client.FrontChannelLogoutURI()
+		if uri == "" {
+			continue
+		}
+		if client.FrontChannelLogoutSessionRequired() {
+			u, err := url.Parse(uri)
+			if err != nil {
+				continue
+			}
+			uri = coreurl.WithQueryParamsAdded(u, map[string]string{
+				"iss": n.URLPrefix.Value().String(),
+				"sid": oauth.IDPSessionID(s),
+			}).String()
+		}
+		uris = append(uris, uri)
+	}
+	return uris, nil
+}
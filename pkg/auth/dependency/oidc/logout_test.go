@@ -0,0 +1,147 @@
+package oidc
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/session"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/urlprefix"
+	taskspec "github.com/skygeario/skygear-server/pkg/auth/task/spec"
+	"github.com/skygeario/skygear-server/pkg/core/async"
+	"github.com/skygeario/skygear-server/pkg/core/authn"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	corejwt "github.com/skygeario/skygear-server/pkg/core/jwt"
+	coretime "github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+type mockClientResolver struct {
+	clients []config.OAuthClientConfiguration
+}
+
+func (r *mockClientResolver) ResolveClient(clientID string) (config.OAuthClientConfiguration, error) {
+	for _, c := range r.clients {
+		if c.ClientID() == clientID {
+			return c, nil
+		}
+	}
+	return nil, nil
+}
+
+func TestLogoutNotifier(t *testing.T) {
+	Convey("LogoutNotifier", t, func() {
+		privKey, pubKey, err := corejwt.GenerateKeyPair(corejwt.AlgorithmES256)
+		So(err, ShouldBeNil)
+		oidcConfig := config.OIDCConfiguration{
+			Keys: []config.OIDCSigningKeyConfiguration{{
+				KID:        "key-id",
+				State:      config.OIDCSigningKeyStateActive,
+				PrivateKey: privKey,
+				PublicKey:  pubKey,
+			}},
+		}
+
+		mockTime := &coretime.MockProvider{TimeNowUTC: time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)}
+		queue := async.NewMockQueue()
+		n := &LogoutNotifier{
+			OIDCConfig: oidcConfig,
+			URLPrefix:  urlprefix.Provider{Prefix: url.URL{Scheme: "https", Host: "auth.example.com"}},
+			Clients: &mockClientResolver{clients: []config.OAuthClientConfiguration{
+				{
+					"client_id":              "backchannel",
+					"backchannel_logout_uri": "https://rp1.example.com/logout",
+				},
+				{
+					"client_id":                            "frontchannel",
+					"frontchannel_logout_uri":              "https://rp2.example.com/logout",
+					"frontchannel_logout_session_required": true,
+				},
+				{"client_id": "none"},
+				{
+					"client_id":              "registered",
+					"backchannel_logout_uri": "https://rp3.example.com/logout",
+				},
+			}},
+			ConfiguredClients: []config.OAuthClientConfiguration{
+				{"client_id": "backchannel"},
+				{"client_id": "frontchannel"},
+				{"client_id": "none"},
+			},
+			TaskQueue: queue,
+			Time:      mockTime,
+		}
+		sess := &session.IDPSession{
+			ID:        "session-id",
+			Attrs:     authn.Attrs{UserID: "user-id"},
+			ClientIDs: []string{"backchannel", "frontchannel", "none", "removed"},
+		}
+
+		Convey("should send logout tokens to back-channel logout URIs", func() {
+			err := n.NotifyLogout(sess)
+			So(err, ShouldBeNil)
+			So(queue.TasksName, ShouldResemble, []string{taskspec.SendLogoutNotificationsTaskName})
+
+			param := queue.TasksParam[0].(taskspec.SendLogoutNotificationsTaskParam)
+			So(param.BackChannelLogoutRequests, ShouldHaveLength, 1)
+			req := param.BackChannelLogoutRequests[0]
+			So(req.URI, ShouldEqual, "https://rp1.example.com/logout")
+			So(req.PublicNetworkOnly, ShouldBeFalse)
+
+			claims := &LogoutTokenClaims{}
+			parser := &jwt.Parser{SkipClaimsValidation: true}
+			token, err := parser.ParseWithClaims(req.LogoutToken, claims, func(token *jwt.Token) (interface{}, error) {
+				return oauth.VerificationKey(&oidcConfig, token)
+			})
+			So(err, ShouldBeNil)
+			So(token.Header["typ"], ShouldEqual, "logout+jwt")
+			So(token.Header["alg"], ShouldEqual, "ES256")
+			So(claims.Issuer, ShouldEqual, "https://auth.example.com")
+			So(claims.Audience, ShouldEqual, "backchannel")
+			So(claims.Subject, ShouldEqual, "user-id")
+			So(claims.SID, ShouldEqual, "session-id")
+			So(claims.Id, ShouldNotBeEmpty)
+			So(claims.Events, ShouldContainKey, BackChannelLogoutEvent)
+		})
+
+		Convey("should restrict logout URIs of registered clients to public network", func() {
+			sess.ClientIDs = []string{"registered"}
+			err := n.NotifyLogout(sess)
+			So(err, ShouldBeNil)
+
+			param := queue.TasksParam[0].(taskspec.SendLogoutNotificationsTaskParam)
+			So(param.BackChannelLogoutRequests, ShouldHaveLength, 1)
+			So(param.BackChannelLogoutRequests[0].URI, ShouldEqual, "https://rp3.example.com/logout")
+			So(param.BackChannelLogoutRequests[0].PublicNetworkOnly, ShouldBeTrue)
+		})
+
+		Convey("should notify client of offline grant", func() {
+			err := n.NotifyLogout(&oauth.OfflineGrant{
+				ID:           "grant-id",
+				ClientID:     "backchannel",
+				IDPSessionID: "session-id",
+				Attrs:        authn.Attrs{UserID: "user-id"},
+			})
+			So(err, ShouldBeNil)
+			So(queue.TasksName, ShouldHaveLength, 1)
+		})
+
+		Convey("should not enqueue task without back-channel logout URIs", func() {
+			sess.ClientIDs = []string{"frontchannel"}
+			err := n.NotifyLogout(sess)
+			So(err, ShouldBeNil)
+			So(queue.TasksName, ShouldBeEmpty)
+		})
+
+		Convey("should return front-channel logout URIs", func() {
+			uris, err := n.FrontChannelLogoutURIs(sess)
+			So(err, ShouldBeNil)
+			So(uris, ShouldResemble, []string{
+				"https://rp2.example.com/logout?iss=https%3A%2F%2Fauth.example.com&sid=session-id",
+			})
+		})
+	})
+}
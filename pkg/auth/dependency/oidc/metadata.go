@@ -1,20 +1,28 @@
 package oidc
 
-import "github.com/skygeario/skygear-server/pkg/auth/dependency/urlprefix"
+import (
+	"sort"
+
+	oauthhandler "github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/handler"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/urlprefix"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+)
 
 type MetadataProvider struct {
 	URLPrefix          urlprefix.Provider
 	JWKSEndpoint       JWKSEndpointProvider
 	UserInfoEndpoint   UserInfoEndpointProvider
 	EndSessionEndpoint EndSessionEndpointProvider
+	CustomScopes       []config.OIDCScopeConfiguration
+	SigningAlgorithms  []string
 }
 
 func (p *MetadataProvider) PopulateMetadata(meta map[string]interface{}) {
 	meta["issuer"] = p.URLPrefix.Value().String()
-	meta["scopes_supported"] = AllowedScopes
+	meta["scopes_supported"] = SupportedScopes(p.CustomScopes)
 	meta["subject_types_supported"] = []string{"public"}
-	meta["id_token_signing_alg_values_supported"] = []string{"RS256"}
-	meta["claims_supported"] = []string{
+	meta["id_token_signing_alg_values_supported"] = p.SigningAlgorithms
+	claims := []string{
 		"iss",
 		"aud",
 		"iat",
@@ -23,8 +31,42 @@ func (p *MetadataProvider) PopulateMetadata(meta map[string]interface{}) {
 		"skygear_user",
 		"skygear_identity",
 		"skygear_session_id",
+		"sid",
 	}
+	claims = append(claims, StandardClaims...)
+	meta["claims_supported"] = append(claims, p.customClaims()...)
+	meta["token_endpoint_auth_methods_supported"] = oauthhandler.ClientAuthMethodsSupported
+	meta["token_endpoint_auth_signing_alg_values_supported"] = oauthhandler.ClientAssertionSigningAlgsSupported
+	meta["revocation_endpoint_auth_methods_supported"] = oauthhandler.ClientAuthMethodsSupported
+	meta["revocation_endpoint_auth_signing_alg_values_supported"] = oauthhandler.ClientAssertionSigningAlgsSupported
+	meta["introspection_endpoint_auth_methods_supported"] = oauthhandler.IntrospectionAuthMethodsSupported
+	meta["introspection_endpoint_auth_signing_alg_values_supported"] = oauthhandler.ClientAssertionSigningAlgsSupported
+	meta["request_parameter_supported"] = true
+	meta["request_uri_parameter_supported"] = false
+	meta["request_object_signing_alg_values_supported"] = oauthhandler.ClientAssertionSigningAlgsSupported
 	meta["jwks_uri"] = p.JWKSEndpoint.JWKSEndpointURI().String()
 	meta["userinfo_endpoint"] = p.UserInfoEndpoint.UserInfoEndpointURI().String()
 	meta["end_session_endpoint"] = p.EndSessionEndpoint.EndSessionEndpointURI().String()
+	meta["backchannel_logout_supported"] = true
+	meta["backchannel_logout_session_supported"] = true
+	meta["frontchannel_logout_supported"] = true
+	meta["frontchannel_logout_session_supported"] = true
+}
+
+func (p *MetadataProvider) customClaims() []string {
+	seen := map[string]struct{}{}
+	for _, claim := range StandardClaims {
+		seen[claim] = struct{}{}
+	}
+	var claims []string
+	for _, scope := range p.CustomScopes {
+		for claim := range scope.Claims {
+			if _, ok := seen[claim]; !ok {
+				seen[claim] = struct{}{}
+				claims = append(claims, claim)
+			}
+		}
+	}
+	sort.Strings(claims)
+	return claims
 }
@@ -6,7 +6,11 @@ import (
 	"github.com/skygeario/skygear-server/pkg/core/config"
 )
 
-func ValidateScopes(client config.OAuthClientConfiguration, scopes []string) error {
+func ValidateScopes(
+	customScopes []config.OIDCScopeConfiguration,
+	client config.OAuthClientConfiguration,
+	scopes []string,
+) error {
 	allowOfflineAccess := false
 	for _, grantType := range client.GrantTypes() {
 		if grantType == "refresh_token" {
@@ -16,7 +20,7 @@ func ValidateScopes(client config.OAuthClientConfiguration, scopes []string) err
 	}
 	hasOIDC := false
 	for _, s := range scopes {
-		if !IsScopeAllowed(s) {
+		if !IsScopeAllowed(s) && !isCustomScopeAllowed(customScopes, client, s) {
 			return protocol.NewError("invalid_scope", "specified scope is not allowed")
 		}
 		if s == "offline_access" && !allowOfflineAccess {
@@ -35,6 +39,9 @@ func ValidateScopes(client config.OAuthClientConfiguration, scopes []string) err
 var AllowedScopes = []string{
 	"openid",
 	"offline_access",
+	ScopeProfile,
+	ScopeEmail,
+	ScopePhone,
 	oauth.FullAccessScope,
 }
 
@@ -46,3 +53,39 @@ func IsScopeAllowed(scope string) bool {
 	}
 	return false
 }
+
+// isCustomScopeAllowed checks whether the custom scope is declared and
+// allowed for the client.
+func isCustomScopeAllowed(
+	customScopes []config.OIDCScopeConfiguration,
+	client config.OAuthClientConfiguration,
+	scope string,
+) bool {
+	if FindCustomScope(customScopes, scope) == nil {
+		return false
+	}
+	for _, s := range client.AllowedScopes() {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func FindCustomScope(customScopes []config.OIDCScopeConfiguration, scope string) *config.OIDCScopeConfiguration {
+	for i, s := range customScopes {
+		if s.Name == scope {
+			return &customScopes[i]
+		}
+	}
+	return nil
+}
+
+// SupportedScopes returns the built-in scopes and the custom scopes.
+func SupportedScopes(customScopes []config.OIDCScopeConfiguration) []string {
+	scopes := append([]string{}, AllowedScopes...)
+	for _, s := range customScopes {
+		scopes = append(scopes, s.Name)
+	}
+	return scopes
+}
@@ -0,0 +1,61 @@
+package samlidp
+
+import (
+	"context"
+
+	"github.com/google/wire"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/logging"
+	"github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+func ProvideMetadataProvider(cfg *config.TenantConfiguration, e EndpointsProvider) *MetadataProvider {
+	return &MetadataProvider{
+		Config:    cfg.AppConfig.SAMLIdP,
+		Endpoints: e,
+	}
+}
+
+func ProvideSSOHandler(
+	ctx context.Context,
+	cfg *config.TenantConfiguration,
+	lf logging.Factory,
+	e EndpointsProvider,
+	authne AuthenticateURLProvider,
+	ucl UserClaimsLoader,
+	tp time.Provider,
+) *SSOHandler {
+	return &SSOHandler{
+		Context:         ctx,
+		Config:          cfg.AppConfig.SAMLIdP,
+		Logger:          lf.NewLogger("saml-sso"),
+		Endpoints:       e,
+		AuthenticateURL: authne,
+		UserClaims:      ucl,
+		Time:            tp,
+	}
+}
+
+func ProvideSLOHandler(
+	ctx context.Context,
+	cfg *config.TenantConfiguration,
+	lf logging.Factory,
+	e EndpointsProvider,
+	sm SessionManager,
+	tp time.Provider,
+) *SLOHandler {
+	return &SLOHandler{
+		Context:   ctx,
+		Config:    cfg.AppConfig.SAMLIdP,
+		Logger:    lf.NewLogger("saml-slo"),
+		Endpoints: e,
+		Sessions:  sm,
+		Time:      tp,
+	}
+}
+
+var DependencySet = wire.NewSet(
+	ProvideMetadataProvider,
+	ProvideSSOHandler,
+	ProvideSLOHandler,
+)
@@ -0,0 +1,148 @@
+package samlidp
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/beevik/etree"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+	"github.com/skygeario/skygear-server/pkg/core/time"
+	"github.com/skygeario/skygear-server/pkg/core/xmldsig"
+)
+
+type SessionManager interface {
+	Logout(session auth.AuthSession, rw http.ResponseWriter) error
+	Revoke(session auth.AuthSession) error
+	GetByUser(userID string, id string) (auth.AuthSession, error)
+}
+
+// SLOHandler handles single logout requests of service providers. The
+// sessions are deleted through session manager, so session_delete events
+// are dispatched and OIDC clients are notified as usual.
+type SLOHandler struct {
+	Context   context.Context
+	Config    *config.SAMLIdPConfiguration
+	Logger    *logrus.Entry
+	Endpoints EndpointsProvider
+	Sessions  SessionManager
+	Time      time.Provider
+}
+
+func (h *SLOHandler) Handle(m Message, rw http.ResponseWriter) Result {
+	root, err := decodeMessage(m.Binding, m.SAMLRequest)
+	if err != nil {
+		return resultError{Message: "malformed request"}
+	}
+	req, err := parseLogoutRequest(root)
+	if err != nil {
+		return resultError{Message: err.Error()}
+	}
+
+	sp := h.Config.ServiceProvider(req.Issuer)
+	if sp == nil {
+		return resultError{Message: "unknown service provider"}
+	}
+	if sp.SLOURL == "" {
+		return resultError{Message: "single logout is not supported by service provider"}
+	}
+
+	// Logout requests must be signed, since the name ID and session
+	// indexes are not secrets.
+	certs, err := sp.Certificates()
+	if err != nil {
+		h.Logger.WithError(err).Error("saml slo handler failed")
+		return resultError{InternalError: true}
+	}
+	if m.Binding == BindingHTTPRedirect {
+		err = verifyRedirectSignature(m.RawQuery, certs)
+	} else {
+		// Only the signed content of the request is trusted.
+		var signed *etree.Element
+		signed, err = xmldsig.Verify(root, certs)
+		if err == nil {
+			req, err = parseLogoutRequest(signed)
+		}
+	}
+	if err != nil {
+		return resultError{Message: "invalid signature"}
+	}
+
+	if req.Destination != "" && req.Destination != h.Endpoints.SAMLSLOEndpointURI().String() {
+		return resultError{Message: "destination mismatch"}
+	}
+	if req.IsExpired(h.Time.NowUTC()) {
+		return resultError{Message: "request expired"}
+	}
+
+	result, err := h.doHandle(sp, req, m.RelayState, rw)
+	if err != nil {
+		h.Logger.WithError(err).Error("saml slo handler failed")
+		return resultError{InternalError: true}
+	}
+	return result
+}
+
+func (h *SLOHandler) doHandle(sp *config.SAMLServiceProviderConfiguration, req *LogoutRequest, relayState string, rw http.ResponseWriter) (Result, error) {
+	if err := h.logout(req, rw); err != nil {
+		return nil, err
+	}
+
+	key, cert, err := h.Config.SigningKey()
+	if err != nil {
+		return nil, err
+	}
+	samlResponse, err := signMessage(&logoutResponseXML{
+		ProtocolNamespace:  protocolNamespace,
+		AssertionNamespace: assertionNamespace,
+		ID:                 newID(),
+		Version:            "2.0",
+		IssueInstant:       formatTime(h.Time.NowUTC()),
+		Destination:        sp.SLOURL,
+		InResponseTo:       req.ID,
+		Issuer:             EntityID(h.Endpoints),
+		Status:             newStatus(statusSuccess, ""),
+	}, key, cert)
+	if err != nil {
+		return nil, err
+	}
+	return postMessage(sp.SLOURL, samlResponse, relayState), nil
+}
+
+// logout ends the sessions of the request. The current session is logged
+// out if it belongs to the subject; other sessions are identified by
+// session indexes of the request.
+func (h *SLOHandler) logout(req *LogoutRequest, rw http.ResponseWriter) error {
+	current := auth.GetSession(h.Context)
+	if current != nil && current.AuthnAttrs().UserID == req.NameID {
+		sid := oauth.IDPSessionID(current)
+		matched := len(req.SessionIndexes) == 0
+		for _, index := range req.SessionIndexes {
+			if index == sid {
+				matched = true
+			}
+		}
+		if matched {
+			return h.Sessions.Logout(current, rw)
+		}
+	}
+
+	for _, index := range req.SessionIndexes {
+		s, err := h.Sessions.GetByUser(req.NameID, index)
+		if errors.Is(err, auth.ErrSessionNotFound) {
+			// The session is already ended.
+			continue
+		} else if err != nil {
+			return err
+		}
+		if err := h.Sessions.Revoke(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,253 @@
+package samlidp
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/beevik/etree"
+	"github.com/sirupsen/logrus"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oidc"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/webapp"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/time"
+	coreurl "github.com/skygeario/skygear-server/pkg/core/url"
+	"github.com/skygeario/skygear-server/pkg/core/xmldsig"
+)
+
+type AuthenticateURLProvider interface {
+	AuthenticateURI(options webapp.AuthenticateURLOptions) (*url.URL, error)
+}
+
+type UserClaimsLoader interface {
+	LoadUserClaims(session auth.AuthSession) (*oidc.UserClaims, error)
+}
+
+// SSOHandler handles authentication requests of service providers, and
+// responds signed assertions of the authenticated user.
+type SSOHandler struct {
+	Context         context.Context
+	Config          *config.SAMLIdPConfiguration
+	Logger          *logrus.Entry
+	Endpoints       EndpointsProvider
+	AuthenticateURL AuthenticateURLProvider
+	UserClaims      UserClaimsLoader
+	Time            time.Provider
+}
+
+func (h *SSOHandler) Handle(m Message) Result {
+	root, err := decodeMessage(m.Binding, m.SAMLRequest)
+	if err != nil {
+		return resultError{Message: "malformed request"}
+	}
+	req, err := parseAuthnRequest(root)
+	if err != nil {
+		return resultError{Message: err.Error()}
+	}
+
+	sp := h.Config.ServiceProvider(req.Issuer)
+	if sp == nil {
+		return resultError{Message: "unknown service provider"}
+	}
+	if sp.WantAuthnRequestsSigned {
+		req, err = h.verifySignature(sp, m, root)
+		if err != nil {
+			return resultError{Message: "invalid signature"}
+		}
+	}
+
+	// Responses are sent to the registered ACS URL only.
+	if req.ACSURL != "" && req.ACSURL != sp.ACSURL {
+		return resultError{Message: "unregistered assertion consumer service URL"}
+	}
+	if req.Destination != "" && req.Destination != h.Endpoints.SAMLSSOEndpointURI().String() {
+		return resultError{Message: "destination mismatch"}
+	}
+
+	result, err := h.doHandle(sp, req, m.RelayState)
+	if err != nil {
+		h.Logger.WithError(err).Error("saml sso handler failed")
+		return resultError{InternalError: true}
+	}
+	return result
+}
+
+// verifySignature verifies the request is signed by the service provider,
+// and returns the signed content of the request. Requests retried after
+// authentication are signed by the IdP instead, since they are re-encoded.
+func (h *SSOHandler) verifySignature(sp *config.SAMLServiceProviderConfiguration, m Message, root *etree.Element) (*AuthnRequest, error) {
+	certs, err := sp.Certificates()
+	if err != nil {
+		return nil, err
+	}
+
+	if m.Binding == BindingHTTPRedirect {
+		_, idpCert, err := h.Config.SigningKey()
+		if err != nil {
+			return nil, err
+		}
+		if err := verifyRedirectSignature(m.RawQuery, append(certs, idpCert)); err != nil {
+			return nil, err
+		}
+		return parseAuthnRequest(root)
+	}
+
+	// Only the signed content of the request is trusted.
+	signed, err := xmldsig.Verify(root, certs)
+	if err != nil {
+		return nil, err
+	}
+	return parseAuthnRequest(signed)
+}
+
+func (h *SSOHandler) doHandle(sp *config.SAMLServiceProviderConfiguration, req *AuthnRequest, relayState string) (Result, error) {
+	if req.ProtocolBinding != "" && req.ProtocolBinding != BindingHTTPPost {
+		return h.respondStatus(sp, req, relayState, statusRequester, statusUnsupportedBinding)
+	}
+
+	session := auth.GetSession(h.Context)
+	authnOptions := webapp.AuthenticateURLOptions{}
+	if req.ForceAuthn {
+		// Force re-authentication and retry without ForceAuthn.
+		req.ForceAuthn = false
+		authnOptions.Prompt = "login"
+		session = nil
+	}
+	if session == nil || session.SessionType() != auth.SessionTypeIdentityProvider {
+		if req.IsPassive {
+			return h.respondStatus(sp, req, relayState, statusResponder, statusNoPassive)
+		}
+
+		// Not authenticated as IdP session => request authentication and retry
+		samlRequest, err := req.encode()
+		if err != nil {
+			return nil, err
+		}
+		retryURI := h.Endpoints.SAMLSSOEndpointURI()
+		if sp.WantAuthnRequestsSigned {
+			// The signature of service provider does not cover the
+			// re-encoded request, so the IdP signs the retry instead.
+			key, _, err := h.Config.SigningKey()
+			if err != nil {
+				return nil, err
+			}
+			retryURI.RawQuery, err = signRedirectQuery(samlRequest, relayState, key)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			retryParams := map[string]string{"SAMLRequest": samlRequest}
+			if relayState != "" {
+				retryParams["RelayState"] = relayState
+			}
+			retryURI = coreurl.WithQueryParamsAdded(retryURI, retryParams)
+		}
+		authnOptions.RedirectURI = retryURI.String()
+
+		authenticateURI, err := h.AuthenticateURL.AuthenticateURI(authnOptions)
+		if err != nil {
+			return nil, err
+		}
+		return resultRedirect{URI: authenticateURI.String()}, nil
+	}
+
+	claims, err := h.UserClaims.LoadUserClaims(session)
+	if err != nil {
+		return nil, err
+	}
+
+	now := h.Time.NowUTC()
+	expireAt := formatTime(now.Add(AssertionValidDuration))
+	response := h.newResponse(sp, req, statusSuccess, "")
+	response.Assertion = &assertionXML{
+		ID:           newID(),
+		Version:      "2.0",
+		IssueInstant: formatTime(now),
+		Issuer:       response.Issuer,
+		Subject: subjectXML{
+			NameID: nameIDXML{
+				Format: nameIDFormatPersistent,
+				Value:  session.AuthnAttrs().UserID,
+			},
+			SubjectConfirmation: subjectConfirmationXML{
+				Method: confirmationBearer,
+				Data: subjectConfirmationDataXML{
+					InResponseTo: req.ID,
+					Recipient:    sp.ACSURL,
+					NotOnOrAfter: expireAt,
+				},
+			},
+		},
+		Conditions: conditionsXML{
+			NotBefore:           formatTime(now),
+			NotOnOrAfter:        expireAt,
+			AudienceRestriction: audienceRestrictionXML{Audience: sp.EntityID},
+		},
+		AuthnStatement: authnStatementXML{
+			AuthnInstant: formatTime(session.GetCreatedAt()),
+			SessionIndex: oauth.IDPSessionID(session),
+			AuthnContext: authnContextXML{AuthnContextClassRef: authnContextUnspecified},
+		},
+		AttributeStatement: newAttributeStatement(claims),
+	}
+
+	return h.respond(sp, response, relayState)
+}
+
+func (h *SSOHandler) newResponse(sp *config.SAMLServiceProviderConfiguration, req *AuthnRequest, code string, subCode string) *responseXML {
+	return &responseXML{
+		ProtocolNamespace:  protocolNamespace,
+		AssertionNamespace: assertionNamespace,
+		ID:                 newID(),
+		Version:            "2.0",
+		IssueInstant:       formatTime(h.Time.NowUTC()),
+		Destination:        sp.ACSURL,
+		InResponseTo:       req.ID,
+		Issuer:             EntityID(h.Endpoints),
+		Status:             newStatus(code, subCode),
+	}
+}
+
+// respondStatus responds the error status to service provider.
+func (h *SSOHandler) respondStatus(sp *config.SAMLServiceProviderConfiguration, req *AuthnRequest, relayState string, code string, subCode string) (Result, error) {
+	return h.respond(sp, h.newResponse(sp, req, code, subCode), relayState)
+}
+
+func (h *SSOHandler) respond(sp *config.SAMLServiceProviderConfiguration, response *responseXML, relayState string) (Result, error) {
+	key, cert, err := h.Config.SigningKey()
+	if err != nil {
+		return nil, err
+	}
+	samlResponse, err := signMessage(response, key, cert)
+	if err != nil {
+		return nil, err
+	}
+	return postMessage(sp.ACSURL, samlResponse, relayState), nil
+}
+
+// newAttributeStatement returns the attributes of standard claims, named
+// after the claims.
+func newAttributeStatement(claims *oidc.UserClaims) *attributeStatementXML {
+	var attributes []attributeXML
+	add := func(name string, value string) {
+		if value == "" {
+			return
+		}
+		attributes = append(attributes, attributeXML{
+			Name:       name,
+			NameFormat: attributeNameFormatBasic,
+			Values:     []string{value},
+		})
+	}
+	add("email", claims.Email)
+	add("name", claims.Name)
+	add("preferred_username", claims.PreferredUsername)
+	add("phone_number", claims.PhoneNumber)
+
+	if len(attributes) == 0 {
+		return nil
+	}
+	return &attributeStatementXML{Attributes: attributes}
+}
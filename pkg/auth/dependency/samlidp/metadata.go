@@ -0,0 +1,115 @@
+package samlidp
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"net/url"
+
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+	"github.com/skygeario/skygear-server/pkg/core/xmldsig"
+)
+
+// ErrNotConfigured is returned when the signing key of IdP is not
+// configured.
+var ErrNotConfigured = errors.New("SAML IdP is not configured")
+
+type EndpointsProvider interface {
+	SAMLMetadataEndpointURI() *url.URL
+	SAMLSSOEndpointURI() *url.URL
+	SAMLSLOEndpointURI() *url.URL
+}
+
+// EntityID returns the entity ID of the IdP, which is the URL of its
+// metadata.
+func EntityID(endpoints EndpointsProvider) string {
+	return endpoints.SAMLMetadataEndpointURI().String()
+}
+
+type entityDescriptorXML struct {
+	XMLName           xml.Name            `xml:"md:EntityDescriptor"`
+	MetadataNamespace string              `xml:"xmlns:md,attr"`
+	EntityID          string              `xml:"entityID,attr"`
+	IDPSSODescriptor  idpSSODescriptorXML `xml:"md:IDPSSODescriptor"`
+}
+
+type idpSSODescriptorXML struct {
+	WantAuthnRequestsSigned    bool             `xml:"WantAuthnRequestsSigned,attr"`
+	ProtocolSupportEnumeration string           `xml:"protocolSupportEnumeration,attr"`
+	KeyDescriptor              keyDescriptorXML `xml:"md:KeyDescriptor"`
+	SingleLogoutServices       []endpointXML    `xml:"md:SingleLogoutService"`
+	NameIDFormat               string           `xml:"md:NameIDFormat"`
+	SingleSignOnServices       []endpointXML    `xml:"md:SingleSignOnService"`
+}
+
+type keyDescriptorXML struct {
+	Use     string     `xml:"use,attr"`
+	KeyInfo keyInfoXML `xml:"ds:KeyInfo"`
+}
+
+type keyInfoXML struct {
+	SignatureNamespace string `xml:"xmlns:ds,attr"`
+	X509Certificate    string `xml:"ds:X509Data>ds:X509Certificate"`
+}
+
+type endpointXML struct {
+	Binding  string `xml:"Binding,attr"`
+	Location string `xml:"Location,attr"`
+}
+
+// MetadataProvider publishes metadata of the IdP, which service providers
+// are configured with.
+type MetadataProvider struct {
+	Config    *config.SAMLIdPConfiguration
+	Endpoints EndpointsProvider
+}
+
+func (p *MetadataProvider) Metadata() ([]byte, error) {
+	if p.Config.Certificate == "" {
+		return nil, ErrNotConfigured
+	}
+	_, cert, err := p.Config.SigningKey()
+	if err != nil {
+		return nil, err
+	}
+
+	// Metadata is shared by all service providers, so signed requests are
+	// advertised as wanted if any service provider is required to sign.
+	wantAuthnRequestsSigned := false
+	for _, sp := range p.Config.ServiceProviders {
+		if sp.WantAuthnRequestsSigned {
+			wantAuthnRequestsSigned = true
+		}
+	}
+
+	sso := p.Endpoints.SAMLSSOEndpointURI().String()
+	slo := p.Endpoints.SAMLSLOEndpointURI().String()
+	metadata, err := xml.MarshalIndent(entityDescriptorXML{
+		MetadataNamespace: metadataNamespace,
+		EntityID:          EntityID(p.Endpoints),
+		IDPSSODescriptor: idpSSODescriptorXML{
+			WantAuthnRequestsSigned:    wantAuthnRequestsSigned,
+			ProtocolSupportEnumeration: protocolNamespace,
+			KeyDescriptor: keyDescriptorXML{
+				Use: "signing",
+				KeyInfo: keyInfoXML{
+					SignatureNamespace: xmldsig.Namespace,
+					X509Certificate:    base64.StdEncoding.EncodeToString(cert.Raw),
+				},
+			},
+			SingleLogoutServices: []endpointXML{
+				{Binding: BindingHTTPRedirect, Location: slo},
+				{Binding: BindingHTTPPost, Location: slo},
+			},
+			NameIDFormat: nameIDFormatPersistent,
+			SingleSignOnServices: []endpointXML{
+				{Binding: BindingHTTPRedirect, Location: sso},
+				{Binding: BindingHTTPPost, Location: sso},
+			},
+		},
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), metadata...), nil
+}
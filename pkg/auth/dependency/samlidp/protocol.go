@@ -0,0 +1,446 @@
+package samlidp
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"strings"
+	gotime "time"
+
+	"github.com/beevik/etree"
+
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+	"github.com/skygeario/skygear-server/pkg/core/uuid"
+	"github.com/skygeario/skygear-server/pkg/core/xmldsig"
+)
+
+const (
+	protocolNamespace  = "urn:oasis:names:tc:SAML:2.0:protocol"
+	assertionNamespace = "urn:oasis:names:tc:SAML:2.0:assertion"
+	metadataNamespace  = "urn:oasis:names:tc:SAML:2.0:metadata"
+
+	BindingHTTPRedirect = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+	BindingHTTPPost     = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+
+	statusSuccess            = "urn:oasis:names:tc:SAML:2.0:status:Success"
+	statusRequester          = "urn:oasis:names:tc:SAML:2.0:status:Requester"
+	statusResponder          = "urn:oasis:names:tc:SAML:2.0:status:Responder"
+	statusNoPassive          = "urn:oasis:names:tc:SAML:2.0:status:NoPassive"
+	statusUnsupportedBinding = "urn:oasis:names:tc:SAML:2.0:status:UnsupportedBinding"
+
+	nameIDFormatPersistent   = "urn:oasis:names:tc:SAML:2.0:nameid-format:persistent"
+	confirmationBearer       = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+	authnContextUnspecified  = "urn:oasis:names:tc:SAML:2.0:ac:classes:unspecified"
+	attributeNameFormatBasic = "urn:oasis:names:tc:SAML:2.0:attrname-format:basic"
+
+	// maxMessageSize limits the size of inflated messages of HTTP-Redirect
+	// binding.
+	maxMessageSize   = 256 * 1024
+	allowedClockSkew = 1 * gotime.Minute
+)
+
+// AssertionValidDuration is the valid period of issued assertions.
+const AssertionValidDuration = 5 * gotime.Minute
+
+// Message is a SAML protocol message received by the IdP.
+type Message struct {
+	Binding     string
+	SAMLRequest string
+	RelayState  string
+	// RawQuery is the query string of HTTP-Redirect binding, which signature
+	// is computed over.
+	RawQuery string
+}
+
+func newID() string {
+	return "_" + uuid.New()
+}
+
+func formatTime(t gotime.Time) string {
+	return t.UTC().Format(gotime.RFC3339)
+}
+
+// decodeMessage decodes the message of the binding.
+func decodeMessage(binding string, message string) (*etree.Element, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(message), ""))
+	if err != nil {
+		return nil, err
+	}
+	if binding == BindingHTTPRedirect {
+		r := flate.NewReader(bytes.NewReader(data))
+		data, err = ioutil.ReadAll(io.LimitReader(r, maxMessageSize+1))
+		if err != nil {
+			return nil, err
+		}
+		if len(data) > maxMessageSize {
+			return nil, errors.New("message too large")
+		}
+	}
+	return xmldsig.Parse(data)
+}
+
+// encodeRedirectMessage encodes the message with HTTP-Redirect binding.
+func encodeRedirectMessage(data []byte) (string, error) {
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.DefaultCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err = w.Write(data); err != nil {
+		return "", err
+	}
+	if err = w.Close(); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+func childText(e *etree.Element, space string, name string) string {
+	child := xmldsig.FindChild(e, space, name)
+	if child == nil {
+		return ""
+	}
+	return strings.TrimSpace(xmldsig.Text(child))
+}
+
+// AuthnRequest is the authentication request from service provider.
+type AuthnRequest struct {
+	ID              string
+	IssueInstant    string
+	Issuer          string
+	Destination     string
+	ACSURL          string
+	ProtocolBinding string
+	ForceAuthn      bool
+	IsPassive       bool
+}
+
+func parseAuthnRequest(e *etree.Element) (*AuthnRequest, error) {
+	if !xmldsig.Is(e, protocolNamespace, "AuthnRequest") {
+		return nil, errors.New("expected AuthnRequest")
+	}
+	if version, _ := xmldsig.Attr(e, "Version"); version != "2.0" {
+		return nil, errors.New("unsupported version")
+	}
+
+	r := &AuthnRequest{Issuer: childText(e, assertionNamespace, "Issuer")}
+	r.ID, _ = xmldsig.Attr(e, "ID")
+	r.IssueInstant, _ = xmldsig.Attr(e, "IssueInstant")
+	r.Destination, _ = xmldsig.Attr(e, "Destination")
+	r.ACSURL, _ = xmldsig.Attr(e, "AssertionConsumerServiceURL")
+	r.ProtocolBinding, _ = xmldsig.Attr(e, "ProtocolBinding")
+	forceAuthn, _ := xmldsig.Attr(e, "ForceAuthn")
+	r.ForceAuthn = forceAuthn == "true" || forceAuthn == "1"
+	isPassive, _ := xmldsig.Attr(e, "IsPassive")
+	r.IsPassive = isPassive == "true" || isPassive == "1"
+
+	if r.ID == "" || r.Issuer == "" {
+		return nil, errors.New("missing ID or issuer")
+	}
+	return r, nil
+}
+
+type authnRequestXML struct {
+	XMLName                     xml.Name `xml:"samlp:AuthnRequest"`
+	ProtocolNamespace           string   `xml:"xmlns:samlp,attr"`
+	AssertionNamespace          string   `xml:"xmlns:saml,attr"`
+	ID                          string   `xml:"ID,attr"`
+	Version                     string   `xml:"Version,attr"`
+	IssueInstant                string   `xml:"IssueInstant,attr"`
+	AssertionConsumerServiceURL string   `xml:"AssertionConsumerServiceURL,attr,omitempty"`
+	ProtocolBinding             string   `xml:"ProtocolBinding,attr,omitempty"`
+	Issuer                      string   `xml:"saml:Issuer"`
+}
+
+// encode encodes the request with HTTP-Redirect binding. Only the fields
+// used by the IdP are retained.
+func (r *AuthnRequest) encode() (string, error) {
+	data, err := xml.Marshal(authnRequestXML{
+		ProtocolNamespace:           protocolNamespace,
+		AssertionNamespace:          assertionNamespace,
+		ID:                          r.ID,
+		Version:                     "2.0",
+		IssueInstant:                r.IssueInstant,
+		AssertionConsumerServiceURL: r.ACSURL,
+		ProtocolBinding:             r.ProtocolBinding,
+		Issuer:                      r.Issuer,
+	})
+	if err != nil {
+		return "", err
+	}
+	return encodeRedirectMessage(data)
+}
+
+// LogoutRequest is the single logout request from service provider.
+type LogoutRequest struct {
+	ID             string
+	Issuer         string
+	Destination    string
+	NotOnOrAfter   string
+	NameID         string
+	SessionIndexes []string
+}
+
+func parseLogoutRequest(e *etree.Element) (*LogoutRequest, error) {
+	if !xmldsig.Is(e, protocolNamespace, "LogoutRequest") {
+		return nil, errors.New("expected LogoutRequest")
+	}
+	if version, _ := xmldsig.Attr(e, "Version"); version != "2.0" {
+		return nil, errors.New("unsupported version")
+	}
+
+	r := &LogoutRequest{
+		Issuer: childText(e, assertionNamespace, "Issuer"),
+		NameID: childText(e, assertionNamespace, "NameID"),
+	}
+	r.ID, _ = xmldsig.Attr(e, "ID")
+	r.Destination, _ = xmldsig.Attr(e, "Destination")
+	r.NotOnOrAfter, _ = xmldsig.Attr(e, "NotOnOrAfter")
+	for _, index := range xmldsig.FindChildren(e, protocolNamespace, "SessionIndex") {
+		r.SessionIndexes = append(r.SessionIndexes, strings.TrimSpace(xmldsig.Text(index)))
+	}
+
+	if r.ID == "" || r.Issuer == "" || r.NameID == "" {
+		return nil, errors.New("missing ID, issuer or name ID")
+	}
+	return r, nil
+}
+
+// IsExpired returns whether the request is expired at now.
+func (r *LogoutRequest) IsExpired(now gotime.Time) bool {
+	if r.NotOnOrAfter == "" {
+		return false
+	}
+	t, err := gotime.Parse(gotime.RFC3339, r.NotOnOrAfter)
+	if err != nil {
+		return true
+	}
+	return !now.Add(-allowedClockSkew).Before(t)
+}
+
+var redirectSignatureAlgorithms = map[string]crypto.Hash{
+	xmldsig.AlgorithmRSASHA256: crypto.SHA256,
+	xmldsig.AlgorithmRSASHA512: crypto.SHA512,
+}
+
+// verifyRedirectSignature verifies the signature of SAMLRequest sent with
+// HTTP-Redirect binding. The signature is computed over the query parameters
+// as they are encoded in the URL, so the raw query is used.
+func verifyRedirectSignature(rawQuery string, certs []*x509.Certificate) error {
+	params := map[string]string{}
+	for _, pair := range strings.Split(rawQuery, "&") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		if _, ok := params[kv[0]]; ok {
+			return errors.Newf("%w: duplicated parameter", xmldsig.ErrInvalidSignature)
+		}
+		params[kv[0]] = kv[1]
+	}
+
+	rawSignature, ok := params["Signature"]
+	if !ok {
+		return xmldsig.ErrNotSigned
+	}
+	sigAlg, err := url.QueryUnescape(params["SigAlg"])
+	if err != nil {
+		return errors.Newf("%w: invalid SigAlg", xmldsig.ErrInvalidSignature)
+	}
+	hash, ok := redirectSignatureAlgorithms[sigAlg]
+	if !ok {
+		return errors.Newf("%w: unsupported signature algorithm", xmldsig.ErrInvalidSignature)
+	}
+	encodedSignature, err := url.QueryUnescape(rawSignature)
+	if err != nil {
+		return errors.Newf("%w: invalid signature encoding", xmldsig.ErrInvalidSignature)
+	}
+	signature, err := base64.StdEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return errors.Newf("%w: invalid signature encoding", xmldsig.ErrInvalidSignature)
+	}
+
+	signed := "SAMLRequest=" + params["SAMLRequest"]
+	if relayState, ok := params["RelayState"]; ok {
+		signed += "&RelayState=" + relayState
+	}
+	signed += "&SigAlg=" + params["SigAlg"]
+
+	h := hash.New()
+	_, _ = h.Write([]byte(signed))
+	hashed := h.Sum(nil)
+	for _, cert := range certs {
+		key, ok := cert.PublicKey.(*rsa.PublicKey)
+		if !ok {
+			continue
+		}
+		if rsa.VerifyPKCS1v15(key, hash, hashed, signature) == nil {
+			return nil
+		}
+	}
+	return xmldsig.ErrInvalidSignature
+}
+
+// signRedirectQuery returns the query string of SAMLRequest signed with
+// HTTP-Redirect binding using RSA-SHA256.
+func signRedirectQuery(samlRequest string, relayState string, key *rsa.PrivateKey) (string, error) {
+	query := "SAMLRequest=" + url.QueryEscape(samlRequest)
+	if relayState != "" {
+		query += "&RelayState=" + url.QueryEscape(relayState)
+	}
+	query += "&SigAlg=" + url.QueryEscape(xmldsig.AlgorithmRSASHA256)
+
+	h := crypto.SHA256.New()
+	_, _ = h.Write([]byte(query))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, h.Sum(nil))
+	if err != nil {
+		return "", err
+	}
+	query += "&Signature=" + url.QueryEscape(base64.StdEncoding.EncodeToString(signature))
+	return query, nil
+}
+
+type statusXML struct {
+	StatusCode statusCodeXML `xml:"samlp:StatusCode"`
+}
+
+type statusCodeXML struct {
+	Value      string         `xml:"Value,attr"`
+	StatusCode *statusCodeXML `xml:"samlp:StatusCode"`
+}
+
+func newStatus(code string, subCode string) statusXML {
+	s := statusXML{StatusCode: statusCodeXML{Value: code}}
+	if subCode != "" {
+		s.StatusCode.StatusCode = &statusCodeXML{Value: subCode}
+	}
+	return s
+}
+
+type responseXML struct {
+	XMLName            xml.Name      `xml:"samlp:Response"`
+	ProtocolNamespace  string        `xml:"xmlns:samlp,attr"`
+	AssertionNamespace string        `xml:"xmlns:saml,attr"`
+	ID                 string        `xml:"ID,attr"`
+	Version            string        `xml:"Version,attr"`
+	IssueInstant       string        `xml:"IssueInstant,attr"`
+	Destination        string        `xml:"Destination,attr"`
+	InResponseTo       string        `xml:"InResponseTo,attr,omitempty"`
+	Issuer             string        `xml:"saml:Issuer"`
+	Status             statusXML     `xml:"samlp:Status"`
+	Assertion          *assertionXML `xml:"saml:Assertion"`
+}
+
+type assertionXML struct {
+	ID                 string                 `xml:"ID,attr"`
+	Version            string                 `xml:"Version,attr"`
+	IssueInstant       string                 `xml:"IssueInstant,attr"`
+	Issuer             string                 `xml:"saml:Issuer"`
+	Subject            subjectXML             `xml:"saml:Subject"`
+	Conditions         conditionsXML          `xml:"saml:Conditions"`
+	AuthnStatement     authnStatementXML      `xml:"saml:AuthnStatement"`
+	AttributeStatement *attributeStatementXML `xml:"saml:AttributeStatement"`
+}
+
+type subjectXML struct {
+	NameID              nameIDXML              `xml:"saml:NameID"`
+	SubjectConfirmation subjectConfirmationXML `xml:"saml:SubjectConfirmation"`
+}
+
+type nameIDXML struct {
+	Format string `xml:"Format,attr"`
+	Value  string `xml:",chardata"`
+}
+
+type subjectConfirmationXML struct {
+	Method string                     `xml:"Method,attr"`
+	Data   subjectConfirmationDataXML `xml:"saml:SubjectConfirmationData"`
+}
+
+type subjectConfirmationDataXML struct {
+	InResponseTo string `xml:"InResponseTo,attr,omitempty"`
+	Recipient    string `xml:"Recipient,attr"`
+	NotOnOrAfter string `xml:"NotOnOrAfter,attr"`
+}
+
+type conditionsXML struct {
+	NotBefore           string                 `xml:"NotBefore,attr"`
+	NotOnOrAfter        string                 `xml:"NotOnOrAfter,attr"`
+	AudienceRestriction audienceRestrictionXML `xml:"saml:AudienceRestriction"`
+}
+
+type audienceRestrictionXML struct {
+	Audience string `xml:"saml:Audience"`
+}
+
+type authnStatementXML struct {
+	AuthnInstant string          `xml:"AuthnInstant,attr"`
+	SessionIndex string          `xml:"SessionIndex,attr,omitempty"`
+	AuthnContext authnContextXML `xml:"saml:AuthnContext"`
+}
+
+type authnContextXML struct {
+	AuthnContextClassRef string `xml:"saml:AuthnContextClassRef"`
+}
+
+type attributeStatementXML struct {
+	Attributes []attributeXML `xml:"saml:Attribute"`
+}
+
+type attributeXML struct {
+	Name       string   `xml:"Name,attr"`
+	NameFormat string   `xml:"NameFormat,attr"`
+	Values     []string `xml:"saml:AttributeValue"`
+}
+
+type logoutResponseXML struct {
+	XMLName            xml.Name  `xml:"samlp:LogoutResponse"`
+	ProtocolNamespace  string    `xml:"xmlns:samlp,attr"`
+	AssertionNamespace string    `xml:"xmlns:saml,attr"`
+	ID                 string    `xml:"ID,attr"`
+	Version            string    `xml:"Version,attr"`
+	IssueInstant       string    `xml:"IssueInstant,attr"`
+	Destination        string    `xml:"Destination,attr"`
+	InResponseTo       string    `xml:"InResponseTo,attr"`
+	Issuer             string    `xml:"saml:Issuer"`
+	Status             statusXML `xml:"samlp:Status"`
+}
+
+// signMessage marshals the response, and signs its assertion, if any, and
+// the response itself. The signed response is encoded with HTTP-POST
+// binding.
+func signMessage(v interface{}, key *rsa.PrivateKey, cert *x509.Certificate) (string, error) {
+	data, err := xml.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	root, err := xmldsig.Parse(data)
+	if err != nil {
+		return "", err
+	}
+
+	if assertion := xmldsig.FindChild(root, assertionNamespace, "Assertion"); assertion != nil {
+		issuer := xmldsig.FindChild(assertion, assertionNamespace, "Issuer")
+		if err := xmldsig.Sign(assertion, key, cert, issuer); err != nil {
+			return "", err
+		}
+	}
+	issuer := xmldsig.FindChild(root, assertionNamespace, "Issuer")
+	if err := xmldsig.Sign(root, key, cert, issuer); err != nil {
+		return "", err
+	}
+
+	signed, err := xmldsig.Serialize(root)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(signed), nil
+}
@@ -0,0 +1,104 @@
+package samlidp
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+)
+
+const formPostTemplateString = `<!DOCTYPE html>
+<html>
+<head>
+<title>Submit this form</title>
+</head>
+<body>
+<noscript>Please submit this form to proceed</noscript>
+<form method="post" action="{{ .url }}">
+{{- range $name, $value := .values }}
+<input type="hidden" name="{{ $name }}" value="{{ $value }}">
+{{- end }}
+<button type="submit" name="" value="">Submit</button>
+</form>
+<script>
+document.forms[0].submit();
+</script>
+</body>
+</html>
+`
+
+var formPostTemplate *template.Template
+
+func init() {
+	var err error
+	formPostTemplate, err = template.New("saml_form_post").Parse(formPostTemplateString)
+	if err != nil {
+		panic(fmt.Errorf("samlidp: invalid form_post template: %w", err))
+	}
+}
+
+type Result interface {
+	WriteResponse(rw http.ResponseWriter, r *http.Request)
+	IsInternalError() bool
+}
+
+type (
+	// resultPost sends the message to service provider with HTTP-POST
+	// binding.
+	resultPost struct {
+		URL    string
+		Values map[string]string
+	}
+	resultRedirect struct {
+		URI string
+	}
+	// resultError is returned when the request cannot be responded to
+	// service provider.
+	resultError struct {
+		Message       string
+		InternalError bool
+	}
+)
+
+func (a resultPost) WriteResponse(rw http.ResponseWriter, r *http.Request) {
+	rw.Header().Set("Content-Type", "text/html; charset=utf-8")
+	err := formPostTemplate.Execute(rw, map[string]interface{}{
+		"url":    a.URL,
+		"values": a.Values,
+	})
+	if err != nil {
+		panic(fmt.Errorf("samlidp: failed to execute form_post template: %w", err))
+	}
+}
+
+func (a resultPost) IsInternalError() bool {
+	return false
+}
+
+func (a resultRedirect) WriteResponse(rw http.ResponseWriter, r *http.Request) {
+	http.Redirect(rw, r, a.URI, http.StatusFound)
+}
+
+func (a resultRedirect) IsInternalError() bool {
+	return false
+}
+
+func (a resultError) WriteResponse(rw http.ResponseWriter, r *http.Request) {
+	if a.InternalError {
+		http.Error(rw, "Internal Server Error", http.StatusInternalServerError)
+	} else {
+		http.Error(rw, "Invalid SAML request: "+a.Message, http.StatusBadRequest)
+	}
+}
+
+func (a resultError) IsInternalError() bool {
+	return a.InternalError
+}
+
+// postMessage returns the result sending the SAMLResponse with relay state.
+func postMessage(url string, samlResponse string, relayState string) resultPost {
+	values := map[string]string{"SAMLResponse": samlResponse}
+	if relayState != "" {
+		values["RelayState"] = relayState
+	}
+	return resultPost{URL: url, Values: values}
+}
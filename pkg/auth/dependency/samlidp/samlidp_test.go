@@ -0,0 +1,388 @@
+package samlidp
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+
+	"github.com/sirupsen/logrus"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oidc"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/session"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/webapp"
+	"github.com/skygeario/skygear-server/pkg/core/authn"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	coretime "github.com/skygeario/skygear-server/pkg/core/time"
+	"github.com/skygeario/skygear-server/pkg/core/xmldsig"
+)
+
+type mockEndpointsProvider struct{}
+
+func (mockEndpointsProvider) SAMLMetadataEndpointURI() *url.URL {
+	return &url.URL{Scheme: "https", Host: "auth", Path: "/saml2/metadata"}
+}
+
+func (mockEndpointsProvider) SAMLSSOEndpointURI() *url.URL {
+	return &url.URL{Scheme: "https", Host: "auth", Path: "/saml2/sso"}
+}
+
+func (mockEndpointsProvider) SAMLSLOEndpointURI() *url.URL {
+	return &url.URL{Scheme: "https", Host: "auth", Path: "/saml2/slo"}
+}
+
+func (mockEndpointsProvider) AuthenticateURI(options webapp.AuthenticateURLOptions) (*url.URL, error) {
+	q := url.Values{"redirect_uri": {options.RedirectURI}, "prompt": {options.Prompt}}
+	return &url.URL{Scheme: "https", Host: "auth", Path: "/login", RawQuery: q.Encode()}, nil
+}
+
+type mockUserClaimsLoader struct{}
+
+func (mockUserClaimsLoader) LoadUserClaims(s auth.AuthSession) (*oidc.UserClaims, error) {
+	return &oidc.UserClaims{Email: "user@example.com"}, nil
+}
+
+type mockSessionManager struct {
+	Sessions   map[string]auth.AuthSession
+	LoggedOut  []string
+	RevokedIDs []string
+}
+
+func (m *mockSessionManager) Logout(s auth.AuthSession, rw http.ResponseWriter) error {
+	m.LoggedOut = append(m.LoggedOut, s.SessionID())
+	return nil
+}
+
+func (m *mockSessionManager) Revoke(s auth.AuthSession) error {
+	m.RevokedIDs = append(m.RevokedIDs, s.SessionID())
+	return nil
+}
+
+func (m *mockSessionManager) GetByUser(userID string, id string) (auth.AuthSession, error) {
+	s, ok := m.Sessions[id]
+	if !ok || s.AuthnAttrs().UserID != userID {
+		return nil, auth.ErrSessionNotFound
+	}
+	return s, nil
+}
+
+func newTestKeyPair(cn string) (*rsa.PrivateKey, *x509.Certificate) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	So(err, ShouldBeNil)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:     time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	So(err, ShouldBeNil)
+	cert, err := x509.ParseCertificate(der)
+	So(err, ShouldBeNil)
+	return key, cert
+}
+
+func encodeCertificate(cert *x509.Certificate) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+}
+
+func writeResult(result Result) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("GET", "/", nil)
+	resp := httptest.NewRecorder()
+	result.WriteResponse(resp, req)
+	return resp
+}
+
+// decodePostedResponse returns the SAMLResponse posted to service provider.
+func decodePostedResponse(result Result) *etree.Element {
+	post, ok := result.(resultPost)
+	So(ok, ShouldBeTrue)
+	data, err := base64.StdEncoding.DecodeString(post.Values["SAMLResponse"])
+	So(err, ShouldBeNil)
+	root, err := xmldsig.Parse(data)
+	So(err, ShouldBeNil)
+	return root
+}
+
+func statusCodeOf(response *etree.Element) []string {
+	var codes []string
+	code := xmldsig.FindChild(xmldsig.FindChild(response, protocolNamespace, "Status"), protocolNamespace, "StatusCode")
+	for code != nil {
+		value, _ := xmldsig.Attr(code, "Value")
+		codes = append(codes, value)
+		code = xmldsig.FindChild(code, protocolNamespace, "StatusCode")
+	}
+	return codes
+}
+
+func TestSAMLIdP(t *testing.T) {
+	Convey("SAML IdP", t, func() {
+		idpKey, idpCert := newTestKeyPair("auth")
+		spKey, spCert := newTestKeyPair("wiki")
+		cfg := &config.SAMLIdPConfiguration{
+			Certificate: encodeCertificate(idpCert),
+			PrivateKey:  string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(idpKey)})),
+			ServiceProviders: []config.SAMLServiceProviderConfiguration{
+				{
+					EntityID:    "https://wiki.example.com",
+					ACSURL:      "https://wiki.example.com/acs",
+					SLOURL:      "https://wiki.example.com/slo",
+					Certificate: encodeCertificate(spCert),
+				},
+			},
+		}
+		mockTime := &coretime.MockProvider{TimeNowUTC: time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)}
+		sess := &session.IDPSession{
+			ID:        "session-id",
+			Attrs:     authn.Attrs{UserID: "user-id"},
+			CreatedAt: time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC),
+		}
+		logger := logrus.NewEntry(logrus.New())
+
+		Convey("SSO", func() {
+			h := &SSOHandler{
+				Context:         context.Background(),
+				Config:          cfg,
+				Logger:          logger,
+				Endpoints:       mockEndpointsProvider{},
+				AuthenticateURL: mockEndpointsProvider{},
+				UserClaims:      mockUserClaimsLoader{},
+				Time:            mockTime,
+			}
+			request := func(attrs string) Message {
+				samlRequest, err := encodeRedirectMessage([]byte(
+					`<samlp:AuthnRequest xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="request-id" Version="2.0" IssueInstant="2020-02-01T00:00:00Z" ` + attrs + `>` +
+						`<saml:Issuer>https://wiki.example.com</saml:Issuer>` +
+						`</samlp:AuthnRequest>`))
+				So(err, ShouldBeNil)
+				return Message{
+					Binding:     BindingHTTPRedirect,
+					SAMLRequest: samlRequest,
+					RelayState:  "relay-state",
+				}
+			}
+
+			Convey("should reject unknown service provider", func() {
+				cfg.ServiceProviders[0].EntityID = "https://other.example.com"
+				resp := writeResult(h.Handle(request("")))
+				So(resp.Code, ShouldEqual, 400)
+				So(resp.Body.String(), ShouldContainSubstring, "unknown service provider")
+			})
+
+			Convey("should reject unregistered ACS URL", func() {
+				resp := writeResult(h.Handle(request(`AssertionConsumerServiceURL="https://evil.example.com/acs"`)))
+				So(resp.Code, ShouldEqual, 400)
+				So(resp.Body.String(), ShouldContainSubstring, "unregistered assertion consumer service URL")
+			})
+
+			Convey("should request authentication and retry", func() {
+				result := h.Handle(request(`ForceAuthn="true"`))
+				redirect, ok := result.(resultRedirect)
+				So(ok, ShouldBeTrue)
+
+				authnURI, _ := url.Parse(redirect.URI)
+				So(authnURI.Path, ShouldEqual, "/login")
+				So(authnURI.Query().Get("prompt"), ShouldEqual, "login")
+
+				retryURI, _ := url.Parse(authnURI.Query().Get("redirect_uri"))
+				So(retryURI.Path, ShouldEqual, "/saml2/sso")
+				So(retryURI.Query().Get("RelayState"), ShouldEqual, "relay-state")
+				root, err := decodeMessage(BindingHTTPRedirect, retryURI.Query().Get("SAMLRequest"))
+				So(err, ShouldBeNil)
+				retried, err := parseAuthnRequest(root)
+				So(err, ShouldBeNil)
+				So(retried.ID, ShouldEqual, "request-id")
+				So(retried.Issuer, ShouldEqual, "https://wiki.example.com")
+				So(retried.ForceAuthn, ShouldBeFalse)
+			})
+
+			Convey("should verify signature of request if wanted", func() {
+				cfg.ServiceProviders[0].WantAuthnRequestsSigned = true
+				signed := func(m Message, key *rsa.PrivateKey) Message {
+					rawQuery, err := signRedirectQuery(m.SAMLRequest, m.RelayState, key)
+					So(err, ShouldBeNil)
+					m.RawQuery = rawQuery
+					return m
+				}
+
+				resp := writeResult(h.Handle(request("")))
+				So(resp.Code, ShouldEqual, 400)
+				So(resp.Body.String(), ShouldContainSubstring, "invalid signature")
+
+				otherKey, _ := newTestKeyPair("other")
+				resp = writeResult(h.Handle(signed(request(""), otherKey)))
+				So(resp.Code, ShouldEqual, 400)
+				So(resp.Body.String(), ShouldContainSubstring, "invalid signature")
+
+				result := h.Handle(signed(request(`ForceAuthn="true"`), spKey))
+				redirect, ok := result.(resultRedirect)
+				So(ok, ShouldBeTrue)
+
+				// The re-encoded request is signed by the IdP.
+				authnURI, _ := url.Parse(redirect.URI)
+				retryURI, _ := url.Parse(authnURI.Query().Get("redirect_uri"))
+				So(verifyRedirectSignature(retryURI.RawQuery, []*x509.Certificate{idpCert}), ShouldBeNil)
+
+				h.Context = authn.WithAuthn(context.Background(), sess, &authn.UserInfo{ID: "user-id"})
+				result = h.Handle(Message{
+					Binding:     BindingHTTPRedirect,
+					SAMLRequest: retryURI.Query().Get("SAMLRequest"),
+					RelayState:  retryURI.Query().Get("RelayState"),
+					RawQuery:    retryURI.RawQuery,
+				})
+				response := decodePostedResponse(result)
+				So(statusCodeOf(response), ShouldResemble, []string{statusSuccess})
+			})
+
+			Convey("should respond NoPassive to passive request", func() {
+				response := decodePostedResponse(h.Handle(request(`IsPassive="true"`)))
+				So(statusCodeOf(response), ShouldResemble, []string{statusResponder, statusNoPassive})
+			})
+
+			Convey("should respond signed assertion", func() {
+				h.Context = authn.WithAuthn(context.Background(), sess, &authn.UserInfo{ID: "user-id"})
+				result := h.Handle(request(`AssertionConsumerServiceURL="https://wiki.example.com/acs"`))
+				post := result.(resultPost)
+				So(post.URL, ShouldEqual, "https://wiki.example.com/acs")
+				So(post.Values["RelayState"], ShouldEqual, "relay-state")
+
+				response := decodePostedResponse(result)
+				_, err := xmldsig.Verify(response, []*x509.Certificate{idpCert})
+				So(err, ShouldBeNil)
+				So(statusCodeOf(response), ShouldResemble, []string{statusSuccess})
+				inResponseTo, _ := xmldsig.Attr(response, "InResponseTo")
+				So(inResponseTo, ShouldEqual, "request-id")
+
+				assertion := xmldsig.FindChild(response, assertionNamespace, "Assertion")
+				assertion, err = xmldsig.Verify(assertion, []*x509.Certificate{idpCert})
+				So(err, ShouldBeNil)
+				So(childText(assertion, assertionNamespace, "Issuer"), ShouldEqual, "https://auth/saml2/metadata")
+				subject := xmldsig.FindChild(assertion, assertionNamespace, "Subject")
+				So(childText(subject, assertionNamespace, "NameID"), ShouldEqual, "user-id")
+				audience := xmldsig.FindChild(xmldsig.FindChild(assertion, assertionNamespace, "Conditions"),
+					assertionNamespace, "AudienceRestriction")
+				So(childText(audience, assertionNamespace, "Audience"), ShouldEqual, "https://wiki.example.com")
+				sessionIndex, _ := xmldsig.Attr(xmldsig.FindChild(assertion, assertionNamespace, "AuthnStatement"), "SessionIndex")
+				So(sessionIndex, ShouldEqual, "session-id")
+				attribute := xmldsig.FindChild(xmldsig.FindChild(assertion, assertionNamespace, "AttributeStatement"),
+					assertionNamespace, "Attribute")
+				name, _ := xmldsig.Attr(attribute, "Name")
+				So(name, ShouldEqual, "email")
+				So(childText(attribute, assertionNamespace, "AttributeValue"), ShouldEqual, "user@example.com")
+			})
+		})
+
+		Convey("SLO", func() {
+			sessions := &mockSessionManager{Sessions: map[string]auth.AuthSession{
+				"session-id": sess,
+				"other-session-id": &session.IDPSession{
+					ID:    "other-session-id",
+					Attrs: authn.Attrs{UserID: "user-id"},
+				},
+			}}
+			h := &SLOHandler{
+				Context:   authn.WithAuthn(context.Background(), sess, &authn.UserInfo{ID: "user-id"}),
+				Config:    cfg,
+				Logger:    logger,
+				Endpoints: mockEndpointsProvider{},
+				Sessions:  sessions,
+				Time:      mockTime,
+			}
+			request := func(sessionIndex string, sign bool) Message {
+				samlRequest, err := encodeRedirectMessage([]byte(
+					`<samlp:LogoutRequest xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="logout-id" Version="2.0" IssueInstant="2020-02-01T00:00:00Z" Destination="https://auth/saml2/slo">` +
+						`<saml:Issuer>https://wiki.example.com</saml:Issuer>` +
+						`<saml:NameID>user-id</saml:NameID>` +
+						`<samlp:SessionIndex>` + sessionIndex + `</samlp:SessionIndex>` +
+						`</samlp:LogoutRequest>`))
+				So(err, ShouldBeNil)
+				rawQuery := "SAMLRequest=" + url.QueryEscape(samlRequest) +
+					"&RelayState=relay-state" +
+					"&SigAlg=" + url.QueryEscape(xmldsig.AlgorithmRSASHA256)
+				if sign {
+					hashed := crypto.SHA256.New()
+					_, _ = hashed.Write([]byte(rawQuery))
+					signature, err := rsa.SignPKCS1v15(rand.Reader, spKey, crypto.SHA256, hashed.Sum(nil))
+					So(err, ShouldBeNil)
+					rawQuery += "&Signature=" + url.QueryEscape(base64.StdEncoding.EncodeToString(signature))
+				}
+				return Message{
+					Binding:     BindingHTTPRedirect,
+					SAMLRequest: samlRequest,
+					RelayState:  "relay-state",
+					RawQuery:    rawQuery,
+				}
+			}
+
+			Convey("should logout current session", func() {
+				result := h.Handle(request("session-id", true), httptest.NewRecorder())
+				So(sessions.LoggedOut, ShouldResemble, []string{"session-id"})
+
+				post := result.(resultPost)
+				So(post.URL, ShouldEqual, "https://wiki.example.com/slo")
+				So(post.Values["RelayState"], ShouldEqual, "relay-state")
+				response := decodePostedResponse(result)
+				So(xmldsig.Is(response, protocolNamespace, "LogoutResponse"), ShouldBeTrue)
+				_, err := xmldsig.Verify(response, []*x509.Certificate{idpCert})
+				So(err, ShouldBeNil)
+				So(statusCodeOf(response), ShouldResemble, []string{statusSuccess})
+				inResponseTo, _ := xmldsig.Attr(response, "InResponseTo")
+				So(inResponseTo, ShouldEqual, "logout-id")
+			})
+
+			Convey("should revoke other session of session index", func() {
+				h.Handle(request("other-session-id", true), httptest.NewRecorder())
+				So(sessions.LoggedOut, ShouldBeEmpty)
+				So(sessions.RevokedIDs, ShouldResemble, []string{"other-session-id"})
+			})
+
+			Convey("should reject unsigned request", func() {
+				resp := writeResult(h.Handle(request("session-id", false), httptest.NewRecorder()))
+				So(resp.Code, ShouldEqual, 400)
+				So(resp.Body.String(), ShouldContainSubstring, "invalid signature")
+				So(sessions.LoggedOut, ShouldBeEmpty)
+			})
+
+			Convey("should reject request signed by other key", func() {
+				_, otherCert := newTestKeyPair("other")
+				cfg.ServiceProviders[0].Certificate = encodeCertificate(otherCert)
+				resp := writeResult(h.Handle(request("session-id", true), httptest.NewRecorder()))
+				So(resp.Code, ShouldEqual, 400)
+				So(sessions.LoggedOut, ShouldBeEmpty)
+			})
+		})
+
+		Convey("should publish metadata", func() {
+			p := &MetadataProvider{Config: cfg, Endpoints: mockEndpointsProvider{}}
+			metadata, err := p.Metadata()
+			So(err, ShouldBeNil)
+			So(string(metadata), ShouldContainSubstring, `entityID="https://auth/saml2/metadata"`)
+			So(string(metadata), ShouldContainSubstring, `Location="https://auth/saml2/sso"`)
+			So(string(metadata), ShouldContainSubstring, base64.StdEncoding.EncodeToString(idpCert.Raw))
+			So(string(metadata), ShouldContainSubstring, `WantAuthnRequestsSigned="false"`)
+
+			cfg.ServiceProviders[0].WantAuthnRequestsSigned = true
+			metadata, err = p.Metadata()
+			So(err, ShouldBeNil)
+			So(string(metadata), ShouldContainSubstring, `WantAuthnRequestsSigned="true"`)
+
+			cfg.Certificate = ""
+			_, err = p.Metadata()
+			So(err, ShouldEqual, ErrNotConfigured)
+		})
+	})
+}
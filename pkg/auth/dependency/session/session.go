@@ -18,10 +18,26 @@ type IDPSession struct {
 	AccessInfo auth.AccessInfo `json:"access_info"`
 
 	TokenHash string `json:"token_hash"`
+
+	// ClientIDs are the clients issued tokens through the session, which
+	// are notified when the session is ended.
+	ClientIDs []string `json:"client_ids,omitempty"`
 }
 
 var _ auth.AuthSession = &IDPSession{}
 
+// AddClientID records the client issued tokens through the session. It
+// returns false if the client is already recorded.
+func (s *IDPSession) AddClientID(clientID string) bool {
+	for _, id := range s.ClientIDs {
+		if id == clientID {
+			return false
+		}
+	}
+	s.ClientIDs = append(s.ClientIDs, clientID)
+	return true
+}
+
 func (s *IDPSession) SessionID() string              { return s.ID }
 func (s *IDPSession) SessionType() authn.SessionType { return auth.SessionTypeIdentityProvider }
 
@@ -37,7 +53,8 @@ func (s *IDPSession) ToAPIModel() *model.Session {
 	ua := model.ParseUserAgent(s.AccessInfo.LastAccess.UserAgent)
 	ua.DeviceName = s.AccessInfo.LastAccess.Extra.DeviceName()
 	return &model.Session{
-		ID: s.ID,
+		ID:   s.ID,
+		Type: auth.SessionTypeIdentityProvider,
 
 		ACR:              s.Attrs.ACR,
 		AMR:              s.Attrs.AMR,
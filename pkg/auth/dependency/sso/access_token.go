@@ -94,8 +94,15 @@ func fetchAccessTokenResp(
 	v.Add("client_id", providerConfig.ClientID)
 	v.Add("client_secret", providerConfig.ClientSecret)
 
-	// nolint: gosec
-	resp, err := http.PostForm(accessTokenURL, v)
+	req, err := http.NewRequest(http.MethodPost, accessTokenURL, strings.NewReader(v.Encode()))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	// GitHub returns form-encoded token response unless JSON is accepted.
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
 	if resp != nil {
 		defer resp.Body.Close()
 	}
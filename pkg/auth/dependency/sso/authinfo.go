@@ -32,6 +32,12 @@ type OAuthAuthorizationResponse struct {
 	Scope string
 }
 
+// SAMLResponse is the response posted by SAML identity provider to ACS.
+type SAMLResponse struct {
+	SAMLResponse string
+	RelayState   string
+}
+
 type getAuthInfoRequest struct {
 	redirectURL     string
 	oauthConfig     *config.OAuthConfiguration
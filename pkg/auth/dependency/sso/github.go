@@ -0,0 +1,81 @@
+package sso
+
+import (
+	"net/url"
+
+	"github.com/skygeario/skygear-server/pkg/core/config"
+)
+
+const (
+	githubAuthorizationURL string = "https://github.com/login/oauth/authorize"
+	// nolint: gosec
+	githubTokenURL    string = "https://github.com/login/oauth/access_token"
+	githubUserInfoURL string = "https://api.github.com/user"
+	githubEmailsURL   string = "https://api.github.com/user/emails"
+)
+
+type GitHubImpl struct {
+	URLPrefix       *url.URL
+	RedirectURLFunc RedirectURLFunc
+	OAuthConfig     *config.OAuthConfiguration
+	ProviderConfig  config.OAuthProviderConfiguration
+	UserInfoDecoder UserInfoDecoder
+}
+
+func (f *GitHubImpl) Type() config.OAuthProviderType {
+	return config.OAuthProviderTypeGitHub
+}
+
+func (f *GitHubImpl) GetAuthURL(state State, encodedState string) (string, error) {
+	p := authURLParams{
+		oauthConfig:    f.OAuthConfig,
+		redirectURI:    f.RedirectURLFunc(f.URLPrefix, f.ProviderConfig),
+		providerConfig: f.ProviderConfig,
+		encodedState:   encodedState,
+		baseURL:        githubAuthorizationURL,
+	}
+	return authURL(p)
+}
+
+func (f *GitHubImpl) GetAuthInfo(r OAuthAuthorizationResponse, state State) (authInfo AuthInfo, err error) {
+	return f.NonOpenIDConnectGetAuthInfo(r, state)
+}
+
+func (f *GitHubImpl) NonOpenIDConnectGetAuthInfo(r OAuthAuthorizationResponse, state State) (authInfo AuthInfo, err error) {
+	accessTokenResp, err := fetchAccessTokenResp(
+		r.Code,
+		githubTokenURL,
+		f.RedirectURLFunc(f.URLPrefix, f.ProviderConfig),
+		f.OAuthConfig,
+		f.ProviderConfig,
+	)
+	if err != nil {
+		return
+	}
+
+	return f.ExternalAccessTokenGetAuthInfo(accessTokenResp)
+}
+
+func (f *GitHubImpl) ExternalAccessTokenGetAuthInfo(accessTokenResp AccessTokenResp) (authInfo AuthInfo, err error) {
+	combinedResponse, err := fetchUserProfileWithEmails(accessTokenResp, githubUserInfoURL, githubEmailsURL)
+	if err != nil {
+		return
+	}
+
+	providerUserInfo, err := f.UserInfoDecoder.DecodeUserInfo(f.ProviderConfig.Type, combinedResponse)
+	if err != nil {
+		return
+	}
+
+	authInfo.ProviderConfig = f.ProviderConfig
+	authInfo.ProviderAccessTokenResp = accessTokenResp
+	authInfo.ProviderRawProfile = combinedResponse
+	authInfo.ProviderUserInfo = *providerUserInfo
+	return
+}
+
+var (
+	_ OAuthProvider                   = &GitHubImpl{}
+	_ NonOpenIDConnectProvider        = &GitHubImpl{}
+	_ ExternalAccessTokenFlowProvider = &GitHubImpl{}
+)
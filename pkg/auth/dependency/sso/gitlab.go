@@ -0,0 +1,92 @@
+package sso
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/skygeario/skygear-server/pkg/core/config"
+)
+
+// GitLab endpoints are relative to the base URL of the instance, so that
+// self-hosted instances are supported.
+const (
+	gitlabAuthorizationPath string = "/oauth/authorize"
+	// nolint: gosec
+	gitlabTokenPath    string = "/oauth/token"
+	gitlabUserInfoPath string = "/api/v4/user"
+	gitlabEmailsPath   string = "/api/v4/user/emails"
+)
+
+type GitLabImpl struct {
+	URLPrefix       *url.URL
+	RedirectURLFunc RedirectURLFunc
+	OAuthConfig     *config.OAuthConfiguration
+	ProviderConfig  config.OAuthProviderConfiguration
+	UserInfoDecoder UserInfoDecoder
+}
+
+func (f *GitLabImpl) endpoint(path string) string {
+	return strings.TrimSuffix(f.ProviderConfig.BaseURL, "/") + path
+}
+
+func (f *GitLabImpl) Type() config.OAuthProviderType {
+	return config.OAuthProviderTypeGitLab
+}
+
+func (f *GitLabImpl) GetAuthURL(state State, encodedState string) (string, error) {
+	p := authURLParams{
+		oauthConfig:    f.OAuthConfig,
+		redirectURI:    f.RedirectURLFunc(f.URLPrefix, f.ProviderConfig),
+		providerConfig: f.ProviderConfig,
+		encodedState:   encodedState,
+		baseURL:        f.endpoint(gitlabAuthorizationPath),
+	}
+	return authURL(p)
+}
+
+func (f *GitLabImpl) GetAuthInfo(r OAuthAuthorizationResponse, state State) (authInfo AuthInfo, err error) {
+	return f.NonOpenIDConnectGetAuthInfo(r, state)
+}
+
+func (f *GitLabImpl) NonOpenIDConnectGetAuthInfo(r OAuthAuthorizationResponse, state State) (authInfo AuthInfo, err error) {
+	accessTokenResp, err := fetchAccessTokenResp(
+		r.Code,
+		f.endpoint(gitlabTokenPath),
+		f.RedirectURLFunc(f.URLPrefix, f.ProviderConfig),
+		f.OAuthConfig,
+		f.ProviderConfig,
+	)
+	if err != nil {
+		return
+	}
+
+	return f.ExternalAccessTokenGetAuthInfo(accessTokenResp)
+}
+
+func (f *GitLabImpl) ExternalAccessTokenGetAuthInfo(accessTokenResp AccessTokenResp) (authInfo AuthInfo, err error) {
+	combinedResponse, err := fetchUserProfileWithEmails(
+		accessTokenResp,
+		f.endpoint(gitlabUserInfoPath),
+		f.endpoint(gitlabEmailsPath),
+	)
+	if err != nil {
+		return
+	}
+
+	providerUserInfo, err := f.UserInfoDecoder.DecodeUserInfo(f.ProviderConfig.Type, combinedResponse)
+	if err != nil {
+		return
+	}
+
+	authInfo.ProviderConfig = f.ProviderConfig
+	authInfo.ProviderAccessTokenResp = accessTokenResp
+	authInfo.ProviderRawProfile = combinedResponse
+	authInfo.ProviderUserInfo = *providerUserInfo
+	return
+}
+
+var (
+	_ OAuthProvider                   = &GitLabImpl{}
+	_ NonOpenIDConnectProvider        = &GitLabImpl{}
+	_ ExternalAccessTokenFlowProvider = &GitLabImpl{}
+)
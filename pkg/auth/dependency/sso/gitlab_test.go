@@ -0,0 +1,101 @@
+package sso
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/loginid"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+)
+
+func TestGitLabImpl(t *testing.T) {
+	Convey("GitLabImpl", t, func() {
+		var tokenRequest url.Values
+		var authorization string
+		mux := http.NewServeMux()
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		mux.HandleFunc("/oauth/token", func(rw http.ResponseWriter, r *http.Request) {
+			_ = r.ParseForm()
+			tokenRequest = r.PostForm
+			rw.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(rw).Encode(map[string]interface{}{
+				"token_type":   "bearer",
+				"access_token": "access-token",
+			})
+		})
+		mux.HandleFunc("/api/v4/user", func(rw http.ResponseWriter, r *http.Request) {
+			authorization = r.Header.Get("Authorization")
+			_ = json.NewEncoder(rw).Encode(map[string]interface{}{
+				"id":           42,
+				"email":        "user@example.com",
+				"confirmed_at": "2020-01-01T00:00:00Z",
+			})
+		})
+		mux.HandleFunc("/api/v4/user/emails", func(rw http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(rw).Encode([]interface{}{})
+		})
+
+		f := false
+		provider := &GitLabImpl{
+			URLPrefix: &url.URL{Scheme: "https", Host: "auth"},
+			RedirectURLFunc: func(urlPrefix *url.URL, providerConfig config.OAuthProviderConfiguration) string {
+				return "https://auth/sso/oauth2/callback/" + providerConfig.ID
+			},
+			OAuthConfig: &config.OAuthConfiguration{},
+			ProviderConfig: config.OAuthProviderConfiguration{
+				ID:           "gitlab",
+				Type:         config.OAuthProviderTypeGitLab,
+				ClientID:     "client-id",
+				ClientSecret: "client-secret",
+				Scope:        "read_user",
+				BaseURL:      server.URL + "/",
+			},
+			UserInfoDecoder: NewUserInfoDecoder(&loginid.NormalizerFactory{
+				Types: &config.LoginIDTypesConfiguration{
+					Email: &config.LoginIDTypeEmailConfiguration{
+						CaseSensitive: &f,
+						BlockPlusSign: &f,
+						IgnoreDotSign: &f,
+					},
+				},
+			}),
+		}
+
+		Convey("should build auth URL of the instance", func() {
+			authURL, err := provider.GetAuthURL(State{}, "encoded-state")
+			So(err, ShouldBeNil)
+			u, err := url.Parse(authURL)
+			So(err, ShouldBeNil)
+			So(u.Host, ShouldEqual, server.Listener.Addr().String())
+			So(u.Path, ShouldEqual, "/oauth/authorize")
+			So(u.Query().Get("scope"), ShouldEqual, "read_user")
+			So(u.Query().Get("state"), ShouldEqual, "encoded-state")
+		})
+
+		Convey("should exchange code and read user info", func() {
+			authInfo, err := provider.GetAuthInfo(OAuthAuthorizationResponse{Code: "code"}, State{})
+			So(err, ShouldBeNil)
+			So(tokenRequest.Get("code"), ShouldEqual, "code")
+			So(tokenRequest.Get("client_secret"), ShouldEqual, "client-secret")
+			So(authorization, ShouldEqual, "Bearer access-token")
+			So(authInfo.ProviderUserInfo, ShouldResemble, ProviderUserInfo{
+				ID:    "42",
+				Email: "user@example.com",
+			})
+		})
+
+		Convey("should read user info with external access token", func() {
+			authInfo, err := provider.ExternalAccessTokenGetAuthInfo(NewBearerAccessTokenResp("cli-token"))
+			So(err, ShouldBeNil)
+			So(authorization, ShouldEqual, "Bearer cli-token")
+			So(authInfo.ProviderUserInfo.ID, ShouldEqual, "42")
+		})
+	})
+}
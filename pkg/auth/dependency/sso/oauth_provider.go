@@ -30,11 +30,18 @@ type ExternalAccessTokenFlowProvider interface {
 }
 
 // OpenIDConnectProvider are OpenID Connect provider.
-// They are Azure AD v2.
+// They are Azure AD v2, Apple and generic OpenID Connect providers.
 type OpenIDConnectProvider interface {
 	OpenIDConnectGetAuthInfo(r OAuthAuthorizationResponse, state State) (authInfo AuthInfo, err error)
 }
 
+// SAMLProvider are SAML 2.0 identity providers, which authenticate with
+// assertions posted to ACS instead of authorization code.
+type SAMLProvider interface {
+	SAMLGetAuthInfo(r SAMLResponse, state State) (authInfo AuthInfo, err error)
+	SPMetadata() ([]byte, error)
+}
+
 type OAuthProviderFactory struct {
 	urlPrefixProvider        urlprefix.Provider
 	redirectURIFunc          RedirectURLFunc
@@ -105,6 +112,38 @@ func (p *OAuthProviderFactory) NewOAuthProvider(id string) OAuthProvider {
 			TimeProvider:             p.timeProvider,
 			LoginIDNormalizerFactory: p.loginIDNormalizerFactory,
 		}
+	case config.OAuthProviderTypeOIDC:
+		return &OIDCImpl{
+			URLPrefix:                p.urlPrefixProvider.Value(),
+			RedirectURLFunc:          p.redirectURIFunc,
+			OAuthConfig:              p.tenantConfig.AppConfig.Identity.OAuth,
+			ProviderConfig:           providerConfig,
+			TimeProvider:             p.timeProvider,
+			LoginIDNormalizerFactory: p.loginIDNormalizerFactory,
+		}
+	case config.OAuthProviderTypeGitHub:
+		return &GitHubImpl{
+			URLPrefix:       p.urlPrefixProvider.Value(),
+			RedirectURLFunc: p.redirectURIFunc,
+			OAuthConfig:     p.tenantConfig.AppConfig.Identity.OAuth,
+			ProviderConfig:  providerConfig,
+			UserInfoDecoder: p.userInfoDecoder,
+		}
+	case config.OAuthProviderTypeGitLab:
+		return &GitLabImpl{
+			URLPrefix:       p.urlPrefixProvider.Value(),
+			RedirectURLFunc: p.redirectURIFunc,
+			OAuthConfig:     p.tenantConfig.AppConfig.Identity.OAuth,
+			ProviderConfig:  providerConfig,
+			UserInfoDecoder: p.userInfoDecoder,
+		}
+	case config.OAuthProviderTypeSAML:
+		return &SAMLImpl{
+			URLPrefix:                p.urlPrefixProvider.Value(),
+			ProviderConfig:           providerConfig,
+			TimeProvider:             p.timeProvider,
+			LoginIDNormalizerFactory: p.loginIDNormalizerFactory,
+		}
 	}
 	return nil
 }
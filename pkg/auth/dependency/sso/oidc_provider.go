@@ -0,0 +1,216 @@
+package sso
+
+import (
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/loginid"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+	coreTime "github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+// Default ID token claims of user info fields, if not mapped in provider
+// config.
+const (
+	OIDCClaimID    = "sub"
+	OIDCClaimEmail = "email"
+)
+
+const (
+	// oidcHTTPTimeout is the timeout of requests to the provider.
+	oidcHTTPTimeout = 10 * time.Second
+	// oidcDiscoveryDocumentTTL is how long the discovery document is cached.
+	oidcDiscoveryDocumentTTL = 1 * time.Hour
+)
+
+var oidcHTTPClient = &http.Client{Timeout: oidcHTTPTimeout}
+
+type oidcDiscoveryDocumentCacheEntry struct {
+	document *OIDCDiscoveryDocument
+	expireAt time.Time
+}
+
+// oidcDiscoveryDocumentCache caches discovery documents by discovery URL.
+// It is shared by all tenants since the documents are public.
+var oidcDiscoveryDocumentCache = struct {
+	mutex   sync.RWMutex
+	entries map[string]oidcDiscoveryDocumentCacheEntry
+}{entries: map[string]oidcDiscoveryDocumentCacheEntry{}}
+
+// OIDCImpl is a generic OpenID Connect provider, configured with the
+// discovery document of the provider.
+type OIDCImpl struct {
+	URLPrefix                *url.URL
+	RedirectURLFunc          RedirectURLFunc
+	OAuthConfig              *config.OAuthConfiguration
+	ProviderConfig           config.OAuthProviderConfiguration
+	TimeProvider             coreTime.Provider
+	LoginIDNormalizerFactory *loginid.NormalizerFactory
+}
+
+func (f *OIDCImpl) getOpenIDConfiguration() (*OIDCDiscoveryDocument, error) {
+	discoveryURL := f.ProviderConfig.DiscoveryURL
+	now := f.TimeProvider.NowUTC()
+
+	cache := &oidcDiscoveryDocumentCache
+	cache.mutex.RLock()
+	entry, ok := cache.entries[discoveryURL]
+	cache.mutex.RUnlock()
+	if ok && now.Before(entry.expireAt) {
+		return entry.document, nil
+	}
+
+	document, err := FetchOIDCDiscoveryDocument(oidcHTTPClient, discoveryURL)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateOIDCIssuer(discoveryURL, document.Issuer); err != nil {
+		return nil, err
+	}
+
+	cache.mutex.Lock()
+	cache.entries[discoveryURL] = oidcDiscoveryDocumentCacheEntry{
+		document: document,
+		expireAt: now.Add(oidcDiscoveryDocumentTTL),
+	}
+	cache.mutex.Unlock()
+
+	return document, nil
+}
+
+// validateOIDCIssuer ensures the issuer is at the origin of the discovery
+// URL, so that a discovery document cannot claim to be of other issuers.
+func validateOIDCIssuer(discoveryURL string, issuer string) error {
+	d, err := url.Parse(discoveryURL)
+	if err != nil {
+		return err
+	}
+	i, err := url.Parse(issuer)
+	if err != nil || issuer == "" {
+		return errors.New("invalid issuer in OIDC discovery document")
+	}
+	if i.Scheme != d.Scheme || i.Host != d.Host {
+		return errors.Newf("OIDC discovery document issuer %s does not match discovery URL", issuer)
+	}
+	return nil
+}
+
+// isEmailVerified reports whether the email_verified claim is true. Some
+// providers represent it as a string.
+func isEmailVerified(claims map[string]interface{}) bool {
+	switch v := claims["email_verified"].(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	}
+	return false
+}
+
+func (f *OIDCImpl) claimNames() (id string, email string) {
+	id, email = OIDCClaimID, OIDCClaimEmail
+	if c := f.ProviderConfig.Claims; c != nil {
+		if c.ID != "" {
+			id = c.ID
+		}
+		if c.Email != "" {
+			email = c.Email
+		}
+	}
+	return
+}
+
+func (f *OIDCImpl) Type() config.OAuthProviderType {
+	return config.OAuthProviderTypeOIDC
+}
+
+func (f *OIDCImpl) GetAuthURL(state State, encodedState string) (string, error) {
+	c, err := f.getOpenIDConfiguration()
+	if err != nil {
+		return "", err
+	}
+	return c.MakeOAuthURL(OIDCAuthParams{
+		ProviderConfig: f.ProviderConfig,
+		RedirectURI:    f.RedirectURLFunc(f.URLPrefix, f.ProviderConfig),
+		Nonce:          state.HashedNonce,
+		EncodedState:   encodedState,
+	}), nil
+}
+
+func (f *OIDCImpl) GetAuthInfo(r OAuthAuthorizationResponse, state State) (authInfo AuthInfo, err error) {
+	return f.OpenIDConnectGetAuthInfo(r, state)
+}
+
+func (f *OIDCImpl) OpenIDConnectGetAuthInfo(r OAuthAuthorizationResponse, state State) (authInfo AuthInfo, err error) {
+	c, err := f.getOpenIDConfiguration()
+	if err != nil {
+		err = NewSSOFailed(NetworkFailed, "failed to get OIDC discovery document")
+		return
+	}
+	// TODO(sso): Cache JWKs
+	keySet, err := c.FetchJWKs(oidcHTTPClient)
+	if err != nil {
+		err = NewSSOFailed(NetworkFailed, "failed to get OIDC JWKs")
+		return
+	}
+
+	var tokenResp AccessTokenResp
+	claims, err := c.ExchangeCode(
+		oidcHTTPClient,
+		r.Code,
+		keySet,
+		f.URLPrefix,
+		f.ProviderConfig.ClientID,
+		f.ProviderConfig.ClientSecret,
+		f.RedirectURLFunc(f.URLPrefix, f.ProviderConfig),
+		state.HashedNonce,
+		f.TimeProvider.NowUTC,
+		&tokenResp,
+	)
+	if err != nil {
+		return
+	}
+
+	if iss, _ := claims["iss"].(string); iss != c.Issuer {
+		err = NewSSOFailed(SSOUnauthorized, "invalid iss")
+		return
+	}
+
+	idClaim, emailClaim := f.claimNames()
+	id, ok := claims[idClaim].(string)
+	if !ok || id == "" {
+		err = NewSSOFailed(SSOUnauthorized, "no subject ID")
+		return
+	}
+
+	// Unverified email cannot be trusted to identify the user.
+	email, _ := claims[emailClaim].(string)
+	if !isEmailVerified(claims) {
+		email = ""
+	}
+	if email != "" {
+		normalizer := f.LoginIDNormalizerFactory.NormalizerWithLoginIDType(config.LoginIDKeyType("email"))
+		email, err = normalizer.Normalize(email)
+		if err != nil {
+			return
+		}
+	}
+
+	authInfo.ProviderConfig = f.ProviderConfig
+	authInfo.ProviderRawProfile = claims
+	authInfo.ProviderAccessTokenResp = tokenResp
+	authInfo.ProviderUserInfo = ProviderUserInfo{
+		ID:    id,
+		Email: email,
+	}
+
+	return
+}
+
+var (
+	_ OAuthProvider         = &OIDCImpl{}
+	_ OpenIDConnectProvider = &OIDCImpl{}
+)
@@ -0,0 +1,185 @@
+package sso
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/lestrrat-go/jwx/jwk"
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/loginid"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	coreTime "github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+func TestOIDCImpl(t *testing.T) {
+	Convey("OIDCImpl", t, func() {
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		So(err, ShouldBeNil)
+		publicKey, err := jwk.New(&privateKey.PublicKey)
+		So(err, ShouldBeNil)
+		_ = publicKey.Set(jwk.KeyIDKey, "key-1")
+
+		var idTokenClaims jwt.MapClaims
+		var tokenRequest url.Values
+		mux := http.NewServeMux()
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		issuer := server.URL
+		discoveryRequests := 0
+		mux.HandleFunc("/.well-known/openid-configuration", func(rw http.ResponseWriter, r *http.Request) {
+			discoveryRequests++
+			_ = json.NewEncoder(rw).Encode(map[string]interface{}{
+				"issuer":                 issuer,
+				"authorization_endpoint": server.URL + "/authorize",
+				"token_endpoint":         server.URL + "/token",
+				"jwks_uri":               server.URL + "/jwks",
+			})
+		})
+		mux.HandleFunc("/jwks", func(rw http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(rw).Encode(map[string]interface{}{
+				"keys": []interface{}{publicKey},
+			})
+		})
+		mux.HandleFunc("/token", func(rw http.ResponseWriter, r *http.Request) {
+			_ = r.ParseForm()
+			tokenRequest = r.PostForm
+
+			token := jwt.NewWithClaims(jwt.SigningMethodRS256, idTokenClaims)
+			token.Header["kid"] = "key-1"
+			idToken, _ := token.SignedString(privateKey)
+			rw.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(rw).Encode(map[string]interface{}{
+				"token_type":   "Bearer",
+				"access_token": "access-token",
+				"id_token":     idToken,
+			})
+		})
+
+		f := false
+		provider := &OIDCImpl{
+			URLPrefix: &url.URL{Scheme: "https", Host: "auth"},
+			RedirectURLFunc: func(urlPrefix *url.URL, providerConfig config.OAuthProviderConfiguration) string {
+				return "https://auth/sso/oauth2/callback/" + providerConfig.ID
+			},
+			ProviderConfig: config.OAuthProviderConfiguration{
+				ID:           "keycloak",
+				Type:         config.OAuthProviderTypeOIDC,
+				ClientID:     "client-id",
+				ClientSecret: "client-secret",
+				Scope:        "openid email",
+				DiscoveryURL: server.URL + "/.well-known/openid-configuration",
+			},
+			TimeProvider: &coreTime.MockProvider{},
+			LoginIDNormalizerFactory: &loginid.NormalizerFactory{
+				Types: &config.LoginIDTypesConfiguration{
+					Email: &config.LoginIDTypeEmailConfiguration{
+						CaseSensitive: &f,
+						BlockPlusSign: &f,
+						IgnoreDotSign: &f,
+					},
+				},
+			},
+		}
+		state := State{HashedNonce: "hashed-nonce"}
+		idTokenClaims = jwt.MapClaims{
+			"iss":            server.URL,
+			"aud":            "client-id",
+			"sub":            "user-sub",
+			"email":          "User@Example.com",
+			"email_verified": true,
+			"uid":            "user-uid",
+			"mail":           "mapped@example.com",
+			"nonce":          "hashed-nonce",
+			"exp":            time.Now().Add(time.Minute).Unix(),
+		}
+
+		Convey("should build auth URL from discovery document", func() {
+			authURL, err := provider.GetAuthURL(state, "encoded-state")
+			So(err, ShouldBeNil)
+			u, err := url.Parse(authURL)
+			So(err, ShouldBeNil)
+			So(u.Path, ShouldEqual, "/authorize")
+			So(u.Query().Get("client_id"), ShouldEqual, "client-id")
+			So(u.Query().Get("scope"), ShouldEqual, "openid email")
+			So(u.Query().Get("nonce"), ShouldEqual, "hashed-nonce")
+			So(u.Query().Get("state"), ShouldEqual, "encoded-state")
+		})
+
+		Convey("should exchange code and read user info from ID token", func() {
+			authInfo, err := provider.GetAuthInfo(OAuthAuthorizationResponse{Code: "code"}, state)
+			So(err, ShouldBeNil)
+			So(tokenRequest.Get("code"), ShouldEqual, "code")
+			So(tokenRequest.Get("client_secret"), ShouldEqual, "client-secret")
+			So(authInfo.ProviderUserInfo, ShouldResemble, ProviderUserInfo{
+				ID:    "user-sub",
+				Email: "user@example.com",
+			})
+		})
+
+		Convey("should map claims", func() {
+			provider.ProviderConfig.Claims = &config.OAuthProviderClaimsConfiguration{
+				ID:    "uid",
+				Email: "mail",
+			}
+			authInfo, err := provider.GetAuthInfo(OAuthAuthorizationResponse{Code: "code"}, state)
+			So(err, ShouldBeNil)
+			So(authInfo.ProviderUserInfo, ShouldResemble, ProviderUserInfo{
+				ID:    "user-uid",
+				Email: "mapped@example.com",
+			})
+		})
+
+		Convey("should cache discovery document", func() {
+			_, err := provider.GetAuthURL(state, "encoded-state")
+			So(err, ShouldBeNil)
+			_, err = provider.GetAuthURL(state, "encoded-state")
+			So(err, ShouldBeNil)
+			So(discoveryRequests, ShouldEqual, 1)
+		})
+
+		Convey("should reject discovery document of other issuers", func() {
+			issuer = "https://evil.example.com"
+			_, err := provider.GetAuthURL(state, "encoded-state")
+			So(err, ShouldBeError, "OIDC discovery document issuer https://evil.example.com does not match discovery URL")
+
+			issuer = ""
+			_, err = provider.GetAuthURL(state, "encoded-state")
+			So(err, ShouldBeError, "invalid issuer in OIDC discovery document")
+		})
+
+		Convey("should ignore unverified email", func() {
+			idTokenClaims["email_verified"] = false
+			authInfo, err := provider.GetAuthInfo(OAuthAuthorizationResponse{Code: "code"}, state)
+			So(err, ShouldBeNil)
+			So(authInfo.ProviderUserInfo, ShouldResemble, ProviderUserInfo{
+				ID: "user-sub",
+			})
+
+			delete(idTokenClaims, "email_verified")
+			authInfo, err = provider.GetAuthInfo(OAuthAuthorizationResponse{Code: "code"}, state)
+			So(err, ShouldBeNil)
+			So(authInfo.ProviderUserInfo.Email, ShouldEqual, "")
+		})
+
+		Convey("should reject ID token of other issuers", func() {
+			idTokenClaims["iss"] = "https://evil.example.com"
+			_, err := provider.GetAuthInfo(OAuthAuthorizationResponse{Code: "code"}, state)
+			So(err, ShouldBeError, "invalid iss")
+		})
+
+		Convey("should reject ID token without subject", func() {
+			delete(idTokenClaims, "sub")
+			_, err := provider.GetAuthInfo(OAuthAuthorizationResponse{Code: "code"}, state)
+			So(err, ShouldBeError, "no subject ID")
+		})
+	})
+}
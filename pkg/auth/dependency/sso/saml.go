@@ -0,0 +1,339 @@
+package sso
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+	gotime "time"
+
+	"github.com/beevik/etree"
+
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/crypto"
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+	"github.com/skygeario/skygear-server/pkg/core/xmldsig"
+)
+
+const (
+	samlProtocolNamespace  = "urn:oasis:names:tc:SAML:2.0:protocol"
+	samlAssertionNamespace = "urn:oasis:names:tc:SAML:2.0:assertion"
+	samlMetadataNamespace  = "urn:oasis:names:tc:SAML:2.0:metadata"
+
+	samlBindingHTTPPost    = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+	samlStatusSuccess      = "urn:oasis:names:tc:SAML:2.0:status:Success"
+	samlNameIDFormatEmail  = "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress"
+	samlConfirmationBearer = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+	samlAllowedClockSkew   = 1 * gotime.Minute
+	samlRequestIDPrefix    = "_"
+)
+
+// SAMLMetadataURL returns the URL of SP metadata of the provider. It is also
+// the entity ID of SP.
+func SAMLMetadataURL(urlPrefix *url.URL, providerConfig config.OAuthProviderConfiguration) string {
+	u := *urlPrefix
+	u.Path = path.Join(u.Path, fmt.Sprintf("sso/saml/metadata/%s", url.PathEscape(providerConfig.ID)))
+	return u.String()
+}
+
+// SAMLACSURL returns the URL of assertion consumer service of the provider.
+func SAMLACSURL(urlPrefix *url.URL, providerConfig config.OAuthProviderConfiguration) string {
+	u := *urlPrefix
+	u.Path = path.Join(u.Path, fmt.Sprintf("sso/saml/acs/%s", url.PathEscape(providerConfig.ID)))
+	return u.String()
+}
+
+// samlRequestID derives ID of authentication request from the relay state,
+// so that the response can be matched with the state without storing the
+// request.
+func samlRequestID(relayState string) string {
+	return samlRequestIDPrefix + crypto.SHA256String(relayState)
+}
+
+type samlAuthnRequest struct {
+	XMLName                     xml.Name         `xml:"samlp:AuthnRequest"`
+	ProtocolNamespace           string           `xml:"xmlns:samlp,attr"`
+	AssertionNamespace          string           `xml:"xmlns:saml,attr"`
+	ID                          string           `xml:"ID,attr"`
+	Version                     string           `xml:"Version,attr"`
+	IssueInstant                string           `xml:"IssueInstant,attr"`
+	Destination                 string           `xml:"Destination,attr"`
+	ProtocolBinding             string           `xml:"ProtocolBinding,attr"`
+	AssertionConsumerServiceURL string           `xml:"AssertionConsumerServiceURL,attr"`
+	Issuer                      string           `xml:"saml:Issuer"`
+	NameIDPolicy                samlNameIDPolicy `xml:"samlp:NameIDPolicy"`
+}
+
+type samlNameIDPolicy struct {
+	AllowCreate bool `xml:"AllowCreate,attr"`
+}
+
+type samlAuthnRequestParams struct {
+	ID           string
+	IssueInstant gotime.Time
+	Destination  string
+	ACSURL       string
+	Issuer       string
+	RelayState   string
+}
+
+// samlRedirectURL returns the URL sending the authentication request to IdP
+// with HTTP-Redirect binding.
+func samlRedirectURL(p samlAuthnRequestParams) (string, error) {
+	request, err := xml.Marshal(samlAuthnRequest{
+		ProtocolNamespace:           samlProtocolNamespace,
+		AssertionNamespace:          samlAssertionNamespace,
+		ID:                          p.ID,
+		Version:                     "2.0",
+		IssueInstant:                p.IssueInstant.UTC().Format(gotime.RFC3339),
+		Destination:                 p.Destination,
+		ProtocolBinding:             samlBindingHTTPPost,
+		AssertionConsumerServiceURL: p.ACSURL,
+		Issuer:                      p.Issuer,
+		NameIDPolicy:                samlNameIDPolicy{AllowCreate: true},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.DefaultCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err = w.Write(request); err != nil {
+		return "", err
+	}
+	if err = w.Close(); err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(p.Destination)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	q.Set("SAMLRequest", base64.StdEncoding.EncodeToString(buf.Bytes()))
+	// The binding limits relay state to 80 bytes, but IdPs generally accept
+	// longer values.
+	q.Set("RelayState", p.RelayState)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+type samlEntityDescriptor struct {
+	XMLName           xml.Name            `xml:"md:EntityDescriptor"`
+	MetadataNamespace string              `xml:"xmlns:md,attr"`
+	EntityID          string              `xml:"entityID,attr"`
+	SPSSODescriptor   samlSPSSODescriptor `xml:"md:SPSSODescriptor"`
+}
+
+type samlSPSSODescriptor struct {
+	AuthnRequestsSigned        bool                `xml:"AuthnRequestsSigned,attr"`
+	WantAssertionsSigned       bool                `xml:"WantAssertionsSigned,attr"`
+	ProtocolSupportEnumeration string              `xml:"protocolSupportEnumeration,attr"`
+	AssertionConsumerService   samlIndexedEndpoint `xml:"md:AssertionConsumerService"`
+}
+
+type samlIndexedEndpoint struct {
+	Binding   string `xml:"Binding,attr"`
+	Location  string `xml:"Location,attr"`
+	Index     int    `xml:"index,attr"`
+	IsDefault bool   `xml:"isDefault,attr"`
+}
+
+func samlSPMetadata(entityID string, acsURL string) ([]byte, error) {
+	metadata, err := xml.MarshalIndent(samlEntityDescriptor{
+		MetadataNamespace: samlMetadataNamespace,
+		EntityID:          entityID,
+		SPSSODescriptor: samlSPSSODescriptor{
+			AuthnRequestsSigned:        false,
+			WantAssertionsSigned:       true,
+			ProtocolSupportEnumeration: samlProtocolNamespace,
+			AssertionConsumerService: samlIndexedEndpoint{
+				Binding:   samlBindingHTTPPost,
+				Location:  acsURL,
+				Index:     0,
+				IsDefault: true,
+			},
+		},
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), metadata...), nil
+}
+
+// samlAssertion is the authenticated subject of a verified SAML response.
+type samlAssertion struct {
+	NameID       string
+	NameIDFormat string
+	Attributes   map[string][]string
+}
+
+type samlResponseParams struct {
+	Certificates []*x509.Certificate
+	IdPEntityID  string
+	SPEntityID   string
+	ACSURL       string
+	RequestID    string
+	Now          gotime.Time
+}
+
+// parseSAMLResponse verifies the SAML response posted to ACS, and returns
+// its assertion. Either the response or the assertion must be signed.
+// Encrypted assertions are not supported.
+// nolint: gocyclo
+func parseSAMLResponse(samlResponse string, p samlResponseParams) (*samlAssertion, error) {
+	invalid := func(msg string) error {
+		return NewSSOFailed(SSOUnauthorized, "invalid SAML response: "+msg)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(samlResponse), ""))
+	if err != nil {
+		return nil, NewSSOFailed(InvalidParams, "invalid SAML response encoding")
+	}
+	response, err := xmldsig.Parse(data)
+	if err != nil || !xmldsig.Is(response, samlProtocolNamespace, "Response") {
+		return nil, NewSSOFailed(InvalidParams, "malformed SAML response")
+	}
+
+	// Only the signed content is consumed, so that content injected outside
+	// the signed element cannot be mistaken as signed.
+	responseSigned := false
+	signedResponse, err := xmldsig.Verify(response, p.Certificates)
+	if err == nil {
+		responseSigned = true
+		response = signedResponse
+	} else if !errors.Is(err, xmldsig.ErrNotSigned) {
+		return nil, invalid("invalid signature")
+	}
+
+	if destination, ok := xmldsig.Attr(response, "Destination"); ok && destination != p.ACSURL {
+		return nil, invalid("destination mismatch")
+	}
+	if inResponseTo, _ := xmldsig.Attr(response, "InResponseTo"); inResponseTo != p.RequestID {
+		return nil, invalid("unsolicited response")
+	}
+	if issuer := xmldsig.FindChild(response, samlAssertionNamespace, "Issuer"); issuer != nil && strings.TrimSpace(xmldsig.Text(issuer)) != p.IdPEntityID {
+		return nil, invalid("issuer mismatch")
+	}
+
+	statusCode := ""
+	if status := xmldsig.FindChild(response, samlProtocolNamespace, "Status"); status != nil {
+		if code := xmldsig.FindChild(status, samlProtocolNamespace, "StatusCode"); code != nil {
+			statusCode, _ = xmldsig.Attr(code, "Value")
+		}
+	}
+	if statusCode != samlStatusSuccess {
+		return nil, NewSSOFailed(SSOUnauthorized, "login failed: "+statusCode)
+	}
+
+	if xmldsig.FindChild(response, samlAssertionNamespace, "EncryptedAssertion") != nil {
+		return nil, invalid("encrypted assertion is not supported")
+	}
+	assertions := xmldsig.FindChildren(response, samlAssertionNamespace, "Assertion")
+	if len(assertions) != 1 {
+		return nil, invalid("expected exactly one assertion")
+	}
+	assertion := assertions[0]
+
+	if !responseSigned {
+		assertion, err = xmldsig.Verify(assertion, p.Certificates)
+		if err != nil {
+			return nil, invalid("invalid signature")
+		}
+	}
+
+	if issuer := xmldsig.FindChild(assertion, samlAssertionNamespace, "Issuer"); issuer == nil || strings.TrimSpace(xmldsig.Text(issuer)) != p.IdPEntityID {
+		return nil, invalid("issuer mismatch")
+	}
+
+	subject := xmldsig.FindChild(assertion, samlAssertionNamespace, "Subject")
+	if subject == nil {
+		return nil, invalid("missing subject")
+	}
+	if !samlSubjectConfirmed(subject, p) {
+		return nil, invalid("subject is not confirmed")
+	}
+
+	if conditions := xmldsig.FindChild(assertion, samlAssertionNamespace, "Conditions"); conditions != nil {
+		if !samlTimeValid(conditions, p.Now) {
+			return nil, invalid("assertion expired")
+		}
+		for _, restriction := range xmldsig.FindChildren(conditions, samlAssertionNamespace, "AudienceRestriction") {
+			matched := false
+			for _, audience := range xmldsig.FindChildren(restriction, samlAssertionNamespace, "Audience") {
+				if strings.TrimSpace(xmldsig.Text(audience)) == p.SPEntityID {
+					matched = true
+				}
+			}
+			if !matched {
+				return nil, invalid("audience mismatch")
+			}
+		}
+	}
+
+	result := &samlAssertion{Attributes: map[string][]string{}}
+	if nameID := xmldsig.FindChild(subject, samlAssertionNamespace, "NameID"); nameID != nil {
+		result.NameID = strings.TrimSpace(xmldsig.Text(nameID))
+		result.NameIDFormat, _ = xmldsig.Attr(nameID, "Format")
+	}
+	for _, statement := range xmldsig.FindChildren(assertion, samlAssertionNamespace, "AttributeStatement") {
+		for _, attribute := range xmldsig.FindChildren(statement, samlAssertionNamespace, "Attribute") {
+			name, _ := xmldsig.Attr(attribute, "Name")
+			for _, value := range xmldsig.FindChildren(attribute, samlAssertionNamespace, "AttributeValue") {
+				result.Attributes[name] = append(result.Attributes[name], strings.TrimSpace(xmldsig.Text(value)))
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// samlSubjectConfirmed returns whether the subject has a valid bearer
+// confirmation for this SP.
+func samlSubjectConfirmed(subject *etree.Element, p samlResponseParams) bool {
+	for _, confirmation := range xmldsig.FindChildren(subject, samlAssertionNamespace, "SubjectConfirmation") {
+		if method, _ := xmldsig.Attr(confirmation, "Method"); method != samlConfirmationBearer {
+			continue
+		}
+		data := xmldsig.FindChild(confirmation, samlAssertionNamespace, "SubjectConfirmationData")
+		if data == nil {
+			continue
+		}
+		if recipient, _ := xmldsig.Attr(data, "Recipient"); recipient != p.ACSURL {
+			continue
+		}
+		if inResponseTo, ok := xmldsig.Attr(data, "InResponseTo"); ok && inResponseTo != p.RequestID {
+			continue
+		}
+		if _, ok := xmldsig.Attr(data, "NotOnOrAfter"); !ok || !samlTimeValid(data, p.Now) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// samlTimeValid validates NotBefore and NotOnOrAfter of e.
+func samlTimeValid(e *etree.Element, now gotime.Time) bool {
+	if v, ok := xmldsig.Attr(e, "NotBefore"); ok {
+		t, err := gotime.Parse(gotime.RFC3339, v)
+		if err != nil || now.Add(samlAllowedClockSkew).Before(t) {
+			return false
+		}
+	}
+	if v, ok := xmldsig.Attr(e, "NotOnOrAfter"); ok {
+		t, err := gotime.Parse(gotime.RFC3339, v)
+		if err != nil || !now.Add(-samlAllowedClockSkew).Before(t) {
+			return false
+		}
+	}
+	return true
+}
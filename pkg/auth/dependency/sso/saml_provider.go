@@ -0,0 +1,137 @@
+package sso
+
+import (
+	"net/url"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/loginid"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	coreTime "github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+// SAMLAttributeEmail is the default attribute of email, if not mapped in
+// provider config. The user ID is the name ID by default.
+const SAMLAttributeEmail = "email"
+
+// SAMLImpl is a SAML 2.0 service provider of an upstream identity provider.
+// The entity ID of SP is its metadata URL.
+type SAMLImpl struct {
+	URLPrefix                *url.URL
+	ProviderConfig           config.OAuthProviderConfiguration
+	TimeProvider             coreTime.Provider
+	LoginIDNormalizerFactory *loginid.NormalizerFactory
+}
+
+func (f *SAMLImpl) entityID() string {
+	return SAMLMetadataURL(f.URLPrefix, f.ProviderConfig)
+}
+
+func (f *SAMLImpl) acsURL() string {
+	return SAMLACSURL(f.URLPrefix, f.ProviderConfig)
+}
+
+// attributeNames returns the mapped attributes of user info fields. Empty ID
+// attribute means the name ID.
+func (f *SAMLImpl) attributeNames() (id string, email string) {
+	email = SAMLAttributeEmail
+	if c := f.ProviderConfig.Claims; c != nil {
+		id = c.ID
+		if c.Email != "" {
+			email = c.Email
+		}
+	}
+	return
+}
+
+func (f *SAMLImpl) Type() config.OAuthProviderType {
+	return config.OAuthProviderTypeSAML
+}
+
+func (f *SAMLImpl) GetAuthURL(state State, encodedState string) (string, error) {
+	return samlRedirectURL(samlAuthnRequestParams{
+		ID:           samlRequestID(encodedState),
+		IssueInstant: f.TimeProvider.NowUTC(),
+		Destination:  f.ProviderConfig.IdPSSOURL,
+		ACSURL:       f.acsURL(),
+		Issuer:       f.entityID(),
+		RelayState:   encodedState,
+	})
+}
+
+func (f *SAMLImpl) GetAuthInfo(r OAuthAuthorizationResponse, state State) (authInfo AuthInfo, err error) {
+	err = NewSSOFailed(InvalidParams, "SAML response is required")
+	return
+}
+
+func (f *SAMLImpl) SAMLGetAuthInfo(r SAMLResponse, state State) (authInfo AuthInfo, err error) {
+	certs, err := f.ProviderConfig.IdPCertificates()
+	if err != nil {
+		return
+	}
+
+	assertion, err := parseSAMLResponse(r.SAMLResponse, samlResponseParams{
+		Certificates: certs,
+		IdPEntityID:  f.ProviderConfig.IdPEntityID,
+		SPEntityID:   f.entityID(),
+		ACSURL:       f.acsURL(),
+		RequestID:    samlRequestID(r.RelayState),
+		Now:          f.TimeProvider.NowUTC(),
+	})
+	if err != nil {
+		return
+	}
+
+	idAttribute, emailAttribute := f.attributeNames()
+	id := assertion.NameID
+	if idAttribute != "" {
+		id = firstValue(assertion.Attributes[idAttribute])
+	}
+	email := firstValue(assertion.Attributes[emailAttribute])
+	if email == "" && assertion.NameIDFormat == samlNameIDFormatEmail {
+		email = assertion.NameID
+	}
+
+	if id == "" {
+		err = NewSSOFailed(SSOUnauthorized, "no subject ID")
+		return
+	}
+	if email != "" {
+		normalizer := f.LoginIDNormalizerFactory.NormalizerWithLoginIDType(config.LoginIDKeyType("email"))
+		email, err = normalizer.Normalize(email)
+		if err != nil {
+			return
+		}
+	}
+
+	attributes := map[string]interface{}{}
+	for name, values := range assertion.Attributes {
+		attributes[name] = values
+	}
+
+	authInfo.ProviderConfig = f.ProviderConfig
+	authInfo.ProviderRawProfile = map[string]interface{}{
+		"name_id":        assertion.NameID,
+		"name_id_format": assertion.NameIDFormat,
+		"attributes":     attributes,
+	}
+	authInfo.ProviderUserInfo = ProviderUserInfo{
+		ID:    id,
+		Email: email,
+	}
+	return
+}
+
+func (f *SAMLImpl) SPMetadata() ([]byte, error) {
+	return samlSPMetadata(f.entityID(), f.acsURL())
+}
+
+func firstValue(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+var (
+	_ OAuthProvider = &SAMLImpl{}
+	_ SAMLProvider  = &SAMLImpl{}
+)
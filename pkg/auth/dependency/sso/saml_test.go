@@ -0,0 +1,196 @@
+package sso
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/loginid"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	coreTime "github.com/skygeario/skygear-server/pkg/core/time"
+	"github.com/skygeario/skygear-server/pkg/core/xmldsig"
+)
+
+func TestSAMLImpl(t *testing.T) {
+	Convey("SAMLImpl", t, func() {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		So(err, ShouldBeNil)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: "idp.example.com"},
+			NotBefore:    time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			NotAfter:     time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		So(err, ShouldBeNil)
+		cert, err := x509.ParseCertificate(der)
+		So(err, ShouldBeNil)
+
+		mockTime := &coreTime.MockProvider{TimeNowUTC: time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)}
+		f := false
+		provider := &SAMLImpl{
+			URLPrefix: &url.URL{Scheme: "https", Host: "auth"},
+			ProviderConfig: config.OAuthProviderConfiguration{
+				ID:             "adfs",
+				Type:           config.OAuthProviderTypeSAML,
+				IdPEntityID:    "https://idp.example.com",
+				IdPSSOURL:      "https://idp.example.com/sso?tenant=1",
+				IdPCertificate: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+			},
+			TimeProvider: mockTime,
+			LoginIDNormalizerFactory: &loginid.NormalizerFactory{
+				Types: &config.LoginIDTypesConfiguration{
+					Email: &config.LoginIDTypeEmailConfiguration{
+						CaseSensitive: &f,
+						BlockPlusSign: &f,
+						IgnoreDotSign: &f,
+					},
+				},
+			},
+		}
+		relayState := "encoded-state"
+		requestID := samlRequestID(relayState)
+
+		assertionXML := func(audience string, notOnOrAfter string) string {
+			return fmt.Sprintf(`<saml:Assertion xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="assertion-1" Version="2.0" IssueInstant="2020-02-01T00:00:00Z">`+
+				`<saml:Issuer>https://idp.example.com</saml:Issuer>`+
+				`<saml:Subject>`+
+				`<saml:NameID Format="urn:oasis:names:tc:SAML:2.0:nameid-format:persistent">user-1</saml:NameID>`+
+				`<saml:SubjectConfirmation Method="urn:oasis:names:tc:SAML:2.0:cm:bearer">`+
+				`<saml:SubjectConfirmationData InResponseTo="%s" Recipient="https://auth/sso/saml/acs/adfs" NotOnOrAfter="%s"/>`+
+				`</saml:SubjectConfirmation>`+
+				`</saml:Subject>`+
+				`<saml:Conditions NotBefore="2020-01-31T23:59:00Z" NotOnOrAfter="%s">`+
+				`<saml:AudienceRestriction><saml:Audience>%s</saml:Audience></saml:AudienceRestriction>`+
+				`</saml:Conditions>`+
+				`<saml:AttributeStatement>`+
+				`<saml:Attribute Name="email"><saml:AttributeValue>User@Example.com</saml:AttributeValue></saml:Attribute>`+
+				`<saml:Attribute Name="uid"><saml:AttributeValue>uid-1</saml:AttributeValue></saml:Attribute>`+
+				`</saml:AttributeStatement>`+
+				`</saml:Assertion>`, requestID, notOnOrAfter, notOnOrAfter, audience)
+		}
+		makeResponse := func(assertion string, signAssertion bool) string {
+			doc := fmt.Sprintf(`<samlp:Response xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" ID="response-1" Version="2.0" IssueInstant="2020-02-01T00:00:00Z" Destination="https://auth/sso/saml/acs/adfs" InResponseTo="%s">`+
+				`<saml:Issuer xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion">https://idp.example.com</saml:Issuer>`+
+				`<samlp:Status><samlp:StatusCode Value="urn:oasis:names:tc:SAML:2.0:status:Success"/></samlp:Status>`+
+				`%s</samlp:Response>`, requestID, assertion)
+			root, err := xmldsig.Parse([]byte(doc))
+			So(err, ShouldBeNil)
+			if signAssertion {
+				assertion := root.ChildElements()[2]
+				So(xmldsig.Sign(assertion, key, cert, assertion.ChildElements()[0]), ShouldBeNil)
+			}
+			data, err := xmldsig.Serialize(root)
+			So(err, ShouldBeNil)
+			return base64.StdEncoding.EncodeToString(data)
+		}
+		validAssertion := assertionXML("https://auth/sso/saml/metadata/adfs", "2020-02-01T00:05:00Z")
+
+		Convey("should build auth URL with HTTP-Redirect binding", func() {
+			authURL, err := provider.GetAuthURL(State{}, relayState)
+			So(err, ShouldBeNil)
+			u, err := url.Parse(authURL)
+			So(err, ShouldBeNil)
+			So(u.Host, ShouldEqual, "idp.example.com")
+			So(u.Query().Get("tenant"), ShouldEqual, "1")
+			So(u.Query().Get("RelayState"), ShouldEqual, relayState)
+
+			compressed, err := base64.StdEncoding.DecodeString(u.Query().Get("SAMLRequest"))
+			So(err, ShouldBeNil)
+			request, err := ioutil.ReadAll(flate.NewReader(bytes.NewReader(compressed)))
+			So(err, ShouldBeNil)
+			So(string(request), ShouldContainSubstring, `ID="`+requestID+`"`)
+			So(string(request), ShouldContainSubstring, `AssertionConsumerServiceURL="https://auth/sso/saml/acs/adfs"`)
+			So(string(request), ShouldContainSubstring, `<saml:Issuer>https://auth/sso/saml/metadata/adfs</saml:Issuer>`)
+		})
+
+		Convey("should publish SP metadata", func() {
+			metadata, err := provider.SPMetadata()
+			So(err, ShouldBeNil)
+			So(string(metadata), ShouldContainSubstring, `entityID="https://auth/sso/saml/metadata/adfs"`)
+			So(string(metadata), ShouldContainSubstring, `Location="https://auth/sso/saml/acs/adfs"`)
+		})
+
+		Convey("should read user info from signed assertion", func() {
+			authInfo, err := provider.SAMLGetAuthInfo(SAMLResponse{
+				SAMLResponse: makeResponse(validAssertion, true),
+				RelayState:   relayState,
+			}, State{})
+			So(err, ShouldBeNil)
+			So(authInfo.ProviderUserInfo, ShouldResemble, ProviderUserInfo{
+				ID:    "user-1",
+				Email: "user@example.com",
+			})
+			So(authInfo.ProviderRawProfile["attributes"], ShouldResemble, map[string]interface{}{
+				"email": []string{"User@Example.com"},
+				"uid":   []string{"uid-1"},
+			})
+		})
+
+		Convey("should map attributes", func() {
+			provider.ProviderConfig.Claims = &config.OAuthProviderClaimsConfiguration{ID: "uid"}
+			authInfo, err := provider.SAMLGetAuthInfo(SAMLResponse{
+				SAMLResponse: makeResponse(validAssertion, true),
+				RelayState:   relayState,
+			}, State{})
+			So(err, ShouldBeNil)
+			So(authInfo.ProviderUserInfo.ID, ShouldEqual, "uid-1")
+		})
+
+		Convey("should reject unsigned assertion", func() {
+			_, err := provider.SAMLGetAuthInfo(SAMLResponse{
+				SAMLResponse: makeResponse(validAssertion, false),
+				RelayState:   relayState,
+			}, State{})
+			So(err, ShouldBeError, "invalid SAML response: invalid signature")
+		})
+
+		Convey("should reject tampered assertion", func() {
+			response, _ := base64.StdEncoding.DecodeString(makeResponse(validAssertion, true))
+			tampered := strings.Replace(string(response), "user-1", "admin", 1)
+			_, err := provider.SAMLGetAuthInfo(SAMLResponse{
+				SAMLResponse: base64.StdEncoding.EncodeToString([]byte(tampered)),
+				RelayState:   relayState,
+			}, State{})
+			So(err, ShouldBeError, "invalid SAML response: invalid signature")
+		})
+
+		Convey("should reject response of other request", func() {
+			_, err := provider.SAMLGetAuthInfo(SAMLResponse{
+				SAMLResponse: makeResponse(validAssertion, true),
+				RelayState:   "other-state",
+			}, State{})
+			So(err, ShouldBeError, "invalid SAML response: unsolicited response")
+		})
+
+		Convey("should reject assertion of other audience", func() {
+			_, err := provider.SAMLGetAuthInfo(SAMLResponse{
+				SAMLResponse: makeResponse(assertionXML("https://other-sp", "2020-02-01T00:05:00Z"), true),
+				RelayState:   relayState,
+			}, State{})
+			So(err, ShouldBeError, "invalid SAML response: audience mismatch")
+		})
+
+		Convey("should reject expired assertion", func() {
+			_, err := provider.SAMLGetAuthInfo(SAMLResponse{
+				SAMLResponse: makeResponse(assertionXML("https://auth/sso/saml/metadata/adfs", "2020-01-31T23:58:00Z"), true),
+				RelayState:   relayState,
+			}, State{})
+			So(err, ShouldBeError, "invalid SAML response: subject is not confirmed")
+		})
+	})
+}
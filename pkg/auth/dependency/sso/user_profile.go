@@ -12,15 +12,27 @@ func fetchUserProfile(
 	accessTokenResp AccessTokenResp,
 	userProfileURL string,
 ) (userProfile map[string]interface{}, err error) {
+	err = fetchProviderAPI(accessTokenResp, userProfileURL, &userProfile)
+	return
+}
+
+// fetchProviderAPI calls the API of provider with the access token, and
+// decodes the JSON response into out.
+func fetchProviderAPI(
+	accessTokenResp AccessTokenResp,
+	apiURL string,
+	out interface{},
+) (err error) {
 	tokenType := accessTokenResp.TokenType()
 	accessTokenValue := accessTokenResp.AccessToken()
 	authorizationHeader := fmt.Sprintf("%s %s", tokenType, accessTokenValue)
 
-	req, err := http.NewRequest(http.MethodGet, userProfileURL, nil)
+	req, err := http.NewRequest(http.MethodGet, apiURL, nil)
 	if err != nil {
 		return
 	}
 	req.Header.Add("Authorization", authorizationHeader)
+	req.Header.Add("Accept", "application/json")
 
 	resp, err := http.DefaultClient.Do(req)
 	if resp != nil {
@@ -44,10 +56,35 @@ func fetchUserProfile(
 		return
 	}
 
-	err = json.NewDecoder(resp.Body).Decode(&userProfile)
+	err = json.NewDecoder(resp.Body).Decode(out)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// fetchUserProfileWithEmails fetches user profile and the list of emails of
+// the user, for providers that do not include verified email in profile.
+func fetchUserProfileWithEmails(
+	accessTokenResp AccessTokenResp,
+	userProfileURL string,
+	userEmailsURL string,
+) (combinedResponse map[string]interface{}, err error) {
+	profile, err := fetchUserProfile(accessTokenResp, userProfileURL)
+	if err != nil {
+		return
+	}
+
+	var emails []interface{}
+	err = fetchProviderAPI(accessTokenResp, userEmailsURL, &emails)
 	if err != nil {
 		return
 	}
 
+	combinedResponse = map[string]interface{}{
+		"profile": profile,
+		"emails":  emails,
+	}
 	return
 }
@@ -2,6 +2,7 @@ package sso
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/loginid"
 	"github.com/skygeario/skygear-server/pkg/core/config"
@@ -34,6 +35,10 @@ func (d *UserInfoDecoderImpl) DecodeUserInfo(providerType config.OAuthProviderTy
 		providerUserInfo = d.decodeAzureADv2(userInfo)
 	case config.OAuthProviderTypeApple:
 		providerUserInfo = d.decodeApple(userInfo)
+	case config.OAuthProviderTypeGitHub:
+		providerUserInfo = d.decodeGitHub(userInfo)
+	case config.OAuthProviderTypeGitLab:
+		providerUserInfo = d.decodeGitLab(userInfo)
 	default:
 		panic(fmt.Sprintf("sso: unknown provider type: %v", providerType))
 	}
@@ -108,3 +113,75 @@ func (d *UserInfoDecoderImpl) decodeLinkedIn(userInfo map[string]interface{}) *P
 		Email: email,
 	}
 }
+
+func (d *UserInfoDecoderImpl) decodeGitHub(userInfo map[string]interface{}) *ProviderUserInfo {
+	profile, _ := userInfo["profile"].(map[string]interface{})
+	id := decodeNumericID(profile["id"])
+
+	// Only the primary email is used, and only if it is verified.
+	email := ""
+	emails, _ := userInfo["emails"].([]interface{})
+	for _, e := range emails {
+		element, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if primary, ok := element["primary"].(bool); !ok || !primary {
+			continue
+		}
+		if verified, ok := element["verified"].(bool); !ok || !verified {
+			continue
+		}
+		email, _ = element["email"].(string)
+	}
+
+	return &ProviderUserInfo{
+		ID:    id,
+		Email: email,
+	}
+}
+
+func (d *UserInfoDecoderImpl) decodeGitLab(userInfo map[string]interface{}) *ProviderUserInfo {
+	profile, _ := userInfo["profile"].(map[string]interface{})
+	id := decodeNumericID(profile["id"])
+
+	// The primary email is the email in profile. Some GitLab versions do not
+	// list it in emails API, so it is verified if confirmed in either.
+	primaryEmail, _ := profile["email"].(string)
+	verified := profile["confirmed_at"] != nil
+	emails, _ := userInfo["emails"].([]interface{})
+	for _, e := range emails {
+		element, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if email, _ := element["email"].(string); email != primaryEmail {
+			continue
+		}
+		if element["confirmed_at"] != nil {
+			verified = true
+		}
+	}
+
+	email := ""
+	if verified {
+		email = primaryEmail
+	}
+
+	return &ProviderUserInfo{
+		ID:    id,
+		Email: email,
+	}
+}
+
+// decodeNumericID decodes user ID in JSON number as string.
+func decodeNumericID(v interface{}) string {
+	switch id := v.(type) {
+	case float64:
+		return strconv.FormatInt(int64(id), 10)
+	case string:
+		return id
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,86 @@
+package sso
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/loginid"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+)
+
+func TestUserInfoDecoder(t *testing.T) {
+	Convey("UserInfoDecoder", t, func() {
+		f := false
+		decoder := NewUserInfoDecoder(&loginid.NormalizerFactory{
+			Types: &config.LoginIDTypesConfiguration{
+				Email: &config.LoginIDTypeEmailConfiguration{
+					CaseSensitive: &f,
+					BlockPlusSign: &f,
+					IgnoreDotSign: &f,
+				},
+			},
+		})
+
+		Convey("should decode GitHub primary verified email", func() {
+			info, err := decoder.DecodeUserInfo(config.OAuthProviderTypeGitHub, map[string]interface{}{
+				"profile": map[string]interface{}{
+					"id":    float64(583231),
+					"email": "public@example.com",
+				},
+				"emails": []interface{}{
+					map[string]interface{}{"email": "other@example.com", "primary": false, "verified": true},
+					map[string]interface{}{"email": "User@Example.com", "primary": true, "verified": true},
+				},
+			})
+			So(err, ShouldBeNil)
+			So(info, ShouldResemble, &ProviderUserInfo{
+				ID:    "583231",
+				Email: "user@example.com",
+			})
+		})
+
+		Convey("should ignore GitHub unverified primary email", func() {
+			info, err := decoder.DecodeUserInfo(config.OAuthProviderTypeGitHub, map[string]interface{}{
+				"profile": map[string]interface{}{"id": float64(583231)},
+				"emails": []interface{}{
+					map[string]interface{}{"email": "user@example.com", "primary": true, "verified": false},
+				},
+			})
+			So(err, ShouldBeNil)
+			So(info, ShouldResemble, &ProviderUserInfo{ID: "583231"})
+		})
+
+		Convey("should decode GitLab confirmed primary email", func() {
+			info, err := decoder.DecodeUserInfo(config.OAuthProviderTypeGitLab, map[string]interface{}{
+				"profile": map[string]interface{}{
+					"id":    float64(42),
+					"email": "user@example.com",
+				},
+				"emails": []interface{}{
+					map[string]interface{}{"email": "other@example.com", "confirmed_at": "2020-01-01T00:00:00Z"},
+					map[string]interface{}{"email": "user@example.com", "confirmed_at": "2020-01-01T00:00:00Z"},
+				},
+			})
+			So(err, ShouldBeNil)
+			So(info, ShouldResemble, &ProviderUserInfo{
+				ID:    "42",
+				Email: "user@example.com",
+			})
+		})
+
+		Convey("should ignore GitLab unconfirmed primary email", func() {
+			info, err := decoder.DecodeUserInfo(config.OAuthProviderTypeGitLab, map[string]interface{}{
+				"profile": map[string]interface{}{
+					"id":    float64(42),
+					"email": "user@example.com",
+				},
+				"emails": []interface{}{
+					map[string]interface{}{"email": "user@example.com", "confirmed_at": nil},
+				},
+			})
+			So(err, ShouldBeNil)
+			So(info, ShouldResemble, &ProviderUserInfo{ID: "42"})
+		})
+	})
+}
@@ -1,6 +1,9 @@
 package user
 
 import (
+	"reflect"
+	gotime "time"
+
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/urlprefix"
@@ -19,9 +22,35 @@ type WelcomeMessageProvider interface {
 	SendToIdentityInfos(infos []*identity.Info) error
 }
 
+type AuthenticatorProvider interface {
+	DeleteAllByUser(userID string) error
+}
+
+type SessionProvider interface {
+	DeleteAll(userID string) error
+}
+
+type OAuthAuthorizationStore interface {
+	DeleteByUser(userID string) error
+}
+
+type VerifyCodeStore interface {
+	DeleteVerifyCodesByUser(userID string) error
+}
+
+type ForgotPasswordCodeStore interface {
+	DeleteByUser(userID string) error
+}
+
 type Commands struct {
 	AuthInfos                     authinfo.Store
 	UserProfiles                  userprofile.Store
+	Identities                    IdentityProvider
+	Authenticators                AuthenticatorProvider
+	Sessions                      SessionProvider
+	OAuthAuthorizations           OAuthAuthorizationStore
+	VerifyCodes                   VerifyCodeStore
+	ForgotPasswordCodes           ForgotPasswordCodeStore
 	Time                          time.Provider
 	Hooks                         hook.Provider
 	URLPrefix                     urlprefix.Provider
@@ -76,6 +105,172 @@ func (c *Commands) Create(userID string, metadata map[string]interface{}, identi
 	return nil
 }
 
+func (c *Commands) SetDisabled(userID string, disabled bool, message string, expiry *gotime.Time) error {
+	authInfo := &authinfo.AuthInfo{}
+	err := c.AuthInfos.GetAuth(userID, authInfo)
+	if err != nil {
+		return err
+	}
+
+	userProfile, err := c.UserProfiles.GetUserProfile(userID)
+	if err != nil {
+		return err
+	}
+
+	identities, err := c.Identities.ListByUser(userID)
+	if err != nil {
+		return err
+	}
+
+	now := c.Time.NowUTC()
+	user := newUser(now, authInfo, &userProfile, identities)
+	err = c.Hooks.DispatchEvent(
+		event.UserUpdateEvent{
+			Reason:     event.UserUpdateReasonAdministrative,
+			IsDisabled: &disabled,
+			User:       *user,
+		},
+		user,
+	)
+	if err != nil {
+		return err
+	}
+
+	authInfo.Disabled = disabled
+	if disabled {
+		authInfo.DisabledMessage = message
+		authInfo.DisabledExpiry = expiry
+	} else {
+		authInfo.DisabledMessage = ""
+		authInfo.DisabledExpiry = nil
+	}
+
+	return c.AuthInfos.UpdateAuth(authInfo)
+}
+
+// UpdateMetadata merges metadata into the existing user metadata. Nothing
+// is updated if the merged metadata is unchanged.
+func (c *Commands) UpdateMetadata(userID string, metadata map[string]interface{}) error {
+	authInfo := &authinfo.AuthInfo{}
+	err := c.AuthInfos.GetAuth(userID, authInfo)
+	if err != nil {
+		return err
+	}
+
+	userProfile, err := c.UserProfiles.GetUserProfile(userID)
+	if err != nil {
+		return err
+	}
+
+	data := userprofile.Data{}
+	for k, v := range userProfile.Data {
+		data[k] = v
+	}
+	for k, v := range metadata {
+		data[k] = v
+	}
+	if reflect.DeepEqual(data, userProfile.Data) {
+		return nil
+	}
+
+	identities, err := c.Identities.ListByUser(userID)
+	if err != nil {
+		return err
+	}
+
+	now := c.Time.NowUTC()
+	user := newUser(now, authInfo, &userProfile, identities)
+	err = c.Hooks.DispatchEvent(
+		event.UserUpdateEvent{
+			Reason:   event.UserUpdateReasonUpdateMetadata,
+			Metadata: &data,
+			User:     *user,
+		},
+		user,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.UserProfiles.UpdateUserProfile(userID, data)
+	return err
+}
+
+// Delete deletes the user with everything belonging to the user:
+// identities, authenticators, sessions, OAuth authorizations and
+// pending verify/forgot password codes. It must be called in a transaction.
+func (c *Commands) Delete(userID string) error {
+	authInfo := &authinfo.AuthInfo{}
+	err := c.AuthInfos.GetAuth(userID, authInfo)
+	if err != nil {
+		return err
+	}
+
+	userProfile, err := c.UserProfiles.GetUserProfile(userID)
+	if err != nil {
+		return err
+	}
+
+	identities, err := c.Identities.ListByUser(userID)
+	if err != nil {
+		return err
+	}
+
+	now := c.Time.NowUTC()
+	user := newUser(now, authInfo, &userProfile, identities)
+	var identityModels []model.Identity
+	for _, i := range identities {
+		identityModels = append(identityModels, i.ToModel())
+	}
+	err = c.Hooks.DispatchEvent(
+		event.UserDeleteEvent{
+			User:       *user,
+			Identities: identityModels,
+		},
+		user,
+	)
+	if err != nil {
+		return err
+	}
+
+	err = c.Sessions.DeleteAll(userID)
+	if err != nil {
+		return err
+	}
+
+	err = c.OAuthAuthorizations.DeleteByUser(userID)
+	if err != nil {
+		return err
+	}
+
+	err = c.Authenticators.DeleteAllByUser(userID)
+	if err != nil {
+		return err
+	}
+
+	err = c.Identities.DeleteAll(userID, identities)
+	if err != nil {
+		return err
+	}
+
+	err = c.VerifyCodes.DeleteVerifyCodesByUser(userID)
+	if err != nil {
+		return err
+	}
+
+	err = c.ForgotPasswordCodes.DeleteByUser(userID)
+	if err != nil {
+		return err
+	}
+
+	err = c.UserProfiles.DeleteUserProfile(userID)
+	if err != nil {
+		return err
+	}
+
+	return c.AuthInfos.DeleteAuth(userID)
+}
+
 func (c *Commands) enqueueSendVerificationCodeTasks(user model.User, identities []*identity.Info) {
 	for _, i := range identities {
 		if i.Type != authn.IdentityTypeLoginID {
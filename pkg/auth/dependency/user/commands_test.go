@@ -0,0 +1,170 @@
+package user
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/userprofile"
+	"github.com/skygeario/skygear-server/pkg/auth/event"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo"
+	"github.com/skygeario/skygear-server/pkg/core/authn"
+	"github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+type mockDeletion struct {
+	identities []*identity.Info
+	deleted    []string
+}
+
+func (m *mockDeletion) ListByUser(userID string) ([]*identity.Info, error) {
+	return m.identities, nil
+}
+
+func (m *mockDeletion) DeleteAll(userID string, is []*identity.Info) error {
+	m.deleted = append(m.deleted, "identities")
+	return nil
+}
+
+func (m *mockDeletion) DeleteAllByUser(userID string) error {
+	m.deleted = append(m.deleted, "authenticators")
+	return nil
+}
+
+func (m *mockDeletion) DeleteVerifyCodesByUser(userID string) error {
+	m.deleted = append(m.deleted, "verify_codes")
+	return nil
+}
+
+type mockSessionDeletion struct{ m *mockDeletion }
+
+func (s mockSessionDeletion) DeleteAll(userID string) error {
+	s.m.deleted = append(s.m.deleted, "sessions")
+	return nil
+}
+
+type mockAuthorizationDeletion struct{ m *mockDeletion }
+
+func (s mockAuthorizationDeletion) DeleteByUser(userID string) error {
+	s.m.deleted = append(s.m.deleted, "oauth_authorizations")
+	return nil
+}
+
+type mockForgotPasswordCodeDeletion struct{ m *mockDeletion }
+
+func (s mockForgotPasswordCodeDeletion) DeleteByUser(userID string) error {
+	s.m.deleted = append(s.m.deleted, "forgot_password_codes")
+	return nil
+}
+
+func TestCommandsDelete(t *testing.T) {
+	Convey("Commands.Delete", t, func() {
+		authInfos := authinfo.NewMockStoreWithUser("user-id")
+		userProfiles := userprofile.NewMockUserProfileStoreByData(map[string]map[string]interface{}{
+			"user-id": map[string]interface{}{"name": "John"},
+		})
+		hooks := hook.NewMockProvider()
+		deletion := &mockDeletion{
+			identities: []*identity.Info{
+				{
+					ID:   "identity-id",
+					Type: authn.IdentityTypeLoginID,
+					Claims: map[string]interface{}{
+						identity.IdentityClaimLoginIDKey:   "email",
+						identity.IdentityClaimLoginIDValue: "john@example.com",
+					},
+				},
+			},
+		}
+
+		commands := &Commands{
+			AuthInfos:           authInfos,
+			UserProfiles:        userProfiles,
+			Identities:          deletion,
+			Authenticators:      deletion,
+			Sessions:            mockSessionDeletion{deletion},
+			OAuthAuthorizations: mockAuthorizationDeletion{deletion},
+			VerifyCodes:         deletion,
+			ForgotPasswordCodes: mockForgotPasswordCodeDeletion{deletion},
+			Time:                &time.MockProvider{},
+			Hooks:               hooks,
+		}
+
+		Convey("should delete user with everything belonging to the user", func() {
+			err := commands.Delete("user-id")
+			So(err, ShouldBeNil)
+
+			So(deletion.deleted, ShouldResemble, []string{
+				"sessions",
+				"oauth_authorizations",
+				"authenticators",
+				"identities",
+				"verify_codes",
+				"forgot_password_codes",
+			})
+			So(authInfos.AuthInfoMap, ShouldNotContainKey, "user-id")
+			So(userProfiles.Data, ShouldNotContainKey, "user-id")
+
+			So(hooks.DispatchedEvents, ShouldHaveLength, 1)
+			payload := hooks.DispatchedEvents[0].(event.UserDeleteEvent)
+			So(payload.User.ID, ShouldEqual, "user-id")
+			So(payload.User.Metadata, ShouldResemble, userprofile.Data{"name": "John"})
+			So(payload.Identities, ShouldHaveLength, 1)
+		})
+
+		Convey("should not delete anything if user does not exist", func() {
+			err := commands.Delete("non-existent")
+			So(err, ShouldBeError, authinfo.ErrNotFound)
+			So(deletion.deleted, ShouldBeEmpty)
+			So(hooks.DispatchedEvents, ShouldBeEmpty)
+		})
+	})
+}
+
+func TestCommandsUpdateMetadata(t *testing.T) {
+	Convey("Commands.UpdateMetadata", t, func() {
+		authInfos := authinfo.NewMockStoreWithUser("user-id")
+		userProfiles := userprofile.NewMockUserProfileStoreByData(map[string]map[string]interface{}{
+			"user-id": map[string]interface{}{"name": "John", "department": "Sales"},
+		})
+		hooks := hook.NewMockProvider()
+
+		commands := &Commands{
+			AuthInfos:    authInfos,
+			UserProfiles: userProfiles,
+			Identities:   &mockDeletion{},
+			Time:         &time.MockProvider{},
+			Hooks:        hooks,
+		}
+
+		Convey("should merge metadata", func() {
+			err := commands.UpdateMetadata("user-id", map[string]interface{}{"department": "Engineering"})
+			So(err, ShouldBeNil)
+
+			So(userProfiles.Data["user-id"], ShouldResemble, map[string]interface{}{
+				"name":       "John",
+				"department": "Engineering",
+			})
+
+			So(hooks.DispatchedEvents, ShouldHaveLength, 1)
+			payload := hooks.DispatchedEvents[0].(event.UserUpdateEvent)
+			So(payload.Reason, ShouldEqual, event.UserUpdateReasonUpdateMetadata)
+			So(*payload.Metadata, ShouldResemble, userprofile.Data{
+				"name":       "John",
+				"department": "Engineering",
+			})
+			So(payload.User.Metadata, ShouldResemble, userprofile.Data{
+				"name":       "John",
+				"department": "Sales",
+			})
+		})
+
+		Convey("should not update unchanged metadata", func() {
+			err := commands.UpdateMetadata("user-id", map[string]interface{}{"department": "Sales"})
+			So(err, ShouldBeNil)
+			So(hooks.DispatchedEvents, ShouldBeEmpty)
+		})
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/skygeario/skygear-server/pkg/core/async"
 	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo"
 	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/db"
 	"github.com/skygeario/skygear-server/pkg/core/time"
 )
 
@@ -19,6 +20,12 @@ type Provider struct {
 func ProvideCommands(
 	ais authinfo.Store,
 	ups userprofile.Store,
+	ip IdentityProvider,
+	ap AuthenticatorProvider,
+	sp SessionProvider,
+	oas OAuthAuthorizationStore,
+	vcs VerifyCodeStore,
+	fpcs ForgotPasswordCodeStore,
 	tp time.Provider,
 	hp hook.Provider,
 	up urlprefix.Provider,
@@ -29,6 +36,12 @@ func ProvideCommands(
 	return &Commands{
 		AuthInfos:                     ais,
 		UserProfiles:                  ups,
+		Identities:                    ip,
+		Authenticators:                ap,
+		Sessions:                      sp,
+		OAuthAuthorizations:           oas,
+		VerifyCodes:                   vcs,
+		ForgotPasswordCodes:           fpcs,
 		Time:                          tp,
 		Hooks:                         hp,
 		URLPrefix:                     up,
@@ -38,8 +51,13 @@ func ProvideCommands(
 	}
 }
 
+func ProvideStore(f db.SQLBuilderFactory, sqle db.SQLExecutor, tp time.Provider) Store {
+	return NewStore(f("auth"), f("core"), sqle, tp)
+}
+
 var DependencySet = wire.NewSet(
 	ProvideCommands,
+	ProvideStore,
 	wire.Struct(new(Queries), "*"),
 	wire.Struct(new(Provider), "*"),
 )
@@ -27,6 +27,7 @@ func newUser(
 	return &model.User{
 		ID:               authInfo.ID,
 		CreatedAt:        userProfile.CreatedAt,
+		UpdatedAt:        userProfile.UpdatedAt,
 		LastLoginAt:      authInfo.LastLoginAt,
 		Verified:         authInfo.IsVerified(),
 		ManuallyVerified: authInfo.ManuallyVerified,
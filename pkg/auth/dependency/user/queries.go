@@ -10,6 +10,7 @@ import (
 
 type IdentityProvider interface {
 	ListByUser(userID string) ([]*identity.Info, error)
+	DeleteAll(userID string, is []*identity.Info) error
 }
 
 type Queries struct {
@@ -17,6 +18,7 @@ type Queries struct {
 	UserProfiles userprofile.Store
 	Identities   IdentityProvider
 	Time         time.Provider
+	Store        Store
 }
 
 func (p *Queries) Get(id string) (*model.User, error) {
@@ -38,3 +40,25 @@ func (p *Queries) Get(id string) (*model.User, error) {
 
 	return newUser(p.Time.NowUTC(), &authInfo, &userProfile, identities), nil
 }
+
+func (p *Queries) List(filter Filter, offset uint64, limit uint64) ([]*model.User, error) {
+	ids, err := p.Store.List(filter, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*model.User, len(ids))
+	for i, id := range ids {
+		user, err := p.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		users[i] = user
+	}
+
+	return users, nil
+}
+
+func (p *Queries) Count(filter Filter) (uint64, error) {
+	return p.Store.Count(filter)
+}
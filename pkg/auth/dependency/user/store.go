@@ -0,0 +1,124 @@
+package user
+
+import (
+	"strings"
+	gotime "time"
+
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/time"
+)
+
+// Filter narrows down the users returned by Store.List.
+type Filter struct {
+	// LoginID matches users having a login ID containing the value, case-insensitively.
+	LoginID string
+	// Disabled matches users by their effective disabled state.
+	Disabled      *bool
+	CreatedAfter  *gotime.Time
+	CreatedBefore *gotime.Time
+}
+
+type Store interface {
+	// List returns IDs of users matching filter, newest first.
+	List(filter Filter, offset uint64, limit uint64) ([]string, error)
+	Count(filter Filter) (uint64, error)
+}
+
+type storeImpl struct {
+	authSQLBuilder db.SQLBuilder
+	coreSQLBuilder db.SQLBuilder
+	sqlExecutor    db.SQLExecutor
+	timeProvider   time.Provider
+}
+
+func NewStore(authBuilder db.SQLBuilder, coreBuilder db.SQLBuilder, executor db.SQLExecutor, timeProvider time.Provider) Store {
+	return &storeImpl{
+		authSQLBuilder: authBuilder,
+		coreSQLBuilder: coreBuilder,
+		sqlExecutor:    executor,
+		timeProvider:   timeProvider,
+	}
+}
+
+func (s *storeImpl) List(filter Filter, offset uint64, limit uint64) ([]string, error) {
+	q := s.applyFilter(s.selectQuery("p.user_id"), filter).
+		OrderBy("p.created_at DESC", "p.user_id").
+		Offset(offset).
+		Limit(limit)
+
+	rows, err := s.sqlExecutor.QueryWith(q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func (s *storeImpl) Count(filter Filter) (uint64, error) {
+	q := s.applyFilter(s.selectQuery("COUNT(*)"), filter)
+
+	row, err := s.sqlExecutor.QueryRowWith(q)
+	if err != nil {
+		return 0, err
+	}
+
+	var count uint64
+	if err := row.Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (s *storeImpl) selectQuery(columns ...string) db.SelectBuilder {
+	return s.authSQLBuilder.Tenant().
+		Select(columns...).
+		From(s.authSQLBuilder.FullTableName("user_profile"), "p").
+		Join(s.coreSQLBuilder.FullTableName("user"), "u", "p.user_id = u.id")
+}
+
+func (s *storeImpl) applyFilter(q db.SelectBuilder, filter Filter) db.SelectBuilder {
+	if filter.LoginID != "" {
+		q = q.Where(
+			"EXISTS (SELECT 1 FROM "+s.authSQLBuilder.FullTableName("identity")+" AS i"+
+				" JOIN "+s.authSQLBuilder.FullTableName("identity_login_id")+" AS l ON i.id = l.identity_id"+
+				" WHERE i.app_id = p.app_id AND i.user_id = p.user_id AND l.login_id ILIKE ?)",
+			"%"+escapeLike(filter.LoginID)+"%",
+		)
+	}
+
+	if filter.Disabled != nil {
+		// NOTE(admin): keep in sync with authinfo.AuthInfo.IsDisabled.
+		now := s.timeProvider.NowUTC()
+		if *filter.Disabled {
+			q = q.Where("u.disabled AND (u.disabled_expiry IS NULL OR u.disabled_expiry > ?)", now)
+		} else {
+			q = q.Where("NOT (u.disabled AND (u.disabled_expiry IS NULL OR u.disabled_expiry > ?))", now)
+		}
+	}
+
+	if filter.CreatedAfter != nil {
+		q = q.Where("p.created_at >= ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		q = q.Where("p.created_at < ?", *filter.CreatedBefore)
+	}
+
+	return q
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
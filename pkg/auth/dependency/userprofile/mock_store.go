@@ -66,3 +66,8 @@ func (u MockUserProfileStoreImpl) UpdateUserProfile(userID string, data Data) (p
 	}
 	return
 }
+
+func (u MockUserProfileStoreImpl) DeleteUserProfile(userID string) error {
+	delete(u.Data, userID)
+	return nil
+}
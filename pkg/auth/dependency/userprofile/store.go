@@ -127,6 +127,15 @@ func (u storeImpl) UpdateUserProfile(userID string, data Data) (profile UserProf
 	return
 }
 
+func (u storeImpl) DeleteUserProfile(userID string) error {
+	builder := u.sqlBuilder.Tenant().
+		Delete(u.sqlBuilder.FullTableName("user_profile")).
+		Where("user_id = ?", userID)
+
+	_, err := u.sqlExecutor.ExecWith(builder)
+	return err
+}
+
 func (u storeImpl) toUserProfile(userID string, data Data, createdAt time.Time, updatedAt time.Time) UserProfile {
 	return UserProfile{
 		ID:        userID,
@@ -22,4 +22,5 @@ type Store interface {
 	CreateUserProfile(userID string, data Data) (UserProfile, error)
 	GetUserProfile(userID string) (UserProfile, error)
 	UpdateUserProfile(userID string, data Data) (UserProfile, error)
+	DeleteUserProfile(userID string) error
 }
@@ -11,24 +11,29 @@ import (
 
 var DependencySet = wire.NewSet(
 	NewDefaultUserVerifyCodeSenderFactory,
+	ProvideStore,
 	ProvideProvider,
 	ProviderHTMLProvider,
 )
 
+func ProvideStore(builder db.SQLBuilder, executor db.SQLExecutor) Store {
+	return NewStore(builder, executor)
+}
+
 func ProvideProvider(
 	tConfig *config.TenantConfiguration,
 	time time.Provider,
-	builder db.SQLBuilder,
-	executor db.SQLExecutor,
+	store Store,
+	users UserProvider,
+	hooks HookProvider,
 ) Provider {
 	return NewProvider(
 		NewCodeGenerator(tConfig),
-		NewStore(
-			builder,
-			executor,
-		),
+		store,
 		tConfig.AppConfig.UserVerification,
 		time,
+		users,
+		hooks,
 	)
 }
 
@@ -35,4 +35,15 @@ func (m *MockStore) GetVerifyCodeByUser(userID string) (*VerifyCode, error) {
 	return nil, errors.New("code not found")
 }
 
+func (m *MockStore) DeleteVerifyCodesByUser(userID string) error {
+	codes := []VerifyCode{}
+	for _, code := range m.CodeByID {
+		if code.UserID != userID {
+			codes = append(codes, code)
+		}
+	}
+	m.CodeByID = codes
+	return nil
+}
+
 var _ Store = &MockStore{}
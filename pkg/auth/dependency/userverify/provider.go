@@ -9,7 +9,11 @@ import (
 	"github.com/skygeario/skygear-server/pkg/core/config"
 	"github.com/skygeario/skygear-server/pkg/core/time"
 
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/loginid"
+	"github.com/skygeario/skygear-server/pkg/auth/event"
+	"github.com/skygeario/skygear-server/pkg/auth/model"
+	"github.com/skygeario/skygear-server/pkg/core/authn"
 )
 
 type LoginIDProvider interface {
@@ -17,6 +21,14 @@ type LoginIDProvider interface {
 	List(userID string) ([]*loginid.Identity, error)
 }
 
+type HookProvider interface {
+	DispatchEvent(payload event.Payload, user *model.User) error
+}
+
+type UserProvider interface {
+	Get(id string) (*model.User, error)
+}
+
 type Provider interface {
 	CreateVerifyCode(*loginid.Identity) (*VerifyCode, error)
 	VerifyUser(
@@ -37,6 +49,8 @@ type providerImpl struct {
 	store         Store
 	config        *config.UserVerificationConfiguration
 	time          time.Provider
+	users         UserProvider
+	hooks         HookProvider
 }
 
 func NewProvider(
@@ -44,12 +58,16 @@ func NewProvider(
 	store Store,
 	config *config.UserVerificationConfiguration,
 	time time.Provider,
+	users UserProvider,
+	hooks HookProvider,
 ) Provider {
 	return &providerImpl{
 		codeGenerator: codeGenerator,
 		store:         store,
 		config:        config,
 		time:          time,
+		users:         users,
+		hooks:         hooks,
 	}
 }
 
@@ -152,11 +170,37 @@ func (provider *providerImpl) markUserVerified(
 	}
 
 	// Update user
+	wasVerified := authInfo.Verified
 	authInfo.VerifyInfo[verifyCode.LoginID] = true
 	if err = provider.UpdateVerificationState(authInfo, authStore, is); err != nil {
 		return
 	}
 
+	if !wasVerified && authInfo.Verified {
+		var user *model.User
+		user, err = provider.users.Get(authInfo.ID)
+		if err != nil {
+			return
+		}
+
+		err = provider.hooks.DispatchEvent(
+			event.UserVerifiedEvent{
+				User: *user,
+				Identity: model.Identity{
+					Type: string(authn.IdentityTypeLoginID),
+					Claims: map[string]interface{}{
+						identity.IdentityClaimLoginIDKey:   verifyCode.LoginIDKey,
+						identity.IdentityClaimLoginIDValue: verifyCode.LoginID,
+					},
+				},
+			},
+			user,
+		)
+		if err != nil {
+			return
+		}
+	}
+
 	return
 }
 
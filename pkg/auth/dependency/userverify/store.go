@@ -10,6 +10,7 @@ type Store interface {
 	CreateVerifyCode(code *VerifyCode) error
 	MarkConsumed(codeID string) error
 	GetVerifyCodeByUser(userID string) (*VerifyCode, error)
+	DeleteVerifyCodesByUser(userID string) error
 }
 
 type storeImpl struct {
@@ -99,4 +100,13 @@ func (s *storeImpl) GetVerifyCodeByUser(userID string) (*VerifyCode, error) {
 	return &verifyCode, err
 }
 
+func (s *storeImpl) DeleteVerifyCodesByUser(userID string) (err error) {
+	builder := s.sqlBuilder.Tenant().
+		Delete(s.sqlBuilder.FullTableName("verify_code")).
+		Where("user_id = ?", userID)
+
+	_, err = s.sqlExecutor.ExecWith(builder)
+	return
+}
+
 var _ Store = &storeImpl{}
@@ -30,6 +30,7 @@ type InteractionFlow interface {
 	AddLoginID(userID string, loginID loginid.LoginID) (*interactionflows.WebAppResult, error)
 	UpdateLoginID(userID string, oldLoginID loginid.LoginID, newLoginID loginid.LoginID) (*interactionflows.WebAppResult, error)
 	RemoveLoginID(userID string, loginID loginid.LoginID) (*interactionflows.WebAppResult, error)
+	LoginWithLDAP(username string, password string) (*interactionflows.WebAppResult, error)
 }
 
 type SSOStateCodec interface {
@@ -131,6 +132,32 @@ func (p *AuthenticateProviderImpl) LoginWithLoginID(w http.ResponseWriter, r *ht
 	return
 }
 
+func (p *AuthenticateProviderImpl) LoginWithLDAP(w http.ResponseWriter, r *http.Request) (writeResponse func(err error), err error) {
+	var result *interactionflows.WebAppResult
+	writeResponse = func(err error) {
+		r.Form.Del("x_password")
+		p.StateProvider.CreateState(r, err)
+		p.handleResult(w, r, result, err)
+	}
+
+	p.ValidateProvider.PrepareValues(r.Form)
+
+	err = p.ValidateProvider.Validate("#WebAppLDAPLoginRequest", r.Form)
+	if err != nil {
+		return
+	}
+
+	result, err = p.Interactions.LoginWithLDAP(
+		r.Form.Get("x_ldap_username"),
+		r.Form.Get("x_password"),
+	)
+	if err != nil {
+		return
+	}
+
+	return
+}
+
 func (p *AuthenticateProviderImpl) GetEnterPasswordForm(w http.ResponseWriter, r *http.Request) (writeResponse func(err error), err error) {
 	return p.get(w, r, TemplateItemTypeAuthUIEnterPasswordHTML)
 }
@@ -563,6 +590,49 @@ func (p *AuthenticateProviderImpl) RemoveLoginID(w http.ResponseWriter, r *http.
 }
 
 func (p *AuthenticateProviderImpl) HandleSSOCallback(w http.ResponseWriter, r *http.Request, providerAlias string) (writeResponse func(error), err error) {
+	return p.handleSSOCallback(w, r, providerAlias, "#SSOCallbackRequest", "state",
+		func(oauthProvider sso.OAuthProvider, state sso.State) (sso.AuthInfo, error) {
+			return oauthProvider.GetAuthInfo(
+				sso.OAuthAuthorizationResponse{
+					Code:  r.Form.Get("code"),
+					State: r.Form.Get("state"),
+					Scope: r.Form.Get("scope"),
+				},
+				state,
+			)
+		},
+	)
+}
+
+func (p *AuthenticateProviderImpl) HandleSAMLCallback(w http.ResponseWriter, r *http.Request, providerAlias string) (writeResponse func(error), err error) {
+	return p.handleSSOCallback(w, r, providerAlias, "#SAMLCallbackRequest", "RelayState",
+		func(oauthProvider sso.OAuthProvider, state sso.State) (authInfo sso.AuthInfo, err error) {
+			samlProvider, ok := oauthProvider.(sso.SAMLProvider)
+			if !ok {
+				err = ErrOAuthProviderNotFound
+				return
+			}
+			return samlProvider.SAMLGetAuthInfo(
+				sso.SAMLResponse{
+					SAMLResponse: r.Form.Get("SAMLResponse"),
+					RelayState:   r.Form.Get("RelayState"),
+				},
+				state,
+			)
+		},
+	)
+}
+
+// handleSSOCallback completes SSO with the auth info from the callback
+// request, whose state is carried by the stateField form field.
+func (p *AuthenticateProviderImpl) handleSSOCallback(
+	w http.ResponseWriter,
+	r *http.Request,
+	providerAlias string,
+	schemaID string,
+	stateField string,
+	getAuthInfo func(oauthProvider sso.OAuthProvider, state sso.State) (sso.AuthInfo, error),
+) (writeResponse func(error), err error) {
 	v := url.Values{}
 	writeResponse = func(err error) {
 		sid := v.Get("x_sid")
@@ -596,12 +666,12 @@ func (p *AuthenticateProviderImpl) HandleSSOCallback(w http.ResponseWriter, r *h
 		return
 	}
 
-	err = p.ValidateProvider.Validate("#SSOCallbackRequest", r.Form)
+	err = p.ValidateProvider.Validate(schemaID, r.Form)
 	if err != nil {
 		return
 	}
 
-	encodedState := r.Form.Get("state")
+	encodedState := r.Form.Get(stateField)
 	state, err := p.SSOStateCodec.DecodeState(encodedState)
 	if err != nil {
 		return
@@ -641,16 +711,7 @@ func (p *AuthenticateProviderImpl) HandleSSOCallback(w http.ResponseWriter, r *h
 		return
 	}
 
-	code := r.Form.Get("code")
-	scope := r.Form.Get("scope")
-	oauthAuthInfo, err := oauthProvider.GetAuthInfo(
-		sso.OAuthAuthorizationResponse{
-			Code:  code,
-			State: encodedState,
-			Scope: scope,
-		},
-		*state,
-	)
+	oauthAuthInfo, err := getAuthInfo(oauthProvider, *state)
 	if err != nil {
 		return
 	}
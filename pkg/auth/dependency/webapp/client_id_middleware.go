@@ -7,24 +7,34 @@ import (
 	"github.com/skygeario/skygear-server/pkg/core/config"
 )
 
+type ClientResolver interface {
+	ResolveClient(clientID string) (config.OAuthClientConfiguration, error)
+}
+
 type ClientIDMiddleware struct {
-	TenantConfig *config.TenantConfiguration
+	Clients ClientResolver
 }
 
 func (m *ClientIDMiddleware) Handle(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		accessKey := m.resolve(r)
+		accessKey, err := m.resolve(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
 		r = r.WithContext(auth.WithAccessKey(r.Context(), accessKey))
 		next.ServeHTTP(w, r)
 	})
 }
 
-func (m *ClientIDMiddleware) resolve(r *http.Request) auth.AccessKey {
+func (m *ClientIDMiddleware) resolve(r *http.Request) (auth.AccessKey, error) {
 	clientID := r.URL.Query().Get("client_id")
-	for _, client := range m.TenantConfig.AppConfig.Clients {
-		if clientID == client.ClientID() {
-			return auth.AccessKey{Client: client}
-		}
+	if clientID == "" {
+		return auth.AccessKey{}, nil
+	}
+	client, err := m.Clients.ResolveClient(clientID)
+	if err != nil || client == nil {
+		return auth.AccessKey{}, err
 	}
-	return auth.AccessKey{}
+	return auth.AccessKey{Client: client}, nil
 }
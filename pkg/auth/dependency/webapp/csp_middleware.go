@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/skygeario/skygear-server/pkg/core/auth"
 	"github.com/skygeario/skygear-server/pkg/core/config"
 )
 
@@ -61,17 +62,32 @@ func isLocalhost(host string) bool {
 }
 
 // CSPMiddleware derives frame-ancestors from clients and
-// writes Content-Security-Policy.
+// writes Content-Security-Policy. Clients registered dynamically are
+// included only if they are first-party clients making the request, as
+// resolved by ClientIDMiddleware.
 type CSPMiddleware struct {
 	Clients []config.OAuthClientConfiguration
 }
 
 func (m *CSPMiddleware) Handle(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		frameAncestors := deriveFrameAncestors(m.Clients)
+		clients := m.Clients
+		if client := auth.GetAccessKey(r.Context()).Client; client != nil && client.IsFirstParty() && !m.isConfigured(client) {
+			clients = append(append([]config.OAuthClientConfiguration{}, clients...), client)
+		}
+		frameAncestors := deriveFrameAncestors(clients)
 		frameAncestors = append(frameAncestors, "'self'")
 		csp := fmt.Sprintf("frame-ancestors %s;", strings.Join(frameAncestors, " "))
 		w.Header().Set("Content-Security-Policy", csp)
 		next.ServeHTTP(w, r)
 	})
 }
+
+func (m *CSPMiddleware) isConfigured(client config.OAuthClientConfiguration) bool {
+	for _, c := range m.Clients {
+		if c.ClientID() == client.ClientID() {
+			return true
+		}
+	}
+	return false
+}
@@ -7,6 +7,7 @@ import (
 
 	. "github.com/smartystreets/goconvey/convey"
 
+	"github.com/skygeario/skygear-server/pkg/core/auth"
 	"github.com/skygeario/skygear-server/pkg/core/config"
 )
 
@@ -101,5 +102,28 @@ func TestCSPMiddleware(t *testing.T) {
 
 			So(w.Result().Header.Get("Content-Security-Policy"), ShouldEqual, "frame-ancestors http://127.0.0.1 http://127.0.0.1:8080 http://[::1] http://[::1]:8080 http://localhost http://localhost:8080 http://skygear.localhost http://skygear.localhost:8080 'self';")
 		})
+
+		Convey("include first-party client of request", func() {
+			withClient := func(client config.OAuthClientConfiguration) *http.Request {
+				r, _ := http.NewRequest("GET", "/", nil)
+				return r.WithContext(auth.WithAccessKey(r.Context(), auth.AccessKey{Client: client}))
+			}
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, withClient(config.OAuthClientConfiguration{
+				"client_id":      "first-party",
+				"is_first_party": true,
+				"redirect_uris":  []interface{}{"https://example.com/path?q=1"},
+			}))
+			So(w.Result().Header.Get("Content-Security-Policy"), ShouldEqual, "frame-ancestors https://example.com 'self';")
+
+			w = httptest.NewRecorder()
+			h.ServeHTTP(w, withClient(config.OAuthClientConfiguration{
+				"client_id":      "third-party",
+				"is_first_party": false,
+				"redirect_uris":  []interface{}{"https://example.com/path?q=1"},
+			}))
+			So(w.Result().Header.Get("Content-Security-Policy"), ShouldEqual, "frame-ancestors 'self';")
+		})
 	})
 }
@@ -56,7 +56,7 @@ func ProvideStateMiddleware(stateStore StateStore) mux.MiddlewareFunc {
 	return m.Handle
 }
 
-func ProvideClientIDMiddleware(tConfig *config.TenantConfiguration) mux.MiddlewareFunc {
-	m := &ClientIDMiddleware{TenantConfig: tConfig}
+func ProvideClientIDMiddleware(cr ClientResolver) mux.MiddlewareFunc {
+	m := &ClientIDMiddleware{Clients: cr}
 	return m.Handle
 }
@@ -18,12 +18,16 @@ const DefaultRedirectURI = "/settings"
 // redirect_uri must have the same origin.
 // Finally a 302 response is written.
 func RedirectToRedirectURI(w http.ResponseWriter, r *http.Request) {
+	http.Redirect(w, r, GetRedirectURI(r), http.StatusFound)
+}
+
+// GetRedirectURI returns the URI RedirectToRedirectURI redirects to.
+func GetRedirectURI(r *http.Request) string {
 	redirectURI, err := getRedirectURI(r)
 	if err != nil {
-		http.Redirect(w, r, DefaultRedirectURI, http.StatusFound)
-	} else {
-		http.Redirect(w, r, redirectURI, http.StatusFound)
+		return DefaultRedirectURI
 	}
+	return redirectURI
 }
 
 func RedirectToPathWithX(w http.ResponseWriter, r *http.Request, path string) {
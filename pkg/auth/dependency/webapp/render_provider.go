@@ -9,4 +9,6 @@ import (
 // RenderProvider renders HTML template.
 type RenderProvider interface {
 	WritePage(w http.ResponseWriter, r *http.Request, templateType config.TemplateItemType, anyErr interface{})
+	// WritePageWithData renders the page with additional template data.
+	WritePageWithData(w http.ResponseWriter, r *http.Request, templateType config.TemplateItemType, data map[string]interface{}, anyErr interface{})
 }
@@ -190,7 +190,20 @@ func (p *RenderProviderImpl) PrepareErrorData(anyError interface{}, data map[str
 }
 
 func (p *RenderProviderImpl) WritePage(w http.ResponseWriter, r *http.Request, templateType config.TemplateItemType, anyError interface{}) {
+	p.WritePageWithData(w, r, templateType, nil, anyError)
+}
+
+func (p *RenderProviderImpl) WritePageWithData(
+	w http.ResponseWriter,
+	r *http.Request,
+	templateType config.TemplateItemType,
+	extraData map[string]interface{},
+	anyError interface{},
+) {
 	data := FormToJSON(r.Form)
+	for k, v := range extraData {
+		data[k] = v
+	}
 
 	p.PrepareStaticData(data)
 	err := p.PrepareIdentityData(r, data)
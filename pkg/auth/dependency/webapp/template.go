@@ -40,6 +40,14 @@ const (
 	// Settings
 	TemplateItemTypeAuthUISettingsHTML         config.TemplateItemType = "auth_ui_settings.html"
 	TemplateItemTypeAuthUISettingsIdentityHTML config.TemplateItemType = "auth_ui_settings_identity.html"
+
+	TemplateItemTypeAuthUISettingsAuthorizationsHTML config.TemplateItemType = "auth_ui_settings_authorizations.html"
+
+	// Device authorization
+	TemplateItemTypeAuthUIDeviceHTML config.TemplateItemType = "auth_ui_device.html"
+
+	// Consent
+	TemplateItemTypeAuthUIConsentHTML config.TemplateItemType = "auth_ui_consent.html"
 )
 
 var TemplateAuthUIHTMLHeadHTML = template.Spec{
@@ -120,6 +128,14 @@ const defineError = `
 		<li class="error-txt">{{ localize "error-duplicated-identity" }}</li>
 	{{ else if eq .x_error.reason "InvalidIdentityRequest" }}
 		<li class="error-txt">{{ localize "error-remove-last-identity" }}</li>
+	{{ else if eq .x_error.reason "InvalidUserCode" }}
+		<li class="error-txt">{{ localize "error-invalid-user-code" }}</li>
+	{{ else if eq .x_error.reason "UserCodeAttemptsExceeded" }}
+		<li class="error-txt">{{ localize "error-user-code-attempts-exceeded" }}</li>
+	{{ else if eq .x_error.reason "InvalidConsentRequest" }}
+		<li class="error-txt">{{ localize "error-invalid-consent-request" }}</li>
+	{{ else if eq .x_error.reason "AuthorizationNotFound" }}
+		<li class="error-txt">{{ localize "error-authorization-not-found" }}</li>
 	{{ else }}
 		<li class="error-txt">{{ .x_error.message }}</li>
 	{{ end }}
@@ -210,10 +226,33 @@ passed
 {{- end -}}
 `
 
+const defineScope = `
+{{- define "SCOPE" -}}
+{{- if eq .name "openid" -}}
+{{ localize "scope-openid" }}
+{{- else if eq .name "offline_access" -}}
+{{ localize "scope-offline-access" }}
+{{- else if eq .name "profile" -}}
+{{ localize "scope-profile" }}
+{{- else if eq .name "email" -}}
+{{ localize "scope-email" }}
+{{- else if eq .name "phone" -}}
+{{ localize "scope-phone" }}
+{{- else if eq .name "https://auth.skygear.io/scopes/full-access" -}}
+{{ localize "scope-full-access" }}
+{{- else if .description -}}
+{{ .description }}
+{{- else -}}
+{{ .name }}
+{{- end -}}
+{{- end -}}
+`
+
 var defines = []string{
 	defineError,
 	definePasswordPolicy,
 	definePasswordPolicyClass,
+	defineScope,
 }
 
 var components = []config.TemplateItemType{
@@ -256,6 +295,18 @@ var TemplateAuthUILoginHTML = template.Spec{
 					{{- if eq .provider_type "azureadv2" -}}
 					{{ localize "sign-in-azureadv2" }}
 					{{- end -}}
+					{{- if eq .provider_type "github" -}}
+					{{ localize "sign-in-github" }}
+					{{- end -}}
+					{{- if eq .provider_type "gitlab" -}}
+					{{ localize "sign-in-gitlab" }}
+					{{- end -}}
+					{{- if eq .provider_type "oidc" -}}
+					{{ localize "sign-in-oidc" .provider_display_name }}
+					{{- end -}}
+					{{- if eq .provider_type "saml" -}}
+					{{ localize "sign-in-saml" .provider_display_name }}
+					{{- end -}}
 				</button>
 				</form>
 				{{ end }}
@@ -324,6 +375,18 @@ var TemplateAuthUILoginHTML = template.Spec{
 				<button class="btn primary-btn align-self-flex-end" type="submit" name="submit" value="">{{ localize "next-button-label" }}</button>
 				{{ end }}
 			</form>
+
+			{{ range .x_identity_candidates }}
+			{{ if eq .type "ldap" }}
+			<form class="authorize-loginid-form" method="post" novalidate>
+				{{ $.csrfField }}
+				<h2 class="primary-txt">{{ localize "sign-in-ldap" .ldap_display_name }}</h2>
+				<input class="input text-input primary-txt" type="text" name="x_ldap_username" autocomplete="username" placeholder="{{ localize "ldap-username-placeholder" }}" value="{{ $.x_ldap_username }}">
+				<input class="input text-input primary-txt" type="password" name="x_password" autocomplete="current-password" placeholder="{{ localize "password-placeholder" }}">
+				<button class="btn primary-btn align-self-flex-end" type="submit" name="x_ldap" value="true">{{ localize "next-button-label" }}</button>
+			</form>
+			{{ end }}
+			{{ end }}
 		</div>
 		{{ template "auth_ui_footer.html" . }}
 	</div>
@@ -744,6 +807,18 @@ var TemplateAuthUISignupHTML = template.Spec{
 					{{- if eq .provider_type "azureadv2" -}}
 					{{ localize "sign-up-azureadv2" }}
 					{{- end -}}
+					{{- if eq .provider_type "github" -}}
+					{{ localize "sign-up-github" }}
+					{{- end -}}
+					{{- if eq .provider_type "gitlab" -}}
+					{{ localize "sign-up-gitlab" }}
+					{{- end -}}
+					{{- if eq .provider_type "oidc" -}}
+					{{ localize "sign-up-oidc" .provider_display_name }}
+					{{- end -}}
+					{{- if eq .provider_type "saml" -}}
+					{{ localize "sign-up-saml" .provider_display_name }}
+					{{- end -}}
 				</button>
 				</form>
 				{{ end }}
@@ -858,6 +933,18 @@ var TemplateAuthUIPromoteHTML = template.Spec{
 					{{- if eq .provider_type "azureadv2" -}}
 					{{ localize "sign-up-azureadv2" }}
 					{{- end -}}
+					{{- if eq .provider_type "github" -}}
+					{{ localize "sign-up-github" }}
+					{{- end -}}
+					{{- if eq .provider_type "gitlab" -}}
+					{{ localize "sign-up-gitlab" }}
+					{{- end -}}
+					{{- if eq .provider_type "oidc" -}}
+					{{ localize "sign-up-oidc" .provider_display_name }}
+					{{- end -}}
+					{{- if eq .provider_type "saml" -}}
+					{{ localize "sign-up-saml" .provider_display_name }}
+					{{- end -}}
 				</button>
 				</form>
 				{{ end }}
@@ -1002,6 +1089,7 @@ var TemplateAuthUISettingsHTML = template.Spec{
 
 <div class="settings-form primary-txt">
   You are authenticated. To logout, please visit <a href="/logout">here</a>.
+  To manage apps connected to your account, please visit <a href="/settings/authorizations">here</a>.
 </div>
 
 {{ template "auth_ui_footer.html" . }}
@@ -1052,6 +1140,18 @@ var TemplateAuthUISettingsIdentityHTML = template.Spec{
            {{ if eq .provider_type "azureadv2" }}
            {{ localize "settings-identity-oauth-azureadv2" }}
            {{ end }}
+           {{ if eq .provider_type "github" }}
+           {{ localize "settings-identity-oauth-github" }}
+           {{ end }}
+           {{ if eq .provider_type "gitlab" }}
+           {{ localize "settings-identity-oauth-gitlab" }}
+           {{ end }}
+           {{ if eq .provider_type "oidc" }}
+           {{ localize "settings-identity-oauth-oidc" .provider_display_name }}
+           {{ end }}
+           {{ if eq .provider_type "saml" }}
+           {{ localize "settings-identity-oauth-saml" .provider_display_name }}
+           {{ end }}
          {{ end }}
          {{ if eq .type "login_id" }}
            {{ if eq .login_id_type "email" }}
@@ -1067,6 +1167,9 @@ var TemplateAuthUISettingsIdentityHTML = template.Spec{
            {{ localize "settings-identity-login-id-raw" }}
            {{ end }}
          {{ end }}
+         {{ if eq .type "ldap" }}
+           {{ localize "settings-identity-ldap" .ldap_display_name }}
+         {{ end }}
       </h2>
 
       {{ if eq .type "oauth" }}{{ if .email }}
@@ -1080,6 +1183,12 @@ var TemplateAuthUISettingsIdentityHTML = template.Spec{
         {{ .login_id_value }}
       </h3>
       {{ end }}{{ end }}
+
+      {{ if eq .type "ldap" }}{{ if .ldap_username }}
+      <h3 class="identity-claim secondary-txt text-ellipsis">
+        {{ .ldap_username }}
+      </h3>
+      {{ end }}{{ end }}
     </div>
 
     {{ if eq .type "oauth" }}
@@ -1140,11 +1249,185 @@ var TemplateAuthUILogoutHTML = template.Spec{
 
 {{ template "auth_ui_header.html" . }}
 
+{{ if .x_frontchannel_logout_uris }}
+<div class="logout-form">
+  <p class="primary-txt">{{ localize "logout-continue-hint" }}</p>
+  <a class="btn primary-btn align-self-center" href="{{ .x_redirect_uri }}">{{ localize "logout-continue-label" }}</a>
+  {{ range .x_frontchannel_logout_uris }}
+  <iframe class="frontchannel-logout-iframe" src="{{ . }}"></iframe>
+  {{ end }}
+</div>
+{{ else }}
 <form class="logout-form" method="post" novalidate>
   {{ $.csrfField }}
   <p class="primary-txt">{{ localize "logout-button-hint" }}</p>
   <button class="btn primary-btn align-self-center" type="submit" name="x_action" value="logout">{{ localize "logout-button-label" }}</button>
 </form>
+{{ end }}
+
+{{ template "auth_ui_footer.html" . }}
+
+</div>
+</body>
+</html>
+`,
+}
+
+var TemplateAuthUIDeviceHTML = template.Spec{
+	Type:        TemplateItemTypeAuthUIDeviceHTML,
+	IsHTML:      true,
+	Translation: TemplateItemTypeAuthUITranslationJSON,
+	Defines:     defines,
+	Components:  components,
+	Default: `<!DOCTYPE html>
+<html>
+{{ template "auth_ui_html_head.html" . }}
+<body class="page">
+<div class="content">
+
+{{ template "auth_ui_header.html" . }}
+
+<form class="simple-form vertical-form form-fields-container" method="post" novalidate>
+{{ $.csrfField }}
+
+<div class="title primary-txt">{{ localize "device-page-title" }}</div>
+
+{{ template "ERROR" . }}
+
+{{ if .x_device_approved }}
+<div class="description primary-txt">{{ localize "device-approved-description" }}</div>
+{{ else if .x_device_denied }}
+<div class="description primary-txt">{{ localize "device-denied-description" }}</div>
+{{ else if .x_client_name }}
+<input type="hidden" name="x_user_code" value="{{ .x_user_code }}">
+
+<div class="description primary-txt">
+  {{ if .x_client_uri }}
+  {{ localize "consent-description" .x_client_name }} (<a href="{{ .x_client_uri }}" target="_blank" rel="noopener">{{ .x_client_uri }}</a>)
+  {{ else }}
+  {{ localize "consent-description" .x_client_name }}
+  {{ end }}
+</div>
+
+<ul class="consent-scopes">
+{{ range .x_scopes }}
+  <li class="primary-txt">{{ template "SCOPE" . }}</li>
+{{ end }}
+</ul>
+
+<button class="btn secondary-btn" type="submit" name="x_action" value="deny">{{ localize "device-deny-button-label" }}</button>
+<button class="btn primary-btn submit-btn align-self-flex-end" type="submit" name="x_action" value="allow">{{ localize "device-approve-button-label" }}</button>
+{{ else }}
+<div class="description primary-txt">{{ localize "device-description" }}</div>
+
+<input class="input text-input primary-txt" type="text" name="x_user_code" placeholder="{{ localize "user-code-placeholder" }}" value="{{ .x_user_code }}" autocomplete="off">
+
+<button class="btn primary-btn submit-btn align-self-flex-end" type="submit" name="x_action" value="submit">{{ localize "next-button-label" }}</button>
+{{ end }}
+
+</form>
+
+{{ template "auth_ui_footer.html" . }}
+
+</div>
+</body>
+</html>
+`,
+}
+
+var TemplateAuthUIConsentHTML = template.Spec{
+	Type:        TemplateItemTypeAuthUIConsentHTML,
+	IsHTML:      true,
+	Translation: TemplateItemTypeAuthUITranslationJSON,
+	Defines:     defines,
+	Components:  components,
+	Default: `<!DOCTYPE html>
+<html>
+{{ template "auth_ui_html_head.html" . }}
+<body class="page">
+<div class="content">
+
+{{ template "auth_ui_header.html" . }}
+
+<form class="simple-form vertical-form form-fields-container" method="post" novalidate>
+{{ $.csrfField }}
+
+<div class="title primary-txt">{{ localize "consent-page-title" }}</div>
+
+{{ template "ERROR" . }}
+
+{{ if .x_client_name }}
+<div class="description primary-txt">
+  {{ if .x_client_uri }}
+  {{ localize "consent-description" .x_client_name }} (<a href="{{ .x_client_uri }}" target="_blank" rel="noopener">{{ .x_client_uri }}</a>)
+  {{ else }}
+  {{ localize "consent-description" .x_client_name }}
+  {{ end }}
+</div>
+
+<ul class="consent-scopes">
+{{ range .x_scopes }}
+  <li class="primary-txt">{{ template "SCOPE" . }}</li>
+{{ end }}
+</ul>
+
+<button class="btn secondary-btn" type="submit" name="x_action" value="deny">{{ localize "consent-deny-button-label" }}</button>
+<button class="btn primary-btn submit-btn align-self-flex-end" type="submit" name="x_action" value="allow">{{ localize "consent-allow-button-label" }}</button>
+{{ end }}
+
+</form>
+
+{{ template "auth_ui_footer.html" . }}
+
+</div>
+</body>
+</html>
+`,
+}
+
+var TemplateAuthUISettingsAuthorizationsHTML = template.Spec{
+	Type:        TemplateItemTypeAuthUISettingsAuthorizationsHTML,
+	IsHTML:      true,
+	Translation: TemplateItemTypeAuthUITranslationJSON,
+	Defines:     defines,
+	Components:  components,
+	Default: `<!DOCTYPE html>
+<html>
+{{ template "auth_ui_html_head.html" . }}
+<body class="page">
+<div class="content">
+
+{{ template "auth_ui_header.html" . }}
+
+<div class="settings-authorizations">
+  <h1 class="title primary-txt">{{ localize "settings-authorizations-title" }}</h1>
+
+  {{ template "ERROR" . }}
+
+  {{ range .x_authorizations }}
+  <div class="authorization">
+    <div class="authorization-info flex-child-no-overflow">
+      <h2 class="authorization-client primary-txt">{{ .client_name }}</h2>
+      {{ if .client_uri }}
+      <h3 class="authorization-client-uri secondary-txt text-ellipsis">{{ .client_uri }}</h3>
+      {{ end }}
+      <ul class="authorization-scopes">
+      {{ range .scopes }}
+        <li class="secondary-txt">{{ template "SCOPE" . }}</li>
+      {{ end }}
+      </ul>
+    </div>
+
+    <form method="post" novalidate>
+    {{ $.csrfField }}
+    <input type="hidden" name="x_authorization_id" value="{{ .id }}">
+    <button class="btn destructive-btn" type="submit" name="x_action" value="revoke">{{ localize "revoke-button-label" }}</button>
+    </form>
+  </div>
+  {{ else }}
+  <p class="secondary-txt">{{ localize "settings-authorizations-empty" }}</p>
+  {{ end }}
+</div>
 
 {{ template "auth_ui_footer.html" . }}
 
@@ -23,6 +23,10 @@ var TemplateAuthUITranslationJSON = template.Spec{
 	"error-password-reset-failed": "This reset password link is invalid, used or expired. Please request a new one.",
 	"error-duplicated-identity": "This identity has been claimed by another user.",
 	"error-remove-last-identity": "Cannot disconnect. You need to keep at least 1 identity.",
+	"error-invalid-user-code": "This code is invalid, used or expired. Please check the code shown on your device.",
+	"error-user-code-attempts-exceeded": "Too many attempts. Please try again later.",
+	"error-invalid-consent-request": "This request is invalid. Please return to the app and try again.",
+	"error-authorization-not-found": "This app is no longer connected to your account.",
 
 	"back-button-title": "Back",
 	"next-button-label": "Next",
@@ -40,6 +44,16 @@ var TemplateAuthUITranslationJSON = template.Spec{
 	"sign-up-linkedin": "Sign up with LinkedIn",
 	"sign-in-azureadv2": "Sign in with Azure AD",
 	"sign-up-azureadv2": "Sign up with Azure AD",
+	"sign-in-github": "Sign in with GitHub",
+	"sign-up-github": "Sign up with GitHub",
+	"sign-in-gitlab": "Sign in with GitLab",
+	"sign-up-gitlab": "Sign up with GitLab",
+	"sign-in-oidc": "Sign in with {0}",
+	"sign-up-oidc": "Sign up with {0}",
+	"sign-in-saml": "Sign in with {0}",
+	"sign-up-saml": "Sign up with {0}",
+	"sign-in-ldap": "Sign in with {0}",
+	"ldap-username-placeholder": "username",
 	"sso-login-id-separator": "or",
 
 	"phone-number-placeholder": "phone",
@@ -100,6 +114,8 @@ var TemplateAuthUITranslationJSON = template.Spec{
 
 	"logout-button-hint": "To logout, please click the button below.",
 	"logout-button-label": "Logout",
+	"logout-continue-hint": "You have been logged out.",
+	"logout-continue-label": "Continue",
 
 	"settings-identity-title": "Account settings",
 	"settings-identity-oauth-google": "Google",
@@ -107,12 +123,40 @@ var TemplateAuthUITranslationJSON = template.Spec{
 	"settings-identity-oauth-facebook": "Facebook",
 	"settings-identity-oauth-linkedin": "LinkedIn",
 	"settings-identity-oauth-azureadv2": "Azure AD",
+	"settings-identity-oauth-github": "GitHub",
+	"settings-identity-oauth-gitlab": "GitLab",
+	"settings-identity-oauth-oidc": "{0}",
+	"settings-identity-oauth-saml": "{0}",
 	"settings-identity-login-id-email": "Email Address",
 	"settings-identity-login-id-phone": "Phone Number",
 	"settings-identity-login-id-username": "Username",
 	"settings-identity-login-id-raw": "Username",
+	"settings-identity-ldap": "{0}",
 
 	"enter-login-id-page-title--change": "Change your {0}",
-	"enter-login-id-page-title--add": "Enter your {0}"
+	"enter-login-id-page-title--add": "Enter your {0}",
+
+	"device-page-title": "Connect a device",
+	"device-description": "Enter the code shown on your device to allow it to access your account.",
+	"user-code-placeholder": "code",
+	"device-approve-button-label": "Allow",
+	"device-deny-button-label": "Deny",
+	"device-approved-description": "Your device is connected. You can now return to your device.",
+	"device-denied-description": "Your device is not allowed to access your account.",
+
+	"consent-page-title": "Allow access",
+	"consent-description": "{0} would like to:",
+	"consent-allow-button-label": "Allow",
+	"consent-deny-button-label": "Deny",
+	"scope-openid": "Know who you are",
+	"scope-offline-access": "Stay connected to your account",
+	"scope-profile": "View your name and username",
+	"scope-email": "View your email address",
+	"scope-phone": "View your phone number",
+	"scope-full-access": "Access and manage your account",
+
+	"settings-authorizations-title": "Connected apps",
+	"settings-authorizations-empty": "No apps are connected to your account.",
+	"revoke-button-label": "Revoke"
 	}`,
 }
@@ -15,6 +15,7 @@ type EndpointsProvider interface {
 	PromoteUserEndpointURI() *url.URL
 	LogoutEndpointURI() *url.URL
 	SettingsEndpointURI() *url.URL
+	ConsentEndpointURI() *url.URL
 }
 
 type AnonymousFlow interface {
@@ -56,6 +57,12 @@ func (p *URLProvider) AuthenticateURI(options AuthenticateURLOptions) (*url.URL,
 	return coreurl.WithQueryParamsAdded(authnURI, q), nil
 }
 
+func (p *URLProvider) ConsentURI(redirectURI string) *url.URL {
+	return coreurl.WithQueryParamsAdded(p.Endpoints.ConsentEndpointURI(), map[string]string{
+		"redirect_uri": redirectURI,
+	})
+}
+
 func (p *URLProvider) LogoutURI() *url.URL {
 	return p.Endpoints.LogoutEndpointURI()
 }
@@ -15,9 +15,11 @@ func init() {
 		EnterLoginIDRequestSchema,
 		CreateLoginIDRequestSchema,
 		EnterPasswordRequestSchema,
+		LDAPLoginRequestSchema,
 		ForgotPasswordRequestSchema,
 		ResetPasswordRequestSchema,
 		SSOCallbackRequestSchema,
+		SAMLCallbackRequestSchema,
 		AddOrChangeLoginIDRequestSchema,
 		RemoveLoginIDRequestSchema,
 	)
@@ -64,6 +66,19 @@ const EnterPasswordRequestSchema = `
 }
 `
 
+// nolint: gosec
+const LDAPLoginRequestSchema = `
+{
+	"$id": "#WebAppLDAPLoginRequest",
+	"type": "object",
+	"properties": {
+		"x_ldap_username": { "type": "string", "minLength": 1 },
+		"x_password": { "type": "string", "minLength": 1 }
+	},
+	"required": ["x_ldap_username", "x_password"]
+}
+`
+
 const CreateLoginIDRequestSchema = `
 {
 	"$id": "#WebAppCreateLoginIDRequest",
@@ -107,6 +122,18 @@ const SSOCallbackRequestSchema = `
 }
 `
 
+const SAMLCallbackRequestSchema = `
+{
+	"$id": "#SAMLCallbackRequest",
+	"type": "object",
+	"properties": {
+		"SAMLResponse": { "type": "string" },
+		"RelayState": { "type": "string" }
+	},
+	"required": ["SAMLResponse", "RelayState"]
+}
+`
+
 // nolint: gosec
 const ForgotPasswordRequestSchema = `
 {
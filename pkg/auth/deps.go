@@ -10,6 +10,7 @@ import (
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
 	authredis "github.com/skygeario/skygear-server/pkg/auth/dependency/auth/redis"
 	authenticatorbearertoken "github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/bearertoken"
+	authenticatorldap "github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/ldap"
 	authenticatoroob "github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/oob"
 	authenticatorpassword "github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/password"
 	authenticatorprovider "github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/provider"
@@ -19,18 +20,21 @@ import (
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/forgotpassword"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
 	identityanonymous "github.com/skygeario/skygear-server/pkg/auth/dependency/identity/anonymous"
+	identityldap "github.com/skygeario/skygear-server/pkg/auth/dependency/identity/ldap"
 	identityloginid "github.com/skygeario/skygear-server/pkg/auth/dependency/identity/loginid"
 	identityoauth "github.com/skygeario/skygear-server/pkg/auth/dependency/identity/oauth"
 	identityprovider "github.com/skygeario/skygear-server/pkg/auth/dependency/identity/provider"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/interaction"
 	interactionflows "github.com/skygeario/skygear-server/pkg/auth/dependency/interaction/flows"
 	interactionredis "github.com/skygeario/skygear-server/pkg/auth/dependency/interaction/redis"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/ldap"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
 	oauthhandler "github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/handler"
 	oauthpq "github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/pq"
 	oauthredis "github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/redis"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/oidc"
 	oidchandler "github.com/skygeario/skygear-server/pkg/auth/dependency/oidc/handler"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/samlidp"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/session"
 	sessionredis "github.com/skygeario/skygear-server/pkg/auth/dependency/session/redis"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/sso"
@@ -142,6 +146,7 @@ var identityDependencySet = wire.NewSet(
 	identityloginid.DependencySet,
 	identityoauth.DependencySet,
 	identityanonymous.DependencySet,
+	identityldap.DependencySet,
 	identityprovider.DependencySet,
 
 	wire.Bind(new(identityprovider.LoginIDIdentityProvider), new(*identityloginid.Provider)),
@@ -153,10 +158,13 @@ var identityDependencySet = wire.NewSet(
 	wire.Bind(new(identityprovider.AnonymousIdentityProvider), new(*identityanonymous.Provider)),
 	wire.Bind(new(interactionflows.AnonymousIdentityProvider), new(*identityanonymous.Provider)),
 
+	wire.Bind(new(identityprovider.LDAPIdentityProvider), new(*identityldap.Provider)),
+
 	wire.Bind(new(webapp.IdentityProvider), new(*identityprovider.Provider)),
 	wire.Bind(new(interaction.IdentityProvider), new(*identityprovider.Provider)),
 	wire.Bind(new(interactionflows.IdentityProvider), new(*identityprovider.Provider)),
 	wire.Bind(new(user.IdentityProvider), new(*identityprovider.Provider)),
+	wire.Bind(new(oidc.IdentityProvider), new(*identityprovider.Provider)),
 )
 
 var interactionDependencySet = wire.NewSet(
@@ -165,7 +173,9 @@ var interactionDependencySet = wire.NewSet(
 	authenticatoroob.DependencySet,
 	authenticatorbearertoken.DependencySet,
 	authenticatorrecoverycode.DependencySet,
+	authenticatorldap.DependencySet,
 	authenticatorprovider.DependencySet,
+	ldap.DependencySet,
 	interaction.DependencySet,
 	interactionredis.DependencySet,
 	interactionflows.DependencySet,
@@ -174,11 +184,15 @@ var interactionDependencySet = wire.NewSet(
 
 	wire.Bind(new(interaction.OOBProvider), new(*authenticatoroob.Provider)),
 	wire.Bind(new(interaction.AuthenticatorProvider), new(*authenticatorprovider.Provider)),
+	wire.Bind(new(user.AuthenticatorProvider), new(*authenticatorprovider.Provider)),
 	wire.Bind(new(authenticatorprovider.PasswordAuthenticatorProvider), new(*authenticatorpassword.Provider)),
 	wire.Bind(new(authenticatorprovider.TOTPAuthenticatorProvider), new(*authenticatortotp.Provider)),
 	wire.Bind(new(authenticatorprovider.OOBOTPAuthenticatorProvider), new(*authenticatoroob.Provider)),
 	wire.Bind(new(authenticatorprovider.BearerTokenAuthenticatorProvider), new(*authenticatorbearertoken.Provider)),
 	wire.Bind(new(authenticatorprovider.RecoveryCodeAuthenticatorProvider), new(*authenticatorrecoverycode.Provider)),
+	wire.Bind(new(authenticatorprovider.LDAPAuthenticatorProvider), new(*authenticatorldap.Provider)),
+	wire.Bind(new(authenticatorldap.Directory), new(*ldap.Directory)),
+	wire.Bind(new(interactionflows.LDAPDirectory), new(*ldap.Directory)),
 
 	wire.Bind(new(interactionflows.InteractionProvider), new(*interaction.Provider)),
 
@@ -200,10 +214,16 @@ var endpointsDependencySet = wire.NewSet(
 	wire.Bind(new(oauth.AuthorizeEndpointProvider), new(*EndpointsProvider)),
 	wire.Bind(new(oauth.TokenEndpointProvider), new(*EndpointsProvider)),
 	wire.Bind(new(oauth.RevokeEndpointProvider), new(*EndpointsProvider)),
+	wire.Bind(new(oauth.IntrospectEndpointProvider), new(*EndpointsProvider)),
+	wire.Bind(new(oauth.DeviceAuthorizationEndpointProvider), new(*EndpointsProvider)),
+	wire.Bind(new(oauth.DeviceVerificationEndpointProvider), new(*EndpointsProvider)),
+	wire.Bind(new(oauth.RegistrationEndpointProvider), new(*EndpointsProvider)),
+	wire.Bind(new(oauth.PushedAuthorizationRequestEndpointProvider), new(*EndpointsProvider)),
 	wire.Bind(new(oidc.JWKSEndpointProvider), new(*EndpointsProvider)),
 	wire.Bind(new(oidc.UserInfoEndpointProvider), new(*EndpointsProvider)),
 	wire.Bind(new(oidc.EndSessionEndpointProvider), new(*EndpointsProvider)),
 	wire.Bind(new(oauthhandler.EndpointsProvider), new(*EndpointsProvider)),
+	wire.Bind(new(samlidp.EndpointsProvider), new(*EndpointsProvider)),
 	wire.Bind(new(webapp.EndpointsProvider), new(*EndpointsProvider)),
 )
 
@@ -217,8 +237,10 @@ var webappDependencySet = wire.NewSet(
 	webapp.DependencySet,
 
 	wire.Bind(new(oauthhandler.AuthenticateURLProvider), new(*webapp.URLProvider)),
+	wire.Bind(new(oauthhandler.ConsentURLProvider), new(*webapp.URLProvider)),
 	wire.Bind(new(oidchandler.LogoutURLProvider), new(*webapp.URLProvider)),
 	wire.Bind(new(oidchandler.SettingsURLProvider), new(*webapp.URLProvider)),
+	wire.Bind(new(samlidp.AuthenticateURLProvider), new(*webapp.URLProvider)),
 )
 
 var welcomemessageDependencySet = wire.NewSet(
@@ -233,9 +255,11 @@ var userDependencySet = wire.NewSet(
 	wire.Bind(new(auth.UserProvider), new(*user.Queries)),
 	wire.Bind(new(forgotpassword.UserProvider), new(*user.Queries)),
 	wire.Bind(new(hook.UserProvider), new(*user.Queries)),
+	wire.Bind(new(userverify.UserProvider), new(*user.Queries)),
 	wire.Bind(new(interaction.UserProvider), new(*user.Provider)),
 	wire.Bind(new(interactionflows.UserProvider), new(*user.Queries)),
 	wire.Bind(new(oidc.UserProvider), new(*user.Queries)),
+	wire.Bind(new(oauthhandler.UserProvider), new(*user.Queries)),
 )
 
 var CommonDependencySet = wire.NewSet(
@@ -277,11 +301,25 @@ var CommonDependencySet = wire.NewSet(
 	oauthredis.DependencySet,
 	oidc.DependencySet,
 	oidchandler.DependencySet,
+	samlidp.DependencySet,
 	userverify.DependencySet,
 	forgotpassword.DependencySet,
 	challengeDependencySet,
 	interactionDependencySet,
 	identityDependencySet,
+
+	wire.Bind(new(user.SessionProvider), new(*auth.SessionManager)),
+	wire.Bind(new(oauthhandler.SessionRevoker), new(*auth.SessionManager)),
+	wire.Bind(new(samlidp.SessionManager), new(*auth.SessionManager)),
+	wire.Bind(new(samlidp.UserClaimsLoader), new(*oidc.IDTokenIssuer)),
+	wire.Bind(new(oauthhandler.AccessGrantResolver), new(*oauth.Resolver)),
+	wire.Bind(new(oauthhandler.ClientResolver), new(*oauth.ClientResolver)),
+	wire.Bind(new(oidc.ClientResolver), new(*oauth.ClientResolver)),
+	wire.Bind(new(webapp.ClientResolver), new(*oauth.ClientResolver)),
+	wire.Bind(new(auth.LogoutNotifier), new(*oidc.LogoutNotifier)),
+	wire.Bind(new(user.OAuthAuthorizationStore), new(*oauthpq.AuthorizationStore)),
+	wire.Bind(new(user.VerifyCodeStore), new(userverify.Store)),
+	wire.Bind(new(user.ForgotPasswordCodeStore), new(*forgotpassword.StoreImpl)),
 )
 
 // DependencySet is for HTTP request
@@ -21,6 +21,7 @@ func (p *EndpointsProvider) urlOf(relPath string) *url.URL {
 func (p *EndpointsProvider) AuthorizeEndpointURI() *url.URL    { return p.urlOf("oauth2/authorize") }
 func (p *EndpointsProvider) TokenEndpointURI() *url.URL        { return p.urlOf("oauth2/token") }
 func (p *EndpointsProvider) RevokeEndpointURI() *url.URL       { return p.urlOf("oauth2/revoke") }
+func (p *EndpointsProvider) IntrospectEndpointURI() *url.URL   { return p.urlOf("oauth2/introspect") }
 func (p *EndpointsProvider) JWKSEndpointURI() *url.URL         { return p.urlOf("oauth2/jwks") }
 func (p *EndpointsProvider) UserInfoEndpointURI() *url.URL     { return p.urlOf("oauth2/userinfo") }
 func (p *EndpointsProvider) EndSessionEndpointURI() *url.URL   { return p.urlOf("oauth2/end_session") }
@@ -28,6 +29,27 @@ func (p *EndpointsProvider) AuthenticateEndpointURI() *url.URL { return p.urlOf(
 func (p *EndpointsProvider) PromoteUserEndpointURI() *url.URL  { return p.urlOf("./promote_user") }
 func (p *EndpointsProvider) LogoutEndpointURI() *url.URL       { return p.urlOf("./logout") }
 func (p *EndpointsProvider) SettingsEndpointURI() *url.URL     { return p.urlOf("./settings") }
+func (p *EndpointsProvider) ConsentEndpointURI() *url.URL      { return p.urlOf("./consent") }
+
+func (p *EndpointsProvider) DeviceAuthorizationEndpointURI() *url.URL {
+	return p.urlOf("oauth2/device_authorization")
+}
+
+func (p *EndpointsProvider) RegistrationEndpointURI() *url.URL {
+	return p.urlOf("oauth2/register")
+}
+
+func (p *EndpointsProvider) PushedAuthorizationRequestEndpointURI() *url.URL {
+	return p.urlOf("oauth2/par")
+}
+
+func (p *EndpointsProvider) DeviceVerificationEndpointURI() *url.URL {
+	return p.urlOf("./device")
+}
+
+func (p *EndpointsProvider) SAMLMetadataEndpointURI() *url.URL { return p.urlOf("saml2/metadata") }
+func (p *EndpointsProvider) SAMLSSOEndpointURI() *url.URL      { return p.urlOf("saml2/sso") }
+func (p *EndpointsProvider) SAMLSLOEndpointURI() *url.URL      { return p.urlOf("saml2/slo") }
 
 var endpointsProviderSet = wire.NewSet(
 	wire.Struct(new(EndpointsProvider), "*"),
@@ -0,0 +1,51 @@
+package event
+
+import "github.com/skygeario/skygear-server/pkg/auth/model"
+
+const (
+	AfterAuthenticatorCreate Type = "after_authenticator_create"
+)
+
+/*
+	@Callback
+		@Operation POST /after_authenticator_create - After authenticator creation
+			An authenticator is added to a user.
+			@RequestBody
+				@JSONSchema {AuthenticatorCreateEvent}
+			@Response 200 {EmptyResponse}
+*/
+type AuthenticatorCreateEvent struct {
+	User          model.User          `json:"user"`
+	Authenticator model.Authenticator `json:"authenticator"`
+}
+
+// @JSONSchema
+const AuthenticatorCreateEventSchema = `
+{
+	"$id": "#AuthenticatorCreateEvent",
+	"type": "object",
+	"properties": {
+		"id": { "type": "string" },
+		"seq": { "type": "integer" },
+		"type": { "type": "string", "enum": ["after_authenticator_create"] },
+		"payload": { "$ref": "#AuthenticatorCreateEventPayload" },
+		"context": { "$ref": "#EventContext" }
+	}
+}
+`
+
+// @JSONSchema
+const AuthenticatorCreateEventPayloadSchema = `
+{
+	"$id": "#AuthenticatorCreateEventPayload",
+	"type": "object",
+	"properties": {
+		"user": { "$ref": "#User" },
+		"authenticator": { "$ref": "#Authenticator" }
+	}
+}
+`
+
+func (AuthenticatorCreateEvent) EventType() Type {
+	return AfterAuthenticatorCreate
+}
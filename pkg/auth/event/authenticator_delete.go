@@ -0,0 +1,51 @@
+package event
+
+import "github.com/skygeario/skygear-server/pkg/auth/model"
+
+const (
+	AfterAuthenticatorDelete Type = "after_authenticator_delete"
+)
+
+/*
+	@Callback
+		@Operation POST /after_authenticator_delete - After authenticator deletion
+			An authenticator is removed from a user.
+			@RequestBody
+				@JSONSchema {AuthenticatorDeleteEvent}
+			@Response 200 {EmptyResponse}
+*/
+type AuthenticatorDeleteEvent struct {
+	User          model.User          `json:"user"`
+	Authenticator model.Authenticator `json:"authenticator"`
+}
+
+// @JSONSchema
+const AuthenticatorDeleteEventSchema = `
+{
+	"$id": "#AuthenticatorDeleteEvent",
+	"type": "object",
+	"properties": {
+		"id": { "type": "string" },
+		"seq": { "type": "integer" },
+		"type": { "type": "string", "enum": ["after_authenticator_delete"] },
+		"payload": { "$ref": "#AuthenticatorDeleteEventPayload" },
+		"context": { "$ref": "#EventContext" }
+	}
+}
+`
+
+// @JSONSchema
+const AuthenticatorDeleteEventPayloadSchema = `
+{
+	"$id": "#AuthenticatorDeleteEventPayload",
+	"type": "object",
+	"properties": {
+		"user": { "$ref": "#User" },
+		"authenticator": { "$ref": "#Authenticator" }
+	}
+}
+`
+
+func (AuthenticatorDeleteEvent) EventType() Type {
+	return AfterAuthenticatorDelete
+}
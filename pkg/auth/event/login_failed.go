@@ -0,0 +1,56 @@
+package event
+
+import (
+	"github.com/skygeario/skygear-server/pkg/auth/model"
+	"github.com/skygeario/skygear-server/pkg/core/authn"
+)
+
+const (
+	AfterLoginFailed Type = "after_login_failed"
+)
+
+/*
+	@Callback
+		@Operation POST /after_login_failed - After login failure
+			A login attempt failed due to invalid credentials.
+			@RequestBody
+				@JSONSchema {LoginFailedEvent}
+			@Response 200 {EmptyResponse}
+*/
+type LoginFailedEvent struct {
+	User              *model.User             `json:"user,omitempty"`
+	Identity          model.Identity          `json:"identity"`
+	AuthenticatorType authn.AuthenticatorType `json:"authenticator_type"`
+}
+
+// @JSONSchema
+const LoginFailedEventSchema = `
+{
+	"$id": "#LoginFailedEvent",
+	"type": "object",
+	"properties": {
+		"id": { "type": "string" },
+		"seq": { "type": "integer" },
+		"type": { "type": "string", "enum": ["after_login_failed"] },
+		"payload": { "$ref": "#LoginFailedEventPayload" },
+		"context": { "$ref": "#EventContext" }
+	}
+}
+`
+
+// @JSONSchema
+const LoginFailedEventPayloadSchema = `
+{
+	"$id": "#LoginFailedEventPayload",
+	"type": "object",
+	"properties": {
+		"user": { "$ref": "#User" },
+		"identity": { "$ref": "#Identity" },
+		"authenticator_type": { "type": "string" }
+	}
+}
+`
+
+func (LoginFailedEvent) EventType() Type {
+	return AfterLoginFailed
+}
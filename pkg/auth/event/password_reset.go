@@ -0,0 +1,49 @@
+package event
+
+import "github.com/skygeario/skygear-server/pkg/auth/model"
+
+const (
+	AfterPasswordReset Type = "after_password_reset"
+)
+
+/*
+	@Callback
+		@Operation POST /after_password_reset - After password reset
+			The password of a user is reset through forgot password.
+			@RequestBody
+				@JSONSchema {PasswordResetEvent}
+			@Response 200 {EmptyResponse}
+*/
+type PasswordResetEvent struct {
+	User model.User `json:"user"`
+}
+
+// @JSONSchema
+const PasswordResetEventSchema = `
+{
+	"$id": "#PasswordResetEvent",
+	"type": "object",
+	"properties": {
+		"id": { "type": "string" },
+		"seq": { "type": "integer" },
+		"type": { "type": "string", "enum": ["after_password_reset"] },
+		"payload": { "$ref": "#PasswordResetEventPayload" },
+		"context": { "$ref": "#EventContext" }
+	}
+}
+`
+
+// @JSONSchema
+const PasswordResetEventPayloadSchema = `
+{
+	"$id": "#PasswordResetEventPayload",
+	"type": "object",
+	"properties": {
+		"user": { "$ref": "#User" }
+	}
+}
+`
+
+func (PasswordResetEvent) EventType() Type {
+	return AfterPasswordReset
+}
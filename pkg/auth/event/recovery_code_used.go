@@ -0,0 +1,49 @@
+package event
+
+import "github.com/skygeario/skygear-server/pkg/auth/model"
+
+const (
+	AfterRecoveryCodeUsed Type = "after_recovery_code_used"
+)
+
+/*
+	@Callback
+		@Operation POST /after_recovery_code_used - After recovery code usage
+			A recovery code is used for authentication.
+			@RequestBody
+				@JSONSchema {RecoveryCodeUsedEvent}
+			@Response 200 {EmptyResponse}
+*/
+type RecoveryCodeUsedEvent struct {
+	User model.User `json:"user"`
+}
+
+// @JSONSchema
+const RecoveryCodeUsedEventSchema = `
+{
+	"$id": "#RecoveryCodeUsedEvent",
+	"type": "object",
+	"properties": {
+		"id": { "type": "string" },
+		"seq": { "type": "integer" },
+		"type": { "type": "string", "enum": ["after_recovery_code_used"] },
+		"payload": { "$ref": "#RecoveryCodeUsedEventPayload" },
+		"context": { "$ref": "#EventContext" }
+	}
+}
+`
+
+// @JSONSchema
+const RecoveryCodeUsedEventPayloadSchema = `
+{
+	"$id": "#RecoveryCodeUsedEventPayload",
+	"type": "object",
+	"properties": {
+		"user": { "$ref": "#User" }
+	}
+}
+`
+
+func (RecoveryCodeUsedEvent) EventType() Type {
+	return AfterRecoveryCodeUsed
+}
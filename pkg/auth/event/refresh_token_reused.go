@@ -0,0 +1,52 @@
+package event
+
+import "github.com/skygeario/skygear-server/pkg/auth/model"
+
+const (
+	AfterRefreshTokenReused Type = "after_refresh_token_reused"
+)
+
+/*
+	@Callback
+		@Operation POST /after_refresh_token_reused - After refresh token reuse
+			A rotated refresh token is used again. The offline grant is revoked
+			since the refresh token may have been leaked.
+			@RequestBody
+				@JSONSchema {RefreshTokenReusedEvent}
+			@Response 200 {EmptyResponse}
+*/
+type RefreshTokenReusedEvent struct {
+	User    model.User    `json:"user"`
+	Session model.Session `json:"session"`
+}
+
+// @JSONSchema
+const RefreshTokenReusedEventSchema = `
+{
+	"$id": "#RefreshTokenReusedEvent",
+	"type": "object",
+	"properties": {
+		"id": { "type": "string" },
+		"seq": { "type": "integer" },
+		"type": { "type": "string", "enum": ["after_refresh_token_reused"] },
+		"payload": { "$ref": "#RefreshTokenReusedEventPayload" },
+		"context": { "$ref": "#EventContext" }
+	}
+}
+`
+
+// @JSONSchema
+const RefreshTokenReusedEventPayloadSchema = `
+{
+	"$id": "#RefreshTokenReusedEventPayload",
+	"type": "object",
+	"properties": {
+		"user": { "$ref": "#User" },
+		"session": { "$ref": "#Session" }
+	}
+}
+`
+
+func (RefreshTokenReusedEvent) EventType() Type {
+	return AfterRefreshTokenReused
+}
@@ -0,0 +1,80 @@
+package event
+
+import "github.com/skygeario/skygear-server/pkg/auth/model"
+
+const (
+	BeforeUserDelete Type = "before_user_delete"
+	AfterUserDelete  Type = "after_user_delete"
+)
+
+/*
+	@Callback
+		@Operation POST /before_user_delete - Before user deletion
+			A user is about to be deleted.
+			@RequestBody
+				@JSONSchema {BeforeUserDeleteEvent}
+			@Response 200 {HookResponse}
+
+		@Operation POST /after_user_delete - After user deletion
+			A user is deleted.
+			@RequestBody
+				@JSONSchema {AfterUserDeleteEvent}
+			@Response 200 {EmptyResponse}
+*/
+type UserDeleteEvent struct {
+	User       model.User       `json:"user"`
+	Identities []model.Identity `json:"identities"`
+}
+
+// @JSONSchema
+const BeforeUserDeleteEventSchema = `
+{
+	"$id": "#BeforeUserDeleteEvent",
+	"type": "object",
+	"properties": {
+		"id": { "type": "string" },
+		"seq": { "type": "integer" },
+		"type": { "type": "string", "enum": ["before_user_delete"] },
+		"payload": { "$ref": "#UserDeleteEventPayload" },
+		"context": { "$ref": "#EventContext" }
+	}
+}
+`
+
+// @JSONSchema
+const AfterUserDeleteEventSchema = `
+{
+	"$id": "#AfterUserDeleteEvent",
+	"type": "object",
+	"properties": {
+		"id": { "type": "string" },
+		"seq": { "type": "integer" },
+		"type": { "type": "string", "enum": ["after_user_delete"] },
+		"payload": { "$ref": "#UserDeleteEventPayload" },
+		"context": { "$ref": "#EventContext" }
+	}
+}
+`
+
+// @JSONSchema
+const UserDeleteEventPayloadSchema = `
+{
+	"$id": "#UserDeleteEventPayload",
+	"type": "object",
+	"properties": {
+		"user": { "$ref": "#User" },
+		"identities": {
+			"type": "array",
+			"items": { "$ref": "#Identity" }
+		}
+	}
+}
+`
+
+func (UserDeleteEvent) BeforeEventType() Type {
+	return BeforeUserDelete
+}
+
+func (UserDeleteEvent) AfterEventType() Type {
+	return AfterUserDelete
+}
@@ -0,0 +1,51 @@
+package event
+
+import "github.com/skygeario/skygear-server/pkg/auth/model"
+
+const (
+	AfterUserVerified Type = "after_user_verified"
+)
+
+/*
+	@Callback
+		@Operation POST /after_user_verified - After user verification
+			A user is verified.
+			@RequestBody
+				@JSONSchema {UserVerifiedEvent}
+			@Response 200 {EmptyResponse}
+*/
+type UserVerifiedEvent struct {
+	User     model.User     `json:"user"`
+	Identity model.Identity `json:"identity"`
+}
+
+// @JSONSchema
+const UserVerifiedEventSchema = `
+{
+	"$id": "#UserVerifiedEvent",
+	"type": "object",
+	"properties": {
+		"id": { "type": "string" },
+		"seq": { "type": "integer" },
+		"type": { "type": "string", "enum": ["after_user_verified"] },
+		"payload": { "$ref": "#UserVerifiedEventPayload" },
+		"context": { "$ref": "#EventContext" }
+	}
+}
+`
+
+// @JSONSchema
+const UserVerifiedEventPayloadSchema = `
+{
+	"$id": "#UserVerifiedEventPayload",
+	"type": "object",
+	"properties": {
+		"user": { "$ref": "#User" },
+		"identity": { "$ref": "#Identity" }
+	}
+}
+`
+
+func (UserVerifiedEvent) EventType() Type {
+	return AfterUserVerified
+}
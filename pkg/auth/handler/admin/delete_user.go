@@ -0,0 +1,73 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	pkg "github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz/policy"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
+)
+
+func AttachDeleteUserHandler(
+	router *mux.Router,
+	authDependency pkg.DependencyMap,
+) {
+	router.NewRoute().
+		Path("/_auth/admin/users/{user_id}").
+		Handler(pkg.MakeHandler(authDependency, newDeleteUserHandler)).
+		Methods("OPTIONS", "DELETE")
+}
+
+type userDeleter interface {
+	Delete(userID string) error
+}
+
+/*
+	@Operation DELETE /admin/users/{user_id} - Delete user
+		Delete a user permanently, with all their identities, authenticators
+		and sessions.
+
+		@Tag Administration
+		@SecurityRequirement master_key
+
+		@Parameter {AdminUserID}
+
+		@Response 200 {EmptyResponse}
+*/
+type DeleteUserHandler struct {
+	TxContext db.TxContext
+	Users     userDeleter
+}
+
+func (h *DeleteUserHandler) ProvideAuthzPolicy() authz.Policy {
+	return policy.AllOf(
+		authz.PolicyFunc(policy.RequireMasterKey),
+	)
+}
+
+func (h *DeleteUserHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	var response handler.APIResponse
+	result, err := h.Handle(r)
+	if err != nil {
+		response.Error = err
+	} else {
+		response.Result = result
+	}
+	handler.WriteResponse(rw, response)
+}
+
+func (h *DeleteUserHandler) Handle(r *http.Request) (interface{}, error) {
+	userID := mux.Vars(r)["user_id"]
+
+	return handler.Transactional(h.TxContext, func() (interface{}, error) {
+		err := h.Users.Delete(userID)
+		if err != nil {
+			return nil, err
+		}
+		return struct{}{}, nil
+	})
+}
@@ -0,0 +1,166 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	pkg "github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity"
+	"github.com/skygeario/skygear-server/pkg/auth/model"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz/policy"
+	"github.com/skygeario/skygear-server/pkg/core/authn"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
+)
+
+func AttachGetUserHandler(
+	router *mux.Router,
+	authDependency pkg.DependencyMap,
+) {
+	router.NewRoute().
+		Path("/_auth/admin/users/{user_id}").
+		Handler(pkg.MakeHandler(authDependency, newGetUserHandler)).
+		Methods("OPTIONS", "GET")
+}
+
+type GetUserResponse struct {
+	User           *model.User      `json:"user"`
+	Identities     []model.Identity `json:"identities"`
+	Authenticators []model.Authenticator  `json:"authenticators"`
+}
+
+// @JSONSchema
+const GetUserResponseSchema = `
+{
+	"$id": "#GetUserResponse",
+	"type": "object",
+	"properties": {
+		"result": {
+			"type": "object",
+			"properties": {
+				"user": { "$ref": "#User" },
+				"identities": {
+					"type": "array",
+					"items": { "$ref": "#Identity" }
+				},
+				"authenticators": {
+					"type": "array",
+					"items": { "$ref": "#Authenticator" }
+				}
+			},
+			"required": ["user", "identities", "authenticators"]
+		}
+	}
+}
+`
+
+// nolint: deadcode
+/*
+	@ID AdminUserID
+	@Parameter user_id path
+		ID of the user.
+		@JSONSchema
+			{ "type": "string" }
+*/
+type adminUserID string
+
+type userGetter interface {
+	Get(userID string) (*model.User, error)
+}
+
+type identityLister interface {
+	ListByUser(userID string) ([]*identity.Info, error)
+}
+
+type authenticatorLister interface {
+	List(userID string, typ authn.AuthenticatorType) ([]*authenticator.Info, error)
+}
+
+// listedAuthenticatorTypes excludes recovery codes and bearer tokens,
+// which are implementation details of MFA.
+var listedAuthenticatorTypes = []authn.AuthenticatorType{
+	authn.AuthenticatorTypePassword,
+	authn.AuthenticatorTypeTOTP,
+	authn.AuthenticatorTypeOOB,
+	authn.AuthenticatorTypeLDAP,
+}
+
+/*
+	@Operation GET /admin/users/{user_id} - Get user
+		Get a user with their identities and authenticators.
+
+		@Tag Administration
+		@SecurityRequirement master_key
+
+		@Parameter {AdminUserID}
+
+		@Response 200
+			@JSONSchema {GetUserResponse}
+*/
+type GetUserHandler struct {
+	TxContext      db.TxContext
+	Users          userGetter
+	Identities     identityLister
+	Authenticators authenticatorLister
+}
+
+func (h *GetUserHandler) ProvideAuthzPolicy() authz.Policy {
+	return policy.AllOf(
+		authz.PolicyFunc(policy.RequireMasterKey),
+	)
+}
+
+func (h *GetUserHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	var response handler.APIResponse
+	result, err := h.Handle(r)
+	if err != nil {
+		response.Error = err
+	} else {
+		response.Result = result
+	}
+	handler.WriteResponse(rw, response)
+}
+
+func (h *GetUserHandler) Handle(r *http.Request) (*GetUserResponse, error) {
+	userID := mux.Vars(r)["user_id"]
+
+	resp := &GetUserResponse{
+		Identities:     []model.Identity{},
+		Authenticators: []model.Authenticator{},
+	}
+	err := db.WithTx(h.TxContext, func() error {
+		user, err := h.Users.Get(userID)
+		if err != nil {
+			return err
+		}
+		resp.User = user
+
+		iis, err := h.Identities.ListByUser(userID)
+		if err != nil {
+			return err
+		}
+		for _, ii := range iis {
+			resp.Identities = append(resp.Identities, ii.ToModel())
+		}
+
+		for _, typ := range listedAuthenticatorTypes {
+			ais, err := h.Authenticators.List(userID, typ)
+			if err != nil {
+				return err
+			}
+			for _, ai := range ais {
+				resp.Authenticators = append(resp.Authenticators, ai.ToModel())
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,173 @@
+package admin
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	pkg "github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz/policy"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
+)
+
+func AttachListHookDeliveryAttemptsHandler(
+	router *mux.Router,
+	authDependency pkg.DependencyMap,
+) {
+	router.NewRoute().
+		Path("/_auth/admin/hooks/attempts").
+		Handler(pkg.MakeHandler(authDependency, newListHookDeliveryAttemptsHandler)).
+		Methods("OPTIONS", "GET")
+}
+
+// HookDeliveryAttempt is an attempt to deliver an event to a hook.
+type HookDeliveryAttempt struct {
+	ID           string    `json:"id"`
+	EventID      string    `json:"event_id"`
+	EventType    string    `json:"event_type"`
+	HookURL      string    `json:"hook_url"`
+	StatusCode   int       `json:"status_code,omitempty"`
+	LatencyMS    int64     `json:"latency_ms"`
+	ResponseBody string    `json:"response_body,omitempty"`
+	Error        string    `json:"error,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type ListHookDeliveryAttemptsResponse struct {
+	Attempts []HookDeliveryAttempt `json:"attempts"`
+}
+
+// @JSONSchema
+const ListHookDeliveryAttemptsResponseSchema = `
+{
+	"$id": "#ListHookDeliveryAttemptsResponse",
+	"type": "object",
+	"properties": {
+		"result": {
+			"type": "object",
+			"properties": {
+				"attempts": {
+					"type": "array",
+					"items": {
+						"type": "object",
+						"properties": {
+							"id": { "type": "string" },
+							"event_id": { "type": "string" },
+							"event_type": { "type": "string" },
+							"hook_url": { "type": "string" },
+							"status_code": { "type": "integer" },
+							"latency_ms": { "type": "integer" },
+							"response_body": { "type": "string" },
+							"error": { "type": "string" },
+							"created_at": { "type": "string", "format": "date-time" }
+						}
+					}
+				}
+			},
+			"required": ["attempts"]
+		}
+	}
+}
+`
+
+// nolint: deadcode
+/*
+	@ID ListHookDeliveryAttemptsEventID
+	@Parameter event_id query
+		List attempts delivering the event.
+		@JSONSchema
+			{ "type": "string" }
+*/
+type listHookDeliveryAttemptsEventID string
+
+// nolint: deadcode
+/*
+	@ID ListHookDeliveryAttemptsHookURL
+	@Parameter hook_url query
+		List attempts delivering to the hook URL.
+		@JSONSchema
+			{ "type": "string" }
+*/
+type listHookDeliveryAttemptsHookURL string
+
+type attemptLister interface {
+	ListAttempts(filter hook.AttemptFilter, offset uint64, limit uint64) ([]*hook.DeliveryAttempt, error)
+}
+
+/*
+	@Operation GET /admin/hooks/attempts - List hook delivery attempts
+		List attempts of delivering events to hooks, newest first.
+
+		@Tag Administration
+		@SecurityRequirement master_key
+
+		@Parameter {ListUsersOffset}
+		@Parameter {ListUsersLimit}
+		@Parameter {ListHookDeliveryAttemptsEventID}
+		@Parameter {ListHookDeliveryAttemptsHookURL}
+
+		@Response 200
+			@JSONSchema {ListHookDeliveryAttemptsResponse}
+*/
+type ListHookDeliveryAttemptsHandler struct {
+	Attempts attemptLister
+}
+
+func (h *ListHookDeliveryAttemptsHandler) ProvideAuthzPolicy() authz.Policy {
+	return policy.AllOf(
+		authz.PolicyFunc(policy.RequireMasterKey),
+	)
+}
+
+func (h *ListHookDeliveryAttemptsHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	var response handler.APIResponse
+	result, err := h.Handle(r)
+	if err != nil {
+		response.Error = err
+	} else {
+		response.Result = result
+	}
+	handler.WriteResponse(rw, response)
+}
+
+func (h *ListHookDeliveryAttemptsHandler) Handle(r *http.Request) (*ListHookDeliveryAttemptsResponse, error) {
+	q := r.URL.Query()
+
+	offset, limit, err := parsePagination(q)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := hook.AttemptFilter{
+		EventID: q.Get("event_id"),
+		HookURL: q.Get("hook_url"),
+	}
+
+	// Attempts are recorded outside of transactions; no transaction is needed.
+	attempts, err := h.Attempts.ListAttempts(filter, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ListHookDeliveryAttemptsResponse{
+		Attempts: []HookDeliveryAttempt{},
+	}
+	for _, a := range attempts {
+		resp.Attempts = append(resp.Attempts, HookDeliveryAttempt{
+			ID:           a.ID,
+			EventID:      a.EventID,
+			EventType:    string(a.EventType),
+			HookURL:      a.HookURL,
+			StatusCode:   a.StatusCode,
+			LatencyMS:    int64(a.Latency / time.Millisecond),
+			ResponseBody: a.ResponseBody,
+			Error:        a.Error,
+			CreatedAt:    a.CreatedAt,
+		})
+	}
+
+	return resp, nil
+}
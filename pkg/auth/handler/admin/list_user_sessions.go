@@ -0,0 +1,118 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	pkg "github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/model"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz/policy"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
+)
+
+func AttachListUserSessionsHandler(
+	router *mux.Router,
+	authDependency pkg.DependencyMap,
+) {
+	router.NewRoute().
+		Path("/_auth/admin/users/{user_id}/sessions").
+		Handler(pkg.MakeHandler(authDependency, newListUserSessionsHandler)).
+		Methods("OPTIONS", "GET")
+}
+
+type ListUserSessionsResponse struct {
+	Sessions []*model.Session `json:"sessions"`
+}
+
+// @JSONSchema
+const ListUserSessionsResponseSchema = `
+{
+	"$id": "#ListUserSessionsResponse",
+	"type": "object",
+	"properties": {
+		"result": {
+			"type": "object",
+			"properties": {
+				"sessions": {
+					"type": "array",
+					"items": { "$ref": "#Session" }
+				}
+			},
+			"required": ["sessions"]
+		}
+	}
+}
+`
+
+type sessionLister interface {
+	List(userID string) ([]auth.AuthSession, error)
+}
+
+/*
+	@Operation GET /admin/users/{user_id}/sessions - List user sessions
+		List the IDP sessions and offline grants of a user, newest first.
+
+		@Tag Administration
+		@SecurityRequirement master_key
+
+		@Parameter {AdminUserID}
+
+		@Response 200
+			List of sessions.
+			@JSONSchema {ListUserSessionsResponse}
+*/
+type ListUserSessionsHandler struct {
+	TxContext db.TxContext
+	Users     userGetter
+	Sessions  sessionLister
+}
+
+func (h *ListUserSessionsHandler) ProvideAuthzPolicy() authz.Policy {
+	return policy.AllOf(
+		authz.PolicyFunc(policy.RequireMasterKey),
+	)
+}
+
+func (h *ListUserSessionsHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	var response handler.APIResponse
+	result, err := h.Handle(r)
+	if err != nil {
+		response.Error = err
+	} else {
+		response.Result = result
+	}
+	handler.WriteResponse(rw, response)
+}
+
+func (h *ListUserSessionsHandler) Handle(r *http.Request) (*ListUserSessionsResponse, error) {
+	userID := mux.Vars(r)["user_id"]
+
+	resp := &ListUserSessionsResponse{
+		Sessions: []*model.Session{},
+	}
+	err := db.WithTx(h.TxContext, func() error {
+		// Ensure the user exists.
+		_, err := h.Users.Get(userID)
+		if err != nil {
+			return err
+		}
+
+		sessions, err := h.Sessions.List(userID)
+		if err != nil {
+			return err
+		}
+		for _, s := range sessions {
+			resp.Sessions = append(resp.Sessions, s.ToAPIModel())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,264 @@
+package admin
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	pkg "github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/user"
+	"github.com/skygeario/skygear-server/pkg/auth/model"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz/policy"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
+	"github.com/skygeario/skygear-server/pkg/core/skyerr"
+)
+
+const (
+	defaultListUsersLimit = 20
+	maxListUsersLimit     = 100
+)
+
+func AttachListUsersHandler(
+	router *mux.Router,
+	authDependency pkg.DependencyMap,
+) {
+	router.NewRoute().
+		Path("/_auth/admin/users").
+		Handler(pkg.MakeHandler(authDependency, newListUsersHandler)).
+		Methods("OPTIONS", "GET")
+}
+
+type ListUsersResponse struct {
+	Users      []*model.User `json:"users"`
+	TotalCount uint64        `json:"total_count"`
+}
+
+// @JSONSchema
+const ListUsersResponseSchema = `
+{
+	"$id": "#ListUsersResponse",
+	"type": "object",
+	"properties": {
+		"result": {
+			"type": "object",
+			"properties": {
+				"users": {
+					"type": "array",
+					"items": { "$ref": "#User" }
+				},
+				"total_count": { "type": "integer" }
+			},
+			"required": ["users", "total_count"]
+		}
+	}
+}
+`
+
+// nolint: deadcode
+/*
+	@ID ListUsersOffset
+	@Parameter offset query
+		Number of users to skip.
+		@JSONSchema
+			{ "type": "integer", "minimum": 0 }
+*/
+type listUsersOffset string
+
+// nolint: deadcode
+/*
+	@ID ListUsersLimit
+	@Parameter limit query
+		Maximum number of users to return, at most 100.
+		@JSONSchema
+			{ "type": "integer", "minimum": 1, "maximum": 100 }
+*/
+type listUsersLimit string
+
+// nolint: deadcode
+/*
+	@ID ListUsersLoginID
+	@Parameter login_id query
+		List users having a login ID containing the value.
+		@JSONSchema
+			{ "type": "string" }
+*/
+type listUsersLoginID string
+
+// nolint: deadcode
+/*
+	@ID ListUsersDisabled
+	@Parameter disabled query
+		List users by their disabled state.
+		@JSONSchema
+			{ "type": "boolean" }
+*/
+type listUsersDisabled string
+
+// nolint: deadcode
+/*
+	@ID ListUsersCreatedAfter
+	@Parameter created_after query
+		List users created at or after the RFC3339 timestamp.
+		@JSONSchema
+			{ "type": "string", "format": "date-time" }
+*/
+type listUsersCreatedAfter string
+
+// nolint: deadcode
+/*
+	@ID ListUsersCreatedBefore
+	@Parameter created_before query
+		List users created before the RFC3339 timestamp.
+		@JSONSchema
+			{ "type": "string", "format": "date-time" }
+*/
+type listUsersCreatedBefore string
+
+type userLister interface {
+	List(filter user.Filter, offset uint64, limit uint64) ([]*model.User, error)
+	Count(filter user.Filter) (uint64, error)
+}
+
+/*
+	@Operation GET /admin/users - List users
+		List users, newest first.
+
+		@Tag Administration
+		@SecurityRequirement master_key
+
+		@Parameter {ListUsersOffset}
+		@Parameter {ListUsersLimit}
+		@Parameter {ListUsersLoginID}
+		@Parameter {ListUsersDisabled}
+		@Parameter {ListUsersCreatedAfter}
+		@Parameter {ListUsersCreatedBefore}
+
+		@Response 200
+			@JSONSchema {ListUsersResponse}
+*/
+type ListUsersHandler struct {
+	TxContext db.TxContext
+	Users     userLister
+}
+
+func (h *ListUsersHandler) ProvideAuthzPolicy() authz.Policy {
+	return policy.AllOf(
+		authz.PolicyFunc(policy.RequireMasterKey),
+	)
+}
+
+func (h *ListUsersHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	var response handler.APIResponse
+	result, err := h.Handle(r)
+	if err != nil {
+		response.Error = err
+	} else {
+		response.Result = result
+	}
+	handler.WriteResponse(rw, response)
+}
+
+func (h *ListUsersHandler) Handle(r *http.Request) (*ListUsersResponse, error) {
+	q := r.URL.Query()
+
+	offset, limit, err := parsePagination(q)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := parseUserFilter(q)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ListUsersResponse{}
+	err = db.WithTx(h.TxContext, func() error {
+		users, err := h.Users.List(filter, offset, limit)
+		if err != nil {
+			return err
+		}
+
+		count, err := h.Users.Count(filter)
+		if err != nil {
+			return err
+		}
+
+		resp.Users = users
+		resp.TotalCount = count
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Users == nil {
+		resp.Users = []*model.User{}
+	}
+
+	return resp, nil
+}
+
+func parsePagination(q url.Values) (offset uint64, limit uint64, err error) {
+	limit = defaultListUsersLimit
+
+	if s := q.Get("offset"); s != "" {
+		offset, err = strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			err = skyerr.NewBadRequest("invalid offset")
+			return
+		}
+	}
+
+	if s := q.Get("limit"); s != "" {
+		limit, err = strconv.ParseUint(s, 10, 64)
+		if err != nil || limit == 0 || limit > maxListUsersLimit {
+			err = skyerr.NewBadRequest("invalid limit")
+			return
+		}
+	}
+
+	return
+}
+
+func parseUserFilter(q url.Values) (filter user.Filter, err error) {
+	filter.LoginID = q.Get("login_id")
+
+	if s := q.Get("disabled"); s != "" {
+		var disabled bool
+		disabled, err = strconv.ParseBool(s)
+		if err != nil {
+			err = skyerr.NewBadRequest("invalid disabled")
+			return
+		}
+		filter.Disabled = &disabled
+	}
+
+	if s := q.Get("created_after"); s != "" {
+		var t time.Time
+		t, err = time.Parse(time.RFC3339, s)
+		if err != nil {
+			err = skyerr.NewBadRequest("invalid created_after")
+			return
+		}
+		t = t.UTC()
+		filter.CreatedAfter = &t
+	}
+
+	if s := q.Get("created_before"); s != "" {
+		var t time.Time
+		t, err = time.Parse(time.RFC3339, s)
+		if err != nil {
+			err = skyerr.NewBadRequest("invalid created_before")
+			return
+		}
+		t = t.UTC()
+		filter.CreatedBefore = &t
+	}
+
+	return
+}
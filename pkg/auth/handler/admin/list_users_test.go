@@ -0,0 +1,72 @@
+package admin
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/user"
+)
+
+func TestListUsersQuery(t *testing.T) {
+	Convey("parsePagination", t, func() {
+		Convey("should use default values", func() {
+			offset, limit, err := parsePagination(url.Values{})
+			So(err, ShouldBeNil)
+			So(offset, ShouldEqual, 0)
+			So(limit, ShouldEqual, defaultListUsersLimit)
+		})
+
+		Convey("should parse offset and limit", func() {
+			offset, limit, err := parsePagination(url.Values{
+				"offset": []string{"40"},
+				"limit":  []string{"100"},
+			})
+			So(err, ShouldBeNil)
+			So(offset, ShouldEqual, 40)
+			So(limit, ShouldEqual, 100)
+		})
+
+		Convey("should reject invalid limit", func() {
+			for _, l := range []string{"0", "101", "-1", "abc"} {
+				_, _, err := parsePagination(url.Values{"limit": []string{l}})
+				So(err, ShouldBeError, "invalid limit")
+			}
+		})
+	})
+
+	Convey("parseUserFilter", t, func() {
+		Convey("should parse empty filter", func() {
+			filter, err := parseUserFilter(url.Values{})
+			So(err, ShouldBeNil)
+			So(filter, ShouldResemble, user.Filter{})
+		})
+
+		Convey("should parse all filters", func() {
+			filter, err := parseUserFilter(url.Values{
+				"login_id":       []string{"john"},
+				"disabled":       []string{"true"},
+				"created_after":  []string{"2020-01-01T00:00:00+08:00"},
+				"created_before": []string{"2020-02-01T00:00:00Z"},
+			})
+			So(err, ShouldBeNil)
+
+			disabled := true
+			after := time.Date(2019, 12, 31, 16, 0, 0, 0, time.UTC)
+			before := time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+			So(filter, ShouldResemble, user.Filter{
+				LoginID:       "john",
+				Disabled:      &disabled,
+				CreatedAfter:  &after,
+				CreatedBefore: &before,
+			})
+		})
+
+		Convey("should reject invalid timestamp", func() {
+			_, err := parseUserFilter(url.Values{"created_after": []string{"yesterday"}})
+			So(err, ShouldBeError, "invalid created_after")
+		})
+	})
+}
@@ -0,0 +1,123 @@
+package admin
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	pkg "github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz/policy"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
+)
+
+func AttachRedeliverEventHandler(
+	router *mux.Router,
+	authDependency pkg.DependencyMap,
+) {
+	router.NewRoute().
+		Path("/_auth/admin/hooks/events/{event_id}/redeliver").
+		Handler(pkg.MakeHandler(authDependency, newRedeliverEventHandler)).
+		Methods("OPTIONS", "POST")
+}
+
+// HookEventDelivery is the delivery state of an event.
+type HookEventDelivery struct {
+	EventID       string     `json:"event_id"`
+	EventType     string     `json:"event_type"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	LastAttemptAt *time.Time `json:"last_attempt_at,omitempty"`
+	LastError     string     `json:"last_error,omitempty"`
+}
+
+// @JSONSchema
+const RedeliverEventResponseSchema = `
+{
+	"$id": "#RedeliverEventResponse",
+	"type": "object",
+	"properties": {
+		"result": {
+			"type": "object",
+			"properties": {
+				"event_id": { "type": "string" },
+				"event_type": { "type": "string" },
+				"status": { "type": "string", "enum": ["pending", "delivered", "failed"] },
+				"attempts": { "type": "integer" },
+				"last_attempt_at": { "type": "string", "format": "date-time" },
+				"last_error": { "type": "string" }
+			}
+		}
+	}
+}
+`
+
+// nolint: deadcode
+/*
+	@ID AdminHookEventID
+	@Parameter event_id path
+		ID of the event.
+		@JSONSchema
+			{ "type": "string" }
+*/
+type adminHookEventID string
+
+type eventRedeliverer interface {
+	Redeliver(eventID string) (*hook.Delivery, error)
+}
+
+/*
+	@Operation POST /admin/hooks/events/{event_id}/redeliver - Re-deliver event
+		Deliver a persisted event to its hooks again, including events
+		failed to be delivered after all attempts.
+
+		@Tag Administration
+		@SecurityRequirement master_key
+
+		@Parameter {AdminHookEventID}
+
+		@Response 200
+			@JSONSchema {RedeliverEventResponse}
+*/
+type RedeliverEventHandler struct {
+	Events eventRedeliverer
+}
+
+func (h *RedeliverEventHandler) ProvideAuthzPolicy() authz.Policy {
+	return policy.AllOf(
+		authz.PolicyFunc(policy.RequireMasterKey),
+	)
+}
+
+func (h *RedeliverEventHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	var response handler.APIResponse
+	result, err := h.Handle(r)
+	if err != nil {
+		response.Error = err
+	} else {
+		response.Result = result
+	}
+	handler.WriteResponse(rw, response)
+}
+
+func (h *RedeliverEventHandler) Handle(r *http.Request) (*HookEventDelivery, error) {
+	eventID := mux.Vars(r)["event_id"]
+
+	// Transactions are managed by the worker, so that no transaction is
+	// held while the event is being delivered.
+	d, err := h.Events.Redeliver(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HookEventDelivery{
+		EventID:       d.Event.ID,
+		EventType:     string(d.Event.Type),
+		Status:        string(d.Status),
+		Attempts:      d.Attempts,
+		LastAttemptAt: d.LastAttemptAt,
+		LastError:     d.LastError,
+	}, nil
+}
@@ -0,0 +1,91 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	pkg "github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz/policy"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
+)
+
+func AttachRevokeUserSessionHandler(
+	router *mux.Router,
+	authDependency pkg.DependencyMap,
+) {
+	router.NewRoute().
+		Path("/_auth/admin/users/{user_id}/sessions/{session_id}").
+		Handler(pkg.MakeHandler(authDependency, newRevokeUserSessionHandler)).
+		Methods("OPTIONS", "DELETE")
+}
+
+// nolint: deadcode
+/*
+	@ID AdminSessionID
+	@Parameter session_id path
+		ID of the session.
+		@JSONSchema
+			{ "type": "string" }
+*/
+type adminSessionID string
+
+type sessionRevoker interface {
+	GetByUser(userID string, id string) (auth.AuthSession, error)
+	Revoke(session auth.AuthSession) error
+}
+
+/*
+	@Operation DELETE /admin/users/{user_id}/sessions/{session_id} - Revoke user session
+		Revoke a session of a user.
+
+		@Tag Administration
+		@SecurityRequirement master_key
+
+		@Parameter {AdminUserID}
+		@Parameter {AdminSessionID}
+
+		@Response 200 {EmptyResponse}
+*/
+type RevokeUserSessionHandler struct {
+	TxContext db.TxContext
+	Sessions  sessionRevoker
+}
+
+func (h *RevokeUserSessionHandler) ProvideAuthzPolicy() authz.Policy {
+	return policy.AllOf(
+		authz.PolicyFunc(policy.RequireMasterKey),
+	)
+}
+
+func (h *RevokeUserSessionHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	var response handler.APIResponse
+	result, err := h.Handle(r)
+	if err != nil {
+		response.Error = err
+	} else {
+		response.Result = result
+	}
+	handler.WriteResponse(rw, response)
+}
+
+func (h *RevokeUserSessionHandler) Handle(r *http.Request) (interface{}, error) {
+	userID := mux.Vars(r)["user_id"]
+	sessionID := mux.Vars(r)["session_id"]
+
+	return handler.Transactional(h.TxContext, func() (interface{}, error) {
+		session, err := h.Sessions.GetByUser(userID, sessionID)
+		if err != nil {
+			return nil, err
+		}
+
+		err = h.Sessions.Revoke(session)
+		if err != nil {
+			return nil, err
+		}
+		return struct{}{}, nil
+	})
+}
@@ -0,0 +1,72 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	pkg "github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz/policy"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
+)
+
+func AttachRevokeUserSessionsHandler(
+	router *mux.Router,
+	authDependency pkg.DependencyMap,
+) {
+	router.NewRoute().
+		Path("/_auth/admin/users/{user_id}/sessions").
+		Handler(pkg.MakeHandler(authDependency, newRevokeUserSessionsHandler)).
+		Methods("OPTIONS", "DELETE")
+}
+
+type allSessionsRevoker interface {
+	RevokeAll(userID string, exceptID string) error
+}
+
+/*
+	@Operation DELETE /admin/users/{user_id}/sessions - Revoke all user sessions
+		Revoke all sessions of a user.
+
+		@Tag Administration
+		@SecurityRequirement master_key
+
+		@Parameter {AdminUserID}
+
+		@Response 200 {EmptyResponse}
+*/
+type RevokeUserSessionsHandler struct {
+	TxContext db.TxContext
+	Sessions  allSessionsRevoker
+}
+
+func (h *RevokeUserSessionsHandler) ProvideAuthzPolicy() authz.Policy {
+	return policy.AllOf(
+		authz.PolicyFunc(policy.RequireMasterKey),
+	)
+}
+
+func (h *RevokeUserSessionsHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	var response handler.APIResponse
+	result, err := h.Handle(r)
+	if err != nil {
+		response.Error = err
+	} else {
+		response.Result = result
+	}
+	handler.WriteResponse(rw, response)
+}
+
+func (h *RevokeUserSessionsHandler) Handle(r *http.Request) (interface{}, error) {
+	userID := mux.Vars(r)["user_id"]
+
+	return handler.Transactional(h.TxContext, func() (interface{}, error) {
+		err := h.Sessions.RevokeAll(userID, "")
+		if err != nil {
+			return nil, err
+		}
+		return struct{}{}, nil
+	})
+}
@@ -0,0 +1,114 @@
+package admin
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	pkg "github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz/policy"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
+	"github.com/skygeario/skygear-server/pkg/core/validation"
+)
+
+func AttachSetUserDisabledHandler(
+	router *mux.Router,
+	authDependency pkg.DependencyMap,
+) {
+	router.NewRoute().
+		Path("/_auth/admin/users/{user_id}/disabled").
+		Handler(pkg.MakeHandler(authDependency, newSetUserDisabledHandler)).
+		Methods("OPTIONS", "POST")
+}
+
+type SetUserDisabledRequest struct {
+	Disabled bool       `json:"disabled"`
+	Message  string     `json:"message"`
+	Expiry   *time.Time `json:"expiry"`
+}
+
+// @JSONSchema
+const SetUserDisabledRequestSchema = `
+{
+	"$id": "#SetUserDisabledRequest",
+	"type": "object",
+	"properties": {
+		"disabled": { "type": "boolean" },
+		"message": { "type": "string" },
+		"expiry": { "type": "string", "format": "date-time" }
+	},
+	"required": ["disabled"]
+}
+`
+
+func (p *SetUserDisabledRequest) Validate() []validation.ErrorCause {
+	if !p.Disabled && (p.Message != "" || p.Expiry != nil) {
+		return []validation.ErrorCause{{
+			Kind:    validation.ErrorGeneral,
+			Pointer: "/disabled",
+			Message: "message and expiry are only allowed when disabling user",
+		}}
+	}
+	return nil
+}
+
+type userDisabler interface {
+	SetDisabled(userID string, disabled bool, message string, expiry *time.Time) error
+}
+
+/*
+	@Operation POST /admin/users/{user_id}/disabled - Set user disabled state
+		Disable or re-enable a user. A disabled user cannot log in.
+		If expiry is given, the user is re-enabled automatically after it.
+
+		@Tag Administration
+		@SecurityRequirement master_key
+
+		@Parameter {AdminUserID}
+		@RequestBody
+			@JSONSchema {SetUserDisabledRequest}
+
+		@Response 200 {EmptyResponse}
+*/
+type SetUserDisabledHandler struct {
+	TxContext db.TxContext
+	Validator *validation.Validator
+	Users     userDisabler
+}
+
+func (h *SetUserDisabledHandler) ProvideAuthzPolicy() authz.Policy {
+	return policy.AllOf(
+		authz.PolicyFunc(policy.RequireMasterKey),
+	)
+}
+
+func (h *SetUserDisabledHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	var response handler.APIResponse
+	result, err := h.Handle(rw, r)
+	if err != nil {
+		response.Error = err
+	} else {
+		response.Result = result
+	}
+	handler.WriteResponse(rw, response)
+}
+
+func (h *SetUserDisabledHandler) Handle(rw http.ResponseWriter, r *http.Request) (interface{}, error) {
+	var payload SetUserDisabledRequest
+	if err := handler.BindJSONBody(r, rw, h.Validator, "#SetUserDisabledRequest", &payload); err != nil {
+		return nil, err
+	}
+
+	userID := mux.Vars(r)["user_id"]
+
+	return handler.Transactional(h.TxContext, func() (interface{}, error) {
+		err := h.Users.SetDisabled(userID, payload.Disabled, payload.Message, payload.Expiry)
+		if err != nil {
+			return nil, err
+		}
+		return struct{}{}, nil
+	})
+}
@@ -0,0 +1,146 @@
+//+build wireinject
+
+package admin
+
+import (
+	"net/http"
+
+	"github.com/google/wire"
+
+	"github.com/skygeario/skygear-server/pkg/auth"
+	authdep "github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
+	authenticatorprovider "github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/provider"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
+	identityprovider "github.com/skygeario/skygear-server/pkg/auth/dependency/identity/provider"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/user"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
+)
+
+func provideListUsersHandler(requireAuthz handler.RequireAuthz, h *ListUsersHandler) http.Handler {
+	return requireAuthz(h, h)
+}
+
+func newListUsersHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	wire.Build(
+		auth.DependencySet,
+		wire.Bind(new(userLister), new(*user.Queries)),
+		wire.Struct(new(ListUsersHandler), "*"),
+		provideListUsersHandler,
+	)
+	return nil
+}
+
+func provideGetUserHandler(requireAuthz handler.RequireAuthz, h *GetUserHandler) http.Handler {
+	return requireAuthz(h, h)
+}
+
+func newGetUserHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	wire.Build(
+		auth.DependencySet,
+		wire.Bind(new(userGetter), new(*user.Queries)),
+		wire.Bind(new(identityLister), new(*identityprovider.Provider)),
+		wire.Bind(new(authenticatorLister), new(*authenticatorprovider.Provider)),
+		wire.Struct(new(GetUserHandler), "*"),
+		provideGetUserHandler,
+	)
+	return nil
+}
+
+func provideSetUserDisabledHandler(requireAuthz handler.RequireAuthz, h *SetUserDisabledHandler) http.Handler {
+	return requireAuthz(h, h)
+}
+
+func newSetUserDisabledHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	wire.Build(
+		auth.DependencySet,
+		wire.Bind(new(userDisabler), new(*user.Commands)),
+		wire.Struct(new(SetUserDisabledHandler), "*"),
+		provideSetUserDisabledHandler,
+	)
+	return nil
+}
+
+func provideDeleteUserHandler(requireAuthz handler.RequireAuthz, h *DeleteUserHandler) http.Handler {
+	return requireAuthz(h, h)
+}
+
+func newDeleteUserHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	wire.Build(
+		auth.DependencySet,
+		wire.Bind(new(userDeleter), new(*user.Commands)),
+		wire.Struct(new(DeleteUserHandler), "*"),
+		provideDeleteUserHandler,
+	)
+	return nil
+}
+
+func provideListHookDeliveryAttemptsHandler(requireAuthz handler.RequireAuthz, h *ListHookDeliveryAttemptsHandler) http.Handler {
+	return requireAuthz(h, h)
+}
+
+func newListHookDeliveryAttemptsHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	wire.Build(
+		auth.DependencySet,
+		wire.Bind(new(attemptLister), new(hook.AttemptStore)),
+		wire.Struct(new(ListHookDeliveryAttemptsHandler), "*"),
+		provideListHookDeliveryAttemptsHandler,
+	)
+	return nil
+}
+
+func provideRedeliverEventHandler(requireAuthz handler.RequireAuthz, h *RedeliverEventHandler) http.Handler {
+	return requireAuthz(h, h)
+}
+
+func newRedeliverEventHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	wire.Build(
+		auth.DependencySet,
+		wire.Bind(new(eventRedeliverer), new(*hook.DeliveryWorker)),
+		wire.Struct(new(RedeliverEventHandler), "*"),
+		provideRedeliverEventHandler,
+	)
+	return nil
+}
+
+func provideListUserSessionsHandler(requireAuthz handler.RequireAuthz, h *ListUserSessionsHandler) http.Handler {
+	return requireAuthz(h, h)
+}
+
+func newListUserSessionsHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	wire.Build(
+		auth.DependencySet,
+		wire.Bind(new(userGetter), new(*user.Queries)),
+		wire.Bind(new(sessionLister), new(*authdep.SessionManager)),
+		wire.Struct(new(ListUserSessionsHandler), "*"),
+		provideListUserSessionsHandler,
+	)
+	return nil
+}
+
+func provideRevokeUserSessionHandler(requireAuthz handler.RequireAuthz, h *RevokeUserSessionHandler) http.Handler {
+	return requireAuthz(h, h)
+}
+
+func newRevokeUserSessionHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	wire.Build(
+		auth.DependencySet,
+		wire.Bind(new(sessionRevoker), new(*authdep.SessionManager)),
+		wire.Struct(new(RevokeUserSessionHandler), "*"),
+		provideRevokeUserSessionHandler,
+	)
+	return nil
+}
+
+func provideRevokeUserSessionsHandler(requireAuthz handler.RequireAuthz, h *RevokeUserSessionsHandler) http.Handler {
+	return requireAuthz(h, h)
+}
+
+func newRevokeUserSessionsHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	wire.Build(
+		auth.DependencySet,
+		wire.Bind(new(allSessionsRevoker), new(*authdep.SessionManager)),
+		wire.Struct(new(RevokeUserSessionsHandler), "*"),
+		provideRevokeUserSessionsHandler,
+	)
+	return nil
+}
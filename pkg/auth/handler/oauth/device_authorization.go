@@ -0,0 +1,63 @@
+package oauth
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	pkg "github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/protocol"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+)
+
+func AttachDeviceAuthorizationHandler(
+	router *mux.Router,
+	authDependency pkg.DependencyMap,
+) {
+	router.NewRoute().
+		Path("/oauth2/device_authorization").
+		Handler(pkg.MakeHandler(authDependency, newDeviceAuthorizationHandler)).
+		Methods("POST", "OPTIONS")
+}
+
+type oauthDeviceAuthorizationHandler interface {
+	Handle(r protocol.DeviceAuthorizationRequest) (protocol.DeviceAuthorizationResponse, error)
+}
+
+type DeviceAuthorizationHandler struct {
+	logger        *logrus.Entry
+	txContext     db.TxContext
+	deviceHandler oauthDeviceAuthorizationHandler
+}
+
+func (h *DeviceAuthorizationHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		http.Error(rw, err.Error(), 400)
+		return
+	}
+
+	req := protocol.DeviceAuthorizationRequest{}
+	for name, values := range r.Form {
+		req[name] = values[0]
+	}
+
+	var resp protocol.DeviceAuthorizationResponse
+	err = db.WithTx(h.txContext, func() (err error) {
+		resp, err = h.deviceHandler.Handle(req)
+		return
+	})
+
+	rw.Header().Set("Content-Type", "application/json")
+	rw.Header().Set("Cache-Control", "no-store")
+	rw.Header().Set("Pragma", "no-cache")
+
+	if err != nil {
+		writeError(rw, h.logger, err, "oauth device authorization handler failed")
+		return
+	}
+
+	_ = json.NewEncoder(rw).Encode(resp)
+}
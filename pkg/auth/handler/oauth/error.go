@@ -0,0 +1,35 @@
+package oauth
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/protocol"
+)
+
+// writeError writes the error response of OAuth endpoints. OAuth protocol
+// errors are returned to the client; other errors are logged as internal
+// server errors.
+func writeError(rw http.ResponseWriter, logger *logrus.Entry, err error, message string) {
+	var oauthError *protocol.OAuthProtocolError
+	if !errors.As(err, &oauthError) {
+		logger.WithError(err).Error(message)
+		http.Error(rw, "Internal Server Error", 500)
+		return
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+	switch oauthError.Response["error"] {
+	case "invalid_client":
+		rw.WriteHeader(http.StatusUnauthorized)
+	case "invalid_token":
+		rw.Header().Set("WWW-Authenticate", oauthError.Response.ToWWWAuthenticateHeader())
+		rw.WriteHeader(http.StatusUnauthorized)
+	default:
+		rw.WriteHeader(http.StatusBadRequest)
+	}
+	_ = json.NewEncoder(rw).Encode(oauthError.Response)
+}
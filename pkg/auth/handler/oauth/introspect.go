@@ -0,0 +1,71 @@
+package oauth
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	pkg "github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/protocol"
+	coreauth "github.com/skygeario/skygear-server/pkg/core/auth"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+)
+
+func AttachIntrospectHandler(
+	router *mux.Router,
+	authDependency pkg.DependencyMap,
+) {
+	router.NewRoute().
+		Path("/oauth2/introspect").
+		Handler(pkg.MakeHandler(authDependency, newIntrospectHandler)).
+		Methods("POST", "OPTIONS")
+}
+
+type oauthIntrospectHandler interface {
+	Authenticate(r protocol.IntrospectRequest) error
+	Handle(r protocol.IntrospectRequest) (protocol.IntrospectResponse, error)
+}
+
+type IntrospectHandler struct {
+	logger            *logrus.Entry
+	txContext         db.TxContext
+	introspectHandler oauthIntrospectHandler
+}
+
+func (h *IntrospectHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		http.Error(rw, err.Error(), 400)
+		return
+	}
+
+	req := protocol.IntrospectRequest{}
+	for name, values := range r.Form {
+		req[name] = values[0]
+	}
+
+	var resp protocol.IntrospectResponse
+	err = db.WithTx(h.txContext, func() error {
+		// Requests with master key need not authenticate as client.
+		if !coreauth.GetAccessKey(r.Context()).IsMasterKey {
+			if err := h.introspectHandler.Authenticate(req); err != nil {
+				return err
+			}
+		}
+
+		resp, err = h.introspectHandler.Handle(req)
+		return err
+	})
+
+	if err != nil {
+		writeError(rw, h.logger, err, "oauth introspect handler failed")
+		return
+	}
+
+	rw.Header().Set("Content-Type", "application/json")
+	rw.Header().Set("Cache-Control", "no-store")
+	rw.Header().Set("Pragma", "no-cache")
+	_ = json.NewEncoder(rw).Encode(resp)
+}
@@ -4,12 +4,11 @@ import (
 	"encoding/json"
 	"net/http"
 
-	"github.com/dgrijalva/jwt-go"
 	"github.com/gorilla/mux"
-	"github.com/lestrrat-go/jwx/jwk"
 
 	pkg "github.com/skygeario/skygear-server/pkg/auth"
 	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/jwt"
 )
 
 func AttachJWKSHandler(
@@ -28,25 +27,24 @@ type JWKSHandler struct {
 }
 
 func (h *JWKSHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
-	jwks := jwk.Set{}
-	for _, key := range h.config.Keys {
-		pubKey, err := jwt.ParseRSAPublicKeyFromPEM([]byte(key.PublicKey))
+	// Retired keys are not published, while published keys are, so that
+	// tokens signed before rotation can still be verified.
+	keys := []interface{}{}
+	for _, key := range h.config.PublishedKeys() {
+		pubKey, err := jwt.ParsePublicKeyFromPEM([]byte(key.PublicKey))
 		if err != nil {
 			http.Error(rw, err.Error(), 500)
 			return
 		}
 
-		k, err := jwk.New(pubKey)
+		k, err := jwt.PublicJWK(key.KID, pubKey)
 		if err != nil {
 			http.Error(rw, err.Error(), 500)
 			return
 		}
-
-		k.Set(jwk.KeyUsageKey, jwk.ForSignature)
-		k.Set(jwk.AlgorithmKey, "RS256")
-		k.Set(jwk.KeyIDKey, key.KID)
-		jwks.Keys = append(jwks.Keys, k)
+		keys = append(keys, k)
 	}
+	jwks := map[string]interface{}{"keys": keys}
 
 	rw.Header().Set("Content-Type", "application/json")
 
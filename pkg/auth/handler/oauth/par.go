@@ -0,0 +1,64 @@
+package oauth
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	pkg "github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/protocol"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+)
+
+func AttachPushedAuthorizationRequestHandler(
+	router *mux.Router,
+	authDependency pkg.DependencyMap,
+) {
+	router.NewRoute().
+		Path("/oauth2/par").
+		Handler(pkg.MakeHandler(authDependency, newPushedAuthorizationRequestHandler)).
+		Methods("POST", "OPTIONS")
+}
+
+type oauthPushedAuthorizationRequestHandler interface {
+	Handle(r protocol.PushedAuthorizationRequest) (protocol.PushedAuthorizationResponse, error)
+}
+
+type PushedAuthorizationRequestHandler struct {
+	logger     *logrus.Entry
+	txContext  db.TxContext
+	parHandler oauthPushedAuthorizationRequestHandler
+}
+
+func (h *PushedAuthorizationRequestHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		http.Error(rw, err.Error(), 400)
+		return
+	}
+
+	req := protocol.PushedAuthorizationRequest{}
+	for name, values := range r.PostForm {
+		req[name] = values[0]
+	}
+
+	var resp protocol.PushedAuthorizationResponse
+	err = db.WithTx(h.txContext, func() (err error) {
+		resp, err = h.parHandler.Handle(req)
+		return
+	})
+
+	rw.Header().Set("Content-Type", "application/json")
+	rw.Header().Set("Cache-Control", "no-store")
+	rw.Header().Set("Pragma", "no-cache")
+
+	if err != nil {
+		writeError(rw, h.logger, err, "oauth par handler failed")
+		return
+	}
+
+	rw.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(rw).Encode(resp)
+}
@@ -0,0 +1,93 @@
+package oauth
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	pkg "github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/protocol"
+	coreauth "github.com/skygeario/skygear-server/pkg/core/auth"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	corehttp "github.com/skygeario/skygear-server/pkg/core/http"
+)
+
+func AttachRegisterHandler(
+	router *mux.Router,
+	authDependency pkg.DependencyMap,
+) {
+	router.NewRoute().
+		Path("/oauth2/register").
+		Handler(pkg.MakeHandler(authDependency, newRegisterHandler)).
+		Methods("POST", "OPTIONS")
+	router.NewRoute().
+		Path("/oauth2/register/{client_id}").
+		Handler(pkg.MakeHandler(authDependency, newRegisterHandler)).
+		Methods("GET", "PUT", "DELETE", "OPTIONS")
+}
+
+type oauthRegisterHandler interface {
+	AuthenticateInitialAccessToken(token string) bool
+	Register(r protocol.ClientMetadata, isMasterKey bool) (protocol.ClientInformationResponse, error)
+	Get(clientID string, token string) (protocol.ClientInformationResponse, error)
+	Update(clientID string, token string, r protocol.ClientMetadata) (protocol.ClientInformationResponse, error)
+	Delete(clientID string, token string) error
+}
+
+type RegisterHandler struct {
+	logger          *logrus.Entry
+	txContext       db.TxContext
+	registerHandler oauthRegisterHandler
+}
+
+func (h *RegisterHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	clientID := mux.Vars(r)["client_id"]
+	token := corehttp.GetBearerToken(r)
+
+	var req protocol.ClientMetadata
+	if r.Method == "POST" || r.Method == "PUT" {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(rw, h.logger, protocol.NewError("invalid_client_metadata", "invalid request body"), "oauth register handler failed")
+			return
+		}
+	}
+
+	var resp protocol.ClientInformationResponse
+	status := http.StatusOK
+	err := db.WithTx(h.txContext, func() (err error) {
+		switch r.Method {
+		case "POST":
+			isMasterKey := coreauth.GetAccessKey(r.Context()).IsMasterKey
+			if !isMasterKey && !h.registerHandler.AuthenticateInitialAccessToken(token) {
+				return protocol.NewError("invalid_token", "invalid initial access token")
+			}
+			resp, err = h.registerHandler.Register(req, isMasterKey)
+			status = http.StatusCreated
+		case "GET":
+			resp, err = h.registerHandler.Get(clientID, token)
+		case "PUT":
+			resp, err = h.registerHandler.Update(clientID, token, req)
+		case "DELETE":
+			err = h.registerHandler.Delete(clientID, token)
+			status = http.StatusNoContent
+		}
+		return
+	})
+
+	if err != nil {
+		writeError(rw, h.logger, err, "oauth register handler failed")
+		return
+	}
+
+	rw.Header().Set("Cache-Control", "no-store")
+	rw.Header().Set("Pragma", "no-cache")
+	if resp == nil {
+		rw.WriteHeader(status)
+		return
+	}
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(status)
+	_ = json.NewEncoder(rw).Encode(resp)
+}
@@ -48,7 +48,6 @@ func (h *RevokeHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	})
 
 	if err != nil {
-		h.logger.WithError(err).Error("oauth revoke handler failed")
-		http.Error(rw, "Internal Server Error", 500)
+		writeError(rw, h.logger, err, "oauth revoke handler failed")
 	}
 }
@@ -10,6 +10,7 @@ import (
 	pkg "github.com/skygeario/skygear-server/pkg/auth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/protocol"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/oidc"
 	"github.com/skygeario/skygear-server/pkg/core/db"
 )
@@ -38,6 +39,14 @@ type UserInfoHandler struct {
 
 func (h *UserInfoHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 	session := auth.GetSession(r.Context())
+	// Client sessions are not associated with any user.
+	if session.SessionType() == auth.SessionTypeClient {
+		errResp := protocol.NewErrorResponse("insufficient_scope", "access token is not issued to user")
+		rw.Header().Add("WWW-Authenticate", errResp.ToWWWAuthenticateHeader())
+		rw.WriteHeader(http.StatusForbidden)
+		return
+	}
+
 	var claims *oidc.UserClaims
 	err := db.WithTx(h.txContext, func() (err error) {
 		claims, err = h.userInfoProvider.LoadUserClaims(session)
@@ -73,6 +73,78 @@ func newRevokeHandler(r *http.Request, m auth.DependencyMap) http.Handler {
 	return nil
 }
 
+func provideIntrospectHandler(lf logging.Factory, tx db.TxContext, ih oauthIntrospectHandler) http.Handler {
+	h := &IntrospectHandler{
+		logger:            lf.NewLogger("oauth-introspect-handler"),
+		txContext:         tx,
+		introspectHandler: ih,
+	}
+	return h
+}
+
+func newIntrospectHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	wire.Build(
+		auth.DependencySet,
+		wire.Bind(new(oauthIntrospectHandler), new(*oauthhandler.IntrospectionHandler)),
+		provideIntrospectHandler,
+	)
+	return nil
+}
+
+func provideDeviceAuthorizationHandler(lf logging.Factory, tx db.TxContext, dh oauthDeviceAuthorizationHandler) http.Handler {
+	h := &DeviceAuthorizationHandler{
+		logger:        lf.NewLogger("oauth-device-authorization-handler"),
+		txContext:     tx,
+		deviceHandler: dh,
+	}
+	return h
+}
+
+func newDeviceAuthorizationHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	wire.Build(
+		auth.DependencySet,
+		wire.Bind(new(oauthDeviceAuthorizationHandler), new(*oauthhandler.DeviceAuthorizationHandler)),
+		provideDeviceAuthorizationHandler,
+	)
+	return nil
+}
+
+func providePushedAuthorizationRequestHandler(lf logging.Factory, tx db.TxContext, ph oauthPushedAuthorizationRequestHandler) http.Handler {
+	h := &PushedAuthorizationRequestHandler{
+		logger:     lf.NewLogger("oauth-par-handler"),
+		txContext:  tx,
+		parHandler: ph,
+	}
+	return h
+}
+
+func newPushedAuthorizationRequestHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	wire.Build(
+		auth.DependencySet,
+		wire.Bind(new(oauthPushedAuthorizationRequestHandler), new(*oauthhandler.PushedAuthorizationRequestHandler)),
+		providePushedAuthorizationRequestHandler,
+	)
+	return nil
+}
+
+func provideRegisterHandler(lf logging.Factory, tx db.TxContext, rh oauthRegisterHandler) http.Handler {
+	h := &RegisterHandler{
+		logger:          lf.NewLogger("oauth-register-handler"),
+		txContext:       tx,
+		registerHandler: rh,
+	}
+	return h
+}
+
+func newRegisterHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	wire.Build(
+		auth.DependencySet,
+		wire.Bind(new(oauthRegisterHandler), new(*oauthhandler.ClientRegistrationHandler)),
+		provideRegisterHandler,
+	)
+	return nil
+}
+
 func provideMetadataHandler(oauth *oauth.MetadataProvider, oidc *oidc.MetadataProvider) http.Handler {
 	h := &MetadataHandler{
 		metaProviders: []oauthMetadataProvider{oauth, oidc},
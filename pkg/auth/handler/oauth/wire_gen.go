@@ -8,33 +8,38 @@ package oauth
 import (
 	"github.com/skygeario/skygear-server/pkg/auth"
 	auth2 "github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
-	redis3 "github.com/skygeario/skygear-server/pkg/auth/dependency/auth/redis"
+	redis4 "github.com/skygeario/skygear-server/pkg/auth/dependency/auth/redis"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/bearertoken"
+	ldap3 "github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/ldap"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/oob"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/password"
 	provider2 "github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/provider"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/recoverycode"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/totp"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/challenge"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/forgotpassword"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/anonymous"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/ldap"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/loginid"
-	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/oauth"
+	oauth2 "github.com/skygeario/skygear-server/pkg/auth/dependency/identity/oauth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/provider"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/interaction"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/interaction/flows"
 	redis2 "github.com/skygeario/skygear-server/pkg/auth/dependency/interaction/redis"
-	oauth2 "github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
+	ldap2 "github.com/skygeario/skygear-server/pkg/auth/dependency/ldap"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/handler"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/pq"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/redis"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/oidc"
 	handler2 "github.com/skygeario/skygear-server/pkg/auth/dependency/oidc/handler"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/session"
-	redis4 "github.com/skygeario/skygear-server/pkg/auth/dependency/session/redis"
+	redis3 "github.com/skygeario/skygear-server/pkg/auth/dependency/session/redis"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/urlprefix"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/user"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/userprofile"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/userverify"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/webapp"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/welcomemessage"
 	"github.com/skygeario/skygear-server/pkg/core/async"
@@ -56,13 +61,20 @@ func newAuthorizeHandler(r *http.Request, m auth.DependencyMap) http.Handler {
 	sqlBuilderFactory := db.ProvideSQLBuilderFactory(tenantConfiguration)
 	sqlBuilder := auth.ProvideAuthSQLBuilder(sqlBuilderFactory)
 	sqlExecutor := db.ProvideSQLExecutor(context, tenantConfiguration)
-	authorizationStore := &pq.AuthorizationStore{
+	clientStore := &pq.ClientStore{
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
+	clientResolver := oauth.ProvideClientResolver(tenantConfiguration, clientStore)
+	urlprefixProvider := urlprefix.NewProvider(r)
 	timeProvider := time.NewProvider()
 	grantStore := redis.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
-	urlprefixProvider := urlprefix.NewProvider(r)
+	tokenGenerator := _wireTokenGeneratorValue
+	authorizationRequestResolver := handler.ProvideAuthorizationRequestResolver(tenantConfiguration, urlprefixProvider, grantStore, tokenGenerator, timeProvider)
+	authorizationStore := &pq.AuthorizationStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
 	endpointsProvider := &auth.EndpointsProvider{
 		PrefixProvider: urlprefixProvider,
 	}
@@ -76,9 +88,10 @@ func newAuthorizeHandler(r *http.Request, m auth.DependencyMap) http.Handler {
 	checker := loginid.ProvideChecker(tenantConfiguration, typeCheckerFactory)
 	normalizerFactory := loginid.ProvideNormalizerFactory(tenantConfiguration)
 	loginidProvider := loginid.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration, checker, normalizerFactory)
-	oauthProvider := oauth.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	oauthProvider := oauth2.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
 	anonymousProvider := anonymous.ProvideProvider(sqlBuilder, sqlExecutor)
-	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider)
+	ldapProvider := ldap.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider, ldapProvider)
 	historyStoreImpl := password.ProvideHistoryStore(timeProvider, sqlBuilder, sqlExecutor)
 	passwordChecker := password.ProvideChecker(tenantConfiguration, historyStoreImpl)
 	passwordProvider := password.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, factory, historyStoreImpl, passwordChecker, tenantConfiguration)
@@ -89,35 +102,58 @@ func newAuthorizeHandler(r *http.Request, m auth.DependencyMap) http.Handler {
 	oobProvider := oob.ProvideProvider(context, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider, engine, urlprefixProvider, queue)
 	bearertokenProvider := bearertoken.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
 	recoverycodeProvider := recoverycode.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
-	provider3 := &provider2.Provider{
+	directory := ldap2.ProvideDirectory(tenantConfiguration)
+	provider3 := ldap3.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, directory)
+	provider4 := &provider2.Provider{
 		Password:     passwordProvider,
 		TOTP:         totpProvider,
 		OOBOTP:       oobProvider,
 		BearerToken:  bearertokenProvider,
 		RecoveryCode: recoverycodeProvider,
+		LDAP:         provider3,
 	}
 	authinfoStore := pq2.ProvideStore(sqlBuilderFactory, sqlExecutor)
 	userprofileStore := userprofile.ProvideStore(timeProvider, sqlBuilder, sqlExecutor)
+	userStore := user.ProvideStore(sqlBuilderFactory, sqlExecutor, timeProvider)
 	queries := &user.Queries{
 		AuthInfos:    authinfoStore,
 		UserProfiles: userprofileStore,
 		Identities:   providerProvider,
 		Time:         timeProvider,
+		Store:        userStore,
+	}
+	hookStore := hook.ProvideStore(tenantConfiguration, sqlBuilder, sqlExecutor)
+	attemptStore := hook.ProvideAttemptStore(context, tenantConfiguration, sqlBuilder)
+	deliverer := hook.ProvideDeliverer(tenantConfiguration, timeProvider, authinfoStore, userprofileStore, loginidProvider, attemptStore, factory)
+	hookProvider := hook.ProvideHookProvider(context, tenantConfiguration, sqlBuilder, hookStore, txContext, timeProvider, queries, deliverer, queue, executor, factory)
+	sessionStore := redis3.ProvideStore(context, tenantConfiguration, timeProvider, factory)
+	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
+	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	manager := session.ProvideSessionManager(sessionStore, timeProvider, tenantConfiguration, cookieConfiguration)
+	sessionManager := &oauth.SessionManager{
+		Store: grantStore,
+		Time:  timeProvider,
+	}
+	eventStore := redis4.ProvideEventStore(context, tenantConfiguration)
+	logoutNotifier := oidc.ProvideLogoutNotifier(tenantConfiguration, urlprefixProvider, clientResolver, queue, timeProvider)
+	authSessionManager := auth2.ProvideSessionManager(queries, hookProvider, manager, sessionManager, eventStore, logoutNotifier, factory)
+	userverifyStore := userverify.ProvideStore(sqlBuilder, sqlExecutor)
+	storeImpl := &forgotpassword.StoreImpl{
+		Context: context,
 	}
-	hookProvider := hook.ProvideHookProvider(context, sqlBuilder, sqlExecutor, tenantConfiguration, txContext, timeProvider, queries, authinfoStore, userprofileStore, loginidProvider, factory)
 	welcomemessageProvider := welcomemessage.ProvideProvider(context, tenantConfiguration, engine, queue)
-	commands := user.ProvideCommands(authinfoStore, userprofileStore, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
+	commands := user.ProvideCommands(authinfoStore, userprofileStore, providerProvider, provider4, authSessionManager, authorizationStore, userverifyStore, storeImpl, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
-	interactionProvider := interaction.ProvideProvider(store, timeProvider, factory, providerProvider, provider3, userProvider, oobProvider, tenantConfiguration, hookProvider)
-	provider4 := challenge.ProvideProvider(context, timeProvider, tenantConfiguration)
+	interactionProvider := interaction.ProvideProvider(store, timeProvider, factory, providerProvider, provider4, userProvider, oobProvider, tenantConfiguration, hookProvider)
+	provider5 := challenge.ProvideProvider(context, timeProvider, tenantConfiguration)
 	anonymousFlow := &flows.AnonymousFlow{
 		Enabled:      isAnonymousIdentityEnabled,
 		Interactions: interactionProvider,
 		Anonymous:    anonymousProvider,
-		Challenges:   provider4,
+		Challenges:   provider5,
 	}
 	stateStoreImpl := &webapp.StateStoreImpl{
 		Context: context,
@@ -127,16 +163,14 @@ func newAuthorizeHandler(r *http.Request, m auth.DependencyMap) http.Handler {
 		Anonymous: anonymousFlow,
 		States:    stateStoreImpl,
 	}
-	scopesValidator := _wireScopesValidatorValue
-	tokenGenerator := _wireTokenGeneratorValue
-	authorizationHandler := handler.ProvideAuthorizationHandler(context, tenantConfiguration, factory, authorizationStore, grantStore, urlProvider, webappURLProvider, scopesValidator, tokenGenerator, timeProvider)
+	scopesValidator := oidc.ProvideScopesValidator(tenantConfiguration)
+	authorizationHandler := handler.ProvideAuthorizationHandler(context, tenantConfiguration, factory, clientResolver, authorizationRequestResolver, authorizationStore, grantStore, urlProvider, webappURLProvider, webappURLProvider, scopesValidator, tokenGenerator, timeProvider)
 	httpHandler := provideAuthorizeHandler(factory, txContext, authorizationHandler)
 	return httpHandler
 }
 
 var (
-	_wireScopesValidatorValue = handler.ScopesValidator(oidc.ValidateScopes)
-	_wireTokenGeneratorValue  = handler.TokenGenerator(oauth2.GenerateToken)
+	_wireTokenGeneratorValue = handler.TokenGenerator(oauth.GenerateToken)
 )
 
 func newTokenHandler(r *http.Request, m auth.DependencyMap) http.Handler {
@@ -147,75 +181,107 @@ func newTokenHandler(r *http.Request, m auth.DependencyMap) http.Handler {
 	sqlBuilderFactory := db.ProvideSQLBuilderFactory(tenantConfiguration)
 	sqlBuilder := auth.ProvideAuthSQLBuilder(sqlBuilderFactory)
 	sqlExecutor := db.ProvideSQLExecutor(context, tenantConfiguration)
-	authorizationStore := &pq.AuthorizationStore{
+	clientStore := &pq.ClientStore{
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
+	clientResolver := oauth.ProvideClientResolver(tenantConfiguration, clientStore)
+	urlprefixProvider := urlprefix.NewProvider(r)
+	endpointsProvider := &auth.EndpointsProvider{
+		PrefixProvider: urlprefixProvider,
+	}
 	timeProvider := time.NewProvider()
 	grantStore := redis.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
-	eventStore := redis3.ProvideEventStore(context, tenantConfiguration)
+	clientAuthenticator := handler.ProvideClientAuthenticator(r, clientResolver, urlprefixProvider, endpointsProvider, grantStore, timeProvider)
+	authorizationStore := &pq.AuthorizationStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	jwtAccessTokenCodec := oauth.ProvideJWTAccessTokenCodec(tenantConfiguration, urlprefixProvider, timeProvider)
+	eventStore := redis4.ProvideEventStore(context, tenantConfiguration)
 	accessEventProvider := auth2.AccessEventProvider{
 		Store: eventStore,
 	}
-	store := redis4.ProvideStore(context, tenantConfiguration, timeProvider, factory)
+	store := redis3.ProvideStore(context, tenantConfiguration, timeProvider, factory)
 	authAccessEventProvider := &auth2.AccessEventProvider{
 		Store: eventStore,
 	}
 	sessionProvider := session.ProvideSessionProvider(r, store, authAccessEventProvider, tenantConfiguration)
-	isAnonymousIdentityEnabled := flows.ProvideIsAnonymousIdentityEnabled(tenantConfiguration)
-	redisStore := redis2.ProvideStore(context, tenantConfiguration, timeProvider)
+	authinfoStore := pq2.ProvideStore(sqlBuilderFactory, sqlExecutor)
+	userprofileStore := userprofile.ProvideStore(timeProvider, sqlBuilder, sqlExecutor)
 	reservedNameChecker := auth.ProvideReservedNameChecker(m)
 	typeCheckerFactory := loginid.ProvideTypeCheckerFactory(tenantConfiguration, reservedNameChecker)
 	checker := loginid.ProvideChecker(tenantConfiguration, typeCheckerFactory)
 	normalizerFactory := loginid.ProvideNormalizerFactory(tenantConfiguration)
 	loginidProvider := loginid.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration, checker, normalizerFactory)
-	oauthProvider := oauth.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	oauthProvider := oauth2.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
 	anonymousProvider := anonymous.ProvideProvider(sqlBuilder, sqlExecutor)
-	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider)
+	ldapProvider := ldap.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider, ldapProvider)
+	userStore := user.ProvideStore(sqlBuilderFactory, sqlExecutor, timeProvider)
+	queries := &user.Queries{
+		AuthInfos:    authinfoStore,
+		UserProfiles: userprofileStore,
+		Identities:   providerProvider,
+		Time:         timeProvider,
+		Store:        userStore,
+	}
+	hookStore := hook.ProvideStore(tenantConfiguration, sqlBuilder, sqlExecutor)
+	attemptStore := hook.ProvideAttemptStore(context, tenantConfiguration, sqlBuilder)
+	deliverer := hook.ProvideDeliverer(tenantConfiguration, timeProvider, authinfoStore, userprofileStore, loginidProvider, attemptStore, factory)
+	executor := auth.ProvideTaskExecutor(m)
+	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
+	hookProvider := hook.ProvideHookProvider(context, tenantConfiguration, sqlBuilder, hookStore, txContext, timeProvider, queries, deliverer, queue, executor, factory)
+	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
+	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	manager := session.ProvideSessionManager(store, timeProvider, tenantConfiguration, cookieConfiguration)
+	sessionManager := &oauth.SessionManager{
+		Store: grantStore,
+		Time:  timeProvider,
+	}
+	logoutNotifier := oidc.ProvideLogoutNotifier(tenantConfiguration, urlprefixProvider, clientResolver, queue, timeProvider)
+	authSessionManager := auth2.ProvideSessionManager(queries, hookProvider, manager, sessionManager, eventStore, logoutNotifier, factory)
+	isAnonymousIdentityEnabled := flows.ProvideIsAnonymousIdentityEnabled(tenantConfiguration)
+	redisStore := redis2.ProvideStore(context, tenantConfiguration, timeProvider)
 	historyStoreImpl := password.ProvideHistoryStore(timeProvider, sqlBuilder, sqlExecutor)
 	passwordChecker := password.ProvideChecker(tenantConfiguration, historyStoreImpl)
 	passwordProvider := password.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, factory, historyStoreImpl, passwordChecker, tenantConfiguration)
 	totpProvider := totp.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
 	engine := auth.ProvideTemplateEngine(tenantConfiguration, m)
-	urlprefixProvider := urlprefix.NewProvider(r)
-	executor := auth.ProvideTaskExecutor(m)
-	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
 	oobProvider := oob.ProvideProvider(context, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider, engine, urlprefixProvider, queue)
 	bearertokenProvider := bearertoken.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
 	recoverycodeProvider := recoverycode.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
-	provider3 := &provider2.Provider{
+	directory := ldap2.ProvideDirectory(tenantConfiguration)
+	provider3 := ldap3.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, directory)
+	provider4 := &provider2.Provider{
 		Password:     passwordProvider,
 		TOTP:         totpProvider,
 		OOBOTP:       oobProvider,
 		BearerToken:  bearertokenProvider,
 		RecoveryCode: recoverycodeProvider,
+		LDAP:         provider3,
 	}
-	authinfoStore := pq2.ProvideStore(sqlBuilderFactory, sqlExecutor)
-	userprofileStore := userprofile.ProvideStore(timeProvider, sqlBuilder, sqlExecutor)
-	queries := &user.Queries{
-		AuthInfos:    authinfoStore,
-		UserProfiles: userprofileStore,
-		Identities:   providerProvider,
-		Time:         timeProvider,
+	userverifyStore := userverify.ProvideStore(sqlBuilder, sqlExecutor)
+	storeImpl := &forgotpassword.StoreImpl{
+		Context: context,
 	}
-	hookProvider := hook.ProvideHookProvider(context, sqlBuilder, sqlExecutor, tenantConfiguration, txContext, timeProvider, queries, authinfoStore, userprofileStore, loginidProvider, factory)
 	welcomemessageProvider := welcomemessage.ProvideProvider(context, tenantConfiguration, engine, queue)
-	commands := user.ProvideCommands(authinfoStore, userprofileStore, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
+	commands := user.ProvideCommands(authinfoStore, userprofileStore, providerProvider, provider4, authSessionManager, authorizationStore, userverifyStore, storeImpl, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
-	interactionProvider := interaction.ProvideProvider(redisStore, timeProvider, factory, providerProvider, provider3, userProvider, oobProvider, tenantConfiguration, hookProvider)
-	provider4 := challenge.ProvideProvider(context, timeProvider, tenantConfiguration)
+	interactionProvider := interaction.ProvideProvider(redisStore, timeProvider, factory, providerProvider, provider4, userProvider, oobProvider, tenantConfiguration, hookProvider)
+	provider5 := challenge.ProvideProvider(context, timeProvider, tenantConfiguration)
 	anonymousFlow := &flows.AnonymousFlow{
 		Enabled:      isAnonymousIdentityEnabled,
 		Interactions: interactionProvider,
 		Anonymous:    anonymousProvider,
-		Challenges:   provider4,
+		Challenges:   provider5,
 	}
-	idTokenIssuer := oidc.ProvideIDTokenIssuer(tenantConfiguration, urlprefixProvider, queries, timeProvider)
+	idTokenIssuer := oidc.ProvideIDTokenIssuer(tenantConfiguration, urlprefixProvider, queries, providerProvider, timeProvider)
 	tokenGenerator := _wireTokenGeneratorValue
-	tokenHandler := handler.ProvideTokenHandler(r, tenantConfiguration, factory, authorizationStore, grantStore, grantStore, grantStore, accessEventProvider, sessionProvider, anonymousFlow, idTokenIssuer, tokenGenerator, timeProvider)
+	tokenHandler := handler.ProvideTokenHandler(r, tenantConfiguration, factory, clientAuthenticator, authorizationStore, grantStore, grantStore, grantStore, grantStore, jwtAccessTokenCodec, accessEventProvider, sessionProvider, queries, hookProvider, authSessionManager, anonymousFlow, idTokenIssuer, tokenGenerator, timeProvider)
 	httpHandler := provideTokenHandler(factory, txContext, tokenHandler)
 	return httpHandler
 }
@@ -228,32 +294,202 @@ func newRevokeHandler(r *http.Request, m auth.DependencyMap) http.Handler {
 	sqlBuilderFactory := db.ProvideSQLBuilderFactory(tenantConfiguration)
 	sqlBuilder := auth.ProvideAuthSQLBuilder(sqlBuilderFactory)
 	sqlExecutor := db.ProvideSQLExecutor(context, tenantConfiguration)
+	clientStore := &pq.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	clientResolver := oauth.ProvideClientResolver(tenantConfiguration, clientStore)
+	urlprefixProvider := urlprefix.NewProvider(r)
+	endpointsProvider := &auth.EndpointsProvider{
+		PrefixProvider: urlprefixProvider,
+	}
 	timeProvider := time.NewProvider()
 	grantStore := redis.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
-	revokeHandler := &handler.RevokeHandler{
-		OfflineGrants: grantStore,
-		AccessGrants:  grantStore,
+	clientAuthenticator := handler.ProvideClientAuthenticator(r, clientResolver, urlprefixProvider, endpointsProvider, grantStore, timeProvider)
+	authorizationStore := &pq.AuthorizationStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
 	}
+	store := redis3.ProvideStore(context, tenantConfiguration, timeProvider, factory)
+	eventStore := redis4.ProvideEventStore(context, tenantConfiguration)
+	accessEventProvider := &auth2.AccessEventProvider{
+		Store: eventStore,
+	}
+	sessionProvider := session.ProvideSessionProvider(r, store, accessEventProvider, tenantConfiguration)
+	resolverSessionProvider := oauth.ProvideResolverProvider(sessionProvider)
+	jwtAccessTokenCodec := oauth.ProvideJWTAccessTokenCodec(tenantConfiguration, urlprefixProvider, timeProvider)
+	resolver := oauth.ProvideResolver(clientResolver, authorizationStore, grantStore, grantStore, resolverSessionProvider, jwtAccessTokenCodec, grantStore, timeProvider)
+	revokeHandler := handler.ProvideRevokeHandler(clientResolver, clientAuthenticator, authorizationStore, grantStore, grantStore, resolver, grantStore)
 	httpHandler := provideRevokeHandler(factory, txContext, revokeHandler)
 	return httpHandler
 }
 
-func newMetadataHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+func newIntrospectHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	context := auth.ProvideContext(r)
+	tenantConfiguration := auth.ProvideTenantConfig(context, m)
+	factory := logging.ProvideLoggerFactory(context, tenantConfiguration)
+	txContext := db.ProvideTxContext(context, tenantConfiguration)
+	sqlBuilderFactory := db.ProvideSQLBuilderFactory(tenantConfiguration)
+	sqlBuilder := auth.ProvideAuthSQLBuilder(sqlBuilderFactory)
+	sqlExecutor := db.ProvideSQLExecutor(context, tenantConfiguration)
+	clientStore := &pq.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	clientResolver := oauth.ProvideClientResolver(tenantConfiguration, clientStore)
+	urlprefixProvider := urlprefix.NewProvider(r)
+	endpointsProvider := &auth.EndpointsProvider{
+		PrefixProvider: urlprefixProvider,
+	}
+	timeProvider := time.NewProvider()
+	grantStore := redis.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
+	clientAuthenticator := handler.ProvideClientAuthenticator(r, clientResolver, urlprefixProvider, endpointsProvider, grantStore, timeProvider)
+	authorizationStore := &pq.AuthorizationStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	store := redis3.ProvideStore(context, tenantConfiguration, timeProvider, factory)
+	eventStore := redis4.ProvideEventStore(context, tenantConfiguration)
+	accessEventProvider := &auth2.AccessEventProvider{
+		Store: eventStore,
+	}
+	sessionProvider := session.ProvideSessionProvider(r, store, accessEventProvider, tenantConfiguration)
+	resolverSessionProvider := oauth.ProvideResolverProvider(sessionProvider)
+	jwtAccessTokenCodec := oauth.ProvideJWTAccessTokenCodec(tenantConfiguration, urlprefixProvider, timeProvider)
+	resolver := oauth.ProvideResolver(clientResolver, authorizationStore, grantStore, grantStore, resolverSessionProvider, jwtAccessTokenCodec, grantStore, timeProvider)
+	authinfoStore := pq2.ProvideStore(sqlBuilderFactory, sqlExecutor)
+	userprofileStore := userprofile.ProvideStore(timeProvider, sqlBuilder, sqlExecutor)
+	reservedNameChecker := auth.ProvideReservedNameChecker(m)
+	typeCheckerFactory := loginid.ProvideTypeCheckerFactory(tenantConfiguration, reservedNameChecker)
+	checker := loginid.ProvideChecker(tenantConfiguration, typeCheckerFactory)
+	normalizerFactory := loginid.ProvideNormalizerFactory(tenantConfiguration)
+	loginidProvider := loginid.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration, checker, normalizerFactory)
+	oauthProvider := oauth2.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	anonymousProvider := anonymous.ProvideProvider(sqlBuilder, sqlExecutor)
+	ldapProvider := ldap.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider, ldapProvider)
+	userStore := user.ProvideStore(sqlBuilderFactory, sqlExecutor, timeProvider)
+	queries := &user.Queries{
+		AuthInfos:    authinfoStore,
+		UserProfiles: userprofileStore,
+		Identities:   providerProvider,
+		Time:         timeProvider,
+		Store:        userStore,
+	}
+	introspectionHandler := &handler.IntrospectionHandler{
+		ClientAuth:     clientAuthenticator,
+		Authorizations: authorizationStore,
+		Resolver:       resolver,
+		Users:          queries,
+		Time:           timeProvider,
+	}
+	httpHandler := provideIntrospectHandler(factory, txContext, introspectionHandler)
+	return httpHandler
+}
+
+func newDeviceAuthorizationHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	context := auth.ProvideContext(r)
+	tenantConfiguration := auth.ProvideTenantConfig(context, m)
+	factory := logging.ProvideLoggerFactory(context, tenantConfiguration)
+	txContext := db.ProvideTxContext(context, tenantConfiguration)
+	sqlBuilderFactory := db.ProvideSQLBuilderFactory(tenantConfiguration)
+	sqlBuilder := auth.ProvideAuthSQLBuilder(sqlBuilderFactory)
+	sqlExecutor := db.ProvideSQLExecutor(context, tenantConfiguration)
+	clientStore := &pq.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	clientResolver := oauth.ProvideClientResolver(tenantConfiguration, clientStore)
+	urlprefixProvider := urlprefix.NewProvider(r)
+	endpointsProvider := &auth.EndpointsProvider{
+		PrefixProvider: urlprefixProvider,
+	}
+	timeProvider := time.NewProvider()
+	grantStore := redis.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
+	clientAuthenticator := handler.ProvideClientAuthenticator(r, clientResolver, urlprefixProvider, endpointsProvider, grantStore, timeProvider)
+	authorizationStore := &pq.AuthorizationStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	scopesValidator := oidc.ProvideScopesValidator(tenantConfiguration)
+	tokenGenerator := _wireTokenGeneratorValue
+	deviceAuthorizationHandler := handler.ProvideDeviceAuthorizationHandler(tenantConfiguration, r, clientResolver, clientAuthenticator, authorizationStore, grantStore, grantStore, endpointsProvider, scopesValidator, tokenGenerator, timeProvider)
+	httpHandler := provideDeviceAuthorizationHandler(factory, txContext, deviceAuthorizationHandler)
+	return httpHandler
+}
+
+func newPushedAuthorizationRequestHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	context := auth.ProvideContext(r)
+	tenantConfiguration := auth.ProvideTenantConfig(context, m)
+	factory := logging.ProvideLoggerFactory(context, tenantConfiguration)
+	txContext := db.ProvideTxContext(context, tenantConfiguration)
+	sqlBuilderFactory := db.ProvideSQLBuilderFactory(tenantConfiguration)
+	sqlBuilder := auth.ProvideAuthSQLBuilder(sqlBuilderFactory)
+	sqlExecutor := db.ProvideSQLExecutor(context, tenantConfiguration)
+	clientStore := &pq.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	clientResolver := oauth.ProvideClientResolver(tenantConfiguration, clientStore)
+	urlprefixProvider := urlprefix.NewProvider(r)
+	endpointsProvider := &auth.EndpointsProvider{
+		PrefixProvider: urlprefixProvider,
+	}
+	timeProvider := time.NewProvider()
+	grantStore := redis.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
+	clientAuthenticator := handler.ProvideClientAuthenticator(r, clientResolver, urlprefixProvider, endpointsProvider, grantStore, timeProvider)
+	tokenGenerator := _wireTokenGeneratorValue
+	authorizationRequestResolver := handler.ProvideAuthorizationRequestResolver(tenantConfiguration, urlprefixProvider, grantStore, tokenGenerator, timeProvider)
+	scopesValidator := oidc.ProvideScopesValidator(tenantConfiguration)
+	pushedAuthorizationRequestHandler := handler.ProvidePushedAuthorizationRequestHandler(clientAuthenticator, authorizationRequestResolver, scopesValidator)
+	httpHandler := providePushedAuthorizationRequestHandler(factory, txContext, pushedAuthorizationRequestHandler)
+	return httpHandler
+}
+
+func newRegisterHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	context := auth.ProvideContext(r)
+	tenantConfiguration := auth.ProvideTenantConfig(context, m)
+	factory := logging.ProvideLoggerFactory(context, tenantConfiguration)
+	txContext := db.ProvideTxContext(context, tenantConfiguration)
+	sqlBuilderFactory := db.ProvideSQLBuilderFactory(tenantConfiguration)
+	sqlBuilder := auth.ProvideAuthSQLBuilder(sqlBuilderFactory)
+	sqlExecutor := db.ProvideSQLExecutor(context, tenantConfiguration)
+	clientStore := &pq.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	authorizationStore := &pq.AuthorizationStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
 	urlprefixProvider := urlprefix.NewProvider(r)
 	endpointsProvider := &auth.EndpointsProvider{
 		PrefixProvider: urlprefixProvider,
 	}
-	metadataProvider := &oauth2.MetadataProvider{
-		AuthorizeEndpoint: endpointsProvider,
-		TokenEndpoint:     endpointsProvider,
-		RevokeEndpoint:    endpointsProvider,
+	tokenGenerator := _wireTokenGeneratorValue
+	timeProvider := time.NewProvider()
+	clientRegistrationHandler := handler.ProvideClientRegistrationHandler(tenantConfiguration, clientStore, authorizationStore, endpointsProvider, tokenGenerator, timeProvider)
+	httpHandler := provideRegisterHandler(factory, txContext, clientRegistrationHandler)
+	return httpHandler
+}
+
+func newMetadataHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	urlprefixProvider := urlprefix.NewProvider(r)
+	endpointsProvider := &auth.EndpointsProvider{
+		PrefixProvider: urlprefixProvider,
 	}
-	oidcMetadataProvider := &oidc.MetadataProvider{
-		URLPrefix:          urlprefixProvider,
-		JWKSEndpoint:       endpointsProvider,
-		UserInfoEndpoint:   endpointsProvider,
-		EndSessionEndpoint: endpointsProvider,
+	metadataProvider := &oauth.MetadataProvider{
+		AuthorizeEndpoint:  endpointsProvider,
+		TokenEndpoint:      endpointsProvider,
+		RevokeEndpoint:     endpointsProvider,
+		IntrospectEndpoint: endpointsProvider,
+		DeviceEndpoint:     endpointsProvider,
+		RegisterEndpoint:   endpointsProvider,
+		PAREndpoint:        endpointsProvider,
 	}
+	context := auth.ProvideContext(r)
+	tenantConfiguration := auth.ProvideTenantConfig(context, m)
+	oidcMetadataProvider := oidc.ProvideMetadataProvider(tenantConfiguration, urlprefixProvider, endpointsProvider, endpointsProvider, endpointsProvider)
 	httpHandler := provideMetadataHandler(metadataProvider, oidcMetadataProvider)
 	return httpHandler
 }
@@ -282,16 +518,19 @@ func newUserInfoHandler(r *http.Request, m auth.DependencyMap) http.Handler {
 	checker := loginid.ProvideChecker(tenantConfiguration, typeCheckerFactory)
 	normalizerFactory := loginid.ProvideNormalizerFactory(tenantConfiguration)
 	loginidProvider := loginid.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration, checker, normalizerFactory)
-	oauthProvider := oauth.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	oauthProvider := oauth2.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
 	anonymousProvider := anonymous.ProvideProvider(sqlBuilder, sqlExecutor)
-	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider)
+	ldapProvider := ldap.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider, ldapProvider)
+	userStore := user.ProvideStore(sqlBuilderFactory, sqlExecutor, timeProvider)
 	queries := &user.Queries{
 		AuthInfos:    store,
 		UserProfiles: userprofileStore,
 		Identities:   providerProvider,
 		Time:         timeProvider,
+		Store:        userStore,
 	}
-	idTokenIssuer := oidc.ProvideIDTokenIssuer(tenantConfiguration, urlprefixProvider, queries, timeProvider)
+	idTokenIssuer := oidc.ProvideIDTokenIssuer(tenantConfiguration, urlprefixProvider, queries, providerProvider, timeProvider)
 	httpHandler := provideUserInfoHandler(factory, txContext, idTokenIssuer)
 	return httpHandler
 }
@@ -301,6 +540,14 @@ func newEndSessionHandler(r *http.Request, m auth.DependencyMap) http.Handler {
 	tenantConfiguration := auth.ProvideTenantConfig(context, m)
 	factory := logging.ProvideLoggerFactory(context, tenantConfiguration)
 	txContext := db.ProvideTxContext(context, tenantConfiguration)
+	sqlBuilderFactory := db.ProvideSQLBuilderFactory(tenantConfiguration)
+	sqlBuilder := auth.ProvideAuthSQLBuilder(sqlBuilderFactory)
+	sqlExecutor := db.ProvideSQLExecutor(context, tenantConfiguration)
+	clientStore := &pq.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	clientResolver := oauth.ProvideClientResolver(tenantConfiguration, clientStore)
 	urlprefixProvider := urlprefix.NewProvider(r)
 	endpointsProvider := &auth.EndpointsProvider{
 		PrefixProvider: urlprefixProvider,
@@ -308,17 +555,15 @@ func newEndSessionHandler(r *http.Request, m auth.DependencyMap) http.Handler {
 	isAnonymousIdentityEnabled := flows.ProvideIsAnonymousIdentityEnabled(tenantConfiguration)
 	timeProvider := time.NewProvider()
 	store := redis2.ProvideStore(context, tenantConfiguration, timeProvider)
-	sqlBuilderFactory := db.ProvideSQLBuilderFactory(tenantConfiguration)
-	sqlBuilder := auth.ProvideAuthSQLBuilder(sqlBuilderFactory)
-	sqlExecutor := db.ProvideSQLExecutor(context, tenantConfiguration)
 	reservedNameChecker := auth.ProvideReservedNameChecker(m)
 	typeCheckerFactory := loginid.ProvideTypeCheckerFactory(tenantConfiguration, reservedNameChecker)
 	checker := loginid.ProvideChecker(tenantConfiguration, typeCheckerFactory)
 	normalizerFactory := loginid.ProvideNormalizerFactory(tenantConfiguration)
 	loginidProvider := loginid.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration, checker, normalizerFactory)
-	oauthProvider := oauth.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	oauthProvider := oauth2.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
 	anonymousProvider := anonymous.ProvideProvider(sqlBuilder, sqlExecutor)
-	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider)
+	ldapProvider := ldap.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider, ldapProvider)
 	historyStoreImpl := password.ProvideHistoryStore(timeProvider, sqlBuilder, sqlExecutor)
 	passwordChecker := password.ProvideChecker(tenantConfiguration, historyStoreImpl)
 	passwordProvider := password.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, factory, historyStoreImpl, passwordChecker, tenantConfiguration)
@@ -329,35 +574,63 @@ func newEndSessionHandler(r *http.Request, m auth.DependencyMap) http.Handler {
 	oobProvider := oob.ProvideProvider(context, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider, engine, urlprefixProvider, queue)
 	bearertokenProvider := bearertoken.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
 	recoverycodeProvider := recoverycode.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
-	provider3 := &provider2.Provider{
+	directory := ldap2.ProvideDirectory(tenantConfiguration)
+	provider3 := ldap3.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, directory)
+	provider4 := &provider2.Provider{
 		Password:     passwordProvider,
 		TOTP:         totpProvider,
 		OOBOTP:       oobProvider,
 		BearerToken:  bearertokenProvider,
 		RecoveryCode: recoverycodeProvider,
+		LDAP:         provider3,
 	}
 	authinfoStore := pq2.ProvideStore(sqlBuilderFactory, sqlExecutor)
 	userprofileStore := userprofile.ProvideStore(timeProvider, sqlBuilder, sqlExecutor)
+	userStore := user.ProvideStore(sqlBuilderFactory, sqlExecutor, timeProvider)
 	queries := &user.Queries{
 		AuthInfos:    authinfoStore,
 		UserProfiles: userprofileStore,
 		Identities:   providerProvider,
 		Time:         timeProvider,
+		Store:        userStore,
+	}
+	hookStore := hook.ProvideStore(tenantConfiguration, sqlBuilder, sqlExecutor)
+	attemptStore := hook.ProvideAttemptStore(context, tenantConfiguration, sqlBuilder)
+	deliverer := hook.ProvideDeliverer(tenantConfiguration, timeProvider, authinfoStore, userprofileStore, loginidProvider, attemptStore, factory)
+	hookProvider := hook.ProvideHookProvider(context, tenantConfiguration, sqlBuilder, hookStore, txContext, timeProvider, queries, deliverer, queue, executor, factory)
+	sessionStore := redis3.ProvideStore(context, tenantConfiguration, timeProvider, factory)
+	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
+	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	manager := session.ProvideSessionManager(sessionStore, timeProvider, tenantConfiguration, cookieConfiguration)
+	grantStore := redis.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
+	sessionManager := &oauth.SessionManager{
+		Store: grantStore,
+		Time:  timeProvider,
+	}
+	eventStore := redis4.ProvideEventStore(context, tenantConfiguration)
+	logoutNotifier := oidc.ProvideLogoutNotifier(tenantConfiguration, urlprefixProvider, clientResolver, queue, timeProvider)
+	authSessionManager := auth2.ProvideSessionManager(queries, hookProvider, manager, sessionManager, eventStore, logoutNotifier, factory)
+	authorizationStore := &pq.AuthorizationStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	userverifyStore := userverify.ProvideStore(sqlBuilder, sqlExecutor)
+	storeImpl := &forgotpassword.StoreImpl{
+		Context: context,
 	}
-	hookProvider := hook.ProvideHookProvider(context, sqlBuilder, sqlExecutor, tenantConfiguration, txContext, timeProvider, queries, authinfoStore, userprofileStore, loginidProvider, factory)
 	welcomemessageProvider := welcomemessage.ProvideProvider(context, tenantConfiguration, engine, queue)
-	commands := user.ProvideCommands(authinfoStore, userprofileStore, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
+	commands := user.ProvideCommands(authinfoStore, userprofileStore, providerProvider, provider4, authSessionManager, authorizationStore, userverifyStore, storeImpl, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
-	interactionProvider := interaction.ProvideProvider(store, timeProvider, factory, providerProvider, provider3, userProvider, oobProvider, tenantConfiguration, hookProvider)
-	provider4 := challenge.ProvideProvider(context, timeProvider, tenantConfiguration)
+	interactionProvider := interaction.ProvideProvider(store, timeProvider, factory, providerProvider, provider4, userProvider, oobProvider, tenantConfiguration, hookProvider)
+	provider5 := challenge.ProvideProvider(context, timeProvider, tenantConfiguration)
 	anonymousFlow := &flows.AnonymousFlow{
 		Enabled:      isAnonymousIdentityEnabled,
 		Interactions: interactionProvider,
 		Anonymous:    anonymousProvider,
-		Challenges:   provider4,
+		Challenges:   provider5,
 	}
 	stateStoreImpl := &webapp.StateStoreImpl{
 		Context: context,
@@ -367,7 +640,7 @@ func newEndSessionHandler(r *http.Request, m auth.DependencyMap) http.Handler {
 		Anonymous: anonymousFlow,
 		States:    stateStoreImpl,
 	}
-	endSessionHandler := handler2.ProvideEndSessionHandler(tenantConfiguration, endpointsProvider, urlProvider, urlProvider)
+	endSessionHandler := handler2.ProvideEndSessionHandler(tenantConfiguration, clientResolver, endpointsProvider, urlProvider, urlProvider)
 	httpHandler := provideEndSessionHandler(factory, txContext, endSessionHandler)
 	return httpHandler
 }
@@ -415,7 +688,43 @@ func provideRevokeHandler(lf logging.Factory, tx db.TxContext, rh oauthRevokeHan
 	return h
 }
 
-func provideMetadataHandler(oauth3 *oauth2.MetadataProvider, oidc2 *oidc.MetadataProvider) http.Handler {
+func provideIntrospectHandler(lf logging.Factory, tx db.TxContext, ih oauthIntrospectHandler) http.Handler {
+	h := &IntrospectHandler{
+		logger:            lf.NewLogger("oauth-introspect-handler"),
+		txContext:         tx,
+		introspectHandler: ih,
+	}
+	return h
+}
+
+func provideDeviceAuthorizationHandler(lf logging.Factory, tx db.TxContext, dh oauthDeviceAuthorizationHandler) http.Handler {
+	h := &DeviceAuthorizationHandler{
+		logger:        lf.NewLogger("oauth-device-authorization-handler"),
+		txContext:     tx,
+		deviceHandler: dh,
+	}
+	return h
+}
+
+func providePushedAuthorizationRequestHandler(lf logging.Factory, tx db.TxContext, ph oauthPushedAuthorizationRequestHandler) http.Handler {
+	h := &PushedAuthorizationRequestHandler{
+		logger:     lf.NewLogger("oauth-par-handler"),
+		txContext:  tx,
+		parHandler: ph,
+	}
+	return h
+}
+
+func provideRegisterHandler(lf logging.Factory, tx db.TxContext, rh oauthRegisterHandler) http.Handler {
+	h := &RegisterHandler{
+		logger:          lf.NewLogger("oauth-register-handler"),
+		txContext:       tx,
+		registerHandler: rh,
+	}
+	return h
+}
+
+func provideMetadataHandler(oauth3 *oauth.MetadataProvider, oidc2 *oidc.MetadataProvider) http.Handler {
 	h := &MetadataHandler{
 		metaProviders: []oauthMetadataProvider{oauth3, oidc2},
 	}
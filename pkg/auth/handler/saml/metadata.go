@@ -0,0 +1,46 @@
+package saml
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	pkg "github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/samlidp"
+)
+
+func AttachMetadataHandler(
+	router *mux.Router,
+	authDependency pkg.DependencyMap,
+) {
+	router.NewRoute().
+		Path("/saml2/metadata").
+		Handler(pkg.MakeHandler(authDependency, newMetadataHandler)).
+		Methods("GET")
+}
+
+type samlMetadataProvider interface {
+	Metadata() ([]byte, error)
+}
+
+type MetadataHandler struct {
+	logger           *logrus.Entry
+	metadataProvider samlMetadataProvider
+}
+
+func (h *MetadataHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	metadata, err := h.metadataProvider.Metadata()
+	if errors.Is(err, samlidp.ErrNotConfigured) {
+		http.NotFound(rw, r)
+		return
+	} else if err != nil {
+		h.logger.WithError(err).Error("saml metadata handler failed")
+		http.Error(rw, "Internal Server Error", 500)
+		return
+	}
+
+	rw.Header().Set("Content-Type", "application/samlmetadata+xml")
+	rw.Write(metadata)
+}
@@ -0,0 +1,57 @@
+package saml
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	pkg "github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/samlidp"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+)
+
+func AttachSLOHandler(
+	router *mux.Router,
+	authDependency pkg.DependencyMap,
+) {
+	router.NewRoute().
+		Path("/saml2/slo").
+		Handler(pkg.MakeHandler(authDependency, newSLOHandler)).
+		Methods("GET", "POST")
+}
+
+type samlSLOHandler interface {
+	Handle(m samlidp.Message, rw http.ResponseWriter) samlidp.Result
+}
+
+type SLOHandler struct {
+	logger     *logrus.Entry
+	txContext  db.TxContext
+	sloHandler samlSLOHandler
+}
+
+func (h *SLOHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	m, err := parseMessage(r)
+	if err != nil {
+		http.Error(rw, err.Error(), 400)
+		return
+	}
+
+	var result samlidp.Result
+	err = db.WithTx(h.txContext, func() error {
+		result = h.sloHandler.Handle(m, rw)
+		if result.IsInternalError() {
+			return errSAMLInternalError
+		}
+		return nil
+	})
+
+	if err == nil || errors.Is(err, errSAMLInternalError) {
+		result.WriteResponse(rw, r)
+	} else {
+		h.logger.WithError(err).Error("saml slo handler failed")
+		http.Error(rw, "Internal Server Error", 500)
+	}
+}
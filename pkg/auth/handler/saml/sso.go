@@ -0,0 +1,82 @@
+package saml
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	pkg "github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/samlidp"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+)
+
+func AttachSSOHandler(
+	router *mux.Router,
+	authDependency pkg.DependencyMap,
+) {
+	router.NewRoute().
+		Path("/saml2/sso").
+		Handler(pkg.MakeHandler(authDependency, newSSOHandler)).
+		Methods("GET", "POST")
+}
+
+type samlSSOHandler interface {
+	Handle(m samlidp.Message) samlidp.Result
+}
+
+var errSAMLInternalError = errors.New("internal error")
+
+type SSOHandler struct {
+	logger     *logrus.Entry
+	txContext  db.TxContext
+	ssoHandler samlSSOHandler
+}
+
+func (h *SSOHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	m, err := parseMessage(r)
+	if err != nil {
+		http.Error(rw, err.Error(), 400)
+		return
+	}
+
+	var result samlidp.Result
+	err = db.WithTx(h.txContext, func() error {
+		result = h.ssoHandler.Handle(m)
+		if result.IsInternalError() {
+			return errSAMLInternalError
+		}
+		return nil
+	})
+
+	if err == nil || errors.Is(err, errSAMLInternalError) {
+		result.WriteResponse(rw, r)
+	} else {
+		h.logger.WithError(err).Error("saml sso handler failed")
+		http.Error(rw, "Internal Server Error", 500)
+	}
+}
+
+// parseMessage reads the SAML request sent with HTTP-Redirect or HTTP-POST
+// binding.
+func parseMessage(r *http.Request) (samlidp.Message, error) {
+	if r.Method == "POST" {
+		if err := r.ParseForm(); err != nil {
+			return samlidp.Message{}, err
+		}
+		return samlidp.Message{
+			Binding:     samlidp.BindingHTTPPost,
+			SAMLRequest: r.PostForm.Get("SAMLRequest"),
+			RelayState:  r.PostForm.Get("RelayState"),
+		}, nil
+	}
+
+	q := r.URL.Query()
+	return samlidp.Message{
+		Binding:     samlidp.BindingHTTPRedirect,
+		SAMLRequest: q.Get("SAMLRequest"),
+		RelayState:  q.Get("RelayState"),
+		RawQuery:    r.URL.RawQuery,
+	}, nil
+}
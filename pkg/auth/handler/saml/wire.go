@@ -0,0 +1,67 @@
+//+build wireinject
+
+package saml
+
+import (
+	"net/http"
+
+	"github.com/google/wire"
+
+	"github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/samlidp"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/logging"
+)
+
+func provideMetadataHandler(lf logging.Factory, mp samlMetadataProvider) http.Handler {
+	h := &MetadataHandler{
+		logger:           lf.NewLogger("saml-metadata-handler"),
+		metadataProvider: mp,
+	}
+	return h
+}
+
+func newMetadataHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	wire.Build(
+		auth.DependencySet,
+		wire.Bind(new(samlMetadataProvider), new(*samlidp.MetadataProvider)),
+		provideMetadataHandler,
+	)
+	return nil
+}
+
+func provideSSOHandler(lf logging.Factory, tx db.TxContext, sh samlSSOHandler) http.Handler {
+	h := &SSOHandler{
+		logger:     lf.NewLogger("saml-sso-handler"),
+		txContext:  tx,
+		ssoHandler: sh,
+	}
+	return h
+}
+
+func newSSOHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	wire.Build(
+		auth.DependencySet,
+		wire.Bind(new(samlSSOHandler), new(*samlidp.SSOHandler)),
+		provideSSOHandler,
+	)
+	return nil
+}
+
+func provideSLOHandler(lf logging.Factory, tx db.TxContext, sh samlSLOHandler) http.Handler {
+	h := &SLOHandler{
+		logger:     lf.NewLogger("saml-slo-handler"),
+		txContext:  tx,
+		sloHandler: sh,
+	}
+	return h
+}
+
+func newSLOHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	wire.Build(
+		auth.DependencySet,
+		wire.Bind(new(samlSLOHandler), new(*samlidp.SLOHandler)),
+		provideSLOHandler,
+	)
+	return nil
+}
@@ -0,0 +1,264 @@
+// Code generated by Wire. DO NOT EDIT.
+
+//go:generate wire
+//+build !wireinject
+
+package saml
+
+import (
+	"github.com/skygeario/skygear-server/pkg/auth"
+	auth2 "github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
+	redis4 "github.com/skygeario/skygear-server/pkg/auth/dependency/auth/redis"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/bearertoken"
+	ldap3 "github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/ldap"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/oob"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/password"
+	provider2 "github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/provider"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/recoverycode"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/totp"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/challenge"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/forgotpassword"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/anonymous"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/ldap"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/loginid"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/oauth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/provider"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/interaction"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/interaction/flows"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/interaction/redis"
+	ldap2 "github.com/skygeario/skygear-server/pkg/auth/dependency/ldap"
+	oauth2 "github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
+	pq2 "github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/pq"
+	redis3 "github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/redis"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oidc"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/samlidp"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/session"
+	redis2 "github.com/skygeario/skygear-server/pkg/auth/dependency/session/redis"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/urlprefix"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/user"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/userprofile"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/userverify"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/webapp"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/welcomemessage"
+	"github.com/skygeario/skygear-server/pkg/core/async"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo/pq"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/logging"
+	"github.com/skygeario/skygear-server/pkg/core/time"
+	"net/http"
+)
+
+// Injectors from wire.go:
+
+func newMetadataHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	context := auth.ProvideContext(r)
+	tenantConfiguration := auth.ProvideTenantConfig(context, m)
+	factory := logging.ProvideLoggerFactory(context, tenantConfiguration)
+	provider := urlprefix.NewProvider(r)
+	endpointsProvider := &auth.EndpointsProvider{
+		PrefixProvider: provider,
+	}
+	metadataProvider := samlidp.ProvideMetadataProvider(tenantConfiguration, endpointsProvider)
+	handler := provideMetadataHandler(factory, metadataProvider)
+	return handler
+}
+
+func newSSOHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	context := auth.ProvideContext(r)
+	tenantConfiguration := auth.ProvideTenantConfig(context, m)
+	factory := logging.ProvideLoggerFactory(context, tenantConfiguration)
+	txContext := db.ProvideTxContext(context, tenantConfiguration)
+	urlprefixProvider := urlprefix.NewProvider(r)
+	endpointsProvider := &auth.EndpointsProvider{
+		PrefixProvider: urlprefixProvider,
+	}
+	isAnonymousIdentityEnabled := flows.ProvideIsAnonymousIdentityEnabled(tenantConfiguration)
+	timeProvider := time.NewProvider()
+	store := redis.ProvideStore(context, tenantConfiguration, timeProvider)
+	sqlBuilderFactory := db.ProvideSQLBuilderFactory(tenantConfiguration)
+	sqlBuilder := auth.ProvideAuthSQLBuilder(sqlBuilderFactory)
+	sqlExecutor := db.ProvideSQLExecutor(context, tenantConfiguration)
+	reservedNameChecker := auth.ProvideReservedNameChecker(m)
+	typeCheckerFactory := loginid.ProvideTypeCheckerFactory(tenantConfiguration, reservedNameChecker)
+	checker := loginid.ProvideChecker(tenantConfiguration, typeCheckerFactory)
+	normalizerFactory := loginid.ProvideNormalizerFactory(tenantConfiguration)
+	loginidProvider := loginid.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration, checker, normalizerFactory)
+	oauthProvider := oauth.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	anonymousProvider := anonymous.ProvideProvider(sqlBuilder, sqlExecutor)
+	ldapProvider := ldap.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider, ldapProvider)
+	historyStoreImpl := password.ProvideHistoryStore(timeProvider, sqlBuilder, sqlExecutor)
+	passwordChecker := password.ProvideChecker(tenantConfiguration, historyStoreImpl)
+	passwordProvider := password.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, factory, historyStoreImpl, passwordChecker, tenantConfiguration)
+	totpProvider := totp.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
+	engine := auth.ProvideTemplateEngine(tenantConfiguration, m)
+	executor := auth.ProvideTaskExecutor(m)
+	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
+	oobProvider := oob.ProvideProvider(context, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider, engine, urlprefixProvider, queue)
+	bearertokenProvider := bearertoken.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
+	recoverycodeProvider := recoverycode.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
+	directory := ldap2.ProvideDirectory(tenantConfiguration)
+	provider3 := ldap3.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, directory)
+	provider4 := &provider2.Provider{
+		Password:     passwordProvider,
+		TOTP:         totpProvider,
+		OOBOTP:       oobProvider,
+		BearerToken:  bearertokenProvider,
+		RecoveryCode: recoverycodeProvider,
+		LDAP:         provider3,
+	}
+	authinfoStore := pq.ProvideStore(sqlBuilderFactory, sqlExecutor)
+	userprofileStore := userprofile.ProvideStore(timeProvider, sqlBuilder, sqlExecutor)
+	userStore := user.ProvideStore(sqlBuilderFactory, sqlExecutor, timeProvider)
+	queries := &user.Queries{
+		AuthInfos:    authinfoStore,
+		UserProfiles: userprofileStore,
+		Identities:   providerProvider,
+		Time:         timeProvider,
+		Store:        userStore,
+	}
+	hookStore := hook.ProvideStore(tenantConfiguration, sqlBuilder, sqlExecutor)
+	attemptStore := hook.ProvideAttemptStore(context, tenantConfiguration, sqlBuilder)
+	deliverer := hook.ProvideDeliverer(tenantConfiguration, timeProvider, authinfoStore, userprofileStore, loginidProvider, attemptStore, factory)
+	hookProvider := hook.ProvideHookProvider(context, tenantConfiguration, sqlBuilder, hookStore, txContext, timeProvider, queries, deliverer, queue, executor, factory)
+	sessionStore := redis2.ProvideStore(context, tenantConfiguration, timeProvider, factory)
+	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
+	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	manager := session.ProvideSessionManager(sessionStore, timeProvider, tenantConfiguration, cookieConfiguration)
+	grantStore := redis3.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
+	sessionManager := &oauth2.SessionManager{
+		Store: grantStore,
+		Time:  timeProvider,
+	}
+	eventStore := redis4.ProvideEventStore(context, tenantConfiguration)
+	clientStore := &pq2.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	clientResolver := oauth2.ProvideClientResolver(tenantConfiguration, clientStore)
+	logoutNotifier := oidc.ProvideLogoutNotifier(tenantConfiguration, urlprefixProvider, clientResolver, queue, timeProvider)
+	authSessionManager := auth2.ProvideSessionManager(queries, hookProvider, manager, sessionManager, eventStore, logoutNotifier, factory)
+	authorizationStore := &pq2.AuthorizationStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	userverifyStore := userverify.ProvideStore(sqlBuilder, sqlExecutor)
+	storeImpl := &forgotpassword.StoreImpl{
+		Context: context,
+	}
+	welcomemessageProvider := welcomemessage.ProvideProvider(context, tenantConfiguration, engine, queue)
+	commands := user.ProvideCommands(authinfoStore, userprofileStore, providerProvider, provider4, authSessionManager, authorizationStore, userverifyStore, storeImpl, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
+	userProvider := &user.Provider{
+		Commands: commands,
+		Queries:  queries,
+	}
+	interactionProvider := interaction.ProvideProvider(store, timeProvider, factory, providerProvider, provider4, userProvider, oobProvider, tenantConfiguration, hookProvider)
+	challengeProvider := challenge.ProvideProvider(context, timeProvider, tenantConfiguration)
+	anonymousFlow := &flows.AnonymousFlow{
+		Enabled:      isAnonymousIdentityEnabled,
+		Interactions: interactionProvider,
+		Anonymous:    anonymousProvider,
+		Challenges:   challengeProvider,
+	}
+	stateStoreImpl := &webapp.StateStoreImpl{
+		Context: context,
+	}
+	urlProvider := &webapp.URLProvider{
+		Endpoints: endpointsProvider,
+		Anonymous: anonymousFlow,
+		States:    stateStoreImpl,
+	}
+	idTokenIssuer := oidc.ProvideIDTokenIssuer(tenantConfiguration, urlprefixProvider, queries, providerProvider, timeProvider)
+	ssoHandler := samlidp.ProvideSSOHandler(context, tenantConfiguration, factory, endpointsProvider, urlProvider, idTokenIssuer, timeProvider)
+	handler := provideSSOHandler(factory, txContext, ssoHandler)
+	return handler
+}
+
+func newSLOHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	context := auth.ProvideContext(r)
+	tenantConfiguration := auth.ProvideTenantConfig(context, m)
+	factory := logging.ProvideLoggerFactory(context, tenantConfiguration)
+	txContext := db.ProvideTxContext(context, tenantConfiguration)
+	urlprefixProvider := urlprefix.NewProvider(r)
+	endpointsProvider := &auth.EndpointsProvider{
+		PrefixProvider: urlprefixProvider,
+	}
+	sqlBuilderFactory := db.ProvideSQLBuilderFactory(tenantConfiguration)
+	sqlExecutor := db.ProvideSQLExecutor(context, tenantConfiguration)
+	store := pq.ProvideStore(sqlBuilderFactory, sqlExecutor)
+	timeProvider := time.NewProvider()
+	sqlBuilder := auth.ProvideAuthSQLBuilder(sqlBuilderFactory)
+	userprofileStore := userprofile.ProvideStore(timeProvider, sqlBuilder, sqlExecutor)
+	reservedNameChecker := auth.ProvideReservedNameChecker(m)
+	typeCheckerFactory := loginid.ProvideTypeCheckerFactory(tenantConfiguration, reservedNameChecker)
+	checker := loginid.ProvideChecker(tenantConfiguration, typeCheckerFactory)
+	normalizerFactory := loginid.ProvideNormalizerFactory(tenantConfiguration)
+	loginidProvider := loginid.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration, checker, normalizerFactory)
+	oauthProvider := oauth.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	anonymousProvider := anonymous.ProvideProvider(sqlBuilder, sqlExecutor)
+	ldapProvider := ldap.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider, ldapProvider)
+	userStore := user.ProvideStore(sqlBuilderFactory, sqlExecutor, timeProvider)
+	queries := &user.Queries{
+		AuthInfos:    store,
+		UserProfiles: userprofileStore,
+		Identities:   providerProvider,
+		Time:         timeProvider,
+		Store:        userStore,
+	}
+	hookStore := hook.ProvideStore(tenantConfiguration, sqlBuilder, sqlExecutor)
+	attemptStore := hook.ProvideAttemptStore(context, tenantConfiguration, sqlBuilder)
+	deliverer := hook.ProvideDeliverer(tenantConfiguration, timeProvider, store, userprofileStore, loginidProvider, attemptStore, factory)
+	executor := auth.ProvideTaskExecutor(m)
+	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
+	hookProvider := hook.ProvideHookProvider(context, tenantConfiguration, sqlBuilder, hookStore, txContext, timeProvider, queries, deliverer, queue, executor, factory)
+	sessionStore := redis2.ProvideStore(context, tenantConfiguration, timeProvider, factory)
+	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
+	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	manager := session.ProvideSessionManager(sessionStore, timeProvider, tenantConfiguration, cookieConfiguration)
+	grantStore := redis3.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
+	sessionManager := &oauth2.SessionManager{
+		Store: grantStore,
+		Time:  timeProvider,
+	}
+	eventStore := redis4.ProvideEventStore(context, tenantConfiguration)
+	clientStore := &pq2.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	clientResolver := oauth2.ProvideClientResolver(tenantConfiguration, clientStore)
+	logoutNotifier := oidc.ProvideLogoutNotifier(tenantConfiguration, urlprefixProvider, clientResolver, queue, timeProvider)
+	authSessionManager := auth2.ProvideSessionManager(queries, hookProvider, manager, sessionManager, eventStore, logoutNotifier, factory)
+	sloHandler := samlidp.ProvideSLOHandler(context, tenantConfiguration, factory, endpointsProvider, authSessionManager, timeProvider)
+	handler := provideSLOHandler(factory, txContext, sloHandler)
+	return handler
+}
+
+// wire.go:
+
+func provideMetadataHandler(lf logging.Factory, mp samlMetadataProvider) http.Handler {
+	h := &MetadataHandler{
+		logger:           lf.NewLogger("saml-metadata-handler"),
+		metadataProvider: mp,
+	}
+	return h
+}
+
+func provideSSOHandler(lf logging.Factory, tx db.TxContext, sh samlSSOHandler) http.Handler {
+	h := &SSOHandler{
+		logger:     lf.NewLogger("saml-sso-handler"),
+		txContext:  tx,
+		ssoHandler: sh,
+	}
+	return h
+}
+
+func provideSLOHandler(lf logging.Factory, tx db.TxContext, sh samlSLOHandler) http.Handler {
+	h := &SLOHandler{
+		logger:     lf.NewLogger("saml-slo-handler"),
+		txContext:  tx,
+		sloHandler: sh,
+	}
+	return h
+}
@@ -0,0 +1,113 @@
+package session
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	pkg "github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/model"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz/policy"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
+)
+
+func AttachListSessionsHandler(
+	router *mux.Router,
+	authDependency pkg.DependencyMap,
+) {
+	router.NewRoute().
+		Path("/_auth/sessions").
+		Handler(pkg.MakeHandler(authDependency, newListSessionsHandler)).
+		Methods("OPTIONS", "GET")
+}
+
+type ListSessionsResponse struct {
+	Sessions         []*model.Session `json:"sessions"`
+	CurrentSessionID string           `json:"current_session_id"`
+}
+
+// @JSONSchema
+const ListSessionsResponseSchema = `
+{
+	"$id": "#ListSessionsResponse",
+	"type": "object",
+	"properties": {
+		"result": {
+			"type": "object",
+			"properties": {
+				"sessions": {
+					"type": "array",
+					"items": { "$ref": "#Session" }
+				},
+				"current_session_id": { "type": "string" }
+			},
+			"required": ["sessions", "current_session_id"]
+		}
+	}
+}
+`
+
+type sessionLister interface {
+	List(userID string) ([]auth.AuthSession, error)
+}
+
+/*
+	@Operation GET /sessions - List sessions
+		List the IDP sessions and offline grants of the current user,
+		newest first.
+
+		@Tag User
+		@SecurityRequirement access_key
+		@SecurityRequirement access_token
+
+		@Response 200
+			List of sessions.
+			@JSONSchema {ListSessionsResponse}
+*/
+type ListSessionsHandler struct {
+	TxContext db.TxContext
+	Sessions  sessionLister
+}
+
+func (h *ListSessionsHandler) ProvideAuthzPolicy() authz.Policy {
+	return policy.RequireValidUser
+}
+
+func (h *ListSessionsHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	var response handler.APIResponse
+	result, err := h.Handle(r)
+	if err != nil {
+		response.Error = err
+	} else {
+		response.Result = result
+	}
+	handler.WriteResponse(rw, response)
+}
+
+func (h *ListSessionsHandler) Handle(r *http.Request) (*ListSessionsResponse, error) {
+	userID := auth.GetUser(r.Context()).ID
+	currentSession := auth.GetSession(r.Context())
+
+	resp := &ListSessionsResponse{
+		Sessions:         []*model.Session{},
+		CurrentSessionID: currentSession.SessionID(),
+	}
+	err := db.WithTx(h.TxContext, func() error {
+		sessions, err := h.Sessions.List(userID)
+		if err != nil {
+			return err
+		}
+		for _, s := range sessions {
+			resp.Sessions = append(resp.Sessions, s.ToAPIModel())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -62,6 +62,8 @@ func (h *ResolveHandler) resolve(r *http.Request) (*authn.Info, error) {
 			return nil, err
 		}
 		info = authn.NewAuthnInfo(session.AuthnAttrs(), user, len(anonIdentities) > 0)
+	} else if valid && session != nil && session.SessionType() == auth.SessionTypeClient {
+		info = authn.NewClientAuthnInfo(session.GetClientID())
 	} else if !valid {
 		info = &authn.Info{IsValid: false}
 	}
@@ -9,6 +9,7 @@ import (
 	. "github.com/smartystreets/goconvey/convey"
 
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/anonymous"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/session"
 	coreauth "github.com/skygeario/skygear-server/pkg/core/auth"
 	"github.com/skygeario/skygear-server/pkg/core/authn"
@@ -80,6 +81,22 @@ func TestResolveHandler(t *testing.T) {
 			})
 		})
 
+		Convey("should attach headers for client sessions", func() {
+			s := &oauth.ClientSession{ClientID: "client-id"}
+			r, _ := http.NewRequest("POST", "/", nil)
+			r = r.WithContext(authn.WithAuthn(r.Context(), s, nil))
+			rw := httptest.NewRecorder()
+			h.ServeHTTP(rw, r)
+
+			resp := rw.Result()
+			So(resp.StatusCode, ShouldEqual, 200)
+			So(resp.Header, ShouldResemble, http.Header{
+				"X-Skygear-Session-Valid": []string{"true"},
+				"X-Skygear-Client-Id":     []string{"client-id"},
+				"X-Skygear-Is-Master-Key": []string{"false"},
+			})
+		})
+
 		Convey("should attach headers for invalid sessions", func() {
 			r, _ := http.NewRequest("POST", "/", nil)
 			r = r.WithContext(authn.WithInvalidAuthn(r.Context()))
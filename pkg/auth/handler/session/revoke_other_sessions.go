@@ -0,0 +1,71 @@
+package session
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	pkg "github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz/policy"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
+)
+
+func AttachRevokeOtherSessionsHandler(
+	router *mux.Router,
+	authDependency pkg.DependencyMap,
+) {
+	router.NewRoute().
+		Path("/_auth/sessions").
+		Handler(pkg.MakeHandler(authDependency, newRevokeOtherSessionsHandler)).
+		Methods("OPTIONS", "DELETE")
+}
+
+type allSessionsRevoker interface {
+	RevokeAll(userID string, exceptID string) error
+}
+
+/*
+	@Operation DELETE /sessions - Revoke other sessions
+		Revoke all sessions of the current user, except the current session.
+
+		@Tag User
+		@SecurityRequirement access_key
+		@SecurityRequirement access_token
+
+		@Response 200 {EmptyResponse}
+*/
+type RevokeOtherSessionsHandler struct {
+	TxContext db.TxContext
+	Sessions  allSessionsRevoker
+}
+
+func (h *RevokeOtherSessionsHandler) ProvideAuthzPolicy() authz.Policy {
+	return policy.RequireValidUser
+}
+
+func (h *RevokeOtherSessionsHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	var response handler.APIResponse
+	result, err := h.Handle(r)
+	if err != nil {
+		response.Error = err
+	} else {
+		response.Result = result
+	}
+	handler.WriteResponse(rw, response)
+}
+
+func (h *RevokeOtherSessionsHandler) Handle(r *http.Request) (interface{}, error) {
+	userID := auth.GetUser(r.Context()).ID
+	currentSessionID := auth.GetSession(r.Context()).SessionID()
+
+	return handler.Transactional(h.TxContext, func() (interface{}, error) {
+		err := h.Sessions.RevokeAll(userID, currentSessionID)
+		if err != nil {
+			return nil, err
+		}
+		return struct{}{}, nil
+	})
+}
@@ -0,0 +1,89 @@
+package session
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	pkg "github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz/policy"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
+)
+
+func AttachRevokeSessionHandler(
+	router *mux.Router,
+	authDependency pkg.DependencyMap,
+) {
+	router.NewRoute().
+		Path("/_auth/sessions/{session_id}").
+		Handler(pkg.MakeHandler(authDependency, newRevokeSessionHandler)).
+		Methods("OPTIONS", "DELETE")
+}
+
+// nolint: deadcode
+/*
+	@ID SessionID
+	@Parameter session_id path
+		ID of the session.
+		@JSONSchema
+			{ "type": "string" }
+*/
+type sessionID string
+
+type sessionRevoker interface {
+	GetByUser(userID string, id string) (auth.AuthSession, error)
+	Revoke(session auth.AuthSession) error
+}
+
+/*
+	@Operation DELETE /sessions/{session_id} - Revoke session
+		Revoke a session of the current user.
+
+		@Tag User
+		@SecurityRequirement access_key
+		@SecurityRequirement access_token
+
+		@Parameter {SessionID}
+
+		@Response 200 {EmptyResponse}
+*/
+type RevokeSessionHandler struct {
+	TxContext db.TxContext
+	Sessions  sessionRevoker
+}
+
+func (h *RevokeSessionHandler) ProvideAuthzPolicy() authz.Policy {
+	return policy.RequireValidUser
+}
+
+func (h *RevokeSessionHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	var response handler.APIResponse
+	result, err := h.Handle(r)
+	if err != nil {
+		response.Error = err
+	} else {
+		response.Result = result
+	}
+	handler.WriteResponse(rw, response)
+}
+
+func (h *RevokeSessionHandler) Handle(r *http.Request) (interface{}, error) {
+	userID := auth.GetUser(r.Context()).ID
+	sessionID := mux.Vars(r)["session_id"]
+
+	return handler.Transactional(h.TxContext, func() (interface{}, error) {
+		session, err := h.Sessions.GetByUser(userID, sessionID)
+		if err != nil {
+			return nil, err
+		}
+
+		err = h.Sessions.Revoke(session)
+		if err != nil {
+			return nil, err
+		}
+		return struct{}{}, nil
+	})
+}
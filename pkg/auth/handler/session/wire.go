@@ -10,6 +10,7 @@ import (
 	pkg "github.com/skygeario/skygear-server/pkg/auth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/anonymous"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
 	"github.com/skygeario/skygear-server/pkg/core/logging"
 	"github.com/skygeario/skygear-server/pkg/core/time"
 )
@@ -31,3 +32,45 @@ func newResolveHandler(r *http.Request, m pkg.DependencyMap) http.Handler {
 	wire.Build(pkg.DependencySet, provideResolveHandler)
 	return nil
 }
+
+func provideListSessionsHandler(requireAuthz handler.RequireAuthz, h *ListSessionsHandler) http.Handler {
+	return requireAuthz(h, h)
+}
+
+func newListSessionsHandler(r *http.Request, m pkg.DependencyMap) http.Handler {
+	wire.Build(
+		pkg.DependencySet,
+		wire.Bind(new(sessionLister), new(*auth.SessionManager)),
+		wire.Struct(new(ListSessionsHandler), "*"),
+		provideListSessionsHandler,
+	)
+	return nil
+}
+
+func provideRevokeSessionHandler(requireAuthz handler.RequireAuthz, h *RevokeSessionHandler) http.Handler {
+	return requireAuthz(h, h)
+}
+
+func newRevokeSessionHandler(r *http.Request, m pkg.DependencyMap) http.Handler {
+	wire.Build(
+		pkg.DependencySet,
+		wire.Bind(new(sessionRevoker), new(*auth.SessionManager)),
+		wire.Struct(new(RevokeSessionHandler), "*"),
+		provideRevokeSessionHandler,
+	)
+	return nil
+}
+
+func provideRevokeOtherSessionsHandler(requireAuthz handler.RequireAuthz, h *RevokeOtherSessionsHandler) http.Handler {
+	return requireAuthz(h, h)
+}
+
+func newRevokeOtherSessionsHandler(r *http.Request, m pkg.DependencyMap) http.Handler {
+	wire.Build(
+		pkg.DependencySet,
+		wire.Bind(new(allSessionsRevoker), new(*auth.SessionManager)),
+		wire.Struct(new(RevokeOtherSessionsHandler), "*"),
+		provideRevokeOtherSessionsHandler,
+	)
+	return nil
+}
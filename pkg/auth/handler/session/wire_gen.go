@@ -9,14 +9,25 @@ import (
 	"github.com/skygeario/skygear-server/pkg/auth"
 	auth2 "github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
 	redis2 "github.com/skygeario/skygear-server/pkg/auth/dependency/auth/redis"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/anonymous"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/ldap"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/loginid"
+	oauth2 "github.com/skygeario/skygear-server/pkg/auth/dependency/identity/oauth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/provider"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/pq"
 	redis3 "github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/redis"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oidc"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/session"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/session/redis"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/urlprefix"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/user"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/userprofile"
+	"github.com/skygeario/skygear-server/pkg/core/async"
 	pq2 "github.com/skygeario/skygear-server/pkg/core/auth/authinfo/pq"
 	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
 	"github.com/skygeario/skygear-server/pkg/core/logging"
 	"github.com/skygeario/skygear-server/pkg/core/time"
 	"net/http"
@@ -45,19 +56,20 @@ func newResolveHandler(r *http.Request, m auth.DependencyMap) http.Handler {
 	sqlBuilderFactory := db.ProvideSQLBuilderFactory(tenantConfiguration)
 	sqlBuilder := auth.ProvideAuthSQLBuilder(sqlBuilderFactory)
 	sqlExecutor := db.ProvideSQLExecutor(context, tenantConfiguration)
+	clientStore := &pq.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	clientResolver := oauth.ProvideClientResolver(tenantConfiguration, clientStore)
 	authorizationStore := &pq.AuthorizationStore{
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
 	grantStore := redis3.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, provider)
 	resolverSessionProvider := oauth.ProvideResolverProvider(sessionProvider)
-	oauthResolver := &oauth.Resolver{
-		Authorizations: authorizationStore,
-		AccessGrants:   grantStore,
-		OfflineGrants:  grantStore,
-		Sessions:       resolverSessionProvider,
-		Time:           provider,
-	}
+	urlprefixProvider := urlprefix.NewProvider(r)
+	jwtAccessTokenCodec := oauth.ProvideJWTAccessTokenCodec(tenantConfiguration, urlprefixProvider, provider)
+	oauthResolver := oauth.ProvideResolver(clientResolver, authorizationStore, grantStore, grantStore, resolverSessionProvider, jwtAccessTokenCodec, grantStore, provider)
 	authAccessEventProvider := auth2.AccessEventProvider{
 		Store: eventStore,
 	}
@@ -76,6 +88,189 @@ func newResolveHandler(r *http.Request, m auth.DependencyMap) http.Handler {
 	return handler
 }
 
+func newListSessionsHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	context := auth.ProvideContext(r)
+	tenantConfiguration := auth.ProvideTenantConfig(context, m)
+	factory := logging.ProvideLoggerFactory(context, tenantConfiguration)
+	requireAuthz := handler.NewRequireAuthzFactory(factory)
+	txContext := db.ProvideTxContext(context, tenantConfiguration)
+	sqlBuilderFactory := db.ProvideSQLBuilderFactory(tenantConfiguration)
+	sqlExecutor := db.ProvideSQLExecutor(context, tenantConfiguration)
+	store := pq2.ProvideStore(sqlBuilderFactory, sqlExecutor)
+	timeProvider := time.NewProvider()
+	sqlBuilder := auth.ProvideAuthSQLBuilder(sqlBuilderFactory)
+	userprofileStore := userprofile.ProvideStore(timeProvider, sqlBuilder, sqlExecutor)
+	reservedNameChecker := auth.ProvideReservedNameChecker(m)
+	typeCheckerFactory := loginid.ProvideTypeCheckerFactory(tenantConfiguration, reservedNameChecker)
+	checker := loginid.ProvideChecker(tenantConfiguration, typeCheckerFactory)
+	normalizerFactory := loginid.ProvideNormalizerFactory(tenantConfiguration)
+	loginidProvider := loginid.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration, checker, normalizerFactory)
+	oauthProvider := oauth2.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	anonymousProvider := anonymous.ProvideProvider(sqlBuilder, sqlExecutor)
+	ldapProvider := ldap.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider, ldapProvider)
+	userStore := user.ProvideStore(sqlBuilderFactory, sqlExecutor, timeProvider)
+	queries := &user.Queries{
+		AuthInfos:    store,
+		UserProfiles: userprofileStore,
+		Identities:   providerProvider,
+		Time:         timeProvider,
+		Store:        userStore,
+	}
+	hookStore := hook.ProvideStore(tenantConfiguration, sqlBuilder, sqlExecutor)
+	attemptStore := hook.ProvideAttemptStore(context, tenantConfiguration, sqlBuilder)
+	deliverer := hook.ProvideDeliverer(tenantConfiguration, timeProvider, store, userprofileStore, loginidProvider, attemptStore, factory)
+	executor := auth.ProvideTaskExecutor(m)
+	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
+	hookProvider := hook.ProvideHookProvider(context, tenantConfiguration, sqlBuilder, hookStore, txContext, timeProvider, queries, deliverer, queue, executor, factory)
+	sessionStore := redis.ProvideStore(context, tenantConfiguration, timeProvider, factory)
+	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
+	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	manager := session.ProvideSessionManager(sessionStore, timeProvider, tenantConfiguration, cookieConfiguration)
+	grantStore := redis3.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
+	sessionManager := &oauth.SessionManager{
+		Store: grantStore,
+		Time:  timeProvider,
+	}
+	eventStore := redis2.ProvideEventStore(context, tenantConfiguration)
+	urlprefixProvider := urlprefix.NewProvider(r)
+	clientStore := &pq.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	clientResolver := oauth.ProvideClientResolver(tenantConfiguration, clientStore)
+	logoutNotifier := oidc.ProvideLogoutNotifier(tenantConfiguration, urlprefixProvider, clientResolver, queue, timeProvider)
+	authSessionManager := auth2.ProvideSessionManager(queries, hookProvider, manager, sessionManager, eventStore, logoutNotifier, factory)
+	listSessionsHandler := &ListSessionsHandler{
+		TxContext: txContext,
+		Sessions:  authSessionManager,
+	}
+	httpHandler := provideListSessionsHandler(requireAuthz, listSessionsHandler)
+	return httpHandler
+}
+
+func newRevokeSessionHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	context := auth.ProvideContext(r)
+	tenantConfiguration := auth.ProvideTenantConfig(context, m)
+	factory := logging.ProvideLoggerFactory(context, tenantConfiguration)
+	requireAuthz := handler.NewRequireAuthzFactory(factory)
+	txContext := db.ProvideTxContext(context, tenantConfiguration)
+	sqlBuilderFactory := db.ProvideSQLBuilderFactory(tenantConfiguration)
+	sqlExecutor := db.ProvideSQLExecutor(context, tenantConfiguration)
+	store := pq2.ProvideStore(sqlBuilderFactory, sqlExecutor)
+	timeProvider := time.NewProvider()
+	sqlBuilder := auth.ProvideAuthSQLBuilder(sqlBuilderFactory)
+	userprofileStore := userprofile.ProvideStore(timeProvider, sqlBuilder, sqlExecutor)
+	reservedNameChecker := auth.ProvideReservedNameChecker(m)
+	typeCheckerFactory := loginid.ProvideTypeCheckerFactory(tenantConfiguration, reservedNameChecker)
+	checker := loginid.ProvideChecker(tenantConfiguration, typeCheckerFactory)
+	normalizerFactory := loginid.ProvideNormalizerFactory(tenantConfiguration)
+	loginidProvider := loginid.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration, checker, normalizerFactory)
+	oauthProvider := oauth2.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	anonymousProvider := anonymous.ProvideProvider(sqlBuilder, sqlExecutor)
+	ldapProvider := ldap.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider, ldapProvider)
+	userStore := user.ProvideStore(sqlBuilderFactory, sqlExecutor, timeProvider)
+	queries := &user.Queries{
+		AuthInfos:    store,
+		UserProfiles: userprofileStore,
+		Identities:   providerProvider,
+		Time:         timeProvider,
+		Store:        userStore,
+	}
+	hookStore := hook.ProvideStore(tenantConfiguration, sqlBuilder, sqlExecutor)
+	attemptStore := hook.ProvideAttemptStore(context, tenantConfiguration, sqlBuilder)
+	deliverer := hook.ProvideDeliverer(tenantConfiguration, timeProvider, store, userprofileStore, loginidProvider, attemptStore, factory)
+	executor := auth.ProvideTaskExecutor(m)
+	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
+	hookProvider := hook.ProvideHookProvider(context, tenantConfiguration, sqlBuilder, hookStore, txContext, timeProvider, queries, deliverer, queue, executor, factory)
+	sessionStore := redis.ProvideStore(context, tenantConfiguration, timeProvider, factory)
+	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
+	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	manager := session.ProvideSessionManager(sessionStore, timeProvider, tenantConfiguration, cookieConfiguration)
+	grantStore := redis3.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
+	sessionManager := &oauth.SessionManager{
+		Store: grantStore,
+		Time:  timeProvider,
+	}
+	eventStore := redis2.ProvideEventStore(context, tenantConfiguration)
+	urlprefixProvider := urlprefix.NewProvider(r)
+	clientStore := &pq.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	clientResolver := oauth.ProvideClientResolver(tenantConfiguration, clientStore)
+	logoutNotifier := oidc.ProvideLogoutNotifier(tenantConfiguration, urlprefixProvider, clientResolver, queue, timeProvider)
+	authSessionManager := auth2.ProvideSessionManager(queries, hookProvider, manager, sessionManager, eventStore, logoutNotifier, factory)
+	revokeSessionHandler := &RevokeSessionHandler{
+		TxContext: txContext,
+		Sessions:  authSessionManager,
+	}
+	httpHandler := provideRevokeSessionHandler(requireAuthz, revokeSessionHandler)
+	return httpHandler
+}
+
+func newRevokeOtherSessionsHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	context := auth.ProvideContext(r)
+	tenantConfiguration := auth.ProvideTenantConfig(context, m)
+	factory := logging.ProvideLoggerFactory(context, tenantConfiguration)
+	requireAuthz := handler.NewRequireAuthzFactory(factory)
+	txContext := db.ProvideTxContext(context, tenantConfiguration)
+	sqlBuilderFactory := db.ProvideSQLBuilderFactory(tenantConfiguration)
+	sqlExecutor := db.ProvideSQLExecutor(context, tenantConfiguration)
+	store := pq2.ProvideStore(sqlBuilderFactory, sqlExecutor)
+	timeProvider := time.NewProvider()
+	sqlBuilder := auth.ProvideAuthSQLBuilder(sqlBuilderFactory)
+	userprofileStore := userprofile.ProvideStore(timeProvider, sqlBuilder, sqlExecutor)
+	reservedNameChecker := auth.ProvideReservedNameChecker(m)
+	typeCheckerFactory := loginid.ProvideTypeCheckerFactory(tenantConfiguration, reservedNameChecker)
+	checker := loginid.ProvideChecker(tenantConfiguration, typeCheckerFactory)
+	normalizerFactory := loginid.ProvideNormalizerFactory(tenantConfiguration)
+	loginidProvider := loginid.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration, checker, normalizerFactory)
+	oauthProvider := oauth2.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	anonymousProvider := anonymous.ProvideProvider(sqlBuilder, sqlExecutor)
+	ldapProvider := ldap.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider, ldapProvider)
+	userStore := user.ProvideStore(sqlBuilderFactory, sqlExecutor, timeProvider)
+	queries := &user.Queries{
+		AuthInfos:    store,
+		UserProfiles: userprofileStore,
+		Identities:   providerProvider,
+		Time:         timeProvider,
+		Store:        userStore,
+	}
+	hookStore := hook.ProvideStore(tenantConfiguration, sqlBuilder, sqlExecutor)
+	attemptStore := hook.ProvideAttemptStore(context, tenantConfiguration, sqlBuilder)
+	deliverer := hook.ProvideDeliverer(tenantConfiguration, timeProvider, store, userprofileStore, loginidProvider, attemptStore, factory)
+	executor := auth.ProvideTaskExecutor(m)
+	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
+	hookProvider := hook.ProvideHookProvider(context, tenantConfiguration, sqlBuilder, hookStore, txContext, timeProvider, queries, deliverer, queue, executor, factory)
+	sessionStore := redis.ProvideStore(context, tenantConfiguration, timeProvider, factory)
+	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
+	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	manager := session.ProvideSessionManager(sessionStore, timeProvider, tenantConfiguration, cookieConfiguration)
+	grantStore := redis3.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
+	sessionManager := &oauth.SessionManager{
+		Store: grantStore,
+		Time:  timeProvider,
+	}
+	eventStore := redis2.ProvideEventStore(context, tenantConfiguration)
+	urlprefixProvider := urlprefix.NewProvider(r)
+	clientStore := &pq.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	clientResolver := oauth.ProvideClientResolver(tenantConfiguration, clientStore)
+	logoutNotifier := oidc.ProvideLogoutNotifier(tenantConfiguration, urlprefixProvider, clientResolver, queue, timeProvider)
+	authSessionManager := auth2.ProvideSessionManager(queries, hookProvider, manager, sessionManager, eventStore, logoutNotifier, factory)
+	revokeOtherSessionsHandler := &RevokeOtherSessionsHandler{
+		TxContext: txContext,
+		Sessions:  authSessionManager,
+	}
+	httpHandler := provideRevokeOtherSessionsHandler(requireAuthz, revokeOtherSessionsHandler)
+	return httpHandler
+}
+
 // wire.go:
 
 func provideResolveHandler(
@@ -90,3 +285,15 @@ func provideResolveHandler(
 		Anonymous:     ap,
 	})
 }
+
+func provideListSessionsHandler(requireAuthz handler.RequireAuthz, h *ListSessionsHandler) http.Handler {
+	return requireAuthz(h, h)
+}
+
+func provideRevokeSessionHandler(requireAuthz handler.RequireAuthz, h *RevokeSessionHandler) http.Handler {
+	return requireAuthz(h, h)
+}
+
+func provideRevokeOtherSessionsHandler(requireAuthz handler.RequireAuthz, h *RevokeOtherSessionsHandler) http.Handler {
+	return requireAuthz(h, h)
+}
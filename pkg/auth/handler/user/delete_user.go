@@ -0,0 +1,78 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	pkg "github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authz/policy"
+	"github.com/skygeario/skygear-server/pkg/core/authn"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
+)
+
+func AttachDeleteUserHandler(
+	router *mux.Router,
+	authDependency pkg.DependencyMap,
+) {
+	router.NewRoute().
+		Path("/_auth/me").
+		Handler(pkg.MakeHandler(authDependency, newDeleteUserHandler)).
+		Methods("OPTIONS", "DELETE")
+}
+
+type userDeleter interface {
+	Delete(userID string) error
+}
+
+/*
+	@Operation DELETE /me - Delete current user
+		Delete the current user permanently, with all their identities,
+		authenticators and sessions. It is available only if
+		authentication.allow_self_deletion is enabled.
+
+		@Tag User
+		@SecurityRequirement access_key
+		@SecurityRequirement access_token
+
+		@Response 200 {EmptyResponse}
+*/
+type DeleteUserHandler struct {
+	TxContext            db.TxContext
+	AuthenticationConfig *config.AuthenticationConfiguration
+	Users                userDeleter
+}
+
+func (h *DeleteUserHandler) ProvideAuthzPolicy() authz.Policy {
+	return policy.RequireValidUser
+}
+
+func (h *DeleteUserHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	var response handler.APIResponse
+	result, err := h.Handle(r)
+	if err != nil {
+		response.Error = err
+	} else {
+		response.Result = result
+	}
+	handler.WriteResponse(rw, response)
+}
+
+func (h *DeleteUserHandler) Handle(r *http.Request) (interface{}, error) {
+	if !h.AuthenticationConfig.AllowSelfDeletion {
+		return nil, ErrSelfDeletionDisabled
+	}
+
+	userID := authn.GetUser(r.Context()).ID
+
+	return handler.Transactional(h.TxContext, func() (interface{}, error) {
+		err := h.Users.Delete(userID)
+		if err != nil {
+			return nil, err
+		}
+		return struct{}{}, nil
+	})
+}
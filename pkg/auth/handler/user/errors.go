@@ -0,0 +1,9 @@
+package user
+
+import "github.com/skygeario/skygear-server/pkg/core/skyerr"
+
+var SelfDeletionDisabled = skyerr.Forbidden.WithReason("SelfDeletionDisabled")
+
+var ErrSelfDeletionDisabled = SelfDeletionDisabled.New(
+	"self deletion is disabled by configuration",
+)
@@ -0,0 +1,33 @@
+//+build wireinject
+
+package user
+
+import (
+	"net/http"
+
+	"github.com/google/wire"
+
+	"github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/user"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
+)
+
+func provideAuthenticationConfig(c *config.TenantConfiguration) *config.AuthenticationConfiguration {
+	return c.AppConfig.Authentication
+}
+
+func provideDeleteUserHandler(requireAuthz handler.RequireAuthz, h *DeleteUserHandler) http.Handler {
+	return requireAuthz(h, h)
+}
+
+func newDeleteUserHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	wire.Build(
+		auth.DependencySet,
+		provideAuthenticationConfig,
+		wire.Bind(new(userDeleter), new(*user.Commands)),
+		wire.Struct(new(DeleteUserHandler), "*"),
+		provideDeleteUserHandler,
+	)
+	return nil
+}
@@ -0,0 +1,149 @@
+// Code generated by Wire. DO NOT EDIT.
+
+//go:generate wire
+//+build !wireinject
+
+package user
+
+import (
+	"github.com/skygeario/skygear-server/pkg/auth"
+	auth2 "github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
+	redis3 "github.com/skygeario/skygear-server/pkg/auth/dependency/auth/redis"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/bearertoken"
+	ldap3 "github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/ldap"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/oob"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/password"
+	provider2 "github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/provider"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/recoverycode"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/totp"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/forgotpassword"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/anonymous"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/ldap"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/loginid"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/oauth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/provider"
+	ldap2 "github.com/skygeario/skygear-server/pkg/auth/dependency/ldap"
+	oauth2 "github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
+	pq2 "github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/pq"
+	redis2 "github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/redis"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oidc"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/session"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/session/redis"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/urlprefix"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/user"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/userprofile"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/userverify"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/welcomemessage"
+	"github.com/skygeario/skygear-server/pkg/core/async"
+	"github.com/skygeario/skygear-server/pkg/core/auth/authinfo/pq"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+	"github.com/skygeario/skygear-server/pkg/core/handler"
+	"github.com/skygeario/skygear-server/pkg/core/logging"
+	"github.com/skygeario/skygear-server/pkg/core/time"
+	"net/http"
+)
+
+// Injectors from wire.go:
+
+func newDeleteUserHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	context := auth.ProvideContext(r)
+	tenantConfiguration := auth.ProvideTenantConfig(context, m)
+	factory := logging.ProvideLoggerFactory(context, tenantConfiguration)
+	requireAuthz := handler.NewRequireAuthzFactory(factory)
+	txContext := db.ProvideTxContext(context, tenantConfiguration)
+	authenticationConfiguration := provideAuthenticationConfig(tenantConfiguration)
+	sqlBuilderFactory := db.ProvideSQLBuilderFactory(tenantConfiguration)
+	sqlExecutor := db.ProvideSQLExecutor(context, tenantConfiguration)
+	store := pq.ProvideStore(sqlBuilderFactory, sqlExecutor)
+	timeProvider := time.NewProvider()
+	sqlBuilder := auth.ProvideAuthSQLBuilder(sqlBuilderFactory)
+	userprofileStore := userprofile.ProvideStore(timeProvider, sqlBuilder, sqlExecutor)
+	reservedNameChecker := auth.ProvideReservedNameChecker(m)
+	typeCheckerFactory := loginid.ProvideTypeCheckerFactory(tenantConfiguration, reservedNameChecker)
+	checker := loginid.ProvideChecker(tenantConfiguration, typeCheckerFactory)
+	normalizerFactory := loginid.ProvideNormalizerFactory(tenantConfiguration)
+	loginidProvider := loginid.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration, checker, normalizerFactory)
+	oauthProvider := oauth.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	anonymousProvider := anonymous.ProvideProvider(sqlBuilder, sqlExecutor)
+	ldapProvider := ldap.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider, ldapProvider)
+	historyStoreImpl := password.ProvideHistoryStore(timeProvider, sqlBuilder, sqlExecutor)
+	passwordChecker := password.ProvideChecker(tenantConfiguration, historyStoreImpl)
+	passwordProvider := password.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, factory, historyStoreImpl, passwordChecker, tenantConfiguration)
+	totpProvider := totp.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
+	engine := auth.ProvideTemplateEngine(tenantConfiguration, m)
+	urlprefixProvider := urlprefix.NewProvider(r)
+	executor := auth.ProvideTaskExecutor(m)
+	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
+	oobProvider := oob.ProvideProvider(context, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider, engine, urlprefixProvider, queue)
+	bearertokenProvider := bearertoken.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
+	recoverycodeProvider := recoverycode.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
+	directory := ldap2.ProvideDirectory(tenantConfiguration)
+	provider3 := ldap3.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, directory)
+	provider4 := &provider2.Provider{
+		Password:     passwordProvider,
+		TOTP:         totpProvider,
+		OOBOTP:       oobProvider,
+		BearerToken:  bearertokenProvider,
+		RecoveryCode: recoverycodeProvider,
+		LDAP:         provider3,
+	}
+	userStore := user.ProvideStore(sqlBuilderFactory, sqlExecutor, timeProvider)
+	queries := &user.Queries{
+		AuthInfos:    store,
+		UserProfiles: userprofileStore,
+		Identities:   providerProvider,
+		Time:         timeProvider,
+		Store:        userStore,
+	}
+	hookStore := hook.ProvideStore(tenantConfiguration, sqlBuilder, sqlExecutor)
+	attemptStore := hook.ProvideAttemptStore(context, tenantConfiguration, sqlBuilder)
+	deliverer := hook.ProvideDeliverer(tenantConfiguration, timeProvider, store, userprofileStore, loginidProvider, attemptStore, factory)
+	hookProvider := hook.ProvideHookProvider(context, tenantConfiguration, sqlBuilder, hookStore, txContext, timeProvider, queries, deliverer, queue, executor, factory)
+	sessionStore := redis.ProvideStore(context, tenantConfiguration, timeProvider, factory)
+	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
+	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	manager := session.ProvideSessionManager(sessionStore, timeProvider, tenantConfiguration, cookieConfiguration)
+	grantStore := redis2.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
+	sessionManager := &oauth2.SessionManager{
+		Store: grantStore,
+		Time:  timeProvider,
+	}
+	eventStore := redis3.ProvideEventStore(context, tenantConfiguration)
+	clientStore := &pq2.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	clientResolver := oauth2.ProvideClientResolver(tenantConfiguration, clientStore)
+	logoutNotifier := oidc.ProvideLogoutNotifier(tenantConfiguration, urlprefixProvider, clientResolver, queue, timeProvider)
+	authSessionManager := auth2.ProvideSessionManager(queries, hookProvider, manager, sessionManager, eventStore, logoutNotifier, factory)
+	authorizationStore := &pq2.AuthorizationStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	userverifyStore := userverify.ProvideStore(sqlBuilder, sqlExecutor)
+	storeImpl := &forgotpassword.StoreImpl{
+		Context: context,
+	}
+	welcomemessageProvider := welcomemessage.ProvideProvider(context, tenantConfiguration, engine, queue)
+	commands := user.ProvideCommands(store, userprofileStore, providerProvider, provider4, authSessionManager, authorizationStore, userverifyStore, storeImpl, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
+	deleteUserHandler := &DeleteUserHandler{
+		TxContext:            txContext,
+		AuthenticationConfig: authenticationConfiguration,
+		Users:                commands,
+	}
+	httpHandler := provideDeleteUserHandler(requireAuthz, deleteUserHandler)
+	return httpHandler
+}
+
+// wire.go:
+
+func provideAuthenticationConfig(c *config.TenantConfiguration) *config.AuthenticationConfiguration {
+	return c.AppConfig.Authentication
+}
+
+func provideDeleteUserHandler(requireAuthz handler.RequireAuthz, h *DeleteUserHandler) http.Handler {
+	return requireAuthz(h, h)
+}
@@ -0,0 +1,92 @@
+package webapp
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	pkg "github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
+	oauthhandler "github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/handler"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/webapp"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+)
+
+func AttachConsentHandler(
+	router *mux.Router,
+	authDependency pkg.DependencyMap,
+) {
+	router.
+		NewRoute().
+		Path("/consent").
+		Methods("OPTIONS", "POST", "GET").
+		Handler(pkg.MakeHandler(authDependency, newConsentHandler))
+}
+
+type consentProvider interface {
+	ParseRequest(authorizeURI string) (*oauthhandler.ConsentRequest, error)
+	Grant(req *oauthhandler.ConsentRequest, session auth.AuthSession) error
+	Deny(req *oauthhandler.ConsentRequest) oauthhandler.AuthorizationResult
+	DescribeScope(scope string) string
+}
+
+type scopeDescriber interface {
+	DescribeScope(scope string) string
+}
+
+func describeScopes(d scopeDescriber, scopes []string) []map[string]interface{} {
+	var described []map[string]interface{}
+	for _, s := range scopes {
+		described = append(described, map[string]interface{}{
+			"name":        s,
+			"description": d.DescribeScope(s),
+		})
+	}
+	return described
+}
+
+type ConsentHandler struct {
+	RenderProvider webapp.RenderProvider
+	Consents       consentProvider
+	TxContext      db.TxContext
+}
+
+func (h *ConsentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	db.WithTx(h.TxContext, func() error {
+		// redirect_uri is the authorize URI to retry after consent.
+		req, err := h.Consents.ParseRequest(r.URL.Query().Get("redirect_uri"))
+		if err != nil {
+			h.RenderProvider.WritePage(w, r, webapp.TemplateItemTypeAuthUIConsentHTML, err)
+			return err
+		}
+
+		if r.Method == "POST" {
+			switch r.Form.Get("x_action") {
+			case "allow":
+				err = h.Consents.Grant(req, auth.GetSession(r.Context()))
+				if err != nil {
+					h.RenderProvider.WritePage(w, r, webapp.TemplateItemTypeAuthUIConsentHTML, err)
+					return err
+				}
+				webapp.RedirectToRedirectURI(w, r)
+				return nil
+			case "deny":
+				h.Consents.Deny(req).WriteResponse(w, r)
+				return nil
+			}
+		}
+
+		data := map[string]interface{}{
+			"x_client_name": req.Client.ClientName(),
+			"x_client_uri":  req.Client.ClientURI(),
+			"x_scopes":      describeScopes(h.Consents, req.Request.Scope()),
+		}
+		h.RenderProvider.WritePageWithData(w, r, webapp.TemplateItemTypeAuthUIConsentHTML, data, nil)
+		return nil
+	})
+}
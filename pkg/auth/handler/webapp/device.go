@@ -0,0 +1,88 @@
+package webapp
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	pkg "github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
+	oauthhandler "github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/handler"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/webapp"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+)
+
+func AttachDeviceHandler(
+	router *mux.Router,
+	authDependency pkg.DependencyMap,
+) {
+	router.
+		NewRoute().
+		Path("/device").
+		Methods("OPTIONS", "POST", "GET").
+		Handler(pkg.MakeHandler(authDependency, newDeviceHandler))
+}
+
+type deviceVerifier interface {
+	ParseUserCode(userCode string, session auth.AuthSession) (*oauthhandler.DeviceConsentRequest, error)
+	Verify(req *oauthhandler.DeviceConsentRequest, session auth.AuthSession, approved bool) error
+}
+
+type DeviceHandler struct {
+	RenderProvider webapp.RenderProvider
+	Verifier       deviceVerifier
+	Scopes         scopeDescriber
+	TxContext      db.TxContext
+}
+
+func (h *DeviceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	db.WithTx(h.TxContext, func() error {
+		if r.Method == "POST" {
+			sess := auth.GetSession(r.Context())
+			req, err := h.Verifier.ParseUserCode(r.Form.Get("x_user_code"), sess)
+			if err != nil {
+				h.RenderProvider.WritePage(w, r, webapp.TemplateItemTypeAuthUIDeviceHTML, err)
+				return err
+			}
+
+			action := r.Form.Get("x_action")
+			if action != "allow" && action != "deny" {
+				// The user code is entered; ask for consent of user.
+				data := map[string]interface{}{
+					"x_client_name": req.Client.ClientName(),
+					"x_client_uri":  req.Client.ClientURI(),
+					"x_scopes":      describeScopes(h.Scopes, req.Grant.Scopes),
+				}
+				h.RenderProvider.WritePageWithData(w, r, webapp.TemplateItemTypeAuthUIDeviceHTML, data, nil)
+				return nil
+			}
+
+			approved := action == "allow"
+			err = h.Verifier.Verify(req, sess, approved)
+			if err != nil {
+				h.RenderProvider.WritePage(w, r, webapp.TemplateItemTypeAuthUIDeviceHTML, err)
+				return err
+			}
+
+			if approved {
+				r.Form.Set("x_device_approved", "true")
+			} else {
+				r.Form.Set("x_device_denied", "true")
+			}
+			h.RenderProvider.WritePage(w, r, webapp.TemplateItemTypeAuthUIDeviceHTML, nil)
+			return nil
+		}
+
+		// The user code is pre-filled with verification_uri_complete.
+		if userCode := r.Form.Get("user_code"); userCode != "" {
+			r.Form.Set("x_user_code", userCode)
+		}
+		h.RenderProvider.WritePage(w, r, webapp.TemplateItemTypeAuthUIDeviceHTML, nil)
+		return nil
+	})
+}
@@ -34,6 +34,7 @@ type loginProvider interface {
 	GetLoginForm(w http.ResponseWriter, r *http.Request) (func(error), error)
 	LoginWithLoginID(w http.ResponseWriter, r *http.Request) (func(error), error)
 	LoginIdentityProvider(w http.ResponseWriter, r *http.Request, providerAlias string) (func(error), error)
+	LoginWithLDAP(w http.ResponseWriter, r *http.Request) (func(error), error)
 }
 
 type LoginHandler struct {
@@ -61,6 +62,12 @@ func (h *LoginHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				return err
 			}
 
+			if r.Form.Get("x_ldap") != "" {
+				writeResponse, err := h.Provider.LoginWithLDAP(w, r)
+				writeResponse(err)
+				return err
+			}
+
 			writeResponse, err := h.Provider.LoginWithLoginID(w, r)
 			writeResponse(err)
 			return err
@@ -25,9 +25,14 @@ type logoutSessionManager interface {
 	Logout(auth.AuthSession, http.ResponseWriter) error
 }
 
+type frontChannelLogoutProvider interface {
+	FrontChannelLogoutURIs(auth.AuthSession) ([]string, error)
+}
+
 type LogoutHandler struct {
 	RenderProvider webapp.RenderProvider
 	SessionManager logoutSessionManager
+	FrontChannel   frontChannelLogoutProvider
 	TxContext      db.TxContext
 }
 
@@ -35,8 +40,20 @@ func (h *LogoutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	db.WithTx(h.TxContext, func() error {
 		if r.Method == "POST" && r.Form.Get("x_action") == "logout" {
 			sess := auth.GetSession(r.Context())
+			// Clients are resolved before the session is ended.
+			uris, err := h.FrontChannel.FrontChannelLogoutURIs(sess)
 			h.SessionManager.Logout(sess, w)
-			webapp.RedirectToRedirectURI(w, r)
+
+			if err != nil || len(uris) == 0 {
+				webapp.RedirectToRedirectURI(w, r)
+				return nil
+			}
+
+			// Render front-channel logout URIs in iframes.
+			h.RenderProvider.WritePageWithData(w, r, webapp.TemplateItemTypeAuthUILogoutHTML, map[string]interface{}{
+				"x_frontchannel_logout_uris": uris,
+				"x_redirect_uri":             webapp.GetRedirectURI(r),
+			}, nil)
 		} else {
 			h.RenderProvider.WritePage(w, r, webapp.TemplateItemTypeAuthUILogoutHTML, nil)
 		}
@@ -0,0 +1,48 @@
+package webapp
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+)
+
+func AttachSAMLACSHandler(
+	router *mux.Router,
+	authDependency auth.DependencyMap,
+) {
+	router.
+		NewRoute().
+		Path("/sso/saml/acs/{provider}").
+		Methods("OPTIONS", "POST").
+		Handler(auth.MakeHandler(authDependency, newSAMLACSHandler))
+}
+
+type samlProvider interface {
+	HandleSAMLCallback(w http.ResponseWriter, r *http.Request, providerAlias string) (func(error), error)
+}
+
+// SAMLACSHandler is the assertion consumer service of SAML identity
+// providers, receiving responses with HTTP-POST binding.
+type SAMLACSHandler struct {
+	Provider  samlProvider
+	TxContext db.TxContext
+}
+
+func (h *SAMLACSHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	vars := mux.Vars(r)
+	providerAlias := vars["provider"]
+
+	db.WithTx(h.TxContext, func() error {
+		writeResponse, err := h.Provider.HandleSAMLCallback(w, r, providerAlias)
+		writeResponse(err)
+		return err
+	})
+}
@@ -0,0 +1,46 @@
+package webapp
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/sso"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/webapp"
+)
+
+func AttachSAMLMetadataHandler(
+	router *mux.Router,
+	authDependency auth.DependencyMap,
+) {
+	router.
+		NewRoute().
+		Path("/sso/saml/metadata/{provider}").
+		Methods("OPTIONS", "GET").
+		Handler(auth.MakeHandler(authDependency, newSAMLMetadataHandler))
+}
+
+// SAMLMetadataHandler publishes SP metadata of SAML identity providers.
+// The metadata URL is also the SP entity ID.
+type SAMLMetadataHandler struct {
+	ProviderFactory webapp.OAuthProviderFactory
+}
+
+func (h *SAMLMetadataHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	provider, ok := h.ProviderFactory.NewOAuthProvider(vars["provider"]).(sso.SAMLProvider)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	metadata, err := provider.SPMetadata()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/samlmetadata+xml")
+	_, _ = w.Write(metadata)
+}
@@ -0,0 +1,82 @@
+package webapp
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	pkg "github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
+	oauthhandler "github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/handler"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/webapp"
+	"github.com/skygeario/skygear-server/pkg/core/db"
+)
+
+func AttachSettingsAuthorizationsHandler(
+	router *mux.Router,
+	authDependency pkg.DependencyMap,
+) {
+	router.
+		NewRoute().
+		Path("/settings/authorizations").
+		Methods("OPTIONS", "POST", "GET").
+		Handler(pkg.MakeHandler(authDependency, newSettingsAuthorizationsHandler))
+}
+
+type authorizationManager interface {
+	List(userID string) ([]oauthhandler.GrantedAuthorization, error)
+	Revoke(userID string, authzID string) error
+	DescribeScope(scope string) string
+}
+
+type SettingsAuthorizationsHandler struct {
+	RenderProvider webapp.RenderProvider
+	Authorizations authorizationManager
+	TxContext      db.TxContext
+}
+
+func (h *SettingsAuthorizationsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	userID := auth.GetSession(r.Context()).AuthnAttrs().UserID
+
+	db.WithTx(h.TxContext, func() error {
+		var revokeErr error
+		if r.Method == "POST" && r.Form.Get("x_action") == "revoke" {
+			revokeErr = h.Authorizations.Revoke(userID, r.Form.Get("x_authorization_id"))
+			if revokeErr == nil {
+				webapp.RedirectToCurrentPath(w, r)
+				return nil
+			}
+		}
+
+		granted, err := h.Authorizations.List(userID)
+		if err != nil {
+			return err
+		}
+
+		var authorizations []map[string]interface{}
+		for _, g := range granted {
+			a := map[string]interface{}{
+				"id":          g.Authorization.ID,
+				"client_name": g.Authorization.ClientID,
+				"scopes":      describeScopes(h.Authorizations, g.Authorization.Scopes),
+				"created_at":  g.Authorization.CreatedAt,
+			}
+			if g.Client != nil {
+				a["client_name"] = g.Client.ClientName()
+				a["client_uri"] = g.Client.ClientURI()
+			}
+			authorizations = append(authorizations, a)
+		}
+
+		data := map[string]interface{}{
+			"x_authorizations": authorizations,
+		}
+		h.RenderProvider.WritePageWithData(w, r, webapp.TemplateItemTypeAuthUISettingsAuthorizationsHTML, data, revokeErr)
+		return revokeErr
+	})
+}
@@ -10,6 +10,8 @@ import (
 	pkg "github.com/skygeario/skygear-server/pkg/auth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/forgotpassword"
+	oauthhandler "github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/handler"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oidc"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/sso"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/webapp"
 )
@@ -160,12 +162,44 @@ func newLogoutHandler(r *http.Request, m pkg.DependencyMap) http.Handler {
 	wire.Build(
 		dependencySet,
 		wire.Bind(new(logoutSessionManager), new(*auth.SessionManager)),
+		wire.Bind(new(frontChannelLogoutProvider), new(*oidc.LogoutNotifier)),
 		wire.Struct(new(LogoutHandler), "*"),
 		wire.Bind(new(http.Handler), new(*LogoutHandler)),
 	)
 	return nil
 }
 
+func newDeviceHandler(r *http.Request, m pkg.DependencyMap) http.Handler {
+	wire.Build(
+		dependencySet,
+		wire.Bind(new(deviceVerifier), new(*oauthhandler.DeviceAuthorizationHandler)),
+		wire.Bind(new(scopeDescriber), new(*oauthhandler.ConsentHandler)),
+		wire.Struct(new(DeviceHandler), "*"),
+		wire.Bind(new(http.Handler), new(*DeviceHandler)),
+	)
+	return nil
+}
+
+func newConsentHandler(r *http.Request, m pkg.DependencyMap) http.Handler {
+	wire.Build(
+		dependencySet,
+		wire.Bind(new(consentProvider), new(*oauthhandler.ConsentHandler)),
+		wire.Struct(new(ConsentHandler), "*"),
+		wire.Bind(new(http.Handler), new(*ConsentHandler)),
+	)
+	return nil
+}
+
+func newSettingsAuthorizationsHandler(r *http.Request, m pkg.DependencyMap) http.Handler {
+	wire.Build(
+		dependencySet,
+		wire.Bind(new(authorizationManager), new(*oauthhandler.ConsentHandler)),
+		wire.Struct(new(SettingsAuthorizationsHandler), "*"),
+		wire.Bind(new(http.Handler), new(*SettingsAuthorizationsHandler)),
+	)
+	return nil
+}
+
 func newSSOCallbackHandler(r *http.Request, m pkg.DependencyMap) http.Handler {
 	wire.Build(
 		dependencySet,
@@ -175,3 +209,22 @@ func newSSOCallbackHandler(r *http.Request, m pkg.DependencyMap) http.Handler {
 	)
 	return nil
 }
+
+func newSAMLACSHandler(r *http.Request, m pkg.DependencyMap) http.Handler {
+	wire.Build(
+		dependencySet,
+		wire.Bind(new(samlProvider), new(*webapp.AuthenticateProviderImpl)),
+		wire.Struct(new(SAMLACSHandler), "*"),
+		wire.Bind(new(http.Handler), new(*SAMLACSHandler)),
+	)
+	return nil
+}
+
+func newSAMLMetadataHandler(r *http.Request, m pkg.DependencyMap) http.Handler {
+	wire.Build(
+		dependencySet,
+		wire.Struct(new(SAMLMetadataHandler), "*"),
+		wire.Bind(new(http.Handler), new(*SAMLMetadataHandler)),
+	)
+	return nil
+}
@@ -9,8 +9,9 @@ import (
 	"github.com/google/wire"
 	"github.com/skygeario/skygear-server/pkg/auth"
 	auth2 "github.com/skygeario/skygear-server/pkg/auth/dependency/auth"
-	redis3 "github.com/skygeario/skygear-server/pkg/auth/dependency/auth/redis"
+	redis4 "github.com/skygeario/skygear-server/pkg/auth/dependency/auth/redis"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/bearertoken"
+	ldap3 "github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/ldap"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/oob"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/password"
 	provider2 "github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/provider"
@@ -20,23 +21,26 @@ import (
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/forgotpassword"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/anonymous"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/ldap"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/loginid"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/oauth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/provider"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/interaction"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/interaction/flows"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/interaction/redis"
+	ldap2 "github.com/skygeario/skygear-server/pkg/auth/dependency/ldap"
 	oauth2 "github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/handler"
 	pq2 "github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/pq"
-	redis2 "github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/redis"
+	redis3 "github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/redis"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/oidc"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/session"
-	redis4 "github.com/skygeario/skygear-server/pkg/auth/dependency/session/redis"
+	redis2 "github.com/skygeario/skygear-server/pkg/auth/dependency/session/redis"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/sso"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/urlprefix"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/user"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/userprofile"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/userverify"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/webapp"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/welcomemessage"
 	"github.com/skygeario/skygear-server/pkg/core/async"
@@ -68,7 +72,8 @@ func newLoginHandler(r *http.Request, m auth.DependencyMap) http.Handler {
 	loginidProvider := loginid.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration, loginidChecker, normalizerFactory)
 	oauthProvider := oauth.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
 	anonymousProvider := anonymous.ProvideProvider(sqlBuilder, sqlExecutor)
-	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider)
+	ldapProvider := ldap.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider, ldapProvider)
 	renderProvider := webapp.ProvideRenderProvider(staticAssetURLPrefix, tenantConfiguration, engine, checker, providerProvider)
 	stateStoreImpl := &webapp.StateStoreImpl{
 		Context: context,
@@ -79,48 +84,79 @@ func newLoginHandler(r *http.Request, m auth.DependencyMap) http.Handler {
 	stateCodec := sso.ProvideStateCodec(tenantConfiguration)
 	store := pq.ProvideStore(sqlBuilderFactory, sqlExecutor)
 	userprofileStore := userprofile.ProvideStore(timeProvider, sqlBuilder, sqlExecutor)
+	userStore := user.ProvideStore(sqlBuilderFactory, sqlExecutor, timeProvider)
 	queries := &user.Queries{
 		AuthInfos:    store,
 		UserProfiles: userprofileStore,
 		Identities:   providerProvider,
 		Time:         timeProvider,
+		Store:        userStore,
 	}
+	hookStore := hook.ProvideStore(tenantConfiguration, sqlBuilder, sqlExecutor)
 	txContext := db.ProvideTxContext(context, tenantConfiguration)
+	attemptStore := hook.ProvideAttemptStore(context, tenantConfiguration, sqlBuilder)
 	factory := logging.ProvideLoggerFactory(context, tenantConfiguration)
-	hookProvider := hook.ProvideHookProvider(context, sqlBuilder, sqlExecutor, tenantConfiguration, txContext, timeProvider, queries, store, userprofileStore, loginidProvider, factory)
+	deliverer := hook.ProvideDeliverer(tenantConfiguration, timeProvider, store, userprofileStore, loginidProvider, attemptStore, factory)
+	executor := auth.ProvideTaskExecutor(m)
+	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
+	hookProvider := hook.ProvideHookProvider(context, tenantConfiguration, sqlBuilder, hookStore, txContext, timeProvider, queries, deliverer, queue, executor, factory)
 	redisStore := redis.ProvideStore(context, tenantConfiguration, timeProvider)
 	passwordProvider := password.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, factory, historyStoreImpl, checker, tenantConfiguration)
 	totpProvider := totp.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
 	urlprefixProvider := urlprefix.NewProvider(r)
-	executor := auth.ProvideTaskExecutor(m)
-	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
 	oobProvider := oob.ProvideProvider(context, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider, engine, urlprefixProvider, queue)
 	bearertokenProvider := bearertoken.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
 	recoverycodeProvider := recoverycode.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
-	provider3 := &provider2.Provider{
+	directory := ldap2.ProvideDirectory(tenantConfiguration)
+	provider3 := ldap3.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, directory)
+	provider4 := &provider2.Provider{
 		Password:     passwordProvider,
 		TOTP:         totpProvider,
 		OOBOTP:       oobProvider,
 		BearerToken:  bearertokenProvider,
 		RecoveryCode: recoverycodeProvider,
+		LDAP:         provider3,
+	}
+	sessionStore := redis2.ProvideStore(context, tenantConfiguration, timeProvider, factory)
+	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
+	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	manager := session.ProvideSessionManager(sessionStore, timeProvider, tenantConfiguration, cookieConfiguration)
+	grantStore := redis3.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
+	sessionManager := &oauth2.SessionManager{
+		Store: grantStore,
+		Time:  timeProvider,
+	}
+	eventStore := redis4.ProvideEventStore(context, tenantConfiguration)
+	clientStore := &pq2.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	clientResolver := oauth2.ProvideClientResolver(tenantConfiguration, clientStore)
+	logoutNotifier := oidc.ProvideLogoutNotifier(tenantConfiguration, urlprefixProvider, clientResolver, queue, timeProvider)
+	authSessionManager := auth2.ProvideSessionManager(queries, hookProvider, manager, sessionManager, eventStore, logoutNotifier, factory)
+	authorizationStore := &pq2.AuthorizationStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	userverifyStore := userverify.ProvideStore(sqlBuilder, sqlExecutor)
+	storeImpl := &forgotpassword.StoreImpl{
+		Context: context,
 	}
 	welcomemessageProvider := welcomemessage.ProvideProvider(context, tenantConfiguration, engine, queue)
-	commands := user.ProvideCommands(store, userprofileStore, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
+	commands := user.ProvideCommands(store, userprofileStore, providerProvider, provider4, authSessionManager, authorizationStore, userverifyStore, storeImpl, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
-	interactionProvider := interaction.ProvideProvider(redisStore, timeProvider, factory, providerProvider, provider3, userProvider, oobProvider, tenantConfiguration, hookProvider)
-	authorizationStore := &pq2.AuthorizationStore{
-		SQLBuilder:  sqlBuilder,
-		SQLExecutor: sqlExecutor,
+	interactionProvider := interaction.ProvideProvider(redisStore, timeProvider, factory, providerProvider, provider4, userProvider, oobProvider, tenantConfiguration, hookProvider)
+	endpointsProvider := &auth.EndpointsProvider{
+		PrefixProvider: urlprefixProvider,
 	}
-	grantStore := redis2.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
-	eventStore := redis3.ProvideEventStore(context, tenantConfiguration)
+	clientAuthenticator := handler.ProvideClientAuthenticator(r, clientResolver, urlprefixProvider, endpointsProvider, grantStore, timeProvider)
+	jwtAccessTokenCodec := oauth2.ProvideJWTAccessTokenCodec(tenantConfiguration, urlprefixProvider, timeProvider)
 	accessEventProvider := auth2.AccessEventProvider{
 		Store: eventStore,
 	}
-	sessionStore := redis4.ProvideStore(context, tenantConfiguration, timeProvider, factory)
 	authAccessEventProvider := &auth2.AccessEventProvider{
 		Store: eventStore,
 	}
@@ -133,13 +169,11 @@ func newLoginHandler(r *http.Request, m auth.DependencyMap) http.Handler {
 		Anonymous:    anonymousProvider,
 		Challenges:   challengeProvider,
 	}
-	idTokenIssuer := oidc.ProvideIDTokenIssuer(tenantConfiguration, urlprefixProvider, queries, timeProvider)
+	idTokenIssuer := oidc.ProvideIDTokenIssuer(tenantConfiguration, urlprefixProvider, queries, providerProvider, timeProvider)
 	tokenGenerator := _wireTokenGeneratorValue
-	tokenHandler := handler.ProvideTokenHandler(r, tenantConfiguration, factory, authorizationStore, grantStore, grantStore, grantStore, accessEventProvider, sessionProvider, anonymousFlow, idTokenIssuer, tokenGenerator, timeProvider)
-	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
-	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	tokenHandler := handler.ProvideTokenHandler(r, tenantConfiguration, factory, clientAuthenticator, authorizationStore, grantStore, grantStore, grantStore, grantStore, jwtAccessTokenCodec, accessEventProvider, sessionProvider, queries, hookProvider, authSessionManager, anonymousFlow, idTokenIssuer, tokenGenerator, timeProvider)
 	userController := flows.ProvideUserController(store, queries, tokenHandler, cookieConfiguration, sessionProvider, hookProvider, timeProvider, tenantConfiguration)
-	webAppFlow := flows.ProvideWebAppFlow(tenantConfiguration, providerProvider, queries, hookProvider, interactionProvider, userController)
+	webAppFlow := flows.ProvideWebAppFlow(tenantConfiguration, providerProvider, queries, hookProvider, interactionProvider, userController, directory)
 	redirectURLFunc := provideRedirectURIForWebAppFunc()
 	oAuthProviderFactory := sso.ProvideOAuthProviderFactory(tenantConfiguration, urlprefixProvider, timeProvider, normalizerFactory, redirectURLFunc)
 	authenticateProviderImpl := &webapp.AuthenticateProviderImpl{
@@ -180,7 +214,8 @@ func newEnterPasswordHandler(r *http.Request, m auth.DependencyMap) http.Handler
 	loginidProvider := loginid.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration, loginidChecker, normalizerFactory)
 	oauthProvider := oauth.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
 	anonymousProvider := anonymous.ProvideProvider(sqlBuilder, sqlExecutor)
-	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider)
+	ldapProvider := ldap.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider, ldapProvider)
 	renderProvider := webapp.ProvideRenderProvider(staticAssetURLPrefix, tenantConfiguration, engine, checker, providerProvider)
 	stateStoreImpl := &webapp.StateStoreImpl{
 		Context: context,
@@ -191,48 +226,79 @@ func newEnterPasswordHandler(r *http.Request, m auth.DependencyMap) http.Handler
 	stateCodec := sso.ProvideStateCodec(tenantConfiguration)
 	store := pq.ProvideStore(sqlBuilderFactory, sqlExecutor)
 	userprofileStore := userprofile.ProvideStore(timeProvider, sqlBuilder, sqlExecutor)
+	userStore := user.ProvideStore(sqlBuilderFactory, sqlExecutor, timeProvider)
 	queries := &user.Queries{
 		AuthInfos:    store,
 		UserProfiles: userprofileStore,
 		Identities:   providerProvider,
 		Time:         timeProvider,
+		Store:        userStore,
 	}
+	hookStore := hook.ProvideStore(tenantConfiguration, sqlBuilder, sqlExecutor)
 	txContext := db.ProvideTxContext(context, tenantConfiguration)
+	attemptStore := hook.ProvideAttemptStore(context, tenantConfiguration, sqlBuilder)
 	factory := logging.ProvideLoggerFactory(context, tenantConfiguration)
-	hookProvider := hook.ProvideHookProvider(context, sqlBuilder, sqlExecutor, tenantConfiguration, txContext, timeProvider, queries, store, userprofileStore, loginidProvider, factory)
+	deliverer := hook.ProvideDeliverer(tenantConfiguration, timeProvider, store, userprofileStore, loginidProvider, attemptStore, factory)
+	executor := auth.ProvideTaskExecutor(m)
+	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
+	hookProvider := hook.ProvideHookProvider(context, tenantConfiguration, sqlBuilder, hookStore, txContext, timeProvider, queries, deliverer, queue, executor, factory)
 	redisStore := redis.ProvideStore(context, tenantConfiguration, timeProvider)
 	passwordProvider := password.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, factory, historyStoreImpl, checker, tenantConfiguration)
 	totpProvider := totp.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
 	urlprefixProvider := urlprefix.NewProvider(r)
-	executor := auth.ProvideTaskExecutor(m)
-	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
 	oobProvider := oob.ProvideProvider(context, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider, engine, urlprefixProvider, queue)
 	bearertokenProvider := bearertoken.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
 	recoverycodeProvider := recoverycode.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
-	provider3 := &provider2.Provider{
+	directory := ldap2.ProvideDirectory(tenantConfiguration)
+	provider3 := ldap3.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, directory)
+	provider4 := &provider2.Provider{
 		Password:     passwordProvider,
 		TOTP:         totpProvider,
 		OOBOTP:       oobProvider,
 		BearerToken:  bearertokenProvider,
 		RecoveryCode: recoverycodeProvider,
+		LDAP:         provider3,
+	}
+	sessionStore := redis2.ProvideStore(context, tenantConfiguration, timeProvider, factory)
+	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
+	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	manager := session.ProvideSessionManager(sessionStore, timeProvider, tenantConfiguration, cookieConfiguration)
+	grantStore := redis3.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
+	sessionManager := &oauth2.SessionManager{
+		Store: grantStore,
+		Time:  timeProvider,
+	}
+	eventStore := redis4.ProvideEventStore(context, tenantConfiguration)
+	clientStore := &pq2.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	clientResolver := oauth2.ProvideClientResolver(tenantConfiguration, clientStore)
+	logoutNotifier := oidc.ProvideLogoutNotifier(tenantConfiguration, urlprefixProvider, clientResolver, queue, timeProvider)
+	authSessionManager := auth2.ProvideSessionManager(queries, hookProvider, manager, sessionManager, eventStore, logoutNotifier, factory)
+	authorizationStore := &pq2.AuthorizationStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	userverifyStore := userverify.ProvideStore(sqlBuilder, sqlExecutor)
+	storeImpl := &forgotpassword.StoreImpl{
+		Context: context,
 	}
 	welcomemessageProvider := welcomemessage.ProvideProvider(context, tenantConfiguration, engine, queue)
-	commands := user.ProvideCommands(store, userprofileStore, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
+	commands := user.ProvideCommands(store, userprofileStore, providerProvider, provider4, authSessionManager, authorizationStore, userverifyStore, storeImpl, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
-	interactionProvider := interaction.ProvideProvider(redisStore, timeProvider, factory, providerProvider, provider3, userProvider, oobProvider, tenantConfiguration, hookProvider)
-	authorizationStore := &pq2.AuthorizationStore{
-		SQLBuilder:  sqlBuilder,
-		SQLExecutor: sqlExecutor,
+	interactionProvider := interaction.ProvideProvider(redisStore, timeProvider, factory, providerProvider, provider4, userProvider, oobProvider, tenantConfiguration, hookProvider)
+	endpointsProvider := &auth.EndpointsProvider{
+		PrefixProvider: urlprefixProvider,
 	}
-	grantStore := redis2.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
-	eventStore := redis3.ProvideEventStore(context, tenantConfiguration)
+	clientAuthenticator := handler.ProvideClientAuthenticator(r, clientResolver, urlprefixProvider, endpointsProvider, grantStore, timeProvider)
+	jwtAccessTokenCodec := oauth2.ProvideJWTAccessTokenCodec(tenantConfiguration, urlprefixProvider, timeProvider)
 	accessEventProvider := auth2.AccessEventProvider{
 		Store: eventStore,
 	}
-	sessionStore := redis4.ProvideStore(context, tenantConfiguration, timeProvider, factory)
 	authAccessEventProvider := &auth2.AccessEventProvider{
 		Store: eventStore,
 	}
@@ -245,13 +311,11 @@ func newEnterPasswordHandler(r *http.Request, m auth.DependencyMap) http.Handler
 		Anonymous:    anonymousProvider,
 		Challenges:   challengeProvider,
 	}
-	idTokenIssuer := oidc.ProvideIDTokenIssuer(tenantConfiguration, urlprefixProvider, queries, timeProvider)
+	idTokenIssuer := oidc.ProvideIDTokenIssuer(tenantConfiguration, urlprefixProvider, queries, providerProvider, timeProvider)
 	tokenGenerator := _wireTokenGeneratorValue
-	tokenHandler := handler.ProvideTokenHandler(r, tenantConfiguration, factory, authorizationStore, grantStore, grantStore, grantStore, accessEventProvider, sessionProvider, anonymousFlow, idTokenIssuer, tokenGenerator, timeProvider)
-	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
-	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	tokenHandler := handler.ProvideTokenHandler(r, tenantConfiguration, factory, clientAuthenticator, authorizationStore, grantStore, grantStore, grantStore, grantStore, jwtAccessTokenCodec, accessEventProvider, sessionProvider, queries, hookProvider, authSessionManager, anonymousFlow, idTokenIssuer, tokenGenerator, timeProvider)
 	userController := flows.ProvideUserController(store, queries, tokenHandler, cookieConfiguration, sessionProvider, hookProvider, timeProvider, tenantConfiguration)
-	webAppFlow := flows.ProvideWebAppFlow(tenantConfiguration, providerProvider, queries, hookProvider, interactionProvider, userController)
+	webAppFlow := flows.ProvideWebAppFlow(tenantConfiguration, providerProvider, queries, hookProvider, interactionProvider, userController, directory)
 	redirectURLFunc := provideRedirectURIForWebAppFunc()
 	oAuthProviderFactory := sso.ProvideOAuthProviderFactory(tenantConfiguration, urlprefixProvider, timeProvider, normalizerFactory, redirectURLFunc)
 	authenticateProviderImpl := &webapp.AuthenticateProviderImpl{
@@ -288,7 +352,8 @@ func newForgotPasswordHandler(r *http.Request, m auth.DependencyMap) http.Handle
 	loginidProvider := loginid.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration, loginidChecker, normalizerFactory)
 	oauthProvider := oauth.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
 	anonymousProvider := anonymous.ProvideProvider(sqlBuilder, sqlExecutor)
-	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider)
+	ldapProvider := ldap.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider, ldapProvider)
 	renderProvider := webapp.ProvideRenderProvider(staticAssetURLPrefix, tenantConfiguration, engine, checker, providerProvider)
 	stateStoreImpl := &webapp.StateStoreImpl{
 		Context: context,
@@ -301,38 +366,68 @@ func newForgotPasswordHandler(r *http.Request, m auth.DependencyMap) http.Handle
 	}
 	store := pq.ProvideStore(sqlBuilderFactory, sqlExecutor)
 	userprofileStore := userprofile.ProvideStore(timeProvider, sqlBuilder, sqlExecutor)
+	userStore := user.ProvideStore(sqlBuilderFactory, sqlExecutor, timeProvider)
 	queries := &user.Queries{
 		AuthInfos:    store,
 		UserProfiles: userprofileStore,
 		Identities:   providerProvider,
 		Time:         timeProvider,
+		Store:        userStore,
 	}
+	hookStore := hook.ProvideStore(tenantConfiguration, sqlBuilder, sqlExecutor)
 	txContext := db.ProvideTxContext(context, tenantConfiguration)
+	attemptStore := hook.ProvideAttemptStore(context, tenantConfiguration, sqlBuilder)
 	factory := logging.ProvideLoggerFactory(context, tenantConfiguration)
-	hookProvider := hook.ProvideHookProvider(context, sqlBuilder, sqlExecutor, tenantConfiguration, txContext, timeProvider, queries, store, userprofileStore, loginidProvider, factory)
-	urlprefixProvider := urlprefix.NewProvider(r)
+	deliverer := hook.ProvideDeliverer(tenantConfiguration, timeProvider, store, userprofileStore, loginidProvider, attemptStore, factory)
 	executor := auth.ProvideTaskExecutor(m)
 	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
+	hookProvider := hook.ProvideHookProvider(context, tenantConfiguration, sqlBuilder, hookStore, txContext, timeProvider, queries, deliverer, queue, executor, factory)
+	urlprefixProvider := urlprefix.NewProvider(r)
 	redisStore := redis.ProvideStore(context, tenantConfiguration, timeProvider)
 	passwordProvider := password.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, factory, historyStoreImpl, checker, tenantConfiguration)
 	totpProvider := totp.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
 	oobProvider := oob.ProvideProvider(context, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider, engine, urlprefixProvider, queue)
 	bearertokenProvider := bearertoken.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
 	recoverycodeProvider := recoverycode.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
-	provider3 := &provider2.Provider{
+	directory := ldap2.ProvideDirectory(tenantConfiguration)
+	provider3 := ldap3.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, directory)
+	provider4 := &provider2.Provider{
 		Password:     passwordProvider,
 		TOTP:         totpProvider,
 		OOBOTP:       oobProvider,
 		BearerToken:  bearertokenProvider,
 		RecoveryCode: recoverycodeProvider,
+		LDAP:         provider3,
+	}
+	sessionStore := redis2.ProvideStore(context, tenantConfiguration, timeProvider, factory)
+	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
+	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	manager := session.ProvideSessionManager(sessionStore, timeProvider, tenantConfiguration, cookieConfiguration)
+	grantStore := redis3.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
+	sessionManager := &oauth2.SessionManager{
+		Store: grantStore,
+		Time:  timeProvider,
+	}
+	eventStore := redis4.ProvideEventStore(context, tenantConfiguration)
+	clientStore := &pq2.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
 	}
+	clientResolver := oauth2.ProvideClientResolver(tenantConfiguration, clientStore)
+	logoutNotifier := oidc.ProvideLogoutNotifier(tenantConfiguration, urlprefixProvider, clientResolver, queue, timeProvider)
+	authSessionManager := auth2.ProvideSessionManager(queries, hookProvider, manager, sessionManager, eventStore, logoutNotifier, factory)
+	authorizationStore := &pq2.AuthorizationStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	userverifyStore := userverify.ProvideStore(sqlBuilder, sqlExecutor)
 	welcomemessageProvider := welcomemessage.ProvideProvider(context, tenantConfiguration, engine, queue)
-	commands := user.ProvideCommands(store, userprofileStore, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
+	commands := user.ProvideCommands(store, userprofileStore, providerProvider, provider4, authSessionManager, authorizationStore, userverifyStore, storeImpl, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
-	interactionProvider := interaction.ProvideProvider(redisStore, timeProvider, factory, providerProvider, provider3, userProvider, oobProvider, tenantConfiguration, hookProvider)
+	interactionProvider := interaction.ProvideProvider(redisStore, timeProvider, factory, providerProvider, provider4, userProvider, oobProvider, tenantConfiguration, hookProvider)
 	passwordFlow := &flows.PasswordFlow{
 		Interactions: interactionProvider,
 	}
@@ -369,7 +464,8 @@ func newForgotPasswordSuccessHandler(r *http.Request, m auth.DependencyMap) http
 	loginidProvider := loginid.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration, loginidChecker, normalizerFactory)
 	oauthProvider := oauth.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
 	anonymousProvider := anonymous.ProvideProvider(sqlBuilder, sqlExecutor)
-	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider)
+	ldapProvider := ldap.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider, ldapProvider)
 	renderProvider := webapp.ProvideRenderProvider(staticAssetURLPrefix, tenantConfiguration, engine, checker, providerProvider)
 	stateStoreImpl := &webapp.StateStoreImpl{
 		Context: context,
@@ -382,38 +478,68 @@ func newForgotPasswordSuccessHandler(r *http.Request, m auth.DependencyMap) http
 	}
 	store := pq.ProvideStore(sqlBuilderFactory, sqlExecutor)
 	userprofileStore := userprofile.ProvideStore(timeProvider, sqlBuilder, sqlExecutor)
+	userStore := user.ProvideStore(sqlBuilderFactory, sqlExecutor, timeProvider)
 	queries := &user.Queries{
 		AuthInfos:    store,
 		UserProfiles: userprofileStore,
 		Identities:   providerProvider,
 		Time:         timeProvider,
+		Store:        userStore,
 	}
+	hookStore := hook.ProvideStore(tenantConfiguration, sqlBuilder, sqlExecutor)
 	txContext := db.ProvideTxContext(context, tenantConfiguration)
+	attemptStore := hook.ProvideAttemptStore(context, tenantConfiguration, sqlBuilder)
 	factory := logging.ProvideLoggerFactory(context, tenantConfiguration)
-	hookProvider := hook.ProvideHookProvider(context, sqlBuilder, sqlExecutor, tenantConfiguration, txContext, timeProvider, queries, store, userprofileStore, loginidProvider, factory)
-	urlprefixProvider := urlprefix.NewProvider(r)
+	deliverer := hook.ProvideDeliverer(tenantConfiguration, timeProvider, store, userprofileStore, loginidProvider, attemptStore, factory)
 	executor := auth.ProvideTaskExecutor(m)
 	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
+	hookProvider := hook.ProvideHookProvider(context, tenantConfiguration, sqlBuilder, hookStore, txContext, timeProvider, queries, deliverer, queue, executor, factory)
+	urlprefixProvider := urlprefix.NewProvider(r)
 	redisStore := redis.ProvideStore(context, tenantConfiguration, timeProvider)
 	passwordProvider := password.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, factory, historyStoreImpl, checker, tenantConfiguration)
 	totpProvider := totp.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
 	oobProvider := oob.ProvideProvider(context, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider, engine, urlprefixProvider, queue)
 	bearertokenProvider := bearertoken.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
 	recoverycodeProvider := recoverycode.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
-	provider3 := &provider2.Provider{
+	directory := ldap2.ProvideDirectory(tenantConfiguration)
+	provider3 := ldap3.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, directory)
+	provider4 := &provider2.Provider{
 		Password:     passwordProvider,
 		TOTP:         totpProvider,
 		OOBOTP:       oobProvider,
 		BearerToken:  bearertokenProvider,
 		RecoveryCode: recoverycodeProvider,
+		LDAP:         provider3,
+	}
+	sessionStore := redis2.ProvideStore(context, tenantConfiguration, timeProvider, factory)
+	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
+	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	manager := session.ProvideSessionManager(sessionStore, timeProvider, tenantConfiguration, cookieConfiguration)
+	grantStore := redis3.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
+	sessionManager := &oauth2.SessionManager{
+		Store: grantStore,
+		Time:  timeProvider,
+	}
+	eventStore := redis4.ProvideEventStore(context, tenantConfiguration)
+	clientStore := &pq2.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
 	}
+	clientResolver := oauth2.ProvideClientResolver(tenantConfiguration, clientStore)
+	logoutNotifier := oidc.ProvideLogoutNotifier(tenantConfiguration, urlprefixProvider, clientResolver, queue, timeProvider)
+	authSessionManager := auth2.ProvideSessionManager(queries, hookProvider, manager, sessionManager, eventStore, logoutNotifier, factory)
+	authorizationStore := &pq2.AuthorizationStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	userverifyStore := userverify.ProvideStore(sqlBuilder, sqlExecutor)
 	welcomemessageProvider := welcomemessage.ProvideProvider(context, tenantConfiguration, engine, queue)
-	commands := user.ProvideCommands(store, userprofileStore, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
+	commands := user.ProvideCommands(store, userprofileStore, providerProvider, provider4, authSessionManager, authorizationStore, userverifyStore, storeImpl, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
-	interactionProvider := interaction.ProvideProvider(redisStore, timeProvider, factory, providerProvider, provider3, userProvider, oobProvider, tenantConfiguration, hookProvider)
+	interactionProvider := interaction.ProvideProvider(redisStore, timeProvider, factory, providerProvider, provider4, userProvider, oobProvider, tenantConfiguration, hookProvider)
 	passwordFlow := &flows.PasswordFlow{
 		Interactions: interactionProvider,
 	}
@@ -450,7 +576,8 @@ func newResetPasswordHandler(r *http.Request, m auth.DependencyMap) http.Handler
 	loginidProvider := loginid.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration, loginidChecker, normalizerFactory)
 	oauthProvider := oauth.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
 	anonymousProvider := anonymous.ProvideProvider(sqlBuilder, sqlExecutor)
-	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider)
+	ldapProvider := ldap.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider, ldapProvider)
 	renderProvider := webapp.ProvideRenderProvider(staticAssetURLPrefix, tenantConfiguration, engine, checker, providerProvider)
 	stateStoreImpl := &webapp.StateStoreImpl{
 		Context: context,
@@ -463,38 +590,68 @@ func newResetPasswordHandler(r *http.Request, m auth.DependencyMap) http.Handler
 	}
 	store := pq.ProvideStore(sqlBuilderFactory, sqlExecutor)
 	userprofileStore := userprofile.ProvideStore(timeProvider, sqlBuilder, sqlExecutor)
+	userStore := user.ProvideStore(sqlBuilderFactory, sqlExecutor, timeProvider)
 	queries := &user.Queries{
 		AuthInfos:    store,
 		UserProfiles: userprofileStore,
 		Identities:   providerProvider,
 		Time:         timeProvider,
+		Store:        userStore,
 	}
+	hookStore := hook.ProvideStore(tenantConfiguration, sqlBuilder, sqlExecutor)
 	txContext := db.ProvideTxContext(context, tenantConfiguration)
+	attemptStore := hook.ProvideAttemptStore(context, tenantConfiguration, sqlBuilder)
 	factory := logging.ProvideLoggerFactory(context, tenantConfiguration)
-	hookProvider := hook.ProvideHookProvider(context, sqlBuilder, sqlExecutor, tenantConfiguration, txContext, timeProvider, queries, store, userprofileStore, loginidProvider, factory)
-	urlprefixProvider := urlprefix.NewProvider(r)
+	deliverer := hook.ProvideDeliverer(tenantConfiguration, timeProvider, store, userprofileStore, loginidProvider, attemptStore, factory)
 	executor := auth.ProvideTaskExecutor(m)
 	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
+	hookProvider := hook.ProvideHookProvider(context, tenantConfiguration, sqlBuilder, hookStore, txContext, timeProvider, queries, deliverer, queue, executor, factory)
+	urlprefixProvider := urlprefix.NewProvider(r)
 	redisStore := redis.ProvideStore(context, tenantConfiguration, timeProvider)
 	passwordProvider := password.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, factory, historyStoreImpl, checker, tenantConfiguration)
 	totpProvider := totp.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
 	oobProvider := oob.ProvideProvider(context, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider, engine, urlprefixProvider, queue)
 	bearertokenProvider := bearertoken.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
 	recoverycodeProvider := recoverycode.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
-	provider3 := &provider2.Provider{
+	directory := ldap2.ProvideDirectory(tenantConfiguration)
+	provider3 := ldap3.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, directory)
+	provider4 := &provider2.Provider{
 		Password:     passwordProvider,
 		TOTP:         totpProvider,
 		OOBOTP:       oobProvider,
 		BearerToken:  bearertokenProvider,
 		RecoveryCode: recoverycodeProvider,
+		LDAP:         provider3,
+	}
+	sessionStore := redis2.ProvideStore(context, tenantConfiguration, timeProvider, factory)
+	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
+	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	manager := session.ProvideSessionManager(sessionStore, timeProvider, tenantConfiguration, cookieConfiguration)
+	grantStore := redis3.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
+	sessionManager := &oauth2.SessionManager{
+		Store: grantStore,
+		Time:  timeProvider,
+	}
+	eventStore := redis4.ProvideEventStore(context, tenantConfiguration)
+	clientStore := &pq2.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
 	}
+	clientResolver := oauth2.ProvideClientResolver(tenantConfiguration, clientStore)
+	logoutNotifier := oidc.ProvideLogoutNotifier(tenantConfiguration, urlprefixProvider, clientResolver, queue, timeProvider)
+	authSessionManager := auth2.ProvideSessionManager(queries, hookProvider, manager, sessionManager, eventStore, logoutNotifier, factory)
+	authorizationStore := &pq2.AuthorizationStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	userverifyStore := userverify.ProvideStore(sqlBuilder, sqlExecutor)
 	welcomemessageProvider := welcomemessage.ProvideProvider(context, tenantConfiguration, engine, queue)
-	commands := user.ProvideCommands(store, userprofileStore, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
+	commands := user.ProvideCommands(store, userprofileStore, providerProvider, provider4, authSessionManager, authorizationStore, userverifyStore, storeImpl, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
-	interactionProvider := interaction.ProvideProvider(redisStore, timeProvider, factory, providerProvider, provider3, userProvider, oobProvider, tenantConfiguration, hookProvider)
+	interactionProvider := interaction.ProvideProvider(redisStore, timeProvider, factory, providerProvider, provider4, userProvider, oobProvider, tenantConfiguration, hookProvider)
 	passwordFlow := &flows.PasswordFlow{
 		Interactions: interactionProvider,
 	}
@@ -531,7 +688,8 @@ func newResetPasswordSuccessHandler(r *http.Request, m auth.DependencyMap) http.
 	loginidProvider := loginid.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration, loginidChecker, normalizerFactory)
 	oauthProvider := oauth.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
 	anonymousProvider := anonymous.ProvideProvider(sqlBuilder, sqlExecutor)
-	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider)
+	ldapProvider := ldap.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider, ldapProvider)
 	renderProvider := webapp.ProvideRenderProvider(staticAssetURLPrefix, tenantConfiguration, engine, checker, providerProvider)
 	stateStoreImpl := &webapp.StateStoreImpl{
 		Context: context,
@@ -544,38 +702,68 @@ func newResetPasswordSuccessHandler(r *http.Request, m auth.DependencyMap) http.
 	}
 	store := pq.ProvideStore(sqlBuilderFactory, sqlExecutor)
 	userprofileStore := userprofile.ProvideStore(timeProvider, sqlBuilder, sqlExecutor)
+	userStore := user.ProvideStore(sqlBuilderFactory, sqlExecutor, timeProvider)
 	queries := &user.Queries{
 		AuthInfos:    store,
 		UserProfiles: userprofileStore,
 		Identities:   providerProvider,
 		Time:         timeProvider,
+		Store:        userStore,
 	}
+	hookStore := hook.ProvideStore(tenantConfiguration, sqlBuilder, sqlExecutor)
 	txContext := db.ProvideTxContext(context, tenantConfiguration)
+	attemptStore := hook.ProvideAttemptStore(context, tenantConfiguration, sqlBuilder)
 	factory := logging.ProvideLoggerFactory(context, tenantConfiguration)
-	hookProvider := hook.ProvideHookProvider(context, sqlBuilder, sqlExecutor, tenantConfiguration, txContext, timeProvider, queries, store, userprofileStore, loginidProvider, factory)
-	urlprefixProvider := urlprefix.NewProvider(r)
+	deliverer := hook.ProvideDeliverer(tenantConfiguration, timeProvider, store, userprofileStore, loginidProvider, attemptStore, factory)
 	executor := auth.ProvideTaskExecutor(m)
 	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
+	hookProvider := hook.ProvideHookProvider(context, tenantConfiguration, sqlBuilder, hookStore, txContext, timeProvider, queries, deliverer, queue, executor, factory)
+	urlprefixProvider := urlprefix.NewProvider(r)
 	redisStore := redis.ProvideStore(context, tenantConfiguration, timeProvider)
 	passwordProvider := password.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, factory, historyStoreImpl, checker, tenantConfiguration)
 	totpProvider := totp.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
 	oobProvider := oob.ProvideProvider(context, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider, engine, urlprefixProvider, queue)
 	bearertokenProvider := bearertoken.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
 	recoverycodeProvider := recoverycode.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
-	provider3 := &provider2.Provider{
+	directory := ldap2.ProvideDirectory(tenantConfiguration)
+	provider3 := ldap3.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, directory)
+	provider4 := &provider2.Provider{
 		Password:     passwordProvider,
 		TOTP:         totpProvider,
 		OOBOTP:       oobProvider,
 		BearerToken:  bearertokenProvider,
 		RecoveryCode: recoverycodeProvider,
+		LDAP:         provider3,
+	}
+	sessionStore := redis2.ProvideStore(context, tenantConfiguration, timeProvider, factory)
+	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
+	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	manager := session.ProvideSessionManager(sessionStore, timeProvider, tenantConfiguration, cookieConfiguration)
+	grantStore := redis3.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
+	sessionManager := &oauth2.SessionManager{
+		Store: grantStore,
+		Time:  timeProvider,
+	}
+	eventStore := redis4.ProvideEventStore(context, tenantConfiguration)
+	clientStore := &pq2.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
 	}
+	clientResolver := oauth2.ProvideClientResolver(tenantConfiguration, clientStore)
+	logoutNotifier := oidc.ProvideLogoutNotifier(tenantConfiguration, urlprefixProvider, clientResolver, queue, timeProvider)
+	authSessionManager := auth2.ProvideSessionManager(queries, hookProvider, manager, sessionManager, eventStore, logoutNotifier, factory)
+	authorizationStore := &pq2.AuthorizationStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	userverifyStore := userverify.ProvideStore(sqlBuilder, sqlExecutor)
 	welcomemessageProvider := welcomemessage.ProvideProvider(context, tenantConfiguration, engine, queue)
-	commands := user.ProvideCommands(store, userprofileStore, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
+	commands := user.ProvideCommands(store, userprofileStore, providerProvider, provider4, authSessionManager, authorizationStore, userverifyStore, storeImpl, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
-	interactionProvider := interaction.ProvideProvider(redisStore, timeProvider, factory, providerProvider, provider3, userProvider, oobProvider, tenantConfiguration, hookProvider)
+	interactionProvider := interaction.ProvideProvider(redisStore, timeProvider, factory, providerProvider, provider4, userProvider, oobProvider, tenantConfiguration, hookProvider)
 	passwordFlow := &flows.PasswordFlow{
 		Interactions: interactionProvider,
 	}
@@ -612,7 +800,8 @@ func newSignupHandler(r *http.Request, m auth.DependencyMap) http.Handler {
 	loginidProvider := loginid.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration, loginidChecker, normalizerFactory)
 	oauthProvider := oauth.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
 	anonymousProvider := anonymous.ProvideProvider(sqlBuilder, sqlExecutor)
-	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider)
+	ldapProvider := ldap.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider, ldapProvider)
 	renderProvider := webapp.ProvideRenderProvider(staticAssetURLPrefix, tenantConfiguration, engine, checker, providerProvider)
 	stateStoreImpl := &webapp.StateStoreImpl{
 		Context: context,
@@ -623,48 +812,79 @@ func newSignupHandler(r *http.Request, m auth.DependencyMap) http.Handler {
 	stateCodec := sso.ProvideStateCodec(tenantConfiguration)
 	store := pq.ProvideStore(sqlBuilderFactory, sqlExecutor)
 	userprofileStore := userprofile.ProvideStore(timeProvider, sqlBuilder, sqlExecutor)
+	userStore := user.ProvideStore(sqlBuilderFactory, sqlExecutor, timeProvider)
 	queries := &user.Queries{
 		AuthInfos:    store,
 		UserProfiles: userprofileStore,
 		Identities:   providerProvider,
 		Time:         timeProvider,
+		Store:        userStore,
 	}
+	hookStore := hook.ProvideStore(tenantConfiguration, sqlBuilder, sqlExecutor)
 	txContext := db.ProvideTxContext(context, tenantConfiguration)
+	attemptStore := hook.ProvideAttemptStore(context, tenantConfiguration, sqlBuilder)
 	factory := logging.ProvideLoggerFactory(context, tenantConfiguration)
-	hookProvider := hook.ProvideHookProvider(context, sqlBuilder, sqlExecutor, tenantConfiguration, txContext, timeProvider, queries, store, userprofileStore, loginidProvider, factory)
+	deliverer := hook.ProvideDeliverer(tenantConfiguration, timeProvider, store, userprofileStore, loginidProvider, attemptStore, factory)
+	executor := auth.ProvideTaskExecutor(m)
+	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
+	hookProvider := hook.ProvideHookProvider(context, tenantConfiguration, sqlBuilder, hookStore, txContext, timeProvider, queries, deliverer, queue, executor, factory)
 	redisStore := redis.ProvideStore(context, tenantConfiguration, timeProvider)
 	passwordProvider := password.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, factory, historyStoreImpl, checker, tenantConfiguration)
 	totpProvider := totp.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
 	urlprefixProvider := urlprefix.NewProvider(r)
-	executor := auth.ProvideTaskExecutor(m)
-	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
 	oobProvider := oob.ProvideProvider(context, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider, engine, urlprefixProvider, queue)
 	bearertokenProvider := bearertoken.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
 	recoverycodeProvider := recoverycode.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
-	provider3 := &provider2.Provider{
+	directory := ldap2.ProvideDirectory(tenantConfiguration)
+	provider3 := ldap3.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, directory)
+	provider4 := &provider2.Provider{
 		Password:     passwordProvider,
 		TOTP:         totpProvider,
 		OOBOTP:       oobProvider,
 		BearerToken:  bearertokenProvider,
 		RecoveryCode: recoverycodeProvider,
+		LDAP:         provider3,
+	}
+	sessionStore := redis2.ProvideStore(context, tenantConfiguration, timeProvider, factory)
+	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
+	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	manager := session.ProvideSessionManager(sessionStore, timeProvider, tenantConfiguration, cookieConfiguration)
+	grantStore := redis3.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
+	sessionManager := &oauth2.SessionManager{
+		Store: grantStore,
+		Time:  timeProvider,
+	}
+	eventStore := redis4.ProvideEventStore(context, tenantConfiguration)
+	clientStore := &pq2.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	clientResolver := oauth2.ProvideClientResolver(tenantConfiguration, clientStore)
+	logoutNotifier := oidc.ProvideLogoutNotifier(tenantConfiguration, urlprefixProvider, clientResolver, queue, timeProvider)
+	authSessionManager := auth2.ProvideSessionManager(queries, hookProvider, manager, sessionManager, eventStore, logoutNotifier, factory)
+	authorizationStore := &pq2.AuthorizationStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	userverifyStore := userverify.ProvideStore(sqlBuilder, sqlExecutor)
+	storeImpl := &forgotpassword.StoreImpl{
+		Context: context,
 	}
 	welcomemessageProvider := welcomemessage.ProvideProvider(context, tenantConfiguration, engine, queue)
-	commands := user.ProvideCommands(store, userprofileStore, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
+	commands := user.ProvideCommands(store, userprofileStore, providerProvider, provider4, authSessionManager, authorizationStore, userverifyStore, storeImpl, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
-	interactionProvider := interaction.ProvideProvider(redisStore, timeProvider, factory, providerProvider, provider3, userProvider, oobProvider, tenantConfiguration, hookProvider)
-	authorizationStore := &pq2.AuthorizationStore{
-		SQLBuilder:  sqlBuilder,
-		SQLExecutor: sqlExecutor,
+	interactionProvider := interaction.ProvideProvider(redisStore, timeProvider, factory, providerProvider, provider4, userProvider, oobProvider, tenantConfiguration, hookProvider)
+	endpointsProvider := &auth.EndpointsProvider{
+		PrefixProvider: urlprefixProvider,
 	}
-	grantStore := redis2.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
-	eventStore := redis3.ProvideEventStore(context, tenantConfiguration)
+	clientAuthenticator := handler.ProvideClientAuthenticator(r, clientResolver, urlprefixProvider, endpointsProvider, grantStore, timeProvider)
+	jwtAccessTokenCodec := oauth2.ProvideJWTAccessTokenCodec(tenantConfiguration, urlprefixProvider, timeProvider)
 	accessEventProvider := auth2.AccessEventProvider{
 		Store: eventStore,
 	}
-	sessionStore := redis4.ProvideStore(context, tenantConfiguration, timeProvider, factory)
 	authAccessEventProvider := &auth2.AccessEventProvider{
 		Store: eventStore,
 	}
@@ -677,13 +897,11 @@ func newSignupHandler(r *http.Request, m auth.DependencyMap) http.Handler {
 		Anonymous:    anonymousProvider,
 		Challenges:   challengeProvider,
 	}
-	idTokenIssuer := oidc.ProvideIDTokenIssuer(tenantConfiguration, urlprefixProvider, queries, timeProvider)
+	idTokenIssuer := oidc.ProvideIDTokenIssuer(tenantConfiguration, urlprefixProvider, queries, providerProvider, timeProvider)
 	tokenGenerator := _wireTokenGeneratorValue
-	tokenHandler := handler.ProvideTokenHandler(r, tenantConfiguration, factory, authorizationStore, grantStore, grantStore, grantStore, accessEventProvider, sessionProvider, anonymousFlow, idTokenIssuer, tokenGenerator, timeProvider)
-	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
-	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	tokenHandler := handler.ProvideTokenHandler(r, tenantConfiguration, factory, clientAuthenticator, authorizationStore, grantStore, grantStore, grantStore, grantStore, jwtAccessTokenCodec, accessEventProvider, sessionProvider, queries, hookProvider, authSessionManager, anonymousFlow, idTokenIssuer, tokenGenerator, timeProvider)
 	userController := flows.ProvideUserController(store, queries, tokenHandler, cookieConfiguration, sessionProvider, hookProvider, timeProvider, tenantConfiguration)
-	webAppFlow := flows.ProvideWebAppFlow(tenantConfiguration, providerProvider, queries, hookProvider, interactionProvider, userController)
+	webAppFlow := flows.ProvideWebAppFlow(tenantConfiguration, providerProvider, queries, hookProvider, interactionProvider, userController, directory)
 	redirectURLFunc := provideRedirectURIForWebAppFunc()
 	oAuthProviderFactory := sso.ProvideOAuthProviderFactory(tenantConfiguration, urlprefixProvider, timeProvider, normalizerFactory, redirectURLFunc)
 	authenticateProviderImpl := &webapp.AuthenticateProviderImpl{
@@ -720,7 +938,8 @@ func newPromoteHandler(r *http.Request, m auth.DependencyMap) http.Handler {
 	loginidProvider := loginid.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration, loginidChecker, normalizerFactory)
 	oauthProvider := oauth.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
 	anonymousProvider := anonymous.ProvideProvider(sqlBuilder, sqlExecutor)
-	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider)
+	ldapProvider := ldap.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider, ldapProvider)
 	renderProvider := webapp.ProvideRenderProvider(staticAssetURLPrefix, tenantConfiguration, engine, checker, providerProvider)
 	stateStoreImpl := &webapp.StateStoreImpl{
 		Context: context,
@@ -731,48 +950,79 @@ func newPromoteHandler(r *http.Request, m auth.DependencyMap) http.Handler {
 	stateCodec := sso.ProvideStateCodec(tenantConfiguration)
 	store := pq.ProvideStore(sqlBuilderFactory, sqlExecutor)
 	userprofileStore := userprofile.ProvideStore(timeProvider, sqlBuilder, sqlExecutor)
+	userStore := user.ProvideStore(sqlBuilderFactory, sqlExecutor, timeProvider)
 	queries := &user.Queries{
 		AuthInfos:    store,
 		UserProfiles: userprofileStore,
 		Identities:   providerProvider,
 		Time:         timeProvider,
+		Store:        userStore,
 	}
+	hookStore := hook.ProvideStore(tenantConfiguration, sqlBuilder, sqlExecutor)
 	txContext := db.ProvideTxContext(context, tenantConfiguration)
+	attemptStore := hook.ProvideAttemptStore(context, tenantConfiguration, sqlBuilder)
 	factory := logging.ProvideLoggerFactory(context, tenantConfiguration)
-	hookProvider := hook.ProvideHookProvider(context, sqlBuilder, sqlExecutor, tenantConfiguration, txContext, timeProvider, queries, store, userprofileStore, loginidProvider, factory)
+	deliverer := hook.ProvideDeliverer(tenantConfiguration, timeProvider, store, userprofileStore, loginidProvider, attemptStore, factory)
+	executor := auth.ProvideTaskExecutor(m)
+	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
+	hookProvider := hook.ProvideHookProvider(context, tenantConfiguration, sqlBuilder, hookStore, txContext, timeProvider, queries, deliverer, queue, executor, factory)
 	redisStore := redis.ProvideStore(context, tenantConfiguration, timeProvider)
 	passwordProvider := password.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, factory, historyStoreImpl, checker, tenantConfiguration)
 	totpProvider := totp.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
 	urlprefixProvider := urlprefix.NewProvider(r)
-	executor := auth.ProvideTaskExecutor(m)
-	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
 	oobProvider := oob.ProvideProvider(context, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider, engine, urlprefixProvider, queue)
 	bearertokenProvider := bearertoken.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
 	recoverycodeProvider := recoverycode.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
-	provider3 := &provider2.Provider{
+	directory := ldap2.ProvideDirectory(tenantConfiguration)
+	provider3 := ldap3.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, directory)
+	provider4 := &provider2.Provider{
 		Password:     passwordProvider,
 		TOTP:         totpProvider,
 		OOBOTP:       oobProvider,
 		BearerToken:  bearertokenProvider,
 		RecoveryCode: recoverycodeProvider,
+		LDAP:         provider3,
+	}
+	sessionStore := redis2.ProvideStore(context, tenantConfiguration, timeProvider, factory)
+	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
+	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	manager := session.ProvideSessionManager(sessionStore, timeProvider, tenantConfiguration, cookieConfiguration)
+	grantStore := redis3.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
+	sessionManager := &oauth2.SessionManager{
+		Store: grantStore,
+		Time:  timeProvider,
+	}
+	eventStore := redis4.ProvideEventStore(context, tenantConfiguration)
+	clientStore := &pq2.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	clientResolver := oauth2.ProvideClientResolver(tenantConfiguration, clientStore)
+	logoutNotifier := oidc.ProvideLogoutNotifier(tenantConfiguration, urlprefixProvider, clientResolver, queue, timeProvider)
+	authSessionManager := auth2.ProvideSessionManager(queries, hookProvider, manager, sessionManager, eventStore, logoutNotifier, factory)
+	authorizationStore := &pq2.AuthorizationStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	userverifyStore := userverify.ProvideStore(sqlBuilder, sqlExecutor)
+	storeImpl := &forgotpassword.StoreImpl{
+		Context: context,
 	}
 	welcomemessageProvider := welcomemessage.ProvideProvider(context, tenantConfiguration, engine, queue)
-	commands := user.ProvideCommands(store, userprofileStore, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
+	commands := user.ProvideCommands(store, userprofileStore, providerProvider, provider4, authSessionManager, authorizationStore, userverifyStore, storeImpl, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
-	interactionProvider := interaction.ProvideProvider(redisStore, timeProvider, factory, providerProvider, provider3, userProvider, oobProvider, tenantConfiguration, hookProvider)
-	authorizationStore := &pq2.AuthorizationStore{
-		SQLBuilder:  sqlBuilder,
-		SQLExecutor: sqlExecutor,
+	interactionProvider := interaction.ProvideProvider(redisStore, timeProvider, factory, providerProvider, provider4, userProvider, oobProvider, tenantConfiguration, hookProvider)
+	endpointsProvider := &auth.EndpointsProvider{
+		PrefixProvider: urlprefixProvider,
 	}
-	grantStore := redis2.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
-	eventStore := redis3.ProvideEventStore(context, tenantConfiguration)
+	clientAuthenticator := handler.ProvideClientAuthenticator(r, clientResolver, urlprefixProvider, endpointsProvider, grantStore, timeProvider)
+	jwtAccessTokenCodec := oauth2.ProvideJWTAccessTokenCodec(tenantConfiguration, urlprefixProvider, timeProvider)
 	accessEventProvider := auth2.AccessEventProvider{
 		Store: eventStore,
 	}
-	sessionStore := redis4.ProvideStore(context, tenantConfiguration, timeProvider, factory)
 	authAccessEventProvider := &auth2.AccessEventProvider{
 		Store: eventStore,
 	}
@@ -785,13 +1035,11 @@ func newPromoteHandler(r *http.Request, m auth.DependencyMap) http.Handler {
 		Anonymous:    anonymousProvider,
 		Challenges:   challengeProvider,
 	}
-	idTokenIssuer := oidc.ProvideIDTokenIssuer(tenantConfiguration, urlprefixProvider, queries, timeProvider)
+	idTokenIssuer := oidc.ProvideIDTokenIssuer(tenantConfiguration, urlprefixProvider, queries, providerProvider, timeProvider)
 	tokenGenerator := _wireTokenGeneratorValue
-	tokenHandler := handler.ProvideTokenHandler(r, tenantConfiguration, factory, authorizationStore, grantStore, grantStore, grantStore, accessEventProvider, sessionProvider, anonymousFlow, idTokenIssuer, tokenGenerator, timeProvider)
-	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
-	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	tokenHandler := handler.ProvideTokenHandler(r, tenantConfiguration, factory, clientAuthenticator, authorizationStore, grantStore, grantStore, grantStore, grantStore, jwtAccessTokenCodec, accessEventProvider, sessionProvider, queries, hookProvider, authSessionManager, anonymousFlow, idTokenIssuer, tokenGenerator, timeProvider)
 	userController := flows.ProvideUserController(store, queries, tokenHandler, cookieConfiguration, sessionProvider, hookProvider, timeProvider, tenantConfiguration)
-	webAppFlow := flows.ProvideWebAppFlow(tenantConfiguration, providerProvider, queries, hookProvider, interactionProvider, userController)
+	webAppFlow := flows.ProvideWebAppFlow(tenantConfiguration, providerProvider, queries, hookProvider, interactionProvider, userController, directory)
 	redirectURLFunc := provideRedirectURIForWebAppFunc()
 	oAuthProviderFactory := sso.ProvideOAuthProviderFactory(tenantConfiguration, urlprefixProvider, timeProvider, normalizerFactory, redirectURLFunc)
 	authenticateProviderImpl := &webapp.AuthenticateProviderImpl{
@@ -828,7 +1076,8 @@ func newCreatePasswordHandler(r *http.Request, m auth.DependencyMap) http.Handle
 	loginidProvider := loginid.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration, loginidChecker, normalizerFactory)
 	oauthProvider := oauth.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
 	anonymousProvider := anonymous.ProvideProvider(sqlBuilder, sqlExecutor)
-	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider)
+	ldapProvider := ldap.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider, ldapProvider)
 	renderProvider := webapp.ProvideRenderProvider(staticAssetURLPrefix, tenantConfiguration, engine, checker, providerProvider)
 	stateStoreImpl := &webapp.StateStoreImpl{
 		Context: context,
@@ -839,48 +1088,79 @@ func newCreatePasswordHandler(r *http.Request, m auth.DependencyMap) http.Handle
 	stateCodec := sso.ProvideStateCodec(tenantConfiguration)
 	store := pq.ProvideStore(sqlBuilderFactory, sqlExecutor)
 	userprofileStore := userprofile.ProvideStore(timeProvider, sqlBuilder, sqlExecutor)
+	userStore := user.ProvideStore(sqlBuilderFactory, sqlExecutor, timeProvider)
 	queries := &user.Queries{
 		AuthInfos:    store,
 		UserProfiles: userprofileStore,
 		Identities:   providerProvider,
 		Time:         timeProvider,
+		Store:        userStore,
 	}
+	hookStore := hook.ProvideStore(tenantConfiguration, sqlBuilder, sqlExecutor)
 	txContext := db.ProvideTxContext(context, tenantConfiguration)
+	attemptStore := hook.ProvideAttemptStore(context, tenantConfiguration, sqlBuilder)
 	factory := logging.ProvideLoggerFactory(context, tenantConfiguration)
-	hookProvider := hook.ProvideHookProvider(context, sqlBuilder, sqlExecutor, tenantConfiguration, txContext, timeProvider, queries, store, userprofileStore, loginidProvider, factory)
+	deliverer := hook.ProvideDeliverer(tenantConfiguration, timeProvider, store, userprofileStore, loginidProvider, attemptStore, factory)
+	executor := auth.ProvideTaskExecutor(m)
+	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
+	hookProvider := hook.ProvideHookProvider(context, tenantConfiguration, sqlBuilder, hookStore, txContext, timeProvider, queries, deliverer, queue, executor, factory)
 	redisStore := redis.ProvideStore(context, tenantConfiguration, timeProvider)
 	passwordProvider := password.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, factory, historyStoreImpl, checker, tenantConfiguration)
 	totpProvider := totp.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
 	urlprefixProvider := urlprefix.NewProvider(r)
-	executor := auth.ProvideTaskExecutor(m)
-	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
 	oobProvider := oob.ProvideProvider(context, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider, engine, urlprefixProvider, queue)
 	bearertokenProvider := bearertoken.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
 	recoverycodeProvider := recoverycode.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
-	provider3 := &provider2.Provider{
+	directory := ldap2.ProvideDirectory(tenantConfiguration)
+	provider3 := ldap3.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, directory)
+	provider4 := &provider2.Provider{
 		Password:     passwordProvider,
 		TOTP:         totpProvider,
 		OOBOTP:       oobProvider,
 		BearerToken:  bearertokenProvider,
 		RecoveryCode: recoverycodeProvider,
+		LDAP:         provider3,
+	}
+	sessionStore := redis2.ProvideStore(context, tenantConfiguration, timeProvider, factory)
+	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
+	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	manager := session.ProvideSessionManager(sessionStore, timeProvider, tenantConfiguration, cookieConfiguration)
+	grantStore := redis3.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
+	sessionManager := &oauth2.SessionManager{
+		Store: grantStore,
+		Time:  timeProvider,
+	}
+	eventStore := redis4.ProvideEventStore(context, tenantConfiguration)
+	clientStore := &pq2.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	clientResolver := oauth2.ProvideClientResolver(tenantConfiguration, clientStore)
+	logoutNotifier := oidc.ProvideLogoutNotifier(tenantConfiguration, urlprefixProvider, clientResolver, queue, timeProvider)
+	authSessionManager := auth2.ProvideSessionManager(queries, hookProvider, manager, sessionManager, eventStore, logoutNotifier, factory)
+	authorizationStore := &pq2.AuthorizationStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	userverifyStore := userverify.ProvideStore(sqlBuilder, sqlExecutor)
+	storeImpl := &forgotpassword.StoreImpl{
+		Context: context,
 	}
 	welcomemessageProvider := welcomemessage.ProvideProvider(context, tenantConfiguration, engine, queue)
-	commands := user.ProvideCommands(store, userprofileStore, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
+	commands := user.ProvideCommands(store, userprofileStore, providerProvider, provider4, authSessionManager, authorizationStore, userverifyStore, storeImpl, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
-	interactionProvider := interaction.ProvideProvider(redisStore, timeProvider, factory, providerProvider, provider3, userProvider, oobProvider, tenantConfiguration, hookProvider)
-	authorizationStore := &pq2.AuthorizationStore{
-		SQLBuilder:  sqlBuilder,
-		SQLExecutor: sqlExecutor,
+	interactionProvider := interaction.ProvideProvider(redisStore, timeProvider, factory, providerProvider, provider4, userProvider, oobProvider, tenantConfiguration, hookProvider)
+	endpointsProvider := &auth.EndpointsProvider{
+		PrefixProvider: urlprefixProvider,
 	}
-	grantStore := redis2.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
-	eventStore := redis3.ProvideEventStore(context, tenantConfiguration)
+	clientAuthenticator := handler.ProvideClientAuthenticator(r, clientResolver, urlprefixProvider, endpointsProvider, grantStore, timeProvider)
+	jwtAccessTokenCodec := oauth2.ProvideJWTAccessTokenCodec(tenantConfiguration, urlprefixProvider, timeProvider)
 	accessEventProvider := auth2.AccessEventProvider{
 		Store: eventStore,
 	}
-	sessionStore := redis4.ProvideStore(context, tenantConfiguration, timeProvider, factory)
 	authAccessEventProvider := &auth2.AccessEventProvider{
 		Store: eventStore,
 	}
@@ -893,13 +1173,11 @@ func newCreatePasswordHandler(r *http.Request, m auth.DependencyMap) http.Handle
 		Anonymous:    anonymousProvider,
 		Challenges:   challengeProvider,
 	}
-	idTokenIssuer := oidc.ProvideIDTokenIssuer(tenantConfiguration, urlprefixProvider, queries, timeProvider)
+	idTokenIssuer := oidc.ProvideIDTokenIssuer(tenantConfiguration, urlprefixProvider, queries, providerProvider, timeProvider)
 	tokenGenerator := _wireTokenGeneratorValue
-	tokenHandler := handler.ProvideTokenHandler(r, tenantConfiguration, factory, authorizationStore, grantStore, grantStore, grantStore, accessEventProvider, sessionProvider, anonymousFlow, idTokenIssuer, tokenGenerator, timeProvider)
-	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
-	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	tokenHandler := handler.ProvideTokenHandler(r, tenantConfiguration, factory, clientAuthenticator, authorizationStore, grantStore, grantStore, grantStore, grantStore, jwtAccessTokenCodec, accessEventProvider, sessionProvider, queries, hookProvider, authSessionManager, anonymousFlow, idTokenIssuer, tokenGenerator, timeProvider)
 	userController := flows.ProvideUserController(store, queries, tokenHandler, cookieConfiguration, sessionProvider, hookProvider, timeProvider, tenantConfiguration)
-	webAppFlow := flows.ProvideWebAppFlow(tenantConfiguration, providerProvider, queries, hookProvider, interactionProvider, userController)
+	webAppFlow := flows.ProvideWebAppFlow(tenantConfiguration, providerProvider, queries, hookProvider, interactionProvider, userController, directory)
 	redirectURLFunc := provideRedirectURIForWebAppFunc()
 	oAuthProviderFactory := sso.ProvideOAuthProviderFactory(tenantConfiguration, urlprefixProvider, timeProvider, normalizerFactory, redirectURLFunc)
 	authenticateProviderImpl := &webapp.AuthenticateProviderImpl{
@@ -935,7 +1213,8 @@ func newSettingsHandler(r *http.Request, m auth.DependencyMap) http.Handler {
 	loginidProvider := loginid.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration, loginidChecker, normalizerFactory)
 	oauthProvider := oauth.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
 	anonymousProvider := anonymous.ProvideProvider(sqlBuilder, sqlExecutor)
-	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider)
+	ldapProvider := ldap.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider, ldapProvider)
 	renderProvider := webapp.ProvideRenderProvider(staticAssetURLPrefix, tenantConfiguration, engine, checker, providerProvider)
 	settingsHandler := &SettingsHandler{
 		RenderProvider: renderProvider,
@@ -961,7 +1240,8 @@ func newSettingsIdentityHandler(r *http.Request, m auth.DependencyMap) http.Hand
 	loginidProvider := loginid.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration, loginidChecker, normalizerFactory)
 	oauthProvider := oauth.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
 	anonymousProvider := anonymous.ProvideProvider(sqlBuilder, sqlExecutor)
-	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider)
+	ldapProvider := ldap.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider, ldapProvider)
 	renderProvider := webapp.ProvideRenderProvider(staticAssetURLPrefix, tenantConfiguration, engine, checker, providerProvider)
 	validateProvider := webapp.ProvideValidateProvider(tenantConfiguration)
 	stateStoreImpl := &webapp.StateStoreImpl{
@@ -973,48 +1253,79 @@ func newSettingsIdentityHandler(r *http.Request, m auth.DependencyMap) http.Hand
 	stateCodec := sso.ProvideStateCodec(tenantConfiguration)
 	store := pq.ProvideStore(sqlBuilderFactory, sqlExecutor)
 	userprofileStore := userprofile.ProvideStore(timeProvider, sqlBuilder, sqlExecutor)
+	userStore := user.ProvideStore(sqlBuilderFactory, sqlExecutor, timeProvider)
 	queries := &user.Queries{
 		AuthInfos:    store,
 		UserProfiles: userprofileStore,
 		Identities:   providerProvider,
 		Time:         timeProvider,
+		Store:        userStore,
 	}
+	hookStore := hook.ProvideStore(tenantConfiguration, sqlBuilder, sqlExecutor)
 	txContext := db.ProvideTxContext(context, tenantConfiguration)
+	attemptStore := hook.ProvideAttemptStore(context, tenantConfiguration, sqlBuilder)
 	factory := logging.ProvideLoggerFactory(context, tenantConfiguration)
-	hookProvider := hook.ProvideHookProvider(context, sqlBuilder, sqlExecutor, tenantConfiguration, txContext, timeProvider, queries, store, userprofileStore, loginidProvider, factory)
+	deliverer := hook.ProvideDeliverer(tenantConfiguration, timeProvider, store, userprofileStore, loginidProvider, attemptStore, factory)
+	executor := auth.ProvideTaskExecutor(m)
+	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
+	hookProvider := hook.ProvideHookProvider(context, tenantConfiguration, sqlBuilder, hookStore, txContext, timeProvider, queries, deliverer, queue, executor, factory)
 	redisStore := redis.ProvideStore(context, tenantConfiguration, timeProvider)
 	passwordProvider := password.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, factory, historyStoreImpl, checker, tenantConfiguration)
 	totpProvider := totp.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
 	urlprefixProvider := urlprefix.NewProvider(r)
-	executor := auth.ProvideTaskExecutor(m)
-	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
 	oobProvider := oob.ProvideProvider(context, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider, engine, urlprefixProvider, queue)
 	bearertokenProvider := bearertoken.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
 	recoverycodeProvider := recoverycode.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
-	provider3 := &provider2.Provider{
+	directory := ldap2.ProvideDirectory(tenantConfiguration)
+	provider3 := ldap3.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, directory)
+	provider4 := &provider2.Provider{
 		Password:     passwordProvider,
 		TOTP:         totpProvider,
 		OOBOTP:       oobProvider,
 		BearerToken:  bearertokenProvider,
 		RecoveryCode: recoverycodeProvider,
+		LDAP:         provider3,
+	}
+	sessionStore := redis2.ProvideStore(context, tenantConfiguration, timeProvider, factory)
+	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
+	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	manager := session.ProvideSessionManager(sessionStore, timeProvider, tenantConfiguration, cookieConfiguration)
+	grantStore := redis3.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
+	sessionManager := &oauth2.SessionManager{
+		Store: grantStore,
+		Time:  timeProvider,
+	}
+	eventStore := redis4.ProvideEventStore(context, tenantConfiguration)
+	clientStore := &pq2.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	clientResolver := oauth2.ProvideClientResolver(tenantConfiguration, clientStore)
+	logoutNotifier := oidc.ProvideLogoutNotifier(tenantConfiguration, urlprefixProvider, clientResolver, queue, timeProvider)
+	authSessionManager := auth2.ProvideSessionManager(queries, hookProvider, manager, sessionManager, eventStore, logoutNotifier, factory)
+	authorizationStore := &pq2.AuthorizationStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	userverifyStore := userverify.ProvideStore(sqlBuilder, sqlExecutor)
+	storeImpl := &forgotpassword.StoreImpl{
+		Context: context,
 	}
 	welcomemessageProvider := welcomemessage.ProvideProvider(context, tenantConfiguration, engine, queue)
-	commands := user.ProvideCommands(store, userprofileStore, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
+	commands := user.ProvideCommands(store, userprofileStore, providerProvider, provider4, authSessionManager, authorizationStore, userverifyStore, storeImpl, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
-	interactionProvider := interaction.ProvideProvider(redisStore, timeProvider, factory, providerProvider, provider3, userProvider, oobProvider, tenantConfiguration, hookProvider)
-	authorizationStore := &pq2.AuthorizationStore{
-		SQLBuilder:  sqlBuilder,
-		SQLExecutor: sqlExecutor,
+	interactionProvider := interaction.ProvideProvider(redisStore, timeProvider, factory, providerProvider, provider4, userProvider, oobProvider, tenantConfiguration, hookProvider)
+	endpointsProvider := &auth.EndpointsProvider{
+		PrefixProvider: urlprefixProvider,
 	}
-	grantStore := redis2.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
-	eventStore := redis3.ProvideEventStore(context, tenantConfiguration)
+	clientAuthenticator := handler.ProvideClientAuthenticator(r, clientResolver, urlprefixProvider, endpointsProvider, grantStore, timeProvider)
+	jwtAccessTokenCodec := oauth2.ProvideJWTAccessTokenCodec(tenantConfiguration, urlprefixProvider, timeProvider)
 	accessEventProvider := auth2.AccessEventProvider{
 		Store: eventStore,
 	}
-	sessionStore := redis4.ProvideStore(context, tenantConfiguration, timeProvider, factory)
 	authAccessEventProvider := &auth2.AccessEventProvider{
 		Store: eventStore,
 	}
@@ -1027,13 +1338,11 @@ func newSettingsIdentityHandler(r *http.Request, m auth.DependencyMap) http.Hand
 		Anonymous:    anonymousProvider,
 		Challenges:   challengeProvider,
 	}
-	idTokenIssuer := oidc.ProvideIDTokenIssuer(tenantConfiguration, urlprefixProvider, queries, timeProvider)
+	idTokenIssuer := oidc.ProvideIDTokenIssuer(tenantConfiguration, urlprefixProvider, queries, providerProvider, timeProvider)
 	tokenGenerator := _wireTokenGeneratorValue
-	tokenHandler := handler.ProvideTokenHandler(r, tenantConfiguration, factory, authorizationStore, grantStore, grantStore, grantStore, accessEventProvider, sessionProvider, anonymousFlow, idTokenIssuer, tokenGenerator, timeProvider)
-	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
-	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	tokenHandler := handler.ProvideTokenHandler(r, tenantConfiguration, factory, clientAuthenticator, authorizationStore, grantStore, grantStore, grantStore, grantStore, jwtAccessTokenCodec, accessEventProvider, sessionProvider, queries, hookProvider, authSessionManager, anonymousFlow, idTokenIssuer, tokenGenerator, timeProvider)
 	userController := flows.ProvideUserController(store, queries, tokenHandler, cookieConfiguration, sessionProvider, hookProvider, timeProvider, tenantConfiguration)
-	webAppFlow := flows.ProvideWebAppFlow(tenantConfiguration, providerProvider, queries, hookProvider, interactionProvider, userController)
+	webAppFlow := flows.ProvideWebAppFlow(tenantConfiguration, providerProvider, queries, hookProvider, interactionProvider, userController, directory)
 	redirectURLFunc := provideRedirectURIForWebAppFunc()
 	oAuthProviderFactory := sso.ProvideOAuthProviderFactory(tenantConfiguration, urlprefixProvider, timeProvider, normalizerFactory, redirectURLFunc)
 	authenticateProviderImpl := &webapp.AuthenticateProviderImpl{
@@ -1071,7 +1380,8 @@ func newOOBOTPHandler(r *http.Request, m auth.DependencyMap) http.Handler {
 	loginidProvider := loginid.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration, loginidChecker, normalizerFactory)
 	oauthProvider := oauth.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
 	anonymousProvider := anonymous.ProvideProvider(sqlBuilder, sqlExecutor)
-	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider)
+	ldapProvider := ldap.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider, ldapProvider)
 	renderProvider := webapp.ProvideRenderProvider(staticAssetURLPrefix, tenantConfiguration, engine, checker, providerProvider)
 	stateStoreImpl := &webapp.StateStoreImpl{
 		Context: context,
@@ -1082,48 +1392,79 @@ func newOOBOTPHandler(r *http.Request, m auth.DependencyMap) http.Handler {
 	stateCodec := sso.ProvideStateCodec(tenantConfiguration)
 	store := pq.ProvideStore(sqlBuilderFactory, sqlExecutor)
 	userprofileStore := userprofile.ProvideStore(timeProvider, sqlBuilder, sqlExecutor)
+	userStore := user.ProvideStore(sqlBuilderFactory, sqlExecutor, timeProvider)
 	queries := &user.Queries{
 		AuthInfos:    store,
 		UserProfiles: userprofileStore,
 		Identities:   providerProvider,
 		Time:         timeProvider,
+		Store:        userStore,
 	}
+	hookStore := hook.ProvideStore(tenantConfiguration, sqlBuilder, sqlExecutor)
 	txContext := db.ProvideTxContext(context, tenantConfiguration)
+	attemptStore := hook.ProvideAttemptStore(context, tenantConfiguration, sqlBuilder)
 	factory := logging.ProvideLoggerFactory(context, tenantConfiguration)
-	hookProvider := hook.ProvideHookProvider(context, sqlBuilder, sqlExecutor, tenantConfiguration, txContext, timeProvider, queries, store, userprofileStore, loginidProvider, factory)
+	deliverer := hook.ProvideDeliverer(tenantConfiguration, timeProvider, store, userprofileStore, loginidProvider, attemptStore, factory)
+	executor := auth.ProvideTaskExecutor(m)
+	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
+	hookProvider := hook.ProvideHookProvider(context, tenantConfiguration, sqlBuilder, hookStore, txContext, timeProvider, queries, deliverer, queue, executor, factory)
 	redisStore := redis.ProvideStore(context, tenantConfiguration, timeProvider)
 	passwordProvider := password.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, factory, historyStoreImpl, checker, tenantConfiguration)
 	totpProvider := totp.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
 	urlprefixProvider := urlprefix.NewProvider(r)
-	executor := auth.ProvideTaskExecutor(m)
-	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
 	oobProvider := oob.ProvideProvider(context, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider, engine, urlprefixProvider, queue)
 	bearertokenProvider := bearertoken.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
 	recoverycodeProvider := recoverycode.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
-	provider3 := &provider2.Provider{
+	directory := ldap2.ProvideDirectory(tenantConfiguration)
+	provider3 := ldap3.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, directory)
+	provider4 := &provider2.Provider{
 		Password:     passwordProvider,
 		TOTP:         totpProvider,
 		OOBOTP:       oobProvider,
 		BearerToken:  bearertokenProvider,
 		RecoveryCode: recoverycodeProvider,
+		LDAP:         provider3,
+	}
+	sessionStore := redis2.ProvideStore(context, tenantConfiguration, timeProvider, factory)
+	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
+	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	manager := session.ProvideSessionManager(sessionStore, timeProvider, tenantConfiguration, cookieConfiguration)
+	grantStore := redis3.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
+	sessionManager := &oauth2.SessionManager{
+		Store: grantStore,
+		Time:  timeProvider,
+	}
+	eventStore := redis4.ProvideEventStore(context, tenantConfiguration)
+	clientStore := &pq2.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	clientResolver := oauth2.ProvideClientResolver(tenantConfiguration, clientStore)
+	logoutNotifier := oidc.ProvideLogoutNotifier(tenantConfiguration, urlprefixProvider, clientResolver, queue, timeProvider)
+	authSessionManager := auth2.ProvideSessionManager(queries, hookProvider, manager, sessionManager, eventStore, logoutNotifier, factory)
+	authorizationStore := &pq2.AuthorizationStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	userverifyStore := userverify.ProvideStore(sqlBuilder, sqlExecutor)
+	storeImpl := &forgotpassword.StoreImpl{
+		Context: context,
 	}
 	welcomemessageProvider := welcomemessage.ProvideProvider(context, tenantConfiguration, engine, queue)
-	commands := user.ProvideCommands(store, userprofileStore, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
+	commands := user.ProvideCommands(store, userprofileStore, providerProvider, provider4, authSessionManager, authorizationStore, userverifyStore, storeImpl, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
-	interactionProvider := interaction.ProvideProvider(redisStore, timeProvider, factory, providerProvider, provider3, userProvider, oobProvider, tenantConfiguration, hookProvider)
-	authorizationStore := &pq2.AuthorizationStore{
-		SQLBuilder:  sqlBuilder,
-		SQLExecutor: sqlExecutor,
+	interactionProvider := interaction.ProvideProvider(redisStore, timeProvider, factory, providerProvider, provider4, userProvider, oobProvider, tenantConfiguration, hookProvider)
+	endpointsProvider := &auth.EndpointsProvider{
+		PrefixProvider: urlprefixProvider,
 	}
-	grantStore := redis2.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
-	eventStore := redis3.ProvideEventStore(context, tenantConfiguration)
+	clientAuthenticator := handler.ProvideClientAuthenticator(r, clientResolver, urlprefixProvider, endpointsProvider, grantStore, timeProvider)
+	jwtAccessTokenCodec := oauth2.ProvideJWTAccessTokenCodec(tenantConfiguration, urlprefixProvider, timeProvider)
 	accessEventProvider := auth2.AccessEventProvider{
 		Store: eventStore,
 	}
-	sessionStore := redis4.ProvideStore(context, tenantConfiguration, timeProvider, factory)
 	authAccessEventProvider := &auth2.AccessEventProvider{
 		Store: eventStore,
 	}
@@ -1136,13 +1477,11 @@ func newOOBOTPHandler(r *http.Request, m auth.DependencyMap) http.Handler {
 		Anonymous:    anonymousProvider,
 		Challenges:   challengeProvider,
 	}
-	idTokenIssuer := oidc.ProvideIDTokenIssuer(tenantConfiguration, urlprefixProvider, queries, timeProvider)
+	idTokenIssuer := oidc.ProvideIDTokenIssuer(tenantConfiguration, urlprefixProvider, queries, providerProvider, timeProvider)
 	tokenGenerator := _wireTokenGeneratorValue
-	tokenHandler := handler.ProvideTokenHandler(r, tenantConfiguration, factory, authorizationStore, grantStore, grantStore, grantStore, accessEventProvider, sessionProvider, anonymousFlow, idTokenIssuer, tokenGenerator, timeProvider)
-	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
-	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	tokenHandler := handler.ProvideTokenHandler(r, tenantConfiguration, factory, clientAuthenticator, authorizationStore, grantStore, grantStore, grantStore, grantStore, jwtAccessTokenCodec, accessEventProvider, sessionProvider, queries, hookProvider, authSessionManager, anonymousFlow, idTokenIssuer, tokenGenerator, timeProvider)
 	userController := flows.ProvideUserController(store, queries, tokenHandler, cookieConfiguration, sessionProvider, hookProvider, timeProvider, tenantConfiguration)
-	webAppFlow := flows.ProvideWebAppFlow(tenantConfiguration, providerProvider, queries, hookProvider, interactionProvider, userController)
+	webAppFlow := flows.ProvideWebAppFlow(tenantConfiguration, providerProvider, queries, hookProvider, interactionProvider, userController, directory)
 	redirectURLFunc := provideRedirectURIForWebAppFunc()
 	oAuthProviderFactory := sso.ProvideOAuthProviderFactory(tenantConfiguration, urlprefixProvider, timeProvider, normalizerFactory, redirectURLFunc)
 	authenticateProviderImpl := &webapp.AuthenticateProviderImpl{
@@ -1179,7 +1518,8 @@ func newEnterLoginIDHandler(r *http.Request, m auth.DependencyMap) http.Handler
 	loginidProvider := loginid.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration, loginidChecker, normalizerFactory)
 	oauthProvider := oauth.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
 	anonymousProvider := anonymous.ProvideProvider(sqlBuilder, sqlExecutor)
-	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider)
+	ldapProvider := ldap.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider, ldapProvider)
 	renderProvider := webapp.ProvideRenderProvider(staticAssetURLPrefix, tenantConfiguration, engine, checker, providerProvider)
 	stateStoreImpl := &webapp.StateStoreImpl{
 		Context: context,
@@ -1190,48 +1530,79 @@ func newEnterLoginIDHandler(r *http.Request, m auth.DependencyMap) http.Handler
 	stateCodec := sso.ProvideStateCodec(tenantConfiguration)
 	store := pq.ProvideStore(sqlBuilderFactory, sqlExecutor)
 	userprofileStore := userprofile.ProvideStore(timeProvider, sqlBuilder, sqlExecutor)
+	userStore := user.ProvideStore(sqlBuilderFactory, sqlExecutor, timeProvider)
 	queries := &user.Queries{
 		AuthInfos:    store,
 		UserProfiles: userprofileStore,
 		Identities:   providerProvider,
 		Time:         timeProvider,
+		Store:        userStore,
 	}
+	hookStore := hook.ProvideStore(tenantConfiguration, sqlBuilder, sqlExecutor)
 	txContext := db.ProvideTxContext(context, tenantConfiguration)
+	attemptStore := hook.ProvideAttemptStore(context, tenantConfiguration, sqlBuilder)
 	factory := logging.ProvideLoggerFactory(context, tenantConfiguration)
-	hookProvider := hook.ProvideHookProvider(context, sqlBuilder, sqlExecutor, tenantConfiguration, txContext, timeProvider, queries, store, userprofileStore, loginidProvider, factory)
+	deliverer := hook.ProvideDeliverer(tenantConfiguration, timeProvider, store, userprofileStore, loginidProvider, attemptStore, factory)
+	executor := auth.ProvideTaskExecutor(m)
+	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
+	hookProvider := hook.ProvideHookProvider(context, tenantConfiguration, sqlBuilder, hookStore, txContext, timeProvider, queries, deliverer, queue, executor, factory)
 	redisStore := redis.ProvideStore(context, tenantConfiguration, timeProvider)
 	passwordProvider := password.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, factory, historyStoreImpl, checker, tenantConfiguration)
 	totpProvider := totp.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
 	urlprefixProvider := urlprefix.NewProvider(r)
-	executor := auth.ProvideTaskExecutor(m)
-	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
 	oobProvider := oob.ProvideProvider(context, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider, engine, urlprefixProvider, queue)
 	bearertokenProvider := bearertoken.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
 	recoverycodeProvider := recoverycode.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
-	provider3 := &provider2.Provider{
+	directory := ldap2.ProvideDirectory(tenantConfiguration)
+	provider3 := ldap3.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, directory)
+	provider4 := &provider2.Provider{
 		Password:     passwordProvider,
 		TOTP:         totpProvider,
 		OOBOTP:       oobProvider,
 		BearerToken:  bearertokenProvider,
 		RecoveryCode: recoverycodeProvider,
+		LDAP:         provider3,
 	}
-	welcomemessageProvider := welcomemessage.ProvideProvider(context, tenantConfiguration, engine, queue)
-	commands := user.ProvideCommands(store, userprofileStore, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
-	userProvider := &user.Provider{
-		Commands: commands,
-		Queries:  queries,
-	}
-	interactionProvider := interaction.ProvideProvider(redisStore, timeProvider, factory, providerProvider, provider3, userProvider, oobProvider, tenantConfiguration, hookProvider)
-	authorizationStore := &pq2.AuthorizationStore{
+	sessionStore := redis2.ProvideStore(context, tenantConfiguration, timeProvider, factory)
+	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
+	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	manager := session.ProvideSessionManager(sessionStore, timeProvider, tenantConfiguration, cookieConfiguration)
+	grantStore := redis3.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
+	sessionManager := &oauth2.SessionManager{
+		Store: grantStore,
+		Time:  timeProvider,
+	}
+	eventStore := redis4.ProvideEventStore(context, tenantConfiguration)
+	clientStore := &pq2.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	clientResolver := oauth2.ProvideClientResolver(tenantConfiguration, clientStore)
+	logoutNotifier := oidc.ProvideLogoutNotifier(tenantConfiguration, urlprefixProvider, clientResolver, queue, timeProvider)
+	authSessionManager := auth2.ProvideSessionManager(queries, hookProvider, manager, sessionManager, eventStore, logoutNotifier, factory)
+	authorizationStore := &pq2.AuthorizationStore{
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
-	grantStore := redis2.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
-	eventStore := redis3.ProvideEventStore(context, tenantConfiguration)
+	userverifyStore := userverify.ProvideStore(sqlBuilder, sqlExecutor)
+	storeImpl := &forgotpassword.StoreImpl{
+		Context: context,
+	}
+	welcomemessageProvider := welcomemessage.ProvideProvider(context, tenantConfiguration, engine, queue)
+	commands := user.ProvideCommands(store, userprofileStore, providerProvider, provider4, authSessionManager, authorizationStore, userverifyStore, storeImpl, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
+	userProvider := &user.Provider{
+		Commands: commands,
+		Queries:  queries,
+	}
+	interactionProvider := interaction.ProvideProvider(redisStore, timeProvider, factory, providerProvider, provider4, userProvider, oobProvider, tenantConfiguration, hookProvider)
+	endpointsProvider := &auth.EndpointsProvider{
+		PrefixProvider: urlprefixProvider,
+	}
+	clientAuthenticator := handler.ProvideClientAuthenticator(r, clientResolver, urlprefixProvider, endpointsProvider, grantStore, timeProvider)
+	jwtAccessTokenCodec := oauth2.ProvideJWTAccessTokenCodec(tenantConfiguration, urlprefixProvider, timeProvider)
 	accessEventProvider := auth2.AccessEventProvider{
 		Store: eventStore,
 	}
-	sessionStore := redis4.ProvideStore(context, tenantConfiguration, timeProvider, factory)
 	authAccessEventProvider := &auth2.AccessEventProvider{
 		Store: eventStore,
 	}
@@ -1244,13 +1615,11 @@ func newEnterLoginIDHandler(r *http.Request, m auth.DependencyMap) http.Handler
 		Anonymous:    anonymousProvider,
 		Challenges:   challengeProvider,
 	}
-	idTokenIssuer := oidc.ProvideIDTokenIssuer(tenantConfiguration, urlprefixProvider, queries, timeProvider)
+	idTokenIssuer := oidc.ProvideIDTokenIssuer(tenantConfiguration, urlprefixProvider, queries, providerProvider, timeProvider)
 	tokenGenerator := _wireTokenGeneratorValue
-	tokenHandler := handler.ProvideTokenHandler(r, tenantConfiguration, factory, authorizationStore, grantStore, grantStore, grantStore, accessEventProvider, sessionProvider, anonymousFlow, idTokenIssuer, tokenGenerator, timeProvider)
-	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
-	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	tokenHandler := handler.ProvideTokenHandler(r, tenantConfiguration, factory, clientAuthenticator, authorizationStore, grantStore, grantStore, grantStore, grantStore, jwtAccessTokenCodec, accessEventProvider, sessionProvider, queries, hookProvider, authSessionManager, anonymousFlow, idTokenIssuer, tokenGenerator, timeProvider)
 	userController := flows.ProvideUserController(store, queries, tokenHandler, cookieConfiguration, sessionProvider, hookProvider, timeProvider, tenantConfiguration)
-	webAppFlow := flows.ProvideWebAppFlow(tenantConfiguration, providerProvider, queries, hookProvider, interactionProvider, userController)
+	webAppFlow := flows.ProvideWebAppFlow(tenantConfiguration, providerProvider, queries, hookProvider, interactionProvider, userController, directory)
 	redirectURLFunc := provideRedirectURIForWebAppFunc()
 	oAuthProviderFactory := sso.ProvideOAuthProviderFactory(tenantConfiguration, urlprefixProvider, timeProvider, normalizerFactory, redirectURLFunc)
 	authenticateProviderImpl := &webapp.AuthenticateProviderImpl{
@@ -1286,42 +1655,279 @@ func newLogoutHandler(r *http.Request, m auth.DependencyMap) http.Handler {
 	loginidProvider := loginid.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration, loginidChecker, normalizerFactory)
 	oauthProvider := oauth.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
 	anonymousProvider := anonymous.ProvideProvider(sqlBuilder, sqlExecutor)
-	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider)
+	ldapProvider := ldap.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider, ldapProvider)
 	renderProvider := webapp.ProvideRenderProvider(staticAssetURLPrefix, tenantConfiguration, engine, checker, providerProvider)
 	store := pq.ProvideStore(sqlBuilderFactory, sqlExecutor)
 	userprofileStore := userprofile.ProvideStore(timeProvider, sqlBuilder, sqlExecutor)
+	userStore := user.ProvideStore(sqlBuilderFactory, sqlExecutor, timeProvider)
 	queries := &user.Queries{
 		AuthInfos:    store,
 		UserProfiles: userprofileStore,
 		Identities:   providerProvider,
 		Time:         timeProvider,
+		Store:        userStore,
 	}
+	hookStore := hook.ProvideStore(tenantConfiguration, sqlBuilder, sqlExecutor)
 	txContext := db.ProvideTxContext(context, tenantConfiguration)
+	attemptStore := hook.ProvideAttemptStore(context, tenantConfiguration, sqlBuilder)
 	factory := logging.ProvideLoggerFactory(context, tenantConfiguration)
-	hookProvider := hook.ProvideHookProvider(context, sqlBuilder, sqlExecutor, tenantConfiguration, txContext, timeProvider, queries, store, userprofileStore, loginidProvider, factory)
-	sessionStore := redis4.ProvideStore(context, tenantConfiguration, timeProvider, factory)
+	deliverer := hook.ProvideDeliverer(tenantConfiguration, timeProvider, store, userprofileStore, loginidProvider, attemptStore, factory)
+	executor := auth.ProvideTaskExecutor(m)
+	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
+	hookProvider := hook.ProvideHookProvider(context, tenantConfiguration, sqlBuilder, hookStore, txContext, timeProvider, queries, deliverer, queue, executor, factory)
+	sessionStore := redis2.ProvideStore(context, tenantConfiguration, timeProvider, factory)
 	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
 	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
 	manager := session.ProvideSessionManager(sessionStore, timeProvider, tenantConfiguration, cookieConfiguration)
-	grantStore := redis2.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
+	grantStore := redis3.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
 	sessionManager := &oauth2.SessionManager{
 		Store: grantStore,
 		Time:  timeProvider,
 	}
-	authSessionManager := &auth2.SessionManager{
-		Users:               queries,
-		Hooks:               hookProvider,
-		IDPSessions:         manager,
-		AccessTokenSessions: sessionManager,
+	eventStore := redis4.ProvideEventStore(context, tenantConfiguration)
+	urlprefixProvider := urlprefix.NewProvider(r)
+	clientStore := &pq2.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
 	}
+	clientResolver := oauth2.ProvideClientResolver(tenantConfiguration, clientStore)
+	logoutNotifier := oidc.ProvideLogoutNotifier(tenantConfiguration, urlprefixProvider, clientResolver, queue, timeProvider)
+	authSessionManager := auth2.ProvideSessionManager(queries, hookProvider, manager, sessionManager, eventStore, logoutNotifier, factory)
 	logoutHandler := &LogoutHandler{
 		RenderProvider: renderProvider,
 		SessionManager: authSessionManager,
+		FrontChannel:   logoutNotifier,
 		TxContext:      txContext,
 	}
 	return logoutHandler
 }
 
+func newDeviceHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	staticAssetURLPrefix := auth.ProvideStaticAssetURLPrefix(m)
+	context := auth.ProvideContext(r)
+	tenantConfiguration := auth.ProvideTenantConfig(context, m)
+	engine := auth.ProvideTemplateEngine(tenantConfiguration, m)
+	timeProvider := time.NewProvider()
+	sqlBuilderFactory := db.ProvideSQLBuilderFactory(tenantConfiguration)
+	sqlBuilder := auth.ProvideAuthSQLBuilder(sqlBuilderFactory)
+	sqlExecutor := db.ProvideSQLExecutor(context, tenantConfiguration)
+	historyStoreImpl := password.ProvideHistoryStore(timeProvider, sqlBuilder, sqlExecutor)
+	checker := password.ProvideChecker(tenantConfiguration, historyStoreImpl)
+	reservedNameChecker := auth.ProvideReservedNameChecker(m)
+	typeCheckerFactory := loginid.ProvideTypeCheckerFactory(tenantConfiguration, reservedNameChecker)
+	loginidChecker := loginid.ProvideChecker(tenantConfiguration, typeCheckerFactory)
+	normalizerFactory := loginid.ProvideNormalizerFactory(tenantConfiguration)
+	loginidProvider := loginid.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration, loginidChecker, normalizerFactory)
+	oauthProvider := oauth.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	anonymousProvider := anonymous.ProvideProvider(sqlBuilder, sqlExecutor)
+	ldapProvider := ldap.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider, ldapProvider)
+	renderProvider := webapp.ProvideRenderProvider(staticAssetURLPrefix, tenantConfiguration, engine, checker, providerProvider)
+	clientStore := &pq2.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	clientResolver := oauth2.ProvideClientResolver(tenantConfiguration, clientStore)
+	urlprefixProvider := urlprefix.NewProvider(r)
+	endpointsProvider := &auth.EndpointsProvider{
+		PrefixProvider: urlprefixProvider,
+	}
+	factory := logging.ProvideLoggerFactory(context, tenantConfiguration)
+	grantStore := redis3.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
+	clientAuthenticator := handler.ProvideClientAuthenticator(r, clientResolver, urlprefixProvider, endpointsProvider, grantStore, timeProvider)
+	authorizationStore := &pq2.AuthorizationStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	scopesValidator := oidc.ProvideScopesValidator(tenantConfiguration)
+	tokenGenerator := _wireTokenGeneratorValue
+	deviceAuthorizationHandler := handler.ProvideDeviceAuthorizationHandler(tenantConfiguration, r, clientResolver, clientAuthenticator, authorizationStore, grantStore, grantStore, endpointsProvider, scopesValidator, tokenGenerator, timeProvider)
+	authorizationRequestResolver := handler.ProvideAuthorizationRequestResolver(tenantConfiguration, urlprefixProvider, grantStore, tokenGenerator, timeProvider)
+	store := pq.ProvideStore(sqlBuilderFactory, sqlExecutor)
+	userprofileStore := userprofile.ProvideStore(timeProvider, sqlBuilder, sqlExecutor)
+	userStore := user.ProvideStore(sqlBuilderFactory, sqlExecutor, timeProvider)
+	queries := &user.Queries{
+		AuthInfos:    store,
+		UserProfiles: userprofileStore,
+		Identities:   providerProvider,
+		Time:         timeProvider,
+		Store:        userStore,
+	}
+	hookStore := hook.ProvideStore(tenantConfiguration, sqlBuilder, sqlExecutor)
+	txContext := db.ProvideTxContext(context, tenantConfiguration)
+	attemptStore := hook.ProvideAttemptStore(context, tenantConfiguration, sqlBuilder)
+	deliverer := hook.ProvideDeliverer(tenantConfiguration, timeProvider, store, userprofileStore, loginidProvider, attemptStore, factory)
+	executor := auth.ProvideTaskExecutor(m)
+	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
+	hookProvider := hook.ProvideHookProvider(context, tenantConfiguration, sqlBuilder, hookStore, txContext, timeProvider, queries, deliverer, queue, executor, factory)
+	sessionStore := redis2.ProvideStore(context, tenantConfiguration, timeProvider, factory)
+	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
+	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	manager := session.ProvideSessionManager(sessionStore, timeProvider, tenantConfiguration, cookieConfiguration)
+	sessionManager := &oauth2.SessionManager{
+		Store: grantStore,
+		Time:  timeProvider,
+	}
+	eventStore := redis4.ProvideEventStore(context, tenantConfiguration)
+	logoutNotifier := oidc.ProvideLogoutNotifier(tenantConfiguration, urlprefixProvider, clientResolver, queue, timeProvider)
+	authSessionManager := auth2.ProvideSessionManager(queries, hookProvider, manager, sessionManager, eventStore, logoutNotifier, factory)
+	consentHandler := handler.ProvideConsentHandler(tenantConfiguration, clientResolver, authorizationRequestResolver, authorizationStore, grantStore, authSessionManager, scopesValidator, timeProvider)
+	deviceHandler := &DeviceHandler{
+		RenderProvider: renderProvider,
+		Verifier:       deviceAuthorizationHandler,
+		Scopes:         consentHandler,
+		TxContext:      txContext,
+	}
+	return deviceHandler
+}
+
+func newConsentHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	staticAssetURLPrefix := auth.ProvideStaticAssetURLPrefix(m)
+	context := auth.ProvideContext(r)
+	tenantConfiguration := auth.ProvideTenantConfig(context, m)
+	engine := auth.ProvideTemplateEngine(tenantConfiguration, m)
+	timeProvider := time.NewProvider()
+	sqlBuilderFactory := db.ProvideSQLBuilderFactory(tenantConfiguration)
+	sqlBuilder := auth.ProvideAuthSQLBuilder(sqlBuilderFactory)
+	sqlExecutor := db.ProvideSQLExecutor(context, tenantConfiguration)
+	historyStoreImpl := password.ProvideHistoryStore(timeProvider, sqlBuilder, sqlExecutor)
+	checker := password.ProvideChecker(tenantConfiguration, historyStoreImpl)
+	reservedNameChecker := auth.ProvideReservedNameChecker(m)
+	typeCheckerFactory := loginid.ProvideTypeCheckerFactory(tenantConfiguration, reservedNameChecker)
+	loginidChecker := loginid.ProvideChecker(tenantConfiguration, typeCheckerFactory)
+	normalizerFactory := loginid.ProvideNormalizerFactory(tenantConfiguration)
+	loginidProvider := loginid.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration, loginidChecker, normalizerFactory)
+	oauthProvider := oauth.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	anonymousProvider := anonymous.ProvideProvider(sqlBuilder, sqlExecutor)
+	ldapProvider := ldap.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider, ldapProvider)
+	renderProvider := webapp.ProvideRenderProvider(staticAssetURLPrefix, tenantConfiguration, engine, checker, providerProvider)
+	clientStore := &pq2.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	clientResolver := oauth2.ProvideClientResolver(tenantConfiguration, clientStore)
+	urlprefixProvider := urlprefix.NewProvider(r)
+	factory := logging.ProvideLoggerFactory(context, tenantConfiguration)
+	grantStore := redis3.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
+	tokenGenerator := _wireTokenGeneratorValue
+	authorizationRequestResolver := handler.ProvideAuthorizationRequestResolver(tenantConfiguration, urlprefixProvider, grantStore, tokenGenerator, timeProvider)
+	authorizationStore := &pq2.AuthorizationStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	store := pq.ProvideStore(sqlBuilderFactory, sqlExecutor)
+	userprofileStore := userprofile.ProvideStore(timeProvider, sqlBuilder, sqlExecutor)
+	userStore := user.ProvideStore(sqlBuilderFactory, sqlExecutor, timeProvider)
+	queries := &user.Queries{
+		AuthInfos:    store,
+		UserProfiles: userprofileStore,
+		Identities:   providerProvider,
+		Time:         timeProvider,
+		Store:        userStore,
+	}
+	hookStore := hook.ProvideStore(tenantConfiguration, sqlBuilder, sqlExecutor)
+	txContext := db.ProvideTxContext(context, tenantConfiguration)
+	attemptStore := hook.ProvideAttemptStore(context, tenantConfiguration, sqlBuilder)
+	deliverer := hook.ProvideDeliverer(tenantConfiguration, timeProvider, store, userprofileStore, loginidProvider, attemptStore, factory)
+	executor := auth.ProvideTaskExecutor(m)
+	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
+	hookProvider := hook.ProvideHookProvider(context, tenantConfiguration, sqlBuilder, hookStore, txContext, timeProvider, queries, deliverer, queue, executor, factory)
+	sessionStore := redis2.ProvideStore(context, tenantConfiguration, timeProvider, factory)
+	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
+	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	manager := session.ProvideSessionManager(sessionStore, timeProvider, tenantConfiguration, cookieConfiguration)
+	sessionManager := &oauth2.SessionManager{
+		Store: grantStore,
+		Time:  timeProvider,
+	}
+	eventStore := redis4.ProvideEventStore(context, tenantConfiguration)
+	logoutNotifier := oidc.ProvideLogoutNotifier(tenantConfiguration, urlprefixProvider, clientResolver, queue, timeProvider)
+	authSessionManager := auth2.ProvideSessionManager(queries, hookProvider, manager, sessionManager, eventStore, logoutNotifier, factory)
+	scopesValidator := oidc.ProvideScopesValidator(tenantConfiguration)
+	consentHandler := handler.ProvideConsentHandler(tenantConfiguration, clientResolver, authorizationRequestResolver, authorizationStore, grantStore, authSessionManager, scopesValidator, timeProvider)
+	webappConsentHandler := &ConsentHandler{
+		RenderProvider: renderProvider,
+		Consents:       consentHandler,
+		TxContext:      txContext,
+	}
+	return webappConsentHandler
+}
+
+func newSettingsAuthorizationsHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	staticAssetURLPrefix := auth.ProvideStaticAssetURLPrefix(m)
+	context := auth.ProvideContext(r)
+	tenantConfiguration := auth.ProvideTenantConfig(context, m)
+	engine := auth.ProvideTemplateEngine(tenantConfiguration, m)
+	timeProvider := time.NewProvider()
+	sqlBuilderFactory := db.ProvideSQLBuilderFactory(tenantConfiguration)
+	sqlBuilder := auth.ProvideAuthSQLBuilder(sqlBuilderFactory)
+	sqlExecutor := db.ProvideSQLExecutor(context, tenantConfiguration)
+	historyStoreImpl := password.ProvideHistoryStore(timeProvider, sqlBuilder, sqlExecutor)
+	checker := password.ProvideChecker(tenantConfiguration, historyStoreImpl)
+	reservedNameChecker := auth.ProvideReservedNameChecker(m)
+	typeCheckerFactory := loginid.ProvideTypeCheckerFactory(tenantConfiguration, reservedNameChecker)
+	loginidChecker := loginid.ProvideChecker(tenantConfiguration, typeCheckerFactory)
+	normalizerFactory := loginid.ProvideNormalizerFactory(tenantConfiguration)
+	loginidProvider := loginid.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration, loginidChecker, normalizerFactory)
+	oauthProvider := oauth.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	anonymousProvider := anonymous.ProvideProvider(sqlBuilder, sqlExecutor)
+	ldapProvider := ldap.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider, ldapProvider)
+	renderProvider := webapp.ProvideRenderProvider(staticAssetURLPrefix, tenantConfiguration, engine, checker, providerProvider)
+	clientStore := &pq2.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	clientResolver := oauth2.ProvideClientResolver(tenantConfiguration, clientStore)
+	urlprefixProvider := urlprefix.NewProvider(r)
+	factory := logging.ProvideLoggerFactory(context, tenantConfiguration)
+	grantStore := redis3.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
+	tokenGenerator := _wireTokenGeneratorValue
+	authorizationRequestResolver := handler.ProvideAuthorizationRequestResolver(tenantConfiguration, urlprefixProvider, grantStore, tokenGenerator, timeProvider)
+	authorizationStore := &pq2.AuthorizationStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	store := pq.ProvideStore(sqlBuilderFactory, sqlExecutor)
+	userprofileStore := userprofile.ProvideStore(timeProvider, sqlBuilder, sqlExecutor)
+	userStore := user.ProvideStore(sqlBuilderFactory, sqlExecutor, timeProvider)
+	queries := &user.Queries{
+		AuthInfos:    store,
+		UserProfiles: userprofileStore,
+		Identities:   providerProvider,
+		Time:         timeProvider,
+		Store:        userStore,
+	}
+	hookStore := hook.ProvideStore(tenantConfiguration, sqlBuilder, sqlExecutor)
+	txContext := db.ProvideTxContext(context, tenantConfiguration)
+	attemptStore := hook.ProvideAttemptStore(context, tenantConfiguration, sqlBuilder)
+	deliverer := hook.ProvideDeliverer(tenantConfiguration, timeProvider, store, userprofileStore, loginidProvider, attemptStore, factory)
+	executor := auth.ProvideTaskExecutor(m)
+	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
+	hookProvider := hook.ProvideHookProvider(context, tenantConfiguration, sqlBuilder, hookStore, txContext, timeProvider, queries, deliverer, queue, executor, factory)
+	sessionStore := redis2.ProvideStore(context, tenantConfiguration, timeProvider, factory)
+	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
+	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	manager := session.ProvideSessionManager(sessionStore, timeProvider, tenantConfiguration, cookieConfiguration)
+	sessionManager := &oauth2.SessionManager{
+		Store: grantStore,
+		Time:  timeProvider,
+	}
+	eventStore := redis4.ProvideEventStore(context, tenantConfiguration)
+	logoutNotifier := oidc.ProvideLogoutNotifier(tenantConfiguration, urlprefixProvider, clientResolver, queue, timeProvider)
+	authSessionManager := auth2.ProvideSessionManager(queries, hookProvider, manager, sessionManager, eventStore, logoutNotifier, factory)
+	scopesValidator := oidc.ProvideScopesValidator(tenantConfiguration)
+	consentHandler := handler.ProvideConsentHandler(tenantConfiguration, clientResolver, authorizationRequestResolver, authorizationStore, grantStore, authSessionManager, scopesValidator, timeProvider)
+	settingsAuthorizationsHandler := &SettingsAuthorizationsHandler{
+		RenderProvider: renderProvider,
+		Authorizations: consentHandler,
+		TxContext:      txContext,
+	}
+	return settingsAuthorizationsHandler
+}
+
 func newSSOCallbackHandler(r *http.Request, m auth.DependencyMap) http.Handler {
 	context := auth.ProvideContext(r)
 	tenantConfiguration := auth.ProvideTenantConfig(context, m)
@@ -1341,7 +1947,8 @@ func newSSOCallbackHandler(r *http.Request, m auth.DependencyMap) http.Handler {
 	loginidProvider := loginid.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration, loginidChecker, normalizerFactory)
 	oauthProvider := oauth.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
 	anonymousProvider := anonymous.ProvideProvider(sqlBuilder, sqlExecutor)
-	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider)
+	ldapProvider := ldap.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider, ldapProvider)
 	renderProvider := webapp.ProvideRenderProvider(staticAssetURLPrefix, tenantConfiguration, engine, checker, providerProvider)
 	stateStoreImpl := &webapp.StateStoreImpl{
 		Context: context,
@@ -1352,48 +1959,217 @@ func newSSOCallbackHandler(r *http.Request, m auth.DependencyMap) http.Handler {
 	stateCodec := sso.ProvideStateCodec(tenantConfiguration)
 	store := pq.ProvideStore(sqlBuilderFactory, sqlExecutor)
 	userprofileStore := userprofile.ProvideStore(timeProvider, sqlBuilder, sqlExecutor)
+	userStore := user.ProvideStore(sqlBuilderFactory, sqlExecutor, timeProvider)
 	queries := &user.Queries{
 		AuthInfos:    store,
 		UserProfiles: userprofileStore,
 		Identities:   providerProvider,
 		Time:         timeProvider,
+		Store:        userStore,
 	}
+	hookStore := hook.ProvideStore(tenantConfiguration, sqlBuilder, sqlExecutor)
 	txContext := db.ProvideTxContext(context, tenantConfiguration)
+	attemptStore := hook.ProvideAttemptStore(context, tenantConfiguration, sqlBuilder)
 	factory := logging.ProvideLoggerFactory(context, tenantConfiguration)
-	hookProvider := hook.ProvideHookProvider(context, sqlBuilder, sqlExecutor, tenantConfiguration, txContext, timeProvider, queries, store, userprofileStore, loginidProvider, factory)
+	deliverer := hook.ProvideDeliverer(tenantConfiguration, timeProvider, store, userprofileStore, loginidProvider, attemptStore, factory)
+	executor := auth.ProvideTaskExecutor(m)
+	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
+	hookProvider := hook.ProvideHookProvider(context, tenantConfiguration, sqlBuilder, hookStore, txContext, timeProvider, queries, deliverer, queue, executor, factory)
 	redisStore := redis.ProvideStore(context, tenantConfiguration, timeProvider)
 	passwordProvider := password.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, factory, historyStoreImpl, checker, tenantConfiguration)
 	totpProvider := totp.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
 	urlprefixProvider := urlprefix.NewProvider(r)
-	executor := auth.ProvideTaskExecutor(m)
-	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
 	oobProvider := oob.ProvideProvider(context, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider, engine, urlprefixProvider, queue)
 	bearertokenProvider := bearertoken.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
 	recoverycodeProvider := recoverycode.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
-	provider3 := &provider2.Provider{
+	directory := ldap2.ProvideDirectory(tenantConfiguration)
+	provider3 := ldap3.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, directory)
+	provider4 := &provider2.Provider{
 		Password:     passwordProvider,
 		TOTP:         totpProvider,
 		OOBOTP:       oobProvider,
 		BearerToken:  bearertokenProvider,
 		RecoveryCode: recoverycodeProvider,
+		LDAP:         provider3,
+	}
+	sessionStore := redis2.ProvideStore(context, tenantConfiguration, timeProvider, factory)
+	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
+	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	manager := session.ProvideSessionManager(sessionStore, timeProvider, tenantConfiguration, cookieConfiguration)
+	grantStore := redis3.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
+	sessionManager := &oauth2.SessionManager{
+		Store: grantStore,
+		Time:  timeProvider,
+	}
+	eventStore := redis4.ProvideEventStore(context, tenantConfiguration)
+	clientStore := &pq2.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	clientResolver := oauth2.ProvideClientResolver(tenantConfiguration, clientStore)
+	logoutNotifier := oidc.ProvideLogoutNotifier(tenantConfiguration, urlprefixProvider, clientResolver, queue, timeProvider)
+	authSessionManager := auth2.ProvideSessionManager(queries, hookProvider, manager, sessionManager, eventStore, logoutNotifier, factory)
+	authorizationStore := &pq2.AuthorizationStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	userverifyStore := userverify.ProvideStore(sqlBuilder, sqlExecutor)
+	storeImpl := &forgotpassword.StoreImpl{
+		Context: context,
 	}
 	welcomemessageProvider := welcomemessage.ProvideProvider(context, tenantConfiguration, engine, queue)
-	commands := user.ProvideCommands(store, userprofileStore, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
+	commands := user.ProvideCommands(store, userprofileStore, providerProvider, provider4, authSessionManager, authorizationStore, userverifyStore, storeImpl, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
 	userProvider := &user.Provider{
 		Commands: commands,
 		Queries:  queries,
 	}
-	interactionProvider := interaction.ProvideProvider(redisStore, timeProvider, factory, providerProvider, provider3, userProvider, oobProvider, tenantConfiguration, hookProvider)
+	interactionProvider := interaction.ProvideProvider(redisStore, timeProvider, factory, providerProvider, provider4, userProvider, oobProvider, tenantConfiguration, hookProvider)
+	endpointsProvider := &auth.EndpointsProvider{
+		PrefixProvider: urlprefixProvider,
+	}
+	clientAuthenticator := handler.ProvideClientAuthenticator(r, clientResolver, urlprefixProvider, endpointsProvider, grantStore, timeProvider)
+	jwtAccessTokenCodec := oauth2.ProvideJWTAccessTokenCodec(tenantConfiguration, urlprefixProvider, timeProvider)
+	accessEventProvider := auth2.AccessEventProvider{
+		Store: eventStore,
+	}
+	authAccessEventProvider := &auth2.AccessEventProvider{
+		Store: eventStore,
+	}
+	sessionProvider := session.ProvideSessionProvider(r, sessionStore, authAccessEventProvider, tenantConfiguration)
+	isAnonymousIdentityEnabled := flows.ProvideIsAnonymousIdentityEnabled(tenantConfiguration)
+	challengeProvider := challenge.ProvideProvider(context, timeProvider, tenantConfiguration)
+	anonymousFlow := &flows.AnonymousFlow{
+		Enabled:      isAnonymousIdentityEnabled,
+		Interactions: interactionProvider,
+		Anonymous:    anonymousProvider,
+		Challenges:   challengeProvider,
+	}
+	idTokenIssuer := oidc.ProvideIDTokenIssuer(tenantConfiguration, urlprefixProvider, queries, providerProvider, timeProvider)
+	tokenGenerator := _wireTokenGeneratorValue
+	tokenHandler := handler.ProvideTokenHandler(r, tenantConfiguration, factory, clientAuthenticator, authorizationStore, grantStore, grantStore, grantStore, grantStore, jwtAccessTokenCodec, accessEventProvider, sessionProvider, queries, hookProvider, authSessionManager, anonymousFlow, idTokenIssuer, tokenGenerator, timeProvider)
+	userController := flows.ProvideUserController(store, queries, tokenHandler, cookieConfiguration, sessionProvider, hookProvider, timeProvider, tenantConfiguration)
+	webAppFlow := flows.ProvideWebAppFlow(tenantConfiguration, providerProvider, queries, hookProvider, interactionProvider, userController, directory)
+	redirectURLFunc := provideRedirectURIForWebAppFunc()
+	oAuthProviderFactory := sso.ProvideOAuthProviderFactory(tenantConfiguration, urlprefixProvider, timeProvider, normalizerFactory, redirectURLFunc)
+	authenticateProviderImpl := &webapp.AuthenticateProviderImpl{
+		ValidateProvider:     validateProvider,
+		RenderProvider:       renderProvider,
+		StateProvider:        stateProviderImpl,
+		SSOStateCodec:        stateCodec,
+		Interactions:         webAppFlow,
+		OAuthProviderFactory: oAuthProviderFactory,
+	}
+	ssoCallbackHandler := &SSOCallbackHandler{
+		Provider:  authenticateProviderImpl,
+		TxContext: txContext,
+	}
+	return ssoCallbackHandler
+}
+
+func newSAMLACSHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	context := auth.ProvideContext(r)
+	tenantConfiguration := auth.ProvideTenantConfig(context, m)
+	validateProvider := webapp.ProvideValidateProvider(tenantConfiguration)
+	staticAssetURLPrefix := auth.ProvideStaticAssetURLPrefix(m)
+	engine := auth.ProvideTemplateEngine(tenantConfiguration, m)
+	timeProvider := time.NewProvider()
+	sqlBuilderFactory := db.ProvideSQLBuilderFactory(tenantConfiguration)
+	sqlBuilder := auth.ProvideAuthSQLBuilder(sqlBuilderFactory)
+	sqlExecutor := db.ProvideSQLExecutor(context, tenantConfiguration)
+	historyStoreImpl := password.ProvideHistoryStore(timeProvider, sqlBuilder, sqlExecutor)
+	checker := password.ProvideChecker(tenantConfiguration, historyStoreImpl)
+	reservedNameChecker := auth.ProvideReservedNameChecker(m)
+	typeCheckerFactory := loginid.ProvideTypeCheckerFactory(tenantConfiguration, reservedNameChecker)
+	loginidChecker := loginid.ProvideChecker(tenantConfiguration, typeCheckerFactory)
+	normalizerFactory := loginid.ProvideNormalizerFactory(tenantConfiguration)
+	loginidProvider := loginid.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration, loginidChecker, normalizerFactory)
+	oauthProvider := oauth.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	anonymousProvider := anonymous.ProvideProvider(sqlBuilder, sqlExecutor)
+	ldapProvider := ldap.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider, ldapProvider)
+	renderProvider := webapp.ProvideRenderProvider(staticAssetURLPrefix, tenantConfiguration, engine, checker, providerProvider)
+	stateStoreImpl := &webapp.StateStoreImpl{
+		Context: context,
+	}
+	stateProviderImpl := &webapp.StateProviderImpl{
+		StateStore: stateStoreImpl,
+	}
+	stateCodec := sso.ProvideStateCodec(tenantConfiguration)
+	store := pq.ProvideStore(sqlBuilderFactory, sqlExecutor)
+	userprofileStore := userprofile.ProvideStore(timeProvider, sqlBuilder, sqlExecutor)
+	userStore := user.ProvideStore(sqlBuilderFactory, sqlExecutor, timeProvider)
+	queries := &user.Queries{
+		AuthInfos:    store,
+		UserProfiles: userprofileStore,
+		Identities:   providerProvider,
+		Time:         timeProvider,
+		Store:        userStore,
+	}
+	hookStore := hook.ProvideStore(tenantConfiguration, sqlBuilder, sqlExecutor)
+	txContext := db.ProvideTxContext(context, tenantConfiguration)
+	attemptStore := hook.ProvideAttemptStore(context, tenantConfiguration, sqlBuilder)
+	factory := logging.ProvideLoggerFactory(context, tenantConfiguration)
+	deliverer := hook.ProvideDeliverer(tenantConfiguration, timeProvider, store, userprofileStore, loginidProvider, attemptStore, factory)
+	executor := auth.ProvideTaskExecutor(m)
+	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
+	hookProvider := hook.ProvideHookProvider(context, tenantConfiguration, sqlBuilder, hookStore, txContext, timeProvider, queries, deliverer, queue, executor, factory)
+	redisStore := redis.ProvideStore(context, tenantConfiguration, timeProvider)
+	passwordProvider := password.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, factory, historyStoreImpl, checker, tenantConfiguration)
+	totpProvider := totp.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
+	urlprefixProvider := urlprefix.NewProvider(r)
+	oobProvider := oob.ProvideProvider(context, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider, engine, urlprefixProvider, queue)
+	bearertokenProvider := bearertoken.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
+	recoverycodeProvider := recoverycode.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration)
+	directory := ldap2.ProvideDirectory(tenantConfiguration)
+	provider3 := ldap3.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, directory)
+	provider4 := &provider2.Provider{
+		Password:     passwordProvider,
+		TOTP:         totpProvider,
+		OOBOTP:       oobProvider,
+		BearerToken:  bearertokenProvider,
+		RecoveryCode: recoverycodeProvider,
+		LDAP:         provider3,
+	}
+	sessionStore := redis2.ProvideStore(context, tenantConfiguration, timeProvider, factory)
+	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
+	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	manager := session.ProvideSessionManager(sessionStore, timeProvider, tenantConfiguration, cookieConfiguration)
+	grantStore := redis3.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
+	sessionManager := &oauth2.SessionManager{
+		Store: grantStore,
+		Time:  timeProvider,
+	}
+	eventStore := redis4.ProvideEventStore(context, tenantConfiguration)
+	clientStore := &pq2.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	clientResolver := oauth2.ProvideClientResolver(tenantConfiguration, clientStore)
+	logoutNotifier := oidc.ProvideLogoutNotifier(tenantConfiguration, urlprefixProvider, clientResolver, queue, timeProvider)
+	authSessionManager := auth2.ProvideSessionManager(queries, hookProvider, manager, sessionManager, eventStore, logoutNotifier, factory)
 	authorizationStore := &pq2.AuthorizationStore{
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
-	grantStore := redis2.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, timeProvider)
-	eventStore := redis3.ProvideEventStore(context, tenantConfiguration)
+	userverifyStore := userverify.ProvideStore(sqlBuilder, sqlExecutor)
+	storeImpl := &forgotpassword.StoreImpl{
+		Context: context,
+	}
+	welcomemessageProvider := welcomemessage.ProvideProvider(context, tenantConfiguration, engine, queue)
+	commands := user.ProvideCommands(store, userprofileStore, providerProvider, provider4, authSessionManager, authorizationStore, userverifyStore, storeImpl, timeProvider, hookProvider, urlprefixProvider, queue, tenantConfiguration, welcomemessageProvider)
+	userProvider := &user.Provider{
+		Commands: commands,
+		Queries:  queries,
+	}
+	interactionProvider := interaction.ProvideProvider(redisStore, timeProvider, factory, providerProvider, provider4, userProvider, oobProvider, tenantConfiguration, hookProvider)
+	endpointsProvider := &auth.EndpointsProvider{
+		PrefixProvider: urlprefixProvider,
+	}
+	clientAuthenticator := handler.ProvideClientAuthenticator(r, clientResolver, urlprefixProvider, endpointsProvider, grantStore, timeProvider)
+	jwtAccessTokenCodec := oauth2.ProvideJWTAccessTokenCodec(tenantConfiguration, urlprefixProvider, timeProvider)
 	accessEventProvider := auth2.AccessEventProvider{
 		Store: eventStore,
 	}
-	sessionStore := redis4.ProvideStore(context, tenantConfiguration, timeProvider, factory)
 	authAccessEventProvider := &auth2.AccessEventProvider{
 		Store: eventStore,
 	}
@@ -1406,13 +2182,11 @@ func newSSOCallbackHandler(r *http.Request, m auth.DependencyMap) http.Handler {
 		Anonymous:    anonymousProvider,
 		Challenges:   challengeProvider,
 	}
-	idTokenIssuer := oidc.ProvideIDTokenIssuer(tenantConfiguration, urlprefixProvider, queries, timeProvider)
+	idTokenIssuer := oidc.ProvideIDTokenIssuer(tenantConfiguration, urlprefixProvider, queries, providerProvider, timeProvider)
 	tokenGenerator := _wireTokenGeneratorValue
-	tokenHandler := handler.ProvideTokenHandler(r, tenantConfiguration, factory, authorizationStore, grantStore, grantStore, grantStore, accessEventProvider, sessionProvider, anonymousFlow, idTokenIssuer, tokenGenerator, timeProvider)
-	insecureCookieConfig := auth.ProvideSessionInsecureCookieConfig(m)
-	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
+	tokenHandler := handler.ProvideTokenHandler(r, tenantConfiguration, factory, clientAuthenticator, authorizationStore, grantStore, grantStore, grantStore, grantStore, jwtAccessTokenCodec, accessEventProvider, sessionProvider, queries, hookProvider, authSessionManager, anonymousFlow, idTokenIssuer, tokenGenerator, timeProvider)
 	userController := flows.ProvideUserController(store, queries, tokenHandler, cookieConfiguration, sessionProvider, hookProvider, timeProvider, tenantConfiguration)
-	webAppFlow := flows.ProvideWebAppFlow(tenantConfiguration, providerProvider, queries, hookProvider, interactionProvider, userController)
+	webAppFlow := flows.ProvideWebAppFlow(tenantConfiguration, providerProvider, queries, hookProvider, interactionProvider, userController, directory)
 	redirectURLFunc := provideRedirectURIForWebAppFunc()
 	oAuthProviderFactory := sso.ProvideOAuthProviderFactory(tenantConfiguration, urlprefixProvider, timeProvider, normalizerFactory, redirectURLFunc)
 	authenticateProviderImpl := &webapp.AuthenticateProviderImpl{
@@ -1423,11 +2197,25 @@ func newSSOCallbackHandler(r *http.Request, m auth.DependencyMap) http.Handler {
 		Interactions:         webAppFlow,
 		OAuthProviderFactory: oAuthProviderFactory,
 	}
-	ssoCallbackHandler := &SSOCallbackHandler{
+	samlacsHandler := &SAMLACSHandler{
 		Provider:  authenticateProviderImpl,
 		TxContext: txContext,
 	}
-	return ssoCallbackHandler
+	return samlacsHandler
+}
+
+func newSAMLMetadataHandler(r *http.Request, m auth.DependencyMap) http.Handler {
+	context := auth.ProvideContext(r)
+	tenantConfiguration := auth.ProvideTenantConfig(context, m)
+	urlprefixProvider := urlprefix.NewProvider(r)
+	timeProvider := time.NewProvider()
+	normalizerFactory := loginid.ProvideNormalizerFactory(tenantConfiguration)
+	redirectURLFunc := provideRedirectURIForWebAppFunc()
+	oAuthProviderFactory := sso.ProvideOAuthProviderFactory(tenantConfiguration, urlprefixProvider, timeProvider, normalizerFactory, redirectURLFunc)
+	samlMetadataHandler := &SAMLMetadataHandler{
+		ProviderFactory: oAuthProviderFactory,
+	}
+	return samlMetadataHandler
 }
 
 // wire.go:
@@ -0,0 +1,23 @@
+package model
+
+import "github.com/skygeario/skygear-server/pkg/core/authn"
+
+// Authenticator is an authenticator of user; secrets are never exposed.
+type Authenticator struct {
+	ID    string                  `json:"id"`
+	Type  authn.AuthenticatorType `json:"type"`
+	Props map[string]interface{}  `json:"props,omitempty"`
+}
+
+// @JSONSchema
+const AuthenticatorSchema = `
+{
+	"$id": "#Authenticator",
+	"type": "object",
+	"properties": {
+		"id": { "type": "string" },
+		"type": { "type": "string" },
+		"props": { "type": "object" }
+	}
+}
+`
@@ -2,11 +2,15 @@ package model
 
 import (
 	"time"
+
+	"github.com/skygeario/skygear-server/pkg/core/authn"
 )
 
 // Session is the API model of user sessions
 type Session struct {
-	ID string `json:"id"`
+	ID       string            `json:"id"`
+	Type     authn.SessionType `json:"type"`
+	ClientID string            `json:"client_id,omitempty"`
 
 	ACR string   `json:"acr,omitempty"`
 	AMR []string `json:"amr,omitempty"`
@@ -25,6 +29,8 @@ const SessionSchema = `
 	"type": "object",
 	"properties": {
 		"id": { "type": "string" },
+		"type": { "type": "string", "enum": ["idp", "offline_grant", "client"] },
+		"client_id": { "type": "string" },
 		"identity_type": { "type": "string" },
 		"identity_claims": { "type": "object" },
 		"acr": { "type": "string" },
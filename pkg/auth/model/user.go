@@ -24,6 +24,7 @@ import (
 type User struct {
 	ID               string           `json:"id,omitempty"`
 	CreatedAt        time.Time        `json:"created_at"`
+	UpdatedAt        time.Time        `json:"updated_at"`
 	LastLoginAt      *time.Time       `json:"last_login_at,omitempty"`
 	Verified         bool             `json:"is_verified"`
 	ManuallyVerified bool             `json:"is_manually_verified"`
@@ -41,6 +42,7 @@ const UserSchema = `
 	"properties": {
 		"id": { "type": "string" },
 		"created_at": { "type": "string" },
+		"updated_at": { "type": "string" },
 		"last_login_at": { "type": "string" },
 		"is_verified": { "type": "boolean" },
 		"is_manually_verified": { "type": "boolean" },
@@ -0,0 +1,101 @@
+package task
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	gotime "time"
+
+	"github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
+	"github.com/skygeario/skygear-server/pkg/auth/task/spec"
+	"github.com/skygeario/skygear-server/pkg/core/async"
+	"github.com/skygeario/skygear-server/pkg/core/config"
+)
+
+// DeliverEventsPollInterval is the interval at which pending events are
+// checked for delivery.
+const DeliverEventsPollInterval = 10 * gotime.Second
+
+func AttachDeliverEventsTask(
+	executor *async.Executor,
+	authDependency auth.DependencyMap,
+) {
+	executor.Register(spec.DeliverEventsTaskName, MakeTask(authDependency, newDeliverEventsTask))
+}
+
+type DeliverEventsTask struct {
+	DeliveryWorker *hook.DeliveryWorker
+}
+
+func (t *DeliverEventsTask) Run(ctx context.Context, param interface{}) (err error) {
+	return t.DeliveryWorker.DeliverPendingEvents()
+}
+
+// DeliverEventsPoller runs the deliver events task periodically for every
+// tracked tenant, so that events are delivered at their next attempt time
+// persisted in the outbox.
+//
+// Tenants are tracked when they are served, since tenant configurations
+// are provided per request when not in standalone mode, and the outbox
+// does not have them. Therefore events persisted before the instance
+// started are delivered only after the tenant serves a request, except in
+// standalone mode where the tenant is tracked on start.
+type DeliverEventsPoller struct {
+	Executor *async.Executor
+	Interval gotime.Duration
+
+	mutex   sync.Mutex
+	tenants map[string]*config.TenantConfiguration
+}
+
+// Track tracks the tenant, replacing its previously tracked configuration.
+func (p *DeliverEventsPoller) Track(tConfig *config.TenantConfiguration) {
+	if tConfig == nil || tConfig.AppID == "" {
+		return
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.tenants == nil {
+		p.tenants = map[string]*config.TenantConfiguration{}
+	}
+	p.tenants[tConfig.AppID] = tConfig
+}
+
+// Handle tracks tenants of the requests.
+func (p *DeliverEventsPoller) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p.Track(config.GetTenantConfig(r.Context()))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Run polls until the context is done.
+func (p *DeliverEventsPoller) Run(ctx context.Context) {
+	ticker := gotime.NewTicker(p.Interval)
+	defer ticker.Stop()
+
+	for {
+		p.poll()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *DeliverEventsPoller) poll() {
+	p.mutex.Lock()
+	tenants := make([]*config.TenantConfiguration, 0, len(p.tenants))
+	for _, tConfig := range p.tenants {
+		tenants = append(tenants, tConfig)
+	}
+	p.mutex.Unlock()
+
+	for _, tConfig := range tenants {
+		async.NewQueue(context.Background(), nil, tConfig, p.Executor).
+			Enqueue(async.TaskSpec{Name: spec.DeliverEventsTaskName})
+	}
+}
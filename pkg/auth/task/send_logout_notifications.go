@@ -0,0 +1,81 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	gotime "time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/skygeario/skygear-server/pkg/auth"
+	"github.com/skygeario/skygear-server/pkg/auth/task/spec"
+	"github.com/skygeario/skygear-server/pkg/core/async"
+	corehttp "github.com/skygeario/skygear-server/pkg/core/http"
+	"github.com/skygeario/skygear-server/pkg/core/logging"
+)
+
+const backChannelLogoutTimeout = 5 * gotime.Second
+
+func AttachSendLogoutNotificationsTask(
+	executor *async.Executor,
+	authDependency auth.DependencyMap,
+) {
+	executor.Register(spec.SendLogoutNotificationsTaskName, MakeTask(authDependency, newSendLogoutNotificationsTask))
+}
+
+type SendLogoutNotificationsTask struct {
+	LoggerFactory logging.Factory
+}
+
+func (t *SendLogoutNotificationsTask) Run(ctx context.Context, param interface{}) (err error) {
+	taskParam := param.(spec.SendLogoutNotificationsTaskParam)
+	logger := t.LoggerFactory.NewLogger("logoutnotifications")
+
+	noRedirect := func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	client := &http.Client{
+		Timeout:       backChannelLogoutTimeout,
+		CheckRedirect: noRedirect,
+	}
+	// Logout URIs of registered clients must not reach internal services.
+	publicClient := &http.Client{
+		Timeout:       backChannelLogoutTimeout,
+		CheckRedirect: noRedirect,
+		Transport: &http.Transport{
+			DialContext: corehttp.NewPublicNetworkDialer(backChannelLogoutTimeout).DialContext,
+		},
+	}
+	for _, req := range taskParam.BackChannelLogoutRequests {
+		c := client
+		if req.PublicNetworkOnly {
+			c = publicClient
+		}
+		err := sendBackChannelLogout(c, req)
+		if err != nil {
+			logger.WithError(err).WithFields(logrus.Fields{
+				"uri": req.URI,
+			}).Error("failed to send back-channel logout")
+		}
+	}
+
+	return
+}
+
+func sendBackChannelLogout(client *http.Client, req spec.BackChannelLogoutRequest) error {
+	form := url.Values{}
+	form.Set("logout_token", req.LogoutToken)
+	resp, err := client.Post(req.URI, "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -45,3 +45,25 @@ type SendMessagesTaskParam struct {
 	EmailMessages []mail.SendOptions
 	SMSMessages   []sms.SendOptions
 }
+
+const (
+	DeliverEventsTaskName = "DeliverEventsTask"
+)
+
+const (
+	SendLogoutNotificationsTaskName = "SendLogoutNotificationsTask"
+)
+
+// BackChannelLogoutRequest is a logout token to be sent to the back-channel
+// logout URI of a client.
+type BackChannelLogoutRequest struct {
+	URI         string
+	LogoutToken string
+	// PublicNetworkOnly indicates the URI must not be in private networks,
+	// since it is provided by a dynamically registered client.
+	PublicNetworkOnly bool
+}
+
+type SendLogoutNotificationsTaskParam struct {
+	BackChannelLogoutRequests []BackChannelLogoutRequest
+}
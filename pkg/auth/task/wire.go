@@ -41,3 +41,21 @@ func newSendMessagesTask(ctx context.Context, m pkg.DependencyMap) async.Task {
 	)
 	return nil
 }
+
+func newDeliverEventsTask(ctx context.Context, m pkg.DependencyMap) async.Task {
+	wire.Build(
+		pkg.CommonDependencySet,
+		wire.Struct(new(DeliverEventsTask), "*"),
+		wire.Bind(new(async.Task), new(*DeliverEventsTask)),
+	)
+	return nil
+}
+
+func newSendLogoutNotificationsTask(ctx context.Context, m pkg.DependencyMap) async.Task {
+	wire.Build(
+		pkg.CommonDependencySet,
+		wire.Struct(new(SendLogoutNotificationsTask), "*"),
+		wire.Bind(new(async.Task), new(*SendLogoutNotificationsTask)),
+	)
+	return nil
+}
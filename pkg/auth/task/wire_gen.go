@@ -9,7 +9,9 @@ import (
 	"context"
 	"github.com/skygeario/skygear-server/pkg/auth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/authenticator/password"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/anonymous"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/ldap"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/loginid"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/oauth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/provider"
@@ -46,16 +48,26 @@ func newVerifyCodeSendTask(ctx context.Context, m auth.DependencyMap) async.Task
 	loginidProvider := loginid.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration, checker, normalizerFactory)
 	oauthProvider := oauth.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
 	anonymousProvider := anonymous.ProvideProvider(sqlBuilder, sqlExecutor)
-	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider)
+	ldapProvider := ldap.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider, ldapProvider)
+	userStore := user.ProvideStore(sqlBuilderFactory, sqlExecutor, timeProvider)
 	queries := &user.Queries{
 		AuthInfos:    store,
 		UserProfiles: userprofileStore,
 		Identities:   providerProvider,
 		Time:         timeProvider,
+		Store:        userStore,
 	}
-	userverifyProvider := userverify.ProvideProvider(tenantConfiguration, timeProvider, sqlBuilder, sqlExecutor)
+	userverifyStore := userverify.ProvideStore(sqlBuilder, sqlExecutor)
+	hookStore := hook.ProvideStore(tenantConfiguration, sqlBuilder, sqlExecutor)
 	txContext := db.ProvideTxContext(ctx, tenantConfiguration)
+	attemptStore := hook.ProvideAttemptStore(ctx, tenantConfiguration, sqlBuilder)
 	factory := logging.ProvideLoggerFactory(ctx, tenantConfiguration)
+	deliverer := hook.ProvideDeliverer(tenantConfiguration, timeProvider, store, userprofileStore, loginidProvider, attemptStore, factory)
+	executor := auth.ProvideTaskExecutor(m)
+	queue := async.ProvideTaskQueue(ctx, txContext, tenantConfiguration, executor)
+	hookProvider := hook.ProvideHookProvider(ctx, tenantConfiguration, sqlBuilder, hookStore, txContext, timeProvider, queries, deliverer, queue, executor, factory)
+	userverifyProvider := userverify.ProvideProvider(tenantConfiguration, timeProvider, userverifyStore, queries, hookProvider)
 	verifyCodeSendTask := &VerifyCodeSendTask{
 		CodeSenderFactory:        codeSenderFactory,
 		Users:                    queries,
@@ -97,3 +109,37 @@ func newSendMessagesTask(ctx context.Context, m auth.DependencyMap) async.Task {
 	}
 	return sendMessagesTask
 }
+
+func newDeliverEventsTask(ctx context.Context, m auth.DependencyMap) async.Task {
+	tenantConfiguration := auth.ProvideTenantConfig(ctx, m)
+	txContext := db.ProvideTxContext(ctx, tenantConfiguration)
+	sqlBuilderFactory := db.ProvideSQLBuilderFactory(tenantConfiguration)
+	sqlBuilder := auth.ProvideAuthSQLBuilder(sqlBuilderFactory)
+	sqlExecutor := db.ProvideSQLExecutor(ctx, tenantConfiguration)
+	store := hook.ProvideStore(tenantConfiguration, sqlBuilder, sqlExecutor)
+	timeProvider := time.NewProvider()
+	authinfoStore := pq.ProvideStore(sqlBuilderFactory, sqlExecutor)
+	userprofileStore := userprofile.ProvideStore(timeProvider, sqlBuilder, sqlExecutor)
+	reservedNameChecker := auth.ProvideReservedNameChecker(m)
+	typeCheckerFactory := loginid.ProvideTypeCheckerFactory(tenantConfiguration, reservedNameChecker)
+	checker := loginid.ProvideChecker(tenantConfiguration, typeCheckerFactory)
+	normalizerFactory := loginid.ProvideNormalizerFactory(tenantConfiguration)
+	loginidProvider := loginid.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration, checker, normalizerFactory)
+	attemptStore := hook.ProvideAttemptStore(ctx, tenantConfiguration, sqlBuilder)
+	factory := logging.ProvideLoggerFactory(ctx, tenantConfiguration)
+	deliverer := hook.ProvideDeliverer(tenantConfiguration, timeProvider, authinfoStore, userprofileStore, loginidProvider, attemptStore, factory)
+	deliveryWorker := hook.ProvideDeliveryWorker(tenantConfiguration, txContext, store, deliverer, timeProvider, factory)
+	deliverEventsTask := &DeliverEventsTask{
+		DeliveryWorker: deliveryWorker,
+	}
+	return deliverEventsTask
+}
+
+func newSendLogoutNotificationsTask(ctx context.Context, m auth.DependencyMap) async.Task {
+	tenantConfiguration := auth.ProvideTenantConfig(ctx, m)
+	factory := logging.ProvideLoggerFactory(ctx, tenantConfiguration)
+	sendLogoutNotificationsTask := &SendLogoutNotificationsTask{
+		LoggerFactory: factory,
+	}
+	return sendLogoutNotificationsTask
+}
@@ -65,6 +65,10 @@ func NewEngineWithConfig(
 
 	e.Register(webapp.TemplateAuthUISettingsHTML)
 	e.Register(webapp.TemplateAuthUISettingsIdentityHTML)
+	e.Register(webapp.TemplateAuthUISettingsAuthorizationsHTML)
+
+	e.Register(webapp.TemplateAuthUIDeviceHTML)
+	e.Register(webapp.TemplateAuthUIConsentHTML)
 
 	e.Register(forgotpassword.TemplateForgotPasswordEmailTXT)
 	e.Register(forgotpassword.TemplateForgotPasswordEmailHTML)
@@ -11,17 +11,21 @@ import (
 	redis2 "github.com/skygeario/skygear-server/pkg/auth/dependency/auth/redis"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/hook"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/anonymous"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/ldap"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/loginid"
 	oauth2 "github.com/skygeario/skygear-server/pkg/auth/dependency/identity/oauth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/identity/provider"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/pq"
 	redis3 "github.com/skygeario/skygear-server/pkg/auth/dependency/oauth/redis"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/oidc"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/session"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/session/redis"
+	"github.com/skygeario/skygear-server/pkg/auth/dependency/urlprefix"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/user"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/userprofile"
 	"github.com/skygeario/skygear-server/pkg/auth/dependency/webapp"
+	"github.com/skygeario/skygear-server/pkg/core/async"
 	"github.com/skygeario/skygear-server/pkg/core/auth"
 	pq2 "github.com/skygeario/skygear-server/pkg/core/auth/authinfo/pq"
 	"github.com/skygeario/skygear-server/pkg/core/db"
@@ -61,19 +65,20 @@ func NewSessionMiddleware(r *http.Request, m DependencyMap) mux.MiddlewareFunc {
 	sqlBuilderFactory := db.ProvideSQLBuilderFactory(tenantConfiguration)
 	sqlBuilder := ProvideAuthSQLBuilder(sqlBuilderFactory)
 	sqlExecutor := db.ProvideSQLExecutor(context, tenantConfiguration)
+	clientStore := &pq.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	clientResolver := oauth.ProvideClientResolver(tenantConfiguration, clientStore)
 	authorizationStore := &pq.AuthorizationStore{
 		SQLBuilder:  sqlBuilder,
 		SQLExecutor: sqlExecutor,
 	}
 	grantStore := redis3.ProvideGrantStore(context, factory, tenantConfiguration, sqlBuilder, sqlExecutor, provider)
 	resolverSessionProvider := oauth.ProvideResolverProvider(sessionProvider)
-	oauthResolver := &oauth.Resolver{
-		Authorizations: authorizationStore,
-		AccessGrants:   grantStore,
-		OfflineGrants:  grantStore,
-		Sessions:       resolverSessionProvider,
-		Time:           provider,
-	}
+	urlprefixProvider := urlprefix.NewProvider(r)
+	jwtAccessTokenCodec := oauth.ProvideJWTAccessTokenCodec(tenantConfiguration, urlprefixProvider, provider)
+	oauthResolver := oauth.ProvideResolver(clientResolver, authorizationStore, grantStore, grantStore, resolverSessionProvider, jwtAccessTokenCodec, grantStore, provider)
 	authAccessEventProvider := auth2.AccessEventProvider{
 		Store: eventStore,
 	}
@@ -117,7 +122,15 @@ func NewStateMiddleware(r *http.Request, m DependencyMap) mux.MiddlewareFunc {
 func NewClientIDMiddleware(r *http.Request, m DependencyMap) mux.MiddlewareFunc {
 	context := ProvideContext(r)
 	tenantConfiguration := ProvideTenantConfig(context, m)
-	middlewareFunc := webapp.ProvideClientIDMiddleware(tenantConfiguration)
+	sqlBuilderFactory := db.ProvideSQLBuilderFactory(tenantConfiguration)
+	sqlBuilder := ProvideAuthSQLBuilder(sqlBuilderFactory)
+	sqlExecutor := db.ProvideSQLExecutor(context, tenantConfiguration)
+	clientStore := &pq.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
+	}
+	clientResolver := oauth.ProvideClientResolver(tenantConfiguration, clientStore)
+	middlewareFunc := webapp.ProvideClientIDMiddleware(clientResolver)
 	return middlewareFunc
 }
 
@@ -137,16 +150,24 @@ func newSessionManager(r *http.Request, m DependencyMap) *auth2.SessionManager {
 	loginidProvider := loginid.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider, tenantConfiguration, checker, normalizerFactory)
 	oauthProvider := oauth2.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
 	anonymousProvider := anonymous.ProvideProvider(sqlBuilder, sqlExecutor)
-	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider)
+	ldapProvider := ldap.ProvideProvider(sqlBuilder, sqlExecutor, timeProvider)
+	providerProvider := provider.ProvideProvider(tenantConfiguration, loginidProvider, oauthProvider, anonymousProvider, ldapProvider)
+	userStore := user.ProvideStore(sqlBuilderFactory, sqlExecutor, timeProvider)
 	queries := &user.Queries{
 		AuthInfos:    store,
 		UserProfiles: userprofileStore,
 		Identities:   providerProvider,
 		Time:         timeProvider,
+		Store:        userStore,
 	}
+	hookStore := hook.ProvideStore(tenantConfiguration, sqlBuilder, sqlExecutor)
 	txContext := db.ProvideTxContext(context, tenantConfiguration)
+	attemptStore := hook.ProvideAttemptStore(context, tenantConfiguration, sqlBuilder)
 	factory := logging.ProvideLoggerFactory(context, tenantConfiguration)
-	hookProvider := hook.ProvideHookProvider(context, sqlBuilder, sqlExecutor, tenantConfiguration, txContext, timeProvider, queries, store, userprofileStore, loginidProvider, factory)
+	deliverer := hook.ProvideDeliverer(tenantConfiguration, timeProvider, store, userprofileStore, loginidProvider, attemptStore, factory)
+	executor := ProvideTaskExecutor(m)
+	queue := async.ProvideTaskQueue(context, txContext, tenantConfiguration, executor)
+	hookProvider := hook.ProvideHookProvider(context, tenantConfiguration, sqlBuilder, hookStore, txContext, timeProvider, queries, deliverer, queue, executor, factory)
 	sessionStore := redis.ProvideStore(context, tenantConfiguration, timeProvider, factory)
 	insecureCookieConfig := ProvideSessionInsecureCookieConfig(m)
 	cookieConfiguration := session.ProvideSessionCookieConfiguration(r, insecureCookieConfig, tenantConfiguration)
@@ -156,12 +177,15 @@ func newSessionManager(r *http.Request, m DependencyMap) *auth2.SessionManager {
 		Store: grantStore,
 		Time:  timeProvider,
 	}
-	authSessionManager := &auth2.SessionManager{
-		Users:               queries,
-		Hooks:               hookProvider,
-		IDPSessions:         manager,
-		AccessTokenSessions: sessionManager,
+	eventStore := redis2.ProvideEventStore(context, tenantConfiguration)
+	urlprefixProvider := urlprefix.NewProvider(r)
+	clientStore := &pq.ClientStore{
+		SQLBuilder:  sqlBuilder,
+		SQLExecutor: sqlExecutor,
 	}
+	clientResolver := oauth.ProvideClientResolver(tenantConfiguration, clientStore)
+	logoutNotifier := oidc.ProvideLogoutNotifier(tenantConfiguration, urlprefixProvider, clientResolver, queue, timeProvider)
+	authSessionManager := auth2.ProvideSessionManager(queries, hookProvider, manager, sessionManager, eventStore, logoutNotifier, factory)
 	return authSessionManager
 }
 
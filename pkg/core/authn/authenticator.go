@@ -8,6 +8,7 @@ const (
 	AuthenticatorTypeOOB          AuthenticatorType = "oob_otp"
 	AuthenticatorTypeRecoveryCode AuthenticatorType = "recovery_code"
 	AuthenticatorTypeBearerToken  AuthenticatorType = "bearer_token"
+	AuthenticatorTypeLDAP         AuthenticatorType = "ldap"
 )
 
 type AuthenticatorOOBChannel string
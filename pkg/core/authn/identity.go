@@ -6,4 +6,5 @@ const (
 	IdentityTypeLoginID   IdentityType = "login_id"
 	IdentityTypeOAuth     IdentityType = "oauth"
 	IdentityTypeAnonymous IdentityType = "anonymous"
+	IdentityTypeLDAP      IdentityType = "ldap"
 )
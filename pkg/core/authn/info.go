@@ -15,6 +15,10 @@ type Info struct {
 
 	SessionACR string
 	SessionAMR []string
+
+	// ClientID is set only if the session represents an OAuth client
+	// itself (i.e. client credentials grant), instead of a user.
+	ClientID string
 }
 
 var _ Session = &Info{}
@@ -31,8 +35,16 @@ func NewAuthnInfo(attrs *Attrs, user *UserInfo, isAnonymous bool) *Info {
 	}
 }
 
+func NewClientAuthnInfo(clientID string) *Info {
+	return &Info{
+		IsValid:  true,
+		ClientID: clientID,
+	}
+}
+
 const (
 	headerSessionValid  = "X-Skygear-Session-Valid"
+	headerClientID      = "X-Skygear-Client-Id"
 	headerUserID        = "X-Skygear-User-Id"
 	headerUserVerified  = "X-Skygear-User-Verified"
 	headerUserDisabled  = "X-Skygear-User-Disabled"
@@ -51,6 +63,11 @@ func (i *Info) PopulateHeaders(rw http.ResponseWriter) {
 		return
 	}
 
+	if i.IsClient() {
+		rw.Header().Set(headerClientID, i.ClientID)
+		return
+	}
+
 	rw.Header().Set(headerUserID, i.UserID)
 	rw.Header().Set(headerUserVerified, strconv.FormatBool(i.UserVerified))
 	rw.Header().Set(headerUserDisabled, strconv.FormatBool(i.UserDisabled))
@@ -72,7 +89,16 @@ func (i *Info) AuthnAttrs() *Attrs {
 	}
 }
 
+// IsClient returns whether the session represents an OAuth client instead of
+// a user.
+func (i *Info) IsClient() bool {
+	return i.ClientID != ""
+}
+
 func (i *Info) User() *UserInfo {
+	if i.IsClient() {
+		return nil
+	}
 	return &UserInfo{
 		ID:         i.UserID,
 		IsDisabled: i.UserDisabled,
@@ -91,6 +117,11 @@ func ParseHeaders(r *http.Request) (*Info, error) {
 		return i, nil
 	}
 
+	if clientID := r.Header.Get(headerClientID); clientID != "" {
+		i.ClientID = clientID
+		return i, nil
+	}
+
 	i.UserID = r.Header.Get(headerUserID)
 	if i.UserVerified, err = strconv.ParseBool(r.Header.Get(headerUserVerified)); err != nil {
 		return nil, err
@@ -42,6 +42,22 @@ func TestAuthnInfo(t *testing.T) {
 				So(ii, ShouldResemble, i)
 			})
 
+			Convey("client auth", func() {
+				var i *authn.Info = authn.NewClientAuthnInfo("client-id")
+
+				i.PopulateHeaders(rw)
+				So(rw.Header(), ShouldResemble, http.Header{
+					"X-Skygear-Session-Valid": []string{"true"},
+					"X-Skygear-Client-Id":     []string{"client-id"},
+				})
+
+				r := &http.Request{Header: rw.Header()}
+				ii, err := authn.ParseHeaders(r)
+				So(err, ShouldBeNil)
+				So(ii, ShouldResemble, i)
+				So(ii.User(), ShouldBeNil)
+			})
+
 			Convey("valid auth", func() {
 				var i *authn.Info = &authn.Info{
 					IsValid:       true,
@@ -7,6 +7,7 @@ type AuthenticationConfiguration struct {
 	SecondaryAuthenticators     []string                    `json:"secondary_authenticators" yaml:"secondary_authenticators" msg:"secondary_authenticators"`
 	SecondaryAuthenticationMode SecondaryAuthenticationMode `json:"secondary_authentication_mode,omitempty" yaml:"secondary_authentication_mode" msg:"secondary_authentication_mode"`
 	Secret                      string                      `json:"secret,omitempty" yaml:"secret" msg:"secret"`
+	AllowSelfDeletion           bool                        `json:"allow_self_deletion,omitempty" yaml:"allow_self_deletion" msg:"allow_self_deletion"`
 }
 
 type SecondaryAuthenticationMode string
@@ -97,6 +97,12 @@ func (z *AuthenticationConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "Secret")
 				return
 			}
+		case "allow_self_deletion":
+			z.AllowSelfDeletion, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "AllowSelfDeletion")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -110,9 +116,9 @@ func (z *AuthenticationConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *AuthenticationConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 5
+	// map header, size 6
 	// write "identities"
-	err = en.Append(0x85, 0xaa, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73)
+	err = en.Append(0x86, 0xaa, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73)
 	if err != nil {
 		return
 	}
@@ -182,15 +188,25 @@ func (z *AuthenticationConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "Secret")
 		return
 	}
+	// write "allow_self_deletion"
+	err = en.Append(0xb3, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x73, 0x65, 0x6c, 0x66, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e)
+	if err != nil {
+		return
+	}
+	err = en.WriteBool(z.AllowSelfDeletion)
+	if err != nil {
+		err = msgp.WrapError(err, "AllowSelfDeletion")
+		return
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *AuthenticationConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 5
+	// map header, size 6
 	// string "identities"
-	o = append(o, 0x85, 0xaa, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73)
+	o = append(o, 0x86, 0xaa, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x69, 0x65, 0x73)
 	o = msgp.AppendArrayHeader(o, uint32(len(z.Identities)))
 	for za0001 := range z.Identities {
 		o = msgp.AppendString(o, z.Identities[za0001])
@@ -213,6 +229,9 @@ func (z *AuthenticationConfiguration) MarshalMsg(b []byte) (o []byte, err error)
 	// string "secret"
 	o = append(o, 0xa6, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74)
 	o = msgp.AppendString(o, z.Secret)
+	// string "allow_self_deletion"
+	o = append(o, 0xb3, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x73, 0x65, 0x6c, 0x66, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x69, 0x6f, 0x6e)
+	o = msgp.AppendBool(o, z.AllowSelfDeletion)
 	return
 }
 
@@ -307,6 +326,12 @@ func (z *AuthenticationConfiguration) UnmarshalMsg(bts []byte) (o []byte, err er
 				err = msgp.WrapError(err, "Secret")
 				return
 			}
+		case "allow_self_deletion":
+			z.AllowSelfDeletion, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "AllowSelfDeletion")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -333,7 +358,7 @@ func (z *AuthenticationConfiguration) Msgsize() (s int) {
 	for za0003 := range z.SecondaryAuthenticators {
 		s += msgp.StringPrefixSize + len(z.SecondaryAuthenticators[za0003])
 	}
-	s += 30 + msgp.StringPrefixSize + len(string(z.SecondaryAuthenticationMode)) + 7 + msgp.StringPrefixSize + len(z.Secret)
+	s += 30 + msgp.StringPrefixSize + len(string(z.SecondaryAuthenticationMode)) + 7 + msgp.StringPrefixSize + len(z.Secret) + 20 + msgp.BoolSize
 	return
 }
 
@@ -1,6 +1,12 @@
 package config
 
-import "github.com/skygeario/skygear-server/pkg/core/auth/metadata"
+import (
+	"crypto/x509"
+	"encoding/pem"
+
+	"github.com/skygeario/skygear-server/pkg/core/auth/metadata"
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+)
 
 //go:generate msgp -tests=false
 
@@ -8,6 +14,7 @@ type IdentityConfiguration struct {
 	LoginID    *LoginIDConfiguration          `json:"login_id,omitempty" yaml:"login_id" msg:"login_id" default_zero_value:"true"`
 	OAuth      *OAuthConfiguration            `json:"oauth,omitempty" yaml:"oauth" msg:"oauth" default_zero_value:"true"`
 	OnConflict *IdentityConflictConfiguration `json:"on_conflict,omitempty" yaml:"on_conflict" msg:"on_conflict" default_zero_value:"true"`
+	LDAP       *LDAPConfiguration             `json:"ldap,omitempty" yaml:"ldap" msg:"ldap" default_zero_value:"true"`
 }
 
 type LoginIDConfiguration struct {
@@ -81,6 +88,10 @@ const (
 	OAuthProviderTypeLinkedIn  OAuthProviderType = "linkedin"
 	OAuthProviderTypeAzureADv2 OAuthProviderType = "azureadv2"
 	OAuthProviderTypeApple     OAuthProviderType = "apple"
+	OAuthProviderTypeOIDC      OAuthProviderType = "oidc"
+	OAuthProviderTypeGitHub    OAuthProviderType = "github"
+	OAuthProviderTypeGitLab    OAuthProviderType = "gitlab"
+	OAuthProviderTypeSAML      OAuthProviderType = "saml"
 )
 
 type OAuthProviderConfiguration struct {
@@ -94,6 +105,88 @@ type OAuthProviderConfiguration struct {
 	// KeyID and TeamID are specific to apple
 	KeyID  string `json:"key_id,omitempty" yaml:"key_id" msg:"key_id"`
 	TeamID string `json:"team_id,omitempty" yaml:"team_id" msg:"team_id"`
+	// DiscoveryURL is specific to oidc
+	DiscoveryURL string `json:"discovery_url,omitempty" yaml:"discovery_url" msg:"discovery_url"`
+	// DisplayName and Claims are specific to oidc and saml
+	DisplayName string                            `json:"display_name,omitempty" yaml:"display_name" msg:"display_name"`
+	Claims      *OAuthProviderClaimsConfiguration `json:"claims,omitempty" yaml:"claims,omitempty" msg:"claims"`
+	// BaseURL is specific to gitlab
+	BaseURL string `json:"base_url,omitempty" yaml:"base_url" msg:"base_url"`
+	// IdPEntityID, IdPSSOURL and IdPCertificate are specific to saml
+	IdPEntityID    string `json:"idp_entity_id,omitempty" yaml:"idp_entity_id" msg:"idp_entity_id"`
+	IdPSSOURL      string `json:"idp_sso_url,omitempty" yaml:"idp_sso_url" msg:"idp_sso_url"`
+	IdPCertificate string `json:"idp_certificate,omitempty" yaml:"idp_certificate" msg:"idp_certificate"`
+}
+
+// IdPCertificates parses the PEM encoded signing certificates of SAML
+// identity provider. Multiple certificates may be configured to rotate them.
+func (c OAuthProviderConfiguration) IdPCertificates() ([]*x509.Certificate, error) {
+	return parseCertificates(c.IdPCertificate)
+}
+
+// parseCertificates parses the PEM encoded certificates.
+func parseCertificates(data string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := []byte(data)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no certificate found")
+	}
+	return certs, nil
+}
+
+// OAuthProviderClaimsConfiguration maps user info fields to claims of the
+// ID token, or attributes of the SAML assertion.
+type OAuthProviderClaimsConfiguration struct {
+	ID    string `json:"id,omitempty" yaml:"id,omitempty" msg:"id"`
+	Email string `json:"email,omitempty" yaml:"email,omitempty" msg:"email"`
+}
+
+type LDAPConfiguration struct {
+	URL      string `json:"url,omitempty" yaml:"url" msg:"url"`
+	StartTLS bool   `json:"start_tls,omitempty" yaml:"start_tls" msg:"start_tls"`
+	// CACertificate is the PEM encoded certificates used to verify the
+	// server certificate instead of the system roots.
+	CACertificate string `json:"ca_certificate,omitempty" yaml:"ca_certificate" msg:"ca_certificate"`
+	// BindDN and BindPassword are the credentials of the service account
+	// used to search for users. Anonymous bind is used if BindDN is empty.
+	BindDN       string `json:"bind_dn,omitempty" yaml:"bind_dn" msg:"bind_dn"`
+	BindPassword string `json:"bind_password,omitempty" yaml:"bind_password" msg:"bind_password"`
+	SearchBase   string `json:"search_base,omitempty" yaml:"search_base" msg:"search_base"`
+	// Filter is the search filter to find the user; {username} is replaced
+	// with the escaped username.
+	Filter      string                       `json:"filter,omitempty" yaml:"filter" msg:"filter"`
+	DisplayName string                       `json:"display_name,omitempty" yaml:"display_name" msg:"display_name"`
+	Attributes  *LDAPAttributesConfiguration `json:"attributes,omitempty" yaml:"attributes" msg:"attributes" default_zero_value:"true"`
+}
+
+// CACertificates parses the configured CA certificates.
+func (c *LDAPConfiguration) CACertificates() ([]*x509.Certificate, error) {
+	return parseCertificates(c.CACertificate)
+}
+
+// LDAPAttributesConfiguration maps LDAP attributes to the identity.
+type LDAPAttributesConfiguration struct {
+	// ID is the attribute that uniquely identifies the user in directory.
+	ID    string `json:"id,omitempty" yaml:"id" msg:"id"`
+	Email string `json:"email,omitempty" yaml:"email" msg:"email"`
+	// Metadata maps user metadata keys to LDAP attributes. The mapped
+	// attributes are synced into user metadata on each login.
+	Metadata map[string]string `json:"metadata,omitempty" yaml:"metadata" msg:"metadata"`
 }
 
 type IdentityConflictConfiguration struct {
@@ -105,6 +105,24 @@ func (z *IdentityConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 					}
 				}
 			}
+		case "ldap":
+			if dc.IsNil() {
+				err = dc.ReadNil()
+				if err != nil {
+					err = msgp.WrapError(err, "LDAP")
+					return
+				}
+				z.LDAP = nil
+			} else {
+				if z.LDAP == nil {
+					z.LDAP = new(LDAPConfiguration)
+				}
+				err = z.LDAP.DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "LDAP")
+					return
+				}
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -118,9 +136,9 @@ func (z *IdentityConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *IdentityConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 3
+	// map header, size 4
 	// write "login_id"
-	err = en.Append(0x83, 0xa8, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x5f, 0x69, 0x64)
+	err = en.Append(0x84, 0xa8, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x5f, 0x69, 0x64)
 	if err != nil {
 		return
 	}
@@ -176,15 +194,32 @@ func (z *IdentityConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
 			return
 		}
 	}
+	// write "ldap"
+	err = en.Append(0xa4, 0x6c, 0x64, 0x61, 0x70)
+	if err != nil {
+		return
+	}
+	if z.LDAP == nil {
+		err = en.WriteNil()
+		if err != nil {
+			return
+		}
+	} else {
+		err = z.LDAP.EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "LDAP")
+			return
+		}
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *IdentityConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 3
+	// map header, size 4
 	// string "login_id"
-	o = append(o, 0x83, 0xa8, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x5f, 0x69, 0x64)
+	o = append(o, 0x84, 0xa8, 0x6c, 0x6f, 0x67, 0x69, 0x6e, 0x5f, 0x69, 0x64)
 	if z.LoginID == nil {
 		o = msgp.AppendNil(o)
 	} else {
@@ -215,6 +250,17 @@ func (z *IdentityConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
 		o = append(o, 0x81, 0xa9, 0x50, 0x72, 0x6f, 0x6d, 0x6f, 0x74, 0x69, 0x6f, 0x6e)
 		o = msgp.AppendString(o, string(z.OnConflict.Promotion))
 	}
+	// string "ldap"
+	o = append(o, 0xa4, 0x6c, 0x64, 0x61, 0x70)
+	if z.LDAP == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		o, err = z.LDAP.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "LDAP")
+			return
+		}
+	}
 	return
 }
 
@@ -314,6 +360,23 @@ func (z *IdentityConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 					}
 				}
 			}
+		case "ldap":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.LDAP = nil
+			} else {
+				if z.LDAP == nil {
+					z.LDAP = new(LDAPConfiguration)
+				}
+				bts, err = z.LDAP.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "LDAP")
+					return
+				}
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -346,6 +409,12 @@ func (z *IdentityConfiguration) Msgsize() (s int) {
 	} else {
 		s += 1 + 10 + msgp.StringPrefixSize + len(string(z.OnConflict.Promotion))
 	}
+	s += 5
+	if z.LDAP == nil {
+		s += msgp.NilSize
+	} else {
+		s += z.LDAP.Msgsize()
+	}
 	return
 }
 
@@ -461,7 +530,7 @@ func (z IdentityConflictConfiguration) Msgsize() (s int) {
 }
 
 // DecodeMsg implements msgp.Decodable
-func (z *LoginIDConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
+func (z *LDAPAttributesConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -478,42 +547,47 @@ func (z *LoginIDConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "types":
-			if dc.IsNil() {
-				err = dc.ReadNil()
-				if err != nil {
-					err = msgp.WrapError(err, "Types")
-					return
-				}
-				z.Types = nil
-			} else {
-				if z.Types == nil {
-					z.Types = new(LoginIDTypesConfiguration)
-				}
-				err = z.Types.DecodeMsg(dc)
-				if err != nil {
-					err = msgp.WrapError(err, "Types")
-					return
-				}
+		case "id":
+			z.ID, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "ID")
+				return
 			}
-		case "keys":
+		case "email":
+			z.Email, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Email")
+				return
+			}
+		case "metadata":
 			var zb0002 uint32
-			zb0002, err = dc.ReadArrayHeader()
+			zb0002, err = dc.ReadMapHeader()
 			if err != nil {
-				err = msgp.WrapError(err, "Keys")
+				err = msgp.WrapError(err, "Metadata")
 				return
 			}
-			if cap(z.Keys) >= int(zb0002) {
-				z.Keys = (z.Keys)[:zb0002]
-			} else {
-				z.Keys = make([]LoginIDKeyConfiguration, zb0002)
+			if z.Metadata == nil {
+				z.Metadata = make(map[string]string, zb0002)
+			} else if len(z.Metadata) > 0 {
+				for key := range z.Metadata {
+					delete(z.Metadata, key)
+				}
 			}
-			for za0001 := range z.Keys {
-				err = z.Keys[za0001].DecodeMsg(dc)
+			for zb0002 > 0 {
+				zb0002--
+				var za0001 string
+				var za0002 string
+				za0001, err = dc.ReadString()
 				if err != nil {
-					err = msgp.WrapError(err, "Keys", za0001)
+					err = msgp.WrapError(err, "Metadata")
+					return
+				}
+				za0002, err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "Metadata", za0001)
 					return
 				}
+				z.Metadata[za0001] = za0002
 			}
 		default:
 			err = dc.Skip()
@@ -527,39 +601,47 @@ func (z *LoginIDConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z *LoginIDConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 2
-	// write "types"
-	err = en.Append(0x82, 0xa5, 0x74, 0x79, 0x70, 0x65, 0x73)
+func (z *LDAPAttributesConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
+	// write "id"
+	err = en.Append(0x83, 0xa2, 0x69, 0x64)
 	if err != nil {
 		return
 	}
-	if z.Types == nil {
-		err = en.WriteNil()
-		if err != nil {
-			return
-		}
-	} else {
-		err = z.Types.EncodeMsg(en)
-		if err != nil {
-			err = msgp.WrapError(err, "Types")
-			return
-		}
+	err = en.WriteString(z.ID)
+	if err != nil {
+		err = msgp.WrapError(err, "ID")
+		return
 	}
-	// write "keys"
-	err = en.Append(0xa4, 0x6b, 0x65, 0x79, 0x73)
+	// write "email"
+	err = en.Append(0xa5, 0x65, 0x6d, 0x61, 0x69, 0x6c)
 	if err != nil {
 		return
 	}
-	err = en.WriteArrayHeader(uint32(len(z.Keys)))
+	err = en.WriteString(z.Email)
 	if err != nil {
-		err = msgp.WrapError(err, "Keys")
+		err = msgp.WrapError(err, "Email")
 		return
 	}
-	for za0001 := range z.Keys {
-		err = z.Keys[za0001].EncodeMsg(en)
+	// write "metadata"
+	err = en.Append(0xa8, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61)
+	if err != nil {
+		return
+	}
+	err = en.WriteMapHeader(uint32(len(z.Metadata)))
+	if err != nil {
+		err = msgp.WrapError(err, "Metadata")
+		return
+	}
+	for za0001, za0002 := range z.Metadata {
+		err = en.WriteString(za0001)
 		if err != nil {
-			err = msgp.WrapError(err, "Keys", za0001)
+			err = msgp.WrapError(err, "Metadata")
+			return
+		}
+		err = en.WriteString(za0002)
+		if err != nil {
+			err = msgp.WrapError(err, "Metadata", za0001)
 			return
 		}
 	}
@@ -567,35 +649,27 @@ func (z *LoginIDConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z *LoginIDConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *LDAPAttributesConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 2
-	// string "types"
-	o = append(o, 0x82, 0xa5, 0x74, 0x79, 0x70, 0x65, 0x73)
-	if z.Types == nil {
-		o = msgp.AppendNil(o)
-	} else {
-		o, err = z.Types.MarshalMsg(o)
-		if err != nil {
-			err = msgp.WrapError(err, "Types")
-			return
-		}
-	}
-	// string "keys"
-	o = append(o, 0xa4, 0x6b, 0x65, 0x79, 0x73)
-	o = msgp.AppendArrayHeader(o, uint32(len(z.Keys)))
-	for za0001 := range z.Keys {
-		o, err = z.Keys[za0001].MarshalMsg(o)
-		if err != nil {
-			err = msgp.WrapError(err, "Keys", za0001)
-			return
-		}
+	// map header, size 3
+	// string "id"
+	o = append(o, 0x83, 0xa2, 0x69, 0x64)
+	o = msgp.AppendString(o, z.ID)
+	// string "email"
+	o = append(o, 0xa5, 0x65, 0x6d, 0x61, 0x69, 0x6c)
+	o = msgp.AppendString(o, z.Email)
+	// string "metadata"
+	o = append(o, 0xa8, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61)
+	o = msgp.AppendMapHeader(o, uint32(len(z.Metadata)))
+	for za0001, za0002 := range z.Metadata {
+		o = msgp.AppendString(o, za0001)
+		o = msgp.AppendString(o, za0002)
 	}
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *LoginIDConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *LDAPAttributesConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -612,41 +686,47 @@ func (z *LoginIDConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "types":
-			if msgp.IsNil(bts) {
-				bts, err = msgp.ReadNilBytes(bts)
-				if err != nil {
-					return
-				}
-				z.Types = nil
-			} else {
-				if z.Types == nil {
-					z.Types = new(LoginIDTypesConfiguration)
-				}
-				bts, err = z.Types.UnmarshalMsg(bts)
-				if err != nil {
-					err = msgp.WrapError(err, "Types")
-					return
-				}
+		case "id":
+			z.ID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ID")
+				return
 			}
-		case "keys":
+		case "email":
+			z.Email, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Email")
+				return
+			}
+		case "metadata":
 			var zb0002 uint32
-			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			zb0002, bts, err = msgp.ReadMapHeaderBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "Keys")
+				err = msgp.WrapError(err, "Metadata")
 				return
 			}
-			if cap(z.Keys) >= int(zb0002) {
-				z.Keys = (z.Keys)[:zb0002]
-			} else {
-				z.Keys = make([]LoginIDKeyConfiguration, zb0002)
+			if z.Metadata == nil {
+				z.Metadata = make(map[string]string, zb0002)
+			} else if len(z.Metadata) > 0 {
+				for key := range z.Metadata {
+					delete(z.Metadata, key)
+				}
 			}
-			for za0001 := range z.Keys {
-				bts, err = z.Keys[za0001].UnmarshalMsg(bts)
+			for zb0002 > 0 {
+				var za0001 string
+				var za0002 string
+				zb0002--
+				za0001, bts, err = msgp.ReadStringBytes(bts)
 				if err != nil {
-					err = msgp.WrapError(err, "Keys", za0001)
+					err = msgp.WrapError(err, "Metadata")
+					return
+				}
+				za0002, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Metadata", za0001)
 					return
 				}
+				z.Metadata[za0001] = za0002
 			}
 		default:
 			bts, err = msgp.Skip(bts)
@@ -661,22 +741,19 @@ func (z *LoginIDConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z *LoginIDConfiguration) Msgsize() (s int) {
-	s = 1 + 6
-	if z.Types == nil {
-		s += msgp.NilSize
-	} else {
-		s += z.Types.Msgsize()
-	}
-	s += 5 + msgp.ArrayHeaderSize
-	for za0001 := range z.Keys {
-		s += z.Keys[za0001].Msgsize()
+func (z *LDAPAttributesConfiguration) Msgsize() (s int) {
+	s = 1 + 3 + msgp.StringPrefixSize + len(z.ID) + 6 + msgp.StringPrefixSize + len(z.Email) + 9 + msgp.MapHeaderSize
+	if z.Metadata != nil {
+		for za0001, za0002 := range z.Metadata {
+			_ = za0002
+			s += msgp.StringPrefixSize + len(za0001) + msgp.StringPrefixSize + len(za0002)
+		}
 	}
 	return
 }
 
 // DecodeMsg implements msgp.Decodable
-func (z *LoginIDKeyConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
+func (z *LDAPConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -693,37 +770,69 @@ func (z *LoginIDKeyConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "key":
-			z.Key, err = dc.ReadString()
+		case "url":
+			z.URL, err = dc.ReadString()
 			if err != nil {
-				err = msgp.WrapError(err, "Key")
+				err = msgp.WrapError(err, "URL")
 				return
 			}
-		case "type":
-			{
-				var zb0002 string
-				zb0002, err = dc.ReadString()
-				if err != nil {
-					err = msgp.WrapError(err, "Type")
-					return
-				}
-				z.Type = LoginIDKeyType(zb0002)
+		case "start_tls":
+			z.StartTLS, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "StartTLS")
+				return
 			}
-		case "maximum":
+		case "ca_certificate":
+			z.CACertificate, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "CACertificate")
+				return
+			}
+		case "bind_dn":
+			z.BindDN, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "BindDN")
+				return
+			}
+		case "bind_password":
+			z.BindPassword, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "BindPassword")
+				return
+			}
+		case "search_base":
+			z.SearchBase, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "SearchBase")
+				return
+			}
+		case "filter":
+			z.Filter, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Filter")
+				return
+			}
+		case "display_name":
+			z.DisplayName, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "DisplayName")
+				return
+			}
+		case "attributes":
 			if dc.IsNil() {
 				err = dc.ReadNil()
 				if err != nil {
-					err = msgp.WrapError(err, "Maximum")
+					err = msgp.WrapError(err, "Attributes")
 					return
 				}
-				z.Maximum = nil
+				z.Attributes = nil
 			} else {
-				if z.Maximum == nil {
-					z.Maximum = new(int)
+				if z.Attributes == nil {
+					z.Attributes = new(LDAPAttributesConfiguration)
 				}
-				*z.Maximum, err = dc.ReadInt()
+				err = z.Attributes.DecodeMsg(dc)
 				if err != nil {
-					err = msgp.WrapError(err, "Maximum")
+					err = msgp.WrapError(err, "Attributes")
 					return
 				}
 			}
@@ -739,34 +848,563 @@ func (z *LoginIDKeyConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z *LoginIDKeyConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 3
-	// write "key"
-	err = en.Append(0x83, 0xa3, 0x6b, 0x65, 0x79)
+func (z *LDAPConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 9
+	// write "url"
+	err = en.Append(0x89, 0xa3, 0x75, 0x72, 0x6c)
 	if err != nil {
 		return
 	}
-	err = en.WriteString(z.Key)
+	err = en.WriteString(z.URL)
 	if err != nil {
-		err = msgp.WrapError(err, "Key")
+		err = msgp.WrapError(err, "URL")
 		return
 	}
-	// write "type"
-	err = en.Append(0xa4, 0x74, 0x79, 0x70, 0x65)
+	// write "start_tls"
+	err = en.Append(0xa9, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x6c, 0x73)
 	if err != nil {
 		return
 	}
-	err = en.WriteString(string(z.Type))
+	err = en.WriteBool(z.StartTLS)
 	if err != nil {
-		err = msgp.WrapError(err, "Type")
+		err = msgp.WrapError(err, "StartTLS")
 		return
 	}
-	// write "maximum"
-	err = en.Append(0xa7, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d)
+	// write "ca_certificate"
+	err = en.Append(0xae, 0x63, 0x61, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65)
 	if err != nil {
 		return
 	}
-	if z.Maximum == nil {
+	err = en.WriteString(z.CACertificate)
+	if err != nil {
+		err = msgp.WrapError(err, "CACertificate")
+		return
+	}
+	// write "bind_dn"
+	err = en.Append(0xa7, 0x62, 0x69, 0x6e, 0x64, 0x5f, 0x64, 0x6e)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.BindDN)
+	if err != nil {
+		err = msgp.WrapError(err, "BindDN")
+		return
+	}
+	// write "bind_password"
+	err = en.Append(0xad, 0x62, 0x69, 0x6e, 0x64, 0x5f, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.BindPassword)
+	if err != nil {
+		err = msgp.WrapError(err, "BindPassword")
+		return
+	}
+	// write "search_base"
+	err = en.Append(0xab, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5f, 0x62, 0x61, 0x73, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.SearchBase)
+	if err != nil {
+		err = msgp.WrapError(err, "SearchBase")
+		return
+	}
+	// write "filter"
+	err = en.Append(0xa6, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Filter)
+	if err != nil {
+		err = msgp.WrapError(err, "Filter")
+		return
+	}
+	// write "display_name"
+	err = en.Append(0xac, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.DisplayName)
+	if err != nil {
+		err = msgp.WrapError(err, "DisplayName")
+		return
+	}
+	// write "attributes"
+	err = en.Append(0xaa, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73)
+	if err != nil {
+		return
+	}
+	if z.Attributes == nil {
+		err = en.WriteNil()
+		if err != nil {
+			return
+		}
+	} else {
+		err = z.Attributes.EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "Attributes")
+			return
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *LDAPConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 9
+	// string "url"
+	o = append(o, 0x89, 0xa3, 0x75, 0x72, 0x6c)
+	o = msgp.AppendString(o, z.URL)
+	// string "start_tls"
+	o = append(o, 0xa9, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x6c, 0x73)
+	o = msgp.AppendBool(o, z.StartTLS)
+	// string "ca_certificate"
+	o = append(o, 0xae, 0x63, 0x61, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65)
+	o = msgp.AppendString(o, z.CACertificate)
+	// string "bind_dn"
+	o = append(o, 0xa7, 0x62, 0x69, 0x6e, 0x64, 0x5f, 0x64, 0x6e)
+	o = msgp.AppendString(o, z.BindDN)
+	// string "bind_password"
+	o = append(o, 0xad, 0x62, 0x69, 0x6e, 0x64, 0x5f, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64)
+	o = msgp.AppendString(o, z.BindPassword)
+	// string "search_base"
+	o = append(o, 0xab, 0x73, 0x65, 0x61, 0x72, 0x63, 0x68, 0x5f, 0x62, 0x61, 0x73, 0x65)
+	o = msgp.AppendString(o, z.SearchBase)
+	// string "filter"
+	o = append(o, 0xa6, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72)
+	o = msgp.AppendString(o, z.Filter)
+	// string "display_name"
+	o = append(o, 0xac, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65)
+	o = msgp.AppendString(o, z.DisplayName)
+	// string "attributes"
+	o = append(o, 0xaa, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73)
+	if z.Attributes == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		o, err = z.Attributes.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Attributes")
+			return
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *LDAPConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "url":
+			z.URL, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "URL")
+				return
+			}
+		case "start_tls":
+			z.StartTLS, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "StartTLS")
+				return
+			}
+		case "ca_certificate":
+			z.CACertificate, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "CACertificate")
+				return
+			}
+		case "bind_dn":
+			z.BindDN, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "BindDN")
+				return
+			}
+		case "bind_password":
+			z.BindPassword, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "BindPassword")
+				return
+			}
+		case "search_base":
+			z.SearchBase, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SearchBase")
+				return
+			}
+		case "filter":
+			z.Filter, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Filter")
+				return
+			}
+		case "display_name":
+			z.DisplayName, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "DisplayName")
+				return
+			}
+		case "attributes":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.Attributes = nil
+			} else {
+				if z.Attributes == nil {
+					z.Attributes = new(LDAPAttributesConfiguration)
+				}
+				bts, err = z.Attributes.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Attributes")
+					return
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *LDAPConfiguration) Msgsize() (s int) {
+	s = 1 + 4 + msgp.StringPrefixSize + len(z.URL) + 10 + msgp.BoolSize + 15 + msgp.StringPrefixSize + len(z.CACertificate) + 8 + msgp.StringPrefixSize + len(z.BindDN) + 14 + msgp.StringPrefixSize + len(z.BindPassword) + 12 + msgp.StringPrefixSize + len(z.SearchBase) + 7 + msgp.StringPrefixSize + len(z.Filter) + 13 + msgp.StringPrefixSize + len(z.DisplayName) + 11
+	if z.Attributes == nil {
+		s += msgp.NilSize
+	} else {
+		s += z.Attributes.Msgsize()
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *LoginIDConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "types":
+			if dc.IsNil() {
+				err = dc.ReadNil()
+				if err != nil {
+					err = msgp.WrapError(err, "Types")
+					return
+				}
+				z.Types = nil
+			} else {
+				if z.Types == nil {
+					z.Types = new(LoginIDTypesConfiguration)
+				}
+				err = z.Types.DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "Types")
+					return
+				}
+			}
+		case "keys":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Keys")
+				return
+			}
+			if cap(z.Keys) >= int(zb0002) {
+				z.Keys = (z.Keys)[:zb0002]
+			} else {
+				z.Keys = make([]LoginIDKeyConfiguration, zb0002)
+			}
+			for za0001 := range z.Keys {
+				err = z.Keys[za0001].DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "Keys", za0001)
+					return
+				}
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *LoginIDConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 2
+	// write "types"
+	err = en.Append(0x82, 0xa5, 0x74, 0x79, 0x70, 0x65, 0x73)
+	if err != nil {
+		return
+	}
+	if z.Types == nil {
+		err = en.WriteNil()
+		if err != nil {
+			return
+		}
+	} else {
+		err = z.Types.EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "Types")
+			return
+		}
+	}
+	// write "keys"
+	err = en.Append(0xa4, 0x6b, 0x65, 0x79, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Keys)))
+	if err != nil {
+		err = msgp.WrapError(err, "Keys")
+		return
+	}
+	for za0001 := range z.Keys {
+		err = z.Keys[za0001].EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "Keys", za0001)
+			return
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *LoginIDConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 2
+	// string "types"
+	o = append(o, 0x82, 0xa5, 0x74, 0x79, 0x70, 0x65, 0x73)
+	if z.Types == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		o, err = z.Types.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Types")
+			return
+		}
+	}
+	// string "keys"
+	o = append(o, 0xa4, 0x6b, 0x65, 0x79, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Keys)))
+	for za0001 := range z.Keys {
+		o, err = z.Keys[za0001].MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Keys", za0001)
+			return
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *LoginIDConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "types":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.Types = nil
+			} else {
+				if z.Types == nil {
+					z.Types = new(LoginIDTypesConfiguration)
+				}
+				bts, err = z.Types.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Types")
+					return
+				}
+			}
+		case "keys":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Keys")
+				return
+			}
+			if cap(z.Keys) >= int(zb0002) {
+				z.Keys = (z.Keys)[:zb0002]
+			} else {
+				z.Keys = make([]LoginIDKeyConfiguration, zb0002)
+			}
+			for za0001 := range z.Keys {
+				bts, err = z.Keys[za0001].UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Keys", za0001)
+					return
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *LoginIDConfiguration) Msgsize() (s int) {
+	s = 1 + 6
+	if z.Types == nil {
+		s += msgp.NilSize
+	} else {
+		s += z.Types.Msgsize()
+	}
+	s += 5 + msgp.ArrayHeaderSize
+	for za0001 := range z.Keys {
+		s += z.Keys[za0001].Msgsize()
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *LoginIDKeyConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "key":
+			z.Key, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Key")
+				return
+			}
+		case "type":
+			{
+				var zb0002 string
+				zb0002, err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "Type")
+					return
+				}
+				z.Type = LoginIDKeyType(zb0002)
+			}
+		case "maximum":
+			if dc.IsNil() {
+				err = dc.ReadNil()
+				if err != nil {
+					err = msgp.WrapError(err, "Maximum")
+					return
+				}
+				z.Maximum = nil
+			} else {
+				if z.Maximum == nil {
+					z.Maximum = new(int)
+				}
+				*z.Maximum, err = dc.ReadInt()
+				if err != nil {
+					err = msgp.WrapError(err, "Maximum")
+					return
+				}
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *LoginIDKeyConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
+	// write "key"
+	err = en.Append(0x83, 0xa3, 0x6b, 0x65, 0x79)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Key)
+	if err != nil {
+		err = msgp.WrapError(err, "Key")
+		return
+	}
+	// write "type"
+	err = en.Append(0xa4, 0x74, 0x79, 0x70, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(string(z.Type))
+	if err != nil {
+		err = msgp.WrapError(err, "Type")
+		return
+	}
+	// write "maximum"
+	err = en.Append(0xa7, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d)
+	if err != nil {
+		return
+	}
+	if z.Maximum == nil {
 		err = en.WriteNil()
 		if err != nil {
 			return
@@ -1793,12 +2431,189 @@ func (z *OAuthConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 			} else {
 				z.Providers = make([]OAuthProviderConfiguration, zb0002)
 			}
-			for za0001 := range z.Providers {
-				err = z.Providers[za0001].DecodeMsg(dc)
-				if err != nil {
-					err = msgp.WrapError(err, "Providers", za0001)
-					return
-				}
+			for za0001 := range z.Providers {
+				err = z.Providers[za0001].DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "Providers", za0001)
+					return
+				}
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *OAuthConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
+	// write "state_jwt_secret"
+	err = en.Append(0x83, 0xb0, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x6a, 0x77, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.StateJWTSecret)
+	if err != nil {
+		err = msgp.WrapError(err, "StateJWTSecret")
+		return
+	}
+	// write "external_access_token_flow_enabled"
+	err = en.Append(0xd9, 0x22, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x66, 0x6c, 0x6f, 0x77, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteBool(z.ExternalAccessTokenFlowEnabled)
+	if err != nil {
+		err = msgp.WrapError(err, "ExternalAccessTokenFlowEnabled")
+		return
+	}
+	// write "providers"
+	err = en.Append(0xa9, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Providers)))
+	if err != nil {
+		err = msgp.WrapError(err, "Providers")
+		return
+	}
+	for za0001 := range z.Providers {
+		err = z.Providers[za0001].EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "Providers", za0001)
+			return
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *OAuthConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "state_jwt_secret"
+	o = append(o, 0x83, 0xb0, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x6a, 0x77, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74)
+	o = msgp.AppendString(o, z.StateJWTSecret)
+	// string "external_access_token_flow_enabled"
+	o = append(o, 0xd9, 0x22, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x66, 0x6c, 0x6f, 0x77, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64)
+	o = msgp.AppendBool(o, z.ExternalAccessTokenFlowEnabled)
+	// string "providers"
+	o = append(o, 0xa9, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Providers)))
+	for za0001 := range z.Providers {
+		o, err = z.Providers[za0001].MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Providers", za0001)
+			return
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *OAuthConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "state_jwt_secret":
+			z.StateJWTSecret, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "StateJWTSecret")
+				return
+			}
+		case "external_access_token_flow_enabled":
+			z.ExternalAccessTokenFlowEnabled, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ExternalAccessTokenFlowEnabled")
+				return
+			}
+		case "providers":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Providers")
+				return
+			}
+			if cap(z.Providers) >= int(zb0002) {
+				z.Providers = (z.Providers)[:zb0002]
+			} else {
+				z.Providers = make([]OAuthProviderConfiguration, zb0002)
+			}
+			for za0001 := range z.Providers {
+				bts, err = z.Providers[za0001].UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Providers", za0001)
+					return
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *OAuthConfiguration) Msgsize() (s int) {
+	s = 1 + 17 + msgp.StringPrefixSize + len(z.StateJWTSecret) + 36 + msgp.BoolSize + 10 + msgp.ArrayHeaderSize
+	for za0001 := range z.Providers {
+		s += z.Providers[za0001].Msgsize()
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *OAuthProviderClaimsConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "id":
+			z.ID, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "ID")
+				return
+			}
+		case "email":
+			z.Email, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Email")
+				return
 			}
 		default:
 			err = dc.Skip()
@@ -1812,73 +2627,46 @@ func (z *OAuthConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z *OAuthConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 3
-	// write "state_jwt_secret"
-	err = en.Append(0x83, 0xb0, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x6a, 0x77, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74)
-	if err != nil {
-		return
-	}
-	err = en.WriteString(z.StateJWTSecret)
-	if err != nil {
-		err = msgp.WrapError(err, "StateJWTSecret")
-		return
-	}
-	// write "external_access_token_flow_enabled"
-	err = en.Append(0xd9, 0x22, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x66, 0x6c, 0x6f, 0x77, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64)
+func (z OAuthProviderClaimsConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 2
+	// write "id"
+	err = en.Append(0x82, 0xa2, 0x69, 0x64)
 	if err != nil {
 		return
 	}
-	err = en.WriteBool(z.ExternalAccessTokenFlowEnabled)
+	err = en.WriteString(z.ID)
 	if err != nil {
-		err = msgp.WrapError(err, "ExternalAccessTokenFlowEnabled")
+		err = msgp.WrapError(err, "ID")
 		return
 	}
-	// write "providers"
-	err = en.Append(0xa9, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73)
+	// write "email"
+	err = en.Append(0xa5, 0x65, 0x6d, 0x61, 0x69, 0x6c)
 	if err != nil {
 		return
 	}
-	err = en.WriteArrayHeader(uint32(len(z.Providers)))
+	err = en.WriteString(z.Email)
 	if err != nil {
-		err = msgp.WrapError(err, "Providers")
+		err = msgp.WrapError(err, "Email")
 		return
 	}
-	for za0001 := range z.Providers {
-		err = z.Providers[za0001].EncodeMsg(en)
-		if err != nil {
-			err = msgp.WrapError(err, "Providers", za0001)
-			return
-		}
-	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z *OAuthConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
+func (z OAuthProviderClaimsConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 3
-	// string "state_jwt_secret"
-	o = append(o, 0x83, 0xb0, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x6a, 0x77, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74)
-	o = msgp.AppendString(o, z.StateJWTSecret)
-	// string "external_access_token_flow_enabled"
-	o = append(o, 0xd9, 0x22, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x5f, 0x66, 0x6c, 0x6f, 0x77, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64)
-	o = msgp.AppendBool(o, z.ExternalAccessTokenFlowEnabled)
-	// string "providers"
-	o = append(o, 0xa9, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73)
-	o = msgp.AppendArrayHeader(o, uint32(len(z.Providers)))
-	for za0001 := range z.Providers {
-		o, err = z.Providers[za0001].MarshalMsg(o)
-		if err != nil {
-			err = msgp.WrapError(err, "Providers", za0001)
-			return
-		}
-	}
+	// map header, size 2
+	// string "id"
+	o = append(o, 0x82, 0xa2, 0x69, 0x64)
+	o = msgp.AppendString(o, z.ID)
+	// string "email"
+	o = append(o, 0xa5, 0x65, 0x6d, 0x61, 0x69, 0x6c)
+	o = msgp.AppendString(o, z.Email)
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *OAuthConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *OAuthProviderClaimsConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -1895,37 +2683,18 @@ func (z *OAuthConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "state_jwt_secret":
-			z.StateJWTSecret, bts, err = msgp.ReadStringBytes(bts)
-			if err != nil {
-				err = msgp.WrapError(err, "StateJWTSecret")
-				return
-			}
-		case "external_access_token_flow_enabled":
-			z.ExternalAccessTokenFlowEnabled, bts, err = msgp.ReadBoolBytes(bts)
+		case "id":
+			z.ID, bts, err = msgp.ReadStringBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "ExternalAccessTokenFlowEnabled")
+				err = msgp.WrapError(err, "ID")
 				return
 			}
-		case "providers":
-			var zb0002 uint32
-			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+		case "email":
+			z.Email, bts, err = msgp.ReadStringBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "Providers")
+				err = msgp.WrapError(err, "Email")
 				return
 			}
-			if cap(z.Providers) >= int(zb0002) {
-				z.Providers = (z.Providers)[:zb0002]
-			} else {
-				z.Providers = make([]OAuthProviderConfiguration, zb0002)
-			}
-			for za0001 := range z.Providers {
-				bts, err = z.Providers[za0001].UnmarshalMsg(bts)
-				if err != nil {
-					err = msgp.WrapError(err, "Providers", za0001)
-					return
-				}
-			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -1939,11 +2708,8 @@ func (z *OAuthConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z *OAuthConfiguration) Msgsize() (s int) {
-	s = 1 + 17 + msgp.StringPrefixSize + len(z.StateJWTSecret) + 36 + msgp.BoolSize + 10 + msgp.ArrayHeaderSize
-	for za0001 := range z.Providers {
-		s += z.Providers[za0001].Msgsize()
-	}
+func (z OAuthProviderClaimsConfiguration) Msgsize() (s int) {
+	s = 1 + 3 + msgp.StringPrefixSize + len(z.ID) + 6 + msgp.StringPrefixSize + len(z.Email)
 	return
 }
 
@@ -2017,6 +2783,89 @@ func (z *OAuthProviderConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "TeamID")
 				return
 			}
+		case "discovery_url":
+			z.DiscoveryURL, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "DiscoveryURL")
+				return
+			}
+		case "display_name":
+			z.DisplayName, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "DisplayName")
+				return
+			}
+		case "claims":
+			if dc.IsNil() {
+				err = dc.ReadNil()
+				if err != nil {
+					err = msgp.WrapError(err, "Claims")
+					return
+				}
+				z.Claims = nil
+			} else {
+				if z.Claims == nil {
+					z.Claims = new(OAuthProviderClaimsConfiguration)
+				}
+				var zb0003 uint32
+				zb0003, err = dc.ReadMapHeader()
+				if err != nil {
+					err = msgp.WrapError(err, "Claims")
+					return
+				}
+				for zb0003 > 0 {
+					zb0003--
+					field, err = dc.ReadMapKeyPtr()
+					if err != nil {
+						err = msgp.WrapError(err, "Claims")
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "id":
+						z.Claims.ID, err = dc.ReadString()
+						if err != nil {
+							err = msgp.WrapError(err, "Claims", "ID")
+							return
+						}
+					case "email":
+						z.Claims.Email, err = dc.ReadString()
+						if err != nil {
+							err = msgp.WrapError(err, "Claims", "Email")
+							return
+						}
+					default:
+						err = dc.Skip()
+						if err != nil {
+							err = msgp.WrapError(err, "Claims")
+							return
+						}
+					}
+				}
+			}
+		case "base_url":
+			z.BaseURL, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "BaseURL")
+				return
+			}
+		case "idp_entity_id":
+			z.IdPEntityID, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "IdPEntityID")
+				return
+			}
+		case "idp_sso_url":
+			z.IdPSSOURL, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "IdPSSOURL")
+				return
+			}
+		case "idp_certificate":
+			z.IdPCertificate, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "IdPCertificate")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -2030,9 +2879,9 @@ func (z *OAuthProviderConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *OAuthProviderConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 8
+	// map header, size 15
 	// write "id"
-	err = en.Append(0x88, 0xa2, 0x69, 0x64)
+	err = en.Append(0x8f, 0xa2, 0x69, 0x64)
 	if err != nil {
 		return
 	}
@@ -2111,15 +2960,108 @@ func (z *OAuthProviderConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "TeamID")
 		return
 	}
+	// write "discovery_url"
+	err = en.Append(0xad, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x5f, 0x75, 0x72, 0x6c)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.DiscoveryURL)
+	if err != nil {
+		err = msgp.WrapError(err, "DiscoveryURL")
+		return
+	}
+	// write "display_name"
+	err = en.Append(0xac, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.DisplayName)
+	if err != nil {
+		err = msgp.WrapError(err, "DisplayName")
+		return
+	}
+	// write "claims"
+	err = en.Append(0xa6, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x73)
+	if err != nil {
+		return
+	}
+	if z.Claims == nil {
+		err = en.WriteNil()
+		if err != nil {
+			return
+		}
+	} else {
+		// map header, size 2
+		// write "id"
+		err = en.Append(0x82, 0xa2, 0x69, 0x64)
+		if err != nil {
+			return
+		}
+		err = en.WriteString(z.Claims.ID)
+		if err != nil {
+			err = msgp.WrapError(err, "Claims", "ID")
+			return
+		}
+		// write "email"
+		err = en.Append(0xa5, 0x65, 0x6d, 0x61, 0x69, 0x6c)
+		if err != nil {
+			return
+		}
+		err = en.WriteString(z.Claims.Email)
+		if err != nil {
+			err = msgp.WrapError(err, "Claims", "Email")
+			return
+		}
+	}
+	// write "base_url"
+	err = en.Append(0xa8, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x75, 0x72, 0x6c)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.BaseURL)
+	if err != nil {
+		err = msgp.WrapError(err, "BaseURL")
+		return
+	}
+	// write "idp_entity_id"
+	err = en.Append(0xad, 0x69, 0x64, 0x70, 0x5f, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f, 0x69, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.IdPEntityID)
+	if err != nil {
+		err = msgp.WrapError(err, "IdPEntityID")
+		return
+	}
+	// write "idp_sso_url"
+	err = en.Append(0xab, 0x69, 0x64, 0x70, 0x5f, 0x73, 0x73, 0x6f, 0x5f, 0x75, 0x72, 0x6c)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.IdPSSOURL)
+	if err != nil {
+		err = msgp.WrapError(err, "IdPSSOURL")
+		return
+	}
+	// write "idp_certificate"
+	err = en.Append(0xaf, 0x69, 0x64, 0x70, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.IdPCertificate)
+	if err != nil {
+		err = msgp.WrapError(err, "IdPCertificate")
+		return
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *OAuthProviderConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 8
+	// map header, size 15
 	// string "id"
-	o = append(o, 0x88, 0xa2, 0x69, 0x64)
+	o = append(o, 0x8f, 0xa2, 0x69, 0x64)
 	o = msgp.AppendString(o, z.ID)
 	// string "type"
 	o = append(o, 0xa4, 0x74, 0x79, 0x70, 0x65)
@@ -2142,6 +3084,37 @@ func (z *OAuthProviderConfiguration) MarshalMsg(b []byte) (o []byte, err error)
 	// string "team_id"
 	o = append(o, 0xa7, 0x74, 0x65, 0x61, 0x6d, 0x5f, 0x69, 0x64)
 	o = msgp.AppendString(o, z.TeamID)
+	// string "discovery_url"
+	o = append(o, 0xad, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x5f, 0x75, 0x72, 0x6c)
+	o = msgp.AppendString(o, z.DiscoveryURL)
+	// string "display_name"
+	o = append(o, 0xac, 0x64, 0x69, 0x73, 0x70, 0x6c, 0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65)
+	o = msgp.AppendString(o, z.DisplayName)
+	// string "claims"
+	o = append(o, 0xa6, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x73)
+	if z.Claims == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		// map header, size 2
+		// string "id"
+		o = append(o, 0x82, 0xa2, 0x69, 0x64)
+		o = msgp.AppendString(o, z.Claims.ID)
+		// string "email"
+		o = append(o, 0xa5, 0x65, 0x6d, 0x61, 0x69, 0x6c)
+		o = msgp.AppendString(o, z.Claims.Email)
+	}
+	// string "base_url"
+	o = append(o, 0xa8, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x75, 0x72, 0x6c)
+	o = msgp.AppendString(o, z.BaseURL)
+	// string "idp_entity_id"
+	o = append(o, 0xad, 0x69, 0x64, 0x70, 0x5f, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f, 0x69, 0x64)
+	o = msgp.AppendString(o, z.IdPEntityID)
+	// string "idp_sso_url"
+	o = append(o, 0xab, 0x69, 0x64, 0x70, 0x5f, 0x73, 0x73, 0x6f, 0x5f, 0x75, 0x72, 0x6c)
+	o = msgp.AppendString(o, z.IdPSSOURL)
+	// string "idp_certificate"
+	o = append(o, 0xaf, 0x69, 0x64, 0x70, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65)
+	o = msgp.AppendString(o, z.IdPCertificate)
 	return
 }
 
@@ -2215,6 +3188,88 @@ func (z *OAuthProviderConfiguration) UnmarshalMsg(bts []byte) (o []byte, err err
 				err = msgp.WrapError(err, "TeamID")
 				return
 			}
+		case "discovery_url":
+			z.DiscoveryURL, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "DiscoveryURL")
+				return
+			}
+		case "display_name":
+			z.DisplayName, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "DisplayName")
+				return
+			}
+		case "claims":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.Claims = nil
+			} else {
+				if z.Claims == nil {
+					z.Claims = new(OAuthProviderClaimsConfiguration)
+				}
+				var zb0003 uint32
+				zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Claims")
+					return
+				}
+				for zb0003 > 0 {
+					zb0003--
+					field, bts, err = msgp.ReadMapKeyZC(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Claims")
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "id":
+						z.Claims.ID, bts, err = msgp.ReadStringBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Claims", "ID")
+							return
+						}
+					case "email":
+						z.Claims.Email, bts, err = msgp.ReadStringBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Claims", "Email")
+							return
+						}
+					default:
+						bts, err = msgp.Skip(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Claims")
+							return
+						}
+					}
+				}
+			}
+		case "base_url":
+			z.BaseURL, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "BaseURL")
+				return
+			}
+		case "idp_entity_id":
+			z.IdPEntityID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "IdPEntityID")
+				return
+			}
+		case "idp_sso_url":
+			z.IdPSSOURL, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "IdPSSOURL")
+				return
+			}
+		case "idp_certificate":
+			z.IdPCertificate, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "IdPCertificate")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -2229,7 +3284,13 @@ func (z *OAuthProviderConfiguration) UnmarshalMsg(bts []byte) (o []byte, err err
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
 func (z *OAuthProviderConfiguration) Msgsize() (s int) {
-	s = 1 + 3 + msgp.StringPrefixSize + len(z.ID) + 5 + msgp.StringPrefixSize + len(string(z.Type)) + 10 + msgp.StringPrefixSize + len(z.ClientID) + 14 + msgp.StringPrefixSize + len(z.ClientSecret) + 6 + msgp.StringPrefixSize + len(z.Scope) + 7 + msgp.StringPrefixSize + len(z.Tenant) + 7 + msgp.StringPrefixSize + len(z.KeyID) + 8 + msgp.StringPrefixSize + len(z.TeamID)
+	s = 1 + 3 + msgp.StringPrefixSize + len(z.ID) + 5 + msgp.StringPrefixSize + len(string(z.Type)) + 10 + msgp.StringPrefixSize + len(z.ClientID) + 14 + msgp.StringPrefixSize + len(z.ClientSecret) + 6 + msgp.StringPrefixSize + len(z.Scope) + 7 + msgp.StringPrefixSize + len(z.Tenant) + 7 + msgp.StringPrefixSize + len(z.KeyID) + 8 + msgp.StringPrefixSize + len(z.TeamID) + 14 + msgp.StringPrefixSize + len(z.DiscoveryURL) + 13 + msgp.StringPrefixSize + len(z.DisplayName) + 7
+	if z.Claims == nil {
+		s += msgp.NilSize
+	} else {
+		s += 1 + 3 + msgp.StringPrefixSize + len(z.Claims.ID) + 6 + msgp.StringPrefixSize + len(z.Claims.Email)
+	}
+	s += 9 + msgp.StringPrefixSize + len(z.BaseURL) + 14 + msgp.StringPrefixSize + len(z.IdPEntityID) + 12 + msgp.StringPrefixSize + len(z.IdPSSOURL) + 16 + msgp.StringPrefixSize + len(z.IdPCertificate)
 	return
 }
 
@@ -48,7 +48,9 @@ var (
 		"type": "object",
 		"properties": {
 			"sync_hook_timeout_second": { "type": "integer" },
-			"sync_hook_total_timeout_second": { "type": "integer" }
+			"sync_hook_total_timeout_second": { "type": "integer" },
+			"async_hook_timeout_second": { "type": "integer" },
+			"async_hook_max_attempts": { "type": "integer", "minimum": 1 }
 		}
 	},
 	"AppConfiguration": {
@@ -65,6 +67,7 @@ var (
 			"session": { "$ref": "#SessionConfiguration" },
 			"cors": { "$ref": "#CORSConfiguration" },
 			"oidc": { "$ref": "#OIDCConfiguration" },
+			"saml_idp": { "$ref": "#SAMLIdPConfiguration" },
 			"authentication": { "$ref": "#AuthenticationConfiguration" },
 			"auth_ui": { "$ref": "#AuthUIConfiguration" },
 			"authenticator": { "$ref": "#AuthenticatorConfiguration" },
@@ -118,17 +121,45 @@ var (
 		"type": "object",
 		"properties": {
 			"client_id": { "$ref": "#NonEmptyString" },
+			"client_name": { "type": "string" },
 			"client_uri": { "type": "string" },
+			"client_secret": { "type": "string" },
+			"client_secret_hash": { "type": "string" },
+			"token_endpoint_auth_method": {
+				"type": "string",
+				"enum": ["none", "client_secret_basic", "client_secret_post", "private_key_jwt"]
+			},
+			"jwks": {
+				"type": "object",
+				"properties": {
+					"keys": {
+						"type": "array",
+						"items": { "type": "object" }
+					}
+				},
+				"required": ["keys"]
+			},
 			"redirect_uris": {
 				"type": "array",
 				"items": { "type": "string" }
 			},
 			"access_token_lifetime": { "type": "integer", "minimum": 0 },
 			"refresh_token_lifetime": { "type": "integer", "minimum": 0 },
+			"refresh_token_rotation": { "type": "boolean" },
+			"jwt_access_token": { "type": "boolean" },
+			"is_first_party": { "type": "boolean" },
 			"grant_types": {
 				"type": "array",
 				"items": { "type": "string" }
 			},
+			"client_credentials_scopes": {
+				"type": "array",
+				"items": { "type": "string" }
+			},
+			"allowed_scopes": {
+				"type": "array",
+				"items": { "type": "string" }
+			},
 			"response_types": {
 				"type": "array",
 				"items": { "type": "string" }
@@ -136,7 +167,11 @@ var (
 			"post_logout_redirect_uris": {
 				"type": "array",
 				"items": { "type": "string" }
-			}
+			},
+			"backchannel_logout_uri": { "type": "string" },
+			"frontchannel_logout_uri": { "type": "string" },
+			"frontchannel_logout_session_required": { "type": "boolean" },
+			"require_pushed_authorization_requests": { "type": "boolean" }
 		},
 		"required": ["client_id"]
 	},
@@ -159,15 +194,67 @@ var (
 					"type": "object",
 					"properties": {
 						"kid": { "type": "string" },
+						"state": {
+							"type": "string",
+							"enum": ["active", "published", "retired"]
+						},
 						"public_key": { "type": "string" },
 						"private_key": { "type": "string" }
 					},
 					"required": ["kid", "public_key", "private_key"]
 				},
 				"minItems": 1
+			},
+			"scopes": {
+				"type": "array",
+				"items": { "$ref": "#OIDCScopeConfiguration" }
+			},
+			"initial_access_tokens": {
+				"type": "array",
+				"items": { "$ref": "#NonEmptyString" }
+			}
+		}
+	},
+	"OIDCScopeConfiguration": {
+		"$id": "#OIDCScopeConfiguration",
+		"type": "object",
+		"additionalProperties": false,
+		"properties": {
+			"name": { "$ref": "#NonEmptyString" },
+			"description": { "type": "string" },
+			"claims": {
+				"type": "object",
+				"additionalProperties": { "type": "string" }
+			}
+		},
+		"required": ["name"]
+	},
+	"SAMLIdPConfiguration": {
+		"$id": "#SAMLIdPConfiguration",
+		"type": "object",
+		"additionalProperties": false,
+		"properties": {
+			"certificate": { "type": "string" },
+			"private_key": { "type": "string" },
+			"service_providers": {
+				"type": "array",
+				"items": { "$ref": "#SAMLServiceProviderConfiguration" }
 			}
 		}
 	},
+	"SAMLServiceProviderConfiguration": {
+		"$id": "#SAMLServiceProviderConfiguration",
+		"type": "object",
+		"additionalProperties": false,
+		"properties": {
+			"entity_id": { "$ref": "#NonEmptyString" },
+			"acs_url": { "$ref": "#NonEmptyString" },
+			"slo_url": { "type": "string" },
+			"certificate": { "$ref": "#NonEmptyString" },
+			"want_authn_requests_signed": { "type": "boolean" }
+		},
+		"required": ["entity_id", "acs_url", "certificate"]
+	},
 	"AuthUIConfiguration": {
 		"$id": "#AuthUIConfiguration",
 		"type": "object",
@@ -205,14 +292,14 @@ var (
 				"type": "array",
 				"items": {
 					"type": "string",
-					"enum": ["oauth", "login_id", "anonymous"]
+					"enum": ["oauth", "login_id", "anonymous", "ldap"]
 				}
 			},
 			"primary_authenticators": {
 				"type": "array",
 				"items": {
 					"type": "string",
-					"enum": ["password", "totp", "oob_otp"]
+					"enum": ["password", "totp", "oob_otp", "ldap"]
 				}
 			},
 			"secondary_authenticators": {
@@ -226,7 +313,8 @@ var (
 				"type": "string",
 				"enum": ["if_requested", "if_exists", "required"]
 			},
-			"secret": { "$ref": "#NonEmptyString" }
+			"secret": { "$ref": "#NonEmptyString" },
+			"allow_self_deletion": { "type": "boolean" }
 		},
 		"required": ["secret"]
 	},
@@ -364,7 +452,8 @@ var (
 		"properties": {
 			"login_id": { "$ref": "#LoginIDConfiguration" },
 			"oauth": { "$ref": "#OAuthConfiguration" },
-			"on_conflict": { "$ref": "#IdentityConflictConfiguration" }
+			"on_conflict": { "$ref": "#IdentityConflictConfiguration" },
+			"ldap": { "$ref": "#LDAPConfiguration" }
 		}
 	},
 	"LoginIDConfiguration": {
@@ -451,14 +540,28 @@ var (
 			"id": { "type": "string" },
 			"type": {
 				"type": "string",
-				"enum": ["google", "facebook", "linkedin", "azureadv2", "apple"]
+				"enum": ["google", "facebook", "linkedin", "azureadv2", "apple", "oidc", "github", "gitlab", "saml"]
 			},
 			"client_id": { "type": "string" },
 			"client_secret": { "type": "string" },
 			"scope": { "type": "string" },
 			"tenant": { "type": "string" },
 			"key_id": { "type": "string" },
-			"team_id": { "type": "string" }
+			"team_id": { "type": "string" },
+			"discovery_url": { "type": "string" },
+			"display_name": { "type": "string" },
+			"claims": {
+				"type": "object",
+				"additionalProperties": false,
+				"properties": {
+					"id": { "$ref": "#NonEmptyString" },
+					"email": { "$ref": "#NonEmptyString" }
+				}
+			},
+			"base_url": { "type": "string" },
+			"idp_entity_id": { "type": "string" },
+			"idp_sso_url": { "type": "string" },
+			"idp_certificate": { "type": "string" }
 		},
 		"allOf": [
 			{
@@ -479,7 +582,23 @@ var (
 			},
 			{
 				"if": {
-					"properties": { "type": { "enum": ["google", "facebook", "linkedin"] } }
+					"properties": { "type": { "const": "oidc" } }
+				},
+				"then": {
+					"required": ["client_id", "client_secret", "discovery_url"]
+				}
+			},
+			{
+				"if": {
+					"properties": { "type": { "const": "saml" } }
+				},
+				"then": {
+					"required": ["idp_entity_id", "idp_sso_url", "idp_certificate"]
+				}
+			},
+			{
+				"if": {
+					"properties": { "type": { "enum": ["google", "facebook", "linkedin", "github", "gitlab"] } }
 				},
 				"then": {
 					"required": ["client_id", "client_secret"]
@@ -487,6 +606,33 @@ var (
 			}
 		]
 	},
+	"LDAPConfiguration": {
+		"$id": "#LDAPConfiguration",
+		"type": "object",
+		"additionalProperties": false,
+		"properties": {
+			"url": { "type": "string" },
+			"start_tls": { "type": "boolean" },
+			"ca_certificate": { "type": "string" },
+			"bind_dn": { "type": "string" },
+			"bind_password": { "type": "string" },
+			"search_base": { "type": "string" },
+			"filter": { "type": "string" },
+			"display_name": { "type": "string" },
+			"attributes": {
+				"type": "object",
+				"additionalProperties": false,
+				"properties": {
+					"id": { "$ref": "#NonEmptyString" },
+					"email": { "$ref": "#NonEmptyString" },
+					"metadata": {
+						"type": "object",
+						"additionalProperties": { "$ref": "#NonEmptyString" }
+					}
+				}
+			}
+		}
+	},
 	"IdentityConflictConfiguration": {
 		"$id": "IdentityConflictConfiguration",
 		"type": "object",
@@ -537,7 +683,11 @@ var (
 		"type": "object",
 		"additionalProperties": false,
 		"properties": {
-			"secret": { "$ref": "#NonEmptyString" }
+			"secret": { "$ref": "#NonEmptyString" },
+			"additional_secrets": {
+				"type": "array",
+				"items": { "$ref": "#NonEmptyString" }
+			}
 		},
 		"required": ["secret"]
 	},
@@ -2,14 +2,21 @@ package config
 
 import (
 	"bytes"
+	"crypto/rsa"
+	"crypto/x509"
 	"database/sql"
 	"database/sql/driver"
 	"encoding/base64"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/url"
+	"strings"
 
+	goldap "github.com/go-ldap/ldap/v3"
 	"gopkg.in/yaml.v2"
 
 	"github.com/skygeario/skygear-server/pkg/core/auth/metadata"
@@ -171,6 +178,11 @@ func (c *TenantConfiguration) DefaultSensitiveLoggerValues() []string {
 		values[i] = clientConfig.ClientID()
 		i++
 	}
+	for _, clientConfig := range c.AppConfig.Clients {
+		if secret := clientConfig.ClientSecret(); secret != "" {
+			values = append(values, secret)
+		}
+	}
 
 	values = append(values,
 		c.AppConfig.Authentication.Secret,
@@ -192,6 +204,7 @@ func (c *TenantConfiguration) DefaultSensitiveLoggerValues() []string {
 		oauthSecrets[i*2+1] = oauthConfig.ClientSecret
 	}
 	values = append(values, oauthSecrets...)
+	values = append(values, c.AppConfig.OIDC.InitialAccessTokens...)
 	return values
 }
 
@@ -211,11 +224,11 @@ func (c *TenantConfiguration) PostValidate() error {
 			return fail(validation.ErrorGeneral, "master key must not be same as client_id", "user_config", "master_key")
 		}
 
-		if clientConfig.RefreshTokenLifetime() < clientConfig.AccessTokenLifetime() {
-			return fail(
-				validation.ErrorGeneral,
-				"refresh token lifetime must be greater than or equal to access token lifetime",
-				"user_config", "clients", key, "refresh_token_lifetime")
+		err := validateOAuthClient(clientConfig, func(kind validation.ErrorCauseKind, msg string, field string) error {
+			return fail(kind, msg, "user_config", "clients", key, field)
+		})
+		if err != nil {
+			return err
 		}
 	}
 
@@ -246,6 +259,144 @@ func (c *TenantConfiguration) PostValidate() error {
 				"user_config", "identity", "oauth", "providers", i)
 		}
 		seenOAuthProviderID[provider.ID] = struct{}{}
+
+		if provider.Type == OAuthProviderTypeSAML {
+			if _, err := provider.IdPCertificates(); err != nil {
+				return fail(
+					validation.ErrorGeneral,
+					"invalid IdP certificate",
+					"user_config", "identity", "oauth", "providers", i, "idp_certificate")
+			}
+		}
+	}
+
+	// Validate OIDC signing keys
+	seenKID := map[string]struct{}{}
+	activeKeys := 0
+	for i, key := range c.AppConfig.OIDC.Keys {
+		if _, ok := seenKID[key.KID]; ok {
+			return fail(
+				validation.ErrorGeneral,
+				"duplicated signing key ID",
+				"user_config", "oidc", "keys", i)
+		}
+		seenKID[key.KID] = struct{}{}
+		if key.State == OIDCSigningKeyStateActive {
+			activeKeys++
+		}
+	}
+	if len(c.AppConfig.OIDC.Keys) > 0 && activeKeys != 1 {
+		return fail(
+			validation.ErrorGeneral,
+			"exactly one signing key must be active",
+			"user_config", "oidc", "keys")
+	}
+
+	// Validate custom scopes
+	seenScope := map[string]struct{}{}
+	for i, scope := range c.AppConfig.OIDC.Scopes {
+		if isBuiltinOIDCScope(scope.Name) {
+			return fail(
+				validation.ErrorGeneral,
+				"scope name is reserved",
+				"user_config", "oidc", "scopes", i, "name")
+		}
+		for claim := range scope.Claims {
+			if isReservedOIDCClaim(claim) {
+				return fail(
+					validation.ErrorGeneral,
+					"claim name is reserved",
+					"user_config", "oidc", "scopes", i, "claims", claim)
+			}
+		}
+		if _, ok := seenScope[scope.Name]; ok {
+			return fail(
+				validation.ErrorGeneral,
+				"duplicated scope",
+				"user_config", "oidc", "scopes", i)
+		}
+		seenScope[scope.Name] = struct{}{}
+	}
+	for key, clientConfig := range c.AppConfig.Clients {
+		for _, scope := range clientConfig.AllowedScopes() {
+			if _, ok := seenScope[scope]; !ok {
+				return fail(
+					validation.ErrorGeneral,
+					"allowed scope is not declared",
+					"user_config", "clients", key, "allowed_scopes")
+			}
+		}
+	}
+
+	// Validate SAML IdP
+	if len(c.AppConfig.SAMLIdP.ServiceProviders) > 0 {
+		if _, _, err := c.AppConfig.SAMLIdP.SigningKey(); err != nil {
+			return fail(
+				validation.ErrorGeneral,
+				"invalid SAML IdP signing key",
+				"user_config", "saml_idp")
+		}
+	}
+	seenEntityID := map[string]struct{}{}
+	for i, sp := range c.AppConfig.SAMLIdP.ServiceProviders {
+		if _, ok := seenEntityID[sp.EntityID]; ok {
+			return fail(
+				validation.ErrorGeneral,
+				"duplicated service provider entity ID",
+				"user_config", "saml_idp", "service_providers", i)
+		}
+		seenEntityID[sp.EntityID] = struct{}{}
+		if _, err := sp.Certificates(); err != nil {
+			return fail(
+				validation.ErrorGeneral,
+				"invalid service provider certificate",
+				"user_config", "saml_idp", "service_providers", i, "certificate")
+		}
+	}
+
+	// Validate LDAP
+	ldapIdentityEnabled := false
+	for _, i := range c.AppConfig.Authentication.Identities {
+		if i == "ldap" {
+			ldapIdentityEnabled = true
+		}
+	}
+	ldapAuthenticatorEnabled := false
+	for _, a := range c.AppConfig.Authentication.PrimaryAuthenticators {
+		if a == "ldap" {
+			ldapAuthenticatorEnabled = true
+		}
+	}
+	// LDAP identity is only proven by binding to the directory, so the
+	// identity and the authenticator must be enabled together.
+	if ldapIdentityEnabled != ldapAuthenticatorEnabled {
+		return fail(
+			validation.ErrorGeneral,
+			"LDAP identity and LDAP authenticator must be enabled together",
+			"user_config", "authentication")
+	}
+	if ldapIdentityEnabled {
+		ldapConfig := c.AppConfig.Identity.LDAP
+		if u, err := url.Parse(ldapConfig.URL); err != nil || (u.Scheme != "ldap" && u.Scheme != "ldaps") || u.Host == "" {
+			return fail(
+				validation.ErrorGeneral,
+				"invalid LDAP URL",
+				"user_config", "identity", "ldap", "url")
+		}
+		if !strings.Contains(ldapConfig.Filter, "{username}") || !isValidLDAPFilter(ldapConfig.Filter) {
+			return fail(
+				validation.ErrorGeneral,
+				"invalid LDAP search filter",
+				"user_config", "identity", "ldap", "filter")
+		}
+		if ldapConfig.CACertificate != "" {
+			if _, err := ldapConfig.CACertificates(); err != nil {
+				return fail(
+					validation.ErrorGeneral,
+					"invalid LDAP CA certificate",
+					"user_config", "identity", "ldap", "ca_certificate")
+			}
+		}
 	}
 
 	// Validate AuthenticationConfiguration
@@ -308,18 +459,12 @@ func (c *TenantConfiguration) AfterUnmarshal() {
 		c.AppConfig.Session.IdleTimeout = 300
 	}
 
+	// Set default OIDC signing key states
+	c.AppConfig.OIDC.SetKeyDefaults()
+
 	// Set default APIClientConfiguration values
-	for i, clientConfig := range c.AppConfig.Clients {
-		if clientConfig.AccessTokenLifetime() == 0 {
-			clientConfig.SetAccessTokenLifetime(1800)
-		}
-		if clientConfig.RefreshTokenLifetime() == 0 {
-			clientConfig.SetRefreshTokenLifetime(86400)
-			if clientConfig.AccessTokenLifetime() > clientConfig.RefreshTokenLifetime() {
-				clientConfig.SetRefreshTokenLifetime(clientConfig.AccessTokenLifetime())
-			}
-		}
-		c.AppConfig.Clients[i] = clientConfig
+	for _, clientConfig := range c.AppConfig.Clients {
+		clientConfig.SetDefaults()
 	}
 
 	// Set default AuthConfiguration
@@ -471,6 +616,20 @@ func (c *TenantConfiguration) AfterUnmarshal() {
 		emailMsg["sender"] = "no-reply@skygear.io"
 	}
 
+	// Set default LDAPConfiguration
+	if c.AppConfig.Identity.LDAP.Filter == "" {
+		c.AppConfig.Identity.LDAP.Filter = "(uid={username})"
+	}
+	if c.AppConfig.Identity.LDAP.DisplayName == "" {
+		c.AppConfig.Identity.LDAP.DisplayName = "LDAP"
+	}
+	if c.AppConfig.Identity.LDAP.Attributes.ID == "" {
+		c.AppConfig.Identity.LDAP.Attributes.ID = "entryUUID"
+	}
+	if c.AppConfig.Identity.LDAP.Attributes.Email == "" {
+		c.AppConfig.Identity.LDAP.Attributes.Email = "mail"
+	}
+
 	// Set type to id
 	// Set default scope for OAuth Provider
 	for i, provider := range c.AppConfig.Identity.OAuth.Providers {
@@ -504,6 +663,31 @@ func (c *TenantConfiguration) AfterUnmarshal() {
 			if provider.Scope == "" {
 				c.AppConfig.Identity.OAuth.Providers[i].Scope = "email"
 			}
+		case OAuthProviderTypeOIDC:
+			if provider.Scope == "" {
+				// https://openid.net/specs/openid-connect-core-1_0.html#ScopeClaims
+				c.AppConfig.Identity.OAuth.Providers[i].Scope = "openid profile email"
+			}
+			if provider.DisplayName == "" {
+				c.AppConfig.Identity.OAuth.Providers[i].DisplayName = c.AppConfig.Identity.OAuth.Providers[i].ID
+			}
+		case OAuthProviderTypeSAML:
+			if provider.DisplayName == "" {
+				c.AppConfig.Identity.OAuth.Providers[i].DisplayName = c.AppConfig.Identity.OAuth.Providers[i].ID
+			}
+		case OAuthProviderTypeGitHub:
+			if provider.Scope == "" {
+				// https://docs.github.com/en/developers/apps/building-oauth-apps/scopes-for-oauth-apps
+				c.AppConfig.Identity.OAuth.Providers[i].Scope = "read:user user:email"
+			}
+		case OAuthProviderTypeGitLab:
+			if provider.Scope == "" {
+				// https://docs.gitlab.com/ee/integration/oauth_provider.html#authorized-applications
+				c.AppConfig.Identity.OAuth.Providers[i].Scope = "read_user"
+			}
+			if provider.BaseURL == "" {
+				c.AppConfig.Identity.OAuth.Providers[i].BaseURL = "https://gitlab.com"
+			}
 		}
 	}
 
@@ -515,13 +699,19 @@ func (c *TenantConfiguration) AfterUnmarshal() {
 		c.AppConfig.AuthUI.CountryCallingCode.Default = c.AppConfig.AuthUI.CountryCallingCode.Values[0]
 	}
 
-	// Set default hook timeout
+	// Set default hook timeout and delivery attempts
 	if c.Hook.SyncHookTimeout == 0 {
 		c.Hook.SyncHookTimeout = 5
 	}
 	if c.Hook.SyncHookTotalTimeout == 0 {
 		c.Hook.SyncHookTotalTimeout = 10
 	}
+	if c.Hook.AsyncHookTimeout == 0 {
+		c.Hook.AsyncHookTimeout = 60
+	}
+	if c.Hook.AsyncHookMaxAttempts == 0 {
+		c.Hook.AsyncHookMaxAttempts = 10
+	}
 }
 
 func ReadTenantConfig(r *http.Request) TenantConfiguration {
@@ -555,6 +745,7 @@ type AppConfiguration struct {
 	Authentication   *AuthenticationConfiguration   `json:"authentication,omitempty" yaml:"authentication" msg:"authentication" default_zero_value:"true"`
 	AuthUI           *AuthUIConfiguration           `json:"auth_ui,omitempty" yaml:"auth_ui" msg:"auth_ui" default_zero_value:"true"`
 	OIDC             *OIDCConfiguration             `json:"oidc,omitempty" yaml:"oidc" msg:"oidc" default_zero_value:"true"`
+	SAMLIdP          *SAMLIdPConfiguration          `json:"saml_idp,omitempty" yaml:"saml_idp" msg:"saml_idp" default_zero_value:"true"`
 	Authenticator    *AuthenticatorConfiguration    `json:"authenticator,omitempty" yaml:"authenticator" msg:"authenticator" default_zero_value:"true"`
 	ForgotPassword   *ForgotPasswordConfiguration   `json:"forgot_password,omitempty" yaml:"forgot_password" msg:"forgot_password" default_zero_value:"true"`
 	WelcomeMessage   *WelcomeMessageConfiguration   `json:"welcome_message,omitempty" yaml:"welcome_message" msg:"welcome_message" default_zero_value:"true"`
@@ -573,6 +764,13 @@ type AssetConfiguration struct {
 	Secret string `json:"secret,omitempty" yaml:"secret" msg:"secret"`
 }
 
+const (
+	ClientAuthMethodNone              = "none"
+	ClientAuthMethodClientSecretBasic = "client_secret_basic"
+	ClientAuthMethodClientSecretPost  = "client_secret_post"
+	ClientAuthMethodPrivateKeyJWT     = "private_key_jwt"
+)
+
 type OAuthClientConfiguration map[string]interface{}
 
 func (c OAuthClientConfiguration) ClientID() string {
@@ -589,6 +787,54 @@ func (c OAuthClientConfiguration) ClientURI() string {
 	return ""
 }
 
+// ClientName returns the name of the client shown to users. It defaults to
+// the client ID.
+func (c OAuthClientConfiguration) ClientName() string {
+	if s, ok := c["client_name"].(string); ok && s != "" {
+		return s
+	}
+	return c.ClientID()
+}
+
+func (c OAuthClientConfiguration) ClientSecret() string {
+	if s, ok := c["client_secret"].(string); ok {
+		return s
+	}
+	return ""
+}
+
+// ClientSecretHash returns the hash of client secret. Secrets of clients
+// registered dynamically are stored as hash instead.
+func (c OAuthClientConfiguration) ClientSecretHash() string {
+	if s, ok := c["client_secret_hash"].(string); ok {
+		return s
+	}
+	return ""
+}
+
+// TokenEndpointAuthMethod returns the method used by the client to
+// authenticate at token endpoint. Clients without a configured method are
+// public clients.
+func (c OAuthClientConfiguration) TokenEndpointAuthMethod() string {
+	if s, ok := c["token_endpoint_auth_method"].(string); ok {
+		return s
+	}
+	return ClientAuthMethodNone
+}
+
+func (c OAuthClientConfiguration) IsConfidential() bool {
+	return c.TokenEndpointAuthMethod() != ClientAuthMethodNone
+}
+
+// JWKS returns the JSON Web Key Set of the client, used to verify
+// private_key_jwt client assertions.
+func (c OAuthClientConfiguration) JWKS() map[string]interface{} {
+	if m, ok := c["jwks"].(map[string]interface{}); ok {
+		return m
+	}
+	return nil
+}
+
 func (c OAuthClientConfiguration) RedirectURIs() (out []string) {
 	if arr, ok := c["redirect_uris"].([]interface{}); ok {
 		for _, item := range arr {
@@ -622,6 +868,58 @@ func (c OAuthClientConfiguration) RefreshTokenLifetime() int {
 	return 0
 }
 
+func (c OAuthClientConfiguration) RefreshTokenRotationEnabled() bool {
+	if b, ok := c["refresh_token_rotation"].(bool); ok {
+		return b
+	}
+	return false
+}
+
+// JWTAccessTokenEnabled returns whether self-contained JWT access tokens are
+// issued to the client, instead of opaque access tokens.
+func (c OAuthClientConfiguration) JWTAccessTokenEnabled() bool {
+	if b, ok := c["jwt_access_token"].(bool); ok {
+		return b
+	}
+	return false
+}
+
+// IsFirstParty returns whether the client is trusted by the app. First-party
+// clients are granted requested scopes without asking users for consent.
+// Clients are first-party unless configured otherwise; clients registered
+// dynamically are registered as third-party by default.
+func (c OAuthClientConfiguration) IsFirstParty() bool {
+	if b, ok := c["is_first_party"].(bool); ok {
+		return b
+	}
+	return true
+}
+
+// ClientCredentialsScopes returns the scopes that can be granted to the client
+// itself with client credentials grant.
+func (c OAuthClientConfiguration) ClientCredentialsScopes() (out []string) {
+	if arr, ok := c["client_credentials_scopes"].([]interface{}); ok {
+		for _, item := range arr {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+	}
+	return out
+}
+
+// AllowedScopes returns the custom scopes the client may request.
+func (c OAuthClientConfiguration) AllowedScopes() (out []string) {
+	if arr, ok := c["allowed_scopes"].([]interface{}); ok {
+		for _, item := range arr {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+	}
+	return out
+}
+
 func (c OAuthClientConfiguration) GrantTypes() (out []string) {
 	if arr, ok := c["grant_types"].([]interface{}); ok {
 		for _, item := range arr {
@@ -655,6 +953,194 @@ func (c OAuthClientConfiguration) PostLogoutRedirectURIs() (out []string) {
 	return out
 }
 
+// BackChannelLogoutURI returns the URI receiving logout tokens when sessions
+// of the client are ended.
+func (c OAuthClientConfiguration) BackChannelLogoutURI() string {
+	if s, ok := c["backchannel_logout_uri"].(string); ok {
+		return s
+	}
+	return ""
+}
+
+// FrontChannelLogoutURI returns the URI rendered in an iframe by the logout
+// page when sessions of the client are ended.
+func (c OAuthClientConfiguration) FrontChannelLogoutURI() string {
+	if s, ok := c["frontchannel_logout_uri"].(string); ok {
+		return s
+	}
+	return ""
+}
+
+// FrontChannelLogoutSessionRequired returns whether iss and sid are added to
+// the front-channel logout URI.
+func (c OAuthClientConfiguration) FrontChannelLogoutSessionRequired() bool {
+	if b, ok := c["frontchannel_logout_session_required"].(bool); ok {
+		return b
+	}
+	return false
+}
+
+// RequirePushedAuthorizationRequests returns whether the client must push
+// authorization requests to the pushed authorization request endpoint.
+func (c OAuthClientConfiguration) RequirePushedAuthorizationRequests() bool {
+	if b, ok := c["require_pushed_authorization_requests"].(bool); ok {
+		return b
+	}
+	return false
+}
+
+// SetDefaults sets the default token lifetimes of the client.
+func (c OAuthClientConfiguration) SetDefaults() {
+	if c.AccessTokenLifetime() == 0 {
+		c.SetAccessTokenLifetime(1800)
+	}
+	if c.RefreshTokenLifetime() == 0 {
+		c.SetRefreshTokenLifetime(86400)
+		if c.AccessTokenLifetime() > c.RefreshTokenLifetime() {
+			c.SetRefreshTokenLifetime(c.AccessTokenLifetime())
+		}
+	}
+}
+
+// ValidateOAuthClient validates a client not from tenant config, such as
+// clients registered dynamically.
+func (c *AppConfiguration) ValidateOAuthClient(client OAuthClientConfiguration) error {
+	err := tenantConfigurationValidator.
+		WithMessage("invalid client metadata").
+		ValidateGoValue("#APIClientConfiguration", map[string]interface{}(client))
+	if err != nil {
+		return err
+	}
+
+	fail := func(kind validation.ErrorCauseKind, msg string, field string) error {
+		return validation.NewValidationFailed("invalid client metadata", []validation.ErrorCause{{
+			Kind:    kind,
+			Pointer: validation.JSONPointer(field),
+			Message: msg,
+		}})
+	}
+
+	if client.ClientID() == c.MasterKey {
+		return fail(validation.ErrorGeneral, "master key must not be same as client_id", "client_id")
+	}
+	err = validateOAuthClient(client, fail)
+	if err != nil {
+		return err
+	}
+
+	for _, scope := range client.AllowedScopes() {
+		declared := false
+		for _, s := range c.OIDC.Scopes {
+			if s.Name == scope {
+				declared = true
+				break
+			}
+		}
+		if !declared {
+			return fail(validation.ErrorGeneral, "allowed scope is not declared", "allowed_scopes")
+		}
+	}
+
+	return nil
+}
+
+func validateOAuthClient(
+	c OAuthClientConfiguration,
+	fail func(kind validation.ErrorCauseKind, msg string, field string) error,
+) error {
+	if c.RefreshTokenLifetime() < c.AccessTokenLifetime() {
+		return fail(
+			validation.ErrorGeneral,
+			"refresh token lifetime must be greater than or equal to access token lifetime",
+			"refresh_token_lifetime")
+	}
+
+	for _, grantType := range c.GrantTypes() {
+		if grantType == "client_credentials" && !c.IsConfidential() {
+			return fail(
+				validation.ErrorGeneral,
+				"client credentials grant is allowed only for confidential clients",
+				"grant_types")
+		}
+	}
+
+	for _, redirectURI := range c.RedirectURIs() {
+		if err := ValidateRedirectURI(redirectURI); err != nil {
+			return fail(validation.ErrorGeneral, err.Error(), "redirect_uris")
+		}
+	}
+
+	for _, field := range []string{"backchannel_logout_uri", "frontchannel_logout_uri"} {
+		s, ok := c[field].(string)
+		if !ok || s == "" {
+			continue
+		}
+		u, err := url.Parse(s)
+		if err != nil || u.Scheme != "https" || u.Host == "" {
+			return fail(
+				validation.ErrorGeneral,
+				"logout URI must be an absolute https URI",
+				field)
+		}
+	}
+
+	switch c.TokenEndpointAuthMethod() {
+	case ClientAuthMethodClientSecretBasic, ClientAuthMethodClientSecretPost:
+		if c.ClientSecret() == "" && c.ClientSecretHash() == "" {
+			return fail(
+				validation.ErrorRequired,
+				"client secret is required for the client authentication method",
+				"client_secret")
+		}
+	case ClientAuthMethodPrivateKeyJWT:
+		if c.JWKS() == nil {
+			return fail(
+				validation.ErrorRequired,
+				"JWKS is required for the client authentication method",
+				"jwks")
+		}
+	}
+
+	return nil
+}
+
+// ValidateRedirectURI checks the redirect URI is safe to redirect to.
+// Only https URIs, http URIs of loopback hosts and private-use URI schemes
+// of native apps (in reverse domain name notation) are allowed.
+func ValidateRedirectURI(redirectURI string) error {
+	u, err := url.Parse(redirectURI)
+	if err != nil || !u.IsAbs() || u.Fragment != "" {
+		return errors.New("redirect URI must be an absolute URI without fragment")
+	}
+
+	switch scheme := strings.ToLower(u.Scheme); scheme {
+	case "https":
+		if u.Host == "" {
+			return errors.New("redirect URI must have a host")
+		}
+	case "http":
+		if !isLoopbackHost(u.Hostname()) {
+			return errors.New("http redirect URI is allowed only for loopback hosts")
+		}
+	case "javascript", "data", "vbscript", "file":
+		return errors.New("redirect URI scheme is not allowed")
+	default:
+		// https://tools.ietf.org/html/rfc8252#section-7.1
+		if !strings.Contains(scheme, ".") {
+			return errors.New("private-use redirect URI scheme must be in reverse domain name notation")
+		}
+	}
+	return nil
+}
+
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
 type SessionConfiguration struct {
 	Lifetime            int     `json:"lifetime,omitempty" yaml:"lifetime" msg:"lifetime"`
 	IdleTimeoutEnabled  bool    `json:"idle_timeout_enabled,omitempty" yaml:"idle_timeout_enabled" msg:"idle_timeout_enabled"`
@@ -673,13 +1159,224 @@ type CORSConfiguration struct {
 }
 
 type OIDCConfiguration struct {
-	Keys []OIDCSigningKeyConfiguration `json:"keys,omitempty" yaml:"keys" msg:"keys"`
+	Keys   []OIDCSigningKeyConfiguration `json:"keys,omitempty" yaml:"keys" msg:"keys"`
+	Scopes []OIDCScopeConfiguration      `json:"scopes,omitempty" yaml:"scopes" msg:"scopes"`
+	// InitialAccessTokens are tokens allowed to register clients dynamically,
+	// in addition to the master key.
+	InitialAccessTokens []string `json:"initial_access_tokens,omitempty" yaml:"initial_access_tokens" msg:"initial_access_tokens"`
+}
+
+// OIDCScopeConfiguration declares a custom scope. Claims maps claim names to
+// keys of user metadata, which are included in ID token and userinfo when
+// the scope is granted.
+type OIDCScopeConfiguration struct {
+	Name        string            `json:"name,omitempty" yaml:"name" msg:"name"`
+	Description string            `json:"description,omitempty" yaml:"description" msg:"description"`
+	Claims      map[string]string `json:"claims,omitempty" yaml:"claims" msg:"claims"`
+}
+
+// NOTE(oidc): keep in sync with oidc.AllowedScopes.
+var builtinOIDCScopes = []string{
+	"openid",
+	"offline_access",
+	"profile",
+	"email",
+	"phone",
+	"https://auth.skygear.io/scopes/full-access",
+}
+
+// reservedOIDCClaims are the claims issued by the server, which custom
+// scopes cannot map to.
+// NOTE(oidc): keep in sync with oidc.UserClaims and oidc.IDTokenClaims.
+var reservedOIDCClaims = []string{
+	// Registered claims
+	"iss",
+	"sub",
+	"aud",
+	"exp",
+	"nbf",
+	"iat",
+	"jti",
+	// ID token claims
+	"nonce",
+	"sid",
+	"auth_time",
+	"acr",
+	"amr",
+	"azp",
+	"at_hash",
+	"c_hash",
+	// Standard claims
+	"name",
+	"preferred_username",
+	"updated_at",
+	"email",
+	"email_verified",
+	"phone_number",
+	"phone_number_verified",
+	// Skygear claims
+	"skygear_user",
+	"skygear_session_id",
+}
+
+func isBuiltinOIDCScope(scope string) bool {
+	for _, s := range builtinOIDCScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func isReservedOIDCClaim(claim string) bool {
+	for _, c := range reservedOIDCClaims {
+		if c == claim {
+			return true
+		}
+	}
+	return false
 }
 
 type OIDCSigningKeyConfiguration struct {
-	KID        string `json:"kid,omitempty" yaml:"kid" msg:"kid"`
-	PublicKey  string `json:"public_key,omitempty" yaml:"public_key" msg:"public_key"`
-	PrivateKey string `json:"private_key,omitempty" yaml:"private_key" msg:"private_key"`
+	KID        string              `json:"kid,omitempty" yaml:"kid" msg:"kid"`
+	State      OIDCSigningKeyState `json:"state,omitempty" yaml:"state" msg:"state"`
+	PublicKey  string              `json:"public_key,omitempty" yaml:"public_key" msg:"public_key"`
+	PrivateKey string              `json:"private_key,omitempty" yaml:"private_key" msg:"private_key"`
+}
+
+// OIDCSigningKeyState is the state of a signing key in key rotation.
+type OIDCSigningKeyState string
+
+const (
+	// The active key signs tokens. There is exactly one active key.
+	OIDCSigningKeyStateActive OIDCSigningKeyState = "active"
+	// Published keys do not sign tokens, but tokens signed by them are
+	// still valid. A new key is published before it becomes active, and an
+	// old key is kept published until tokens signed by it are expired.
+	OIDCSigningKeyStatePublished OIDCSigningKeyState = "published"
+	// Retired keys are no longer published.
+	OIDCSigningKeyStateRetired OIDCSigningKeyState = "retired"
+)
+
+// SetKeyDefaults sets the state of keys without explicit state. The first of
+// them becomes active if there is no active key; others are published.
+func (c *OIDCConfiguration) SetKeyDefaults() {
+	hasActiveKey := false
+	for _, key := range c.Keys {
+		if key.State == OIDCSigningKeyStateActive {
+			hasActiveKey = true
+		}
+	}
+	for i, key := range c.Keys {
+		if key.State != "" {
+			continue
+		}
+		if !hasActiveKey {
+			c.Keys[i].State = OIDCSigningKeyStateActive
+			hasActiveKey = true
+		} else {
+			c.Keys[i].State = OIDCSigningKeyStatePublished
+		}
+	}
+}
+
+// ActiveKey returns the key signing tokens, or nil if there is none.
+func (c *OIDCConfiguration) ActiveKey() *OIDCSigningKeyConfiguration {
+	for i, key := range c.Keys {
+		if key.State == OIDCSigningKeyStateActive {
+			return &c.Keys[i]
+		}
+	}
+	return nil
+}
+
+// PublishedKeys returns the keys that are not retired, which tokens are
+// verified with.
+func (c *OIDCConfiguration) PublishedKeys() []OIDCSigningKeyConfiguration {
+	var keys []OIDCSigningKeyConfiguration
+	for _, key := range c.Keys {
+		if key.State != OIDCSigningKeyStateRetired {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// SAMLIdPConfiguration configures the auth gear as a SAML 2.0 identity
+// provider of the registered service providers.
+type SAMLIdPConfiguration struct {
+	// Certificate and PrivateKey are the PEM encoded RSA key pair signing
+	// responses. The certificate is published in IdP metadata.
+	Certificate      string                             `json:"certificate,omitempty" yaml:"certificate" msg:"certificate"`
+	PrivateKey       string                             `json:"private_key,omitempty" yaml:"private_key" msg:"private_key"`
+	ServiceProviders []SAMLServiceProviderConfiguration `json:"service_providers,omitempty" yaml:"service_providers" msg:"service_providers"`
+}
+
+type SAMLServiceProviderConfiguration struct {
+	EntityID string `json:"entity_id,omitempty" yaml:"entity_id" msg:"entity_id"`
+	ACSURL   string `json:"acs_url,omitempty" yaml:"acs_url" msg:"acs_url"`
+	// SLOURL receives logout responses; single logout is not supported
+	// if it is empty.
+	SLOURL string `json:"slo_url,omitempty" yaml:"slo_url,omitempty" msg:"slo_url"`
+	// Certificate verifies signatures of logout requests, and of
+	// authentication requests if WantAuthnRequestsSigned is set. Multiple
+	// certificates may be configured to rotate them.
+	Certificate string `json:"certificate,omitempty" yaml:"certificate" msg:"certificate"`
+	// WantAuthnRequestsSigned rejects authentication requests that are not
+	// signed by the service provider.
+	WantAuthnRequestsSigned bool `json:"want_authn_requests_signed,omitempty" yaml:"want_authn_requests_signed" msg:"want_authn_requests_signed"`
+}
+
+// Certificates parses the PEM encoded signing certificates of the service
+// provider.
+func (c SAMLServiceProviderConfiguration) Certificates() ([]*x509.Certificate, error) {
+	return parseCertificates(c.Certificate)
+}
+
+// ServiceProvider returns the service provider with the entity ID, or nil
+// if it is not registered.
+func (c *SAMLIdPConfiguration) ServiceProvider(entityID string) *SAMLServiceProviderConfiguration {
+	for i, sp := range c.ServiceProviders {
+		if sp.EntityID == entityID {
+			return &c.ServiceProviders[i]
+		}
+	}
+	return nil
+}
+
+// SigningKey parses the key pair signing responses.
+func (c *SAMLIdPConfiguration) SigningKey() (*rsa.PrivateKey, *x509.Certificate, error) {
+	certs, err := parseCertificates(c.Certificate)
+	if err != nil {
+		return nil, nil, err
+	}
+	cert := certs[0]
+
+	block, _ := pem.Decode([]byte(c.PrivateKey))
+	if block == nil {
+		return nil, nil, errors.New("no private key found")
+	}
+	var key interface{}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		err = fmt.Errorf("unexpected private key type: %s", block.Type)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, nil, errors.New("private key must be RSA key")
+	}
+	if pub, ok := cert.PublicKey.(*rsa.PublicKey); !ok || pub.N.Cmp(rsaKey.N) != 0 || pub.E != rsaKey.E {
+		return nil, nil, errors.New("certificate does not match private key")
+	}
+	return rsaKey, cert, nil
 }
 
 type ForgotPasswordConfiguration struct {
@@ -757,6 +1454,9 @@ func (c *UserVerificationConfiguration) GetLoginIDKey(key string) (*UserVerifica
 
 type HookAppConfiguration struct {
 	Secret string `json:"secret,omitempty" yaml:"secret" msg:"secret"`
+	// AdditionalSecrets are also used to sign requests, so that secrets can
+	// be rotated without downtime.
+	AdditionalSecrets []string `json:"additional_secrets,omitempty" yaml:"additional_secrets" msg:"additional_secrets"`
 }
 
 // DatabaseConfiguration is database configuration.
@@ -805,9 +1505,16 @@ func (c NexmoConfiguration) IsValid() bool {
 type HookTenantConfiguration struct {
 	SyncHookTimeout      int `json:"sync_hook_timeout_second,omitempty" yaml:"sync_hook_timeout_second" msg:"sync_hook_timeout_second"`
 	SyncHookTotalTimeout int `json:"sync_hook_total_timeout_second,omitempty" yaml:"sync_hook_total_timeout_second" msg:"sync_hook_total_timeout_second"`
+	AsyncHookTimeout     int `json:"async_hook_timeout_second,omitempty" yaml:"async_hook_timeout_second" msg:"async_hook_timeout_second"`
+	AsyncHookMaxAttempts int `json:"async_hook_max_attempts,omitempty" yaml:"async_hook_max_attempts" msg:"async_hook_max_attempts"`
 }
 
 var (
 	_ sql.Scanner   = &TenantConfiguration{}
 	_ driver.Valuer = &TenantConfiguration{}
 )
+
+func isValidLDAPFilter(filter string) bool {
+	_, err := goldap.CompileFilter(filter)
+	return err == nil
+}
@@ -192,6 +192,24 @@ func (z *AppConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 					return
 				}
 			}
+		case "saml_idp":
+			if dc.IsNil() {
+				err = dc.ReadNil()
+				if err != nil {
+					err = msgp.WrapError(err, "SAMLIdP")
+					return
+				}
+				z.SAMLIdP = nil
+			} else {
+				if z.SAMLIdP == nil {
+					z.SAMLIdP = new(SAMLIdPConfiguration)
+				}
+				err = z.SAMLIdP.DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "SAMLIdP")
+					return
+				}
+			}
 		case "authenticator":
 			if dc.IsNil() {
 				err = dc.ReadNil()
@@ -349,6 +367,25 @@ func (z *AppConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 							err = msgp.WrapError(err, "Hook", "Secret")
 							return
 						}
+					case "additional_secrets":
+						var zb0007 uint32
+						zb0007, err = dc.ReadArrayHeader()
+						if err != nil {
+							err = msgp.WrapError(err, "Hook", "AdditionalSecrets")
+							return
+						}
+						if cap(z.Hook.AdditionalSecrets) >= int(zb0007) {
+							z.Hook.AdditionalSecrets = (z.Hook.AdditionalSecrets)[:zb0007]
+						} else {
+							z.Hook.AdditionalSecrets = make([]string, zb0007)
+						}
+						for za0004 := range z.Hook.AdditionalSecrets {
+							z.Hook.AdditionalSecrets[za0004], err = dc.ReadString()
+							if err != nil {
+								err = msgp.WrapError(err, "Hook", "AdditionalSecrets", za0004)
+								return
+							}
+						}
 					default:
 						err = dc.Skip()
 						if err != nil {
@@ -406,14 +443,14 @@ func (z *AppConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 				if z.Twilio == nil {
 					z.Twilio = new(TwilioConfiguration)
 				}
-				var zb0007 uint32
-				zb0007, err = dc.ReadMapHeader()
+				var zb0008 uint32
+				zb0008, err = dc.ReadMapHeader()
 				if err != nil {
 					err = msgp.WrapError(err, "Twilio")
 					return
 				}
-				for zb0007 > 0 {
-					zb0007--
+				for zb0008 > 0 {
+					zb0008--
 					field, err = dc.ReadMapKeyPtr()
 					if err != nil {
 						err = msgp.WrapError(err, "Twilio")
@@ -453,14 +490,14 @@ func (z *AppConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 				if z.Nexmo == nil {
 					z.Nexmo = new(NexmoConfiguration)
 				}
-				var zb0008 uint32
-				zb0008, err = dc.ReadMapHeader()
+				var zb0009 uint32
+				zb0009, err = dc.ReadMapHeader()
 				if err != nil {
 					err = msgp.WrapError(err, "Nexmo")
 					return
 				}
-				for zb0008 > 0 {
-					zb0008--
+				for zb0009 > 0 {
+					zb0009--
 					field, err = dc.ReadMapKeyPtr()
 					if err != nil {
 						err = msgp.WrapError(err, "Nexmo")
@@ -518,14 +555,14 @@ func (z *AppConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 				if z.Asset == nil {
 					z.Asset = new(AssetConfiguration)
 				}
-				var zb0009 uint32
-				zb0009, err = dc.ReadMapHeader()
+				var zb0010 uint32
+				zb0010, err = dc.ReadMapHeader()
 				if err != nil {
 					err = msgp.WrapError(err, "Asset")
 					return
 				}
-				for zb0009 > 0 {
-					zb0009--
+				for zb0010 > 0 {
+					zb0010--
 					field, err = dc.ReadMapKeyPtr()
 					if err != nil {
 						err = msgp.WrapError(err, "Asset")
@@ -560,9 +597,9 @@ func (z *AppConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *AppConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 20
+	// map header, size 21
 	// write "api_version"
-	err = en.Append(0xde, 0x0, 0x14, 0xab, 0x61, 0x70, 0x69, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e)
+	err = en.Append(0xde, 0x0, 0x15, 0xab, 0x61, 0x70, 0x69, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e)
 	if err != nil {
 		return
 	}
@@ -701,6 +738,23 @@ func (z *AppConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
 			return
 		}
 	}
+	// write "saml_idp"
+	err = en.Append(0xa8, 0x73, 0x61, 0x6d, 0x6c, 0x5f, 0x69, 0x64, 0x70)
+	if err != nil {
+		return
+	}
+	if z.SAMLIdP == nil {
+		err = en.WriteNil()
+		if err != nil {
+			return
+		}
+	} else {
+		err = z.SAMLIdP.EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "SAMLIdP")
+			return
+		}
+	}
 	// write "authenticator"
 	err = en.Append(0xad, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72)
 	if err != nil {
@@ -823,9 +877,9 @@ func (z *AppConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
 			return
 		}
 	} else {
-		// map header, size 1
+		// map header, size 2
 		// write "secret"
-		err = en.Append(0x81, 0xa6, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74)
+		err = en.Append(0x82, 0xa6, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74)
 		if err != nil {
 			return
 		}
@@ -834,6 +888,23 @@ func (z *AppConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
 			err = msgp.WrapError(err, "Hook", "Secret")
 			return
 		}
+		// write "additional_secrets"
+		err = en.Append(0xb2, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73)
+		if err != nil {
+			return
+		}
+		err = en.WriteArrayHeader(uint32(len(z.Hook.AdditionalSecrets)))
+		if err != nil {
+			err = msgp.WrapError(err, "Hook", "AdditionalSecrets")
+			return
+		}
+		for za0004 := range z.Hook.AdditionalSecrets {
+			err = en.WriteString(z.Hook.AdditionalSecrets[za0004])
+			if err != nil {
+				err = msgp.WrapError(err, "Hook", "AdditionalSecrets", za0004)
+				return
+			}
+		}
 	}
 	// write "messages"
 	err = en.Append(0xa8, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73)
@@ -981,9 +1052,9 @@ func (z *AppConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
 // MarshalMsg implements msgp.Marshaler
 func (z *AppConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 20
+	// map header, size 21
 	// string "api_version"
-	o = append(o, 0xde, 0x0, 0x14, 0xab, 0x61, 0x70, 0x69, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e)
+	o = append(o, 0xde, 0x0, 0x15, 0xab, 0x61, 0x70, 0x69, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e)
 	o = msgp.AppendString(o, z.APIVersion)
 	// string "clients"
 	o = append(o, 0xa7, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x73)
@@ -1056,6 +1127,17 @@ func (z *AppConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
 			return
 		}
 	}
+	// string "saml_idp"
+	o = append(o, 0xa8, 0x73, 0x61, 0x6d, 0x6c, 0x5f, 0x69, 0x64, 0x70)
+	if z.SAMLIdP == nil {
+		o = msgp.AppendNil(o)
+	} else {
+		o, err = z.SAMLIdP.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "SAMLIdP")
+			return
+		}
+	}
 	// string "authenticator"
 	o = append(o, 0xad, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x6f, 0x72)
 	if z.Authenticator == nil {
@@ -1129,10 +1211,16 @@ func (z *AppConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
 	if z.Hook == nil {
 		o = msgp.AppendNil(o)
 	} else {
-		// map header, size 1
+		// map header, size 2
 		// string "secret"
-		o = append(o, 0x81, 0xa6, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74)
+		o = append(o, 0x82, 0xa6, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74)
 		o = msgp.AppendString(o, z.Hook.Secret)
+		// string "additional_secrets"
+		o = append(o, 0xb2, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73)
+		o = msgp.AppendArrayHeader(o, uint32(len(z.Hook.AdditionalSecrets)))
+		for za0004 := range z.Hook.AdditionalSecrets {
+			o = msgp.AppendString(o, z.Hook.AdditionalSecrets[za0004])
+		}
 	}
 	// string "messages"
 	o = append(o, 0xa8, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73)
@@ -1387,6 +1475,23 @@ func (z *AppConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 					return
 				}
 			}
+		case "saml_idp":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.SAMLIdP = nil
+			} else {
+				if z.SAMLIdP == nil {
+					z.SAMLIdP = new(SAMLIdPConfiguration)
+				}
+				bts, err = z.SAMLIdP.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "SAMLIdP")
+					return
+				}
+			}
 		case "authenticator":
 			if msgp.IsNil(bts) {
 				bts, err = msgp.ReadNilBytes(bts)
@@ -1538,6 +1643,25 @@ func (z *AppConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 							err = msgp.WrapError(err, "Hook", "Secret")
 							return
 						}
+					case "additional_secrets":
+						var zb0007 uint32
+						zb0007, bts, err = msgp.ReadArrayHeaderBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "Hook", "AdditionalSecrets")
+							return
+						}
+						if cap(z.Hook.AdditionalSecrets) >= int(zb0007) {
+							z.Hook.AdditionalSecrets = (z.Hook.AdditionalSecrets)[:zb0007]
+						} else {
+							z.Hook.AdditionalSecrets = make([]string, zb0007)
+						}
+						for za0004 := range z.Hook.AdditionalSecrets {
+							z.Hook.AdditionalSecrets[za0004], bts, err = msgp.ReadStringBytes(bts)
+							if err != nil {
+								err = msgp.WrapError(err, "Hook", "AdditionalSecrets", za0004)
+								return
+							}
+						}
 					default:
 						bts, err = msgp.Skip(bts)
 						if err != nil {
@@ -1592,14 +1716,14 @@ func (z *AppConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				if z.Twilio == nil {
 					z.Twilio = new(TwilioConfiguration)
 				}
-				var zb0007 uint32
-				zb0007, bts, err = msgp.ReadMapHeaderBytes(bts)
+				var zb0008 uint32
+				zb0008, bts, err = msgp.ReadMapHeaderBytes(bts)
 				if err != nil {
 					err = msgp.WrapError(err, "Twilio")
 					return
 				}
-				for zb0007 > 0 {
-					zb0007--
+				for zb0008 > 0 {
+					zb0008--
 					field, bts, err = msgp.ReadMapKeyZC(bts)
 					if err != nil {
 						err = msgp.WrapError(err, "Twilio")
@@ -1638,14 +1762,14 @@ func (z *AppConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				if z.Nexmo == nil {
 					z.Nexmo = new(NexmoConfiguration)
 				}
-				var zb0008 uint32
-				zb0008, bts, err = msgp.ReadMapHeaderBytes(bts)
+				var zb0009 uint32
+				zb0009, bts, err = msgp.ReadMapHeaderBytes(bts)
 				if err != nil {
 					err = msgp.WrapError(err, "Nexmo")
 					return
 				}
-				for zb0008 > 0 {
-					zb0008--
+				for zb0009 > 0 {
+					zb0009--
 					field, bts, err = msgp.ReadMapKeyZC(bts)
 					if err != nil {
 						err = msgp.WrapError(err, "Nexmo")
@@ -1701,14 +1825,14 @@ func (z *AppConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				if z.Asset == nil {
 					z.Asset = new(AssetConfiguration)
 				}
-				var zb0009 uint32
-				zb0009, bts, err = msgp.ReadMapHeaderBytes(bts)
+				var zb0010 uint32
+				zb0010, bts, err = msgp.ReadMapHeaderBytes(bts)
 				if err != nil {
 					err = msgp.WrapError(err, "Asset")
 					return
 				}
-				for zb0009 > 0 {
-					zb0009--
+				for zb0010 > 0 {
+					zb0010--
 					field, bts, err = msgp.ReadMapKeyZC(bts)
 					if err != nil {
 						err = msgp.WrapError(err, "Asset")
@@ -1784,6 +1908,12 @@ func (z *AppConfiguration) Msgsize() (s int) {
 	} else {
 		s += z.OIDC.Msgsize()
 	}
+	s += 9
+	if z.SAMLIdP == nil {
+		s += msgp.NilSize
+	} else {
+		s += z.SAMLIdP.Msgsize()
+	}
 	s += 14
 	if z.Authenticator == nil {
 		s += msgp.NilSize
@@ -1818,7 +1948,10 @@ func (z *AppConfiguration) Msgsize() (s int) {
 	if z.Hook == nil {
 		s += msgp.NilSize
 	} else {
-		s += 1 + 7 + msgp.StringPrefixSize + len(z.Hook.Secret)
+		s += 1 + 7 + msgp.StringPrefixSize + len(z.Hook.Secret) + 19 + msgp.ArrayHeaderSize
+		for za0004 := range z.Hook.AdditionalSecrets {
+			s += msgp.StringPrefixSize + len(z.Hook.AdditionalSecrets[za0004])
+		}
 	}
 	s += 9
 	if z.Messages == nil {
@@ -2758,6 +2891,25 @@ func (z *HookAppConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "Secret")
 				return
 			}
+		case "additional_secrets":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "AdditionalSecrets")
+				return
+			}
+			if cap(z.AdditionalSecrets) >= int(zb0002) {
+				z.AdditionalSecrets = (z.AdditionalSecrets)[:zb0002]
+			} else {
+				z.AdditionalSecrets = make([]string, zb0002)
+			}
+			for za0001 := range z.AdditionalSecrets {
+				z.AdditionalSecrets[za0001], err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "AdditionalSecrets", za0001)
+					return
+				}
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -2770,10 +2922,10 @@ func (z *HookAppConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z HookAppConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 1
+func (z *HookAppConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 2
 	// write "secret"
-	err = en.Append(0x81, 0xa6, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74)
+	err = en.Append(0x82, 0xa6, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74)
 	if err != nil {
 		return
 	}
@@ -2782,16 +2934,39 @@ func (z HookAppConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "Secret")
 		return
 	}
+	// write "additional_secrets"
+	err = en.Append(0xb2, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.AdditionalSecrets)))
+	if err != nil {
+		err = msgp.WrapError(err, "AdditionalSecrets")
+		return
+	}
+	for za0001 := range z.AdditionalSecrets {
+		err = en.WriteString(z.AdditionalSecrets[za0001])
+		if err != nil {
+			err = msgp.WrapError(err, "AdditionalSecrets", za0001)
+			return
+		}
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z HookAppConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *HookAppConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 1
+	// map header, size 2
 	// string "secret"
-	o = append(o, 0x81, 0xa6, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74)
+	o = append(o, 0x82, 0xa6, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74)
 	o = msgp.AppendString(o, z.Secret)
+	// string "additional_secrets"
+	o = append(o, 0xb2, 0x61, 0x64, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.AdditionalSecrets)))
+	for za0001 := range z.AdditionalSecrets {
+		o = msgp.AppendString(o, z.AdditionalSecrets[za0001])
+	}
 	return
 }
 
@@ -2819,6 +2994,25 @@ func (z *HookAppConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				err = msgp.WrapError(err, "Secret")
 				return
 			}
+		case "additional_secrets":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "AdditionalSecrets")
+				return
+			}
+			if cap(z.AdditionalSecrets) >= int(zb0002) {
+				z.AdditionalSecrets = (z.AdditionalSecrets)[:zb0002]
+			} else {
+				z.AdditionalSecrets = make([]string, zb0002)
+			}
+			for za0001 := range z.AdditionalSecrets {
+				z.AdditionalSecrets[za0001], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "AdditionalSecrets", za0001)
+					return
+				}
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -2832,8 +3026,11 @@ func (z *HookAppConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z HookAppConfiguration) Msgsize() (s int) {
-	s = 1 + 7 + msgp.StringPrefixSize + len(z.Secret)
+func (z *HookAppConfiguration) Msgsize() (s int) {
+	s = 1 + 7 + msgp.StringPrefixSize + len(z.Secret) + 19 + msgp.ArrayHeaderSize
+	for za0001 := range z.AdditionalSecrets {
+		s += msgp.StringPrefixSize + len(z.AdditionalSecrets[za0001])
+	}
 	return
 }
 
@@ -2867,6 +3064,18 @@ func (z *HookTenantConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 				err = msgp.WrapError(err, "SyncHookTotalTimeout")
 				return
 			}
+		case "async_hook_timeout_second":
+			z.AsyncHookTimeout, err = dc.ReadInt()
+			if err != nil {
+				err = msgp.WrapError(err, "AsyncHookTimeout")
+				return
+			}
+		case "async_hook_max_attempts":
+			z.AsyncHookMaxAttempts, err = dc.ReadInt()
+			if err != nil {
+				err = msgp.WrapError(err, "AsyncHookMaxAttempts")
+				return
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -2879,10 +3088,10 @@ func (z *HookTenantConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z HookTenantConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 2
+func (z *HookTenantConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 4
 	// write "sync_hook_timeout_second"
-	err = en.Append(0x82, 0xb8, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x68, 0x6f, 0x6f, 0x6b, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64)
+	err = en.Append(0x84, 0xb8, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x68, 0x6f, 0x6f, 0x6b, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64)
 	if err != nil {
 		return
 	}
@@ -2901,19 +3110,45 @@ func (z HookTenantConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
 		err = msgp.WrapError(err, "SyncHookTotalTimeout")
 		return
 	}
+	// write "async_hook_timeout_second"
+	err = en.Append(0xb9, 0x61, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x68, 0x6f, 0x6f, 0x6b, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt(z.AsyncHookTimeout)
+	if err != nil {
+		err = msgp.WrapError(err, "AsyncHookTimeout")
+		return
+	}
+	// write "async_hook_max_attempts"
+	err = en.Append(0xb7, 0x61, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x68, 0x6f, 0x6f, 0x6b, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt(z.AsyncHookMaxAttempts)
+	if err != nil {
+		err = msgp.WrapError(err, "AsyncHookMaxAttempts")
+		return
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z HookTenantConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *HookTenantConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 2
+	// map header, size 4
 	// string "sync_hook_timeout_second"
-	o = append(o, 0x82, 0xb8, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x68, 0x6f, 0x6f, 0x6b, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64)
+	o = append(o, 0x84, 0xb8, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x68, 0x6f, 0x6f, 0x6b, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64)
 	o = msgp.AppendInt(o, z.SyncHookTimeout)
 	// string "sync_hook_total_timeout_second"
 	o = append(o, 0xbe, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x68, 0x6f, 0x6f, 0x6b, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64)
 	o = msgp.AppendInt(o, z.SyncHookTotalTimeout)
+	// string "async_hook_timeout_second"
+	o = append(o, 0xb9, 0x61, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x68, 0x6f, 0x6f, 0x6b, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64)
+	o = msgp.AppendInt(o, z.AsyncHookTimeout)
+	// string "async_hook_max_attempts"
+	o = append(o, 0xb7, 0x61, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x68, 0x6f, 0x6f, 0x6b, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x73)
+	o = msgp.AppendInt(o, z.AsyncHookMaxAttempts)
 	return
 }
 
@@ -2947,6 +3182,18 @@ func (z *HookTenantConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error)
 				err = msgp.WrapError(err, "SyncHookTotalTimeout")
 				return
 			}
+		case "async_hook_timeout_second":
+			z.AsyncHookTimeout, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "AsyncHookTimeout")
+				return
+			}
+		case "async_hook_max_attempts":
+			z.AsyncHookMaxAttempts, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "AsyncHookMaxAttempts")
+				return
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -2960,8 +3207,8 @@ func (z *HookTenantConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error)
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z HookTenantConfiguration) Msgsize() (s int) {
-	s = 1 + 25 + msgp.IntSize + 31 + msgp.IntSize
+func (z *HookTenantConfiguration) Msgsize() (s int) {
+	s = 1 + 25 + msgp.IntSize + 31 + msgp.IntSize + 26 + msgp.IntSize + 24 + msgp.IntSize
 	return
 }
 
@@ -3242,45 +3489,48 @@ func (z *OIDCConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 				z.Keys = make([]OIDCSigningKeyConfiguration, zb0002)
 			}
 			for za0001 := range z.Keys {
-				var zb0003 uint32
-				zb0003, err = dc.ReadMapHeader()
+				err = z.Keys[za0001].DecodeMsg(dc)
 				if err != nil {
 					err = msgp.WrapError(err, "Keys", za0001)
 					return
 				}
-				for zb0003 > 0 {
-					zb0003--
-					field, err = dc.ReadMapKeyPtr()
-					if err != nil {
-						err = msgp.WrapError(err, "Keys", za0001)
-						return
-					}
-					switch msgp.UnsafeString(field) {
-					case "kid":
-						z.Keys[za0001].KID, err = dc.ReadString()
-						if err != nil {
-							err = msgp.WrapError(err, "Keys", za0001, "KID")
-							return
-						}
-					case "public_key":
-						z.Keys[za0001].PublicKey, err = dc.ReadString()
-						if err != nil {
-							err = msgp.WrapError(err, "Keys", za0001, "PublicKey")
-							return
-						}
-					case "private_key":
-						z.Keys[za0001].PrivateKey, err = dc.ReadString()
-						if err != nil {
-							err = msgp.WrapError(err, "Keys", za0001, "PrivateKey")
-							return
-						}
-					default:
-						err = dc.Skip()
-						if err != nil {
-							err = msgp.WrapError(err, "Keys", za0001)
-							return
-						}
-					}
+			}
+		case "scopes":
+			var zb0003 uint32
+			zb0003, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Scopes")
+				return
+			}
+			if cap(z.Scopes) >= int(zb0003) {
+				z.Scopes = (z.Scopes)[:zb0003]
+			} else {
+				z.Scopes = make([]OIDCScopeConfiguration, zb0003)
+			}
+			for za0002 := range z.Scopes {
+				err = z.Scopes[za0002].DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "Scopes", za0002)
+					return
+				}
+			}
+		case "initial_access_tokens":
+			var zb0004 uint32
+			zb0004, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "InitialAccessTokens")
+				return
+			}
+			if cap(z.InitialAccessTokens) >= int(zb0004) {
+				z.InitialAccessTokens = (z.InitialAccessTokens)[:zb0004]
+			} else {
+				z.InitialAccessTokens = make([]string, zb0004)
+			}
+			for za0003 := range z.InitialAccessTokens {
+				z.InitialAccessTokens[za0003], err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "InitialAccessTokens", za0003)
+					return
 				}
 			}
 		default:
@@ -3296,9 +3546,9 @@ func (z *OIDCConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *OIDCConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 1
+	// map header, size 3
 	// write "keys"
-	err = en.Append(0x81, 0xa4, 0x6b, 0x65, 0x79, 0x73)
+	err = en.Append(0x83, 0xa4, 0x6b, 0x65, 0x79, 0x73)
 	if err != nil {
 		return
 	}
@@ -3308,35 +3558,43 @@ func (z *OIDCConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
 		return
 	}
 	for za0001 := range z.Keys {
-		// map header, size 3
-		// write "kid"
-		err = en.Append(0x83, 0xa3, 0x6b, 0x69, 0x64)
-		if err != nil {
-			return
-		}
-		err = en.WriteString(z.Keys[za0001].KID)
+		err = z.Keys[za0001].EncodeMsg(en)
 		if err != nil {
-			err = msgp.WrapError(err, "Keys", za0001, "KID")
+			err = msgp.WrapError(err, "Keys", za0001)
 			return
 		}
-		// write "public_key"
-		err = en.Append(0xaa, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79)
-		if err != nil {
-			return
-		}
-		err = en.WriteString(z.Keys[za0001].PublicKey)
-		if err != nil {
-			err = msgp.WrapError(err, "Keys", za0001, "PublicKey")
-			return
-		}
-		// write "private_key"
-		err = en.Append(0xab, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x5f, 0x6b, 0x65, 0x79)
+	}
+	// write "scopes"
+	err = en.Append(0xa6, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Scopes)))
+	if err != nil {
+		err = msgp.WrapError(err, "Scopes")
+		return
+	}
+	for za0002 := range z.Scopes {
+		err = z.Scopes[za0002].EncodeMsg(en)
 		if err != nil {
+			err = msgp.WrapError(err, "Scopes", za0002)
 			return
 		}
-		err = en.WriteString(z.Keys[za0001].PrivateKey)
+	}
+	// write "initial_access_tokens"
+	err = en.Append(0xb5, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.InitialAccessTokens)))
+	if err != nil {
+		err = msgp.WrapError(err, "InitialAccessTokens")
+		return
+	}
+	for za0003 := range z.InitialAccessTokens {
+		err = en.WriteString(z.InitialAccessTokens[za0003])
 		if err != nil {
-			err = msgp.WrapError(err, "Keys", za0001, "PrivateKey")
+			err = msgp.WrapError(err, "InitialAccessTokens", za0003)
 			return
 		}
 	}
@@ -3346,21 +3604,32 @@ func (z *OIDCConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
 // MarshalMsg implements msgp.Marshaler
 func (z *OIDCConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 1
+	// map header, size 3
 	// string "keys"
-	o = append(o, 0x81, 0xa4, 0x6b, 0x65, 0x79, 0x73)
+	o = append(o, 0x83, 0xa4, 0x6b, 0x65, 0x79, 0x73)
 	o = msgp.AppendArrayHeader(o, uint32(len(z.Keys)))
 	for za0001 := range z.Keys {
-		// map header, size 3
-		// string "kid"
-		o = append(o, 0x83, 0xa3, 0x6b, 0x69, 0x64)
-		o = msgp.AppendString(o, z.Keys[za0001].KID)
-		// string "public_key"
-		o = append(o, 0xaa, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79)
-		o = msgp.AppendString(o, z.Keys[za0001].PublicKey)
-		// string "private_key"
-		o = append(o, 0xab, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x5f, 0x6b, 0x65, 0x79)
-		o = msgp.AppendString(o, z.Keys[za0001].PrivateKey)
+		o, err = z.Keys[za0001].MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Keys", za0001)
+			return
+		}
+	}
+	// string "scopes"
+	o = append(o, 0xa6, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Scopes)))
+	for za0002 := range z.Scopes {
+		o, err = z.Scopes[za0002].MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Scopes", za0002)
+			return
+		}
+	}
+	// string "initial_access_tokens"
+	o = append(o, 0xb5, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x61, 0x63, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.InitialAccessTokens)))
+	for za0003 := range z.InitialAccessTokens {
+		o = msgp.AppendString(o, z.InitialAccessTokens[za0003])
 	}
 	return
 }
@@ -3396,46 +3665,714 @@ func (z *OIDCConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				z.Keys = make([]OIDCSigningKeyConfiguration, zb0002)
 			}
 			for za0001 := range z.Keys {
-				var zb0003 uint32
-				zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+				bts, err = z.Keys[za0001].UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Keys", za0001)
+					return
+				}
+			}
+		case "scopes":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Scopes")
+				return
+			}
+			if cap(z.Scopes) >= int(zb0003) {
+				z.Scopes = (z.Scopes)[:zb0003]
+			} else {
+				z.Scopes = make([]OIDCScopeConfiguration, zb0003)
+			}
+			for za0002 := range z.Scopes {
+				bts, err = z.Scopes[za0002].UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Scopes", za0002)
+					return
+				}
+			}
+		case "initial_access_tokens":
+			var zb0004 uint32
+			zb0004, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "InitialAccessTokens")
+				return
+			}
+			if cap(z.InitialAccessTokens) >= int(zb0004) {
+				z.InitialAccessTokens = (z.InitialAccessTokens)[:zb0004]
+			} else {
+				z.InitialAccessTokens = make([]string, zb0004)
+			}
+			for za0003 := range z.InitialAccessTokens {
+				z.InitialAccessTokens[za0003], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "InitialAccessTokens", za0003)
+					return
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *OIDCConfiguration) Msgsize() (s int) {
+	s = 1 + 5 + msgp.ArrayHeaderSize
+	for za0001 := range z.Keys {
+		s += z.Keys[za0001].Msgsize()
+	}
+	s += 7 + msgp.ArrayHeaderSize
+	for za0002 := range z.Scopes {
+		s += z.Scopes[za0002].Msgsize()
+	}
+	s += 22 + msgp.ArrayHeaderSize
+	for za0003 := range z.InitialAccessTokens {
+		s += msgp.StringPrefixSize + len(z.InitialAccessTokens[za0003])
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *OIDCScopeConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "name":
+			z.Name, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Name")
+				return
+			}
+		case "description":
+			z.Description, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Description")
+				return
+			}
+		case "claims":
+			var zb0002 uint32
+			zb0002, err = dc.ReadMapHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Claims")
+				return
+			}
+			if z.Claims == nil {
+				z.Claims = make(map[string]string, zb0002)
+			} else if len(z.Claims) > 0 {
+				for key := range z.Claims {
+					delete(z.Claims, key)
+				}
+			}
+			for zb0002 > 0 {
+				zb0002--
+				var za0001 string
+				var za0002 string
+				za0001, err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "Claims")
+					return
+				}
+				za0002, err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "Claims", za0001)
+					return
+				}
+				z.Claims[za0001] = za0002
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *OIDCScopeConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
+	// write "name"
+	err = en.Append(0x83, 0xa4, 0x6e, 0x61, 0x6d, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Name)
+	if err != nil {
+		err = msgp.WrapError(err, "Name")
+		return
+	}
+	// write "description"
+	err = en.Append(0xab, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Description)
+	if err != nil {
+		err = msgp.WrapError(err, "Description")
+		return
+	}
+	// write "claims"
+	err = en.Append(0xa6, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteMapHeader(uint32(len(z.Claims)))
+	if err != nil {
+		err = msgp.WrapError(err, "Claims")
+		return
+	}
+	for za0001, za0002 := range z.Claims {
+		err = en.WriteString(za0001)
+		if err != nil {
+			err = msgp.WrapError(err, "Claims")
+			return
+		}
+		err = en.WriteString(za0002)
+		if err != nil {
+			err = msgp.WrapError(err, "Claims", za0001)
+			return
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *OIDCScopeConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "name"
+	o = append(o, 0x83, 0xa4, 0x6e, 0x61, 0x6d, 0x65)
+	o = msgp.AppendString(o, z.Name)
+	// string "description"
+	o = append(o, 0xab, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e)
+	o = msgp.AppendString(o, z.Description)
+	// string "claims"
+	o = append(o, 0xa6, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x73)
+	o = msgp.AppendMapHeader(o, uint32(len(z.Claims)))
+	for za0001, za0002 := range z.Claims {
+		o = msgp.AppendString(o, za0001)
+		o = msgp.AppendString(o, za0002)
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *OIDCScopeConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "name":
+			z.Name, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Name")
+				return
+			}
+		case "description":
+			z.Description, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Description")
+				return
+			}
+		case "claims":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Claims")
+				return
+			}
+			if z.Claims == nil {
+				z.Claims = make(map[string]string, zb0002)
+			} else if len(z.Claims) > 0 {
+				for key := range z.Claims {
+					delete(z.Claims, key)
+				}
+			}
+			for zb0002 > 0 {
+				var za0001 string
+				var za0002 string
+				zb0002--
+				za0001, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Claims")
+					return
+				}
+				za0002, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Claims", za0001)
+					return
+				}
+				z.Claims[za0001] = za0002
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *OIDCScopeConfiguration) Msgsize() (s int) {
+	s = 1 + 5 + msgp.StringPrefixSize + len(z.Name) + 12 + msgp.StringPrefixSize + len(z.Description) + 7 + msgp.MapHeaderSize
+	if z.Claims != nil {
+		for za0001, za0002 := range z.Claims {
+			_ = za0002
+			s += msgp.StringPrefixSize + len(za0001) + msgp.StringPrefixSize + len(za0002)
+		}
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *OIDCSigningKeyConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "kid":
+			z.KID, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "KID")
+				return
+			}
+		case "state":
+			{
+				var zb0002 string
+				zb0002, err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "State")
+					return
+				}
+				z.State = OIDCSigningKeyState(zb0002)
+			}
+		case "public_key":
+			z.PublicKey, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "PublicKey")
+				return
+			}
+		case "private_key":
+			z.PrivateKey, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "PrivateKey")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *OIDCSigningKeyConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 4
+	// write "kid"
+	err = en.Append(0x84, 0xa3, 0x6b, 0x69, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.KID)
+	if err != nil {
+		err = msgp.WrapError(err, "KID")
+		return
+	}
+	// write "state"
+	err = en.Append(0xa5, 0x73, 0x74, 0x61, 0x74, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(string(z.State))
+	if err != nil {
+		err = msgp.WrapError(err, "State")
+		return
+	}
+	// write "public_key"
+	err = en.Append(0xaa, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.PublicKey)
+	if err != nil {
+		err = msgp.WrapError(err, "PublicKey")
+		return
+	}
+	// write "private_key"
+	err = en.Append(0xab, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x5f, 0x6b, 0x65, 0x79)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.PrivateKey)
+	if err != nil {
+		err = msgp.WrapError(err, "PrivateKey")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *OIDCSigningKeyConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "kid"
+	o = append(o, 0x84, 0xa3, 0x6b, 0x69, 0x64)
+	o = msgp.AppendString(o, z.KID)
+	// string "state"
+	o = append(o, 0xa5, 0x73, 0x74, 0x61, 0x74, 0x65)
+	o = msgp.AppendString(o, string(z.State))
+	// string "public_key"
+	o = append(o, 0xaa, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79)
+	o = msgp.AppendString(o, z.PublicKey)
+	// string "private_key"
+	o = append(o, 0xab, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x5f, 0x6b, 0x65, 0x79)
+	o = msgp.AppendString(o, z.PrivateKey)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *OIDCSigningKeyConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "kid":
+			z.KID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "KID")
+				return
+			}
+		case "state":
+			{
+				var zb0002 string
+				zb0002, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "State")
+					return
+				}
+				z.State = OIDCSigningKeyState(zb0002)
+			}
+		case "public_key":
+			z.PublicKey, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "PublicKey")
+				return
+			}
+		case "private_key":
+			z.PrivateKey, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "PrivateKey")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *OIDCSigningKeyConfiguration) Msgsize() (s int) {
+	s = 1 + 4 + msgp.StringPrefixSize + len(z.KID) + 6 + msgp.StringPrefixSize + len(string(z.State)) + 11 + msgp.StringPrefixSize + len(z.PublicKey) + 12 + msgp.StringPrefixSize + len(z.PrivateKey)
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *OIDCSigningKeyState) DecodeMsg(dc *msgp.Reader) (err error) {
+	{
+		var zb0001 string
+		zb0001, err = dc.ReadString()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		(*z) = OIDCSigningKeyState(zb0001)
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z OIDCSigningKeyState) EncodeMsg(en *msgp.Writer) (err error) {
+	err = en.WriteString(string(z))
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z OIDCSigningKeyState) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	o = msgp.AppendString(o, string(z))
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *OIDCSigningKeyState) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	{
+		var zb0001 string
+		zb0001, bts, err = msgp.ReadStringBytes(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		(*z) = OIDCSigningKeyState(zb0001)
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z OIDCSigningKeyState) Msgsize() (s int) {
+	s = msgp.StringPrefixSize + len(string(z))
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *SAMLIdPConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "certificate":
+			z.Certificate, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Certificate")
+				return
+			}
+		case "private_key":
+			z.PrivateKey, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "PrivateKey")
+				return
+			}
+		case "service_providers":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "ServiceProviders")
+				return
+			}
+			if cap(z.ServiceProviders) >= int(zb0002) {
+				z.ServiceProviders = (z.ServiceProviders)[:zb0002]
+			} else {
+				z.ServiceProviders = make([]SAMLServiceProviderConfiguration, zb0002)
+			}
+			for za0001 := range z.ServiceProviders {
+				err = z.ServiceProviders[za0001].DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "ServiceProviders", za0001)
+					return
+				}
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *SAMLIdPConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
+	// write "certificate"
+	err = en.Append(0x83, 0xab, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Certificate)
+	if err != nil {
+		err = msgp.WrapError(err, "Certificate")
+		return
+	}
+	// write "private_key"
+	err = en.Append(0xab, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x5f, 0x6b, 0x65, 0x79)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.PrivateKey)
+	if err != nil {
+		err = msgp.WrapError(err, "PrivateKey")
+		return
+	}
+	// write "service_providers"
+	err = en.Append(0xb1, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.ServiceProviders)))
+	if err != nil {
+		err = msgp.WrapError(err, "ServiceProviders")
+		return
+	}
+	for za0001 := range z.ServiceProviders {
+		err = z.ServiceProviders[za0001].EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "ServiceProviders", za0001)
+			return
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *SAMLIdPConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "certificate"
+	o = append(o, 0x83, 0xab, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65)
+	o = msgp.AppendString(o, z.Certificate)
+	// string "private_key"
+	o = append(o, 0xab, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x5f, 0x6b, 0x65, 0x79)
+	o = msgp.AppendString(o, z.PrivateKey)
+	// string "service_providers"
+	o = append(o, 0xb1, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.ServiceProviders)))
+	for za0001 := range z.ServiceProviders {
+		o, err = z.ServiceProviders[za0001].MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "ServiceProviders", za0001)
+			return
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *SAMLIdPConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "certificate":
+			z.Certificate, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Certificate")
+				return
+			}
+		case "private_key":
+			z.PrivateKey, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "PrivateKey")
+				return
+			}
+		case "service_providers":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ServiceProviders")
+				return
+			}
+			if cap(z.ServiceProviders) >= int(zb0002) {
+				z.ServiceProviders = (z.ServiceProviders)[:zb0002]
+			} else {
+				z.ServiceProviders = make([]SAMLServiceProviderConfiguration, zb0002)
+			}
+			for za0001 := range z.ServiceProviders {
+				bts, err = z.ServiceProviders[za0001].UnmarshalMsg(bts)
 				if err != nil {
-					err = msgp.WrapError(err, "Keys", za0001)
+					err = msgp.WrapError(err, "ServiceProviders", za0001)
 					return
 				}
-				for zb0003 > 0 {
-					zb0003--
-					field, bts, err = msgp.ReadMapKeyZC(bts)
-					if err != nil {
-						err = msgp.WrapError(err, "Keys", za0001)
-						return
-					}
-					switch msgp.UnsafeString(field) {
-					case "kid":
-						z.Keys[za0001].KID, bts, err = msgp.ReadStringBytes(bts)
-						if err != nil {
-							err = msgp.WrapError(err, "Keys", za0001, "KID")
-							return
-						}
-					case "public_key":
-						z.Keys[za0001].PublicKey, bts, err = msgp.ReadStringBytes(bts)
-						if err != nil {
-							err = msgp.WrapError(err, "Keys", za0001, "PublicKey")
-							return
-						}
-					case "private_key":
-						z.Keys[za0001].PrivateKey, bts, err = msgp.ReadStringBytes(bts)
-						if err != nil {
-							err = msgp.WrapError(err, "Keys", za0001, "PrivateKey")
-							return
-						}
-					default:
-						bts, err = msgp.Skip(bts)
-						if err != nil {
-							err = msgp.WrapError(err, "Keys", za0001)
-							return
-						}
-					}
-				}
 			}
 		default:
 			bts, err = msgp.Skip(bts)
@@ -3450,16 +4387,16 @@ func (z *OIDCConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z *OIDCConfiguration) Msgsize() (s int) {
-	s = 1 + 5 + msgp.ArrayHeaderSize
-	for za0001 := range z.Keys {
-		s += 1 + 4 + msgp.StringPrefixSize + len(z.Keys[za0001].KID) + 11 + msgp.StringPrefixSize + len(z.Keys[za0001].PublicKey) + 12 + msgp.StringPrefixSize + len(z.Keys[za0001].PrivateKey)
+func (z *SAMLIdPConfiguration) Msgsize() (s int) {
+	s = 1 + 12 + msgp.StringPrefixSize + len(z.Certificate) + 12 + msgp.StringPrefixSize + len(z.PrivateKey) + 18 + msgp.ArrayHeaderSize
+	for za0001 := range z.ServiceProviders {
+		s += z.ServiceProviders[za0001].Msgsize()
 	}
 	return
 }
 
 // DecodeMsg implements msgp.Decodable
-func (z *OIDCSigningKeyConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
+func (z *SAMLServiceProviderConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -3476,22 +4413,34 @@ func (z *OIDCSigningKeyConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "kid":
-			z.KID, err = dc.ReadString()
+		case "entity_id":
+			z.EntityID, err = dc.ReadString()
 			if err != nil {
-				err = msgp.WrapError(err, "KID")
+				err = msgp.WrapError(err, "EntityID")
 				return
 			}
-		case "public_key":
-			z.PublicKey, err = dc.ReadString()
+		case "acs_url":
+			z.ACSURL, err = dc.ReadString()
 			if err != nil {
-				err = msgp.WrapError(err, "PublicKey")
+				err = msgp.WrapError(err, "ACSURL")
 				return
 			}
-		case "private_key":
-			z.PrivateKey, err = dc.ReadString()
+		case "slo_url":
+			z.SLOURL, err = dc.ReadString()
 			if err != nil {
-				err = msgp.WrapError(err, "PrivateKey")
+				err = msgp.WrapError(err, "SLOURL")
+				return
+			}
+		case "certificate":
+			z.Certificate, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Certificate")
+				return
+			}
+		case "want_authn_requests_signed":
+			z.WantAuthnRequestsSigned, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "WantAuthnRequestsSigned")
 				return
 			}
 		default:
@@ -3506,59 +4455,85 @@ func (z *OIDCSigningKeyConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 }
 
 // EncodeMsg implements msgp.Encodable
-func (z OIDCSigningKeyConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 3
-	// write "kid"
-	err = en.Append(0x83, 0xa3, 0x6b, 0x69, 0x64)
+func (z *SAMLServiceProviderConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 5
+	// write "entity_id"
+	err = en.Append(0x85, 0xa9, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f, 0x69, 0x64)
 	if err != nil {
 		return
 	}
-	err = en.WriteString(z.KID)
+	err = en.WriteString(z.EntityID)
 	if err != nil {
-		err = msgp.WrapError(err, "KID")
+		err = msgp.WrapError(err, "EntityID")
 		return
 	}
-	// write "public_key"
-	err = en.Append(0xaa, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79)
+	// write "acs_url"
+	err = en.Append(0xa7, 0x61, 0x63, 0x73, 0x5f, 0x75, 0x72, 0x6c)
 	if err != nil {
 		return
 	}
-	err = en.WriteString(z.PublicKey)
+	err = en.WriteString(z.ACSURL)
 	if err != nil {
-		err = msgp.WrapError(err, "PublicKey")
+		err = msgp.WrapError(err, "ACSURL")
 		return
 	}
-	// write "private_key"
-	err = en.Append(0xab, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x5f, 0x6b, 0x65, 0x79)
+	// write "slo_url"
+	err = en.Append(0xa7, 0x73, 0x6c, 0x6f, 0x5f, 0x75, 0x72, 0x6c)
 	if err != nil {
 		return
 	}
-	err = en.WriteString(z.PrivateKey)
+	err = en.WriteString(z.SLOURL)
 	if err != nil {
-		err = msgp.WrapError(err, "PrivateKey")
+		err = msgp.WrapError(err, "SLOURL")
+		return
+	}
+	// write "certificate"
+	err = en.Append(0xab, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Certificate)
+	if err != nil {
+		err = msgp.WrapError(err, "Certificate")
+		return
+	}
+	// write "want_authn_requests_signed"
+	err = en.Append(0xba, 0x77, 0x61, 0x6e, 0x74, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x6e, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteBool(z.WantAuthnRequestsSigned)
+	if err != nil {
+		err = msgp.WrapError(err, "WantAuthnRequestsSigned")
 		return
 	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
-func (z OIDCSigningKeyConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
+func (z *SAMLServiceProviderConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 3
-	// string "kid"
-	o = append(o, 0x83, 0xa3, 0x6b, 0x69, 0x64)
-	o = msgp.AppendString(o, z.KID)
-	// string "public_key"
-	o = append(o, 0xaa, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x5f, 0x6b, 0x65, 0x79)
-	o = msgp.AppendString(o, z.PublicKey)
-	// string "private_key"
-	o = append(o, 0xab, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x5f, 0x6b, 0x65, 0x79)
-	o = msgp.AppendString(o, z.PrivateKey)
+	// map header, size 5
+	// string "entity_id"
+	o = append(o, 0x85, 0xa9, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x5f, 0x69, 0x64)
+	o = msgp.AppendString(o, z.EntityID)
+	// string "acs_url"
+	o = append(o, 0xa7, 0x61, 0x63, 0x73, 0x5f, 0x75, 0x72, 0x6c)
+	o = msgp.AppendString(o, z.ACSURL)
+	// string "slo_url"
+	o = append(o, 0xa7, 0x73, 0x6c, 0x6f, 0x5f, 0x75, 0x72, 0x6c)
+	o = msgp.AppendString(o, z.SLOURL)
+	// string "certificate"
+	o = append(o, 0xab, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65)
+	o = msgp.AppendString(o, z.Certificate)
+	// string "want_authn_requests_signed"
+	o = append(o, 0xba, 0x77, 0x61, 0x6e, 0x74, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x6e, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x73, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64)
+	o = msgp.AppendBool(o, z.WantAuthnRequestsSigned)
 	return
 }
 
 // UnmarshalMsg implements msgp.Unmarshaler
-func (z *OIDCSigningKeyConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
+func (z *SAMLServiceProviderConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 	var field []byte
 	_ = field
 	var zb0001 uint32
@@ -3575,22 +4550,34 @@ func (z *OIDCSigningKeyConfiguration) UnmarshalMsg(bts []byte) (o []byte, err er
 			return
 		}
 		switch msgp.UnsafeString(field) {
-		case "kid":
-			z.KID, bts, err = msgp.ReadStringBytes(bts)
+		case "entity_id":
+			z.EntityID, bts, err = msgp.ReadStringBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "KID")
+				err = msgp.WrapError(err, "EntityID")
 				return
 			}
-		case "public_key":
-			z.PublicKey, bts, err = msgp.ReadStringBytes(bts)
+		case "acs_url":
+			z.ACSURL, bts, err = msgp.ReadStringBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "PublicKey")
+				err = msgp.WrapError(err, "ACSURL")
 				return
 			}
-		case "private_key":
-			z.PrivateKey, bts, err = msgp.ReadStringBytes(bts)
+		case "slo_url":
+			z.SLOURL, bts, err = msgp.ReadStringBytes(bts)
 			if err != nil {
-				err = msgp.WrapError(err, "PrivateKey")
+				err = msgp.WrapError(err, "SLOURL")
+				return
+			}
+		case "certificate":
+			z.Certificate, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Certificate")
+				return
+			}
+		case "want_authn_requests_signed":
+			z.WantAuthnRequestsSigned, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "WantAuthnRequestsSigned")
 				return
 			}
 		default:
@@ -3606,8 +4593,8 @@ func (z *OIDCSigningKeyConfiguration) UnmarshalMsg(bts []byte) (o []byte, err er
 }
 
 // Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
-func (z OIDCSigningKeyConfiguration) Msgsize() (s int) {
-	s = 1 + 4 + msgp.StringPrefixSize + len(z.KID) + 11 + msgp.StringPrefixSize + len(z.PublicKey) + 12 + msgp.StringPrefixSize + len(z.PrivateKey)
+func (z *SAMLServiceProviderConfiguration) Msgsize() (s int) {
+	s = 1 + 10 + msgp.StringPrefixSize + len(z.EntityID) + 8 + msgp.StringPrefixSize + len(z.ACSURL) + 8 + msgp.StringPrefixSize + len(z.SLOURL) + 12 + msgp.StringPrefixSize + len(z.Certificate) + 27 + msgp.BoolSize
 	return
 }
 
@@ -4428,40 +5415,11 @@ func (z *TenantConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 				if z.Hook == nil {
 					z.Hook = new(HookTenantConfiguration)
 				}
-				var zb0002 uint32
-				zb0002, err = dc.ReadMapHeader()
+				err = z.Hook.DecodeMsg(dc)
 				if err != nil {
 					err = msgp.WrapError(err, "Hook")
 					return
 				}
-				for zb0002 > 0 {
-					zb0002--
-					field, err = dc.ReadMapKeyPtr()
-					if err != nil {
-						err = msgp.WrapError(err, "Hook")
-						return
-					}
-					switch msgp.UnsafeString(field) {
-					case "sync_hook_timeout_second":
-						z.Hook.SyncHookTimeout, err = dc.ReadInt()
-						if err != nil {
-							err = msgp.WrapError(err, "Hook", "SyncHookTimeout")
-							return
-						}
-					case "sync_hook_total_timeout_second":
-						z.Hook.SyncHookTotalTimeout, err = dc.ReadInt()
-						if err != nil {
-							err = msgp.WrapError(err, "Hook", "SyncHookTotalTimeout")
-							return
-						}
-					default:
-						err = dc.Skip()
-						if err != nil {
-							err = msgp.WrapError(err, "Hook")
-							return
-						}
-					}
-				}
 			}
 		case "database_config":
 			if dc.IsNil() {
@@ -4475,14 +5433,14 @@ func (z *TenantConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 				if z.DatabaseConfig == nil {
 					z.DatabaseConfig = new(DatabaseConfiguration)
 				}
-				var zb0003 uint32
-				zb0003, err = dc.ReadMapHeader()
+				var zb0002 uint32
+				zb0002, err = dc.ReadMapHeader()
 				if err != nil {
 					err = msgp.WrapError(err, "DatabaseConfig")
 					return
 				}
-				for zb0003 > 0 {
-					zb0003--
+				for zb0002 > 0 {
+					zb0002--
 					field, err = dc.ReadMapKeyPtr()
 					if err != nil {
 						err = msgp.WrapError(err, "DatabaseConfig")
@@ -4529,16 +5487,16 @@ func (z *TenantConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 				}
 			}
 		case "template_items":
-			var zb0004 uint32
-			zb0004, err = dc.ReadArrayHeader()
+			var zb0003 uint32
+			zb0003, err = dc.ReadArrayHeader()
 			if err != nil {
 				err = msgp.WrapError(err, "TemplateItems")
 				return
 			}
-			if cap(z.TemplateItems) >= int(zb0004) {
-				z.TemplateItems = (z.TemplateItems)[:zb0004]
+			if cap(z.TemplateItems) >= int(zb0003) {
+				z.TemplateItems = (z.TemplateItems)[:zb0003]
 			} else {
-				z.TemplateItems = make([]TemplateItem, zb0004)
+				z.TemplateItems = make([]TemplateItem, zb0003)
 			}
 			for za0001 := range z.TemplateItems {
 				err = z.TemplateItems[za0001].DecodeMsg(dc)
@@ -4548,26 +5506,26 @@ func (z *TenantConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 				}
 			}
 		case "hooks":
-			var zb0005 uint32
-			zb0005, err = dc.ReadArrayHeader()
+			var zb0004 uint32
+			zb0004, err = dc.ReadArrayHeader()
 			if err != nil {
 				err = msgp.WrapError(err, "Hooks")
 				return
 			}
-			if cap(z.Hooks) >= int(zb0005) {
-				z.Hooks = (z.Hooks)[:zb0005]
+			if cap(z.Hooks) >= int(zb0004) {
+				z.Hooks = (z.Hooks)[:zb0004]
 			} else {
-				z.Hooks = make([]Hook, zb0005)
+				z.Hooks = make([]Hook, zb0004)
 			}
 			for za0002 := range z.Hooks {
-				var zb0006 uint32
-				zb0006, err = dc.ReadMapHeader()
+				var zb0005 uint32
+				zb0005, err = dc.ReadMapHeader()
 				if err != nil {
 					err = msgp.WrapError(err, "Hooks", za0002)
 					return
 				}
-				for zb0006 > 0 {
-					zb0006--
+				for zb0005 > 0 {
+					zb0005--
 					field, err = dc.ReadMapKeyPtr()
 					if err != nil {
 						err = msgp.WrapError(err, "Hooks", za0002)
@@ -4596,16 +5554,16 @@ func (z *TenantConfiguration) DecodeMsg(dc *msgp.Reader) (err error) {
 				}
 			}
 		case "deployment_routes":
-			var zb0007 uint32
-			zb0007, err = dc.ReadArrayHeader()
+			var zb0006 uint32
+			zb0006, err = dc.ReadArrayHeader()
 			if err != nil {
 				err = msgp.WrapError(err, "DeploymentRoutes")
 				return
 			}
-			if cap(z.DeploymentRoutes) >= int(zb0007) {
-				z.DeploymentRoutes = (z.DeploymentRoutes)[:zb0007]
+			if cap(z.DeploymentRoutes) >= int(zb0006) {
+				z.DeploymentRoutes = (z.DeploymentRoutes)[:zb0006]
 			} else {
-				z.DeploymentRoutes = make([]DeploymentRoute, zb0007)
+				z.DeploymentRoutes = make([]DeploymentRoute, zb0006)
 			}
 			for za0003 := range z.DeploymentRoutes {
 				err = z.DeploymentRoutes[za0003].DecodeMsg(dc)
@@ -4669,25 +5627,9 @@ func (z *TenantConfiguration) EncodeMsg(en *msgp.Writer) (err error) {
 			return
 		}
 	} else {
-		// map header, size 2
-		// write "sync_hook_timeout_second"
-		err = en.Append(0x82, 0xb8, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x68, 0x6f, 0x6f, 0x6b, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64)
-		if err != nil {
-			return
-		}
-		err = en.WriteInt(z.Hook.SyncHookTimeout)
+		err = z.Hook.EncodeMsg(en)
 		if err != nil {
-			err = msgp.WrapError(err, "Hook", "SyncHookTimeout")
-			return
-		}
-		// write "sync_hook_total_timeout_second"
-		err = en.Append(0xbe, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x68, 0x6f, 0x6f, 0x6b, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64)
-		if err != nil {
-			return
-		}
-		err = en.WriteInt(z.Hook.SyncHookTotalTimeout)
-		if err != nil {
-			err = msgp.WrapError(err, "Hook", "SyncHookTotalTimeout")
+			err = msgp.WrapError(err, "Hook")
 			return
 		}
 	}
@@ -4829,13 +5771,11 @@ func (z *TenantConfiguration) MarshalMsg(b []byte) (o []byte, err error) {
 	if z.Hook == nil {
 		o = msgp.AppendNil(o)
 	} else {
-		// map header, size 2
-		// string "sync_hook_timeout_second"
-		o = append(o, 0x82, 0xb8, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x68, 0x6f, 0x6f, 0x6b, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64)
-		o = msgp.AppendInt(o, z.Hook.SyncHookTimeout)
-		// string "sync_hook_total_timeout_second"
-		o = append(o, 0xbe, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x68, 0x6f, 0x6f, 0x6b, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64)
-		o = msgp.AppendInt(o, z.Hook.SyncHookTotalTimeout)
+		o, err = z.Hook.MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Hook")
+			return
+		}
 	}
 	// string "database_config"
 	o = append(o, 0xaf, 0x64, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67)
@@ -4943,40 +5883,11 @@ func (z *TenantConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				if z.Hook == nil {
 					z.Hook = new(HookTenantConfiguration)
 				}
-				var zb0002 uint32
-				zb0002, bts, err = msgp.ReadMapHeaderBytes(bts)
+				bts, err = z.Hook.UnmarshalMsg(bts)
 				if err != nil {
 					err = msgp.WrapError(err, "Hook")
 					return
 				}
-				for zb0002 > 0 {
-					zb0002--
-					field, bts, err = msgp.ReadMapKeyZC(bts)
-					if err != nil {
-						err = msgp.WrapError(err, "Hook")
-						return
-					}
-					switch msgp.UnsafeString(field) {
-					case "sync_hook_timeout_second":
-						z.Hook.SyncHookTimeout, bts, err = msgp.ReadIntBytes(bts)
-						if err != nil {
-							err = msgp.WrapError(err, "Hook", "SyncHookTimeout")
-							return
-						}
-					case "sync_hook_total_timeout_second":
-						z.Hook.SyncHookTotalTimeout, bts, err = msgp.ReadIntBytes(bts)
-						if err != nil {
-							err = msgp.WrapError(err, "Hook", "SyncHookTotalTimeout")
-							return
-						}
-					default:
-						bts, err = msgp.Skip(bts)
-						if err != nil {
-							err = msgp.WrapError(err, "Hook")
-							return
-						}
-					}
-				}
 			}
 		case "database_config":
 			if msgp.IsNil(bts) {
@@ -4989,14 +5900,14 @@ func (z *TenantConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				if z.DatabaseConfig == nil {
 					z.DatabaseConfig = new(DatabaseConfiguration)
 				}
-				var zb0003 uint32
-				zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+				var zb0002 uint32
+				zb0002, bts, err = msgp.ReadMapHeaderBytes(bts)
 				if err != nil {
 					err = msgp.WrapError(err, "DatabaseConfig")
 					return
 				}
-				for zb0003 > 0 {
-					zb0003--
+				for zb0002 > 0 {
+					zb0002--
 					field, bts, err = msgp.ReadMapKeyZC(bts)
 					if err != nil {
 						err = msgp.WrapError(err, "DatabaseConfig")
@@ -5042,16 +5953,16 @@ func (z *TenantConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				}
 			}
 		case "template_items":
-			var zb0004 uint32
-			zb0004, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
 			if err != nil {
 				err = msgp.WrapError(err, "TemplateItems")
 				return
 			}
-			if cap(z.TemplateItems) >= int(zb0004) {
-				z.TemplateItems = (z.TemplateItems)[:zb0004]
+			if cap(z.TemplateItems) >= int(zb0003) {
+				z.TemplateItems = (z.TemplateItems)[:zb0003]
 			} else {
-				z.TemplateItems = make([]TemplateItem, zb0004)
+				z.TemplateItems = make([]TemplateItem, zb0003)
 			}
 			for za0001 := range z.TemplateItems {
 				bts, err = z.TemplateItems[za0001].UnmarshalMsg(bts)
@@ -5061,26 +5972,26 @@ func (z *TenantConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				}
 			}
 		case "hooks":
-			var zb0005 uint32
-			zb0005, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			var zb0004 uint32
+			zb0004, bts, err = msgp.ReadArrayHeaderBytes(bts)
 			if err != nil {
 				err = msgp.WrapError(err, "Hooks")
 				return
 			}
-			if cap(z.Hooks) >= int(zb0005) {
-				z.Hooks = (z.Hooks)[:zb0005]
+			if cap(z.Hooks) >= int(zb0004) {
+				z.Hooks = (z.Hooks)[:zb0004]
 			} else {
-				z.Hooks = make([]Hook, zb0005)
+				z.Hooks = make([]Hook, zb0004)
 			}
 			for za0002 := range z.Hooks {
-				var zb0006 uint32
-				zb0006, bts, err = msgp.ReadMapHeaderBytes(bts)
+				var zb0005 uint32
+				zb0005, bts, err = msgp.ReadMapHeaderBytes(bts)
 				if err != nil {
 					err = msgp.WrapError(err, "Hooks", za0002)
 					return
 				}
-				for zb0006 > 0 {
-					zb0006--
+				for zb0005 > 0 {
+					zb0005--
 					field, bts, err = msgp.ReadMapKeyZC(bts)
 					if err != nil {
 						err = msgp.WrapError(err, "Hooks", za0002)
@@ -5109,16 +6020,16 @@ func (z *TenantConfiguration) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				}
 			}
 		case "deployment_routes":
-			var zb0007 uint32
-			zb0007, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			var zb0006 uint32
+			zb0006, bts, err = msgp.ReadArrayHeaderBytes(bts)
 			if err != nil {
 				err = msgp.WrapError(err, "DeploymentRoutes")
 				return
 			}
-			if cap(z.DeploymentRoutes) >= int(zb0007) {
-				z.DeploymentRoutes = (z.DeploymentRoutes)[:zb0007]
+			if cap(z.DeploymentRoutes) >= int(zb0006) {
+				z.DeploymentRoutes = (z.DeploymentRoutes)[:zb0006]
 			} else {
-				z.DeploymentRoutes = make([]DeploymentRoute, zb0007)
+				z.DeploymentRoutes = make([]DeploymentRoute, zb0006)
 			}
 			for za0003 := range z.DeploymentRoutes {
 				bts, err = z.DeploymentRoutes[za0003].UnmarshalMsg(bts)
@@ -5145,7 +6056,7 @@ func (z *TenantConfiguration) Msgsize() (s int) {
 	if z.Hook == nil {
 		s += msgp.NilSize
 	} else {
-		s += 1 + 25 + msgp.IntSize + 31 + msgp.IntSize
+		s += z.Hook.Msgsize()
 	}
 	s += 16
 	if z.DatabaseConfig == nil {
@@ -114,6 +114,21 @@ func NewTxContextWithContext(ctx context.Context, tConfig config.TenantConfigura
 	return newDBContext(ctx, tConfig)
 }
 
+// NewNonTxContextWithContext creates a new context.DB from context, which
+// executes statements outside of the ongoing transaction, so that their
+// effects persist even if the transaction is rolled back.
+func NewNonTxContextWithContext(ctx context.Context, tConfig config.TenantConfiguration) Context {
+	return &nonTxDBContext{dbContext: newDBContext(ctx, tConfig)}
+}
+
+type nonTxDBContext struct {
+	*dbContext
+}
+
+func (d *nonTxDBContext) DB() (ExtContext, error) {
+	return d.lazydb()
+}
+
 func (d *dbContext) DB() (ExtContext, error) {
 	if d.tx() != nil {
 		return d.tx(), nil
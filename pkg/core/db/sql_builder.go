@@ -123,6 +123,11 @@ func (b SelectBuilder) ToSql() (string, []interface{}, error) {
 	return b.builder.ToSql()
 }
 
+func (b SelectBuilder) Column(column interface{}, args ...interface{}) SelectBuilder {
+	b.builder = b.builder.Column(column, args...)
+	return b
+}
+
 func (b SelectBuilder) From(from string, alias ...string) SelectBuilder {
 	if len(alias) > 0 {
 		from = fmt.Sprintf("%s AS %s", from, alias[0])
@@ -162,3 +167,8 @@ func (b SelectBuilder) Limit(limit uint64) SelectBuilder {
 	b.builder = b.builder.Limit(limit)
 	return b
 }
+
+func (b SelectBuilder) Offset(offset uint64) SelectBuilder {
+	b.builder = b.builder.Offset(offset)
+	return b
+}
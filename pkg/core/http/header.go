@@ -33,6 +33,8 @@ const (
 
 	// Outbound webhook request
 	HeaderRequestBodySignature = "x-skygear-body-signature"
+	HeaderWebhookSignature     = "x-skygear-webhook-signature"
+	HeaderWebhookEventID       = "x-skygear-event-id"
 )
 
 func GetHost(req *gohttp.Request) (host string) {
@@ -100,6 +102,11 @@ func parseAuthorizationHeader(r *gohttp.Request) (token string) {
 	return authorization[1]
 }
 
+// GetBearerToken extracts the bearer token in Authorization header of r.
+func GetBearerToken(r *gohttp.Request) string {
+	return parseAuthorizationHeader(r)
+}
+
 // GetSessionIdentifier extracts session identifier from r.
 // The session identifier is either in cookie or Authorization header.
 // Cookie has higher precedence.
@@ -0,0 +1,105 @@
+package httpsigning
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	coreHttp "github.com/skygeario/skygear-server/pkg/core/http"
+)
+
+const (
+	// WebhookSignatureVersion is the version of webhook signature scheme.
+	// The signature of version "v1" is HEX(HMAC-SHA256(key, timestamp + "." + body)).
+	WebhookSignatureVersion = "v1"
+)
+
+// SignWebhook computes the value of webhook signature header.
+// The timestamp and body are signed with each of the keys, so that receivers
+// can verify the signature with any one of them.
+//
+// The header value is in format "t=<timestamp>,v1=<signature>,v1=<signature>".
+func SignWebhook(keys [][]byte, body []byte, t time.Time) string {
+	timestamp := strconv.FormatInt(t.Unix(), 10)
+	parts := []string{"t=" + timestamp}
+	for _, key := range keys {
+		sig := Hex(HMACSHA256(key, webhookStringToSign(timestamp, body)))
+		parts = append(parts, WebhookSignatureVersion+"="+sig)
+	}
+	return strings.Join(parts, ",")
+}
+
+// VerifyWebhook verifies the webhook signature header value against body with key.
+// Signatures older than tolerance are rejected to prevent replay.
+func VerifyWebhook(key []byte, header string, body []byte, now time.Time, tolerance time.Duration) error {
+	var timestamp string
+	var sigs [][]byte
+	for _, part := range strings.Split(header, ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "t":
+			timestamp = kv[1]
+		case WebhookSignatureVersion:
+			sig, err := hex.DecodeString(kv[1])
+			if err != nil {
+				continue
+			}
+			sigs = append(sigs, sig)
+		}
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return InvalidSignature.New("invalid webhook signature timestamp")
+	}
+	if len(sigs) == 0 {
+		return InvalidSignature.Errorf("no webhook signature of version %s", WebhookSignatureVersion)
+	}
+
+	expectedSig := HMACSHA256(key, webhookStringToSign(timestamp, body))
+	matched := false
+	for _, sig := range sigs {
+		if hmac.Equal(sig, expectedSig) {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return InvalidSignature.New("invalid signature")
+	}
+
+	t := time.Unix(unix, 0)
+	if now.Sub(t) > tolerance || t.Sub(now) > tolerance {
+		return ErrExpiredSignature
+	}
+
+	return nil
+}
+
+// VerifyWebhookRequest verifies the webhook signature of r with key.
+// The body of r is restored after verification.
+func VerifyWebhookRequest(key []byte, r *http.Request, now time.Time, tolerance time.Duration) error {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	return VerifyWebhook(key, r.Header.Get(coreHttp.HeaderWebhookSignature), body, now, tolerance)
+}
+
+func webhookStringToSign(timestamp string, body []byte) []byte {
+	buf := &bytes.Buffer{}
+	buf.WriteString(timestamp)
+	buf.WriteRune('.')
+	buf.Write(body)
+	return buf.Bytes()
+}
@@ -0,0 +1,60 @@
+package httpsigning
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	coreHttp "github.com/skygeario/skygear-server/pkg/core/http"
+)
+
+func TestWebhookSigning(t *testing.T) {
+	Convey("Webhook Signing", t, func() {
+		signTime := time.Date(2019, 10, 11, 3, 4, 5, 0, time.UTC)
+		body := []byte(`{"id":"0000000000000001"}`)
+		oldKey := []byte("old-secret")
+		newKey := []byte("new-secret")
+
+		Convey("Sign and Verify", func() {
+			header := SignWebhook([][]byte{oldKey, newKey}, body, signTime)
+			So(header, ShouldStartWith, "t=1570763045,v1=")
+
+			So(VerifyWebhook(oldKey, header, body, signTime, 5*time.Minute), ShouldBeNil)
+			So(VerifyWebhook(newKey, header, body, signTime, 5*time.Minute), ShouldBeNil)
+		})
+
+		Convey("Invalid signature", func() {
+			header := SignWebhook([][]byte{oldKey}, body, signTime)
+
+			err := VerifyWebhook(newKey, header, body, signTime, 5*time.Minute)
+			So(err, ShouldBeError, "invalid signature")
+
+			err = VerifyWebhook(oldKey, header, []byte(`{"id":"0000000000000002"}`), signTime, 5*time.Minute)
+			So(err, ShouldBeError, "invalid signature")
+
+			err = VerifyWebhook(oldKey, "v1=abcd", body, signTime, 5*time.Minute)
+			So(err, ShouldBeError, "invalid webhook signature timestamp")
+		})
+
+		Convey("Replayed signature", func() {
+			header := SignWebhook([][]byte{oldKey}, body, signTime)
+
+			err := VerifyWebhook(oldKey, header, body, signTime.Add(6*time.Minute), 5*time.Minute)
+			So(err, ShouldBeError, "expired signature")
+		})
+
+		Convey("Verify request", func() {
+			r, _ := http.NewRequest("POST", "https://example.com/", bytes.NewReader(body))
+			r.Header.Set(coreHttp.HeaderWebhookSignature, SignWebhook([][]byte{newKey}, body, signTime))
+
+			So(VerifyWebhookRequest(newKey, r, signTime, 5*time.Minute), ShouldBeNil)
+
+			buf := &bytes.Buffer{}
+			_, _ = buf.ReadFrom(r.Body)
+			So(buf.Bytes(), ShouldResemble, body)
+		})
+	})
+}
@@ -0,0 +1,70 @@
+package http
+
+import (
+	"errors"
+	"net"
+	"syscall"
+	"time"
+)
+
+var ErrPrivateNetworkAddress = errors.New("http: connecting to private network address is not allowed")
+
+var privateNetworks []*net.IPNet
+
+func init() {
+	for _, cidr := range []string{
+		"0.0.0.0/8",
+		"10.0.0.0/8",
+		"100.64.0.0/10",
+		"127.0.0.0/8",
+		"169.254.0.0/16",
+		"172.16.0.0/12",
+		"192.0.0.0/24",
+		"192.168.0.0/16",
+		"198.18.0.0/15",
+		"::/128",
+		"::1/128",
+		"fc00::/7",
+		"fe80::/10",
+	} {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		privateNetworks = append(privateNetworks, network)
+	}
+}
+
+// IsPublicIP returns whether the IP address is routable on the public
+// internet, i.e. not loopback, link-local, private or otherwise reserved.
+func IsPublicIP(ip net.IP) bool {
+	if ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	for _, network := range privateNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// NewPublicNetworkDialer returns a dialer which refuses to connect to
+// non-public IP addresses. Addresses are checked after name resolution, so
+// that host names resolving to private addresses are refused as well.
+func NewPublicNetworkDialer(timeout time.Duration) *net.Dialer {
+	return &net.Dialer{
+		Timeout: timeout,
+		Control: func(network string, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || !IsPublicIP(ip) {
+				return ErrPrivateNetworkAddress
+			}
+			return nil
+		},
+	}
+}
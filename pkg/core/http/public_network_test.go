@@ -0,0 +1,36 @@
+package http
+
+import (
+	"net"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	Convey("IsPublicIP", t, func() {
+		for _, ip := range []string{
+			"127.0.0.1",
+			"10.1.2.3",
+			"172.16.0.1",
+			"192.168.1.1",
+			"169.254.169.254",
+			"0.0.0.0",
+			"::1",
+			"fd00::1",
+			"fe80::1",
+			"::ffff:127.0.0.1",
+			"224.0.0.1",
+		} {
+			So(IsPublicIP(net.ParseIP(ip)), ShouldBeFalse)
+		}
+
+		for _, ip := range []string{
+			"8.8.8.8",
+			"172.32.0.1",
+			"2001:4860:4860::8888",
+		} {
+			So(IsPublicIP(net.ParseIP(ip)), ShouldBeTrue)
+		}
+	})
+}
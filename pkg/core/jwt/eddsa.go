@@ -0,0 +1,52 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"errors"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// ErrEdDSAVerification occurs when the EdDSA signature is invalid.
+var ErrEdDSAVerification = errors.New("eddsa: verification error")
+
+// SigningMethodEdDSA implements EdDSA signing method with Ed25519 keys,
+// which is not provided by jwt-go.
+type SigningMethodEdDSA struct{}
+
+func init() {
+	jwt.RegisterSigningMethod(AlgorithmEdDSA, func() jwt.SigningMethod {
+		return SigningMethodEdDSA{}
+	})
+}
+
+func (m SigningMethodEdDSA) Alg() string {
+	return AlgorithmEdDSA
+}
+
+func (m SigningMethodEdDSA) Verify(signingString, signature string, key interface{}) error {
+	pubKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return jwt.ErrInvalidKeyType
+	}
+
+	sig, err := jwt.DecodeSegment(signature)
+	if err != nil {
+		return err
+	}
+
+	if !ed25519.Verify(pubKey, []byte(signingString), sig) {
+		return ErrEdDSAVerification
+	}
+	return nil
+}
+
+func (m SigningMethodEdDSA) Sign(signingString string, key interface{}) (string, error) {
+	privKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return "", jwt.ErrInvalidKeyType
+	}
+
+	sig := ed25519.Sign(privKey, []byte(signingString))
+	return jwt.EncodeSegment(sig), nil
+}
@@ -0,0 +1,214 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmES256 = "ES256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// SupportedSigningAlgorithms are the algorithms of keys that can be parsed
+// and generated.
+var SupportedSigningAlgorithms = []string{
+	AlgorithmRS256,
+	AlgorithmES256,
+	AlgorithmEdDSA,
+}
+
+// ErrNotPEMEncoded occurs when the key is not PEM encoded.
+var ErrNotPEMEncoded = errors.New("key is not pem encoded")
+
+// ErrUnsupportedKey occurs when the key type is not supported.
+var ErrUnsupportedKey = errors.New("unsupported key type")
+
+// ParsePrivateKeyFromPEM parses a PEM encoded RSA, P-256 ECDSA or Ed25519
+// private key.
+func ParsePrivateKeyFromPEM(content []byte) (interface{}, error) {
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, ErrNotPEMEncoded
+	}
+
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := SigningAlgorithmOf(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// ParsePublicKeyFromPEM parses a PEM encoded RSA, P-256 ECDSA or Ed25519
+// public key.
+func ParsePublicKeyFromPEM(content []byte) (interface{}, error) {
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, ErrNotPEMEncoded
+	}
+
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		var cert *x509.Certificate
+		cert, err = x509.ParseCertificate(block.Bytes)
+		if err == nil {
+			key = cert.PublicKey
+		}
+	default:
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := SigningAlgorithmOf(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// SigningAlgorithmOf returns the JWS algorithm used with the key.
+func SigningAlgorithmOf(key interface{}) (string, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey, *rsa.PublicKey:
+		return AlgorithmRS256, nil
+	case *ecdsa.PrivateKey:
+		return ecdsaAlgorithm(k.Curve)
+	case *ecdsa.PublicKey:
+		return ecdsaAlgorithm(k.Curve)
+	case ed25519.PrivateKey, ed25519.PublicKey:
+		return AlgorithmEdDSA, nil
+	default:
+		return "", ErrUnsupportedKey
+	}
+}
+
+func ecdsaAlgorithm(curve elliptic.Curve) (string, error) {
+	if curve != elliptic.P256() {
+		return "", ErrUnsupportedKey
+	}
+	return AlgorithmES256, nil
+}
+
+// SigningMethodOf returns the signing method used with the key.
+func SigningMethodOf(key interface{}) (jwt.SigningMethod, error) {
+	alg, err := SigningAlgorithmOf(key)
+	if err != nil {
+		return nil, err
+	}
+	return jwt.GetSigningMethod(alg), nil
+}
+
+// GenerateKeyPair generates a new key pair of the algorithm, encoded in PEM.
+// Private keys are encoded in PKCS #8, and public keys in PKIX.
+func GenerateKeyPair(alg string) (privateKeyPEM string, publicKeyPEM string, err error) {
+	var privKey interface{}
+	var pubKey interface{}
+	switch alg {
+	case AlgorithmRS256:
+		var k *rsa.PrivateKey
+		k, err = rsa.GenerateKey(rand.Reader, 2048)
+		privKey, pubKey = k, &k.PublicKey
+	case AlgorithmES256:
+		var k *ecdsa.PrivateKey
+		k, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		privKey, pubKey = k, &k.PublicKey
+	case AlgorithmEdDSA:
+		var k ed25519.PrivateKey
+		pubKey, k, err = ed25519.GenerateKey(rand.Reader)
+		privKey = k
+	default:
+		err = fmt.Errorf("unsupported algorithm: %s", alg)
+	}
+	if err != nil {
+		return
+	}
+
+	privBytes, err := x509.MarshalPKCS8PrivateKey(privKey)
+	if err != nil {
+		return
+	}
+	pubBytes, err := x509.MarshalPKIXPublicKey(pubKey)
+	if err != nil {
+		return
+	}
+
+	privateKeyPEM = string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privBytes}))
+	publicKeyPEM = string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubBytes}))
+	return
+}
+
+// PublicJWK returns the JWK representation of the public key.
+func PublicJWK(kid string, key interface{}) (map[string]interface{}, error) {
+	alg, err := SigningAlgorithmOf(key)
+	if err != nil {
+		return nil, err
+	}
+
+	jwk := map[string]interface{}{
+		"kid": kid,
+		"use": "sig",
+		"alg": alg,
+	}
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		jwk["kty"] = "RSA"
+		jwk["n"] = encodeBase64(k.N.Bytes())
+		jwk["e"] = encodeBase64(big.NewInt(int64(k.E)).Bytes())
+	case *ecdsa.PublicKey:
+		size := (k.Curve.Params().BitSize + 7) / 8
+		jwk["kty"] = "EC"
+		jwk["crv"] = k.Curve.Params().Name
+		jwk["x"] = encodeBase64(padBytes(k.X.Bytes(), size))
+		jwk["y"] = encodeBase64(padBytes(k.Y.Bytes(), size))
+	case ed25519.PublicKey:
+		jwk["kty"] = "OKP"
+		jwk["crv"] = "Ed25519"
+		jwk["x"] = encodeBase64(k)
+	default:
+		return nil, ErrUnsupportedKey
+	}
+	return jwk, nil
+}
+
+func encodeBase64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func padBytes(b []byte, size int) []byte {
+	if len(b) >= size {
+		return b
+	}
+	padded := make([]byte, size)
+	copy(padded[size-len(b):], b)
+	return padded
+}
@@ -0,0 +1,49 @@
+package jwt
+
+import (
+	"testing"
+
+	"github.com/dgrijalva/jwt-go"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSigningKey(t *testing.T) {
+	Convey("Signing keys", t, func() {
+		for _, c := range []struct {
+			Alg string
+			Kty string
+		}{
+			{AlgorithmRS256, "RSA"},
+			{AlgorithmES256, "EC"},
+			{AlgorithmEdDSA, "OKP"},
+		} {
+			privPEM, pubPEM, err := GenerateKeyPair(c.Alg)
+			So(err, ShouldBeNil)
+
+			privKey, err := ParsePrivateKeyFromPEM([]byte(privPEM))
+			So(err, ShouldBeNil)
+			pubKey, err := ParsePublicKeyFromPEM([]byte(pubPEM))
+			So(err, ShouldBeNil)
+
+			method, err := SigningMethodOf(privKey)
+			So(err, ShouldBeNil)
+			So(method.Alg(), ShouldEqual, c.Alg)
+
+			token, err := jwt.NewWithClaims(method, jwt.MapClaims{"sub": "user"}).SignedString(privKey)
+			So(err, ShouldBeNil)
+			_, err = jwt.Parse(token, func(*jwt.Token) (interface{}, error) { return pubKey, nil })
+			So(err, ShouldBeNil)
+
+			jwk, err := PublicJWK("kid", pubKey)
+			So(err, ShouldBeNil)
+			So(jwk["kty"], ShouldEqual, c.Kty)
+			So(jwk["alg"], ShouldEqual, c.Alg)
+			So(jwk["kid"], ShouldEqual, "kid")
+		}
+	})
+
+	Convey("should reject unsupported algorithm", t, func() {
+		_, _, err := GenerateKeyPair("HS256")
+		So(err, ShouldBeError, "unsupported algorithm: HS256")
+	})
+}
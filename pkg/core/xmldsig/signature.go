@@ -0,0 +1,90 @@
+package xmldsig
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/russellhaering/goxmldsig/etreeutils"
+
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+)
+
+// Namespace is the namespace of XML Signature elements.
+const Namespace = dsig.Namespace
+
+// Signature algorithm identifiers, which are also used by HTTP-Redirect
+// binding of SAML.
+const (
+	AlgorithmRSASHA256 = dsig.RSASHA256SignatureMethod
+	AlgorithmRSASHA512 = dsig.RSASHA512SignatureMethod
+)
+
+var ErrNotSigned = errors.New("xmldsig: element is not signed")
+var ErrInvalidSignature = errors.New("xmldsig: invalid signature")
+
+// Verify verifies the enveloped signature of e with the certificates, and
+// returns the signed content of e. The signature must reference e itself by
+// its ID attribute.
+//
+// The returned element is a canonicalized copy of e without the signature.
+// Callers must only consume the returned element, since anything else in
+// the document, including e, is not covered by the signature.
+func Verify(e *etree.Element, certs []*x509.Certificate) (*etree.Element, error) {
+	// Namespaces declared by ancestors are in scope of the signed content,
+	// so they are declared on the detached element before verification.
+	ctx, err := etreeutils.NSBuildParentContext(e)
+	if err != nil {
+		return nil, errors.Newf("%w: %v", ErrInvalidSignature, err)
+	}
+	detached, err := etreeutils.NSDetatch(ctx, e)
+	if err != nil {
+		return nil, errors.Newf("%w: %v", ErrInvalidSignature, err)
+	}
+
+	validationCtx := dsig.NewDefaultValidationContext(&dsig.MemoryX509CertificateStore{
+		Roots: certs,
+	})
+	signed, err := validationCtx.Validate(detached)
+	if err == dsig.ErrMissingSignature {
+		return nil, ErrNotSigned
+	} else if err != nil {
+		return nil, errors.Newf("%w: %v", ErrInvalidSignature, err)
+	}
+	return signed, nil
+}
+
+// Sign signs e with enveloped signature using RSA-SHA256 and exclusive
+// canonicalization. The signature references e by its ID attribute, and is
+// inserted as child of e after the child element after, or as the first
+// child if after is nil.
+func Sign(e *etree.Element, key *rsa.PrivateKey, cert *x509.Certificate, after *etree.Element) error {
+	signingCtx, err := dsig.NewSigningContext(key, [][]byte{cert.Raw})
+	if err != nil {
+		return err
+	}
+	signingCtx.Canonicalizer = dsig.MakeC14N10ExclusiveCanonicalizerWithPrefixList("")
+
+	// The signed content is canonicalized in place, so a detached copy with
+	// namespaces in scope is signed instead.
+	ctx, err := etreeutils.NSBuildParentContext(e)
+	if err != nil {
+		return err
+	}
+	detached, err := etreeutils.NSDetatch(ctx, e)
+	if err != nil {
+		return err
+	}
+	signature, err := signingCtx.ConstructSignature(detached, true)
+	if err != nil {
+		return err
+	}
+
+	index := 0
+	if after != nil && after.Parent() == e {
+		index = after.Index() + 1
+	}
+	e.InsertChildAt(index, signature)
+	return nil
+}
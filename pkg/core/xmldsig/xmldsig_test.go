@@ -0,0 +1,115 @@
+package xmldsig
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/skygeario/skygear-server/pkg/core/errors"
+)
+
+func newTestCertificate() (*rsa.PrivateKey, *x509.Certificate) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	So(err, ShouldBeNil)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	So(err, ShouldBeNil)
+	cert, err := x509.ParseCertificate(der)
+	So(err, ShouldBeNil)
+	return key, cert
+}
+
+func TestParse(t *testing.T) {
+	Convey("Parse", t, func() {
+		Convey("should resolve namespaces", func() {
+			root, err := Parse([]byte(`<p:Response xmlns:p="urn:p" xmlns:a="urn:a"><a:Issuer>issuer</a:Issuer><Data xmlns="urn:d"/></p:Response>`))
+			So(err, ShouldBeNil)
+			So(Is(root, "urn:p", "Response"), ShouldBeTrue)
+			So(FindChild(root, "urn:a", "Issuer"), ShouldNotBeNil)
+			So(FindChild(root, "urn:p", "Issuer"), ShouldBeNil)
+			So(FindChildren(root, "urn:d", "Data"), ShouldHaveLength, 1)
+		})
+
+		Convey("should join text separated by comments", func() {
+			root, err := Parse([]byte(`<a>user@example.com<!-- comment -->.evil.com</a>`))
+			So(err, ShouldBeNil)
+			So(Text(root), ShouldEqual, "user@example.com.evil.com")
+		})
+
+		Convey("should reject document type declaration", func() {
+			_, err := Parse([]byte(`<!DOCTYPE a [<!ENTITY e "e">]><a>&e;</a>`))
+			So(err, ShouldNotBeNil)
+			_, err = Parse([]byte(`<!DOCTYPE a><a></a>`))
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+func TestSignature(t *testing.T) {
+	Convey("Sign and Verify", t, func() {
+		key, cert := newTestCertificate()
+		root, err := Parse([]byte(`<p:Response xmlns:p="urn:p" ID="id-1"><p:Issuer>issuer</p:Issuer><p:Assertion ID="id-2"><p:Issuer>issuer</p:Issuer><p:Data>data</p:Data></p:Assertion></p:Response>`))
+		So(err, ShouldBeNil)
+		assertion := FindChild(root, "urn:p", "Assertion")
+		So(Sign(assertion, key, cert, FindChild(assertion, "urn:p", "Issuer")), ShouldBeNil)
+		So(Sign(root, key, cert, FindChild(root, "urn:p", "Issuer")), ShouldBeNil)
+		So(Is(root.ChildElements()[1], Namespace, "Signature"), ShouldBeTrue)
+		So(Is(assertion.ChildElements()[1], Namespace, "Signature"), ShouldBeTrue)
+
+		data, err := Serialize(root)
+		So(err, ShouldBeNil)
+		signed, err := Parse(data)
+		So(err, ShouldBeNil)
+
+		Convey("should verify signed element", func() {
+			verified, err := Verify(signed, []*x509.Certificate{cert})
+			So(err, ShouldBeNil)
+			So(FindChild(verified, Namespace, "Signature"), ShouldBeNil)
+			So(FindChild(verified, "urn:p", "Assertion"), ShouldNotBeNil)
+		})
+
+		Convey("should verify signed child element", func() {
+			verified, err := Verify(FindChild(signed, "urn:p", "Assertion"), []*x509.Certificate{cert})
+			So(err, ShouldBeNil)
+			So(Is(verified, "urn:p", "Assertion"), ShouldBeTrue)
+			So(Text(FindChild(verified, "urn:p", "Data")), ShouldEqual, "data")
+		})
+
+		Convey("should reject other certificates", func() {
+			_, otherCert := newTestCertificate()
+			_, err := Verify(signed, []*x509.Certificate{otherCert})
+			So(errors.Is(err, ErrInvalidSignature), ShouldBeTrue)
+		})
+
+		Convey("should reject modified element", func() {
+			data := FindChild(FindChild(signed, "urn:p", "Assertion"), "urn:p", "Data")
+			data.SetText("modified")
+			_, err := Verify(signed, []*x509.Certificate{cert})
+			So(errors.Is(err, ErrInvalidSignature), ShouldBeTrue)
+		})
+
+		Convey("should reject signature of other element", func() {
+			signed.SelectAttr("ID").Value = "id-3"
+			_, err := Verify(signed, []*x509.Certificate{cert})
+			So(err, ShouldEqual, ErrNotSigned)
+		})
+
+		Convey("should reject unsigned element", func() {
+			unsigned, err := Parse([]byte(`<p:Response xmlns:p="urn:p" ID="id-1"></p:Response>`))
+			So(err, ShouldBeNil)
+			_, err = Verify(unsigned, []*x509.Certificate{cert})
+			So(err, ShouldEqual, ErrNotSigned)
+		})
+	})
+}